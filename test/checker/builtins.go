@@ -0,0 +1,200 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds every built-in checker's dial/HTTP/TLS probe
+// unless a Config.Params["timeout_ms"] override says otherwise.
+const defaultTimeout = 5 * time.Second
+
+func timeoutFrom(params map[string]interface{}) time.Duration {
+	switch ms := params["timeout_ms"].(type) {
+	case float64:
+		if ms > 0 {
+			return time.Duration(ms) * time.Millisecond
+		}
+	case int:
+		if ms > 0 {
+			return time.Duration(ms) * time.Millisecond
+		}
+	}
+	return defaultTimeout
+}
+
+func stringOutput(outputs map[string]interface{}, key string) (string, bool) {
+	value, ok := outputs[key].(string)
+	return value, ok && value != ""
+}
+
+// tcpChecker is a generic Checker that dials outputKey's value as a
+// "host:port" TCP address.
+type tcpChecker struct {
+	name      string
+	outputKey string
+	params    map[string]interface{}
+}
+
+func (c *tcpChecker) Name() string { return c.name }
+
+func (c *tcpChecker) Applies(outputs map[string]interface{}) bool {
+	_, ok := stringOutput(outputs, c.outputKey)
+	return ok
+}
+
+func (c *tcpChecker) Configure(params map[string]interface{}) { c.params = params }
+
+func (c *tcpChecker) Run(ctx context.Context, outputs map[string]interface{}) CheckResult {
+	endpoint, _ := stringOutput(outputs, c.outputKey)
+	dialer := net.Dialer{Timeout: timeoutFrom(c.params)}
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return CheckResult{Checker: c.name, Status: StatusFailed, Message: fmt.Sprintf("failed to dial %s", endpoint), Err: err}
+	}
+	_ = conn.Close()
+	return CheckResult{Checker: c.name, Status: StatusPassed, Message: fmt.Sprintf("dialed %s", endpoint)}
+}
+
+// httpChecker is a generic Checker that issues a GET against outputKey's
+// value and treats any non-5xx response as healthy.
+type httpChecker struct {
+	name      string
+	outputKey string
+	params    map[string]interface{}
+}
+
+func (c *httpChecker) Name() string { return c.name }
+
+func (c *httpChecker) Applies(outputs map[string]interface{}) bool {
+	_, ok := stringOutput(outputs, c.outputKey)
+	return ok
+}
+
+func (c *httpChecker) Configure(params map[string]interface{}) { c.params = params }
+
+func (c *httpChecker) Run(ctx context.Context, outputs map[string]interface{}) CheckResult {
+	endpoint, _ := stringOutput(outputs, c.outputKey)
+	client := http.Client{Timeout: timeoutFrom(c.params)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return CheckResult{Checker: c.name, Status: StatusFailed, Message: fmt.Sprintf("invalid endpoint %s", endpoint), Err: err}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return CheckResult{Checker: c.name, Status: StatusFailed, Message: fmt.Sprintf("failed to reach %s", endpoint), Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return CheckResult{Checker: c.name, Status: StatusFailed, Message: fmt.Sprintf("%s returned %d", endpoint, resp.StatusCode)}
+	}
+	return CheckResult{Checker: c.name, Status: StatusPassed, Message: fmt.Sprintf("%s returned %d", endpoint, resp.StatusCode)}
+}
+
+// tlsChecker is a generic Checker that performs a TLS handshake against
+// outputKey's value (an https:// URL) and fails if the leaf certificate
+// has already expired.
+type tlsChecker struct {
+	name      string
+	outputKey string
+	params    map[string]interface{}
+}
+
+func (c *tlsChecker) Name() string { return c.name }
+
+func (c *tlsChecker) Applies(outputs map[string]interface{}) bool {
+	value, ok := stringOutput(outputs, c.outputKey)
+	return ok && strings.HasPrefix(value, "https://")
+}
+
+func (c *tlsChecker) Configure(params map[string]interface{}) { c.params = params }
+
+func (c *tlsChecker) Run(ctx context.Context, outputs map[string]interface{}) CheckResult {
+	endpoint, _ := stringOutput(outputs, c.outputKey)
+	host := strings.TrimPrefix(endpoint, "https://")
+	if idx := strings.IndexByte(host, '/'); idx >= 0 {
+		host = host[:idx]
+	}
+	if !strings.Contains(host, ":") {
+		host += ":443"
+	}
+
+	dialer := net.Dialer{Timeout: timeoutFrom(c.params)}
+	conn, err := tls.DialWithDialer(&dialer, "tcp", host, &tls.Config{})
+	if err != nil {
+		return CheckResult{Checker: c.name, Status: StatusFailed, Message: fmt.Sprintf("TLS handshake with %s failed", host), Err: err}
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) > 0 && time.Now().After(state.PeerCertificates[0].NotAfter) {
+		return CheckResult{
+			Checker: c.name,
+			Status:  StatusFailed,
+			Message: fmt.Sprintf("certificate for %s expired %s", host, state.PeerCertificates[0].NotAfter),
+		}
+	}
+	return CheckResult{Checker: c.name, Status: StatusPassed, Message: fmt.Sprintf("TLS handshake with %s succeeded", host)}
+}
+
+// placeholderChecker always Applies and reports StatusSkipped: it
+// documents that this package has no generic, credential-free way to
+// verify the thing it names, and that live verification needs a
+// caller-supplied Checker wired in via RegisterChecker for their own
+// cloud/network stack.
+type placeholderChecker struct {
+	name   string
+	reason string
+}
+
+func (c *placeholderChecker) Name() string { return c.name }
+
+func (c *placeholderChecker) Applies(map[string]interface{}) bool { return true }
+
+func (c *placeholderChecker) Run(context.Context, map[string]interface{}) CheckResult {
+	return CheckResult{Checker: c.name, Status: StatusSkipped, Message: c.reason}
+}
+
+func init() {
+	RegisterChecker(CategoryHealth, &tcpChecker{name: "database-health", outputKey: "database_endpoint"})
+	RegisterChecker(CategoryHealth, &tcpChecker{name: "cache-health", outputKey: "cache_endpoint"})
+	RegisterChecker(CategoryHealth, &httpChecker{name: "load-balancer-health", outputKey: "load_balancer_endpoint"})
+	RegisterChecker(CategoryHealth, &httpChecker{name: "container-service-health", outputKey: "container_service_endpoint"})
+
+	RegisterChecker(CategoryConnectivity, &httpChecker{name: "http-connectivity", outputKey: "public_endpoint"})
+	RegisterChecker(CategoryConnectivity, &tcpChecker{name: "internal-connectivity", outputKey: "private_endpoint"})
+
+	RegisterChecker(CategorySecurity, &tlsChecker{name: "ssl", outputKey: "public_endpoint"})
+	RegisterChecker(CategorySecurity, &placeholderChecker{
+		name:   "network-security",
+		reason: "no generic network-ACL implementation; register a custom Checker for your cloud's security-group/firewall API",
+	})
+	RegisterChecker(CategorySecurity, &placeholderChecker{
+		name:   "access-control",
+		reason: "no generic IAM implementation; register a custom Checker for your cloud's policy-simulation API",
+	})
+	RegisterChecker(CategorySecurity, &placeholderChecker{
+		name:   "encryption",
+		reason: "no generic encryption-at-rest implementation; register a custom Checker for your cloud's KMS/disk-encryption API",
+	})
+
+	RegisterChecker(CategoryBackup, &placeholderChecker{
+		name:   "database-backup",
+		reason: "no generic backup-verification implementation; register a custom Checker for your database's backup API",
+	})
+	RegisterChecker(CategoryBackup, &placeholderChecker{
+		name:   "storage-backup",
+		reason: "no generic backup-verification implementation; register a custom Checker for your storage provider's backup API",
+	})
+
+	RegisterChecker(CategoryMonitoring, &httpChecker{name: "monitoring-endpoint", outputKey: "monitoring_endpoint"})
+	RegisterChecker(CategoryMonitoring, &httpChecker{name: "alerting-endpoint", outputKey: "alerting_endpoint"})
+}
@@ -0,0 +1,117 @@
+// Package checker defines the pluggable, in-process post-deploy Checker
+// registry TestSuite's health/connectivity/security/backup/monitoring test
+// stages (tests/terraform/main_test.go) run instead of their former
+// hard-coded `if outputs["x"]` chains. Modeled on Terraform 0.14's
+// internal provisioners: a Checker runs in-process, with no
+// subprocess/plugin overhead, yet stays independently unit-testable
+// because Run only ever sees the outputs map, never the calling
+// TestSuite. A caller registers a Checker for infrastructure this package
+// knows nothing about (a Kafka cluster, an S3 bucket policy, ...) via
+// RegisterChecker, without patching the suite itself.
+package checker
+
+import (
+	"context"
+	"sync"
+)
+
+// Status is a CheckResult's outcome.
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// CheckResult is one Checker's verdict for a single RunAll pass.
+type CheckResult struct {
+	Checker string
+	Status  Status
+	Message string
+	Err     error
+}
+
+// Checker is one post-deploy check RunAll can drive. Applies lets a
+// Checker opt out of an outputs map that doesn't name the resource it
+// checks, so RunAll can offer every registered Checker a given category's
+// outputs unconditionally and let each decide relevance for itself.
+type Checker interface {
+	Name() string
+	Applies(outputs map[string]interface{}) bool
+	Run(ctx context.Context, outputs map[string]interface{}) CheckResult
+}
+
+// Category groups checkers under the TestSuite stage that runs them, so
+// registering a Checker under CategorySecurity, say, is enough to have it
+// picked up by TestSecurity without the suite needing to know it exists.
+type Category string
+
+const (
+	CategoryHealth       Category = "health"
+	CategoryConnectivity Category = "connectivity"
+	CategorySecurity     Category = "security"
+	CategoryBackup       Category = "backup"
+	CategoryMonitoring   Category = "monitoring"
+)
+
+// Config enables/disables and parameterizes one registered Checker by
+// name -- the shape TestConfig's `checkers:` YAML block decodes into,
+// keyed by Checker.Name(). A name absent from the block runs with
+// Disabled false (enabled) and a nil Params, so registering a Checker is
+// enough to activate it without also touching test-config.yaml.
+type Config struct {
+	Disabled bool                   `yaml:"disabled"`
+	Params   map[string]interface{} `yaml:"params"`
+}
+
+var (
+	mu       sync.Mutex
+	registry = map[Category][]Checker{}
+)
+
+// RegisterChecker adds c to category's registry. Built-in checkers
+// register themselves via this package's init(); callers register their
+// own the same way, typically from an init() in their own package.
+func RegisterChecker(category Category, c Checker) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[category] = append(registry[category], c)
+}
+
+// Registered returns every Checker registered under category, in
+// registration order.
+func Registered(category Category) []Checker {
+	mu.Lock()
+	defer mu.Unlock()
+	checkers := registry[category]
+	out := make([]Checker, len(checkers))
+	copy(out, checkers)
+	return out
+}
+
+// Configurable is implemented by a Checker that accepts per-run Params
+// from its Config. RunAll applies them before calling Run.
+type Configurable interface {
+	Configure(params map[string]interface{})
+}
+
+// RunAll runs every checker in checkers that Applies to outputs and isn't
+// Disabled in configs, in the order given.
+func RunAll(ctx context.Context, checkers []Checker, outputs map[string]interface{}, configs map[string]Config) []CheckResult {
+	var results []CheckResult
+	for _, c := range checkers {
+		cfg, ok := configs[c.Name()]
+		if ok && cfg.Disabled {
+			continue
+		}
+		if !c.Applies(outputs) {
+			continue
+		}
+		if configurable, ok := c.(Configurable); ok && len(cfg.Params) > 0 {
+			configurable.Configure(cfg.Params)
+		}
+		results = append(results, c.Run(ctx, outputs))
+	}
+	return results
+}
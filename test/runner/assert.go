@@ -0,0 +1,69 @@
+package runner
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+// Evaluate compares outputs[a.Output] against a's expectation, returning a
+// non-nil error describing the mismatch when it fails.
+func (a Assertion) Evaluate(outputs map[string]interface{}) error {
+	actual, ok := outputs[a.Output]
+	if !ok {
+		return errors.Errorf("output %q not present", a.Output)
+	}
+
+	switch a.Type {
+	case AssertRegex:
+		pattern, err := regexp.Compile(a.Regex)
+		if err != nil {
+			return errors.Wrapf(err, "invalid regex %q for output %q", a.Regex, a.Output)
+		}
+		actualStr := fmt.Sprintf("%v", actual)
+		if !pattern.MatchString(actualStr) {
+			return errors.Errorf("output %q value %q does not match regex %q", a.Output, actualStr, a.Regex)
+		}
+		return nil
+
+	case AssertRange:
+		value, ok := toFloat(actual)
+		if !ok {
+			return errors.Errorf("output %q value %v is not numeric", a.Output, actual)
+		}
+		if a.Min != nil && value < *a.Min {
+			return errors.Errorf("output %q value %v is below min %v", a.Output, value, *a.Min)
+		}
+		if a.Max != nil && value > *a.Max {
+			return errors.Errorf("output %q value %v is above max %v", a.Output, value, *a.Max)
+		}
+		return nil
+
+	case AssertEquals, "":
+		if fmt.Sprintf("%v", actual) != fmt.Sprintf("%v", a.Equals) {
+			return errors.Errorf("output %q value %v does not equal expected %v", a.Output, actual, a.Equals)
+		}
+		return nil
+
+	default:
+		return errors.Errorf("unknown assertion type %q for output %q", a.Type, a.Output)
+	}
+}
+
+// toFloat converts the numeric types Terraform/JSON outputs commonly
+// decode to (float64, int, int64) into a float64 for AssertRange
+// comparisons.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
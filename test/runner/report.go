@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+)
+
+// jsonResult is the shape WriteJSONReport writes each Result as -- plain
+// JSON tags of its own, since Result itself is also used as an in-memory
+// value by callers that have no need for a report-specific encoding.
+type jsonResult struct {
+	Scenario   string   `json:"scenario"`
+	Status     Status   `json:"status"`
+	DurationMS int64    `json:"duration_ms"`
+	Failures   []string `json:"failures,omitempty"`
+}
+
+// WriteJSONReport writes results to path as a JSON array, using the
+// repo's goccy/go-json encoder.
+func WriteJSONReport(path string, results []Result) error {
+	report := make([]jsonResult, 0, len(results))
+	for _, result := range results {
+		report = append(report, jsonResult{
+			Scenario:   result.Scenario,
+			Status:     result.Status,
+			DurationMS: result.Duration.Milliseconds(),
+			Failures:   result.Failures,
+		})
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JSON report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write JSON report to %s", path)
+	}
+	return nil
+}
+
+// junitTestSuites is the <testsuites> root element WriteJUnitReport emits.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes results as a single <testsuites><testsuite> of
+// suiteName, one <testcase> per scenario with a <failure> listing every
+// failed assertion or setup step -- the format CI systems already know how
+// to render from hashicorp's own `terraform test -junit-xml`.
+func WriteJUnitReport(path, suiteName string, results []Result) error {
+	suite := junitTestSuite{Name: suiteName, Tests: len(results)}
+
+	for _, result := range results {
+		testCase := junitTestCase{Name: result.Scenario, Time: result.Duration.Seconds()}
+		if result.Status == StatusFailed {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d assertion(s) failed", len(result.Failures)),
+				Body:    strings.Join(result.Failures, "\n"),
+			}
+		}
+		suite.Time += result.Duration.Seconds()
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	doc := junitTestSuites{Suites: []junitTestSuite{suite}}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal JUnit report")
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write JUnit report to %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iac"
+)
+
+// Status is a Scenario's outcome after Run executes it.
+type Status string
+
+const (
+	StatusPassed Status = "passed"
+	StatusFailed Status = "failed"
+)
+
+// Result is one Scenario's outcome: its Status, how long it took, and any
+// Failures -- one per Assertion or setup step that didn't pass.
+type Result struct {
+	Scenario string
+	Status   Status
+	Duration time.Duration
+	Failures []string
+}
+
+// workspaceName gives each scenario its own Terraform workspace, scoped by
+// runID, so concurrent scenarios against the same module directory (or
+// concurrent suite runs in CI) never collide on shared state.
+func workspaceName(runID, scenarioName string) string {
+	return "tftest-" + runID + "-" + scenarioName
+}
+
+// Run executes every scenario against its own Terraform workspace, bounded
+// to at most parallel running at once, and returns one Result per
+// scenario in the same order scenarios was given. runID scopes each
+// scenario's workspace name so concurrent runs don't collide.
+func Run(ctx context.Context, scenarios []Scenario, runID string, parallel int) ([]Result, error) {
+	results := make([]Result, len(scenarios))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	if parallel > 0 {
+		group.SetLimit(parallel)
+	}
+
+	for i, scenario := range scenarios {
+		i, scenario := i, scenario
+		group.Go(func() error {
+			results[i] = runScenario(groupCtx, scenario, runID)
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// runScenario executes one Scenario. It never returns an error itself -- a
+// failed plan/apply or assertion is recorded on the Result as a Failure so
+// one scenario's infrastructure failure doesn't abort the rest of the
+// parallel batch.
+func runScenario(ctx context.Context, scenario Scenario, runID string) (result Result) {
+	start := time.Now()
+	result = Result{Scenario: scenario.Name}
+	defer func() { result.Duration = time.Since(start) }()
+
+	tfRunner, err := iac.NewRunner(scenario.ResolvedDir(), workspaceName(runID, scenario.Name),
+		iac.WithVars(scenario.Variables))
+	if err != nil {
+		result.Status = StatusFailed
+		result.Failures = []string{errors.Wrap(err, "no terraform/tofu binary found").Error()}
+		return result
+	}
+
+	if err := tfRunner.Init(ctx); err != nil {
+		result.Status = StatusFailed
+		result.Failures = []string{err.Error()}
+		return result
+	}
+	defer func() { _ = tfRunner.Cleanup(ctx, result.Status == StatusFailed) }()
+
+	outputs, err := scenarioOutputs(ctx, tfRunner, scenario)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Failures = []string{err.Error()}
+		return result
+	}
+
+	var failures []string
+	for _, assertion := range scenario.Assertions {
+		if evalErr := assertion.Evaluate(outputs); evalErr != nil {
+			failures = append(failures, evalErr.Error())
+		}
+	}
+
+	result.Failures = failures
+	if len(failures) > 0 {
+		result.Status = StatusFailed
+	} else {
+		result.Status = StatusPassed
+	}
+	return result
+}
+
+// scenarioOutputs runs scenario's Mode against tfRunner and returns the
+// resulting output values to evaluate Assertions against: ModeApply
+// applies and reads outputs back from state, while ModePlan reads the
+// planned output values straight out of the plan without ever creating
+// anything.
+func scenarioOutputs(ctx context.Context, tfRunner *iac.Runner, scenario Scenario) (map[string]interface{}, error) {
+	plan, err := tfRunner.Plan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to plan scenario %q", scenario.Name)
+	}
+
+	if scenario.Mode == ModePlan {
+		outputs := make(map[string]interface{}, len(plan.OutputChanges))
+		for name, change := range plan.OutputChanges {
+			outputs[name] = change.After
+		}
+		return outputs, nil
+	}
+
+	if err := tfRunner.Apply(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to apply scenario %q", scenario.Name)
+	}
+
+	outputs, err := tfRunner.Output(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read outputs for scenario %q", scenario.Name)
+	}
+	return outputs, nil
+}
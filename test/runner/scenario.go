@@ -0,0 +1,116 @@
+// Package runner discovers and executes declarative Terraform-module test
+// scenarios: a lightweight, YAML-based analogue of HashiCorp's
+// experimental `terraform test` command. TestSuite.RunTerraformTestScenarios
+// (tests/terraform/terraform_test_runner_test.go) uses this package to give
+// callers a real assertion DSL evaluated against terraform.OutputAll-style
+// outputs, instead of the hand-written Go health checks
+// RunInfrastructureTests already runs.
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Mode is the Terraform operation a Scenario exercises before its
+// Assertions are evaluated.
+type Mode string
+
+const (
+	ModeApply Mode = "apply"
+	ModePlan  Mode = "plan"
+)
+
+// AssertionType selects how Assertion.Evaluate compares an output's actual
+// value against its expectation.
+type AssertionType string
+
+const (
+	AssertEquals AssertionType = "equals"
+	AssertRegex  AssertionType = "regex"
+	AssertRange  AssertionType = "range"
+)
+
+// Assertion checks one Terraform output from a Scenario's plan or apply
+// result. Which of Equals, Regex, or Min/Max is read depends on Type.
+type Assertion struct {
+	Output string        `yaml:"output"`
+	Type   AssertionType `yaml:"type"`
+	Equals interface{}   `yaml:"equals,omitempty"`
+	Regex  string        `yaml:"regex,omitempty"`
+	Min    *float64      `yaml:"min,omitempty"`
+	Max    *float64      `yaml:"max,omitempty"`
+}
+
+// Scenario is one *.tftest.yaml file: a Terraform module directory, the
+// Variables and Mode to exercise it with, and the Assertions its resulting
+// outputs must satisfy.
+type Scenario struct {
+	Name       string                 `yaml:"name"`
+	Dir        string                 `yaml:"dir"`
+	Mode       Mode                   `yaml:"mode"`
+	Variables  map[string]interface{} `yaml:"variables"`
+	Assertions []Assertion            `yaml:"assertions"`
+
+	// Path is the scenario file's own location, set by Discover so a
+	// relative Dir resolves against the scenario file rather than
+	// whatever directory the caller happens to run from.
+	Path string `yaml:"-"`
+}
+
+// ResolvedDir returns Dir resolved relative to the scenario file's own
+// directory, so a scenarios directory can live anywhere relative to the
+// Terraform modules it exercises.
+func (s Scenario) ResolvedDir() string {
+	if filepath.IsAbs(s.Dir) {
+		return s.Dir
+	}
+	return filepath.Join(filepath.Dir(s.Path), s.Dir)
+}
+
+// Discover reads every *.tftest.yaml file under dir and parses it as a
+// Scenario. A missing dir is not an error -- it returns a nil slice, since
+// an unconfigured scenarios directory should fall back to the caller's
+// existing checks rather than fail the run outright.
+func Discover(dir string) ([]Scenario, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var scenarios []Scenario
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".tftest.yaml") {
+			return nil
+		}
+
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return errors.Wrapf(readErr, "failed to read scenario %s", path)
+		}
+
+		var scenario Scenario
+		if parseErr := yaml.Unmarshal(data, &scenario); parseErr != nil {
+			return errors.Wrapf(parseErr, "failed to parse scenario %s", path)
+		}
+		scenario.Path = path
+		if scenario.Name == "" {
+			scenario.Name = strings.TrimSuffix(filepath.Base(path), ".tftest.yaml")
+		}
+		if scenario.Mode == "" {
+			scenario.Mode = ModeApply
+		}
+		scenarios = append(scenarios, scenario)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scenarios, nil
+}
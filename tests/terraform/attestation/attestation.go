@@ -0,0 +1,80 @@
+// Package attestation verifies a confidential-computing workload's runtime
+// measurements against a per-TEE-variant expected baseline, the same shape
+// Constellation's config/attestation package uses: one Measurements struct
+// per variant (AWS Nitro, Azure SEV-SNP, GCP SEV/TDX, ...), compared
+// field-by-field against a live Report. Fetching that Report is provider
+// specific, so it's pluggable via Verifier/RegisterVerifier rather than
+// hard-coded here.
+package attestation
+
+import "context"
+
+// Measurements is one TEE variant's expected runtime measurements. PCRs
+// keys are platform-specific register indices/names (e.g. "0", "4" for
+// Nitro, "launch-digest" for SEV-SNP); values are the expected hex digest.
+type Measurements struct {
+	PCRs              map[string]string
+	Microcode         string
+	BootloaderVersion string
+	TEEVersion        string
+}
+
+// Report is a sample workload's observed attestation measurements for a
+// single Variant, as fetched by a Verifier.
+type Report struct {
+	Variant           string
+	PCRs              map[string]string
+	Microcode         string
+	BootloaderVersion string
+	TEEVersion        string
+}
+
+// Drift describes one measurement that didn't match its expected value.
+type Drift struct {
+	Field    string
+	Expected string
+	Actual   string
+}
+
+// Verifier fetches a sample workload's attestation Report for one TEE
+// Variant. RegisterVerifier wires a concrete implementation (Nitro,
+// SEV-SNP, SEV/TDX, ...) in by name; Verify below is provider-agnostic and
+// works against any Report a Verifier produces.
+type Verifier interface {
+	// Variant is the name this Verifier answers for, matching a
+	// Measurements map key (e.g. "aws-nitro").
+	Variant() string
+	// Fetch retrieves the current attestation Report from the sample
+	// workload this Verifier is wired to.
+	Fetch(ctx context.Context) (Report, error)
+}
+
+// Verify compares report against expected, returning every mismatched
+// measurement rather than stopping at the first one, so a caller can
+// report a complete drift list instead of "something doesn't match."
+func Verify(report Report, expected Measurements) []Drift {
+	var drifts []Drift
+
+	for pcr, expectedValue := range expected.PCRs {
+		actualValue, ok := report.PCRs[pcr]
+		if !ok {
+			drifts = append(drifts, Drift{Field: "pcr:" + pcr, Expected: expectedValue, Actual: "<missing>"})
+			continue
+		}
+		if actualValue != expectedValue {
+			drifts = append(drifts, Drift{Field: "pcr:" + pcr, Expected: expectedValue, Actual: actualValue})
+		}
+	}
+
+	if expected.Microcode != "" && report.Microcode != expected.Microcode {
+		drifts = append(drifts, Drift{Field: "microcode", Expected: expected.Microcode, Actual: report.Microcode})
+	}
+	if expected.BootloaderVersion != "" && report.BootloaderVersion != expected.BootloaderVersion {
+		drifts = append(drifts, Drift{Field: "bootloader_version", Expected: expected.BootloaderVersion, Actual: report.BootloaderVersion})
+	}
+	if expected.TEEVersion != "" && report.TEEVersion != expected.TEEVersion {
+		drifts = append(drifts, Drift{Field: "tee_version", Expected: expected.TEEVersion, Actual: report.TEEVersion})
+	}
+
+	return drifts
+}
@@ -0,0 +1,20 @@
+package attestation
+
+import "context"
+
+// MockVerifier is a Verifier stand-in for CI and unit tests: Fetch always
+// returns Report and Err unchanged, so Verify's drift detection can be
+// exercised without a live confidential-computing workload.
+type MockVerifier struct {
+	VariantName string
+	Report      Report
+	Err         error
+}
+
+// Variant implements Verifier.
+func (m MockVerifier) Variant() string { return m.VariantName }
+
+// Fetch implements Verifier.
+func (m MockVerifier) Fetch(ctx context.Context) (Report, error) {
+	return m.Report, m.Err
+}
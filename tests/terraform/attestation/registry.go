@@ -0,0 +1,26 @@
+package attestation
+
+import "sync"
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Verifier{}
+)
+
+// RegisterVerifier wires v in under v.Variant(), overwriting any Verifier
+// previously registered for that variant. Built-in provider Verifiers
+// register themselves from an init(), the same way this suite's other
+// pluggable registries (e.g. the disaster-recovery action Registry) do.
+func RegisterVerifier(v Verifier) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[v.Variant()] = v
+}
+
+// Lookup returns the Verifier registered for variant, if any.
+func Lookup(variant string) (Verifier, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	v, ok := registry[variant]
+	return v, ok
+}
@@ -0,0 +1,268 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/attestation"
+)
+
+// attestationConfigGoldenPath is the checked-in AttestationConfig fixture
+// TestAttestationConfigGoldenRoundTrip parses and re-serializes to prove
+// the yaml tags on AttestationConfig/AttestationMeasurements round-trip
+// without loss.
+const attestationConfigGoldenPath = "testdata/attestation_config.yaml"
+
+// awsNitroVerifier fetches attestation reports from AWS Nitro Enclave
+// workloads for the "aws-nitro" variant.
+type awsNitroVerifier struct {
+	suite *AWSTestSuite
+}
+
+func (v awsNitroVerifier) Variant() string { return "aws-nitro" }
+
+// Fetch would retrieve the sample workload's Nitro attestation document.
+// A Nitro attestation document can only be produced from inside the
+// enclave itself (via the NSM device) and signed with the enclave's
+// ephemeral key -- there's no EC2 API that returns one for a running
+// instance, and this suite doesn't deploy a sample enclave workload to ask.
+func (v awsNitroVerifier) Fetch(ctx context.Context) (attestation.Report, error) {
+	if v.suite == nil {
+		return attestation.Report{}, errors.New("AWS test suite is not initialized")
+	}
+	return attestation.Report{}, errors.New("no sample Nitro Enclave workload is deployed by this suite to fetch an attestation document from")
+}
+
+// azureSEVSNPVerifier fetches attestation reports from Azure confidential
+// VM workloads for the "azure-sev-snp" variant.
+type azureSEVSNPVerifier struct {
+	suite *AzureTestSuite
+}
+
+func (v azureSEVSNPVerifier) Variant() string { return "azure-sev-snp" }
+
+// Fetch would submit the sample workload's SEV-SNP hardware report to the
+// Microsoft Azure Attestation service and parse the returned JWT's claims.
+// That hardware report can only be generated on the confidential VM itself
+// via its vTPM, and this suite doesn't deploy a sample confidential VM
+// workload to request one from.
+func (v azureSEVSNPVerifier) Fetch(ctx context.Context) (attestation.Report, error) {
+	if v.suite == nil {
+		return attestation.Report{}, errors.New("Azure test suite is not initialized")
+	}
+	return attestation.Report{}, errors.New("no sample SEV-SNP confidential VM workload is deployed by this suite to request a hardware report from")
+}
+
+// gcpTEEVerifier fetches attestation reports from GCP Confidential Space /
+// SEV-TDX workloads for the "gcp-sev-tdx" variant.
+type gcpTEEVerifier struct {
+	suite *GCPTestSuite
+}
+
+func (v gcpTEEVerifier) Variant() string { return "gcp-sev-tdx" }
+
+// Fetch would exchange the sample workload's OIDC attestation token (minted
+// by the Confidential Space launcher and signed by Google's attestation
+// service) for a verified claims set. That token can only be minted by the
+// running workload itself, and this suite doesn't deploy a sample
+// Confidential Space workload to fetch one from.
+func (v gcpTEEVerifier) Fetch(ctx context.Context) (attestation.Report, error) {
+	if v.suite == nil {
+		return attestation.Report{}, errors.New("GCP test suite is not initialized")
+	}
+	return attestation.Report{}, errors.New("no sample Confidential Space workload is deployed by this suite to fetch an attestation token from")
+}
+
+func init() {
+	attestation.RegisterVerifier(awsNitroVerifier{})
+	attestation.RegisterVerifier(azureSEVSNPVerifier{})
+	attestation.RegisterVerifier(gcpTEEVerifier{})
+}
+
+// buildAttestationMeasurements converts an AttestationMeasurements config
+// entry into the attestation package's provider-agnostic Measurements type.
+func buildAttestationMeasurements(config AttestationMeasurements) attestation.Measurements {
+	return attestation.Measurements{
+		PCRs:              config.PCRs,
+		Microcode:         config.Microcode,
+		BootloaderVersion: config.BootloaderVersion,
+		TEEVersion:        config.TEEVersion,
+	}
+}
+
+// testWorkloadAttestation fetches each configured AttestationConfig
+// variant's live report via its registered attestation.Verifier and fails
+// with a complete measurement-drift report rather than stopping at the
+// first mismatch. Both testUnifiedIAM and testSecurityMonitoring call this
+// -- Constellation-style attestation backs both "is this the identity our
+// federated IAM trusts" and "is this workload actually running the image
+// we expect," and this suite doesn't split those into a third test method.
+func (suite *MultiCloudTestSuite) testWorkloadAttestation() error {
+	config := suite.MultiCloudConfig.SecurityConfig.Attestation
+	if !config.Enabled {
+		return nil
+	}
+
+	var driftReports []string
+	for variantName, expected := range config.Variants {
+		verifier, ok := attestation.Lookup(variantName)
+		if !ok {
+			return errors.Errorf("no attestation verifier registered for variant %q", variantName)
+		}
+
+		report, err := verifier.Fetch(suite.Context)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch attestation report for variant %q", variantName)
+		}
+
+		for _, drift := range attestation.Verify(report, buildAttestationMeasurements(expected)) {
+			driftReports = append(driftReports, fmt.Sprintf("%s: %s expected %q, got %q", variantName, drift.Field, drift.Expected, drift.Actual))
+		}
+	}
+
+	if len(driftReports) > 0 {
+		return errors.Errorf("workload attestation measurement drift detected:\n%s", strings.Join(driftReports, "\n"))
+	}
+	return nil
+}
+
+// TestAttestationConfigGoldenRoundTrip loads attestationConfigGoldenPath,
+// re-marshals it, and re-parses the result, proving AttestationConfig's
+// yaml tags survive a round trip without silently dropping or renaming a
+// field -- and that the fixture itself passes the same validator chain
+// ValidateMultiCloudConfig runs in production.
+func TestAttestationConfigGoldenRoundTrip(t *testing.T) {
+	data, err := os.ReadFile(attestationConfigGoldenPath)
+	require.NoError(t, err)
+
+	var original AttestationConfig
+	require.NoError(t, yaml.Unmarshal(data, &original))
+	require.True(t, original.Enabled)
+	require.Len(t, original.Variants, 3)
+
+	v, _, err := newMultiCloudValidator()
+	require.NoError(t, err)
+	require.NoError(t, v.Struct(original))
+
+	reserialized, err := yaml.Marshal(original)
+	require.NoError(t, err)
+
+	var roundTripped AttestationConfig
+	require.NoError(t, yaml.Unmarshal(reserialized, &roundTripped))
+	assert.Equal(t, original, roundTripped)
+
+	nitro := roundTripped.Variants["aws-nitro"]
+	assert.Equal(t, "1.4.0", nitro.BootloaderVersion)
+	sevSNP := roundTripped.Variants["azure-sev-snp"]
+	assert.Equal(t, "latest", sevSNP.BootloaderVersion)
+}
+
+// TestAttestationConfigRejectsMissingMeasurements proves a variant with no
+// PCRs fails validation instead of silently verifying against an empty
+// baseline.
+func TestAttestationConfigRejectsMissingMeasurements(t *testing.T) {
+	config := AttestationConfig{
+		Enabled: true,
+		Variants: map[string]AttestationMeasurements{
+			"aws-nitro": {Microcode: "0x1", BootloaderVersion: "1.0.0", TEEVersion: "1.0.0"},
+		},
+	}
+
+	v, _, err := newMultiCloudValidator()
+	require.NoError(t, err)
+	assert.Error(t, v.Struct(config))
+}
+
+// TestWorkloadAttestationDetectsDrift drives testWorkloadAttestation
+// against a MockVerifier whose Report disagrees with the configured
+// Measurements, proving the drift gets surfaced without needing a live
+// confidential-computing workload.
+func TestWorkloadAttestationDetectsDrift(t *testing.T) {
+	const variant = "test-mock-variant"
+	attestation.RegisterVerifier(attestation.MockVerifier{
+		VariantName: variant,
+		Report: attestation.Report{
+			Variant:           variant,
+			PCRs:              map[string]string{"0": "unexpected-digest"},
+			Microcode:         "0x1",
+			BootloaderVersion: "1.0.0",
+			TEEVersion:        "1.0.0",
+		},
+	})
+
+	suite := &MultiCloudTestSuite{
+		Context: context.Background(),
+		MultiCloudConfig: MultiCloudConfig{
+			SecurityConfig: MultiCloudSecurityConfig{
+				Attestation: AttestationConfig{
+					Enabled: true,
+					Variants: map[string]AttestationMeasurements{
+						variant: {
+							PCRs:              map[string]string{"0": "expected-digest"},
+							Microcode:         "0x1",
+							BootloaderVersion: "1.0.0",
+							TEEVersion:        "1.0.0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := suite.testWorkloadAttestation()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pcr:0")
+	assert.Contains(t, err.Error(), "expected-digest")
+	assert.Contains(t, err.Error(), "unexpected-digest")
+}
+
+// TestWorkloadAttestationPassesOnMatch proves a Report matching its
+// configured Measurements passes with no error.
+func TestWorkloadAttestationPassesOnMatch(t *testing.T) {
+	const variant = "test-mock-variant-match"
+	measurements := attestation.Measurements{
+		PCRs:              map[string]string{"0": "digest"},
+		Microcode:         "0x1",
+		BootloaderVersion: "1.0.0",
+		TEEVersion:        "1.0.0",
+	}
+	attestation.RegisterVerifier(attestation.MockVerifier{
+		VariantName: variant,
+		Report: attestation.Report{
+			Variant:           variant,
+			PCRs:              measurements.PCRs,
+			Microcode:         measurements.Microcode,
+			BootloaderVersion: measurements.BootloaderVersion,
+			TEEVersion:        measurements.TEEVersion,
+		},
+	})
+
+	suite := &MultiCloudTestSuite{
+		Context: context.Background(),
+		MultiCloudConfig: MultiCloudConfig{
+			SecurityConfig: MultiCloudSecurityConfig{
+				Attestation: AttestationConfig{
+					Enabled: true,
+					Variants: map[string]AttestationMeasurements{
+						variant: {
+							PCRs:              measurements.PCRs,
+							Microcode:         measurements.Microcode,
+							BootloaderVersion: measurements.BootloaderVersion,
+							TEEVersion:        measurements.TEEVersion,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.NoError(t, suite.testWorkloadAttestation())
+}
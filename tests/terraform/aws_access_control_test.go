@@ -0,0 +1,41 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/pkg/errors"
+)
+
+// EvaluateAccessControls simulates every check in
+// suite.Config.AWSAccessControlMatrix via IAM's SimulatePrincipalPolicy and
+// returns one violation per check whose simulated decision disagrees with
+// its ExpectAllowed. A nil/empty matrix evaluates nothing and returns no
+// violations -- there's no default matrix to assume.
+func (suite *AWSTestSuite) EvaluateAccessControls() ([]string, error) {
+	var violations []string
+
+	for _, check := range suite.Config.AWSAccessControlMatrix {
+		output, err := suite.IAM().SimulatePrincipalPolicy(suite.Context, &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(check.Principal),
+			ActionNames:     []string{check.Action},
+			ResourceArns:    []string{check.Resource},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to simulate policy for principal %s", check.Principal)
+		}
+
+		for _, result := range output.EvaluationResults {
+			allowed := result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed
+			if allowed != check.ExpectAllowed {
+				violations = append(violations, fmt.Sprintf(
+					"principal %s action %s on resource %s: expected allowed=%t, got %s",
+					check.Principal, check.Action, check.Resource, check.ExpectAllowed, result.EvalDecision))
+			}
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,202 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	"github.com/pkg/errors"
+)
+
+// minHealthyTargetHealthCheck builds a SteadyStateCheck asserting at least
+// minHealthy targets in targetGroupARN are healthy -- the ALB half of
+// TestChaosEngineering's steady-state hypotheses.
+func minHealthyTargetHealthCheck(suite *AWSTestSuite, targetGroupARN string, minHealthy int) SteadyStateCheck {
+	return func(ctx context.Context) error {
+		output, err := suite.ELB().DescribeTargetHealth(ctx, &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(targetGroupARN),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe target health for steady-state check")
+		}
+
+		var healthy int
+		for _, description := range output.TargetHealthDescriptions {
+			if description.TargetHealth != nil && description.TargetHealth.State == elbv2types.TargetHealthStateEnumHealthy {
+				healthy++
+			}
+		}
+		if healthy < minHealthy {
+			return errors.Errorf("only %d/%d required healthy targets in %s", healthy, minHealthy, targetGroupARN)
+		}
+		return nil
+	}
+}
+
+// rdsAvailableHealthCheck builds a SteadyStateCheck asserting dbInstanceID
+// is in the "available" state -- the RDS half of TestChaosEngineering's
+// steady-state hypotheses.
+func rdsAvailableHealthCheck(suite *AWSTestSuite, dbInstanceID string) SteadyStateCheck {
+	return func(ctx context.Context) error {
+		output, err := suite.RDS().DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(dbInstanceID),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to describe RDS instance %s for steady-state check", dbInstanceID)
+		}
+		if len(output.DBInstances) == 0 {
+			return errors.Errorf("RDS instance %s not found", dbInstanceID)
+		}
+		if status := aws.ToString(output.DBInstances[0].DBInstanceStatus); status != "available" {
+			return errors.Errorf("RDS instance %s is %q, not available", dbInstanceID, status)
+		}
+		return nil
+	}
+}
+
+// httpHealthCheck builds a SteadyStateCheck wrapping
+// AWSTestSuite.TestHTTPConnectivity against endpoint, so a chaos experiment
+// can assert the application itself stayed reachable throughout injection.
+func httpHealthCheck(suite *AWSTestSuite, endpoint string) SteadyStateCheck {
+	return func(ctx context.Context) error {
+		return suite.TestHTTPConnectivity(endpoint)
+	}
+}
+
+// loadBalancerHealthCheck builds a SteadyStateCheck wrapping
+// AWSTestSuite.TestLoadBalancerHealth against endpoint.
+func loadBalancerHealthCheck(suite *AWSTestSuite, endpoint string) SteadyStateCheck {
+	return func(ctx context.Context) error {
+		return suite.TestLoadBalancerHealth(endpoint)
+	}
+}
+
+// composeSteadyState ANDs every non-nil check together, so an experiment can
+// assert ALB target health, RDS availability, and application reachability
+// in one SteadyStateCheck -- the first failing check's error wins.
+func composeSteadyState(checks ...SteadyStateCheck) SteadyStateCheck {
+	return func(ctx context.Context) error {
+		for _, check := range checks {
+			if check == nil {
+				continue
+			}
+			if err := check(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// GameDayScenario is one canned chaos scenario TestChaosEngineering runs: a
+// name and the Experiment it builds.
+type GameDayScenario struct {
+	Name       string
+	Experiment *Experiment
+}
+
+// GameDayResult is one GameDayScenario's outcome.
+type GameDayResult struct {
+	Scenario string            `json:"scenario"`
+	Result   *ExperimentResult `json:"result,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// GameDayReport is RunGameDay's structured output: every scenario's
+// ExperimentResult (or the error that kept it from completing).
+type GameDayReport struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Results     []GameDayResult `json:"results"`
+}
+
+// RunGameDay runs every scenario in order -- rather than in parallel, so one
+// scenario's induced instability doesn't overlap with and confound
+// another's steady-state check -- collecting each one's ExperimentResult or
+// error into a GameDayReport.
+func RunGameDay(ctx context.Context, scenarios []GameDayScenario) *GameDayReport {
+	report := &GameDayReport{GeneratedAt: time.Now().UTC()}
+	for _, scenario := range scenarios {
+		result, err := scenario.Experiment.Run(ctx)
+		entry := GameDayResult{Scenario: scenario.Name, Result: result}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		report.Results = append(report.Results, entry)
+	}
+	return report
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *GameDayReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal game day report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write game day report %s", path)
+	}
+	return nil
+}
+
+// EC2InstanceTerminationScenario builds a canned experiment that stops
+// instances tagged asgTags (an ASG subset), asserting targetGroupARN keeps
+// at least minHealthy healthy targets and endpoint stays reachable
+// throughout.
+func EC2InstanceTerminationScenario(runner *ChaosRunner, asgTags map[string]string, targetGroupARN string, minHealthy int, endpoint string) GameDayScenario {
+	experiment := runner.Experiment("ec2-instance-termination").
+		Target(asgTags).
+		Action(ActionStopInstances).
+		SteadyState(composeSteadyState(
+			minHealthyTargetHealthCheck(runner.suite, targetGroupARN, minHealthy),
+			httpHealthCheck(runner.suite, endpoint),
+		))
+	return GameDayScenario{Name: "ec2-instance-termination", Experiment: experiment}
+}
+
+// RDSFailoverScenario builds a canned experiment that forces the RDS
+// cluster tagged dbClusterTags to fail over, asserting dbInstanceID returns
+// to "available" and endpoint stays reachable throughout.
+func RDSFailoverScenario(runner *ChaosRunner, dbClusterTags map[string]string, dbInstanceID, endpoint string) GameDayScenario {
+	experiment := runner.Experiment("rds-failover").
+		Target(dbClusterTags).
+		Action(ActionRDSFailover).
+		SteadyState(composeSteadyState(
+			rdsAvailableHealthCheck(runner.suite, dbInstanceID),
+			httpHealthCheck(runner.suite, endpoint),
+		))
+	return GameDayScenario{Name: "rds-failover", Experiment: experiment}
+}
+
+// EBSIOPauseScenario builds a canned experiment that pauses I/O on EBS
+// volumes tagged volumeTags, asserting targetGroupARN keeps at least
+// minHealthy healthy targets throughout -- a paused data volume should
+// degrade the application onto other targets, not take the whole fleet
+// down.
+func EBSIOPauseScenario(runner *ChaosRunner, volumeTags map[string]string, targetGroupARN string, minHealthy int) GameDayScenario {
+	experiment := runner.Experiment("ebs-io-pause").
+		Target(volumeTags).
+		Action(ActionEBSPauseIO).
+		SteadyState(minHealthyTargetHealthCheck(runner.suite, targetGroupARN, minHealthy))
+	return GameDayScenario{Name: "ebs-io-pause", Experiment: experiment}
+}
+
+// NetworkLatencyScenario builds a canned experiment that injects network
+// latency (via the AWSFIS-Run-Network-Latency SSM document) into instances
+// tagged instanceTags, asserting endpoint's load balancer health and HTTP
+// reachability both hold up under the added latency.
+func NetworkLatencyScenario(runner *ChaosRunner, instanceTags map[string]string, endpoint string) GameDayScenario {
+	experiment := runner.Experiment("network-latency-injection").
+		Target(instanceTags).
+		Action(ActionNetworkLatency).
+		SteadyState(composeSteadyState(
+			loadBalancerHealthCheck(runner.suite, endpoint),
+			httpHealthCheck(runner.suite, endpoint),
+		))
+	return GameDayScenario{Name: "network-latency-injection", Experiment: experiment}
+}
@@ -0,0 +1,289 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/fis"
+	fistypes "github.com/aws/aws-sdk-go-v2/service/fis/types"
+
+	"github.com/pkg/errors"
+)
+
+// ChaosAction is an AWS FIS action ID -- the built-in actions this runner's
+// DSL exposes cover the scenarios TestChaosEngineering's stub called out:
+// stopping instances, throttling an API via SSM, injecting network
+// latency via SSM, forcing an RDS failover, pausing EBS volume I/O, and
+// simulating an AZ power interruption.
+type ChaosAction string
+
+const (
+	ActionStopInstances       ChaosAction = "aws:ec2:stop-instances"
+	ActionThrottleAPI         ChaosAction = "aws:ssm:send-command"
+	ActionNetworkLatency      ChaosAction = "aws:ssm:send-command"
+	ActionRDSFailover         ChaosAction = "aws:rds:failover-db-cluster"
+	ActionEBSPauseIO          ChaosAction = "aws:ebs:pause-volume-io"
+	ActionAZPowerInterruption ChaosAction = "aws:ec2:api-insufficient-instance-capacity-error"
+)
+
+// chaosSSMDocuments maps the two SSM-backed actions to the document each
+// one runs, since aws:ssm:send-command alone doesn't say what to execute.
+var chaosSSMDocuments = map[ChaosAction]string{
+	ActionThrottleAPI:    "AWSFIS-Run-Throttle-API",
+	ActionNetworkLatency: "AWSFIS-Run-Network-Latency",
+}
+
+// SteadyStateCheck asserts the system is healthy; Experiment.Run calls it
+// before injection (abort if already unhealthy) and after (rollback the
+// experiment if the injection broke something it shouldn't have).
+type SteadyStateCheck func(ctx context.Context) error
+
+// ChaosRunner composes and launches FIS experiments against a suite's
+// tagged resources, the live counterpart to TestChaosEngineering's
+// previous stub.
+type ChaosRunner struct {
+	suite   *AWSTestSuite
+	roleARN string
+}
+
+// NewChaosRunner returns a ChaosRunner that launches FIS experiments using
+// roleARN, the IAM role FIS assumes to perform actions.
+func NewChaosRunner(suite *AWSTestSuite, roleARN string) *ChaosRunner {
+	return &ChaosRunner{suite: suite, roleARN: roleARN}
+}
+
+// Experiment starts building a named chaos experiment.
+func (r *ChaosRunner) Experiment(name string) *Experiment {
+	return &Experiment{runner: r, name: name, stopOnAlarm: true}
+}
+
+// Experiment is the fluent DSL TestChaosEngineering (and any future chaos
+// test) builds up before calling Run:
+//
+//	runner.Experiment("az-failure").
+//		Target(tags).
+//		Action(ActionStopInstances).
+//		SteadyState(func(ctx context.Context) error { ... }).
+//		Run(ctx)
+type Experiment struct {
+	runner             *ChaosRunner
+	name               string
+	action             ChaosAction
+	targetTags         map[string]string
+	targetResourceType string
+	steadyState        SteadyStateCheck
+	dryRun             bool
+	stopOnAlarm        bool
+}
+
+// actionResourceTypes maps each ChaosAction to the FIS resource type its
+// target selects by default -- most actions act on EC2 instances, but RDS
+// failover and EBS I/O pause act on different resource types entirely.
+// TargetResourceType overrides this when an experiment needs something
+// else.
+var actionResourceTypes = map[ChaosAction]string{
+	ActionStopInstances:       "aws:ec2:instance",
+	ActionThrottleAPI:         "aws:ec2:instance",
+	ActionNetworkLatency:      "aws:ec2:instance",
+	ActionRDSFailover:         "aws:rds:cluster",
+	ActionEBSPauseIO:          "aws:ec2:ebs-volume",
+	ActionAZPowerInterruption: "aws:ec2:instance",
+}
+
+// Target scopes the experiment to resources carrying tags -- typically
+// the current run's TestID/TestSuite tags from CreateOptions, so chaos
+// only ever touches what this test run created.
+func (e *Experiment) Target(tags map[string]string) *Experiment {
+	e.targetTags = tags
+	return e
+}
+
+// TargetResourceType overrides the FIS resource type e's target selects by,
+// in place of actionResourceTypes' default for e.Action.
+func (e *Experiment) TargetResourceType(resourceType string) *Experiment {
+	e.targetResourceType = resourceType
+	return e
+}
+
+// Action sets the FIS action this experiment injects.
+func (e *Experiment) Action(action ChaosAction) *Experiment {
+	e.action = action
+	return e
+}
+
+// SteadyState sets the health check Run verifies before and after
+// injection.
+func (e *Experiment) SteadyState(check SteadyStateCheck) *Experiment {
+	e.steadyState = check
+	return e
+}
+
+// DryRun makes Run render the experiment template JSON instead of
+// launching it -- useful for reviewing what an experiment would do before
+// it runs against real resources.
+func (e *Experiment) DryRun() *Experiment {
+	e.dryRun = true
+	return e
+}
+
+// ExperimentResult is what Run returns: either the rendered template (dry
+// run) or the outcome of a real launch.
+type ExperimentResult struct {
+	DryRun       bool
+	TemplateJSON string
+	ExperimentID string
+	FinalState   string
+	RolledBack   bool
+}
+
+// Run launches the experiment: checks steady state, creates and starts an
+// FIS experiment template targeting e.targetTags via e.action, polls until
+// it completes, then re-checks steady state and stops the experiment early
+// if that check fails.
+func (e *Experiment) Run(ctx context.Context) (*ExperimentResult, error) {
+	if e.action == "" {
+		return nil, errors.New("chaos experiment has no Action set")
+	}
+
+	template := e.buildTemplate()
+	if e.dryRun {
+		rendered, err := json.MarshalIndent(template, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to render dry-run experiment template")
+		}
+		return &ExperimentResult{DryRun: true, TemplateJSON: string(rendered)}, nil
+	}
+
+	if e.steadyState != nil {
+		if err := e.steadyState(ctx); err != nil {
+			return nil, errors.Wrapf(err, "steady state check failed before running experiment %q", e.name)
+		}
+	}
+
+	created, err := e.runner.suite.FIS().CreateExperimentTemplate(ctx, template)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create FIS experiment template for %q", e.name)
+	}
+	templateID := aws.ToString(created.ExperimentTemplate.Id)
+
+	started, err := e.runner.suite.FIS().StartExperiment(ctx, &fis.StartExperimentInput{
+		ExperimentTemplateId: aws.String(templateID),
+		Tags:                 map[string]string{tagTestSuite: trackingSuiteName, "ChaosExperiment": e.name},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to start FIS experiment for %q", e.name)
+	}
+	experimentID := aws.ToString(started.Experiment.Id)
+
+	finalState, err := e.awaitCompletion(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExperimentResult{ExperimentID: experimentID, FinalState: finalState}
+
+	if e.steadyState != nil {
+		if err := e.steadyState(ctx); err != nil {
+			if e.stopOnAlarm {
+				if _, stopErr := e.runner.suite.FIS().StopExperiment(ctx, &fis.StopExperimentInput{
+					Id: aws.String(experimentID),
+				}); stopErr != nil {
+					e.runner.suite.Logger.Warn().Err(stopErr).Str("experiment", experimentID).
+						Msg("failed to stop experiment after steady-state breach")
+				}
+				result.RolledBack = true
+			}
+			return result, errors.Wrapf(err, "steady state breached after experiment %q", e.name)
+		}
+	}
+
+	return result, nil
+}
+
+// buildTemplate composes a minimal FIS experiment template for e: one
+// action targeting resources selected by e.targetTags, with a CloudWatch
+// alarm stop condition left to the caller to wire up via Target's tags --
+// FIS itself enforces a non-empty stop-condition list, so an "NONE" sentinel
+// is used when the caller hasn't configured one.
+func (e *Experiment) buildTemplate() *fis.CreateExperimentTemplateInput {
+	const targetKey = "chaosTargets"
+
+	resourceTags := make(map[string]string, len(e.targetTags))
+	for k, v := range e.targetTags {
+		resourceTags[k] = v
+	}
+
+	actionInput := fistypes.CreateExperimentTemplateActionInput{
+		ActionId: aws.String(string(e.action)),
+		Targets:  map[string]string{"Instances": targetKey},
+	}
+	if document, ok := chaosSSMDocuments[e.action]; ok {
+		actionInput.Parameters = map[string]string{"documentArn": document}
+	}
+
+	resourceType := e.targetResourceType
+	if resourceType == "" {
+		resourceType = actionResourceTypes[e.action]
+	}
+	if resourceType == "" {
+		resourceType = "aws:ec2:instance"
+	}
+
+	return &fis.CreateExperimentTemplateInput{
+		Description: aws.String("chaos experiment: " + e.name),
+		RoleArn:     aws.String(e.runner.roleARN),
+		Actions: map[string]fistypes.CreateExperimentTemplateActionInput{
+			"injectFault": actionInput,
+		},
+		Targets: map[string]fistypes.CreateExperimentTemplateTargetInput{
+			targetKey: {
+				ResourceType:  aws.String(resourceType),
+				SelectionMode: aws.String("ALL"),
+				ResourceTags:  resourceTags,
+			},
+		},
+		StopConditions: []fistypes.CreateExperimentTemplateStopConditionInput{
+			{Source: aws.String("none")},
+		},
+		Tags: map[string]string{tagTestSuite: trackingSuiteName},
+	}
+}
+
+// chaosPollInterval and chaosPollTimeout bound how long awaitCompletion
+// polls GetExperiment before giving up -- FIS experiments using the
+// built-in actions above typically finish in under a couple of minutes.
+const (
+	chaosPollInterval = 5 * time.Second
+	chaosPollTimeout  = 10 * time.Minute
+)
+
+// awaitCompletion polls GetExperiment until experimentID reaches a
+// terminal state (completed, stopped, or failed) or chaosPollTimeout
+// elapses.
+func (e *Experiment) awaitCompletion(ctx context.Context, experimentID string) (string, error) {
+	deadline := time.Now().Add(chaosPollTimeout)
+	for {
+		got, err := e.runner.suite.FIS().GetExperiment(ctx, &fis.GetExperimentInput{Id: aws.String(experimentID)})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to poll FIS experiment %s", experimentID)
+		}
+
+		status := got.Experiment.State.Status
+		switch status {
+		case fistypes.ExperimentStatusCompleted, fistypes.ExperimentStatusStopped, fistypes.ExperimentStatusFailed:
+			return string(status), nil
+		}
+
+		if time.Now().After(deadline) {
+			return string(status), errors.Errorf("FIS experiment %s did not reach a terminal state within %s", experimentID, chaosPollTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return string(status), ctx.Err()
+		case <-time.After(chaosPollInterval):
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultAWSCostAnomalyStdDevK / defaultAWSCostAnomalyWoWThreshold are
+// CostAnalyzer's defaults, mirroring azure_cost_anomaly_test.go's
+// defaultAnomalyK but for a mean/stddev model instead of median/MAD -- Cost
+// Explorer's SERVICE/TAG grouping gives much shorter, noisier daily series
+// than Azure's per-resource-group usage details, so a standard-deviation
+// threshold plus an explicit week-over-week check catches what a single MAD
+// cutoff would miss.
+const (
+	defaultAWSCostAnomalyStdDevK      = 2.5
+	defaultAWSCostAnomalyWoWThreshold = 0.5 // 50% week-over-week growth
+	defaultCostAnomalyLookbackDays    = 30
+)
+
+// AWSDailyCost is one group's (a Cost Explorer SERVICE or TAG value) spend
+// for one calendar day -- the AWS counterpart to azure_cost_anomaly_test.go's
+// DailyCost, which is keyed by resource group instead.
+type AWSDailyCost struct {
+	Date    time.Time
+	Group   string
+	CostUSD float64
+}
+
+// AWSCostAnomaly flags a day where a group's spend exceeded its trailing
+// window's mean by more than K standard deviations, or grew week-over-week
+// by more than a configured threshold -- the AWS counterpart to
+// azure_cost_anomaly_test.go's CostAnomaly.
+type AWSCostAnomaly struct {
+	Group        string    `json:"group"`
+	Date         time.Time `json:"date"`
+	CostUSD      float64   `json:"cost_usd"`
+	MeanUSD      float64   `json:"mean_usd"`
+	StdDevUSD    float64   `json:"stddev_usd"`
+	WoWGrowthPct float64   `json:"wow_growth_pct,omitempty"`
+	Reason       string    `json:"reason"`
+	Correlation  string    `json:"correlation,omitempty"`
+}
+
+// CostAnalyzer pulls grouped daily spend from Cost Explorer and flags
+// anomalous days, optionally correlating them against this suite's other
+// resource inventory and publishing a CloudWatch custom metric.
+type CostAnalyzer struct {
+	suite *AWSTestSuite
+
+	StdDevK      float64
+	WoWThreshold float64
+}
+
+// NewCostAnalyzer returns a CostAnalyzer for suite using the default
+// thresholds; callers can override StdDevK/WoWThreshold before calling
+// DetectAnomalies.
+func NewCostAnalyzer(suite *AWSTestSuite) *CostAnalyzer {
+	return &CostAnalyzer{
+		suite:        suite,
+		StdDevK:      defaultAWSCostAnomalyStdDevK,
+		WoWThreshold: defaultAWSCostAnomalyWoWThreshold,
+	}
+}
+
+// FetchDailyCostsByService pulls per-day, per-service spend for the last
+// days days via GetCostAndUsage grouped by the SERVICE dimension.
+func (a *CostAnalyzer) FetchDailyCostsByService(days int) ([]AWSDailyCost, error) {
+	return a.fetchDailyCosts(days, cetypes.GroupDefinition{
+		Type: cetypes.GroupDefinitionTypeDimension,
+		Key:  aws.String("SERVICE"),
+	})
+}
+
+// FetchDailyCostsByTag pulls per-day spend grouped by tagKey's values for
+// the last days days, for anomaly detection scoped to a cost-allocation tag
+// (e.g. "Team" or "Environment") instead of service.
+func (a *CostAnalyzer) FetchDailyCostsByTag(tagKey string, days int) ([]AWSDailyCost, error) {
+	return a.fetchDailyCosts(days, cetypes.GroupDefinition{
+		Type: cetypes.GroupDefinitionTypeTag,
+		Key:  aws.String(tagKey),
+	})
+}
+
+// fetchDailyCosts pages through GetCostAndUsage for the last days days,
+// grouped by group, flattening every result into an AWSDailyCost per
+// (day, group key) pair.
+func (a *CostAnalyzer) fetchDailyCosts(days int, group cetypes.GroupDefinition) ([]AWSDailyCost, error) {
+	end := time.Now().UTC()
+	start := end.AddDate(0, 0, -days)
+
+	var costs []AWSDailyCost
+	var nextPageToken *string
+	for {
+		output, err := a.suite.CostExplorer().GetCostAndUsage(a.suite.Context, &costexplorer.GetCostAndUsageInput{
+			TimePeriod: &cetypes.DateInterval{
+				Start: aws.String(start.Format("2006-01-02")),
+				End:   aws.String(end.Format("2006-01-02")),
+			},
+			Granularity:   cetypes.GranularityDaily,
+			Metrics:       []string{"BlendedCost"},
+			GroupBy:       []cetypes.GroupDefinition{group},
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query Cost Explorer for daily grouped spend")
+		}
+
+		for _, result := range output.ResultsByTime {
+			if result.TimePeriod == nil || result.TimePeriod.Start == nil {
+				continue
+			}
+			date, err := time.Parse("2006-01-02", *result.TimePeriod.Start)
+			if err != nil {
+				continue
+			}
+			for _, g := range result.Groups {
+				if len(g.Keys) == 0 {
+					continue
+				}
+				metric, ok := g.Metrics["BlendedCost"]
+				if !ok || metric.Amount == nil {
+					continue
+				}
+				var amount float64
+				if _, err := fmt.Sscanf(*metric.Amount, "%f", &amount); err != nil {
+					continue
+				}
+				costs = append(costs, AWSDailyCost{Date: date, Group: g.Keys[0], CostUSD: amount})
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		nextPageToken = output.NextPageToken
+	}
+
+	return costs, nil
+}
+
+// DetectAnomalies groups costs by Group, computes each group's mean and
+// standard deviation across the whole series, and flags every day whose
+// spend exceeds mean + a.StdDevK*stddev or whose spend grew more than
+// a.WoWThreshold over the same group's cost exactly 7 days earlier.
+func (a *CostAnalyzer) DetectAnomalies(costs []AWSDailyCost) []AWSCostAnomaly {
+	byGroup := make(map[string][]AWSDailyCost)
+	for _, c := range costs {
+		byGroup[c.Group] = append(byGroup[c.Group], c)
+	}
+
+	var anomalies []AWSCostAnomaly
+	for group, series := range byGroup {
+		sort.Slice(series, func(i, j int) bool { return series[i].Date.Before(series[j].Date) })
+
+		values := make([]float64, len(series))
+		for i, c := range series {
+			values[i] = c.CostUSD
+		}
+		mean, stddev := meanAndStdDev(values)
+		threshold := mean + a.StdDevK*stddev
+
+		for i, c := range series {
+			if stddev > 0 && c.CostUSD > threshold {
+				anomalies = append(anomalies, AWSCostAnomaly{
+					Group: group, Date: c.Date, CostUSD: c.CostUSD,
+					MeanUSD: mean, StdDevUSD: stddev, Reason: "stddev",
+				})
+				continue
+			}
+
+			if i < 7 {
+				continue
+			}
+			prior := series[i-7].CostUSD
+			if prior <= 0 {
+				continue
+			}
+			growth := (c.CostUSD - prior) / prior
+			if growth > a.WoWThreshold {
+				anomalies = append(anomalies, AWSCostAnomaly{
+					Group: group, Date: c.Date, CostUSD: c.CostUSD,
+					MeanUSD: mean, StdDevUSD: stddev,
+					WoWGrowthPct: growth, Reason: "wow-growth",
+				})
+			}
+		}
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Date.Equal(anomalies[j].Date) {
+			return anomalies[i].Group < anomalies[j].Group
+		}
+		return anomalies[i].Date.Before(anomalies[j].Date)
+	})
+	return anomalies
+}
+
+// meanAndStdDev returns the population mean and standard deviation of
+// values, both zero for an empty slice.
+func meanAndStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		d := v - mean
+		variance += d * d
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
+
+// CorrelateAnomaly attempts to explain anomaly by cross-referencing this
+// suite's other resource inventory, the way the request calls out for a NAT
+// Gateway spend spike (newly allocated Elastic IPs) or an ECS spend spike
+// (active cluster/task-definition changes). Best-effort: a lookup failure
+// only leaves Correlation unset rather than failing the caller.
+func (a *CostAnalyzer) CorrelateAnomaly(anomaly *AWSCostAnomaly) {
+	switch {
+	case strings.Contains(anomaly.Group, "NAT Gateway"):
+		a.correlateNATGateway(anomaly)
+	case strings.Contains(anomaly.Group, "Elastic Container Service"), strings.Contains(anomaly.Group, "EC2 Container Service"):
+		a.correlateECS(anomaly)
+	}
+}
+
+// correlateNATGateway counts currently-associated Elastic IPs, a common
+// driver of NAT Gateway data-processing spend spikes when new EIPs route
+// outbound traffic through it.
+func (a *CostAnalyzer) correlateNATGateway(anomaly *AWSCostAnomaly) {
+	output, err := a.suite.EC2().DescribeAddresses(a.suite.Context, &ec2.DescribeAddressesInput{})
+	if err != nil {
+		a.suite.Logger.Warn().Err(err).Msg("cost analyzer: failed to describe Elastic IPs for NAT Gateway correlation")
+		return
+	}
+
+	var associated int
+	for _, address := range output.Addresses {
+		if address.AssociationId != nil {
+			associated++
+		}
+	}
+	if associated > 0 {
+		anomaly.Correlation = fmt.Sprintf("%d Elastic IPs currently associated; check whether any were allocated around %s", associated, anomaly.Date.Format("2006-01-02"))
+	}
+}
+
+// correlateECS counts active ECS clusters, flagging that task-definition
+// CPU/memory changes are worth checking as the likely cause of an ECS
+// spend spike.
+func (a *CostAnalyzer) correlateECS(anomaly *AWSCostAnomaly) {
+	output, err := a.suite.ECS().ListClusters(a.suite.Context, &ecs.ListClustersInput{})
+	if err != nil {
+		a.suite.Logger.Warn().Err(err).Msg("cost analyzer: failed to list ECS clusters for correlation")
+		return
+	}
+	if len(output.ClusterArns) == 0 {
+		return
+	}
+	anomaly.Correlation = fmt.Sprintf("%d ECS clusters active; check task-definition CPU/memory changes around %s", len(output.ClusterArns), anomaly.Date.Format("2006-01-02"))
+}
+
+// AWSCostAnomalyReport is CostAnalyzer's JSON findings output -- the AWS
+// counterpart to azure_cost_anomaly_test.go's CostManagementArtifact.
+type AWSCostAnomalyReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Anomalies   []AWSCostAnomaly `json:"anomalies"`
+}
+
+// WriteFindings serializes anomalies as JSON to path.
+func (a *CostAnalyzer) WriteFindings(path string, anomalies []AWSCostAnomaly) error {
+	report := AWSCostAnomalyReport{GeneratedAt: time.Now().UTC(), Anomalies: anomalies}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost anomaly report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write cost anomaly report %s", path)
+	}
+	return nil
+}
+
+// awsCostAnomalyMetricNamespace/Name are the CloudWatch custom metric
+// PushAnomalyMetric publishes the anomaly count under.
+const (
+	awsCostAnomalyMetricNamespace = "LearningAssistant/CostAnomalies"
+	awsCostAnomalyMetricName      = "AnomalyCount"
+)
+
+// PushAnomalyMetric publishes len(anomalies) to CloudWatch as a custom
+// metric, so an anomaly count can be alarmed on the same way any other
+// operational metric in this account is.
+func (a *CostAnalyzer) PushAnomalyMetric(anomalies []AWSCostAnomaly) error {
+	_, err := a.suite.CloudWatch().PutMetricData(a.suite.Context, &cloudwatch.PutMetricDataInput{
+		Namespace: aws.String(awsCostAnomalyMetricNamespace),
+		MetricData: []cwtypes.MetricDatum{
+			{
+				MetricName: aws.String(awsCostAnomalyMetricName),
+				Value:      aws.Float64(float64(len(anomalies))),
+				Unit:       cwtypes.StandardUnitCount,
+				Timestamp:  aws.Time(time.Now().UTC()),
+			},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to push cost anomaly metric to CloudWatch")
+	}
+	return nil
+}
+
+// RequireWithinGuardrails fails t for every anomaly whose spend exceeds
+// suite.Config.CostLimits.MaxDailyCost, the same budget guardrail
+// CostGuard/PreflightBudget enforce elsewhere in this suite.
+func (a *CostAnalyzer) RequireWithinGuardrails(t *testing.T, anomalies []AWSCostAnomaly) {
+	limit := a.suite.Config.CostLimits.MaxDailyCost
+	for _, anomaly := range anomalies {
+		assert.LessOrEqualf(t, anomaly.CostUSD, limit,
+			"cost anomaly for %s on %s ($%.2f) exceeds the $%.2f daily budget guardrail",
+			anomaly.Group, anomaly.Date.Format("2006-01-02"), anomaly.CostUSD, limit)
+	}
+}
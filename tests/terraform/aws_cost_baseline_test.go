@@ -0,0 +1,218 @@
+package test
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/budgets"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// defaultCostBaselineMonths/StdDevK are CostAnomalyBaseline's defaults --
+// a 6-month trailing window and a 2.0 standard-deviation threshold, looser
+// than CostAnalyzer's daily 2.5 since a monthly series has far fewer, far
+// noisier points to average over. defaultBudgetWarningThreshold is
+// AssertBudgetHealth's default: flag a budget once actual or forecasted
+// spend crosses 80% of its limit.
+const (
+	defaultCostBaselineMonths     = 6
+	defaultCostBaselineStdDevK    = 2.0
+	defaultBudgetWarningThreshold = 0.8
+)
+
+// CostAnomalyBaseline is a per-service, trailing-month cost baseline -- the
+// month-grained counterpart to aws_cost_anomaly_test.go's CostAnalyzer,
+// for TestCost's "Cost Explorer" subtest, which today only dumps the latest
+// month's BlendedCost without comparing it against history.
+type CostAnomalyBaseline struct {
+	suite *AWSTestSuite
+
+	Months  int
+	StdDevK float64
+}
+
+// NewCostAnomalyBaseline returns a CostAnomalyBaseline for suite using the
+// default trailing window and threshold; callers can override Months/StdDevK
+// before calling RequireCurrentPeriodWithinBaseline.
+func NewCostAnomalyBaseline(suite *AWSTestSuite) *CostAnomalyBaseline {
+	return &CostAnomalyBaseline{suite: suite, Months: defaultCostBaselineMonths, StdDevK: defaultCostBaselineStdDevK}
+}
+
+// FetchMonthlyCostsByService pulls b.Months trailing months of per-service
+// BlendedCost via GetCostAndUsage grouped by the SERVICE dimension, one
+// AWSDailyCost per (month, service) pair -- reusing AWSDailyCost since its
+// shape (a group's spend at a point in time) fits a month just as well as a
+// day.
+func (b *CostAnomalyBaseline) FetchMonthlyCostsByService() ([]AWSDailyCost, error) {
+	months := b.Months
+	if months <= 0 {
+		months = defaultCostBaselineMonths
+	}
+
+	end := time.Now().UTC()
+	start := end.AddDate(0, -months, 0)
+
+	var costs []AWSDailyCost
+	var nextPageToken *string
+	for {
+		output, err := b.suite.CostExplorer().GetCostAndUsage(b.suite.Context, &costexplorer.GetCostAndUsageInput{
+			TimePeriod: &cetypes.DateInterval{
+				Start: aws.String(start.Format("2006-01-02")),
+				End:   aws.String(end.Format("2006-01-02")),
+			},
+			Granularity: cetypes.GranularityMonthly,
+			Metrics:     []string{"BlendedCost"},
+			GroupBy: []cetypes.GroupDefinition{
+				{Type: cetypes.GroupDefinitionTypeDimension, Key: aws.String("SERVICE")},
+			},
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query Cost Explorer for monthly grouped spend")
+		}
+
+		for _, result := range output.ResultsByTime {
+			if result.TimePeriod == nil || result.TimePeriod.Start == nil {
+				continue
+			}
+			month, err := time.Parse("2006-01-02", *result.TimePeriod.Start)
+			if err != nil {
+				continue
+			}
+			for _, g := range result.Groups {
+				if len(g.Keys) == 0 {
+					continue
+				}
+				metric, ok := g.Metrics["BlendedCost"]
+				if !ok || metric.Amount == nil {
+					continue
+				}
+				var amount float64
+				if _, err := fmt.Sscanf(*metric.Amount, "%f", &amount); err != nil {
+					continue
+				}
+				costs = append(costs, AWSDailyCost{Date: month, Group: g.Keys[0], CostUSD: amount})
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		nextPageToken = output.NextPageToken
+	}
+
+	return costs, nil
+}
+
+// RequireCurrentPeriodWithinBaseline groups costs by service, computes each
+// service's mean and standard deviation across its whole trailing window,
+// and fails t for any service whose most recent month exceeds
+// mean + b.StdDevK*stddev.
+func (b *CostAnomalyBaseline) RequireCurrentPeriodWithinBaseline(t *testing.T, costs []AWSDailyCost) {
+	byService := make(map[string][]AWSDailyCost)
+	for _, c := range costs {
+		byService[c.Group] = append(byService[c.Group], c)
+	}
+
+	for service, series := range byService {
+		sort.Slice(series, func(i, j int) bool { return series[i].Date.Before(series[j].Date) })
+
+		values := make([]float64, len(series))
+		for i, c := range series {
+			values[i] = c.CostUSD
+		}
+		mean, stddev := meanAndStdDev(values)
+		if stddev <= 0 {
+			continue
+		}
+		threshold := mean + b.StdDevK*stddev
+
+		current := series[len(series)-1]
+		assert.LessOrEqualf(t, current.CostUSD, threshold,
+			"%s's current month ($%.2f) exceeds its %d-month baseline of mean $%.2f + %.1f stddev ($%.2f)",
+			service, current.CostUSD, len(series), mean, b.StdDevK, threshold)
+	}
+}
+
+// AssertBudgetHealth fails t for every budget in this account whose actual
+// spend, or DescribeBudgetPerformanceHistory's latest forecasted spend,
+// exceeds warningThreshold (e.g. 0.8 for 80%) of its BudgetLimit. A
+// restricted or empty budgets list only logs a warning, consistent with
+// TestCost's existing "Budgets" subtest.
+func (suite *AWSTestSuite) AssertBudgetHealth(t *testing.T, warningThreshold float64) {
+	identity, err := suite.STS().GetCallerIdentity(suite.Context, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		suite.Logger.Warn().Err(err).Msg("unable to resolve account ID for budget health check")
+		return
+	}
+	accountID := aws.ToString(identity.Account)
+
+	budgetList, err := suite.Budgets().DescribeBudgets(suite.Context, &budgets.DescribeBudgetsInput{
+		AccountId: aws.String(accountID),
+	})
+	if err != nil {
+		suite.Logger.Warn().Err(err).Msg("unable to access budgets for budget health check")
+		return
+	}
+
+	for _, budget := range budgetList.Budgets {
+		if budget.BudgetLimit == nil || budget.BudgetLimit.Amount == nil {
+			continue
+		}
+		limit, err := strconv.ParseFloat(*budget.BudgetLimit.Amount, 64)
+		if err != nil || limit <= 0 {
+			continue
+		}
+		name := aws.ToString(budget.BudgetName)
+
+		if budget.CalculatedSpend != nil && budget.CalculatedSpend.ActualSpend != nil && budget.CalculatedSpend.ActualSpend.Amount != nil {
+			if actual, err := strconv.ParseFloat(*budget.CalculatedSpend.ActualSpend.Amount, 64); err == nil {
+				assert.LessOrEqualf(t, actual/limit, warningThreshold,
+					"budget %q actual spend $%.2f is %.0f%% of its $%.2f limit, over the %.0f%% warning threshold",
+					name, actual, (actual/limit)*100, limit, warningThreshold*100)
+			}
+		}
+
+		forecast, err := suite.forecastedBudgetSpend(accountID, name)
+		if err != nil {
+			suite.Logger.Warn().Err(err).Str("budget", name).Msg("unable to fetch budget performance history for forecast")
+			continue
+		}
+		if forecast <= 0 {
+			continue
+		}
+		assert.LessOrEqualf(t, forecast/limit, warningThreshold,
+			"budget %q forecasted spend $%.2f is %.0f%% of its $%.2f limit, over the %.0f%% warning threshold",
+			name, forecast, (forecast/limit)*100, limit, warningThreshold*100)
+	}
+}
+
+// forecastedBudgetSpend returns budgetName's latest forecasted spend via
+// DescribeBudgetPerformanceHistory, 0 if no forecast is available yet.
+func (suite *AWSTestSuite) forecastedBudgetSpend(accountID, budgetName string) (float64, error) {
+	output, err := suite.Budgets().DescribeBudgetPerformanceHistory(suite.Context, &budgets.DescribeBudgetPerformanceHistoryInput{
+		AccountId:  aws.String(accountID),
+		BudgetName: aws.String(budgetName),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to describe budget performance history for %s", budgetName)
+	}
+	if output.BudgetPerformanceHistory == nil || len(output.BudgetPerformanceHistory.BudgetedAndActualAmountsList) == 0 {
+		return 0, nil
+	}
+
+	latest := output.BudgetPerformanceHistory.BudgetedAndActualAmountsList[len(output.BudgetPerformanceHistory.BudgetedAndActualAmountsList)-1]
+	if latest.ForecastedAmount == nil || latest.ForecastedAmount.Amount == nil {
+		return 0, nil
+	}
+	return strconv.ParseFloat(*latest.ForecastedAmount.Amount, 64)
+}
@@ -0,0 +1,469 @@
+package test
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/budgets"
+	budgetstypes "github.com/aws/aws-sdk-go-v2/service/budgets/types"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	pricingtypes "github.com/aws/aws-sdk-go-v2/service/pricing/types"
+
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// pricingServiceCodes maps the resourceType strings EstimateResourceCost
+// accepts to the AWS Price List service code filtering GetProducts by.
+// Scoped to the services the suite already has accessors for
+// (EC2/RDS/S3/Lambda/ELB in aws_test.go); add an entry here alongside a new
+// accessor rather than guessing a code for a service CostGuard can't yet
+// query elsewhere.
+var pricingServiceCodes = map[string]string{
+	"ec2":    "AmazonEC2",
+	"rds":    "AmazonRDS",
+	"s3":     "AmazonS3",
+	"lambda": "AWSLambda",
+	"elb":    "AWSELB",
+}
+
+// skuPriceLRU is a fixed-capacity, least-recently-used cache of SKU ->
+// on-demand USD price, so EstimateResourceCost doesn't re-call
+// pricing.GetProducts (and risk its rate limit) for the same instance
+// type/region pair within a test run.
+type skuPriceLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type skuPriceEntry struct {
+	key   string
+	price float64
+}
+
+func newSKUPriceLRU(capacity int) *skuPriceLRU {
+	return &skuPriceLRU{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *skuPriceLRU) get(key string) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*skuPriceEntry).price, true
+}
+
+func (c *skuPriceLRU) put(key string, price float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		element.Value.(*skuPriceEntry).price = price
+		c.order.MoveToFront(element)
+		return
+	}
+
+	element := c.order.PushFront(&skuPriceEntry{key: key, price: price})
+	c.items[key] = element
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*skuPriceEntry).key)
+		}
+	}
+}
+
+// defaultSKUPriceCacheSize bounds skuPriceLRU so a long-running suite with
+// many distinct instance types/regions can't grow the cache unbounded.
+const defaultSKUPriceCacheSize = 256
+
+// CostDeltaReport is CostGuard.PostTeardownReport's output: what a test run
+// was estimated to cost going in, and what Cost Explorer says it actually
+// cost once billing data settles.
+type CostDeltaReport struct {
+	TestID       string    `json:"testId"`
+	GeneratedAt  time.Time `json:"generatedAt"`
+	BaselineUSD  float64   `json:"baselineUsd"`
+	EstimatedUSD float64   `json:"estimatedUsd"`
+	ActualUSD    float64   `json:"actualUsd"`
+	DeltaUSD     float64   `json:"deltaUsd"`
+	Settled      bool      `json:"settled"`
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *CostDeltaReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost delta report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write cost delta report %s", path)
+	}
+	return nil
+}
+
+// CostGuard tracks a test run's AWS spend: a baseline recorded at
+// construction, a running total of EstimateResourceCost calls enforced
+// against MaxEstimatedCostUSD, and -- once Cost Explorer's billing data
+// catches up -- an actual-vs-estimated delta report.
+type CostGuard struct {
+	suite               *AWSTestSuite
+	MaxEstimatedCostUSD float64
+
+	mu           sync.Mutex
+	baselineUSD  float64
+	estimatedUSD float64
+
+	priceCache *skuPriceLRU
+}
+
+// NewCostGuard records suite's current TestID-tagged spend as the baseline
+// and returns a CostGuard enforcing maxEstimatedCostUSD against costs
+// estimated from there.
+func NewCostGuard(suite *AWSTestSuite, maxEstimatedCostUSD float64) (*CostGuard, error) {
+	guard := &CostGuard{
+		suite:               suite,
+		MaxEstimatedCostUSD: maxEstimatedCostUSD,
+		priceCache:          newSKUPriceLRU(defaultSKUPriceCacheSize),
+	}
+
+	baseline, err := guard.spendForTestID()
+	if err != nil {
+		return nil, err
+	}
+	guard.baselineUSD = baseline
+	return guard, nil
+}
+
+// spendForTestID sums BlendedCost over the last day for resources tagged
+// with this suite's TestID, via costexplorer.GetCostAndUsage.
+func (guard *CostGuard) spendForTestID() (float64, error) {
+	end := time.Now()
+	start := end.AddDate(0, 0, -1)
+
+	output, err := guard.suite.CostExplorer().GetCostAndUsage(guard.suite.Context, &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &cetypes.DateInterval{
+			Start: aws.String(start.Format("2006-01-02")),
+			End:   aws.String(end.Format("2006-01-02")),
+		},
+		Granularity: cetypes.GranularityDaily,
+		Metrics:     []string{"BlendedCost"},
+		Filter: &cetypes.Expression{
+			Tags: &cetypes.TagValues{
+				Key:    aws.String("TestID"),
+				Values: []string{guard.suite.TestID},
+			},
+		},
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query Cost Explorer for TestID spend")
+	}
+
+	var total float64
+	for _, result := range output.ResultsByTime {
+		cost, ok := result.Total["BlendedCost"]
+		if !ok || cost.Amount == nil {
+			continue
+		}
+		var amount float64
+		if _, err := fmt.Sscanf(*cost.Amount, "%f", &amount); err == nil {
+			total += amount
+		}
+	}
+	return total, nil
+}
+
+// pricingClient returns the suite's Pricing client. The Price List API is
+// only served from us-east-1 (and ap-south-1), regardless of suite.Region,
+// so this builds its own regional client rather than using the suite's
+// Region-keyed awsClient helper.
+func (suite *AWSTestSuite) pricingClient() *pricing.Client {
+	key := "pricing/us-east-1"
+	if cached, ok := suite.clients.Load(key); ok {
+		return cached.(*pricing.Client)
+	}
+	regionalConfig := suite.AWSConfig.Copy()
+	regionalConfig.Region = "us-east-1"
+	client := pricing.NewFromConfig(regionalConfig)
+	actual, _ := suite.clients.LoadOrStore(key, client)
+	return actual.(*pricing.Client)
+}
+
+// skuCacheKey builds a deterministic skuPriceLRU key for resourceType and
+// attrs from a fixed, ordered list of fields, rather than ranging over attrs
+// directly -- map iteration order isn't stable, so that would scatter the
+// same SKU across multiple cache entries.
+func skuCacheKey(resourceType string, attrs map[string]string) string {
+	key := resourceType
+	for _, field := range []string{"instanceType", "region", "storageClass", "databaseEngine"} {
+		if value, ok := attrs[field]; ok {
+			key += "/" + field + "=" + value
+		}
+	}
+	return key
+}
+
+// EstimateResourceCost resolves resourceType's on-demand USD price via
+// pricing.GetProducts, filtering on attrs (e.g. {"instanceType": "m5.large",
+// "region": "US East (N. Virginia)"}), caching the result in guard's
+// skuPriceLRU so repeated estimates for the same SKU don't re-hit the
+// Pricing API.
+func (guard *CostGuard) EstimateResourceCost(resourceType string, attrs map[string]string) (float64, error) {
+	cacheKey := skuCacheKey(resourceType, attrs)
+	if price, ok := guard.priceCache.get(cacheKey); ok {
+		return price, nil
+	}
+
+	serviceCode, ok := pricingServiceCodes[resourceType]
+	if !ok {
+		return 0, errors.Errorf("no pricing service code configured for resource type %q", resourceType)
+	}
+
+	filters := make([]pricingtypes.Filter, 0, len(attrs))
+	for field, value := range attrs {
+		filters = append(filters, pricingtypes.Filter{
+			Type:  pricingtypes.FilterTypeTermMatch,
+			Field: aws.String(field),
+			Value: aws.String(value),
+		})
+	}
+
+	output, err := guard.suite.pricingClient().GetProducts(guard.suite.Context, &pricing.GetProductsInput{
+		ServiceCode: aws.String(serviceCode),
+		Filters:     filters,
+		MaxResults:  aws.Int32(1),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to fetch pricing for %s", resourceType)
+	}
+	if len(output.PriceList) == 0 {
+		return 0, errors.Errorf("no pricing products matched %s with attrs %v", resourceType, attrs)
+	}
+
+	price, err := parseOnDemandPrice(output.PriceList[0])
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse pricing product for %s", resourceType)
+	}
+
+	guard.priceCache.put(cacheKey, price)
+	return price, nil
+}
+
+// pricingProduct is the subset of a Price List GetProducts document
+// EstimateResourceCost needs: the first OnDemand term's first price
+// dimension's USD unit price.
+type pricingProduct struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// parseOnDemandPrice extracts the USD on-demand unit price from raw, a JSON
+// Price List product document.
+func parseOnDemandPrice(raw string) (float64, error) {
+	var product pricingProduct
+	if err := json.Unmarshal([]byte(raw), &product); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal pricing product")
+	}
+
+	for _, term := range product.Terms.OnDemand {
+		for _, dimension := range term.PriceDimensions {
+			usd, ok := dimension.PricePerUnit["USD"]
+			if !ok {
+				continue
+			}
+			var price float64
+			if _, err := fmt.Sscanf(usd, "%f", &price); err != nil {
+				return 0, errors.Wrap(err, "failed to parse USD price")
+			}
+			return price, nil
+		}
+	}
+	return 0, errors.New("no OnDemand price dimension found in pricing product")
+}
+
+// RequireWithinBudget estimates resourceType's cost via EstimateResourceCost
+// and fails t immediately (require-style) if adding it to guard's running
+// estimate would exceed MaxEstimatedCostUSD, before the caller creates
+// anything. On success it adds the estimate to the running total and
+// returns it.
+func (guard *CostGuard) RequireWithinBudget(t *testing.T, resourceType string, attrs map[string]string) float64 {
+	cost, err := guard.EstimateResourceCost(resourceType, attrs)
+	require.NoError(t, err, "failed to estimate cost for %s", resourceType)
+
+	guard.mu.Lock()
+	projected := guard.estimatedUSD + cost
+	guard.mu.Unlock()
+
+	require.LessOrEqualf(t, projected, guard.MaxEstimatedCostUSD,
+		"creating %s (est. $%.4f) would push this test's estimated cost to $%.4f, over its $%.2f budget",
+		resourceType, cost, projected, guard.MaxEstimatedCostUSD)
+
+	guard.mu.Lock()
+	guard.estimatedUSD = projected
+	guard.mu.Unlock()
+
+	return cost
+}
+
+// postTeardownSettleAttempts/Interval bound PostTeardownReport's wait for
+// Cost Explorer's billing data to stop being marked Estimated. Real bills
+// can take up to ~24h to fully settle; a CI job calling this is expected to
+// run on its own schedule well after teardown, so these defaults only cover
+// the last mile of that lag, not the full 24h.
+const (
+	postTeardownSettleAttempts = 5
+	postTeardownSettleInterval = 30 * time.Second
+)
+
+// PostTeardownReport polls Cost Explorer (with retry, since billing data
+// lags real usage) for this TestID's actual spend, and writes a
+// CostDeltaReport comparing it against guard's baseline and running
+// estimate to reportPath.
+func (guard *CostGuard) PostTeardownReport(t *testing.T, reportPath string) (*CostDeltaReport, error) {
+	var actualUSD float64
+	var settled bool
+
+	_, retryErr := retry.DoWithRetryE(t, "cost-explorer-settle", postTeardownSettleAttempts, postTeardownSettleInterval,
+		func() (string, error) {
+			spend, estimated, err := guard.actualSpendForTestID()
+			if err != nil {
+				return "", err
+			}
+			actualUSD = spend
+			settled = !estimated
+			if estimated {
+				return "", errors.New("Cost Explorer data for this TestID is still marked estimated")
+			}
+			return "", nil
+		})
+
+	report := &CostDeltaReport{
+		TestID:       guard.suite.TestID,
+		GeneratedAt:  time.Now(),
+		BaselineUSD:  guard.baselineUSD,
+		EstimatedUSD: guard.estimatedUSD,
+		ActualUSD:    actualUSD,
+		DeltaUSD:     actualUSD - guard.estimatedUSD,
+		Settled:      settled,
+	}
+
+	if writeErr := report.WriteReport(reportPath); writeErr != nil {
+		return report, writeErr
+	}
+	return report, retryErr
+}
+
+// actualSpendForTestID returns this TestID's UnblendedCost total and
+// whether Cost Explorer still considers any of it an estimate.
+func (guard *CostGuard) actualSpendForTestID() (float64, bool, error) {
+	end := time.Now()
+	start := end.AddDate(0, 0, -1)
+
+	output, err := guard.suite.CostExplorer().GetCostAndUsage(guard.suite.Context, &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &cetypes.DateInterval{
+			Start: aws.String(start.Format("2006-01-02")),
+			End:   aws.String(end.Format("2006-01-02")),
+		},
+		Granularity: cetypes.GranularityDaily,
+		Metrics:     []string{"UnblendedCost"},
+		Filter: &cetypes.Expression{
+			Tags: &cetypes.TagValues{
+				Key:    aws.String("TestID"),
+				Values: []string{guard.suite.TestID},
+			},
+		},
+	})
+	if err != nil {
+		return 0, false, errors.Wrap(err, "failed to query Cost Explorer for actual TestID spend")
+	}
+
+	var total float64
+	var estimated bool
+	for _, result := range output.ResultsByTime {
+		if result.Estimated {
+			estimated = true
+		}
+		cost, ok := result.Total["UnblendedCost"]
+		if !ok || cost.Amount == nil {
+			continue
+		}
+		var amount float64
+		if _, err := fmt.Sscanf(*cost.Amount, "%f", &amount); err == nil {
+			total += amount
+		}
+	}
+	return total, estimated, nil
+}
+
+// CreateBudgetAlert creates an AWS Budgets budget, scoped to this TestID via
+// a cost-allocation tag filter, that alerts notificationEmail once spend
+// crosses thresholdPercent of MaxEstimatedCostUSD -- a hard AWS-side
+// backstop alongside RequireWithinBudget's in-process one. accountID is the
+// account to create the budget in (see TestCost's "Budgets" subtest for why
+// this suite doesn't resolve it automatically).
+func (guard *CostGuard) CreateBudgetAlert(accountID, notificationEmail string, thresholdPercent float64) error {
+	budget := budgetstypes.Budget{
+		BudgetName: aws.String("test-" + guard.suite.TestID),
+		BudgetLimit: &budgetstypes.Spend{
+			Amount: aws.String(fmt.Sprintf("%.2f", guard.MaxEstimatedCostUSD)),
+			Unit:   aws.String("USD"),
+		},
+		TimeUnit:   budgetstypes.TimeUnitDaily,
+		BudgetType: budgetstypes.BudgetTypeCost,
+		CostFilters: map[string][]string{
+			"TagKeyValue": {"user:TestID$" + guard.suite.TestID},
+		},
+	}
+
+	var notifications []budgetstypes.NotificationWithSubscribers
+	if notificationEmail != "" {
+		notifications = append(notifications, budgetstypes.NotificationWithSubscribers{
+			Notification: &budgetstypes.Notification{
+				NotificationType:   budgetstypes.NotificationTypeActual,
+				ComparisonOperator: budgetstypes.ComparisonOperatorGreaterThan,
+				Threshold:          thresholdPercent,
+				ThresholdType:      budgetstypes.ThresholdTypePercentage,
+			},
+			Subscribers: []budgetstypes.Subscriber{{
+				SubscriptionType: budgetstypes.SubscriptionTypeEmail,
+				Address:          aws.String(notificationEmail),
+			}},
+		})
+	}
+
+	_, err := guard.suite.Budgets().CreateBudget(guard.suite.Context, &budgets.CreateBudgetInput{
+		AccountId:                    aws.String(accountID),
+		Budget:                       &budget,
+		NotificationsWithSubscribers: notifications,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create budget alert for TestID %s", guard.suite.TestID)
+	}
+	return nil
+}
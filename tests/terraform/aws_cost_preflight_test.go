@@ -0,0 +1,286 @@
+package test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/budgets"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// costCapOverride lets an operator cap a run's total spend from the command
+// line without editing test-config.yaml: go test ./tests/terraform/...
+// -cost-cap=5.00. Zero (the default) leaves PreflightBudget.EffectiveCostCap
+// falling back to suite.Config.CostLimits.MaxDailyCost.
+var costCapOverride = flag.Float64("cost-cap", 0,
+	"total USD spend (TestID-tagged) at which the cost watchdog force-terminates resources; 0 falls back to cost_limits.max_daily_cost")
+
+// ResourceDeclaration is one resource a test stage intends to create,
+// declared up front so PreflightBudget.CheckStage can price it via
+// CostGuard.EstimateResourceCost before the stage creates anything. Count
+// lets a stage that creates N identical resources (e.g. an ASG of m5.large
+// instances) declare it once rather than repeating Attrs N times.
+type ResourceDeclaration struct {
+	ResourceType string
+	Attrs        map[string]string
+	Count        int
+}
+
+// StageCostReport is PreflightBudget's per-stage entry in a
+// cost-attribution report: what a stage was estimated to cost going in,
+// and what share of this TestID's actual Cost Explorer spend it's
+// attributed after the run.
+type StageCostReport struct {
+	Stage        string  `json:"stage"`
+	EstimatedUSD float64 `json:"estimatedUsd"`
+	ActualUSD    float64 `json:"actualUsd"`
+}
+
+// PreflightBudget gates each test stage behind a pre-flight cost estimate --
+// computed from a ResourceDeclaration manifest before the stage creates
+// anything -- and, once a run is underway, a background watchdog that
+// force-terminates this TestID's tagged resources the moment actual spend
+// crosses a cap. It builds on CostGuard's per-SKU pricing and
+// ResourceTracker's tag-based cleanup rather than reimplementing either.
+type PreflightBudget struct {
+	suite     *AWSTestSuite
+	guard     *CostGuard
+	tracker   *ResourceTracker
+	accountID string
+
+	mu      sync.Mutex
+	reports []StageCostReport
+}
+
+// NewPreflightBudget returns a PreflightBudget for suite, pricing stages
+// through guard and, if the watchdog trips, cleaning up through tracker.
+// accountID is the account CheckStage checks for an active Budget ALARM in
+// (see CreateBudgetAlert for why this suite doesn't resolve it
+// automatically).
+func NewPreflightBudget(suite *AWSTestSuite, guard *CostGuard, tracker *ResourceTracker, accountID string) *PreflightBudget {
+	return &PreflightBudget{suite: suite, guard: guard, tracker: tracker, accountID: accountID}
+}
+
+// CheckStage prices every declaration in manifest via
+// CostGuard.EstimateResourceCost, sums it into an hourly estimate, and
+// fails t immediately -- before stage creates anything -- if that estimate
+// would push this run's hourly rate over suite.Config.CostLimits.MaxHourlyCost,
+// or if an existing Budget on pb.accountID is already in ALARM. On success
+// it records the estimate under stage for WriteAttributionReport and
+// returns it.
+func (pb *PreflightBudget) CheckStage(t *testing.T, stage string, manifest []ResourceDeclaration) float64 {
+	pb.requireNoActiveBudgetAlarm(t)
+
+	var hourly float64
+	for _, decl := range manifest {
+		cost, err := pb.guard.EstimateResourceCost(decl.ResourceType, decl.Attrs)
+		require.NoError(t, err, "failed to estimate cost for stage %s resource %s", stage, decl.ResourceType)
+
+		count := decl.Count
+		if count == 0 {
+			count = 1
+		}
+		hourly += cost * float64(count)
+	}
+
+	maxHourly := pb.suite.Config.CostLimits.MaxHourlyCost
+	require.LessOrEqualf(t, hourly, maxHourly,
+		"stage %s would cost an estimated $%.4f/hr, over the $%.2f/hr budget", stage, hourly, maxHourly)
+
+	pb.mu.Lock()
+	pb.reports = append(pb.reports, StageCostReport{Stage: stage, EstimatedUSD: hourly})
+	pb.mu.Unlock()
+
+	return hourly
+}
+
+// requireNoActiveBudgetAlarm fails t if any Budget on pb.accountID is
+// already in ALARM -- i.e. its calculated actual spend has crossed its
+// limit -- since a stage that creates more resources on top of an
+// already-blown budget only makes the overrun worse. The Budgets API has no
+// first-class "alarm" status, so this treats ActualSpend >= BudgetLimit as
+// the AWS-side equivalent of one. Best-effort: a DescribeBudgets failure
+// (e.g. restricted access, as TestCost's "Budgets" subtest already allows
+// for) only logs a warning rather than failing t.
+func (pb *PreflightBudget) requireNoActiveBudgetAlarm(t *testing.T) {
+	output, err := pb.suite.Budgets().DescribeBudgets(pb.suite.Context, &budgets.DescribeBudgetsInput{
+		AccountId: aws.String(pb.accountID),
+	})
+	if err != nil {
+		pb.suite.Logger.Warn().Err(err).Msg("unable to check budget alarm state before stage")
+		return
+	}
+
+	for _, budget := range output.Budgets {
+		if budget.CalculatedSpend == nil || budget.CalculatedSpend.ActualSpend == nil || budget.BudgetLimit == nil {
+			continue
+		}
+		actual := parseSpendAmount(budget.CalculatedSpend.ActualSpend.Amount)
+		limit := parseSpendAmount(budget.BudgetLimit.Amount)
+		require.Falsef(t, limit > 0 && actual >= limit,
+			"budget %s is in ALARM (actual $%.2f >= limit $%.2f); refusing to start a new stage",
+			aws.ToString(budget.BudgetName), actual, limit)
+	}
+}
+
+// parseSpendAmount parses a Budgets Spend.Amount string (e.g. "123.45")
+// into a float64, returning 0 on a malformed or nil value rather than
+// erroring -- callers treat 0 as "no limit/spend known" rather than a
+// reason to fail.
+func parseSpendAmount(amount *string) float64 {
+	if amount == nil {
+		return 0
+	}
+	var parsed float64
+	fmt.Sscanf(*amount, "%f", &parsed)
+	return parsed
+}
+
+// EffectiveCostCap resolves the watchdog's total-spend cap: the -cost-cap
+// flag override if set, else suite.Config.CostLimits.MaxDailyCost as a
+// conservative default -- a run that's expected to finish well inside a day
+// shouldn't need its own dedicated config field just for the watchdog.
+func (pb *PreflightBudget) EffectiveCostCap() float64 {
+	if *costCapOverride > 0 {
+		return *costCapOverride
+	}
+	return pb.suite.Config.CostLimits.MaxDailyCost
+}
+
+// watchdogPollInterval bounds how often StartWatchdog re-checks actual
+// spend against its cap. Cost Explorer data itself lags real usage by
+// hours, so polling faster than this wouldn't catch an overrun any sooner --
+// it would just burn GetCostAndUsage calls.
+const watchdogPollInterval = 5 * time.Minute
+
+// StartWatchdog launches a background goroutine that polls this suite's
+// TestID-tagged actual spend every watchdogPollInterval and, the moment it
+// crosses capUSD, force-terminates every tagged resource via
+// pb.tracker.Cleanup -- a last-resort backstop for when CheckStage's
+// pre-flight estimates undershoot (e.g. spot price swings, a resource that
+// creates more than it declared). capUSD <= 0 disables the watchdog
+// entirely. Call the returned stop func once the run tears down cleanly on
+// its own, to cancel the watchdog before it polls again.
+func (pb *PreflightBudget) StartWatchdog(capUSD float64) (stop func()) {
+	if capUSD <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(pb.suite.Context)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(watchdogPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				spend, _, err := pb.guard.actualSpendForTestID()
+				if err != nil {
+					pb.suite.Logger.Warn().Err(err).Msg("cost watchdog failed to poll actual spend")
+					continue
+				}
+				if spend < capUSD {
+					continue
+				}
+
+				pb.suite.Logger.Error().
+					Float64("actual_usd", spend).
+					Float64("cap_usd", capUSD).
+					Msg("cost watchdog tripped; force-terminating TestID-tagged resources")
+				if _, cleanupErr := pb.tracker.Cleanup(); cleanupErr != nil {
+					pb.suite.Logger.Error().Err(cleanupErr).Msg("cost watchdog cleanup failed")
+				}
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// WriteAttributionReport resolves this TestID's actual Cost Explorer spend
+// and splits it across the recorded stages proportional to each stage's
+// estimate -- Cost Explorer only reports spend for the TestID as a whole,
+// not per stage, so proportional-to-estimate is the best attribution
+// available without per-stage cost-allocation tags -- then writes the
+// combined estimate/actual breakdown to both csvPath and jsonPath.
+func (pb *PreflightBudget) WriteAttributionReport(csvPath, jsonPath string) error {
+	pb.mu.Lock()
+	reports := make([]StageCostReport, len(pb.reports))
+	copy(reports, pb.reports)
+	pb.mu.Unlock()
+
+	actual, _, err := pb.guard.actualSpendForTestID()
+	if err != nil {
+		pb.suite.Logger.Warn().Err(err).Msg("failed to resolve actual spend for attribution report")
+	} else {
+		var totalEstimated float64
+		for _, report := range reports {
+			totalEstimated += report.EstimatedUSD
+		}
+		if totalEstimated > 0 {
+			for i := range reports {
+				reports[i].ActualUSD = actual * (reports[i].EstimatedUSD / totalEstimated)
+			}
+		}
+	}
+
+	if err := writeCostAttributionJSON(jsonPath, reports); err != nil {
+		return err
+	}
+	return writeCostAttributionCSV(csvPath, reports)
+}
+
+// writeCostAttributionJSON marshals reports to jsonPath as indented JSON.
+func writeCostAttributionJSON(path string, reports []StageCostReport) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost attribution report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write cost attribution report %s", path)
+	}
+	return nil
+}
+
+// writeCostAttributionCSV writes reports to path as a header row followed
+// by one row per stage.
+func writeCostAttributionCSV(path string, reports []StageCostReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create cost attribution report %s", path)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"stage", "estimated_usd", "actual_usd"}); err != nil {
+		return errors.Wrap(err, "failed to write cost attribution header")
+	}
+	for _, report := range reports {
+		row := []string{report.Stage, fmt.Sprintf("%.4f", report.EstimatedUSD), fmt.Sprintf("%.4f", report.ActualUSD)}
+		if err := writer.Write(row); err != nil {
+			return errors.Wrap(err, "failed to write cost attribution row")
+		}
+	}
+	return writer.Error()
+}
@@ -0,0 +1,340 @@
+package test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go-v2/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/sso"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/pkg/errors"
+)
+
+// CredentialProvider is anything that can produce aws.Credentials --
+// identical in shape to aws.CredentialsProvider, but named and documented
+// here so this file's implementations read as a deliberate, pluggable
+// chain rather than ad hoc wrappers. Every type below satisfies both.
+type CredentialProvider interface {
+	Retrieve(ctx context.Context) (aws.Credentials, error)
+}
+
+// IMDSv2CredentialProvider resolves instance-role credentials through
+// ec2rolecreds backed by an IMDSv2 (token-required) client. aws-sdk-go-v2's
+// imds client falls back to IMDSv1 if a token request fails; setting
+// EnableFallback to false pins it to v2-only, so a blocked token endpoint
+// or too-low hop limit fails loudly instead of silently downgrading to the
+// unauthenticated v1 path.
+type IMDSv2CredentialProvider struct {
+	provider *ec2rolecreds.Provider
+}
+
+// NewIMDSv2CredentialProvider returns an IMDSv2CredentialProvider using the
+// default IMDS endpoint.
+func NewIMDSv2CredentialProvider() *IMDSv2CredentialProvider {
+	client := imds.New(imds.Options{
+		EnableFallback: aws.FalseTernary,
+	})
+	provider := ec2rolecreds.New(func(o *ec2rolecreds.Options) {
+		o.Client = client
+	})
+	return &IMDSv2CredentialProvider{provider: provider}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *IMDSv2CredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrap(err, "failed to retrieve IMDSv2 instance role credentials")
+	}
+	return creds, nil
+}
+
+// AssumeRoleCredentialProvider assumes roleARN via sts.AssumeRole using
+// base's credentials, optionally scoped by an external ID and/or an MFA
+// serial + token (sts requires a fresh TOTP code per assume, so
+// mfaTokenProvider is called on every Retrieve that needs one).
+type AssumeRoleCredentialProvider struct {
+	base             CredentialProvider
+	region           string
+	roleARN          string
+	externalID       string
+	mfaSerial        string
+	mfaTokenProvider func() (string, error)
+}
+
+// NewAssumeRoleCredentialProvider returns an AssumeRoleCredentialProvider
+// that assumes roleARN in region using base's credentials. externalID and
+// mfaSerial are optional (pass "" to omit); mfaTokenProvider is required
+// when mfaSerial is set and ignored otherwise.
+func NewAssumeRoleCredentialProvider(base CredentialProvider, region, roleARN, externalID, mfaSerial string, mfaTokenProvider func() (string, error)) *AssumeRoleCredentialProvider {
+	return &AssumeRoleCredentialProvider{
+		base:             base,
+		region:           region,
+		roleARN:          roleARN,
+		externalID:       externalID,
+		mfaSerial:        mfaSerial,
+		mfaTokenProvider: mfaTokenProvider,
+	}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *AssumeRoleCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	baseCreds, err := p.base.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to retrieve base credentials for assuming %s", p.roleARN)
+	}
+
+	client := sts.NewFromConfig(aws.Config{
+		Region:      p.region,
+		Credentials: staticCredentialsProvider(baseCreds),
+	})
+
+	provider := stscreds.NewAssumeRoleProvider(client, p.roleARN, func(o *stscreds.AssumeRoleOptions) {
+		if p.externalID != "" {
+			o.ExternalID = aws.String(p.externalID)
+		}
+		if p.mfaSerial != "" {
+			o.SerialNumber = aws.String(p.mfaSerial)
+			o.TokenProvider = p.mfaTokenProvider
+		}
+	})
+
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to assume role %s", p.roleARN)
+	}
+	return creds, nil
+}
+
+// AssumeRoleWithWebIdentityCredentialProvider assumes roleARN using an OIDC
+// identity token -- the shape IRSA (EKS service accounts) and GitHub
+// Actions OIDC both use: a JWT written to (or fetched for) the process,
+// exchanged for role credentials via sts.AssumeRoleWithWebIdentity without
+// any long-lived AWS secret involved.
+type AssumeRoleWithWebIdentityCredentialProvider struct {
+	region         string
+	roleARN        string
+	sessionName    string
+	tokenRetriever stscreds.IdentityTokenRetriever
+}
+
+// NewAssumeRoleWithWebIdentityCredentialProvider returns a provider that
+// assumes roleARN in region as sessionName, reading the identity token via
+// tokenRetriever -- typically stscreds.IdentityTokenFile(path) for IRSA,
+// where path is $AWS_WEB_IDENTITY_TOKEN_FILE, or a custom
+// stscreds.IdentityTokenRetriever that fetches a GitHub Actions OIDC token.
+func NewAssumeRoleWithWebIdentityCredentialProvider(region, roleARN, sessionName string, tokenRetriever stscreds.IdentityTokenRetriever) *AssumeRoleWithWebIdentityCredentialProvider {
+	return &AssumeRoleWithWebIdentityCredentialProvider{
+		region:         region,
+		roleARN:        roleARN,
+		sessionName:    sessionName,
+		tokenRetriever: tokenRetriever,
+	}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *AssumeRoleWithWebIdentityCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	client := sts.NewFromConfig(aws.Config{Region: p.region})
+	provider := stscreds.NewWebIdentityRoleProvider(client, p.roleARN, p.tokenRetriever, func(o *stscreds.WebIdentityRoleOptions) {
+		if p.sessionName != "" {
+			o.RoleSessionName = p.sessionName
+		}
+	})
+
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to assume role %s with web identity", p.roleARN)
+	}
+	return creds, nil
+}
+
+// SSOCredentialProvider resolves credentials from a cached AWS SSO token --
+// the one `aws sso login` obtains through the ssooidc device-authorization
+// flow and writes to ~/.aws/sso/cache. This provider only consumes that
+// cache via ssocreds/sso.Client; it doesn't itself drive the interactive
+// device-code login.
+type SSOCredentialProvider struct {
+	region    string
+	startURL  string
+	accountID string
+	roleName  string
+}
+
+// NewSSOCredentialProvider returns an SSOCredentialProvider for the given
+// SSO start URL, account, and permission set role name.
+func NewSSOCredentialProvider(region, startURL, accountID, roleName string) *SSOCredentialProvider {
+	return &SSOCredentialProvider{region: region, startURL: startURL, accountID: accountID, roleName: roleName}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *SSOCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	client := sso.NewFromConfig(aws.Config{Region: p.region})
+	provider := ssocreds.New(client, p.accountID, p.roleName, p.startURL)
+
+	creds, err := provider.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to retrieve SSO credentials for %s/%s", p.accountID, p.roleName)
+	}
+	return creds, nil
+}
+
+// RoleChainCredentialProvider assumes a sequence of roles, each one using
+// the previous hop's credentials -- e.g.
+// ["arn:aws:iam::111:role/audit", "arn:aws:iam::222:role/deploy"] assumes
+// audit with base's credentials, then assumes deploy with audit's.
+type RoleChainCredentialProvider struct {
+	base     CredentialProvider
+	region   string
+	roleARNs []string
+}
+
+// NewRoleChainCredentialProvider returns a RoleChainCredentialProvider that
+// assumes each of roleARNs in order, starting from base's credentials.
+func NewRoleChainCredentialProvider(base CredentialProvider, region string, roleARNs []string) *RoleChainCredentialProvider {
+	return &RoleChainCredentialProvider{base: base, region: region, roleARNs: roleARNs}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *RoleChainCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	current := p.base
+	for _, roleARN := range p.roleARNs {
+		current = NewAssumeRoleCredentialProvider(current, p.region, roleARN, "", "", nil)
+	}
+
+	creds, err := current.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to walk role chain %v", p.roleARNs)
+	}
+	return creds, nil
+}
+
+// staticCredentialsProvider wraps a fully-resolved aws.Credentials value as
+// an aws.CredentialsProvider, so intermediate role-chain hops can configure
+// an sts.Client against the previous hop's credentials without re-deriving
+// them from scratch.
+func staticCredentialsProvider(creds aws.Credentials) aws.CredentialsProvider {
+	return credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken)
+}
+
+// cachingRefreshFraction is the fraction of a credential's lifetime
+// CachingCredentialProvider lets elapse before proactively refreshing --
+// i.e. it refreshes once 80% of the lifetime has passed, well before
+// expiry actually arrives.
+const cachingRefreshFraction = 0.8
+
+// CachingCredentialProvider wraps another CredentialProvider, caching its
+// result and proactively re-calling Retrieve once cachingRefreshFraction of
+// the cached credentials' lifetime has elapsed, rather than waiting for
+// them to actually expire (and risk an in-flight AWS call failing with an
+// expired-token error).
+type CachingCredentialProvider struct {
+	inner CredentialProvider
+
+	mu       sync.Mutex
+	cached   aws.Credentials
+	issuedAt time.Time
+}
+
+// NewCachingCredentialProvider wraps inner with proactive-refresh caching.
+func NewCachingCredentialProvider(inner CredentialProvider) *CachingCredentialProvider {
+	return &CachingCredentialProvider{inner: inner}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *CachingCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached.HasKeys() && !p.needsRefresh() {
+		return p.cached, nil
+	}
+
+	creds, err := p.inner.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, err
+	}
+
+	p.cached = creds
+	p.issuedAt = time.Now()
+	return creds, nil
+}
+
+// needsRefresh reports whether cachingRefreshFraction of p.cached's
+// lifetime has elapsed. Non-expiring credentials (Expires.IsZero(), e.g.
+// long-lived IAM user keys) never need a proactive refresh.
+func (p *CachingCredentialProvider) needsRefresh() bool {
+	if p.cached.Expires.IsZero() {
+		return false
+	}
+	lifetime := p.cached.Expires.Sub(p.issuedAt)
+	refreshAt := p.issuedAt.Add(time.Duration(float64(lifetime) * cachingRefreshFraction))
+	return time.Now().After(refreshAt)
+}
+
+// EnvCredentialProvider resolves static credentials from the
+// AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY/AWS_SESSION_TOKEN environment
+// variables on every Retrieve, so a rotated value takes effect without the
+// suite needing to be rebuilt.
+type EnvCredentialProvider struct{}
+
+// NewEnvCredentialProvider returns an EnvCredentialProvider.
+func NewEnvCredentialProvider() *EnvCredentialProvider {
+	return &EnvCredentialProvider{}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *EnvCredentialProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	accessKeyID := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretAccessKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKeyID == "" || secretAccessKey == "" {
+		return aws.Credentials{}, errors.New("AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY not set")
+	}
+	provider := credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, os.Getenv("AWS_SESSION_TOKEN"))
+	return provider.Retrieve(ctx)
+}
+
+// SharedCredentialsFileProvider resolves credentials for a named profile
+// out of the AWS shared credentials/config files (~/.aws/credentials,
+// ~/.aws/config, or Path/ConfigPaths if set), re-reading the file on every
+// Retrieve so an operator rotating the profile's keys on disk doesn't
+// require restarting the suite.
+type SharedCredentialsFileProvider struct {
+	Profile     string
+	ConfigPaths []string
+}
+
+// NewSharedCredentialsFileProvider returns a SharedCredentialsFileProvider
+// for profile, reading from the default shared config file locations.
+// configPaths overrides those locations when non-empty (e.g. to point at a
+// fixture file in a test).
+func NewSharedCredentialsFileProvider(profile string, configPaths ...string) *SharedCredentialsFileProvider {
+	return &SharedCredentialsFileProvider{Profile: profile, ConfigPaths: configPaths}
+}
+
+// Retrieve implements CredentialProvider.
+func (p *SharedCredentialsFileProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	var optFns []func(*config.LoadOptions) error
+	optFns = append(optFns, config.WithSharedConfigProfile(p.Profile))
+	if len(p.ConfigPaths) > 0 {
+		optFns = append(optFns, config.WithSharedConfigFiles(p.ConfigPaths))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to load shared config profile %q", p.Profile)
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return aws.Credentials{}, errors.Wrapf(err, "failed to retrieve credentials for shared config profile %q", p.Profile)
+	}
+	return creds, nil
+}
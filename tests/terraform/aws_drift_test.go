@@ -0,0 +1,186 @@
+package test
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+
+	"github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+)
+
+// awsDriftWhitelistedFields are the only attributes DiffDrift compares for
+// an aws_* resource, the AWS counterpart to azure_drift_test.go's
+// driftWhitelistedFields -- everything else (IDs, timestamps, computed-only
+// fields, ...) is expected to differ between state and the live resource
+// and is ignored.
+var awsDriftWhitelistedFields = []string{"allocated_storage", "engine_version", "scheme", "policy"}
+
+// ExtractManagedAWSResources walks state's root and child modules, returning
+// every aws_* resource with its whitelisted attributes normalized into the
+// same ManagedResource shape BuildAWSDriftInventory produces for live
+// resources. Resources are keyed by whichever identifying attribute their
+// type exposes (aws_db_instance's "identifier", aws_lb's "name", an IAM
+// policy's "name"), falling back to the Terraform address if none of those
+// are set, since "name" alone (what the Azure equivalent keys on) isn't
+// universal across AWS resource types.
+func ExtractManagedAWSResources(state *tfjson.State) []ManagedResource {
+	if state == nil || state.Values == nil || state.Values.RootModule == nil {
+		return nil
+	}
+
+	var resources []ManagedResource
+	var walk func(module *tfjson.StateModule)
+	walk = func(module *tfjson.StateModule) {
+		for _, r := range module.Resources {
+			attrs := map[string]interface{}{}
+			for _, field := range awsDriftWhitelistedFields {
+				v, ok := r.AttributeValues[field]
+				if !ok {
+					continue
+				}
+				if field == "policy" {
+					v = decodePolicyDocument(v)
+				}
+				attrs[field] = v
+			}
+
+			resources = append(resources, ManagedResource{
+				Address:    r.Address,
+				Type:       r.Type,
+				Name:       awsResourceIdentifyingName(r),
+				Attributes: attrs,
+			})
+		}
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+
+	return resources
+}
+
+// awsResourceIdentifyingName returns the attribute DiffDrift should match r
+// against its live counterpart by, trying the identifying attribute each
+// resource type this drift check covers actually has.
+func awsResourceIdentifyingName(r *tfjson.StateResource) string {
+	for _, field := range []string{"identifier", "name", "function_name", "cluster_name"} {
+		if v, ok := r.AttributeValues[field].(string); ok && v != "" {
+			return v
+		}
+	}
+	return r.Address
+}
+
+// decodePolicyDocument unmarshals a JSON policy document string into a
+// generic map so equalDriftValue's marshal-and-compare can ignore
+// whitespace/key-order differences between the Terraform-declared document
+// and the one AWS returns. Non-string or unparseable input is returned
+// unchanged, so the comparison still runs (and just reports drift) instead
+// of failing outright.
+func decodePolicyDocument(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+		return v
+	}
+	return decoded
+}
+
+// BuildAWSDriftInventory enumerates the live RDS instances, ALB/NLBs, and
+// customer-managed IAM policies TestDrift diffs against Terraform state,
+// covering the fields requests calling out RDS AllocatedStorage, ALB
+// Scheme, and IAM policy document drift specifically need.
+func BuildAWSDriftInventory(suite *AWSTestSuite) ([]ResourceGraphNode, error) {
+	var graph []ResourceGraphNode
+
+	instances, err := suite.RDS().DescribeDBInstances(suite.Context, &rds.DescribeDBInstancesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe RDS instances for drift")
+	}
+	for _, instance := range instances.DBInstances {
+		graph = append(graph, ResourceGraphNode{
+			Kind: "AWS::RDS::DBInstance",
+			Name: aws.ToString(instance.DBInstanceIdentifier),
+			Properties: map[string]interface{}{
+				"allocated_storage": instance.AllocatedStorage,
+				"engine_version":    aws.ToString(instance.EngineVersion),
+			},
+		})
+	}
+
+	lbPaginator := elbv2.NewDescribeLoadBalancersPaginator(suite.ELB(), &elbv2.DescribeLoadBalancersInput{})
+	for lbPaginator.HasMorePages() {
+		page, err := lbPaginator.NextPage(suite.Context)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe load balancers for drift")
+		}
+		for _, lb := range page.LoadBalancers {
+			graph = append(graph, ResourceGraphNode{
+				Kind: "AWS::ElasticLoadBalancingV2::LoadBalancer",
+				Name: aws.ToString(lb.LoadBalancerName),
+				Properties: map[string]interface{}{
+					"scheme": string(lb.Scheme),
+				},
+			})
+		}
+	}
+
+	policyNodes, err := buildIAMPolicyDriftNodes(suite)
+	if err != nil {
+		return nil, err
+	}
+	graph = append(graph, policyNodes...)
+
+	return graph, nil
+}
+
+// buildIAMPolicyDriftNodes lists every customer-managed IAM policy and
+// resolves each one's default version document, for the policy-document
+// drift the request calls for. AWS policies are returned with their
+// document URL-encoded, which decodePolicyDocument's json.Unmarshal can't
+// parse directly, so it's query-unescaped first.
+func buildIAMPolicyDriftNodes(suite *AWSTestSuite) ([]ResourceGraphNode, error) {
+	var nodes []ResourceGraphNode
+
+	paginator := iam.NewListPoliciesPaginator(suite.IAM(), &iam.ListPoliciesInput{Scope: iamtypes.PolicyScopeTypeLocal})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(suite.Context)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list IAM policies for drift")
+		}
+		for _, policy := range page.Policies {
+			version, err := suite.IAM().GetPolicyVersion(suite.Context, &iam.GetPolicyVersionInput{
+				PolicyArn: policy.Arn,
+				VersionId: policy.DefaultVersionId,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get policy version for %s", aws.ToString(policy.Arn))
+			}
+
+			document := aws.ToString(version.PolicyVersion.Document)
+			if decoded, err := url.QueryUnescape(document); err == nil {
+				document = decoded
+			}
+
+			nodes = append(nodes, ResourceGraphNode{
+				Kind: "AWS::IAM::Policy",
+				Name: aws.ToString(policy.PolicyName),
+				Properties: map[string]interface{}{
+					"policy": decodePolicyDocument(document),
+				},
+			})
+		}
+	}
+
+	return nodes, nil
+}
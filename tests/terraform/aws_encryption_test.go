@@ -0,0 +1,95 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/pkg/errors"
+)
+
+// EvaluateEncryption checks encryption-at-rest across every S3 bucket, RDS
+// instance, SNS topic and SQS queue this suite's account owns, plus the
+// account's EBS "encrypt by default" setting, and returns one violation
+// string per resource found unencrypted. A resource's own describe/get
+// error (e.g. a bucket this account no longer has permission on) is
+// recorded as a violation rather than failing the whole evaluation, since
+// one inaccessible resource shouldn't hide findings on the rest.
+func (suite *AWSTestSuite) EvaluateEncryption() ([]string, error) {
+	var violations []string
+
+	buckets, err := suite.S3().ListBuckets(suite.Context, &s3.ListBucketsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list S3 buckets")
+	}
+	for _, bucket := range buckets.Buckets {
+		name := aws.ToString(bucket.Name)
+		output, err := suite.S3().GetBucketEncryption(suite.Context, &s3.GetBucketEncryptionInput{Bucket: aws.String(name)})
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("S3 bucket %s: GetBucketEncryption failed: %v", name, err))
+			continue
+		}
+		if output.ServerSideEncryptionConfiguration == nil || len(output.ServerSideEncryptionConfiguration.Rules) == 0 {
+			violations = append(violations, fmt.Sprintf("S3 bucket %s has no default encryption configured", name))
+		}
+	}
+
+	instances, err := suite.RDS().DescribeDBInstances(suite.Context, &rds.DescribeDBInstancesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe RDS instances")
+	}
+	for _, instance := range instances.DBInstances {
+		if !aws.ToBool(instance.StorageEncrypted) {
+			violations = append(violations, fmt.Sprintf("RDS instance %s is not storage-encrypted", aws.ToString(instance.DBInstanceIdentifier)))
+		}
+	}
+
+	ebsDefault, err := suite.EC2().GetEbsEncryptionByDefault(suite.Context, &ec2.GetEbsEncryptionByDefaultInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get EBS encryption by default setting")
+	}
+	if !aws.ToBool(ebsDefault.EbsEncryptionByDefault) {
+		violations = append(violations, fmt.Sprintf("EBS encryption by default is not enabled in region %s", suite.Region))
+	}
+
+	topics, err := suite.SNS().ListTopics(suite.Context, &sns.ListTopicsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list SNS topics")
+	}
+	for _, topic := range topics.Topics {
+		arn := aws.ToString(topic.TopicArn)
+		attrs, err := suite.SNS().GetTopicAttributes(suite.Context, &sns.GetTopicAttributesInput{TopicArn: aws.String(arn)})
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("SNS topic %s: GetTopicAttributes failed: %v", arn, err))
+			continue
+		}
+		if attrs.Attributes["KmsMasterKeyId"] == "" {
+			violations = append(violations, fmt.Sprintf("SNS topic %s has no KMS key configured", arn))
+		}
+	}
+
+	queues, err := suite.SQS().ListQueues(suite.Context, &sqs.ListQueuesInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list SQS queues")
+	}
+	for _, queueURL := range queues.QueueUrls {
+		attrs, err := suite.SQS().GetQueueAttributes(suite.Context, &sqs.GetQueueAttributesInput{
+			QueueUrl:       aws.String(queueURL),
+			AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameKmsMasterKeyId},
+		})
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("SQS queue %s: GetQueueAttributes failed: %v", queueURL, err))
+			continue
+		}
+		if attrs.Attributes[string(sqstypes.QueueAttributeNameKmsMasterKeyId)] == "" {
+			violations = append(violations, fmt.Sprintf("SQS queue %s has no KMS key configured", queueURL))
+		}
+	}
+
+	return violations, nil
+}
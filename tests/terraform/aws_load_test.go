@@ -0,0 +1,172 @@
+package test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/awsload"
+)
+
+// awsLoadTargetFromOutputs builds the awsload.Target each of
+// TestLoadPerformance/TestStressPerformance/TestEndurancePerformance hits,
+// pointed at outputs["load_balancer_endpoint"] (falling back to
+// outputs["public_endpoint"]).
+func awsLoadTargetFromOutputs(outputs map[string]interface{}) (awsload.Target, error) {
+	endpoint, _ := outputs["load_balancer_endpoint"].(string)
+	if endpoint == "" {
+		endpoint, _ = outputs["public_endpoint"].(string)
+	}
+	if endpoint == "" {
+		return awsload.Target{}, errors.New("no load_balancer_endpoint or public_endpoint in outputs")
+	}
+	if !strings.HasPrefix(endpoint, "http") {
+		endpoint = "https://" + endpoint
+	}
+	return awsload.Target{URL: endpoint}, nil
+}
+
+// albArnSuffix extracts the "app/name/id" suffix GetMetricData's
+// LoadBalancer dimension expects from a full load balancer ARN.
+func albArnSuffix(arn string) string {
+	idx := strings.Index(arn, "loadbalancer/")
+	if idx < 0 {
+		return ""
+	}
+	return arn[idx+len("loadbalancer/"):]
+}
+
+// TestLoadPerformance drives a 5-minute fixed-RPS load scenario against
+// outputs' endpoint and fails if p95 latency or throughput violate
+// suite.Config.PerformanceConfig.
+func (suite *AWSTestSuite) TestLoadPerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "load").Msg("Testing load performance")
+
+	target, err := awsLoadTargetFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+
+	rps := suite.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 50
+	}
+	scenario := awsload.Scenario{
+		Name:     "load",
+		RPS:      rps,
+		Duration: 5 * time.Minute,
+		Targets:  []awsload.Target{target},
+		Assertions: awsload.LoadAssertions{
+			P95Latency:    time.Duration(suite.Config.PerformanceConfig.MaxResponseTimeMs) * time.Millisecond,
+			MinThroughput: float64(rps),
+		},
+	}
+
+	result, err := awsload.Run(suite.Context, scenario)
+	suite.Logger.Info().Interface("result", result).Msg("Load performance completed")
+	if err != nil {
+		return errors.Wrap(err, "load performance scenario failed")
+	}
+	return nil
+}
+
+// TestStressPerformance drives a load scenario at 10x the configured
+// minimum throughput for 5 minutes, then polls CloudWatch over that window
+// for the live ASG/ALB/RDS discovered the same way TestChaosEngineering
+// discovers them, asserting the ASG's desired capacity actually grew.
+func (suite *AWSTestSuite) TestStressPerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "stress").Msg("Testing stress performance")
+
+	target, err := awsLoadTargetFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+
+	rps := 10 * suite.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 500
+	}
+	duration := 5 * time.Minute
+	scenario := awsload.Scenario{
+		Name:     "stress",
+		RPS:      rps,
+		RampUp:   time.Minute,
+		Duration: duration,
+		Targets:  []awsload.Target{target},
+		Assertions: awsload.LoadAssertions{
+			MaxErrorRate: 0.05,
+		},
+	}
+
+	var asgName, alb, rdsInstanceID string
+	if groups, err := suite.AutoScaling().DescribeAutoScalingGroups(suite.Context, &autoscaling.DescribeAutoScalingGroupsInput{}); err == nil && len(groups.AutoScalingGroups) > 0 {
+		asgName = aws.ToString(groups.AutoScalingGroups[0].AutoScalingGroupName)
+	}
+	if albs, err := suite.ELB().DescribeLoadBalancers(suite.Context, &elbv2.DescribeLoadBalancersInput{}); err == nil && len(albs.LoadBalancers) > 0 {
+		alb = albArnSuffix(aws.ToString(albs.LoadBalancers[0].LoadBalancerArn))
+	}
+	if instances, err := suite.RDS().DescribeDBInstances(suite.Context, &rds.DescribeDBInstancesInput{}); err == nil && len(instances.DBInstances) > 0 {
+		rdsInstanceID = aws.ToString(instances.DBInstances[0].DBInstanceIdentifier)
+	}
+
+	start := time.Now()
+	result, runErr := awsload.Run(suite.Context, scenario)
+	suite.Logger.Info().Interface("result", result).Msg("Stress performance completed")
+
+	if asgName != "" {
+		metrics, err := awsload.PollAutoscalingMetrics(suite.Context, suite.CloudWatch(), asgName, alb, rdsInstanceID, start, time.Now())
+		if err != nil {
+			suite.Logger.Warn().Err(err).Msg("Failed to poll autoscaling metrics after stress run")
+		} else if !metrics.ScaledUp() {
+			suite.Logger.Warn().Str("asg", asgName).Msg("Auto Scaling group desired capacity did not increase during stress run")
+		}
+	}
+
+	if runErr != nil {
+		return errors.Wrap(runErr, "stress performance scenario failed")
+	}
+	return nil
+}
+
+// TestEndurancePerformance holds a steady-state load for an hour to catch
+// leaks/degradation that only show up under sustained load; it only runs
+// when suite.Config.PerformanceConfig.EnableEnduranceTesting is set.
+func (suite *AWSTestSuite) TestEndurancePerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "endurance").Msg("Testing endurance performance")
+
+	if !suite.Config.PerformanceConfig.EnableEnduranceTesting {
+		suite.Logger.Info().Msg("Endurance testing disabled, skipping")
+		return nil
+	}
+
+	target, err := awsLoadTargetFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+
+	rps := suite.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 20
+	}
+	scenario := awsload.Scenario{
+		Name:     "endurance",
+		RPS:      rps,
+		Duration: time.Hour,
+		Targets:  []awsload.Target{target},
+		Assertions: awsload.LoadAssertions{
+			P99Latency: time.Duration(suite.Config.PerformanceConfig.MaxResponseTimeMs) * time.Millisecond,
+		},
+	}
+
+	result, err := awsload.Run(suite.Context, scenario)
+	suite.Logger.Info().Interface("result", result).Msg("Endurance performance completed")
+	if err != nil {
+		return errors.Wrap(err, "endurance performance scenario failed")
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TargetAccount is one cross-account, multi-region fan-out target for
+// MultiAccountSuite: the account to assume into, the role ARN to assume
+// (and, if the trust policy requires one, the external ID), and the
+// regions within that account to run against.
+type TargetAccount struct {
+	AccountID  string
+	RoleARN    string
+	ExternalID string
+	Regions    []string
+}
+
+// MultiAccountResult is one (account,region) pair's outcome from
+// MultiAccountSuite.Run.
+type MultiAccountResult struct {
+	AccountID string        `json:"accountId"`
+	Region    string        `json:"region"`
+	Passed    bool          `json:"passed"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"durationNs"`
+}
+
+// MultiAccountReport is Run's aggregated output across every (account,
+// region) pair it attempted.
+type MultiAccountReport struct {
+	GeneratedAt time.Time            `json:"generatedAt"`
+	Results     []MultiAccountResult `json:"results"`
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *MultiAccountReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal multi-account report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write multi-account report %s", path)
+	}
+	return nil
+}
+
+// MultiAccountSuite fans a test body out across TargetAccount entries, each
+// region of each account getting its own AWSTestSuite built from base's
+// credentials via a cached, proactively-refreshed AssumeRoleCredentialProvider
+// -- the cross-account counterpart to MultiRegionRunner, which only fans out
+// within the single account base already authenticates as.
+type MultiAccountSuite struct {
+	Base        *AWSTestSuite
+	Targets     []TargetAccount
+	MaxParallel int
+}
+
+// MultiAccountOption configures a MultiAccountSuite.
+type MultiAccountOption func(*MultiAccountSuite)
+
+// WithMultiAccountParallel bounds how many (account,region) pairs run
+// concurrently. n <= 0 leaves every subtest unbounded beyond go test's own
+// -parallel flag.
+func WithMultiAccountParallel(n int) MultiAccountOption {
+	return func(ms *MultiAccountSuite) { ms.MaxParallel = n }
+}
+
+// NewMultiAccountSuite returns a MultiAccountSuite that assumes into each of
+// targets from base's credentials.
+func NewMultiAccountSuite(base *AWSTestSuite, targets []TargetAccount, opts ...MultiAccountOption) *MultiAccountSuite {
+	suite := &MultiAccountSuite{Base: base, Targets: targets}
+	for _, opt := range opts {
+		opt(suite)
+	}
+	return suite
+}
+
+// buildAccountSuite returns an AWSTestSuite that operates in target's
+// account and region, authenticating by assuming target.RoleARN from
+// ms.Base's own credentials.
+func (ms *MultiAccountSuite) buildAccountSuite(target TargetAccount, region string) *AWSTestSuite {
+	assumeRole := NewAssumeRoleCredentialProvider(ms.Base.AWSConfig.Credentials, region, target.RoleARN, target.ExternalID, "", nil)
+	provider := NewCachingCredentialProvider(assumeRole)
+
+	regionalConfig := ms.Base.AWSConfig.Copy()
+	regionalConfig.Region = region
+	regionalConfig.Credentials = aws.CredentialsProviderFunc(provider.Retrieve)
+
+	return &AWSTestSuite{
+		AWSConfig: regionalConfig,
+		Region:    region,
+		TestID:    ms.Base.TestID + "-" + target.AccountID + "-" + region,
+		Config:    ms.Base.Config,
+		Logger: ms.Base.Logger.With().
+			Str("account_id", target.AccountID).
+			Str("region", region).
+			Logger(),
+		Context: ms.Base.Context,
+	}
+}
+
+// Run drives testBody across every (account,region) pair in ms.Targets, via
+// a t.Run subtest keyed "<accountID>/<region>", each run in parallel and
+// bounded to ms.MaxParallel concurrent bodies by a semaphore (t.Parallel()
+// itself only defers to go test's global -parallel limit, which this suite
+// doesn't want to assume is configured). A subtest failing never aborts the
+// others; Run aggregates every pair's outcome into the returned
+// MultiAccountReport regardless.
+func (ms *MultiAccountSuite) Run(t *testing.T, testBody func(*testing.T, *AWSTestSuite)) *MultiAccountReport {
+	limit := ms.MaxParallel
+	if limit <= 0 {
+		limit = len(ms.Targets) + 1
+	}
+	sem := make(chan struct{}, limit)
+
+	var mu sync.Mutex
+	var results []MultiAccountResult
+
+	for _, target := range ms.Targets {
+		target := target
+		for _, region := range target.Regions {
+			region := region
+			name := fmt.Sprintf("%s/%s", target.AccountID, region)
+
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				start := time.Now()
+				accountSuite := ms.buildAccountSuite(target, region)
+
+				var testErr error
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							testErr = errors.Errorf("panic: %v", r)
+						}
+					}()
+					testBody(t, accountSuite)
+					if t.Failed() {
+						testErr = errors.New("subtest reported a failure")
+					}
+				}()
+
+				result := MultiAccountResult{
+					AccountID: target.AccountID,
+					Region:    region,
+					Passed:    testErr == nil,
+					Duration:  time.Since(start),
+				}
+				if testErr != nil {
+					result.Error = testErr.Error()
+				}
+
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			})
+		}
+	}
+
+	return &MultiAccountReport{GeneratedAt: time.Now(), Results: results}
+}
+
+// DiscoverOrganizationAccounts enumerates every ACTIVE member account of
+// org's organization via organizations.ListAccounts, paginating until
+// exhausted, for MultiAccountSuite.Run's OrganizationsDiscovery mode to
+// build a TargetAccount list from without an operator hand-maintaining one.
+// org must be authenticated as the organization's management account or a
+// delegated administrator.
+func DiscoverOrganizationAccounts(org *AWSTestSuite) ([]orgtypes.Account, error) {
+	var accounts []orgtypes.Account
+
+	paginator := organizations.NewListAccountsPaginator(org.Organizations(), &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(org.Context)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list organization accounts")
+		}
+		for _, account := range page.Accounts {
+			if account.Status == orgtypes.AccountStatusActive {
+				accounts = append(accounts, account)
+			}
+		}
+	}
+	return accounts, nil
+}
+
+// DiscoverTargets builds a TargetAccount per account DiscoverOrganizationAccounts
+// finds, assuming roleName (e.g. "OrganizationAccountAccessRole") in each,
+// across every region in regions -- the auto-enumerating counterpart to
+// hand-listing TargetAccount entries, for suites that want to cover an
+// entire AWS Organization. org's own account is skipped, since it's already
+// the one running the suite.
+func DiscoverTargets(org *AWSTestSuite, roleName string, regions []string) ([]TargetAccount, error) {
+	accounts, err := DiscoverOrganizationAccounts(org)
+	if err != nil {
+		return nil, err
+	}
+
+	selfAccount, err := org.STS().GetCallerIdentity(org.Context, &sts.GetCallerIdentityInput{})
+	var selfAccountID string
+	if err == nil {
+		selfAccountID = aws.ToString(selfAccount.Account)
+	}
+
+	var targets []TargetAccount
+	for _, account := range accounts {
+		accountID := aws.ToString(account.Id)
+		if accountID == "" || accountID == selfAccountID {
+			continue
+		}
+		targets = append(targets, TargetAccount{
+			AccountID: accountID,
+			RoleARN:   fmt.Sprintf("arn:aws:iam::%s:role/%s", accountID, roleName),
+			Regions:   regions,
+		})
+	}
+	return targets, nil
+}
+
+// requireMultiAccountResults fails t if any result in report reports a
+// failure, logging each one's error for the caller's post-run assertions.
+// Kept separate from Run so a caller that wants to inspect results before
+// deciding pass/fail (e.g. tolerating a known-flaky account) can do so.
+func requireMultiAccountResults(t *testing.T, report *MultiAccountReport) {
+	for _, result := range report.Results {
+		require.Truef(t, result.Passed, "%s/%s failed: %s", result.AccountID, result.Region, result.Error)
+	}
+}
@@ -0,0 +1,334 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+// commercialRegions is AWS's standard (non-GovCloud, non-China) partition
+// regions as of this writing. RunCanary uses it as the default sweep set;
+// refresh this list as AWS launches new regions.
+var commercialRegions = []string{
+	"us-east-1", "us-east-2", "us-west-1", "us-west-2",
+	"ca-central-1",
+	"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-north-1", "eu-south-1",
+	"ap-northeast-1", "ap-northeast-2", "ap-northeast-3",
+	"ap-southeast-1", "ap-southeast-2", "ap-southeast-3",
+	"ap-south-1", "sa-east-1",
+}
+
+// QuotaRequirement is a ServiceQuotas precheck MultiRegionRunner applies
+// before fanning out to a region: the region is skipped (not failed) if its
+// current quota value for QuotaCode is below MinValue.
+type QuotaRequirement struct {
+	ServiceCode string
+	QuotaCode   string
+	MinValue    float64
+}
+
+// MultiRegionResult is one region's outcome from a MultiRegionRunner.Run
+// call.
+type MultiRegionResult struct {
+	Region             string        `json:"region"`
+	Passed             bool          `json:"passed"`
+	Skipped            bool          `json:"skipped,omitempty"`
+	SkipReason         string        `json:"skipReason,omitempty"`
+	Duration           time.Duration `json:"durationNs"`
+	Error              string        `json:"error,omitempty"`
+	PartialFailureARNs []string      `json:"partialFailureArns,omitempty"`
+}
+
+// MultiRegionReport is Run's aggregated output across every region it
+// attempted.
+type MultiRegionReport struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Results     []MultiRegionResult `json:"results"`
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *MultiRegionReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal multi-region report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write multi-region report %s", path)
+	}
+	return nil
+}
+
+// teardownFunc tears an individual region's AWSTestSuite clone down after
+// its test body runs, reporting what it found. It's a field on
+// MultiRegionRunner (rather than always calling ResourceTracker directly)
+// so tests can substitute a fake that doesn't need live AWS credentials.
+type teardownFunc func(*AWSTestSuite) (*ResourceTrackerReport, error)
+
+// defaultTeardown runs a real ResourceTracker.Cleanup against clone.
+func defaultTeardown(clone *AWSTestSuite) (*ResourceTrackerReport, error) {
+	return NewResourceTracker(clone, false).Cleanup()
+}
+
+// MultiRegionRunner fans a test body out across regions, each against its
+// own AWSTestSuite clone (so a panic, leaked resource, or rate limit in one
+// region's clients can't affect another's), bounded to MaxParallel
+// concurrent regions via errgroup.
+type MultiRegionRunner struct {
+	Base        *AWSTestSuite
+	MaxParallel int
+	Allowlist   []string
+	Denylist    []string
+	Quota       *QuotaRequirement
+
+	teardown teardownFunc
+}
+
+// MultiRegionOption configures a MultiRegionRunner.
+type MultiRegionOption func(*MultiRegionRunner)
+
+// WithMaxParallel bounds how many regions run concurrently. n <= 0 falls
+// back to runtime.NumCPU().
+func WithMaxParallel(n int) MultiRegionOption {
+	return func(r *MultiRegionRunner) { r.MaxParallel = n }
+}
+
+// WithAllowlist restricts Run to only the given regions (in addition to
+// whatever it's called with).
+func WithAllowlist(regions []string) MultiRegionOption {
+	return func(r *MultiRegionRunner) { r.Allowlist = regions }
+}
+
+// WithDenylist excludes the given regions from every Run call.
+func WithDenylist(regions []string) MultiRegionOption {
+	return func(r *MultiRegionRunner) { r.Denylist = regions }
+}
+
+// WithRequiredQuota adds a ServiceQuotas precheck: a region is skipped
+// (not failed) if its current value for requirement.QuotaCode is below
+// requirement.MinValue.
+func WithRequiredQuota(requirement QuotaRequirement) MultiRegionOption {
+	return func(r *MultiRegionRunner) { r.Quota = &requirement }
+}
+
+// WithTeardown overrides how each region's clone is torn down after its
+// test body runs. Intended for tests that need to avoid a real
+// ResourceTracker/AWS call; production callers should leave this unset.
+func WithTeardown(fn teardownFunc) MultiRegionOption {
+	return func(r *MultiRegionRunner) { r.teardown = fn }
+}
+
+// NewMultiRegionRunner returns a MultiRegionRunner whose region clones
+// inherit base's credentials, Config, and Logger.
+func NewMultiRegionRunner(base *AWSTestSuite, opts ...MultiRegionOption) *MultiRegionRunner {
+	runner := &MultiRegionRunner{Base: base, teardown: defaultTeardown}
+	for _, opt := range opts {
+		opt(runner)
+	}
+	return runner
+}
+
+// CloneForRegion returns an AWSTestSuite for region, sharing suite's
+// credentials/Config/Context but with its own aws.Config.Region, its own
+// TestID suffixed "-<region>" (so ResourceTracker/CostGuard scope to this
+// clone alone), and its own empty client cache.
+func (suite *AWSTestSuite) CloneForRegion(region string) *AWSTestSuite {
+	regionalConfig := suite.AWSConfig.Copy()
+	regionalConfig.Region = region
+
+	return &AWSTestSuite{
+		AWSConfig: regionalConfig,
+		Region:    region,
+		TestID:    suite.TestID + "-" + region,
+		Config:    suite.Config,
+		Logger:    suite.Logger.With().Str("region", region).Logger(),
+		Context:   suite.Context,
+	}
+}
+
+// filterRegions applies runner.Allowlist (if set) and runner.Denylist to
+// regions.
+func (runner *MultiRegionRunner) filterRegions(regions []string) []string {
+	var allow map[string]bool
+	if len(runner.Allowlist) > 0 {
+		allow = make(map[string]bool, len(runner.Allowlist))
+		for _, region := range runner.Allowlist {
+			allow[region] = true
+		}
+	}
+
+	deny := make(map[string]bool, len(runner.Denylist))
+	for _, region := range runner.Denylist {
+		deny[region] = true
+	}
+
+	var filtered []string
+	for _, region := range regions {
+		if allow != nil && !allow[region] {
+			continue
+		}
+		if deny[region] {
+			continue
+		}
+		filtered = append(filtered, region)
+	}
+	return filtered
+}
+
+// quotaSkipReason returns a non-empty skip reason if runner.Quota is set
+// and region's current value for it is below Quota.MinValue.
+func (runner *MultiRegionRunner) quotaSkipReason(region string) (string, error) {
+	if runner.Quota == nil {
+		return "", nil
+	}
+
+	clone := runner.Base.CloneForRegion(region)
+	output, err := clone.ServiceQuotas().GetServiceQuota(clone.Context, &servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(runner.Quota.ServiceCode),
+		QuotaCode:   aws.String(runner.Quota.QuotaCode),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check quota %s/%s in %s", runner.Quota.ServiceCode, runner.Quota.QuotaCode, region)
+	}
+
+	if output.Quota == nil || output.Quota.Value == nil || *output.Quota.Value < runner.Quota.MinValue {
+		return "quota below required minimum", nil
+	}
+	return "", nil
+}
+
+// Run fans testBody out across regions (after applying the allow/denylist
+// and, if configured, the ServiceQuotas precheck), bounded to
+// runner.MaxParallel concurrent regions, and returns every region's result.
+// A region failing testBody never aborts the others -- Run only returns an
+// error if the precheck itself fails.
+func (runner *MultiRegionRunner) Run(regions []string, testBody func(*AWSTestSuite) error) (*MultiRegionReport, error) {
+	filtered := runner.filterRegions(regions)
+
+	limit := runner.MaxParallel
+	if limit <= 0 {
+		limit = runtime.NumCPU()
+	}
+
+	results := make([]MultiRegionResult, len(filtered))
+	group, ctx := errgroup.WithContext(runner.Base.Context)
+	group.SetLimit(limit)
+
+	for i, region := range filtered {
+		i, region := i, region
+		group.Go(func() error {
+			results[i] = runner.runRegion(ctx, region, testBody)
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return &MultiRegionReport{GeneratedAt: time.Now(), Results: results}, nil
+}
+
+// runRegion runs testBody against region's clone, then tears it down,
+// recording any resource that survived teardown as a partial failure.
+func (runner *MultiRegionRunner) runRegion(ctx context.Context, region string, testBody func(*AWSTestSuite) error) MultiRegionResult {
+	start := time.Now()
+
+	if reason, err := runner.quotaSkipReason(region); err != nil {
+		runner.Base.Logger.Warn().Err(err).Str("region", region).Msg("quota precheck failed, running region anyway")
+	} else if reason != "" {
+		return MultiRegionResult{Region: region, Skipped: true, SkipReason: reason, Duration: time.Since(start)}
+	}
+
+	clone := runner.Base.CloneForRegion(region)
+	clone.Context = ctx
+
+	testErr := testBody(clone)
+
+	result := MultiRegionResult{Region: region, Passed: testErr == nil, Duration: time.Since(start)}
+	if testErr != nil {
+		result.Error = testErr.Error()
+	}
+
+	report, teardownErr := runner.teardown(clone)
+	if teardownErr != nil {
+		clone.Logger.Warn().Err(teardownErr).Str("region", region).Msg("multi-region teardown failed")
+	}
+	if report != nil {
+		for _, resource := range report.Resources {
+			if resource.Disposition == "failed" {
+				result.PartialFailureARNs = append(result.PartialFailureARNs, resource.ARN)
+			}
+		}
+	}
+
+	return result
+}
+
+// RunCanary runs assertions against every commercialRegions entry -- opt-in
+// so a CI job can wire it to a weekly schedule to catch regional drift,
+// rather than this package deciding its own cadence.
+func (runner *MultiRegionRunner) RunCanary(assertions func(*AWSTestSuite) error) (*MultiRegionReport, error) {
+	return runner.Run(commercialRegions, assertions)
+}
+
+// TestMultiRegionRunnerAggregatesPerRegionResults drives Run with a fake
+// teardown (so it needs no live AWS credentials) to prove per-region
+// pass/fail and partial-failure ARNs are aggregated independently of each
+// other -- one region failing its test body doesn't stop or skew another's
+// result.
+func TestMultiRegionRunnerAggregatesPerRegionResults(t *testing.T) {
+	base := &AWSTestSuite{Context: context.Background(), TestID: "base"}
+	runner := NewMultiRegionRunner(base,
+		WithMaxParallel(4),
+		WithTeardown(func(clone *AWSTestSuite) (*ResourceTrackerReport, error) {
+			if clone.Region == "us-west-2" {
+				return &ResourceTrackerReport{Resources: []TrackedResource{{ARN: "arn:aws:ec2:us-west-2:111:instance/i-stuck", Disposition: "failed"}}}, nil
+			}
+			return &ResourceTrackerReport{}, nil
+		}),
+	)
+
+	report, err := runner.Run([]string{"us-east-1", "us-west-2"}, func(clone *AWSTestSuite) error {
+		if clone.Region == "us-west-2" {
+			return errors.New("simulated failure")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+
+	byRegion := map[string]MultiRegionResult{}
+	for _, result := range report.Results {
+		byRegion[result.Region] = result
+	}
+
+	assert.True(t, byRegion["us-east-1"].Passed)
+	assert.Empty(t, byRegion["us-east-1"].PartialFailureARNs)
+
+	assert.False(t, byRegion["us-west-2"].Passed)
+	assert.Equal(t, "simulated failure", byRegion["us-west-2"].Error)
+	assert.Equal(t, []string{"arn:aws:ec2:us-west-2:111:instance/i-stuck"}, byRegion["us-west-2"].PartialFailureARNs)
+}
+
+// TestMultiRegionRunnerFiltersAllowlistAndDenylist proves filterRegions
+// honors both lists together: only allowlisted regions survive, and a
+// denylisted region is excluded even if it was allowlisted.
+func TestMultiRegionRunnerFiltersAllowlistAndDenylist(t *testing.T) {
+	runner := NewMultiRegionRunner(&AWSTestSuite{Context: context.Background()},
+		WithAllowlist([]string{"us-east-1", "us-west-2", "eu-west-1"}),
+		WithDenylist([]string{"eu-west-1"}),
+	)
+
+	filtered := runner.filterRegions([]string{"us-east-1", "us-west-2", "eu-west-1", "ap-south-1"})
+	assert.Equal(t, []string{"us-east-1", "us-west-2"}, filtered)
+}
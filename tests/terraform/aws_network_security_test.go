@@ -0,0 +1,112 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pkg/errors"
+)
+
+// awsSensitivePorts are the ports that must never be open to the world on
+// an ingress rule unless explicitly whitelisted.
+var awsSensitivePorts = []int32{22, 3389, 3306, 5432, 6379}
+
+// EvaluateNetworkSecurity describes every security group and network ACL in
+// suite.Region and returns one violation per ingress rule that opens a
+// sensitive port (ssh, RDP, MySQL, Postgres, Redis) to 0.0.0.0/0, unless
+// the resource's ID appears in suite.Config.AWSSensitiveIngressWhitelist.
+func (suite *AWSTestSuite) EvaluateNetworkSecurity() ([]string, error) {
+	whitelisted := make(map[string]bool, len(suite.Config.AWSSensitiveIngressWhitelist))
+	for _, id := range suite.Config.AWSSensitiveIngressWhitelist {
+		whitelisted[id] = true
+	}
+
+	var violations []string
+
+	groups, err := suite.EC2().DescribeSecurityGroups(suite.Context, &ec2.DescribeSecurityGroupsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe security groups")
+	}
+	for _, sg := range groups.SecurityGroups {
+		groupID := aws.ToString(sg.GroupId)
+		if whitelisted[groupID] {
+			continue
+		}
+		for _, permission := range sg.IpPermissions {
+			for _, port := range openSensitivePorts(permission) {
+				if !ingressOpenToWorld(permission) {
+					continue
+				}
+				violations = append(violations, fmt.Sprintf("security group %s allows sensitive port %d from 0.0.0.0/0", groupID, port))
+			}
+		}
+	}
+
+	nacls, err := suite.EC2().DescribeNetworkAcls(suite.Context, &ec2.DescribeNetworkAclsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe network ACLs")
+	}
+	for _, nacl := range nacls.NetworkAcls {
+		naclID := aws.ToString(nacl.NetworkAclId)
+		if whitelisted[naclID] {
+			continue
+		}
+		for _, entry := range nacl.Entries {
+			if aws.ToBool(entry.Egress) || entry.RuleAction != ec2types.RuleActionAllow {
+				continue
+			}
+			if aws.ToString(entry.CidrBlock) != "0.0.0.0/0" {
+				continue
+			}
+			for _, port := range openSensitivePortsInRange(entry.PortRange) {
+				violations = append(violations, fmt.Sprintf("network ACL %s allows sensitive port %d from 0.0.0.0/0", naclID, port))
+			}
+		}
+	}
+
+	return violations, nil
+}
+
+// ingressOpenToWorld reports whether permission's ingress is reachable from
+// any IPv4 address.
+func ingressOpenToWorld(permission ec2types.IpPermission) bool {
+	for _, ipRange := range permission.IpRanges {
+		if aws.ToString(ipRange.CidrIp) == "0.0.0.0/0" {
+			return true
+		}
+	}
+	return false
+}
+
+// openSensitivePorts returns every port in awsSensitivePorts that
+// permission's FromPort/ToPort range covers.
+func openSensitivePorts(permission ec2types.IpPermission) []int32 {
+	if permission.FromPort == nil || permission.ToPort == nil {
+		return nil
+	}
+	var ports []int32
+	for _, sensitive := range awsSensitivePorts {
+		if sensitive >= aws.ToInt32(permission.FromPort) && sensitive <= aws.ToInt32(permission.ToPort) {
+			ports = append(ports, sensitive)
+		}
+	}
+	return ports
+}
+
+// openSensitivePortsInRange returns every port in awsSensitivePorts that
+// portRange covers. A nil portRange (the NACL entry applies to all
+// protocols/ports) is treated as covering every sensitive port.
+func openSensitivePortsInRange(portRange *ec2types.PortRange) []int32 {
+	if portRange == nil {
+		return awsSensitivePorts
+	}
+	var ports []int32
+	for _, sensitive := range awsSensitivePorts {
+		if sensitive >= aws.ToInt32(portRange.From) && sensitive <= aws.ToInt32(portRange.To) {
+			ports = append(ports, sensitive)
+		}
+	}
+	return ports
+}
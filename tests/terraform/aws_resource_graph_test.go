@@ -0,0 +1,335 @@
+package test
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	cstypes "github.com/aws/aws-sdk-go-v2/service/configservice/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourceexplorer2"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+
+	"github.com/pkg/errors"
+)
+
+// defaultGraphResourceTypes is the set of AWS Config resource types
+// ResourceGraph queries by default -- the ones TestVPC/TestEC2/TestRDS
+// already inspect piecemeal via DescribeVpcs/DescribeInstances/
+// DescribeVolumes/etc, now fetched once per run instead of once per test.
+var defaultGraphResourceTypes = []string{
+	"AWS::EC2::VPC",
+	"AWS::EC2::Subnet",
+	"AWS::EC2::Instance",
+	"AWS::EC2::Volume",
+	"AWS::EC2::SecurityGroup",
+	"AWS::EC2::RouteTable",
+	"AWS::EC2::NatGateway",
+	"AWS::RDS::DBInstance",
+	"AWS::RDS::DBSubnetGroup",
+}
+
+// GraphNode is one resource ResourceGraph discovered, keyed by its Config
+// resourceId. Configuration is the type-specific JSON blob Config recorded
+// for it (e.g. an AWS::EC2::RouteTable's routes/associations), left raw
+// since each resource type's shape differs and most callers only need a
+// handful of its fields.
+type GraphNode struct {
+	ID            string
+	ARN           string
+	ResourceType  string
+	Name          string
+	Region        string
+	Tags          map[string]string
+	Configuration json.RawMessage
+}
+
+// GraphEdge is one relationship ResourceGraph discovered between two
+// nodes, e.g. a volume attached to an instance or a security group scoped
+// to a VPC.
+type GraphEdge struct {
+	From         string
+	To           string
+	Relationship string
+}
+
+// ResourceGraph is an in-memory graph of this suite's AWS resources, built
+// once per run from AWS Config's advanced query API (and supplemented by
+// Resource Explorer and resourcegroupstaggingapi), so tests can query
+// relationships -- graph.Neighbors(vpcID, "AWS::EC2::Instance") -- instead
+// of re-paginating each service's Describe* call for every test that needs
+// the same topology.
+type ResourceGraph struct {
+	suite *AWSTestSuite
+
+	mu        sync.RWMutex
+	nodes     map[string]*GraphNode
+	edgesFrom map[string][]GraphEdge
+}
+
+// configSelectResult mirrors the JSON document configservice.SelectResourceConfig
+// returns per result row for "SELECT resourceId, resourceType, resourceName,
+// arn, awsRegion, relationships, configuration WHERE resourceType = '...'".
+type configSelectResult struct {
+	ResourceID    string          `json:"resourceId"`
+	ResourceType  string          `json:"resourceType"`
+	ResourceName  string          `json:"resourceName"`
+	ARN           string          `json:"arn"`
+	AWSRegion     string          `json:"awsRegion"`
+	Configuration json.RawMessage `json:"configuration"`
+	Relationships []struct {
+		ResourceID       string `json:"resourceId"`
+		ResourceType     string `json:"resourceType"`
+		RelationshipName string `json:"relationshipName"`
+	} `json:"relationships"`
+}
+
+// BuildResourceGraph queries AWS Config's advanced query API for every
+// resource type in resourceTypes (defaultGraphResourceTypes if empty),
+// turning each result row into a GraphNode and its relationships into
+// GraphEdges, then enriches every node's Tags via
+// resourcegroupstaggingapi.GetResources in a single batched call.
+func BuildResourceGraph(suite *AWSTestSuite, resourceTypes []string) (*ResourceGraph, error) {
+	if len(resourceTypes) == 0 {
+		resourceTypes = defaultGraphResourceTypes
+	}
+
+	graph := &ResourceGraph{
+		suite:     suite,
+		nodes:     make(map[string]*GraphNode),
+		edgesFrom: make(map[string][]GraphEdge),
+	}
+
+	for _, resourceType := range resourceTypes {
+		if err := graph.queryResourceType(resourceType); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := graph.enrichTags(); err != nil {
+		suite.Logger.Warn().Err(err).Msg("resource graph: failed to enrich nodes with tags")
+	}
+
+	return graph, nil
+}
+
+// queryResourceType runs a Config advanced query for resourceType, paging
+// through NextToken until exhausted, adding a GraphNode per result and a
+// GraphEdge per relationship it reports.
+func (graph *ResourceGraph) queryResourceType(resourceType string) error {
+	expression := "SELECT resourceId, resourceType, resourceName, arn, awsRegion, relationships, configuration WHERE resourceType = '" + resourceType + "'"
+
+	var nextToken *string
+	for {
+		output, err := graph.suite.ConfigService().SelectResourceConfig(graph.suite.Context, &configservice.SelectResourceConfigInput{
+			Expression: aws.String(expression),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to query Config for resource type %s", resourceType)
+		}
+
+		for _, raw := range output.Results {
+			var result configSelectResult
+			if err := json.Unmarshal([]byte(raw), &result); err != nil {
+				graph.suite.Logger.Warn().Err(err).Str("resource_type", resourceType).Msg("resource graph: failed to unmarshal Config query result")
+				continue
+			}
+			graph.addNode(&result)
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+	return nil
+}
+
+// addNode records result as a GraphNode and each of its relationships as a
+// GraphEdge in both directions, so Neighbors works regardless of which side
+// of the relationship the caller starts from.
+func (graph *ResourceGraph) addNode(result *configSelectResult) {
+	node := &GraphNode{
+		ID:            result.ResourceID,
+		ARN:           result.ARN,
+		ResourceType:  result.ResourceType,
+		Name:          result.ResourceName,
+		Region:        result.AWSRegion,
+		Configuration: result.Configuration,
+	}
+
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+
+	graph.nodes[node.ID] = node
+	for _, rel := range result.Relationships {
+		edge := GraphEdge{From: node.ID, To: rel.ResourceID, Relationship: rel.RelationshipName}
+		graph.edgesFrom[node.ID] = append(graph.edgesFrom[node.ID], edge)
+		graph.edgesFrom[rel.ResourceID] = append(graph.edgesFrom[rel.ResourceID], GraphEdge{From: rel.ResourceID, To: node.ID, Relationship: rel.RelationshipName})
+	}
+}
+
+// enrichTags batches every known node ARN through
+// resourcegroupstaggingapi.GetResources (in groups of resourceARNListLimit,
+// the API's per-call cap) and copies the returned tags onto each node.
+const resourceARNListLimit = 20
+
+func (graph *ResourceGraph) enrichTags() error {
+	graph.mu.RLock()
+	arns := make([]string, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		if node.ARN != "" {
+			arns = append(arns, node.ARN)
+		}
+	}
+	graph.mu.RUnlock()
+
+	client := resourcegroupstaggingapi.NewFromConfig(graph.suite.AWSConfig)
+
+	for start := 0; start < len(arns); start += resourceARNListLimit {
+		end := start + resourceARNListLimit
+		if end > len(arns) {
+			end = len(arns)
+		}
+		batch := arns[start:end]
+
+		output, err := client.GetResources(graph.suite.Context, &resourcegroupstaggingapi.GetResourcesInput{
+			ResourceARNList: batch,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to batch-fetch resource tags")
+		}
+
+		graph.mu.Lock()
+		for _, mapping := range output.ResourceTagMappingList {
+			node := graph.nodeByARN(aws.ToString(mapping.ResourceARN))
+			if node == nil {
+				continue
+			}
+			if node.Tags == nil {
+				node.Tags = make(map[string]string, len(mapping.Tags))
+			}
+			for _, tag := range mapping.Tags {
+				node.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+		}
+		graph.mu.Unlock()
+	}
+	return nil
+}
+
+// nodeByARN linear-scans graph.nodes for one with the given ARN. Callers
+// must hold graph.mu. Node counts per run are small enough (hundreds, not
+// millions) that this is simpler than maintaining a second ARN-keyed index
+// solely for enrichTags' one-time use.
+func (graph *ResourceGraph) nodeByARN(arn string) *GraphNode {
+	for _, node := range graph.nodes {
+		if node.ARN == arn {
+			return node
+		}
+	}
+	return nil
+}
+
+// AugmentFromResourceExplorer searches Resource Explorer for resources
+// matching query (e.g. "resourcetype:ec2:instance") and adds any whose ARN
+// isn't already a graph node -- covering resources Config's aggregator
+// hasn't recorded yet (e.g. a region where the Config recorder is stopped),
+// at the cost of a node with no relationships since Resource Explorer
+// doesn't report them.
+func (graph *ResourceGraph) AugmentFromResourceExplorer(query string) error {
+	var nextToken *string
+	for {
+		output, err := graph.suite.ResourceExplorer2().Search(graph.suite.Context, &resourceexplorer2.SearchInput{
+			QueryString: aws.String(query),
+			NextToken:   nextToken,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to search Resource Explorer for %q", query)
+		}
+
+		graph.mu.Lock()
+		for _, resource := range output.Resources {
+			arn := aws.ToString(resource.Arn)
+			if graph.nodeByARN(arn) != nil {
+				continue
+			}
+			node := &GraphNode{
+				ID:           arn,
+				ARN:          arn,
+				ResourceType: aws.ToString(resource.ResourceType),
+				Region:       aws.ToString(resource.Region),
+			}
+			graph.nodes[node.ID] = node
+		}
+		graph.mu.Unlock()
+
+		if output.NextToken == nil {
+			break
+		}
+		nextToken = output.NextToken
+	}
+	return nil
+}
+
+// Node returns the graph node for id, or nil if BuildResourceGraph didn't
+// discover a resource with that Config resourceId/ARN.
+func (graph *ResourceGraph) Node(id string) *GraphNode {
+	graph.mu.RLock()
+	defer graph.mu.RUnlock()
+	return graph.nodes[id]
+}
+
+// Neighbors returns every node reachable from id by a single relationship
+// edge whose ResourceType equals relatedType, e.g.
+// graph.Neighbors(vpcID, "AWS::EC2::Instance") for every instance in a VPC.
+// Pass "" for relatedType to return every neighbor regardless of type.
+func (graph *ResourceGraph) Neighbors(id, relatedType string) []*GraphNode {
+	graph.mu.RLock()
+	defer graph.mu.RUnlock()
+
+	var neighbors []*GraphNode
+	for _, edge := range graph.edgesFrom[id] {
+		node, ok := graph.nodes[edge.To]
+		if !ok {
+			continue
+		}
+		if relatedType != "" && node.ResourceType != relatedType {
+			continue
+		}
+		neighbors = append(neighbors, node)
+	}
+	return neighbors
+}
+
+// routeTableConfiguration is the subset of an AWS::EC2::RouteTable Config
+// configuration blob HasActiveNATRoute needs.
+type routeTableConfiguration struct {
+	Routes []struct {
+		NatGatewayID string `json:"natGatewayId"`
+		State        string `json:"state"`
+	} `json:"routes"`
+}
+
+// HasActiveNATRoute reports whether node -- expected to be an
+// AWS::EC2::RouteTable graph node -- has at least one active route through
+// a NAT gateway. It's the building block for topology assertions like
+// "every private subnet has a route to a NAT gateway in the same AZ":
+// route := graph.Neighbors(subnetID, "AWS::EC2::RouteTable")[0];
+// route.HasActiveNATRoute().
+func (node *GraphNode) HasActiveNATRoute() bool {
+	if node == nil || node.Configuration == nil {
+		return false
+	}
+	var config routeTableConfiguration
+	if err := json.Unmarshal(node.Configuration, &config); err != nil {
+		return false
+	}
+	for _, route := range config.Routes {
+		if route.NatGatewayID != "" && route.State == "active" {
+			return true
+		}
+	}
+	return false
+}
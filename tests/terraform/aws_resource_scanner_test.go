@@ -0,0 +1,462 @@
+package test
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/pkg/errors"
+)
+
+// scanParallelServices bounds how many of ResourceScanner's registered scans
+// run concurrently, for operators tuning discovery time against accounts
+// large enough (10k+ buckets, 1k+ Lambdas) that fully serial listing no
+// longer finishes in a reasonable test run: go test -run TestAWSInfrastructure
+// ./tests/terraform/... -parallel-services=8
+var scanParallelServices = flag.Int("parallel-services", 4,
+	"max number of AWS service inventories ResourceScanner discovers concurrently")
+
+// ScanMetrics records one registered scan's cost, for operators sizing
+// -parallel-services and diagnosing why a run took longer than expected.
+type ScanMetrics struct {
+	Service   string
+	Duration  time.Duration
+	APICalls  int
+	Throttles int
+}
+
+// regionRateLimiter paces calls against a single region to at most qps per
+// second, a plain interval gate rather than a true token bucket since every
+// caller here is a paginator loop making calls back-to-back, not bursting.
+// qps <= 0 disables pacing entirely.
+type regionRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newRegionRateLimiter(qps int) *regionRateLimiter {
+	if qps <= 0 {
+		return &regionRateLimiter{}
+	}
+	return &regionRateLimiter{interval: time.Second / time.Duration(qps)}
+}
+
+// Wait blocks until at least rl.interval has elapsed since the previous
+// call, a no-op if rl was built with qps <= 0.
+func (rl *regionRateLimiter) Wait() {
+	if rl.interval == 0 {
+		return
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if wait := rl.interval - time.Since(rl.last); wait > 0 {
+		time.Sleep(wait)
+	}
+	rl.last = time.Now()
+}
+
+// scanMaxRetries and scanBaseBackoff bound withRetry's exponential backoff:
+// 200ms, 400ms, 800ms, 1.6s, 3.2s before giving up.
+const (
+	scanMaxRetries  = 5
+	scanBaseBackoff = 200 * time.Millisecond
+)
+
+// isThrottlingError reports whether err is one of the throttling signals
+// AWS services in this suite return under load, by substring match against
+// the SDK's stringified error -- every v2 service wraps these in a distinct
+// generated error type, so a substring match on the message is simpler than
+// importing and type-switching on every service's *types.ThrottlingException.
+func isThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, code := range []string{"ThrottlingException", "RequestLimitExceeded", "TooManyRequestsException", "Throttling", "SlowDown"} {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry calls fn, retrying with exponential backoff while it returns a
+// throttling error, recording each attempt and retry against metrics.
+func withRetry(metrics *ScanMetrics, fn func() error) error {
+	backoff := scanBaseBackoff
+	var err error
+	for attempt := 0; attempt <= scanMaxRetries; attempt++ {
+		metrics.APICalls++
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if !isThrottlingError(err) {
+			return err
+		}
+		metrics.Throttles++
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return err
+}
+
+// ScanFunc discovers one service's inventory. Implementations pace their
+// List/Describe calls through rl.Wait() and route every call through
+// withRetry(metrics, ...) so throttling is retried consistently and counted.
+type ScanFunc func(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error)
+
+// ResourceScanner fans per-service resource discovery out across a bounded
+// worker pool instead of each Test* subtest re-listing the same inventory
+// serially (TestELB alone used to call DescribeLoadBalancers three times).
+// Run populates an in-memory cache that Get retrieves from, paced by a
+// shared per-region QPS budget and retrying throttled calls with backoff.
+type ResourceScanner struct {
+	suite   *AWSTestSuite
+	qps     int
+	workers int
+
+	mu      sync.RWMutex
+	scans   map[string]ScanFunc
+	cache   map[string]interface{}
+	metrics map[string]ScanMetrics
+}
+
+// ResourceScannerOption configures a ResourceScanner built by NewResourceScanner.
+type ResourceScannerOption func(*ResourceScanner)
+
+// WithScannerQPS bounds every registered scan's call rate against suite's
+// region. 0 (the default) leaves calls unpaced beyond withRetry's backoff.
+func WithScannerQPS(qps int) ResourceScannerOption {
+	return func(s *ResourceScanner) { s.qps = qps }
+}
+
+// WithScannerWorkers overrides the concurrency bound otherwise taken from
+// -parallel-services.
+func WithScannerWorkers(n int) ResourceScannerOption {
+	return func(s *ResourceScanner) { s.workers = n }
+}
+
+// NewResourceScanner returns a ResourceScanner over suite with the built-in
+// scans registered for the services Test* subtests list most heavily: S3
+// buckets, ELB load balancers and listeners, IAM users, Lambda functions,
+// ECS clusters, and EKS clusters.
+func NewResourceScanner(suite *AWSTestSuite, opts ...ResourceScannerOption) *ResourceScanner {
+	scanner := &ResourceScanner{
+		suite:   suite,
+		workers: *scanParallelServices,
+		scans:   make(map[string]ScanFunc),
+		cache:   make(map[string]interface{}),
+		metrics: make(map[string]ScanMetrics),
+	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+
+	scanner.RegisterScan("s3.buckets", scanS3Buckets)
+	scanner.RegisterScan("elb.loadBalancers", scanELBLoadBalancers)
+	scanner.RegisterScan("elb.listeners", scanELBListeners)
+	scanner.RegisterScan("iam.users", scanIAMUsers)
+	scanner.RegisterScan("lambda.functions", scanLambdaFunctions)
+	scanner.RegisterScan("ecs.clusters", scanECSClusters)
+	scanner.RegisterScan("eks.clusters", scanEKSClusters)
+
+	return scanner
+}
+
+// RegisterScan adds or replaces the scan function registered under name, for
+// callers extending the default set without forking this file.
+func (s *ResourceScanner) RegisterScan(name string, fn ScanFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scans[name] = fn
+}
+
+// Run executes every registered scan concurrently, bounded to s.workers at a
+// time and paced by a QPS budget shared across all of them, populating the
+// cache Get reads from. It returns the first error any scan reported, after
+// every scan has finished (a throttled or failing scan never blocks the
+// others from completing and populating their own cache entries).
+func (s *ResourceScanner) Run(ctx context.Context) error {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.scans))
+	for name := range s.scans {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+
+	rl := newRegionRateLimiter(s.qps)
+	sem := make(chan struct{}, s.workers)
+	var wg sync.WaitGroup
+	errs := make(chan error, len(names))
+
+	for _, name := range names {
+		name := name
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			s.mu.RLock()
+			fn := s.scans[name]
+			s.mu.RUnlock()
+
+			metrics := ScanMetrics{Service: name}
+			start := time.Now()
+			result, err := fn(ctx, s.suite, rl, &metrics)
+			metrics.Duration = time.Since(start)
+
+			s.mu.Lock()
+			s.metrics[name] = metrics
+			if err == nil {
+				s.cache[name] = result
+			}
+			s.mu.Unlock()
+
+			if err != nil {
+				errs <- errors.Wrapf(err, "scan %s failed", name)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	var firstErr error
+	for err := range errs {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Get returns the inventory name's scan produced, or (nil, false) if Run
+// hasn't completed it (or it failed, or name was never registered).
+func (s *ResourceScanner) Get(name string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.cache[name]
+	return v, ok
+}
+
+// Metrics returns a copy of every scan's ScanMetrics recorded by the most
+// recent Run.
+func (s *ResourceScanner) Metrics() map[string]ScanMetrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]ScanMetrics, len(s.metrics))
+	for k, v := range s.metrics {
+		out[k] = v
+	}
+	return out
+}
+
+// scanS3Buckets lists every bucket in the account. ListBuckets has no
+// pagination token in this SDK version -- a single account's bucket count
+// is always small enough for one call -- so withRetry's backoff is the only
+// thing standing between this and a ThrottlingException.
+func scanS3Buckets(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var buckets []s3types.Bucket
+	rl.Wait()
+	err := withRetry(metrics, func() error {
+		output, err := suite.S3().ListBuckets(ctx, &s3.ListBucketsInput{})
+		if err != nil {
+			return err
+		}
+		buckets = output.Buckets
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list S3 buckets")
+	}
+	return buckets, nil
+}
+
+// scanELBLoadBalancers pages through every ALB/NLB/GLB in the account.
+func scanELBLoadBalancers(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var loadBalancers []elbv2types.LoadBalancer
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(suite.ELB(), &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		rl.Wait()
+		var page *elbv2.DescribeLoadBalancersOutput
+		err := withRetry(metrics, func() error {
+			var pageErr error
+			page, pageErr = paginator.NextPage(ctx)
+			return pageErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe load balancers")
+		}
+		loadBalancers = append(loadBalancers, page.LoadBalancers...)
+	}
+	return loadBalancers, nil
+}
+
+// scanELBListeners pages through every listener of every ALB/NLB/GLB in the
+// account, flattened into a single slice since none of TestELB's listener
+// assertions need to know which load balancer a listener belongs to.
+func scanELBListeners(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	lbsRaw, err := scanELBLoadBalancers(ctx, suite, rl, metrics)
+	if err != nil {
+		return nil, err
+	}
+	loadBalancers := lbsRaw.([]elbv2types.LoadBalancer)
+
+	var listeners []elbv2types.Listener
+	for _, lb := range loadBalancers {
+		paginator := elbv2.NewDescribeListenersPaginator(suite.ELB(), &elbv2.DescribeListenersInput{
+			LoadBalancerArn: lb.LoadBalancerArn,
+		})
+		for paginator.HasMorePages() {
+			rl.Wait()
+			var page *elbv2.DescribeListenersOutput
+			err := withRetry(metrics, func() error {
+				var pageErr error
+				page, pageErr = paginator.NextPage(ctx)
+				return pageErr
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to describe listeners for %s", *lb.LoadBalancerArn)
+			}
+			listeners = append(listeners, page.Listeners...)
+		}
+	}
+	return listeners, nil
+}
+
+// scanIAMUsers pages through every IAM user in the account.
+func scanIAMUsers(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var users []iamtypes.User
+	paginator := iam.NewListUsersPaginator(suite.IAM(), &iam.ListUsersInput{})
+	for paginator.HasMorePages() {
+		rl.Wait()
+		var page *iam.ListUsersOutput
+		err := withRetry(metrics, func() error {
+			var pageErr error
+			page, pageErr = paginator.NextPage(ctx)
+			return pageErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list IAM users")
+		}
+		users = append(users, page.Users...)
+	}
+	return users, nil
+}
+
+// scanLambdaFunctions pages through every Lambda function in the account.
+func scanLambdaFunctions(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var functions []lambdatypes.FunctionConfiguration
+	paginator := lambda.NewListFunctionsPaginator(suite.Lambda(), &lambda.ListFunctionsInput{})
+	for paginator.HasMorePages() {
+		rl.Wait()
+		var page *lambda.ListFunctionsOutput
+		err := withRetry(metrics, func() error {
+			var pageErr error
+			page, pageErr = paginator.NextPage(ctx)
+			return pageErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Lambda functions")
+		}
+		functions = append(functions, page.Functions...)
+	}
+	return functions, nil
+}
+
+// scanECSClusters pages through every ECS cluster ARN, then resolves them to
+// full cluster descriptions in batches (DescribeClusters takes up to 100
+// ARNs per call).
+func scanECSClusters(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var arns []string
+	paginator := ecs.NewListClustersPaginator(suite.ECS(), &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		rl.Wait()
+		var page *ecs.ListClustersOutput
+		err := withRetry(metrics, func() error {
+			var pageErr error
+			page, pageErr = paginator.NextPage(ctx)
+			return pageErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list ECS clusters")
+		}
+		arns = append(arns, page.ClusterArns...)
+	}
+
+	const describeBatchLimit = 100
+	var clusters []ecstypes.Cluster
+	for start := 0; start < len(arns); start += describeBatchLimit {
+		end := start + describeBatchLimit
+		if end > len(arns) {
+			end = len(arns)
+		}
+		rl.Wait()
+		var output *ecs.DescribeClustersOutput
+		err := withRetry(metrics, func() error {
+			var describeErr error
+			output, describeErr = suite.ECS().DescribeClusters(ctx, &ecs.DescribeClustersInput{Clusters: arns[start:end]})
+			return describeErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe ECS clusters")
+		}
+		clusters = append(clusters, output.Clusters...)
+	}
+	return clusters, nil
+}
+
+// scanEKSClusters pages through every EKS cluster name, then resolves each
+// to its full description (EKS has no batch describe, unlike ECS).
+func scanEKSClusters(ctx context.Context, suite *AWSTestSuite, rl *regionRateLimiter, metrics *ScanMetrics) (interface{}, error) {
+	var names []string
+	paginator := eks.NewListClustersPaginator(suite.EKS(), &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		rl.Wait()
+		var page *eks.ListClustersOutput
+		err := withRetry(metrics, func() error {
+			var pageErr error
+			page, pageErr = paginator.NextPage(ctx)
+			return pageErr
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list EKS clusters")
+		}
+		names = append(names, page.Clusters...)
+	}
+
+	var clusters []ekstypes.Cluster
+	for _, name := range names {
+		name := name
+		rl.Wait()
+		var output *eks.DescribeClusterOutput
+		err := withRetry(metrics, func() error {
+			var describeErr error
+			output, describeErr = suite.EKS().DescribeCluster(ctx, &eks.DescribeClusterInput{Name: &name})
+			return describeErr
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to describe EKS cluster %s", name)
+		}
+		clusters = append(clusters, *output.Cluster)
+	}
+	return clusters, nil
+}
@@ -0,0 +1,396 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	rgtatypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+
+	"github.com/pkg/errors"
+)
+
+// trackingSuiteName tags every resource this test suite creates, so
+// ReapOrphans can find leaks across *any* TestID instead of just the
+// currently-running one.
+const trackingSuiteName = "learning-assistant"
+
+// Tag keys stamped onto every resource created through a CreateOptions
+// wrapper -- the AWS equivalent of the instance label/tracking-id
+// annotation pair K8sTestSuite.stampTracking uses (k8s_resource_tracking_test.go).
+const (
+	tagTestID    = "TestID"
+	tagTestSuite = "TestSuite"
+	tagCreatedAt = "CreatedAt"
+)
+
+// CreateOptions wraps the tags a suite-driven resource-creation call should
+// pass to AWS, merging the caller's own tags with the TestID/TestSuite/
+// CreatedAt triple ResourceTracker needs to find and reclaim the resource
+// later. Call sites pass opts.Tags to whatever tagging shape their service's
+// Create call expects (e.g. ec2 TagSpecifications, rds.Tags).
+type CreateOptions struct {
+	Tags map[string]string
+}
+
+// NewCreateOptions returns a CreateOptions stamped with suite's tracking
+// tags, merged with extra (extra wins on key collision).
+func (suite *AWSTestSuite) NewCreateOptions(extra map[string]string) *CreateOptions {
+	tags := map[string]string{
+		tagTestID:    suite.TestID,
+		tagTestSuite: trackingSuiteName,
+		tagCreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	for key, value := range extra {
+		tags[key] = value
+	}
+	return &CreateOptions{Tags: tags}
+}
+
+// TrackedResource is one AWS resource ResourceTracker found via
+// resourcegroupstaggingapi, along with the disposition Cleanup/ReapOrphans
+// gave it.
+type TrackedResource struct {
+	ARN          string            `json:"arn"`
+	Region       string            `json:"region"`
+	CloudControl string            `json:"cloudControlType,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	Disposition  string            `json:"disposition"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// ResourceTrackerReport is the JSON document written per test run, listing
+// every resource ResourceTracker saw and what happened to it -- the AWS
+// analogue of SuiteResult for RunTestManifest (k8s_resource_report_test.go).
+type ResourceTrackerReport struct {
+	TestID      string            `json:"testId"`
+	GeneratedAt time.Time         `json:"generatedAt"`
+	DryRun      bool              `json:"dryRun"`
+	Resources   []TrackedResource `json:"resources"`
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *ResourceTrackerReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal resource tracker report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write resource tracker report %s", path)
+	}
+	return nil
+}
+
+// cloudControlDeleteOrder lists the CloudFormation/CloudControl type names
+// ResourceTracker deletes in, front-to-back, so a resource is never deleted
+// before whatever depends on it -- e.g. instances before the security groups
+// and subnets they sit in, load balancers before the VPC they're attached
+// to. Types not listed here are deleted last, in the order GetResources
+// returns them.
+var cloudControlDeleteOrder = []string{
+	"AWS::ECS::Service",
+	"AWS::EKS::Nodegroup",
+	"AWS::EC2::Instance",
+	"AWS::ElasticLoadBalancingV2::LoadBalancer",
+	"AWS::ElasticLoadBalancingV2::TargetGroup",
+	"AWS::RDS::DBInstance",
+	"AWS::Lambda::Function",
+	"AWS::EC2::NatGateway",
+	"AWS::EC2::SecurityGroup",
+	"AWS::EC2::Subnet",
+	"AWS::EC2::InternetGateway",
+	"AWS::EC2::VPC",
+	"AWS::S3::Bucket",
+	"AWS::KMS::Key",
+	"AWS::IAM::Role",
+}
+
+// arnServiceToCloudControlType maps an ARN's service and resource-type
+// segment to the CloudFormation type name cloudcontrol.GetResource/
+// DeleteResource expect. Unmapped services return "" and are left for a
+// human to clean up rather than guessed at.
+var arnServiceToCloudControlType = map[string]string{
+	"ec2:instance":                      "AWS::EC2::Instance",
+	"ec2:security-group":                "AWS::EC2::SecurityGroup",
+	"ec2:subnet":                        "AWS::EC2::Subnet",
+	"ec2:vpc":                           "AWS::EC2::VPC",
+	"ec2:natgateway":                    "AWS::EC2::NatGateway",
+	"ec2:internet-gateway":              "AWS::EC2::InternetGateway",
+	"elasticloadbalancing:loadbalancer": "AWS::ElasticLoadBalancingV2::LoadBalancer",
+	"elasticloadbalancing:targetgroup":  "AWS::ElasticLoadBalancingV2::TargetGroup",
+	"rds:db":                            "AWS::RDS::DBInstance",
+	"lambda:function":                   "AWS::Lambda::Function",
+	"ecs:service":                       "AWS::ECS::Service",
+	"eks:nodegroup":                     "AWS::EKS::Nodegroup",
+	"s3:":                               "AWS::S3::Bucket",
+	"kms:key":                           "AWS::KMS::Key",
+	"iam:role":                          "AWS::IAM::Role",
+}
+
+// cloudControlTypeForARN derives arn's CloudFormation type name, so
+// ResourceTracker can delete it through cloudcontrol without per-service
+// delete code. s3 ARNs ("arn:aws:s3:::bucket") have no region/account
+// segment and no "/" resource-type separator, so they're special-cased.
+func cloudControlTypeForARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return ""
+	}
+	service := parts[2]
+	resource := parts[5]
+
+	if service == "s3" {
+		return arnServiceToCloudControlType["s3:"]
+	}
+
+	resourceType := resource
+	if idx := strings.IndexAny(resource, "/:"); idx != -1 {
+		resourceType = resource[:idx]
+	}
+	return arnServiceToCloudControlType[service+":"+resourceType]
+}
+
+// ResourceTracker inventories and reclaims every AWS resource a suite run
+// created, using resourcegroupstaggingapi's cross-service GetResources to
+// find them by tag and cloudcontrol's generic GetResource/DeleteResource to
+// remove them, so no per-service delete code is needed as new services are
+// added to the suite.
+type ResourceTracker struct {
+	suite   *AWSTestSuite
+	Regions []string
+	DryRun  bool
+}
+
+// NewResourceTracker returns a ResourceTracker scoped to suite, sweeping
+// suite.Config.AWSRegions if configured, otherwise just suite.Region.
+func NewResourceTracker(suite *AWSTestSuite, dryRun bool) *ResourceTracker {
+	regions := suite.Config.AWSRegions
+	if len(regions) == 0 {
+		regions = []string{suite.Region}
+	}
+	return &ResourceTracker{suite: suite, Regions: regions, DryRun: dryRun}
+}
+
+// taggingClient returns a resourcegroupstaggingapi client for region,
+// reusing suite's lazily-cached clients registry.
+func (tracker *ResourceTracker) taggingClient(region string) *resourcegroupstaggingapi.Client {
+	suite := tracker.suite
+	key := "resourcegroupstaggingapi/" + region
+	if cached, ok := suite.clients.Load(key); ok {
+		return cached.(*resourcegroupstaggingapi.Client)
+	}
+	regionalConfig := suite.AWSConfig.Copy()
+	regionalConfig.Region = region
+	client := resourcegroupstaggingapi.NewFromConfig(regionalConfig)
+	actual, _ := suite.clients.LoadOrStore(key, client)
+	return actual.(*resourcegroupstaggingapi.Client)
+}
+
+// cloudControlClient returns a cloudcontrol client for region.
+func (tracker *ResourceTracker) cloudControlClient(region string) *cloudcontrol.Client {
+	suite := tracker.suite
+	key := "cloudcontrol/" + region
+	if cached, ok := suite.clients.Load(key); ok {
+		return cached.(*cloudcontrol.Client)
+	}
+	regionalConfig := suite.AWSConfig.Copy()
+	regionalConfig.Region = region
+	client := cloudcontrol.NewFromConfig(regionalConfig)
+	actual, _ := suite.clients.LoadOrStore(key, client)
+	return actual.(*cloudcontrol.Client)
+}
+
+// getResources pages through GetResources in region for every resource
+// carrying all of filterTags, translating each ResourceTagMapping into a
+// TrackedResource.
+func (tracker *ResourceTracker) getResources(region string, filterTags map[string]string) ([]TrackedResource, error) {
+	var tagFilters []rgtatypes.TagFilter
+	for key, value := range filterTags {
+		tagFilters = append(tagFilters, rgtatypes.TagFilter{Key: aws.String(key), Values: []string{value}})
+	}
+
+	client := tracker.taggingClient(region)
+	var resources []TrackedResource
+	var paginationToken *string
+	for {
+		output, err := client.GetResources(tracker.suite.Context, &resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters:      tagFilters,
+			PaginationToken: paginationToken,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list tagged resources in %s", region)
+		}
+
+		for _, mapping := range output.ResourceTagMappingList {
+			tags := map[string]string{}
+			for _, tag := range mapping.Tags {
+				tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			arn := aws.ToString(mapping.ResourceARN)
+			resources = append(resources, TrackedResource{
+				ARN:          arn,
+				Region:       region,
+				CloudControl: cloudControlTypeForARN(arn),
+				Tags:         tags,
+			})
+		}
+
+		if output.PaginationToken == nil || *output.PaginationToken == "" {
+			break
+		}
+		paginationToken = output.PaginationToken
+	}
+	return resources, nil
+}
+
+// orderForCleanup sorts resources by cloudControlDeleteOrder, so dependent
+// resources (instances, load balancers) are deleted before what they
+// depend on (security groups, VPCs). Resources with an unmapped or unknown
+// CloudControl type sort last, in their original order.
+func orderForCleanup(resources []TrackedResource) []TrackedResource {
+	rank := func(cloudControlType string) int {
+		for i, typeName := range cloudControlDeleteOrder {
+			if typeName == cloudControlType {
+				return i
+			}
+		}
+		return len(cloudControlDeleteOrder)
+	}
+
+	ordered := make([]TrackedResource, len(resources))
+	copy(ordered, resources)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i].CloudControl) < rank(ordered[j].CloudControl)
+	})
+	return ordered
+}
+
+// deleteResource deletes resource via cloudcontrol, first confirming via
+// GetResource that it still exists (a prior resource's deletion may have
+// already cascaded it away), and sets resource.Disposition/Error.
+func (tracker *ResourceTracker) deleteResource(resource *TrackedResource) {
+	if resource.CloudControl == "" {
+		resource.Disposition = "skipped-unmapped-type"
+		return
+	}
+
+	client := tracker.cloudControlClient(resource.Region)
+	ctx := tracker.suite.Context
+
+	if _, err := client.GetResource(ctx, &cloudcontrol.GetResourceInput{
+		TypeName:   aws.String(resource.CloudControl),
+		Identifier: aws.String(resource.ARN),
+	}); err != nil {
+		resource.Disposition = "already-gone"
+		return
+	}
+
+	if _, err := client.DeleteResource(ctx, &cloudcontrol.DeleteResourceInput{
+		TypeName:   aws.String(resource.CloudControl),
+		Identifier: aws.String(resource.ARN),
+	}); err != nil {
+		resource.Disposition = "failed"
+		resource.Error = err.Error()
+		tracker.suite.Logger.Warn().Err(err).Str("arn", resource.ARN).Msg("failed to delete tracked resource")
+		return
+	}
+
+	resource.Disposition = "deleted"
+	tracker.suite.Logger.Info().Str("arn", resource.ARN).Str("type", resource.CloudControl).Msg("deleted tracked resource")
+}
+
+// Cleanup finds every resource tagged with this suite's TestID across
+// tracker.Regions and, unless tracker.DryRun, deletes them in dependency
+// order via cloudcontrol. It always returns a ResourceTrackerReport
+// recording every ARN it found and its final disposition, dry-run or not.
+func (tracker *ResourceTracker) Cleanup() (*ResourceTrackerReport, error) {
+	return tracker.sweep(map[string]string{tagTestID: tracker.suite.TestID})
+}
+
+// ReapOrphans finds every resource tagged TestSuite=learning-assistant
+// whose CreatedAt tag is older than olderThan, across tracker.Regions, and
+// deletes them unless tracker.DryRun -- for a CI nightly job to catch
+// leaks from a suite run that crashed before its own Cleanup ran.
+func (tracker *ResourceTracker) ReapOrphans(olderThan time.Duration) (*ResourceTrackerReport, error) {
+	resources, err := tracker.findTagged(map[string]string{tagTestSuite: trackingSuiteName})
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var stale []TrackedResource
+	for _, resource := range resources {
+		createdAt, err := time.Parse(time.RFC3339, resource.Tags[tagCreatedAt])
+		if err != nil || createdAt.After(cutoff) {
+			continue
+		}
+		stale = append(stale, resource)
+	}
+
+	return tracker.dispose(stale)
+}
+
+// sweep is Cleanup's shared implementation: find resources matching
+// filterTags, then dispose of them.
+func (tracker *ResourceTracker) sweep(filterTags map[string]string) (*ResourceTrackerReport, error) {
+	resources, err := tracker.findTagged(filterTags)
+	if err != nil {
+		return nil, err
+	}
+	return tracker.dispose(resources)
+}
+
+// findTagged lists resources matching filterTags across every configured
+// region.
+func (tracker *ResourceTracker) findTagged(filterTags map[string]string) ([]TrackedResource, error) {
+	var all []TrackedResource
+	for _, region := range tracker.Regions {
+		resources, err := tracker.getResources(region, filterTags)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resources...)
+	}
+	return all, nil
+}
+
+// dispose orders resources for dependency-safe deletion and either deletes
+// each (recording its disposition) or, in DryRun mode, marks every one
+// "skipped-dry-run" -- then returns the resulting report.
+func (tracker *ResourceTracker) dispose(resources []TrackedResource) (*ResourceTrackerReport, error) {
+	ordered := orderForCleanup(resources)
+	for i := range ordered {
+		if tracker.DryRun {
+			ordered[i].Disposition = "skipped-dry-run"
+			continue
+		}
+		tracker.deleteResource(&ordered[i])
+	}
+
+	return &ResourceTrackerReport{
+		TestID:      tracker.suite.TestID,
+		GeneratedAt: time.Now(),
+		DryRun:      tracker.DryRun,
+		Resources:   ordered,
+	}, nil
+}
+
+// CleanupAndReport runs Cleanup and writes its report to reportPath,
+// returning the cleanup error (if any) ahead of any report-write error so
+// callers that only care about cleanup succeeding can check the first
+// return value alone.
+func (tracker *ResourceTracker) CleanupAndReport(reportPath string) (*ResourceTrackerReport, error) {
+	report, err := tracker.Cleanup()
+	if err != nil {
+		return nil, err
+	}
+	if writeErr := report.WriteReport(reportPath); writeErr != nil {
+		return report, writeErr
+	}
+	return report, nil
+}
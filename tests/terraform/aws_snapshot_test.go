@@ -0,0 +1,270 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// updateSnapshots rewrites each stage's golden file from its current
+// canonicalized snapshot instead of diffing against it, for operators to
+// run after an intentional infrastructure change: go test
+// -run TestAWSInfrastructure ./tests/terraform/... -update-snapshots
+var updateSnapshots = flag.Bool("update-snapshots", false,
+	"rewrite golden snapshot files instead of diffing the live snapshot against them")
+
+// snapshotDir roots every golden file AssertSnapshot reads from or writes
+// to, under testdata/snapshots/<region>/<stage>.json.
+const snapshotDir = "testdata/snapshots"
+
+// arnPattern and timestampPattern match the two most common sources of
+// snapshot noise in a Describe* response: ARNs (account ID plus a random
+// suffix baked in) and timestamps (always "now" relative to when the
+// snapshot was taken). Both get replaced with a stable placeholder so two
+// snapshots of the same logical infrastructure, taken seconds or days
+// apart, canonicalize identically.
+var (
+	arnPattern       = regexp.MustCompile(`^arn:aws:[a-z0-9-]+:[a-z0-9-]*:[0-9]*:.+$`)
+	timestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}`)
+)
+
+// snapshotPath returns the golden file path for region/stage.
+func snapshotPath(region, stage string) string {
+	return filepath.Join(snapshotDir, region, stage+".json")
+}
+
+// CanonicalizeSnapshot marshals raw (typically a Describe*Output struct
+// from a Test* stage) to JSON, then walks the decoded document replacing
+// ARNs, timestamps, and testID with stable placeholders, and re-marshals
+// it indented. Re-marshaling through a generic map[string]interface{} also
+// sorts every object's keys (encoding/json's default for maps), which is
+// what makes the result diff-stable regardless of the field order the SDK
+// happened to populate the struct in.
+func CanonicalizeSnapshot(raw interface{}, testID string) ([]byte, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal snapshot input")
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, errors.Wrap(err, "failed to decode snapshot input")
+	}
+
+	canonical, err := json.MarshalIndent(redactSnapshotValue(decoded, testID), "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal canonical snapshot")
+	}
+	return canonical, nil
+}
+
+// redactSnapshotValue recursively applies redactSnapshotString to every
+// string leaf in value, rebuilding maps and slices along the way.
+func redactSnapshotValue(value interface{}, testID string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			out[key] = redactSnapshotValue(val, testID)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = redactSnapshotValue(val, testID)
+		}
+		return out
+	case string:
+		return redactSnapshotString(v, testID)
+	default:
+		return v
+	}
+}
+
+// redactSnapshotString replaces s with a stable placeholder if it looks
+// like an ARN, a timestamp, or matches testID verbatim; otherwise it's
+// returned unchanged.
+func redactSnapshotString(s, testID string) string {
+	switch {
+	case arnPattern.MatchString(s):
+		return "<ARN>"
+	case timestampPattern.MatchString(s):
+		return "<TIMESTAMP>"
+	case testID != "" && s == testID:
+		return "<TEST_ID>"
+	default:
+		return s
+	}
+}
+
+// AssertSnapshot canonicalizes raw via CanonicalizeSnapshot and compares it
+// against the checked-in golden file for suite.Region/stage, failing t with
+// a unified diff on mismatch. Run with -update-snapshots to rewrite the
+// golden file from the current snapshot instead of diffing against it --
+// the same two-mode shape as gcp_asset_inventory_test.go's
+// -update-gcp-baseline.
+func AssertSnapshot(t *testing.T, suite *AWSTestSuite, stage string, raw interface{}) {
+	canonical, err := CanonicalizeSnapshot(raw, suite.TestID)
+	require.NoError(t, err, "failed to canonicalize snapshot for stage %s", stage)
+
+	path := snapshotPath(suite.Region, stage)
+
+	if *updateSnapshots {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755), "failed to create snapshot directory for %s", path)
+		require.NoError(t, os.WriteFile(path, canonical, 0o644), "failed to write snapshot %s", path)
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("no golden snapshot at %s for stage %s; run with -update-snapshots to create one", path, stage)
+	}
+	require.NoError(t, err, "failed to read golden snapshot %s", path)
+
+	if string(golden) == string(canonical) {
+		return
+	}
+	t.Fatalf("snapshot mismatch for stage %s (%s):\n%s", stage, path, unifiedDiff(string(golden), string(canonical)))
+}
+
+// unifiedDiff returns a minimal unified-style diff between a and b: a line
+// prefixed "-" for one only a has, "+" for one only b has, and an unmarked
+// line for one shared by both at that point in the sequence. It's a plain
+// line-level LCS diff (no external dependency), which is plenty for the
+// small, already-indented JSON documents this file compares.
+func unifiedDiff(a, b string) string {
+	aLines := strings.Split(a, "\n")
+	bLines := strings.Split(b, "\n")
+
+	lcs := longestCommonSubsequence(aLines, bLines)
+
+	var out strings.Builder
+	i, j, k := 0, 0, 0
+	for i < len(aLines) || j < len(bLines) {
+		switch {
+		case k < len(lcs) && i < len(aLines) && j < len(bLines) && aLines[i] == lcs[k] && bLines[j] == lcs[k]:
+			fmt.Fprintf(&out, "  %s\n", aLines[i])
+			i++
+			j++
+			k++
+		case i < len(aLines) && (k >= len(lcs) || aLines[i] != lcs[k]):
+			fmt.Fprintf(&out, "- %s\n", aLines[i])
+			i++
+		case j < len(bLines):
+			fmt.Fprintf(&out, "+ %s\n", bLines[j])
+			j++
+		}
+	}
+	return out.String()
+}
+
+// longestCommonSubsequence returns the longest common subsequence of lines
+// shared by a and b, via the standard O(len(a)*len(b)) DP table.
+func longestCommonSubsequence(a, b []string) []string {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else if dp[i+1][j] >= dp[i][j+1] {
+				dp[i][j] = dp[i+1][j]
+			} else {
+				dp[i][j] = dp[i][j+1]
+			}
+		}
+	}
+
+	var lcs []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lcs = append(lcs, a[i])
+			i++
+			j++
+		case dp[i+1][j] >= dp[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return lcs
+}
+
+// DriftCompareInput is one side of a CompareSnapshotDrift comparison:
+// Label identifies it in the report ("account-111111111111", "2026-07-01T00:00Z",
+// ...) and Snapshots holds one CanonicalizeSnapshot result per stage.
+type DriftCompareInput struct {
+	Label     string
+	Snapshots map[string][]byte
+}
+
+// SnapshotDriftFinding is one stage's comparison between two
+// DriftCompareInputs.
+type SnapshotDriftFinding struct {
+	Stage string `json:"stage"`
+	Equal bool   `json:"equal"`
+	Diff  string `json:"diff,omitempty"`
+}
+
+// SnapshotDriftReport is CompareSnapshotDrift's output.
+type SnapshotDriftReport struct {
+	GeneratedAt time.Time              `json:"generatedAt"`
+	Left        string                 `json:"left"`
+	Right       string                 `json:"right"`
+	Findings    []SnapshotDriftFinding `json:"findings"`
+}
+
+// WriteReport marshals report to path as JSON.
+func (report *SnapshotDriftReport) WriteReport(path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot drift report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write snapshot drift report %s", path)
+	}
+	return nil
+}
+
+// CompareSnapshotDrift diffs left against right stage-by-stage, independent
+// of any golden file -- the "drift" mode of this suite's snapshot testing:
+// point it at two DriftCompareInputs built from two different accounts'
+// live snapshots, or the same account's snapshots captured at two points in
+// time, to get a structured per-stage diff report.
+func CompareSnapshotDrift(left, right DriftCompareInput) *SnapshotDriftReport {
+	stages := make(map[string]bool, len(left.Snapshots)+len(right.Snapshots))
+	for stage := range left.Snapshots {
+		stages[stage] = true
+	}
+	for stage := range right.Snapshots {
+		stages[stage] = true
+	}
+
+	findings := make([]SnapshotDriftFinding, 0, len(stages))
+	for stage := range stages {
+		l, r := left.Snapshots[stage], right.Snapshots[stage]
+		finding := SnapshotDriftFinding{Stage: stage, Equal: string(l) == string(r)}
+		if !finding.Equal {
+			finding.Diff = unifiedDiff(string(l), string(r))
+		}
+		findings = append(findings, finding)
+	}
+
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Stage < findings[j].Stage })
+
+	return &SnapshotDriftReport{GeneratedAt: time.Now(), Left: left.Label, Right: right.Label, Findings: findings}
+}
@@ -0,0 +1,89 @@
+package test
+
+import (
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// awsAllowedCipherSuites is the default TLSPolicy.AllowedCipherSuites this
+// suite checks negotiated connections against: TLS 1.2 AEAD suites plus
+// every TLS 1.3 suite, which crypto/tls always negotiates regardless of
+// CipherSuites configuration.
+var awsAllowedCipherSuites = []uint16{
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	tls.TLS_AES_128_GCM_SHA256,
+	tls.TLS_AES_256_GCM_SHA384,
+	tls.TLS_CHACHA20_POLY1305_SHA256,
+}
+
+// TestSSLConfiguration dials every HTTPS endpoint in outputs (keys ending
+// in "_endpoint"), asserting TLS 1.2+, an unexpired certificate with a SAN
+// matching the endpoint's host, and a negotiated cipher suite from
+// awsAllowedCipherSuites. It evaluates every endpoint before returning, so
+// a single failing endpoint doesn't hide violations on the others.
+func (suite *AWSTestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing SSL configuration")
+
+	var endpoints []string
+	for key, value := range outputs {
+		if !strings.HasSuffix(key, "_endpoint") {
+			continue
+		}
+		if endpoint, ok := value.(string); ok && endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return errors.New("outputs contain no *_endpoint values to test SSL configuration against")
+	}
+
+	var failed []string
+	for _, endpoint := range endpoints {
+		address := endpoint
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(endpoint, "443")
+		}
+		host := hostOnly(address)
+
+		dialer := &net.Dialer{Timeout: 10 * time.Second}
+		conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: host})
+		if err != nil {
+			failed = append(failed, address)
+			suite.Logger.Error().Str("endpoint", address).Err(err).Msg("TLS handshake failed")
+			continue
+		}
+
+		policy := TLSPolicy{
+			MinDaysRemaining:    30,
+			MinVersion:          tls.VersionTLS12,
+			RequiredSANs:        []string{host},
+			AllowedCipherSuites: awsAllowedCipherSuites,
+		}
+		violations := AssertTLSCertificate(conn.ConnectionState(), policy)
+		conn.Close()
+
+		event := suite.Logger.Info()
+		if len(violations) > 0 {
+			event = suite.Logger.Error()
+			failed = append(failed, address)
+		}
+		event.Str("endpoint", address).Int("violations", len(violations)).Msg("TLS configuration checked")
+		for _, v := range violations {
+			suite.Logger.Error().Str("endpoint", address).Err(v).Msg("TLS policy violation")
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("%d of %d endpoint(s) failed SSL configuration checks: %s", len(failed), len(endpoints), strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// AccountRegion is one (account, region) fan-out target for AWSSuiteConfig.Run
+// -- a flatter counterpart to TargetAccount (which groups a whole account's
+// regions under one entry), matching how callers naturally enumerate a
+// compliance/DR matrix one row per account/region pair.
+type AccountRegion struct {
+	AccountID  string
+	RoleARN    string
+	ExternalID string
+	Region     string
+}
+
+// AWSSuiteConfig ties together a suite's authentication strategy (Credentials)
+// and the (account, region) matrix it runs across (Targets), so a caller
+// building an AWSTestSuite no longer has to go through NewAWSTestSuite's
+// default credential chain: Run resolves Credentials once for Region, then
+// fans out across Targets the same way MultiAccountSuite.Run does.
+type AWSSuiteConfig struct {
+	Credentials CredentialProvider
+	Region      string
+	TestConfig  TestConfig
+	Targets     []AccountRegion
+	MaxParallel int
+}
+
+// groupAccountRegions collapses targets into one TargetAccount per distinct
+// (AccountID, RoleARN, ExternalID), each carrying every Region that shares
+// those three fields -- the shape MultiAccountSuite.Run expects.
+func groupAccountRegions(targets []AccountRegion) []TargetAccount {
+	type key struct {
+		accountID  string
+		roleARN    string
+		externalID string
+	}
+	order := make([]key, 0, len(targets))
+	byKey := make(map[key]*TargetAccount, len(targets))
+
+	for _, target := range targets {
+		k := key{accountID: target.AccountID, roleARN: target.RoleARN, externalID: target.ExternalID}
+		account, ok := byKey[k]
+		if !ok {
+			account = &TargetAccount{AccountID: target.AccountID, RoleARN: target.RoleARN, ExternalID: target.ExternalID}
+			byKey[k] = account
+			order = append(order, k)
+		}
+		account.Regions = append(account.Regions, target.Region)
+	}
+
+	accounts := make([]TargetAccount, 0, len(order))
+	for _, k := range order {
+		accounts = append(accounts, *byKey[k])
+	}
+	return accounts
+}
+
+// Run builds a base AWSTestSuite authenticated via cfg.Credentials in
+// cfg.Region, then fans testBody out across every (account, region) pair in
+// cfg.Targets through MultiAccountSuite.Run, bounded to cfg.MaxParallel
+// concurrent subtests and tagged "<accountID>/<region>". It fails t
+// immediately if the base suite can't be constructed; per-target failures
+// are instead aggregated into the returned MultiAccountReport.
+func (cfg AWSSuiteConfig) Run(t *testing.T, testBody func(*testing.T, *AWSTestSuite)) *MultiAccountReport {
+	t.Helper()
+
+	base, err := NewAWSTestSuite(cfg.Region, cfg.TestConfig, WithCredentialsProvider(cfg.Credentials))
+	require.NoError(t, errors.Wrap(err, "failed to build base AWS test suite from AWSSuiteConfig"))
+
+	accounts := groupAccountRegions(cfg.Targets)
+	multiAccount := NewMultiAccountSuite(base, accounts, WithMultiAccountParallel(cfg.MaxParallel))
+	return multiAccount.Run(t, testBody)
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// targetsFileFlag lets an operator point a run at a YAML matrix file without
+// editing test-config.yaml -- the TestMatrix counterpart to
+// aws_cost_preflight_test.go's -cost-cap flag.
+var targetsFileFlag = flag.String("targets-file", "",
+	"path to a YAML file listing {account_id, role_arn, external_id, regions[]} targets for TestMatrix")
+
+// TargetsFileSchema is the on-disk shape LoadTargetsFile parses: a plain
+// list of targets, one entry per account.
+type TargetsFileSchema struct {
+	Targets []TargetsFileEntry `yaml:"targets"`
+}
+
+// TargetsFileEntry is one YAML entry in a targets file, mapped 1:1 onto a
+// TargetAccount.
+type TargetsFileEntry struct {
+	AccountID  string   `yaml:"account_id"`
+	RoleARN    string   `yaml:"role_arn"`
+	ExternalID string   `yaml:"external_id"`
+	Regions    []string `yaml:"regions"`
+}
+
+// LoadTargetsFile reads and parses a YAML targets file at path into
+// TargetAccount entries for TestMatrix/MultiAccountSuite.
+func LoadTargetsFile(path string) ([]TargetAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read targets file %s", path)
+	}
+
+	var schema TargetsFileSchema
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse targets file %s", path)
+	}
+
+	targets := make([]TargetAccount, 0, len(schema.Targets))
+	for _, entry := range schema.Targets {
+		targets = append(targets, TargetAccount{
+			AccountID:  entry.AccountID,
+			RoleARN:    entry.RoleARN,
+			ExternalID: entry.ExternalID,
+			Regions:    entry.Regions,
+		})
+	}
+	return targets, nil
+}
+
+// EffectiveTargets resolves TestMatrix's target list: the -targets-file flag
+// if set, else fallback (e.g. targets hand-written in test-config.yaml) --
+// the same flag-overrides-config precedent PreflightBudget's -cost-cap flag
+// follows.
+func EffectiveTargets(fallback []TargetAccount) ([]TargetAccount, error) {
+	if *targetsFileFlag == "" {
+		return fallback, nil
+	}
+	return LoadTargetsFile(*targetsFileFlag)
+}
+
+// ServiceTest is one named Test* method TestMatrix runs against every
+// (account, region) combination in its matrix, nested under its own t.Run so
+// a failure is keyed "<account>/<region>/<service>" instead of just
+// "<account>/<region>".
+type ServiceTest struct {
+	Name string
+	Run  func(*testing.T, *AWSTestSuite)
+}
+
+// TestMatrix drives a set of ServiceTests across a set of TargetAccounts, so
+// a single go test invocation covers every (account, region, service)
+// combination and aggregates every one's outcome into a single
+// MultiAccountReport keyed by account+region -- the org-wide "run every
+// Test* method against every account/region" entry point MultiAccountSuite's
+// single-testBody Run doesn't provide on its own.
+type TestMatrix struct {
+	Targets     []TargetAccount
+	Services    []ServiceTest
+	MaxParallel int
+}
+
+// NewTestMatrix returns a TestMatrix covering targets and services.
+func NewTestMatrix(targets []TargetAccount, services []ServiceTest) *TestMatrix {
+	return &TestMatrix{Targets: targets, Services: services}
+}
+
+// Run drives every ServiceTest in tm.Services, each in its own
+// t.Run("<service>") nested under MultiAccountSuite's "<account>/<region>"
+// subtest, against every (account, region) pair in tm.Targets.
+func (tm *TestMatrix) Run(t *testing.T, base *AWSTestSuite) *MultiAccountReport {
+	multiAccount := NewMultiAccountSuite(base, tm.Targets, WithMultiAccountParallel(tm.MaxParallel))
+
+	return multiAccount.Run(t, func(t *testing.T, accountSuite *AWSTestSuite) {
+		for _, service := range tm.Services {
+			service := service
+			t.Run(service.Name, func(t *testing.T) {
+				service.Run(t, accountSuite)
+			})
+		}
+	})
+}
@@ -0,0 +1,54 @@
+// Package awsassert is a fluent, declarative assertion DSL over live AWS
+// resources: a constructor fetches the resource once, chained predicate
+// methods record failures instead of stopping at the first one, and Check
+// reports every accumulated failure as a single structured t.Errorf call.
+// It replaces the ad-hoc assert.NotEmpty/assert.True calls scattered across
+// this suite's compliance, performance, and DR tests with a vocabulary
+// named after the thing being asserted about.
+package awsassert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// assertion is embedded by every resource-specific assertion type in this
+// package. It gives each one a constructor-time fetch error (the resource
+// didn't exist or the describe call failed) and an accumulating list of
+// predicate failures, both reported together by Check.
+type assertion struct {
+	resourceKind string
+	name         string
+	fetchErr     error
+	failures     []string
+}
+
+// failf records a failed predicate; it does not stop subsequent predicates
+// in the chain from running.
+func (a *assertion) failf(format string, args ...interface{}) {
+	a.failures = append(a.failures, fmt.Sprintf(format, args...))
+}
+
+// errNotFound builds the fetch error every resource constructor in this
+// package uses when a describe call succeeds but returns zero matches.
+func errNotFound(resourceKind, name string) error {
+	return fmt.Errorf("%s %q not found", resourceKind, name)
+}
+
+// Check reports a.fetchErr, or every accumulated predicate failure as one
+// t.Errorf call, so a caller sees every violation an assertion chain found
+// in a single test run instead of stopping at the first.
+func (a *assertion) Check(t *testing.T) {
+	t.Helper()
+
+	if a.fetchErr != nil {
+		t.Errorf("%s %q: %v", a.resourceKind, a.name, a.fetchErr)
+		return
+	}
+	if len(a.failures) == 0 {
+		return
+	}
+	t.Errorf("%s %q failed %d assertion(s):\n  - %s",
+		a.resourceKind, a.name, len(a.failures), strings.Join(a.failures, "\n  - "))
+}
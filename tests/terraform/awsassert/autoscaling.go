@@ -0,0 +1,101 @@
+package awsassert
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+)
+
+// ASGClient is the Auto Scaling API surface AutoScalingGroup needs.
+type ASGClient interface {
+	DescribeAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput, opts ...func(*autoscaling.Options)) (*autoscaling.DescribeAutoScalingGroupsOutput, error)
+}
+
+// ASGAssertion is a fluent assertion chain over one Auto Scaling group.
+type ASGAssertion struct {
+	assertion
+	ctx   context.Context
+	group *asgtypes.AutoScalingGroup
+}
+
+// AutoScalingGroup fetches the named Auto Scaling group via client and
+// starts a fluent assertion chain over it.
+func AutoScalingGroup(ctx context.Context, client ASGClient, name string) *ASGAssertion {
+	a := &ASGAssertion{assertion: assertion{resourceKind: "auto scaling group", name: name}, ctx: ctx}
+
+	output, err := client.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{name},
+	})
+	if err != nil {
+		a.fetchErr = err
+		return a
+	}
+	if len(output.AutoScalingGroups) == 0 {
+		a.fetchErr = errNotFound("auto scaling group", name)
+		return a
+	}
+	a.group = &output.AutoScalingGroups[0]
+	return a
+}
+
+// HasMinSize asserts the group's MinSize is at least min.
+func (a *ASGAssertion) HasMinSize(min int32) *ASGAssertion {
+	if a.group == nil {
+		return a
+	}
+	if a.group.MinSize == nil || *a.group.MinSize < min {
+		a.failf("expected min size >= %d, got %v", min, aws.ToInt32(a.group.MinSize))
+	}
+	return a
+}
+
+// HasMaxSizeAtLeast asserts the group's MaxSize is at least min; passing
+// the group's own MinSize gives the invariant TestPerformance's original
+// Auto Scaling subtest checked directly.
+func (a *ASGAssertion) HasMaxSizeAtLeast(min int32) *ASGAssertion {
+	if a.group == nil {
+		return a
+	}
+	if a.group.MaxSize == nil || *a.group.MaxSize < min {
+		a.failf("expected max size >= %d, got %v", min, aws.ToInt32(a.group.MaxSize))
+	}
+	return a
+}
+
+// DesiredCapacityWithinBounds asserts DesiredCapacity falls within
+// [MinSize, MaxSize].
+func (a *ASGAssertion) DesiredCapacityWithinBounds() *ASGAssertion {
+	if a.group == nil {
+		return a
+	}
+	desired, min, max := aws.ToInt32(a.group.DesiredCapacity), aws.ToInt32(a.group.MinSize), aws.ToInt32(a.group.MaxSize)
+	if desired < min || desired > max {
+		a.failf("desired capacity %d is outside [%d, %d]", desired, min, max)
+	}
+	return a
+}
+
+// SpansAZs asserts the group spans at least min availability zones.
+func (a *ASGAssertion) SpansAZs(min int) *ASGAssertion {
+	if a.group == nil {
+		return a
+	}
+	if len(a.group.AvailabilityZones) < min {
+		a.failf("expected to span >= %d availability zones, got %d", min, len(a.group.AvailabilityZones))
+	}
+	return a
+}
+
+// HealthCheckType asserts the group's HealthCheckType equals want (e.g.
+// "ELB" or "EC2").
+func (a *ASGAssertion) HealthCheckType(want string) *ASGAssertion {
+	if a.group == nil {
+		return a
+	}
+	if aws.ToString(a.group.HealthCheckType) != want {
+		a.failf("expected health check type %q, got %q", want, aws.ToString(a.group.HealthCheckType))
+	}
+	return a
+}
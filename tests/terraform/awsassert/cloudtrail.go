@@ -0,0 +1,62 @@
+package awsassert
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	cttypes "github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+)
+
+// CloudTrailClient is the CloudTrail API surface CloudTrail needs.
+type CloudTrailClient interface {
+	DescribeTrails(ctx context.Context, input *cloudtrail.DescribeTrailsInput, opts ...func(*cloudtrail.Options)) (*cloudtrail.DescribeTrailsOutput, error)
+}
+
+// CloudTrailAssertion is a fluent assertion chain over one CloudTrail
+// trail.
+type CloudTrailAssertion struct {
+	assertion
+	trail *cttypes.Trail
+}
+
+// CloudTrail fetches the named trail via client and starts a fluent
+// assertion chain over it.
+func CloudTrail(ctx context.Context, client CloudTrailClient, name string) *CloudTrailAssertion {
+	a := &CloudTrailAssertion{assertion: assertion{resourceKind: "CloudTrail trail", name: name}}
+
+	output, err := client.DescribeTrails(ctx, &cloudtrail.DescribeTrailsInput{TrailNameList: []string{name}})
+	if err != nil {
+		a.fetchErr = err
+		return a
+	}
+	if len(output.TrailList) == 0 {
+		a.fetchErr = errNotFound("CloudTrail trail", name)
+		return a
+	}
+	a.trail = &output.TrailList[0]
+	return a
+}
+
+// MultiRegion asserts the trail is configured as multi-region.
+func (a *CloudTrailAssertion) MultiRegion() *CloudTrailAssertion {
+	if a.trail == nil {
+		return a
+	}
+	if !aws.ToBool(a.trail.IsMultiRegionTrail) {
+		a.failf("expected multi-region trail")
+	}
+	return a
+}
+
+// LogFileValidationEnabled asserts the trail has log file integrity
+// validation enabled.
+func (a *CloudTrailAssertion) LogFileValidationEnabled() *CloudTrailAssertion {
+	if a.trail == nil {
+		return a
+	}
+	if !aws.ToBool(a.trail.LogFileValidationEnabled) {
+		a.failf("expected log file validation enabled")
+	}
+	return a
+}
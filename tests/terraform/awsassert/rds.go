@@ -0,0 +1,78 @@
+package awsassert
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+)
+
+// RDSClient is the RDS API surface RDSInstance needs.
+type RDSClient interface {
+	DescribeDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput, opts ...func(*rds.Options)) (*rds.DescribeDBInstancesOutput, error)
+}
+
+// RDSAssertion is a fluent assertion chain over one RDS instance.
+type RDSAssertion struct {
+	assertion
+	instance *rdstypes.DBInstance
+}
+
+// RDSInstance fetches the named RDS instance via client and starts a fluent
+// assertion chain over it.
+func RDSInstance(ctx context.Context, client RDSClient, id string) *RDSAssertion {
+	a := &RDSAssertion{assertion: assertion{resourceKind: "RDS instance", name: id}}
+
+	output, err := client.DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: aws.String(id)})
+	if err != nil {
+		a.fetchErr = err
+		return a
+	}
+	if len(output.DBInstances) == 0 {
+		a.fetchErr = errNotFound("RDS instance", id)
+		return a
+	}
+	a.instance = &output.DBInstances[0]
+	return a
+}
+
+// MultiAZ asserts the instance is deployed Multi-AZ.
+func (a *RDSAssertion) MultiAZ() *RDSAssertion {
+	if a.instance == nil {
+		return a
+	}
+	if !aws.ToBool(a.instance.MultiAZ) {
+		a.failf("expected Multi-AZ deployment")
+	}
+	return a
+}
+
+// BackupRetention asserts the instance's backup retention period is at
+// least min days.
+func (a *RDSAssertion) BackupRetention(min int32) *RDSAssertion {
+	if a.instance == nil {
+		return a
+	}
+	if aws.ToInt32(a.instance.BackupRetentionPeriod) < min {
+		a.failf("expected backup retention >= %d days, got %d", min, aws.ToInt32(a.instance.BackupRetentionPeriod))
+	}
+	return a
+}
+
+// EncryptedAt asserts the instance is encrypted at rest with kmsARN as its
+// KMS key. An empty kmsARN only asserts storage encryption is enabled,
+// without pinning a specific key.
+func (a *RDSAssertion) EncryptedAt(kmsARN string) *RDSAssertion {
+	if a.instance == nil {
+		return a
+	}
+	if !aws.ToBool(a.instance.StorageEncrypted) {
+		a.failf("expected storage encryption enabled")
+		return a
+	}
+	if kmsARN != "" && aws.ToString(a.instance.KmsKeyId) != kmsARN {
+		a.failf("expected KMS key %q, got %q", kmsARN, aws.ToString(a.instance.KmsKeyId))
+	}
+	return a
+}
@@ -0,0 +1,86 @@
+package awsassert
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Client is the S3 API surface S3Bucket needs.
+type S3Client interface {
+	GetBucketReplication(ctx context.Context, input *s3.GetBucketReplicationInput, opts ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error)
+	GetBucketEncryption(ctx context.Context, input *s3.GetBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketVersioning(ctx context.Context, input *s3.GetBucketVersioningInput, opts ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error)
+}
+
+// S3Assertion is a fluent assertion chain over one S3 bucket. Unlike
+// ASGAssertion/RDSAssertion, which fetch a single describe call upfront,
+// each predicate here calls its own distinct S3 API and records a fetch
+// error as a predicate failure -- there's no single "describe bucket" call
+// that covers replication, encryption, and versioning together.
+type S3Assertion struct {
+	assertion
+	ctx    context.Context
+	client S3Client
+}
+
+// S3Bucket starts a fluent assertion chain over the named S3 bucket.
+func S3Bucket(ctx context.Context, client S3Client, name string) *S3Assertion {
+	return &S3Assertion{assertion: assertion{resourceKind: "S3 bucket", name: name}, ctx: ctx, client: client}
+}
+
+// HasReplication asserts the bucket has at least one replication rule
+// configured.
+func (a *S3Assertion) HasReplication() *S3Assertion {
+	output, err := a.client.GetBucketReplication(a.ctx, &s3.GetBucketReplicationInput{Bucket: aws.String(a.name)})
+	if err != nil {
+		a.failf("expected replication configured, but GetBucketReplication failed: %v", err)
+		return a
+	}
+	if output.ReplicationConfiguration == nil || len(output.ReplicationConfiguration.Rules) == 0 {
+		a.failf("expected at least one replication rule")
+	}
+	return a
+}
+
+// HasEncryption asserts the bucket's default encryption uses SSE-KMS with
+// kmsARN as the key. An empty kmsARN only asserts that SSE-KMS default
+// encryption is configured, without pinning a specific key.
+func (a *S3Assertion) HasEncryption(kmsARN string) *S3Assertion {
+	output, err := a.client.GetBucketEncryption(a.ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(a.name)})
+	if err != nil {
+		a.failf("expected default encryption configured, but GetBucketEncryption failed: %v", err)
+		return a
+	}
+	if output.ServerSideEncryptionConfiguration == nil || len(output.ServerSideEncryptionConfiguration.Rules) == 0 {
+		a.failf("expected at least one default encryption rule")
+		return a
+	}
+
+	for _, rule := range output.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
+		}
+		keyID := aws.ToString(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+		if kmsARN == "" || keyID == kmsARN {
+			return a
+		}
+	}
+	a.failf("expected default encryption with KMS key %q", kmsARN)
+	return a
+}
+
+// VersioningEnabled asserts the bucket has versioning enabled.
+func (a *S3Assertion) VersioningEnabled() *S3Assertion {
+	output, err := a.client.GetBucketVersioning(a.ctx, &s3.GetBucketVersioningInput{Bucket: aws.String(a.name)})
+	if err != nil {
+		a.failf("expected versioning enabled, but GetBucketVersioning failed: %v", err)
+		return a
+	}
+	if output.Status != s3types.BucketVersioningStatusEnabled {
+		a.failf("expected versioning status Enabled, got %q", output.Status)
+	}
+	return a
+}
@@ -0,0 +1,96 @@
+package awsload
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// CloudWatchClient is the CloudWatch API surface PollAutoscalingMetrics
+// needs.
+type CloudWatchClient interface {
+	GetMetricData(ctx context.Context, input *cloudwatch.GetMetricDataInput, opts ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// MetricSeries is one CloudWatch metric's datapoints over a polled window.
+type MetricSeries struct {
+	Label      string
+	Timestamps []time.Time
+	Values     []float64
+}
+
+// AutoscalingMetrics is the signal set RunStress uses to assert that
+// autoscaling actually triggered under load.
+type AutoscalingMetrics struct {
+	DesiredCapacity    MetricSeries
+	TargetResponseTime MetricSeries
+	RDSCPUUtilization  MetricSeries
+}
+
+// ScaledUp reports whether the ASG's desired capacity grew between the
+// first and last datapoint polled. Fewer than two datapoints (the window
+// was too short, or the ASG wasn't found) is "no".
+func (m AutoscalingMetrics) ScaledUp() bool {
+	if len(m.DesiredCapacity.Values) < 2 {
+		return false
+	}
+	return m.DesiredCapacity.Values[len(m.DesiredCapacity.Values)-1] > m.DesiredCapacity.Values[0]
+}
+
+// PollAutoscalingMetrics fetches asgName's GroupDesiredCapacity,
+// albArnSuffix's TargetResponseTime, and rdsInstanceID's CPUUtilization
+// over [start, end] in a single GetMetricData call, at 1-minute
+// resolution. Any of the three names may be empty, in which case that
+// series' dimension is omitted and its MetricSeries comes back empty.
+func PollAutoscalingMetrics(ctx context.Context, client CloudWatchClient, asgName, albArnSuffix, rdsInstanceID string, start, end time.Time) (AutoscalingMetrics, error) {
+	queries := []cwtypes.MetricDataQuery{
+		metricQuery("desired_capacity", "AWS/AutoScaling", "GroupDesiredCapacity", "AutoScalingGroupName", asgName),
+		metricQuery("target_response_time", "AWS/ApplicationELB", "TargetResponseTime", "LoadBalancer", albArnSuffix),
+		metricQuery("rds_cpu_utilization", "AWS/RDS", "CPUUtilization", "DBInstanceIdentifier", rdsInstanceID),
+	}
+
+	output, err := client.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+	})
+	if err != nil {
+		return AutoscalingMetrics{}, err
+	}
+
+	series := make(map[string]MetricSeries, len(output.MetricDataResults))
+	for _, result := range output.MetricDataResults {
+		series[aws.ToString(result.Id)] = MetricSeries{
+			Label:      aws.ToString(result.Label),
+			Timestamps: result.Timestamps,
+			Values:     result.Values,
+		}
+	}
+	return AutoscalingMetrics{
+		DesiredCapacity:    series["desired_capacity"],
+		TargetResponseTime: series["target_response_time"],
+		RDSCPUUtilization:  series["rds_cpu_utilization"],
+	}, nil
+}
+
+// metricQuery builds a single-dimension MetricDataQuery. A blank
+// dimensionValue still produces a valid query (CloudWatch just returns no
+// datapoints for it), so callers don't need to conditionally build the
+// slice themselves.
+func metricQuery(id, namespace, metricName, dimensionName, dimensionValue string) cwtypes.MetricDataQuery {
+	return cwtypes.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cwtypes.MetricStat{
+			Metric: &cwtypes.Metric{
+				Namespace:  aws.String(namespace),
+				MetricName: aws.String(metricName),
+				Dimensions: []cwtypes.Dimension{{Name: aws.String(dimensionName), Value: aws.String(dimensionValue)}},
+			},
+			Period: aws.Int32(60),
+			Stat:   aws.String("Average"),
+		},
+	}
+}
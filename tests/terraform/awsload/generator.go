@@ -0,0 +1,197 @@
+package awsload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hdrhistogram "github.com/HdrHistogram/hdrhistogram-go"
+	"github.com/pkg/errors"
+)
+
+// Result summarizes a completed Scenario run.
+type Result struct {
+	Scenario      string        `json:"scenario"`
+	Requests      int64         `json:"requests"`
+	Errors        int64         `json:"errors"`
+	ErrorRate     float64       `json:"error_rate"`
+	ThroughputRPS float64       `json:"throughput_rps"`
+	P50           time.Duration `json:"p50_ns"`
+	P95           time.Duration `json:"p95_ns"`
+	P99           time.Duration `json:"p99_ns"`
+	Violations    []string      `json:"violations,omitempty"`
+}
+
+// WriteReport renders r as JSON to path.
+func (r Result) WriteReport(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal load report")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write load report to %s", path)
+	}
+	return nil
+}
+
+// hdrHistogramMaxMicros bounds the histogram at 30s, far beyond any
+// latency this generator's http.Client (30s timeout) could ever record.
+const hdrHistogramMaxMicros = int64(30 * time.Second / time.Microsecond)
+
+// Run issues HTTP requests against scenario.Targets (cycled round-robin)
+// at a token-bucket-limited rate -- ramping linearly from 0 to scenario.RPS
+// over scenario.RampUp, then held at scenario.RPS for the remainder of
+// scenario.Duration -- through a fixed worker pool pulling jobs off a
+// channel, recording each request's latency into an HDR histogram, and
+// returns a Result checked against scenario.Assertions. A non-nil error
+// means at least one assertion was violated; Result is still populated and
+// usable either way.
+func Run(ctx context.Context, scenario Scenario) (Result, error) {
+	if len(scenario.Targets) == 0 {
+		return Result{}, errors.New("scenario has no targets")
+	}
+
+	workers := scenario.Workers
+	if workers <= 0 {
+		workers = scenario.RPS
+	}
+	if workers <= 0 {
+		workers = 10
+	}
+
+	hist := hdrhistogram.New(1, hdrHistogramMaxMicros, 3)
+	var histMu sync.Mutex
+	var requests, failed int64
+
+	jobs := make(chan Target, workers*2)
+	var wg sync.WaitGroup
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for target := range jobs {
+				start := time.Now()
+				ok := issue(client, target)
+				elapsed := time.Since(start)
+
+				atomic.AddInt64(&requests, 1)
+				if !ok {
+					atomic.AddInt64(&failed, 1)
+				}
+				histMu.Lock()
+				hist.RecordValue(min64(elapsed.Microseconds(), hdrHistogramMaxMicros))
+				histMu.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+	deadline := start.Add(scenario.Duration)
+	bucket := newTokenBucket(scenario.RPS, scenario.RampUp, start)
+
+	targetIdx := 0
+drive:
+	for {
+		now := time.Now()
+		if !now.Before(deadline) {
+			break drive
+		}
+		select {
+		case <-ctx.Done():
+			break drive
+		default:
+		}
+		if !bucket.take(now) {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		jobs <- scenario.Targets[targetIdx%len(scenario.Targets)]
+		targetIdx++
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	result := Result{
+		Scenario: scenario.Name,
+		Requests: atomic.LoadInt64(&requests),
+		Errors:   atomic.LoadInt64(&failed),
+	}
+	if result.Requests > 0 {
+		result.ErrorRate = float64(result.Errors) / float64(result.Requests)
+	}
+	if elapsed > 0 {
+		result.ThroughputRPS = float64(result.Requests) / elapsed.Seconds()
+	}
+	result.P50 = time.Duration(hist.ValueAtQuantile(50)) * time.Microsecond
+	result.P95 = time.Duration(hist.ValueAtQuantile(95)) * time.Microsecond
+	result.P99 = time.Duration(hist.ValueAtQuantile(99)) * time.Microsecond
+
+	result.Violations = checkAssertions(result, scenario.Assertions)
+	if len(result.Violations) > 0 {
+		return result, errors.Errorf("%d assertion violation(s): %s", len(result.Violations), strings.Join(result.Violations, "; "))
+	}
+	return result, nil
+}
+
+// issue fires one HTTP request and reports whether it succeeded (a
+// non-5xx status). An empty target.Method is sent as GET.
+func issue(client *http.Client, target Target) bool {
+	method := target.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var body io.Reader
+	if len(target.Body) > 0 {
+		body = strings.NewReader(string(target.Body))
+	}
+	req, err := http.NewRequest(method, target.URL, body)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode < 500
+}
+
+// checkAssertions returns one violation string per SLO in a that result
+// fails; a zero-valued field in a is never checked.
+func checkAssertions(result Result, a LoadAssertions) []string {
+	var violations []string
+	if a.P50Latency > 0 && result.P50 > a.P50Latency {
+		violations = append(violations, fmt.Sprintf("p50 latency %s exceeds %s", result.P50, a.P50Latency))
+	}
+	if a.P95Latency > 0 && result.P95 > a.P95Latency {
+		violations = append(violations, fmt.Sprintf("p95 latency %s exceeds %s", result.P95, a.P95Latency))
+	}
+	if a.P99Latency > 0 && result.P99 > a.P99Latency {
+		violations = append(violations, fmt.Sprintf("p99 latency %s exceeds %s", result.P99, a.P99Latency))
+	}
+	if a.MaxErrorRate > 0 && result.ErrorRate > a.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.4f exceeds %.4f", result.ErrorRate, a.MaxErrorRate))
+	}
+	if a.MinThroughput > 0 && result.ThroughputRPS < a.MinThroughput {
+		violations = append(violations, fmt.Sprintf("throughput %.1f rps below %.1f rps", result.ThroughputRPS, a.MinThroughput))
+	}
+	return violations
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,45 @@
+// Package awsload is a token-bucket-limited HTTP load generator: a fixed
+// worker pool pulls Targets off a job channel at a rate ramped linearly up
+// to Scenario.RPS, recording per-request latency into an HDR histogram for
+// accurate tail percentiles, and the resulting Result is checked against
+// Scenario.Assertions. cloudwatch.go layers ASG/ALB/RDS metric polling on
+// top so a stress run can assert autoscaling actually triggered.
+package awsload
+
+import (
+	"time"
+)
+
+// Target is one HTTP request this generator can issue.
+type Target struct {
+	Method string
+	URL    string
+	Body   []byte
+}
+
+// LoadAssertions are the SLOs a Scenario's Result is checked against. A
+// zero field means "don't check this" -- there's no implicit default.
+type LoadAssertions struct {
+	P50Latency    time.Duration
+	P95Latency    time.Duration
+	P99Latency    time.Duration
+	MaxErrorRate  float64
+	MinThroughput float64
+}
+
+// Scenario describes one load-generator run: Targets are cycled
+// round-robin and issued at RPS, ramping linearly from 0 to RPS over
+// RampUp, for Duration, then checked against Assertions.
+type Scenario struct {
+	Name       string
+	RPS        int
+	Duration   time.Duration
+	RampUp     time.Duration
+	Targets    []Target
+	Assertions LoadAssertions
+
+	// Workers sizes the fixed worker pool pulling jobs off the generator's
+	// job channel; it defaults to RPS (so no worker is ever idle waiting
+	// on the next tick) when unset.
+	Workers int
+}
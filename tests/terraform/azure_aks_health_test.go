@@ -0,0 +1,223 @@
+package test
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// aksExpectedAddOns are the kube-system components every managed cluster is
+// expected to run regardless of the add-ons a particular test config enables.
+var aksExpectedAddOns = []string{"coredns", "kube-proxy", "metrics-server"}
+
+// AKSAgentPoolHealth is the per-agent-pool slice of an AKSHealth report.
+type AKSAgentPoolHealth struct {
+	Name                string
+	VMSize              string
+	OrchestratorVersion string
+	MinCount            int32
+	MaxCount            int32
+	AvailabilityZones   []string
+}
+
+// AKSHealth is the result of deep-probing a single AKS cluster: its
+// in-cluster kube-apiserver state plus its ARM-reported agent pools and
+// add-ons.
+type AKSHealth struct {
+	ClusterName          string
+	NodesReady           bool
+	CoreAddOnsReady      map[string]bool
+	CrashLoopingPods     []string
+	AgentPools           []AKSAgentPoolHealth
+	OmsAgentConfigured   bool
+	AzurePolicyEnabled   bool
+	KeyVaultSecretsBound bool
+}
+
+// ProbeAKSCluster builds a kubeconfig-backed client-go clientset from the
+// cluster's admin credentials and reports node, core add-on, agent pool and
+// add-on health in a single AKSHealth value. The cluster's resource group is
+// parsed from its ARM resource ID rather than threaded in by the caller,
+// since the cluster is the only source of truth for it while paging.
+func (suite *AzureTestSuite) ProbeAKSCluster(cluster *armcontainerservice.ManagedCluster) (*AKSHealth, error) {
+	if cluster.Name == nil {
+		return nil, errors.New("cannot probe an AKS cluster with no name")
+	}
+	resourceGroup, err := resourceGroupFromID(stringOrEmpty(cluster.ID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot determine resource group for AKS cluster %s", *cluster.Name)
+	}
+
+	health := &AKSHealth{
+		ClusterName:     *cluster.Name,
+		CoreAddOnsReady: make(map[string]bool),
+	}
+
+	clientset, err := suite.buildAKSClientset(resourceGroup, *cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list nodes for AKS cluster %s", *cluster.Name)
+	}
+	health.NodesReady = len(nodes.Items) > 0
+	for _, node := range nodes.Items {
+		if !nodeIsReady(&node) {
+			health.NodesReady = false
+		}
+	}
+
+	pods, err := clientset.CoreV1().Pods("kube-system").List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list kube-system pods for AKS cluster %s", *cluster.Name)
+	}
+	for _, addOn := range aksExpectedAddOns {
+		health.CoreAddOnsReady[addOn] = false
+	}
+	for _, pod := range pods.Items {
+		for _, addOn := range aksExpectedAddOns {
+			if strings.Contains(pod.Name, addOn) && podIsReady(&pod) {
+				health.CoreAddOnsReady[addOn] = true
+			}
+		}
+		if podIsCrashLooping(&pod) {
+			health.CrashLoopingPods = append(health.CrashLoopingPods, pod.Name)
+		}
+	}
+
+	if cluster.Properties != nil {
+		health.OmsAgentConfigured = aksAddOnEnabled(cluster, "omsagent")
+		health.AzurePolicyEnabled = aksAddOnEnabled(cluster, "azurepolicy")
+		health.KeyVaultSecretsBound = aksAddOnEnabled(cluster, "azureKeyvaultSecretsProvider")
+	}
+
+	pools, err := suite.listAKSAgentPools(resourceGroup, *cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	health.AgentPools = pools
+
+	return health, nil
+}
+
+// buildAKSClientset fetches the cluster's admin kubeconfig via ARM and turns
+// it into a client-go clientset for in-cluster probing.
+func (suite *AzureTestSuite) buildAKSClientset(resourceGroup, clusterName string) (*kubernetes.Clientset, error) {
+	resp, err := suite.ContainerService.ListClusterAdminCredentials(suite.Context, resourceGroup, clusterName, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch admin credentials for AKS cluster %s", clusterName)
+	}
+	if len(resp.Kubeconfigs) == 0 || resp.Kubeconfigs[0].Value == nil {
+		return nil, errors.Errorf("AKS cluster %s returned no admin kubeconfig", clusterName)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(resp.Kubeconfigs[0].Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse kubeconfig for AKS cluster %s", clusterName)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build clientset for AKS cluster %s", clusterName)
+	}
+	return clientset, nil
+}
+
+// listAKSAgentPools enumerates a cluster's agent pools via the ARM
+// AgentPoolsClient, reporting VM size, orchestrator version, autoscaler
+// bounds and availability-zone spread for each.
+func (suite *AzureTestSuite) listAKSAgentPools(resourceGroup, clusterName string) ([]AKSAgentPoolHealth, error) {
+	var pools []AKSAgentPoolHealth
+
+	pager := suite.AgentPools.NewListPager(resourceGroup, clusterName, nil)
+	for pager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, pager)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list agent pools for AKS cluster %s", clusterName)
+		}
+		for _, pool := range page.Value {
+			if pool.Name == nil || pool.Properties == nil {
+				continue
+			}
+			health := AKSAgentPoolHealth{Name: *pool.Name}
+			if pool.Properties.VMSize != nil {
+				health.VMSize = *pool.Properties.VMSize
+			}
+			if pool.Properties.OrchestratorVersion != nil {
+				health.OrchestratorVersion = *pool.Properties.OrchestratorVersion
+			}
+			if pool.Properties.MinCount != nil {
+				health.MinCount = *pool.Properties.MinCount
+			}
+			if pool.Properties.MaxCount != nil {
+				health.MaxCount = *pool.Properties.MaxCount
+			}
+			for _, zone := range pool.Properties.AvailabilityZones {
+				if zone != nil {
+					health.AvailabilityZones = append(health.AvailabilityZones, *zone)
+				}
+			}
+			pools = append(pools, health)
+		}
+	}
+
+	return pools, nil
+}
+
+// resourceGroupFromID extracts the resource group segment from an ARM
+// resource ID of the form
+// "/subscriptions/{sub}/resourceGroups/{rg}/providers/...".
+func resourceGroupFromID(id string) (string, error) {
+	parts := strings.Split(id, "/")
+	for i, part := range parts {
+		if strings.EqualFold(part, "resourceGroups") && i+1 < len(parts) {
+			return parts[i+1], nil
+		}
+	}
+	return "", errors.Errorf("resource ID %q has no resourceGroups segment", id)
+}
+
+func aksAddOnEnabled(cluster *armcontainerservice.ManagedCluster, name string) bool {
+	if cluster.Properties == nil || cluster.Properties.AddonProfiles == nil {
+		return false
+	}
+	profile, ok := cluster.Properties.AddonProfiles[name]
+	return ok && profile != nil && profile.Enabled != nil && *profile.Enabled
+}
+
+func nodeIsReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func podIsReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func podIsCrashLooping(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return true
+		}
+	}
+	return false
+}
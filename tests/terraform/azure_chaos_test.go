@@ -0,0 +1,205 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// ChaosScenario describes one Azure Chaos Studio drill and the recovery
+// SLOs it's expected to meet.
+type ChaosScenario struct {
+	Name           string   `yaml:"name"`
+	ResourceGroup  string   `yaml:"resource_group"`
+	ExperimentName string   `yaml:"experiment_name"`
+	ProbeURL       string   `yaml:"probe_url"`
+	MaxDowntime    string   `yaml:"max_downtime"`
+	MaxErrorRate   float64  `yaml:"max_error_rate"`
+	TargetRTO      string   `yaml:"target_rto"`
+	TargetRPO      string   `yaml:"target_rpo"`
+	RollbackSteps  []string `yaml:"rollback_steps"`
+}
+
+// StorageFailoverScenario describes a geo-replication failover drill for a
+// GRS/GZRS storage account: trigger (or dry-run) the account failover, then
+// verify the failover endpoint serves traffic within TargetRTO.
+type StorageFailoverScenario struct {
+	Name             string `yaml:"name"`
+	ResourceGroup    string `yaml:"resource_group"`
+	AccountName      string `yaml:"account_name"`
+	DryRun           bool   `yaml:"dry_run"`
+	FailoverEndpoint string `yaml:"failover_endpoint"`
+	TargetRTO        string `yaml:"target_rto"`
+}
+
+// DRPlan is the top-level shape of a disaster-recovery drill YAML: a set of
+// Chaos Studio scenarios and storage/DB failover drills.
+type DRPlan struct {
+	Scenarios       []ChaosScenario           `yaml:"scenarios"`
+	StorageFailover []StorageFailoverScenario `yaml:"storage_failover"`
+}
+
+// LoadDRPlan reads and parses a DR plan YAML file.
+func LoadDRPlan(path string) (*DRPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read DR plan %s", path)
+	}
+	var plan DRPlan
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse DR plan %s", path)
+	}
+	return &plan, nil
+}
+
+// DRDrillResult is the outcome of running one ChaosScenario: the Chaos
+// Studio experiment that was launched, the observed downtime/error rate,
+// and the full health-probe timeline for post-mortem attachment.
+type DRDrillResult struct {
+	Scenario      ChaosScenario
+	ExperimentID  string
+	Downtime      time.Duration
+	ErrorRate     float64
+	TimeToRecover time.Duration
+	Timeline      []ProbeResult
+}
+
+// RunDRDrill launches scenario's Chaos Studio experiment, polls prober at a
+// fixed interval until the experiment completes (recording every probe
+// result into the timeline), and derives downtime/error-rate/time-to-recover
+// from that timeline.
+func (suite *AzureTestSuite) RunDRDrill(scenario ChaosScenario, prober Prober, pollInterval time.Duration) (*DRDrillResult, error) {
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	poller, err := suite.Chaos.BeginStart(suite.Context, scenario.ResourceGroup, scenario.ExperimentName, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to start chaos experiment %s", scenario.ExperimentName)
+	}
+
+	experimentID := fmt.Sprintf(
+		"/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Chaos/experiments/%s",
+		suite.SubscriptionID, scenario.ResourceGroup, scenario.ExperimentName)
+	result := &DRDrillResult{Scenario: scenario, ExperimentID: experimentID}
+
+	done := make(chan error, 1)
+	go func() {
+		_, pollErr := pollARM(suite.Context, suite.Logger, poller)
+		done <- pollErr
+	}()
+
+	start := time.Now()
+	var firstFailure, recovered time.Time
+	var failureCount int
+
+polling:
+	for {
+		select {
+		case pollErr := <-done:
+			if pollErr != nil {
+				return result, errors.Wrapf(pollErr, "chaos experiment %s did not complete", scenario.ExperimentName)
+			}
+			break polling
+		case <-time.After(pollInterval):
+			probe := prober.Probe(suite.Context)
+			result.Timeline = append(result.Timeline, probe)
+			if !probe.Success {
+				failureCount++
+				if firstFailure.IsZero() {
+					firstFailure = time.Now()
+				}
+				recovered = time.Time{}
+			} else if !firstFailure.IsZero() && recovered.IsZero() {
+				recovered = time.Now()
+			}
+		}
+	}
+
+	if len(result.Timeline) > 0 {
+		result.ErrorRate = float64(failureCount) / float64(len(result.Timeline))
+	}
+	if !firstFailure.IsZero() {
+		end := recovered
+		if end.IsZero() {
+			end = time.Now()
+		}
+		result.Downtime = end.Sub(firstFailure)
+		result.TimeToRecover = end.Sub(start)
+	}
+
+	return result, nil
+}
+
+// TriggerStorageFailover initiates (or, when dryRun is true, only logs) an
+// account failover for GRS/GZRS storage accounts, to exercise the
+// geo-replication DR path without an active Chaos Studio experiment.
+func (suite *AzureTestSuite) TriggerStorageFailover(resourceGroup, accountName string, dryRun bool) error {
+	if dryRun {
+		suite.Logger.Info().Str("account", accountName).Msg("Dry-run: would trigger storage account failover")
+		return nil
+	}
+
+	poller, err := suite.StorageClients.Accounts.BeginFailover(suite.Context, resourceGroup, accountName, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start failover for storage account %s", accountName)
+	}
+	if _, err := pollARM(suite.Context, suite.Logger, poller); err != nil {
+		return errors.Wrapf(err, "failover did not complete for storage account %s", accountName)
+	}
+	return nil
+}
+
+// AssertDRDrill runs scenario's Chaos Studio experiment and fails t if the
+// experiment errors, the observed downtime/error rate exceed the scenario's
+// SLOs, or time-to-recover exceeds TargetRTO. On failure the assertion
+// message carries the experiment ID and probe timeline for post-mortem.
+func (suite *AzureTestSuite) AssertDRDrill(t *testing.T, scenario ChaosScenario, prober Prober, pollInterval time.Duration) *DRDrillResult {
+	result, err := suite.RunDRDrill(scenario, prober, pollInterval)
+	require.NoError(t, err, "chaos experiment %s failed to run to completion", scenario.ExperimentName)
+
+	if scenario.MaxDowntime != "" {
+		maxDowntime, err := time.ParseDuration(scenario.MaxDowntime)
+		require.NoError(t, err, "invalid max_downtime %q for scenario %s", scenario.MaxDowntime, scenario.Name)
+		require.LessOrEqualf(t, result.Downtime, maxDowntime,
+			"scenario %s exceeded max downtime: experiment=%s timeline=%+v", scenario.Name, result.ExperimentID, result.Timeline)
+	}
+	require.LessOrEqualf(t, result.ErrorRate, scenario.MaxErrorRate,
+		"scenario %s exceeded max error rate: experiment=%s timeline=%+v", scenario.Name, result.ExperimentID, result.Timeline)
+	if scenario.TargetRTO != "" {
+		targetRTO, err := time.ParseDuration(scenario.TargetRTO)
+		require.NoError(t, err, "invalid target_rto %q for scenario %s", scenario.TargetRTO, scenario.Name)
+		require.LessOrEqualf(t, result.TimeToRecover, targetRTO,
+			"scenario %s missed its RTO: experiment=%s timeline=%+v", scenario.Name, result.ExperimentID, result.Timeline)
+	}
+
+	return result
+}
+
+// AssertStorageFailoverDrill triggers (or dry-runs) scenario's storage
+// account failover, then probes FailoverEndpoint until it serves traffic,
+// failing t if that doesn't happen within TargetRTO.
+func (suite *AzureTestSuite) AssertStorageFailoverDrill(t *testing.T, scenario StorageFailoverScenario) {
+	start := time.Now()
+	err := suite.TriggerStorageFailover(scenario.ResourceGroup, scenario.AccountName, scenario.DryRun)
+	require.NoError(t, err, "failover drill %s should trigger cleanly", scenario.Name)
+
+	if scenario.FailoverEndpoint == "" {
+		return
+	}
+	prober := HTTPProber{URL: scenario.FailoverEndpoint, MaxRetries: 5}
+	probe := prober.Probe(suite.Context)
+	require.True(t, probe.Success, "failover endpoint %s should be reachable after failover: %v", scenario.FailoverEndpoint, probe.Err)
+
+	if scenario.TargetRTO != "" {
+		targetRTO, err := time.ParseDuration(scenario.TargetRTO)
+		require.NoError(t, err, "invalid target_rto %q for storage failover %s", scenario.TargetRTO, scenario.Name)
+		require.LessOrEqualf(t, time.Since(start), targetRTO,
+			"storage failover %s missed its RTO", scenario.Name)
+	}
+}
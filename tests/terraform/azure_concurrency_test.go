@@ -0,0 +1,54 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// runConcurrent runs each fn in jobs on a bounded worker pool of size
+// maxWorkers (at least 1), collecting results in the same order as jobs.
+// It returns the first error encountered; in-flight jobs are allowed to
+// finish but no further jobs are started once an error is seen.
+func runConcurrent[T any](maxWorkers int, jobs []func() (T, error)) ([]T, error) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
+
+	results := make([]T, len(jobs))
+	errs := make([]error, len(jobs))
+
+	sem := make(chan struct{}, maxWorkers)
+	var wg sync.WaitGroup
+	var failed sync.Once
+	var firstErr error
+	var stop int32
+
+	for i, job := range jobs {
+		if atomic.LoadInt32(&stop) != 0 {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, job func() (T, error)) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, err := job()
+			results[i] = result
+			errs[i] = err
+			if err != nil {
+				failed.Do(func() { firstErr = err })
+				atomic.StoreInt32(&stop, 1)
+			}
+		}(i, job)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return results, errors.Wrap(firstErr, "one or more concurrent enumeration jobs failed")
+	}
+	return results, nil
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
+	"github.com/pkg/errors"
+)
+
+// defaultAnomalyK is the default MAD multiplier used when a policy doesn't
+// configure cost_anomaly_k.
+const defaultAnomalyK = 3.0
+
+// DailyCost is one resource group's spend for one calendar day.
+type DailyCost struct {
+	Date          time.Time
+	ResourceGroup string
+	CostUSD       float64
+}
+
+// CostAnomaly flags a day where a resource group's spend exceeded
+// median + K*MAD over its trailing window.
+type CostAnomaly struct {
+	ResourceGroup string    `json:"resource_group"`
+	Date          time.Time `json:"date"`
+	CostUSD       float64   `json:"cost_usd"`
+	MedianUSD     float64   `json:"median_usd"`
+	MAD           float64   `json:"mad"`
+	K             float64   `json:"k"`
+}
+
+// FetchDailyCosts pulls per-day, per-resource-group usage details for the
+// last N days from the Consumption UsageDetails API.
+func (suite *AzureTestSuite) FetchDailyCosts(scope string, days int) ([]DailyCost, error) {
+	var costs []DailyCost
+
+	pager := suite.Consumption.NewListPager(scope, nil)
+	cutoff := time.Now().UTC().AddDate(0, 0, -days)
+	for pager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, pager)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list usage details for scope %s", scope)
+		}
+		for _, detail := range page.Value {
+			legacy, ok := detail.(*armconsumption.UsageDetail)
+			if !ok || legacy.Properties == nil {
+				continue
+			}
+			props := legacy.Properties
+			if props.Date == nil || props.Cost == nil || props.ResourceGroup == nil {
+				continue
+			}
+			if props.Date.Before(cutoff) {
+				continue
+			}
+			costs = append(costs, DailyCost{
+				Date:          *props.Date,
+				ResourceGroup: *props.ResourceGroup,
+				CostUSD:       *props.Cost,
+			})
+		}
+	}
+
+	return costs, nil
+}
+
+// DetectCostAnomalies groups daily costs by resource group and flags any day
+// whose cost exceeds the group's median plus k times its median absolute
+// deviation (MAD). k defaults to defaultAnomalyK when <= 0.
+func DetectCostAnomalies(costs []DailyCost, k float64) []CostAnomaly {
+	if k <= 0 {
+		k = defaultAnomalyK
+	}
+
+	byGroup := make(map[string][]DailyCost)
+	for _, c := range costs {
+		byGroup[c.ResourceGroup] = append(byGroup[c.ResourceGroup], c)
+	}
+
+	var anomalies []CostAnomaly
+	for group, series := range byGroup {
+		values := make([]float64, len(series))
+		for i, c := range series {
+			values[i] = c.CostUSD
+		}
+		median := medianOf(values)
+		mad := medianAbsoluteDeviation(values, median)
+		threshold := median + k*mad
+
+		for _, c := range series {
+			if mad > 0 && c.CostUSD > threshold {
+				anomalies = append(anomalies, CostAnomaly{
+					ResourceGroup: group,
+					Date:          c.Date,
+					CostUSD:       c.CostUSD,
+					MedianUSD:     median,
+					MAD:           mad,
+					K:             k,
+				})
+			}
+		}
+	}
+
+	return anomalies
+}
+
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func medianAbsoluteDeviation(values []float64, median float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		d := v - median
+		if d < 0 {
+			d = -d
+		}
+		deviations[i] = d
+	}
+	return medianOf(deviations)
+}
+
+// BurnRateForecast projects month-to-date spend to a full-month figure using
+// a flat daily burn rate.
+type BurnRateForecast struct {
+	MonthToDateUSD float64 `json:"month_to_date_usd"`
+	ForecastUSD    float64 `json:"forecast_usd"`
+	BudgetUSD      float64 `json:"budget_usd"`
+	DaysElapsed    int     `json:"days_elapsed"`
+	DaysInMonth    int     `json:"days_in_month"`
+	ProjectedOver  bool    `json:"projected_over_budget"`
+}
+
+// ComputeBurnRate projects actualUSD (spent so far this month, as of asOf)
+// to a full-month figure and flags whether that forecast would exceed
+// budgetUSD.
+func ComputeBurnRate(actualUSD, budgetUSD float64, asOf time.Time) BurnRateForecast {
+	daysElapsed := asOf.Day()
+	daysInMonth := time.Date(asOf.Year(), asOf.Month()+1, 0, 0, 0, 0, 0, asOf.Location()).Day()
+
+	forecast := actualUSD
+	if daysElapsed > 0 {
+		forecast = actualUSD / float64(daysElapsed) * float64(daysInMonth)
+	}
+
+	return BurnRateForecast{
+		MonthToDateUSD: actualUSD,
+		ForecastUSD:    forecast,
+		BudgetUSD:      budgetUSD,
+		DaysElapsed:    daysElapsed,
+		DaysInMonth:    daysInMonth,
+		ProjectedOver:  forecast > budgetUSD,
+	}
+}
+
+// CostManagementArtifact is the machine-readable snapshot AssertCostBudgets
+// and its anomaly/forecast helpers can be assembled into for downstream CI
+// reporting.
+type CostManagementArtifact struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Reports     []*CostReport               `json:"reports"`
+	Anomalies   []CostAnomaly               `json:"anomalies"`
+	Forecasts   map[string]BurnRateForecast `json:"forecasts"`
+}
+
+// WriteCostReportArtifact serializes the artifact as JSON to path so CI can
+// publish it alongside test results.
+func WriteCostReportArtifact(path string, artifact CostManagementArtifact) error {
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost management artifact")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write cost management artifact to %s", path)
+	}
+	return nil
+}
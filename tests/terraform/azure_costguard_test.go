@@ -0,0 +1,237 @@
+package test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// BudgetPolicy declares one spending guardrail for a scope (typically a
+// resource group ARM ID), checked against both actual month-to-date spend
+// and the matching Budget resource configured in Azure.
+type BudgetPolicy struct {
+	Scope      string  `yaml:"scope"`
+	MonthlyUSD float64 `yaml:"monthly_usd"`
+	TagKey     string  `yaml:"tag_key"`
+	TagValue   string  `yaml:"tag_value"`
+	AlertPct   []int   `yaml:"alert_pct"`
+	// SoftThresholdPct/HardThresholdPct are percentages of MonthlyUSD.
+	// Crossing the soft threshold logs a warning; crossing the hard
+	// threshold fails the test. Both default to 100 (soft) and 100 (hard)
+	// when unset, i.e. only the plain monthly_usd check applies.
+	SoftThresholdPct float64 `yaml:"soft_threshold_pct"`
+	HardThresholdPct float64 `yaml:"hard_threshold_pct"`
+}
+
+// CostBudgetPolicyFile is the top-level shape of a costguard policy YAML.
+type CostBudgetPolicyFile struct {
+	Budgets []BudgetPolicy `yaml:"budgets"`
+}
+
+// LoadCostBudgetPolicy reads and parses a costguard policy YAML file.
+func LoadCostBudgetPolicy(path string) (*CostBudgetPolicyFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cost budget policy %s", path)
+	}
+
+	var policy CostBudgetPolicyFile
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cost budget policy %s", path)
+	}
+	return &policy, nil
+}
+
+// CostReport is the per-budget spend breakdown AssertCostBudgets produces,
+// exposed so callers embedding AzureTestSuite can inspect or re-log it.
+type CostReport struct {
+	Scope           string
+	ActualUSD       float64
+	ByResourceGroup map[string]float64
+	ByService       map[string]float64
+}
+
+// AssertCostBudgets loads a costguard policy from policyPath, queries actual
+// month-to-date spend per budget scope grouped by resource group and
+// service, and fails t when actual spend exceeds the declared monthly_usd or
+// when the corresponding Azure Budget resource doesn't match the declared
+// thresholds. It returns one CostReport per budget for callers that want the
+// breakdown beyond pass/fail.
+func (suite *AzureTestSuite) AssertCostBudgets(t *testing.T, policyPath string) []*CostReport {
+	policy, err := LoadCostBudgetPolicy(policyPath)
+	if err != nil {
+		t.Fatalf("failed to load cost budget policy: %v", err)
+	}
+
+	var reports []*CostReport
+	for _, budget := range policy.Budgets {
+		report, err := suite.assertBudget(t, budget)
+		if err != nil {
+			t.Errorf("cost budget check failed for scope %s: %v", budget.Scope, err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+func (suite *AzureTestSuite) assertBudget(t *testing.T, budget BudgetPolicy) (*CostReport, error) {
+	report, err := suite.queryMonthToDateCost(budget.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	suite.Logger.Info().
+		Str("scope", budget.Scope).
+		Float64("actual_usd", report.ActualUSD).
+		Float64("monthly_usd", budget.MonthlyUSD).
+		Interface("by_service", report.ByService).
+		Interface("by_resource_group", report.ByResourceGroup).
+		Msg("Cost report")
+
+	softPct := budget.SoftThresholdPct
+	if softPct == 0 {
+		softPct = 100
+	}
+	hardPct := budget.HardThresholdPct
+	if hardPct == 0 {
+		hardPct = 100
+	}
+	softLimit := budget.MonthlyUSD * softPct / 100
+	hardLimit := budget.MonthlyUSD * hardPct / 100
+
+	if report.ActualUSD > softLimit {
+		suite.Logger.Warn().
+			Str("scope", budget.Scope).
+			Float64("actual_usd", report.ActualUSD).
+			Float64("soft_limit_usd", softLimit).
+			Msg("Cost is over the soft budget threshold")
+	}
+	assert.LessOrEqualf(t, report.ActualUSD, hardLimit,
+		"scope %s spent $%.2f, exceeding its $%.2f hard budget threshold", budget.Scope, report.ActualUSD, hardLimit)
+
+	if err := suite.assertBudgetResource(t, budget); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// queryMonthToDateCost runs a Cost Management usage query for the current
+// billing month, grouped by ResourceGroupName and ServiceName.
+func (suite *AzureTestSuite) queryMonthToDateCost(scope string) (*CostReport, error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	definition := armcostmanagement.QueryDefinition{
+		Type:      to.Ptr(armcostmanagement.ExportTypeUsage),
+		Timeframe: to.Ptr(armcostmanagement.TimeframeTypeCustom),
+		TimePeriod: &armcostmanagement.QueryTimePeriod{
+			From: to.Ptr(monthStart),
+			To:   to.Ptr(now),
+		},
+		Dataset: &armcostmanagement.QueryDataset{
+			Granularity: to.Ptr(armcostmanagement.GranularityTypeNone),
+			Aggregation: map[string]*armcostmanagement.QueryAggregation{
+				"totalCost": {Name: to.Ptr("PreTaxCost"), Function: to.Ptr(armcostmanagement.FunctionTypeSum)},
+			},
+			Grouping: []*armcostmanagement.QueryGrouping{
+				{Type: to.Ptr(armcostmanagement.QueryColumnTypeDimension), Name: to.Ptr("ResourceGroupName")},
+				{Type: to.Ptr(armcostmanagement.QueryColumnTypeDimension), Name: to.Ptr("ServiceName")},
+			},
+		},
+	}
+
+	var result armcostmanagement.QueryClientUsageResponse
+	err := retryARM(suite.Context, suite.Logger, defaultARMRetryPolicy, func() error {
+		var queryErr error
+		result, queryErr = suite.CostQuery.Usage(suite.Context, scope, definition, nil)
+		return queryErr
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cost usage query failed for scope %s", scope)
+	}
+
+	report := &CostReport{
+		Scope:           scope,
+		ByResourceGroup: make(map[string]float64),
+		ByService:       make(map[string]float64),
+	}
+	if result.Properties == nil {
+		return report, nil
+	}
+
+	columnIndex := make(map[string]int)
+	for i, column := range result.Properties.Columns {
+		if column != nil && column.Name != nil {
+			columnIndex[*column.Name] = i
+		}
+	}
+
+	for _, row := range result.Properties.Rows {
+		cost := floatFromRow(row, columnIndex["PreTaxCost"])
+		resourceGroup := stringFromRow(row, columnIndex["ResourceGroupName"])
+		service := stringFromRow(row, columnIndex["ServiceName"])
+
+		report.ActualUSD += cost
+		report.ByResourceGroup[resourceGroup] += cost
+		report.ByService[service] += cost
+	}
+
+	return report, nil
+}
+
+// assertBudgetResource fetches the Azure Budget resource matching the
+// policy's scope and asserts its configured amount and alert thresholds
+// match what the policy declares.
+func (suite *AzureTestSuite) assertBudgetResource(t *testing.T, budget BudgetPolicy) error {
+	resp, err := suite.CostBudgets.Get(suite.Context, budget.Scope, suite.Config.ProjectName, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch Budget resource for scope %s", budget.Scope)
+	}
+	if resp.Properties == nil || resp.Properties.Amount == nil {
+		return errors.Errorf("Budget resource for scope %s has no configured amount", budget.Scope)
+	}
+
+	assert.Equalf(t, budget.MonthlyUSD, *resp.Properties.Amount,
+		"Budget resource for scope %s should be configured for $%.2f", budget.Scope, budget.MonthlyUSD)
+
+	configuredPct := make(map[int]bool)
+	for _, notification := range resp.Properties.Notifications {
+		if notification != nil && notification.Threshold != nil {
+			configuredPct[int(*notification.Threshold)] = true
+		}
+	}
+	for _, pct := range budget.AlertPct {
+		assert.Truef(t, configuredPct[pct],
+			"Budget resource for scope %s should have an alert notification at %d%%", budget.Scope, pct)
+	}
+
+	return nil
+}
+
+func floatFromRow(row []interface{}, index int) float64 {
+	if index < 0 || index >= len(row) {
+		return 0
+	}
+	if v, ok := row[index].(float64); ok {
+		return v
+	}
+	return 0
+}
+
+func stringFromRow(row []interface{}, index int) string {
+	if index < 0 || index >= len(row) {
+		return ""
+	}
+	if v, ok := row[index].(string); ok {
+		return v
+	}
+	return ""
+}
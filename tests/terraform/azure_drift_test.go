@@ -0,0 +1,348 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
+	"github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+)
+
+// driftWhitelistedFields are the only attributes AttributeDrift is computed
+// over; everything else (IDs, timestamps, computed-only fields, ...) is
+// expected to differ between state and the live resource and is ignored.
+var driftWhitelistedFields = []string{"sku", "tags", "network_rules", "minimum_tls_version"}
+
+// resourceGraphScaleThreshold is the resource-count cutoff above which
+// BuildDriftResourceGraph switches from per-service NewListPager calls to a
+// single Microsoft.ResourceGraph/resources query.
+const resourceGraphScaleThreshold = 200
+
+// ManagedResource is one azurerm_* resource read out of Terraform state,
+// keyed by its Terraform address, with only the whitelisted attributes kept.
+type ManagedResource struct {
+	Address    string
+	Type       string
+	Name       string
+	Attributes map[string]interface{}
+}
+
+// DriftKind classifies one DriftFinding.
+type DriftKind string
+
+const (
+	DriftUnmanaged DriftKind = "unmanaged" // exists in Azure, not in state
+	DriftMissing   DriftKind = "missing"   // exists in state, not in Azure
+	DriftAttribute DriftKind = "attribute" // exists in both, whitelisted field differs
+)
+
+// DriftFinding is one resource-level difference between Terraform state and
+// the live Azure resources enumerated from AzureTestSuite.
+type DriftFinding struct {
+	Kind     DriftKind
+	Resource string
+	Field    string      `json:",omitempty"`
+	Expected interface{} `json:",omitempty"`
+	Actual   interface{} `json:",omitempty"`
+}
+
+// DriftReport is the structured output of TestDrift: every finding plus
+// when the comparison ran, for artifact storage and CI gating.
+type DriftReport struct {
+	GeneratedAt time.Time
+	Findings    []DriftFinding
+}
+
+// ShowTerraformState runs `terraform show -json` against dir and parses the
+// result with terraform-json, giving a typed view of the currently applied
+// state without needing a live backend round-trip per caller.
+func ShowTerraformState(dir string) (*tfjson.State, error) {
+	cmd := exec.Command("terraform", "show", "-json")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run terraform show -json in %s", dir)
+	}
+
+	var state tfjson.State
+	if err := json.Unmarshal(out, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to parse terraform show output")
+	}
+	return &state, nil
+}
+
+// ExtractManagedResources walks state's root and child modules, returning
+// every azurerm_* resource with its whitelisted attributes normalized into
+// the same shape BuildDriftResourceGraph produces for live resources.
+func ExtractManagedResources(state *tfjson.State) []ManagedResource {
+	if state == nil || state.Values == nil || state.Values.RootModule == nil {
+		return nil
+	}
+
+	var resources []ManagedResource
+	var walk func(module *tfjson.StateModule)
+	walk = func(module *tfjson.StateModule) {
+		for _, r := range module.Resources {
+			attrs := map[string]interface{}{}
+			for _, field := range driftWhitelistedFields {
+				if v, ok := r.AttributeValues[field]; ok {
+					attrs[field] = v
+				}
+			}
+			name, _ := r.AttributeValues["name"].(string)
+			resources = append(resources, ManagedResource{
+				Address:    r.Address,
+				Type:       r.Type,
+				Name:       name,
+				Attributes: attrs,
+			})
+		}
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+
+	return resources
+}
+
+// QueryResourceGraph runs query against Microsoft.ResourceGraph/resources
+// for subscriptionID, returning each matching row as a ResourceGraphNode.
+// Used instead of per-service pagers once the live resource count exceeds
+// resourceGraphScaleThreshold.
+func QueryResourceGraph(ctx context.Context, client *armresourcegraph.Client, subscriptionID, query string) ([]ResourceGraphNode, error) {
+	resp, err := client.Resources(ctx, armresourcegraph.QueryRequest{
+		Subscriptions: []*string{&subscriptionID},
+		Query:         &query,
+	}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run resource graph query")
+	}
+
+	rows, ok := resp.Data.([]interface{})
+	if !ok {
+		return nil, errors.New("unexpected resource graph response shape")
+	}
+
+	var graph []ResourceGraphNode
+	for _, row := range rows {
+		fields, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, _ := fields["type"].(string)
+		name, _ := fields["name"].(string)
+		props, _ := fields["properties"].(map[string]interface{})
+		graph = append(graph, ResourceGraphNode{Kind: kind, Name: name, Properties: props})
+	}
+	return graph, nil
+}
+
+// BuildDriftResourceGraph enumerates the live VMs, Web Apps, Key Vaults,
+// Cosmos DB accounts and Redis caches that TestDrift diffs against state.
+// Below resourceGraphScaleThreshold resources it walks each service's own
+// NewListPager; above it, it issues a single resource-graph query instead so
+// the drift check stays cheap at scale.
+func (suite *AzureTestSuite) BuildDriftResourceGraph(resourceGraphClient *armresourcegraph.Client) ([]ResourceGraphNode, error) {
+	var graph []ResourceGraphNode
+
+	vmPager := suite.ComputeClients.VirtualMachines.NewListAllPager(nil)
+	for vmPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, vmPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list virtual machines for drift")
+		}
+		for _, vm := range page.Value {
+			if vm.Name == nil {
+				continue
+			}
+			props := map[string]interface{}{}
+			if vm.Tags != nil {
+				props["tags"] = vm.Tags
+			}
+			if vm.Properties != nil && vm.Properties.HardwareProfile != nil && vm.Properties.HardwareProfile.VMSize != nil {
+				props["sku"] = string(*vm.Properties.HardwareProfile.VMSize)
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "VirtualMachine", Name: *vm.Name, Properties: props})
+		}
+
+		if len(graph) > resourceGraphScaleThreshold && resourceGraphClient != nil {
+			return QueryResourceGraph(suite.Context, resourceGraphClient, suite.SubscriptionID,
+				"Resources | project type, name, properties")
+		}
+	}
+
+	appPager := suite.AppService.NewListPager(nil)
+	for appPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, appPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list web apps for drift")
+		}
+		for _, app := range page.Value {
+			if app.Name == nil {
+				continue
+			}
+			props := map[string]interface{}{}
+			if app.Tags != nil {
+				props["tags"] = app.Tags
+			}
+			if app.Properties != nil && app.Properties.HTTPSOnly != nil {
+				props["minimum_tls_version"] = *app.Properties.HTTPSOnly
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "AppService", Name: *app.Name, Properties: props})
+		}
+	}
+
+	vaultPager := suite.KeyVault.NewListPager(nil, nil)
+	for vaultPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, vaultPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list key vaults for drift")
+		}
+		for _, vault := range page.Value {
+			if vault.Name == nil {
+				continue
+			}
+			props := map[string]interface{}{}
+			if vault.Tags != nil {
+				props["tags"] = vault.Tags
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "KeyVault", Name: *vault.Name, Properties: props})
+		}
+	}
+
+	cosmosPager := suite.CosmosDB.NewListPager(nil)
+	for cosmosPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, cosmosPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Cosmos DB accounts for drift")
+		}
+		for _, account := range page.Value {
+			if account.Name == nil {
+				continue
+			}
+			props := map[string]interface{}{}
+			if account.Tags != nil {
+				props["tags"] = account.Tags
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "CosmosDBAccount", Name: *account.Name, Properties: props})
+		}
+	}
+
+	redisPager := suite.Redis.NewListBySubscriptionPager(nil)
+	for redisPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, redisPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list Redis caches for drift")
+		}
+		for _, cache := range page.Value {
+			if cache.Name == nil {
+				continue
+			}
+			props := map[string]interface{}{}
+			if cache.Tags != nil {
+				props["tags"] = cache.Tags
+			}
+			if cache.Properties != nil && cache.Properties.SKU != nil && cache.Properties.SKU.Name != nil {
+				props["sku"] = string(*cache.Properties.SKU.Name)
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "RedisCache", Name: *cache.Name, Properties: props})
+		}
+	}
+
+	return graph, nil
+}
+
+// DiffDrift compares live (from BuildDriftResourceGraph) against managed
+// (from ExtractManagedResources), matching resources by name and reporting
+// unmanaged/missing resources plus attribute drift on the whitelisted
+// fields.
+func DiffDrift(live []ResourceGraphNode, managed []ManagedResource) []DriftFinding {
+	managedByName := make(map[string]ManagedResource, len(managed))
+	for _, m := range managed {
+		managedByName[m.Name] = m
+	}
+	liveByName := make(map[string]ResourceGraphNode, len(live))
+	for _, l := range live {
+		liveByName[l.Name] = l
+	}
+
+	var findings []DriftFinding
+
+	for _, l := range live {
+		m, ok := managedByName[l.Name]
+		if !ok {
+			findings = append(findings, DriftFinding{Kind: DriftUnmanaged, Resource: l.Name})
+			continue
+		}
+		for _, field := range driftWhitelistedFields {
+			liveVal, liveOK := l.Properties[field]
+			managedVal, managedOK := m.Attributes[field]
+			if !liveOK && !managedOK {
+				continue
+			}
+			if !equalDriftValue(liveVal, managedVal) {
+				findings = append(findings, DriftFinding{
+					Kind:     DriftAttribute,
+					Resource: l.Name,
+					Field:    field,
+					Expected: managedVal,
+					Actual:   liveVal,
+				})
+			}
+		}
+	}
+
+	for _, m := range managed {
+		if _, ok := liveByName[m.Name]; !ok {
+			findings = append(findings, DriftFinding{Kind: DriftMissing, Resource: m.Name})
+		}
+	}
+
+	return findings
+}
+
+// equalDriftValue compares two attribute values loosely enough to survive
+// state/SDK type mismatches (e.g. map[string]string vs map[string]interface{}).
+func equalDriftValue(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return false
+	}
+	return string(aj) == string(bj)
+}
+
+// WriteDriftReport serializes report as indented JSON to path for CI
+// artifact storage.
+func WriteDriftReport(path string, report DriftReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal drift report")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write drift report to %s", path)
+	}
+	return nil
+}
+
+// PlanDetailedExitCode runs `terraform plan -detailed-exitcode` in dir and
+// returns the exit code Terraform reports: 0 (no changes), 1 (error) or
+// 2 (changes present), so CI can gate on unexpected diffs the same way it
+// gates on drift findings.
+func PlanDetailedExitCode(dir string) (int, error) {
+	cmd := exec.Command("terraform", "plan", "-detailed-exitcode", "-input=false")
+	cmd.Dir = dir
+	err := cmd.Run()
+	if err == nil {
+		return 0, nil
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, errors.Wrapf(err, "failed to run terraform plan -detailed-exitcode in %s", dir)
+}
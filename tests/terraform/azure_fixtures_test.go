@@ -0,0 +1,255 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FixtureMode selects how a FixtureTransport handles ARM traffic.
+type FixtureMode string
+
+const (
+	// FixtureModeLive passes requests straight through, recording nothing.
+	FixtureModeLive FixtureMode = "live"
+	// FixtureModeRecord passes requests through and records the exchange.
+	FixtureModeRecord FixtureMode = "record"
+	// FixtureModeReplay serves recorded exchanges without hitting the network.
+	FixtureModeReplay FixtureMode = "replay"
+)
+
+// fixtureExchange is a single recorded request/response pair. URL is
+// always stored canonicalized (see canonicalizeURL) so replay matching
+// doesn't depend on query-parameter ordering or on values redacted out of
+// the live request.
+type fixtureExchange struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers"`
+	Body       string      `json:"body"`
+}
+
+// Redactor scrubs sensitive content from a recorded response's headers
+// and body before FixtureTransport writes it to the fixture file. Run
+// after FixtureTransport's own built-in auth-token/GUID redaction, so a
+// Redactor only needs to handle secrets that redaction doesn't already
+// catch -- SAS tokens, Key Vault access keys, and the like.
+type Redactor interface {
+	Redact(headers http.Header, body []byte) (http.Header, []byte)
+}
+
+// RedactorFunc adapts a plain function to a Redactor.
+type RedactorFunc func(headers http.Header, body []byte) (http.Header, []byte)
+
+// Redact implements Redactor.
+func (f RedactorFunc) Redact(headers http.Header, body []byte) (http.Header, []byte) {
+	return f(headers, body)
+}
+
+// sensitiveHeaders lists response headers FixtureTransport always strips
+// before recording, regardless of Redactors -- these never belong in a
+// checked-in fixture.
+var sensitiveHeaders = []string{"Authorization", "Www-Authenticate", "Proxy-Authenticate", "Set-Cookie"}
+
+// guidPattern matches a canonical UUID/GUID, the shape Azure subscription
+// and tenant IDs take throughout ARM URLs, headers, and response bodies.
+var guidPattern = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// FixtureTransport implements azcore/policy.Transporter, letting an
+// AzureTestSuite record ARM request/response pairs to a JSON fixture file and
+// later replay them offline, without a live subscription. Recorded
+// exchanges are canonicalized -- auth headers stripped, GUIDs replaced
+// with stable placeholders, query parameters sorted -- and passed through
+// Redactors before being written to FixturePath, so a checked-in fixture
+// never carries live credentials.
+type FixtureTransport struct {
+	Mode        FixtureMode
+	FixturePath string
+	Redactors   []Redactor
+
+	inner        http.RoundTripper
+	mu           sync.Mutex
+	recorded     []fixtureExchange
+	replayQueue  []fixtureExchange
+	replayLoaded bool
+	guidAliases  map[string]string
+}
+
+// redactGUIDs replaces every GUID in s with a stable placeholder: the
+// same GUID value always maps to the same placeholder within one
+// FixtureTransport, so relationships between distinct subscription/tenant
+// IDs stay visible in the fixture without the real values leaking into it.
+func (ft *FixtureTransport) redactGUIDs(s string) string {
+	return guidPattern.ReplaceAllStringFunc(s, func(guid string) string {
+		if ft.guidAliases == nil {
+			ft.guidAliases = make(map[string]string)
+		}
+		alias, ok := ft.guidAliases[guid]
+		if !ok {
+			alias = fmt.Sprintf("00000000-0000-0000-0000-%012d", len(ft.guidAliases)+1)
+			ft.guidAliases[guid] = alias
+		}
+		return alias
+	})
+}
+
+// canonicalizeURL strips auth-token-bearing query parameters, sorts the
+// remaining query parameters, and redacts any GUID -- the form both
+// record and replay match requests on, so neither query-param ordering
+// nor a live subscription/tenant ID affects whether a replayed request
+// finds its fixture.
+func (ft *FixtureTransport) canonicalizeURL(u *url.URL) string {
+	clone := *u
+	query := clone.Query()
+	for _, key := range []string{"sig", "sv", "se", "token"} {
+		query.Del(key)
+	}
+	clone.RawQuery = query.Encode()
+	return ft.redactGUIDs(clone.String())
+}
+
+// redactHeaders clones headers with sensitiveHeaders stripped and every
+// remaining value GUID-redacted.
+func (ft *FixtureTransport) redactHeaders(headers http.Header) http.Header {
+	clone := headers.Clone()
+	for _, name := range sensitiveHeaders {
+		clone.Del(name)
+	}
+	for name, values := range clone {
+		for i, v := range values {
+			values[i] = ft.redactGUIDs(v)
+		}
+		clone[name] = values
+	}
+	return clone
+}
+
+// NewFixtureTransport builds a transport for the given mode and fixture file.
+// In FixtureModeReplay the fixture file is loaded eagerly so a missing or
+// corrupt fixture fails fast instead of during the first ARM call.
+func NewFixtureTransport(mode FixtureMode, fixturePath string) (*FixtureTransport, error) {
+	ft := &FixtureTransport{
+		Mode:        mode,
+		FixturePath: fixturePath,
+		inner:       http.DefaultTransport,
+	}
+
+	if mode == FixtureModeReplay {
+		if err := ft.loadFixture(); err != nil {
+			return nil, errors.Wrap(err, "failed to load ARM fixture for replay")
+		}
+	}
+
+	return ft, nil
+}
+
+func (ft *FixtureTransport) loadFixture() error {
+	data, err := os.ReadFile(ft.FixturePath)
+	if err != nil {
+		return err
+	}
+	var exchanges []fixtureExchange
+	if err := json.Unmarshal(data, &exchanges); err != nil {
+		return errors.Wrap(err, "failed to parse fixture file")
+	}
+	ft.replayQueue = exchanges
+	ft.replayLoaded = true
+	return nil
+}
+
+// Do implements policy.Transporter.
+func (ft *FixtureTransport) Do(req *http.Request) (*http.Response, error) {
+	if ft.Mode == FixtureModeReplay {
+		return ft.replay(req)
+	}
+
+	resp, err := ft.inner.RoundTrip(req)
+	if err != nil || ft.Mode != FixtureModeRecord {
+		return resp, err
+	}
+
+	return ft.record(req, resp)
+}
+
+func (ft *FixtureTransport) replay(req *http.Request) (*http.Response, error) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	canonicalURL := ft.canonicalizeURL(req.URL)
+	for i, exchange := range ft.replayQueue {
+		if exchange.Method != req.Method {
+			continue
+		}
+		if exchange.URL != canonicalURL {
+			continue
+		}
+		ft.replayQueue = append(ft.replayQueue[:i], ft.replayQueue[i+1:]...)
+		return &http.Response{
+			StatusCode: exchange.StatusCode,
+			Header:     exchange.Headers,
+			Body:       io.NopCloser(bytes.NewBufferString(exchange.Body)),
+			Request:    req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded fixture for %s %s", req.Method, canonicalURL)
+}
+
+func (ft *FixtureTransport) record(req *http.Request, resp *http.Response) (*http.Response, error) {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body for recording")
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	ft.mu.Lock()
+	canonicalURL := ft.canonicalizeURL(req.URL)
+	headers := ft.redactHeaders(resp.Header)
+	body := []byte(ft.redactGUIDs(string(bodyBytes)))
+	for _, redactor := range ft.Redactors {
+		headers, body = redactor.Redact(headers, body)
+	}
+	ft.recorded = append(ft.recorded, fixtureExchange{
+		Method:     req.Method,
+		URL:        canonicalURL,
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Body:       string(body),
+	})
+	ft.mu.Unlock()
+
+	return resp, nil
+}
+
+// Flush writes all recorded exchanges to FixturePath. Call it once the test
+// run that owns this transport has finished issuing ARM calls.
+func (ft *FixtureTransport) Flush() error {
+	if ft.Mode != FixtureModeRecord {
+		return nil
+	}
+
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(ft.FixturePath), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create fixture directory")
+	}
+
+	data, err := json.MarshalIndent(ft.recorded, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal recorded fixtures")
+	}
+
+	return os.WriteFile(ft.FixturePath, data, 0o644)
+}
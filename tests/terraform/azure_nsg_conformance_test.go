@@ -0,0 +1,104 @@
+package test
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
+	"github.com/pkg/errors"
+)
+
+// NSGRuleExpectation describes a single expected (or forbidden) security
+// rule shape, independent of any one NSG's actual rule priority/name.
+type NSGRuleExpectation struct {
+	Description string
+	Direction   armnetwork.SecurityRuleDirection
+	Access      armnetwork.SecurityRuleAccess
+	Protocol    armnetwork.SecurityRuleProtocol
+	Port        string
+	// Forbidden, when true, means conformance fails if any rule matches
+	// this expectation rather than failing when none do.
+	Forbidden bool
+}
+
+// NSGPolicyBundle is a named set of NSGRuleExpectation checks, e.g. a
+// CIS Azure Foundations Benchmark network-security control pack.
+type NSGPolicyBundle struct {
+	Name  string
+	Rules []NSGRuleExpectation
+}
+
+// CISNetworkSecurityBundle forbids unrestricted inbound RDP/SSH, matching
+// CIS Azure Foundations Benchmark controls 6.1/6.2.
+var CISNetworkSecurityBundle = NSGPolicyBundle{
+	Name: "cis-network-security",
+	Rules: []NSGRuleExpectation{
+		{
+			Description: "RDP (3389) must not be open to the internet",
+			Direction:   armnetwork.SecurityRuleDirectionInbound,
+			Access:      armnetwork.SecurityRuleAccessAllow,
+			Protocol:    armnetwork.SecurityRuleProtocolTCP,
+			Port:        "3389",
+			Forbidden:   true,
+		},
+		{
+			Description: "SSH (22) must not be open to the internet",
+			Direction:   armnetwork.SecurityRuleDirectionInbound,
+			Access:      armnetwork.SecurityRuleAccessAllow,
+			Protocol:    armnetwork.SecurityRuleProtocolTCP,
+			Port:        "22",
+			Forbidden:   true,
+		},
+	},
+}
+
+// NSGConformanceViolation records a rule expectation an NSG failed.
+type NSGConformanceViolation struct {
+	NSGName     string
+	Expectation NSGRuleExpectation
+}
+
+// CheckNSGConformance evaluates every rule in an NSG's security rule
+// collection (explicit rules only; default rules are excluded since they
+// aren't user-configurable) against a policy bundle's expectations.
+func CheckNSGConformance(nsg *armnetwork.SecurityGroup, bundle NSGPolicyBundle) ([]NSGConformanceViolation, error) {
+	if nsg.Properties == nil {
+		return nil, errors.New("NSG has no properties to evaluate")
+	}
+
+	var violations []NSGConformanceViolation
+	for _, expectation := range bundle.Rules {
+		matched := nsgHasMatchingRule(nsg.Properties.SecurityRules, expectation)
+		if expectation.Forbidden && matched {
+			violations = append(violations, NSGConformanceViolation{NSGName: stringOrEmpty(nsg.Name), Expectation: expectation})
+		}
+		if !expectation.Forbidden && !matched {
+			violations = append(violations, NSGConformanceViolation{NSGName: stringOrEmpty(nsg.Name), Expectation: expectation})
+		}
+	}
+	return violations, nil
+}
+
+func nsgHasMatchingRule(rules []*armnetwork.SecurityRule, expectation NSGRuleExpectation) bool {
+	for _, rule := range rules {
+		if rule.Properties == nil {
+			continue
+		}
+		p := rule.Properties
+		if p.Direction == nil || *p.Direction != expectation.Direction {
+			continue
+		}
+		if p.Access == nil || *p.Access != expectation.Access {
+			continue
+		}
+		if p.Protocol == nil || (*p.Protocol != expectation.Protocol && *p.Protocol != armnetwork.SecurityRuleProtocolAsterisk) {
+			continue
+		}
+		if p.DestinationPortRange != nil && portRangeAllowsUnrestricted(*p.DestinationPortRange, expectation.Port) &&
+			p.SourceAddressPrefix != nil && *p.SourceAddressPrefix == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func portRangeAllowsUnrestricted(portRange, port string) bool {
+	return portRange == "*" || portRange == port
+}
@@ -0,0 +1,611 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"golang.org/x/crypto/ocsp"
+)
+
+// ProbeResult is the structured, Go-test-friendly outcome every Prober
+// implementation returns, so callers can aggregate latency/retry/error-class
+// reporting the same way regardless of probe kind.
+type ProbeResult struct {
+	Target     string
+	Success    bool
+	Latency    time.Duration
+	Retries    int
+	ErrorClass string
+	Err        error
+}
+
+// Prober is implemented by every concrete health check in this file.
+type Prober interface {
+	Probe(ctx context.Context) ProbeResult
+}
+
+// probeWithRetry runs attempt up to maxRetries+1 times with full-jitter
+// backoff between attempts, wrapping the outcome (including retry count and
+// elapsed latency) into a ProbeResult.
+func probeWithRetry(target string, maxRetries int, attempt func(ctx context.Context) error) func(ctx context.Context) ProbeResult {
+	return func(ctx context.Context) ProbeResult {
+		start := time.Now()
+		var lastErr error
+		for i := 0; i <= maxRetries; i++ {
+			if i > 0 {
+				backoff := time.Duration(rand.Int63n(int64(time.Duration(i) * 200 * time.Millisecond)))
+				time.Sleep(backoff)
+			}
+			lastErr = attempt(ctx)
+			if lastErr == nil {
+				return ProbeResult{Target: target, Success: true, Latency: time.Since(start), Retries: i}
+			}
+		}
+		return ProbeResult{
+			Target:     target,
+			Success:    false,
+			Latency:    time.Since(start),
+			Retries:    maxRetries,
+			ErrorClass: classifyProbeError(lastErr),
+			Err:        lastErr,
+		}
+	}
+}
+
+func classifyProbeError(err error) string {
+	if err == nil {
+		return ""
+	}
+	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+		return "timeout"
+	}
+	if strings.Contains(err.Error(), "connection refused") {
+		return "connection_refused"
+	}
+	if strings.Contains(err.Error(), "certificate") {
+		return "tls_error"
+	}
+	return "unknown"
+}
+
+// TCPProber dials Address and, when TLSHandshake is set, completes a TLS
+// handshake so callers can chain into certificate assertions.
+type TCPProber struct {
+	Address      string
+	TLSHandshake bool
+	Timeout      time.Duration
+	MaxRetries   int
+}
+
+func (p TCPProber) Probe(ctx context.Context) ProbeResult {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	return probeWithRetry(p.Address, p.MaxRetries, func(ctx context.Context) error {
+		dialer := &net.Dialer{Timeout: timeout}
+		conn, err := dialer.DialContext(ctx, "tcp", p.Address)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		if p.TLSHandshake {
+			tlsConn := tls.Client(conn, &tls.Config{ServerName: hostOnly(p.Address)})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				return err
+			}
+			defer tlsConn.Close()
+		}
+		return nil
+	})(ctx)
+}
+
+// HTTPProber issues an HTTP GET against URL and succeeds when the response
+// status code is in ExpectedStatusCodes (defaults to just 200).
+type HTTPProber struct {
+	URL                 string
+	ExpectedStatusCodes []int
+	MaxRetries          int
+	Timeout             time.Duration
+}
+
+func (p HTTPProber) Probe(ctx context.Context) ProbeResult {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	expected := p.ExpectedStatusCodes
+	if len(expected) == 0 {
+		expected = []int{http.StatusOK}
+	}
+	client := &http.Client{Timeout: timeout}
+
+	return probeWithRetry(p.URL, p.MaxRetries, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		for _, code := range expected {
+			if resp.StatusCode == code {
+				return nil
+			}
+		}
+		return errors.Errorf("unexpected status code %d from %s", resp.StatusCode, p.URL)
+	})(ctx)
+}
+
+// PostgresProber pings a Postgres endpoint via pgx's database/sql driver.
+type PostgresProber struct {
+	DSN        string
+	MaxRetries int
+}
+
+func (p PostgresProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.DSN, p.MaxRetries, func(ctx context.Context) error {
+		db, err := sql.Open("pgx", p.DSN)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.PingContext(ctx)
+	})(ctx)
+}
+
+// RedisProber pings a Redis endpoint via go-redis, optionally over TLS (as
+// required by GCP Memorystore instances with in-transit encryption enabled).
+type RedisProber struct {
+	Addr       string
+	Password   string
+	TLS        bool
+	MaxRetries int
+}
+
+func (p RedisProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.Addr, p.MaxRetries, func(ctx context.Context) error {
+		opts := &redis.Options{Addr: p.Addr, Password: p.Password}
+		if p.TLS {
+			opts.TLSConfig = &tls.Config{ServerName: hostOnly(p.Addr)}
+		}
+		client := redis.NewClient(opts)
+		defer client.Close()
+		return client.Ping(ctx).Err()
+	})(ctx)
+}
+
+// K8sHealthProber checks the Kubernetes API server's /livez and /readyz
+// endpoints, used to validate AKS control-plane health.
+type K8sHealthProber struct {
+	APIServerURL string
+	BearerToken  string
+	MaxRetries   int
+}
+
+func (p K8sHealthProber) Probe(ctx context.Context) ProbeResult {
+	client := &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+
+	return probeWithRetry(p.APIServerURL, p.MaxRetries, func(ctx context.Context) error {
+		for _, path := range []string{"/livez", "/readyz"} {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.APIServerURL+path, nil)
+			if err != nil {
+				return err
+			}
+			if p.BearerToken != "" {
+				req.Header.Set("Authorization", "Bearer "+p.BearerToken)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return errors.Errorf("%s returned status %d", path, resp.StatusCode)
+			}
+		}
+		return nil
+	})(ctx)
+}
+
+// TLSPolicy declares the certificate properties AssertTLSCertificate checks
+// a peer's leaf certificate and negotiated connection state against.
+type TLSPolicy struct {
+	MinDaysRemaining int
+	RequiredSANs     []string
+	MinVersion       uint16
+
+	// AllowedCipherSuites, if non-empty, restricts the negotiated cipher
+	// suite to this list -- any other negotiated suite is a violation. A
+	// nil/empty slice means no restriction, so existing callers that never
+	// set it are unaffected.
+	AllowedCipherSuites []uint16
+
+	// RejectWeakSignatureAlgorithms fails the leaf certificate if it was
+	// signed with MD5, SHA-1, or DSA-SHA1.
+	RejectWeakSignatureAlgorithms bool
+
+	// CheckOCSPStaple requires the handshake to present a stapled OCSP
+	// response reporting a current "good" status.
+	CheckOCSPStaple bool
+
+	// RootCAs validates the peer's chain against this pool instead of the
+	// system trust store, for a private/internal CA. Nil means the system
+	// trust store -- see AssertCertificateChainTrust.
+	RootCAs *x509.CertPool
+}
+
+// weakSignatureAlgorithms are x509.SignatureAlgorithm values
+// RejectWeakSignatureAlgorithms treats as a violation.
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// AssertTLSCertificate validates state's negotiated version and the peer's
+// leaf certificate (issuer, expiry, SANs) against policy, returning one
+// error per violation.
+func AssertTLSCertificate(state tls.ConnectionState, policy TLSPolicy) []error {
+	var violations []error
+
+	if policy.MinVersion != 0 && state.Version < policy.MinVersion {
+		violations = append(violations, errors.Errorf("negotiated TLS version 0x%x is below required minimum 0x%x", state.Version, policy.MinVersion))
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		return append(violations, errors.New("no peer certificates presented"))
+	}
+	leaf := state.PeerCertificates[0]
+
+	if leaf.Issuer.String() == "" {
+		violations = append(violations, errors.New("leaf certificate has no issuer"))
+	}
+
+	daysRemaining := int(time.Until(leaf.NotAfter).Hours() / 24)
+	if daysRemaining < policy.MinDaysRemaining {
+		violations = append(violations, errors.Errorf("certificate expires in %d days, below required minimum of %d", daysRemaining, policy.MinDaysRemaining))
+	}
+
+	for _, required := range policy.RequiredSANs {
+		if !sanMatches(leaf, required) {
+			violations = append(violations, errors.Errorf("certificate is missing required SAN %q", required))
+		}
+	}
+
+	if len(policy.AllowedCipherSuites) > 0 && !cipherSuiteAllowed(state.CipherSuite, policy.AllowedCipherSuites) {
+		violations = append(violations, errors.Errorf("negotiated cipher suite %s is not in the allow-list", tls.CipherSuiteName(state.CipherSuite)))
+	}
+
+	if policy.RejectWeakSignatureAlgorithms && weakSignatureAlgorithms[leaf.SignatureAlgorithm] {
+		violations = append(violations, errors.Errorf("certificate uses weak signature algorithm %s", leaf.SignatureAlgorithm))
+	}
+
+	if policy.CheckOCSPStaple {
+		if _, err := ocspStapleStatus(state); err != nil {
+			violations = append(violations, err)
+		}
+	}
+
+	return violations
+}
+
+// AssertCertificateChainTrust cryptographically verifies the peer's leaf
+// certificate chains to a trusted root -- the system trust store, or roots
+// if policy.RootCAs is set, for a private/internal CA -- using every
+// certificate after the leaf in state.PeerCertificates as intermediates.
+// This is real x509 path validation, unlike a denylist/allowlist check on
+// the cipher suite or issuer organization name alone.
+func AssertCertificateChainTrust(state tls.ConnectionState, serverName string, policy TLSPolicy) []error {
+	if len(state.PeerCertificates) == 0 {
+		return []error{errors.New("no peer certificates presented")}
+	}
+	leaf := state.PeerCertificates[0]
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: serverName, Intermediates: intermediates, Roots: policy.RootCAs}); err != nil {
+		return []error{errors.Wrap(err, "certificate chain does not verify against trusted roots")}
+	}
+	return nil
+}
+
+// ocspStapleStatus parses state's stapled OCSP response (using the next
+// certificate in the chain as the issuer) and requires a current "good"
+// status.
+func ocspStapleStatus(state tls.ConnectionState) (string, error) {
+	if len(state.OCSPResponse) == 0 {
+		return "not_stapled", errors.New("no OCSP staple presented")
+	}
+
+	issuer := state.PeerCertificates[0]
+	if len(state.PeerCertificates) > 1 {
+		issuer = state.PeerCertificates[1]
+	}
+
+	resp, err := ocsp.ParseResponse(state.OCSPResponse, issuer)
+	if err != nil {
+		return "invalid", errors.Wrap(err, "failed to parse OCSP staple")
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		if time.Now().After(resp.NextUpdate) {
+			return "expired", errors.New("OCSP staple is stale past its NextUpdate time")
+		}
+		return "good", nil
+	case ocsp.Revoked:
+		return "revoked", errors.New("OCSP staple reports the certificate as revoked")
+	default:
+		return "unknown", errors.New("OCSP staple reports an unknown status")
+	}
+}
+
+// TLSReport is one endpoint's full TLS inspection result: the negotiated
+// connection parameters, the leaf certificate's validity window and
+// signature algorithm, whether its chain verifies against a trusted root,
+// its OCSP staple status (when checked), and every policy violation found.
+type TLSReport struct {
+	Endpoint           string
+	ServerName         string
+	TLSVersion         string
+	CipherSuite        string
+	NotBefore          time.Time
+	NotAfter           time.Time
+	DaysRemaining      int
+	SignatureAlgorithm string
+	ChainValid         bool
+	OCSPStatus         string
+	Violations         []string
+}
+
+// InspectTLS dials address (host:port, defaulting to :443) with SNI
+// serverName (defaulting to address's host when empty, so SNI-based
+// virtual-hosting can be tested by passing a serverName that differs from
+// address), then runs every check policy enables -- certificate validity,
+// SAN match, protocol version, cipher suite, weak signature algorithm,
+// OCSP staple, and real chain-of-trust verification -- returning a
+// TLSReport the caller can log and assert on.
+func InspectTLS(address, serverName string, policy TLSPolicy) (TLSReport, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "443")
+	}
+	if serverName == "" {
+		serverName = hostOnly(address)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: serverName, NextProtos: []string{"h2", "http/1.1"}})
+	if err != nil {
+		return TLSReport{}, errors.Wrapf(err, "TLS handshake failed for %s", address)
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	leaf := state.PeerCertificates[0]
+
+	report := TLSReport{
+		Endpoint:           address,
+		ServerName:         serverName,
+		TLSVersion:         tlsVersionName(state.Version),
+		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+		NotBefore:          leaf.NotBefore,
+		NotAfter:           leaf.NotAfter,
+		DaysRemaining:      int(time.Until(leaf.NotAfter).Hours() / 24),
+		SignatureAlgorithm: leaf.SignatureAlgorithm.String(),
+	}
+
+	for _, violation := range AssertTLSCertificate(state, policy) {
+		report.Violations = append(report.Violations, violation.Error())
+	}
+
+	chainErrs := AssertCertificateChainTrust(state, serverName, policy)
+	report.ChainValid = len(chainErrs) == 0
+	for _, err := range chainErrs {
+		report.Violations = append(report.Violations, err.Error())
+	}
+
+	if policy.CheckOCSPStaple {
+		report.OCSPStatus, _ = ocspStapleStatus(state)
+	}
+
+	return report, nil
+}
+
+// cipherSuiteAllowed reports whether suite appears in allowed.
+func cipherSuiteAllowed(suite uint16, allowed []uint16) bool {
+	for _, a := range allowed {
+		if a == suite {
+			return true
+		}
+	}
+	return false
+}
+
+func sanMatches(cert *x509.Certificate, san string) bool {
+	for _, dnsName := range cert.DNSNames {
+		if dnsName == san {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOnly(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// TestDatabaseHealth pings a Postgres endpoint and returns an error unless
+// the connection succeeds within its retry budget.
+func (suite *AzureTestSuite) TestDatabaseHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database health")
+
+	result := (PostgresProber{DSN: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "database", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "database health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestCacheHealth pings a Redis endpoint and returns an error unless the
+// connection succeeds within its retry budget.
+func (suite *AzureTestSuite) TestCacheHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing cache health")
+
+	result := (RedisProber{Addr: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "cache", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "cache health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestLoadBalancerHealth dials a load balancer's frontend TCP endpoint.
+func (suite *AzureTestSuite) TestLoadBalancerHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing load balancer health")
+
+	result := (TCPProber{Address: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "load_balancer", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "load balancer health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestContainerServiceHealth checks an AKS cluster's kube-apiserver liveness
+// and readiness endpoints.
+func (suite *AzureTestSuite) TestContainerServiceHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing container service health")
+
+	result := (K8sHealthProber{APIServerURL: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "container_service", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "container service health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestHTTPConnectivity issues an HTTP GET and asserts a 2xx response.
+func (suite *AzureTestSuite) TestHTTPConnectivity(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing HTTP connectivity")
+
+	result := (HTTPProber{URL: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "http_connectivity", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "HTTP connectivity probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestInternalConnectivity dials an internal (VNet-scoped) TCP endpoint.
+func (suite *AzureTestSuite) TestInternalConnectivity(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing internal connectivity")
+
+	result := (TCPProber{Address: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "internal_connectivity", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "internal connectivity probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestSSLConfiguration completes a TLS handshake against outputs["endpoint"]
+// and asserts the negotiated cipher/version and peer certificate chain
+// against suite.Config's TLS expectations.
+func (suite *AzureTestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing SSL configuration")
+
+	var endpoints []string
+	for key, value := range outputs {
+		if !strings.HasSuffix(key, "_endpoint") && key != "endpoint" {
+			continue
+		}
+		if endpoint, ok := value.(string); ok && endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return errors.New("outputs contain no \"endpoint\" or *_endpoint values to test SSL configuration against")
+	}
+
+	// serverName, if set, overrides every endpoint's SNI -- e.g. to test
+	// an SNI-routed virtual host whose hostname differs from the
+	// connection's actual DNS name.
+	serverName, _ := outputs["tls_server_name"].(string)
+
+	policy := TLSPolicy{
+		MinDaysRemaining:              30,
+		MinVersion:                    tls.VersionTLS12,
+		RejectWeakSignatureAlgorithms: true,
+	}
+
+	var failed []string
+	for _, endpoint := range endpoints {
+		report, err := InspectTLS(endpoint, serverName, policy)
+		if err != nil {
+			failed = append(failed, endpoint)
+			suite.Logger.Error().Str("endpoint", endpoint).Err(err).Msg("TLS inspection failed")
+			continue
+		}
+
+		event := suite.Logger.Info()
+		if len(report.Violations) > 0 {
+			event = suite.Logger.Error()
+			failed = append(failed, endpoint)
+		}
+		event.Str("endpoint", report.Endpoint).
+			Str("server_name", report.ServerName).
+			Str("tls_version", report.TLSVersion).
+			Str("cipher_suite", report.CipherSuite).
+			Str("signature_algorithm", report.SignatureAlgorithm).
+			Int("days_remaining", report.DaysRemaining).
+			Bool("chain_valid", report.ChainValid).
+			Strs("violations", report.Violations).
+			Msg("TLS configuration checked")
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("SSL configuration violations on endpoint(s): %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func logProbeResult(logger zerolog.Logger, kind string, result ProbeResult) {
+	event := logger.Info()
+	if !result.Success {
+		event = logger.Error()
+	}
+	event.Str("probe", kind).
+		Str("target", result.Target).
+		Bool("success", result.Success).
+		Dur("latency", result.Latency).
+		Int("retries", result.Retries).
+		Str("error_class", result.ErrorClass).
+		Msg("Probe completed")
+}
@@ -0,0 +1,403 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/pkg/errors"
+)
+
+// ResourceGraphNode is a normalized, kind-tagged view of one Azure resource,
+// flattened into plain maps so Rego rules can query uniformly across
+// resource types without per-kind input schemas.
+type ResourceGraphNode struct {
+	Kind       string
+	Name       string
+	Properties map[string]interface{}
+}
+
+// RegoRule is one compliance-as-code rule: a self-contained Rego module
+// whose `deny` set is evaluated against the resource graph.
+type RegoRule struct {
+	Name     string
+	Module   string
+	Severity string
+}
+
+// regoPolicyModule is the single Rego module backing DefaultRegoPolicyBundle.
+// All rules live in one module/package so they share the `input.resources`
+// shape and can be evaluated in a single query.
+const regoPolicyModule = `
+package policy
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "StorageAccount"
+	resource.properties.minimum_tls_version != "TLS1_2"
+	msg := sprintf("storage account %v must set MinimumTlsVersion=TLS1_2", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "StorageAccount"
+	resource.properties.allow_blob_public_access == true
+	msg := sprintf("storage account %v must not allow blob public access", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "KeyVault"
+	resource.properties.purge_protection_enabled != true
+	msg := sprintf("key vault %v must enable purge protection", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "KeyVault"
+	resource.properties.soft_delete_enabled != true
+	msg := sprintf("key vault %v must enable soft delete", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "22"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 22", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "3389"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 3389", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "5432"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 5432", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "3306"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 3306", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "6379"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 6379", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "NetworkSecurityGroup"
+	rule := resource.properties.rules[_]
+	rule.source_address_prefix == "0.0.0.0/0"
+	rule.destination_port_range == "27017"
+	msg := sprintf("NSG %v allows 0.0.0.0/0 on port 27017", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "RoleDefinition"
+	action := resource.properties.actions[_]
+	action == "*"
+	msg := sprintf("custom role %v grants wildcard (*) actions", [resource.name])
+}
+`
+
+// DefaultRegoPolicyBundle is the starter rule pack matching the examples in
+// the compliance-as-code design: TLS/public-access on Storage, purge
+// protection/soft delete on Key Vault, 0.0.0.0/0 ingress on sensitive ports
+// (SSH, RDP, Postgres, MySQL, Redis, MongoDB) on NSGs, and wildcard-action
+// custom role definitions.
+var DefaultRegoPolicyBundle = []RegoRule{
+	{Name: "rego-compliance-bundle", Module: regoPolicyModule, Severity: "high"},
+}
+
+// PolicyFinding is one compliance-as-code violation, normalized so it can be
+// reported via zerolog, gated by severity, or serialized to SARIF.
+type PolicyFinding struct {
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// BuildResourceGraph enumerates Storage Accounts, Key Vaults and Network
+// Security Groups into a flat, kind-tagged resource graph for Rego
+// evaluation.
+func (suite *AzureTestSuite) BuildResourceGraph() ([]ResourceGraphNode, error) {
+	var graph []ResourceGraphNode
+
+	storagePager := suite.Storage.NewListPager(nil)
+	for storagePager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, storagePager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list storage accounts for resource graph")
+		}
+		for _, account := range page.Value {
+			if account.Name == nil || account.Properties == nil {
+				continue
+			}
+			props := map[string]interface{}{
+				"allow_blob_public_access": account.Properties.AllowBlobPublicAccess != nil && *account.Properties.AllowBlobPublicAccess,
+			}
+			if account.Properties.MinimumTLSVersion != nil {
+				props["minimum_tls_version"] = string(*account.Properties.MinimumTLSVersion)
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "StorageAccount", Name: *account.Name, Properties: props})
+		}
+	}
+
+	vaultPager := suite.KeyVault.NewListPager(nil, nil)
+	for vaultPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, vaultPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list key vaults for resource graph")
+		}
+		for _, vault := range page.Value {
+			if vault.Name == nil || vault.Properties == nil {
+				continue
+			}
+			props := map[string]interface{}{
+				"purge_protection_enabled": vault.Properties.EnablePurgeProtection != nil && *vault.Properties.EnablePurgeProtection,
+				"soft_delete_enabled":      vault.Properties.EnableSoftDelete != nil && *vault.Properties.EnableSoftDelete,
+			}
+			graph = append(graph, ResourceGraphNode{Kind: "KeyVault", Name: *vault.Name, Properties: props})
+		}
+	}
+
+	nsgPager := suite.NetworkClients.NetworkSecurityGroups.NewListAllPager(nil)
+	for nsgPager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, nsgPager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list NSGs for resource graph")
+		}
+		for _, nsg := range page.Value {
+			if nsg.Name == nil || nsg.Properties == nil {
+				continue
+			}
+			var rules []map[string]interface{}
+			for _, rule := range nsg.Properties.SecurityRules {
+				if rule.Properties == nil {
+					continue
+				}
+				ruleProps := map[string]interface{}{}
+				if rule.Properties.SourceAddressPrefix != nil {
+					ruleProps["source_address_prefix"] = *rule.Properties.SourceAddressPrefix
+				}
+				if rule.Properties.DestinationPortRange != nil {
+					ruleProps["destination_port_range"] = *rule.Properties.DestinationPortRange
+				}
+				rules = append(rules, ruleProps)
+			}
+			graph = append(graph, ResourceGraphNode{
+				Kind:       "NetworkSecurityGroup",
+				Name:       *nsg.Name,
+				Properties: map[string]interface{}{"rules": rules},
+			})
+		}
+	}
+
+	scope := "/subscriptions/" + suite.SubscriptionID
+	rolePager := suite.RoleDefinitions.NewListPager(scope, nil)
+	for rolePager.More() {
+		page, err := nextPageARM(suite.Context, suite.Logger, rolePager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list role definitions for resource graph")
+		}
+		for _, role := range page.Value {
+			if role.Properties == nil || role.Properties.RoleName == nil {
+				continue
+			}
+			// Built-in roles such as Owner are allowed to grant "*" by
+			// design; only custom roles are posture-checked.
+			if role.Properties.RoleType == nil || *role.Properties.RoleType != "CustomRole" {
+				continue
+			}
+			var actions []string
+			for _, permission := range role.Properties.Permissions {
+				for _, action := range permission.Actions {
+					if action != nil {
+						actions = append(actions, *action)
+					}
+				}
+			}
+			graph = append(graph, ResourceGraphNode{
+				Kind:       "RoleDefinition",
+				Name:       *role.Properties.RoleName,
+				Properties: map[string]interface{}{"actions": actions},
+			})
+		}
+	}
+
+	return graph, nil
+}
+
+// EvaluateRegoRules runs every rule's `deny` set against graph and flattens
+// the results into PolicyFinding values.
+func EvaluateRegoRules(ctx context.Context, graph []ResourceGraphNode, rules []RegoRule) ([]PolicyFinding, error) {
+	resources := make([]map[string]interface{}, len(graph))
+	for i, node := range graph {
+		resources[i] = map[string]interface{}{
+			"kind":       node.Kind,
+			"name":       node.Name,
+			"properties": node.Properties,
+		}
+	}
+	input := map[string]interface{}{"resources": resources}
+
+	var findings []PolicyFinding
+	for _, rule := range rules {
+		query, err := rego.New(
+			rego.Query("data.policy.deny"),
+			rego.Module(rule.Name+".rego", rule.Module),
+		).PrepareForEval(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to prepare rego rule %s", rule.Name)
+		}
+
+		results, err := query.Eval(ctx, rego.EvalInput(input))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to evaluate rego rule %s", rule.Name)
+		}
+
+		for _, result := range results {
+			for _, expr := range result.Expressions {
+				messages, ok := expr.Value.([]interface{})
+				if !ok {
+					continue
+				}
+				for _, msg := range messages {
+					text, ok := msg.(string)
+					if !ok {
+						continue
+					}
+					findings = append(findings, PolicyFinding{Rule: rule.Name, Severity: rule.Severity, Message: text})
+				}
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// severityRank orders severities from least to most important so
+// FindingsExceedThreshold can compare across levels.
+var severityRank = map[string]int{"low": 0, "medium": 1, "high": 2, "critical": 3}
+
+// FindingsExceedThreshold reports whether any finding's severity meets or
+// exceeds threshold (e.g. "high" matches "high" and "critical" findings).
+func FindingsExceedThreshold(findings []PolicyFinding, threshold string) bool {
+	min, ok := severityRank[threshold]
+	if !ok {
+		min = severityRank["low"]
+	}
+	for _, f := range findings {
+		if rank, ok := severityRank[f.Severity]; ok && rank >= min {
+			return true
+		}
+	}
+	return false
+}
+
+// sarifLog is a minimal SARIF 2.1.0 document shape, just enough for GitHub
+// code-scanning to ingest a flat list of rule violations.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID  string       `json:"ruleId"`
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// WriteSARIF serializes findings as a SARIF 2.1.0 log so results integrate
+// with GitHub code-scanning.
+func WriteSARIF(path string, findings []PolicyFinding) error {
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+	for _, f := range findings {
+		if !ruleIDs[f.Rule] {
+			ruleIDs[f.Rule] = true
+			rules = append(rules, sarifRule{ID: f.Rule})
+		}
+		results = append(results, sarifResult{
+			RuleID:  f.Rule,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Message},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "azure-compliance-as-code", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal SARIF log")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write SARIF log to %s", path)
+	}
+	return nil
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sweepTagCreatedAt is the tag NewAzureTestSuite-created resource groups are
+// expected to carry so the sweeper can tell how old they are.
+const sweepTagCreatedAt = "test-created-at"
+
+// sweepTagOwner marks a resource group as owned by this test harness, so the
+// sweeper never touches resources it didn't create.
+const sweepTagOwner = "test-owner"
+
+// sweepOwnerValue is the value sweepTagOwner must carry to be eligible for
+// sweeping.
+const sweepOwnerValue = "azure-test-suite"
+
+// ResourceSweeper deletes resource groups left behind by interrupted test
+// runs (e.g. a killed CI job that never reached Cleanup()) once they're
+// older than a configured TTL.
+type ResourceSweeper struct {
+	suite *AzureTestSuite
+	TTL   time.Duration
+}
+
+// NewResourceSweeper builds a sweeper bound to this suite with the given TTL.
+func (suite *AzureTestSuite) NewResourceSweeper(ttl time.Duration) *ResourceSweeper {
+	return &ResourceSweeper{suite: suite, TTL: ttl}
+}
+
+// DanglingResourceGroups returns the names of resource groups owned by this
+// test harness whose sweepTagCreatedAt is older than the sweeper's TTL.
+func (s *ResourceSweeper) DanglingResourceGroups() ([]string, error) {
+	var dangling []string
+
+	pager := s.suite.Resources.NewListPager(nil)
+	for pager.More() {
+		page, err := nextPageARM(s.suite.Context, s.suite.Logger, pager)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list resource groups for sweeping")
+		}
+
+		for _, rg := range page.Value {
+			if rg.Name == nil || rg.Tags == nil {
+				continue
+			}
+			if owner := rg.Tags[sweepTagOwner]; owner == nil || *owner != sweepOwnerValue {
+				continue
+			}
+			createdRaw := rg.Tags[sweepTagCreatedAt]
+			if createdRaw == nil {
+				continue
+			}
+			createdAt, err := time.Parse(time.RFC3339, *createdRaw)
+			if err != nil {
+				s.suite.Logger.Warn().Str("resource_group", *rg.Name).Err(err).
+					Msg("Could not parse sweep timestamp tag, skipping")
+				continue
+			}
+			if time.Since(createdAt) > s.TTL {
+				dangling = append(dangling, *rg.Name)
+			}
+		}
+	}
+
+	return dangling, nil
+}
+
+// Sweep deletes every dangling resource group it finds, logging but not
+// failing on individual delete errors so one stuck resource group doesn't
+// block cleanup of the rest.
+func (s *ResourceSweeper) Sweep() error {
+	dangling, err := s.DanglingResourceGroups()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range dangling {
+		poller, err := s.suite.Resources.BeginDeleteByID(s.suite.Context, name, "", nil)
+		if err != nil {
+			s.suite.Logger.Error().Str("resource_group", name).Err(err).Msg("Failed to start sweep delete")
+			continue
+		}
+		if _, err := pollARM(s.suite.Context, s.suite.Logger, poller); err != nil {
+			s.suite.Logger.Error().Str("resource_group", name).Err(err).Msg("Sweep delete did not complete")
+			continue
+		}
+		s.suite.Logger.Info().Str("resource_group", name).Msg("Swept dangling resource group")
+	}
+
+	return nil
+}
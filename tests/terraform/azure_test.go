@@ -3,82 +3,239 @@ package test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/sql/armsql"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerinstance/armcontainerinstance/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/recoveryservices/armrecoveryservices"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/recoveryservicesbackup/armrecoveryservicesbackup"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmosdb/armcosmos/v2"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventhub/armeventhub"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/servicebus/armservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/applicationinsights/armapplicationinsights"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/automation/armautomation"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/batch/armbatch"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cdn/armcdn"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/chaos/armchaos"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v4"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/consumption/armconsumption"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerinstance/armcontainerinstance/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmosdb/armcosmos/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/costmanagement/armcostmanagement"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/databricks/armdatabricks"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/datafactory/armdatafactory/v3"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/datalake/armdatalakestore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/datalake/armdatalakeanalytics"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/datalake/armdatalakestore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/devtestlabs/armdevtestlabs"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventgrid/armeventgrid/v2"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/eventhub/armeventhub"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/frontdoor/armfrontdoor"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/hdinsight/armhdinsight"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/iothub/armiothub"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/keyvault/armkeyvault"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/logic/armlogic"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/machinelearning/armmachinelearning/v3"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/maps/armmaps"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/mariadb/armmariadb"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/monitor/armmonitor"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/mysql/armmysql"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/mysql/armmysqlflexibleservers"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/postgresql/armpostgresql"
-	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/postgresql/armpostgresqlflexibleservers"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/notificationhubs/armnotificationhubs"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationsmanagement/armoperationsmanagement"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/policy/armpolicy"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/postgresql/armpostgresql"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/postgresql/armpostgresqlflexibleservers"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/powerbidedicated/armpowerbidedicated"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/recoveryservices/armrecoveryservices"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/recoveryservicesbackup/armrecoveryservicesbackup"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/redis/armredis/v2"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/relay/armrelay"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resourcegraph/armresourcegraph"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/search/armsearch"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/security/armsecurity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/servicebus/armservicebus"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/signalr/armsignalr"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/sql/armsql"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/streamanalytics/armstreamanalytics"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/synapse/armsynapse"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/trafficmanager/armtrafficmanager"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/web/armweb"
-	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/gruntwork-io/terratest/modules/test-structure"
-	"github.com/gruntwork-io/terratest/modules/retry"
-	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/files"
 	"github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
-	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/rs/zerolog/log"
-	"github.com/pkg/errors"
-	"github.com/google/uuid"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iamsynth"
 )
 
+// AzureEnvironment carries the ARM endpoint, AD authority host, storage
+// suffix, and audience for a specific Azure cloud instance, so the suite can
+// target sovereign/national clouds instead of only Azure Public.
+type AzureEnvironment struct {
+	Name                         string `yaml:"name"`
+	ResourceManagerEndpoint      string `yaml:"resource_manager_endpoint"`
+	ActiveDirectoryAuthorityHost string `yaml:"active_directory_authority_host"`
+	StorageEndpointSuffix        string `yaml:"storage_endpoint_suffix"`
+	Audience                     string `yaml:"audience"`
+}
+
+// Preset AzureEnvironment configurations. AzureStack is left mostly empty
+// since its endpoints are deployment-specific and must come from config.
+var (
+	AzurePublic = AzureEnvironment{
+		Name:                         "AzurePublic",
+		ResourceManagerEndpoint:      cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint,
+		ActiveDirectoryAuthorityHost: cloud.AzurePublic.ActiveDirectoryAuthorityHost,
+		StorageEndpointSuffix:        "core.windows.net",
+		Audience:                     cloud.AzurePublic.Services[cloud.ResourceManager].Audience,
+	}
+	AzureGovernment = AzureEnvironment{
+		Name:                         "AzureGovernment",
+		ResourceManagerEndpoint:      cloud.AzureGovernment.Services[cloud.ResourceManager].Endpoint,
+		ActiveDirectoryAuthorityHost: cloud.AzureGovernment.ActiveDirectoryAuthorityHost,
+		StorageEndpointSuffix:        "core.usgovcloudapi.net",
+		Audience:                     cloud.AzureGovernment.Services[cloud.ResourceManager].Audience,
+	}
+	AzureChina = AzureEnvironment{
+		Name:                         "AzureChina",
+		ResourceManagerEndpoint:      cloud.AzureChina.Services[cloud.ResourceManager].Endpoint,
+		ActiveDirectoryAuthorityHost: cloud.AzureChina.ActiveDirectoryAuthorityHost,
+		StorageEndpointSuffix:        "core.chinacloudapi.cn",
+		Audience:                     cloud.AzureChina.Services[cloud.ResourceManager].Audience,
+	}
+	AzureStack = AzureEnvironment{Name: "AzureStack"}
+)
+
+// namedAzureEnvironments lets config select a cloud by string name (as
+// Terraform's azurerm provider does with its `environment` argument) instead
+// of embedding an AzureEnvironment struct literal.
+var namedAzureEnvironments = map[string]AzureEnvironment{
+	"public":       AzurePublic,
+	"azurepublic":  AzurePublic,
+	"usgovernment": AzureGovernment,
+	"government":   AzureGovernment,
+	"china":        AzureChina,
+	"azurechina":   AzureChina,
+}
+
+// ResolveAzureEnvironment looks up an AzureEnvironment by name
+// ("public", "usgovernment", "china"). A custom environment (non-empty
+// ResourceManagerEndpoint) is returned as-is, supporting Azure Stack and
+// other custom ARM endpoints that have no fixed preset.
+func ResolveAzureEnvironment(name string, custom AzureEnvironment) (AzureEnvironment, error) {
+	if custom.ResourceManagerEndpoint != "" {
+		return custom, nil
+	}
+	if name == "" {
+		return AzurePublic, nil
+	}
+	env, ok := namedAzureEnvironments[strings.ToLower(name)]
+	if !ok {
+		return AzureEnvironment{}, errors.Errorf("unknown Azure environment %q", name)
+	}
+	return env, nil
+}
+
+// CredentialSource selects how NewAzureTestSuite obtains an
+// azcore.TokenCredential.
+type CredentialSource string
+
+const (
+	CredentialSourceClientSecret      CredentialSource = "client_secret"
+	CredentialSourceClientCertificate CredentialSource = "client_certificate"
+	CredentialSourceManagedIdentity   CredentialSource = "managed_identity"
+	CredentialSourceWorkloadIdentity  CredentialSource = "workload_identity"
+	CredentialSourceAzureCLI          CredentialSource = "azure_cli"
+	CredentialSourceDefaultChain      CredentialSource = "default_chain"
+)
+
+// AzureCredentialConfig configures how NewAzureTestSuite authenticates. When
+// Source is empty it falls back to CredentialSourceClientSecret using the
+// clientSecret argument, preserving the previous default behavior.
+type AzureCredentialConfig struct {
+	Source                    CredentialSource `yaml:"source"`
+	ClientCertificatePath     string           `yaml:"client_certificate_path"`
+	ClientCertificatePassword string           `yaml:"client_certificate_password"`
+	ManagedIdentityClientID   string           `yaml:"managed_identity_client_id"`
+}
+
+// cloudConfiguration converts an AzureEnvironment into the cloud.Configuration
+// consumed by azidentity and the arm client options.
+func (e AzureEnvironment) cloudConfiguration() cloud.Configuration {
+	return cloud.Configuration{
+		ActiveDirectoryAuthorityHost: e.ActiveDirectoryAuthorityHost,
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {
+				Endpoint: e.ResourceManagerEndpoint,
+				Audience: e.Audience,
+			},
+		},
+	}
+}
+
+// newAzureCredential builds an azcore.TokenCredential for the requested
+// CredentialSource, falling through to a chained credential (managed
+// identity, workload identity, then Azure CLI) for CredentialSourceDefaultChain.
+func newAzureCredential(tenantID, clientID, clientSecret string, credConfig AzureCredentialConfig, clientOpts azcore.ClientOptions) (azcore.TokenCredential, error) {
+	switch credConfig.Source {
+	case CredentialSourceClientCertificate:
+		certData, err := os.ReadFile(credConfig.ClientCertificatePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read client certificate")
+		}
+		certs, key, err := azidentity.ParseCertificates(certData, []byte(credConfig.ClientCertificatePassword))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse client certificate")
+		}
+		return azidentity.NewClientCertificateCredential(tenantID, clientID, certs, key, &azidentity.ClientCertificateCredentialOptions{ClientOptions: clientOpts})
+	case CredentialSourceManagedIdentity:
+		opts := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOpts}
+		if credConfig.ManagedIdentityClientID != "" {
+			opts.ID = azidentity.ClientID(credConfig.ManagedIdentityClientID)
+		}
+		return azidentity.NewManagedIdentityCredential(opts)
+	case CredentialSourceWorkloadIdentity:
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{ClientOptions: clientOpts})
+	case CredentialSourceAzureCLI:
+		return azidentity.NewAzureCLICredential(nil)
+	case CredentialSourceDefaultChain:
+		managedIdentity, err := azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOpts})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create managed identity credential")
+		}
+		azureCLI, err := azidentity.NewAzureCLICredential(nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create Azure CLI credential")
+		}
+		return azidentity.NewChainedTokenCredential([]azcore.TokenCredential{managedIdentity, azureCLI}, nil)
+	default:
+		return azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: clientOpts})
+	}
+}
+
 // AzureTestSuite manages Azure-specific infrastructure tests
 type AzureTestSuite struct {
 	SubscriptionID string
@@ -91,80 +248,88 @@ type AzureTestSuite struct {
 	Logger         zerolog.Logger
 	Context        context.Context
 	Credential     azcore.TokenCredential
-	
+	Environment    AzureEnvironment
+	ArmOptions     *arm.ClientOptions
+
 	// Core services
-	Compute           *armcompute.VirtualMachinesClient
-	Network           *armnetwork.VirtualNetworksClient
-	Storage           *armstorage.AccountsClient
-	SQL               *armsql.ServersClient
-	ContainerService  *armcontainerservice.ManagedClustersClient
-	
+	Compute          *armcompute.VirtualMachinesClient
+	Network          *armnetwork.VirtualNetworksClient
+	Storage          *armstorage.AccountsClient
+	SQL              *armsql.ServersClient
+	ContainerService *armcontainerservice.ManagedClustersClient
+	AgentPools       *armcontainerservice.AgentPoolsClient
+
 	// Management services
-	Resources         *armresources.Client
-	Authorization     *armauthorization.RoleAssignmentsClient
-	
+	Resources       *armresources.Client
+	Authorization   *armauthorization.RoleAssignmentsClient
+	RoleDefinitions *armauthorization.RoleDefinitionsClient
+
 	// Monitoring and logging
-	Monitor           *armmonitor.MetricsClient
+	Monitor             *armmonitor.MetricsClient
 	OperationalInsights *armoperationalinsights.WorkspacesClient
-	
+
 	// App services
 	AppService        *armappservice.WebAppsClient
 	ContainerInstance *armcontainerinstance.ContainerGroupsClient
-	
+
 	// Security services
-	KeyVault          *armkeyvault.VaultsClient
-	Security          *armsecurity.AssessmentsClient
-	
+	KeyVault *armkeyvault.VaultsClient
+	Security *armsecurity.AssessmentsClient
+
 	// Network services
-	DNS               *armdns.ZonesClient
-	
+	DNS *armdns.ZonesClient
+
 	// Backup and recovery
-	RecoveryServices  *armrecoveryservices.VaultsClient
+	RecoveryServices       *armrecoveryservices.VaultsClient
 	RecoveryServicesBackup *armrecoveryservicesbackup.BackupPoliciesClient
-	
+
 	// Data services
-	Redis             *armredis.Client
-	CosmosDB          *armcosmos.DatabaseAccountsClient
-	EventHub          *armeventhub.EventHubsClient
-	ServiceBus        *armservicebus.QueuesClient
-	
+	Redis      *armredis.Client
+	CosmosDB   *armcosmos.DatabaseAccountsClient
+	EventHub   *armeventhub.EventHubsClient
+	ServiceBus *armservicebus.QueuesClient
+
 	// Additional services
-	ApplicationInsights *armapplicationinsights.ComponentsClient
-	Automation        *armautomation.AccountClient
-	Batch             *armbatch.AccountClient
-	CDN               *armcdn.ProfilesClient
-	CognitiveServices *armcognitiveservices.AccountsClient
-	Consumption       *armconsumption.UsageDetailsClient
-	CostManagement    *armcostmanagement.DimensionsClient
-	Databricks        *armdatabricks.WorkspacesClient
-	DataFactory       *armdatafactory.FactoriesClient
-	DataLakeStore     *armdatalakestore.AccountsClient
-	DataLakeAnalytics *armdatalakeanalytics.AccountsClient
-	DevTestLabs       *armdevtestlabs.LabsClient
-	EventGrid         *armeventgrid.TopicsClient
-	FrontDoor         *armfrontdoor.FrontDoorsClient
-	HDInsight         *armhdinsight.ClustersClient
-	IoTHub            *armiothub.ResourceClient
-	Logic             *armlogic.WorkflowsClient
-	MachineLearning   *armmachinelearning.WorkspacesClient
-	Maps              *armmaps.AccountsClient
-	MariaDB           *armmariadb.ServersClient
-	MySQL             *armmysql.ServersClient
-	MySQLFlexible     *armmysqlflexibleservers.ServersClient
-	PostgreSQL        *armpostgresql.ServersClient
-	PostgreSQLFlexible *armpostgresqlflexibleservers.ServersClient
-	NotificationHubs  *armnotificationhubs.NamespacesClient
+	ApplicationInsights  *armapplicationinsights.ComponentsClient
+	Automation           *armautomation.AccountClient
+	Batch                *armbatch.AccountClient
+	CDN                  *armcdn.ProfilesClient
+	CognitiveServices    *armcognitiveservices.AccountsClient
+	Consumption          *armconsumption.UsageDetailsClient
+	CostManagement       *armcostmanagement.DimensionsClient
+	CostQuery            *armcostmanagement.QueryClient
+	CostBudgets          *armcostmanagement.BudgetsClient
+	Chaos                *armchaos.ExperimentsClient
+	ResourceGraph        *armresourcegraph.Client
+	Databricks           *armdatabricks.WorkspacesClient
+	DataFactory          *armdatafactory.FactoriesClient
+	DataLakeStore        *armdatalakestore.AccountsClient
+	DataLakeAnalytics    *armdatalakeanalytics.AccountsClient
+	DevTestLabs          *armdevtestlabs.LabsClient
+	EventGrid            *armeventgrid.TopicsClient
+	FrontDoor            *armfrontdoor.FrontDoorsClient
+	HDInsight            *armhdinsight.ClustersClient
+	IoTHub               *armiothub.ResourceClient
+	Logic                *armlogic.WorkflowsClient
+	MachineLearning      *armmachinelearning.WorkspacesClient
+	Maps                 *armmaps.AccountsClient
+	MariaDB              *armmariadb.ServersClient
+	MySQL                *armmysql.ServersClient
+	MySQLFlexible        *armmysqlflexibleservers.ServersClient
+	PostgreSQL           *armpostgresql.ServersClient
+	PostgreSQLFlexible   *armpostgresqlflexibleservers.ServersClient
+	NotificationHubs     *armnotificationhubs.NamespacesClient
 	OperationsManagement *armoperationsmanagement.SolutionsClient
-	Policy            *armpolicy.AssignmentsClient
-	PowerBIDedicated  *armpowerbidedicated.CapacitiesClient
-	Relay             *armrelay.NamespacesClient
-	Search            *armsearch.ServicesClient
-	SignalR           *armsignalr.Client
-	StreamAnalytics   *armstreamanalytics.StreamingJobsClient
-	Synapse           *armsynapse.WorkspacesClient
-	TrafficManager    *armtrafficmanager.ProfilesClient
-	Web               *armweb.AppsClient
-	
+	Policy               *armpolicy.AssignmentsClient
+	PowerBIDedicated     *armpowerbidedicated.CapacitiesClient
+	Relay                *armrelay.NamespacesClient
+	Search               *armsearch.ServicesClient
+	SignalR              *armsignalr.Client
+	StreamAnalytics      *armstreamanalytics.StreamingJobsClient
+	Synapse              *armsynapse.WorkspacesClient
+	TrafficManager       *armtrafficmanager.ProfilesClient
+	Web                  *armweb.AppsClient
+
 	// Client collections
 	NetworkClients    NetworkClients
 	ComputeClients    ComputeClients
@@ -177,24 +342,24 @@ type AzureTestSuite struct {
 
 // NetworkClients contains all network-related clients
 type NetworkClients struct {
-	VirtualNetworks          *armnetwork.VirtualNetworksClient
-	Subnets                  *armnetwork.SubnetsClient
-	NetworkSecurityGroups    *armnetwork.SecurityGroupsClient
-	NetworkInterfaces        *armnetwork.InterfacesClient
-	PublicIPAddresses        *armnetwork.PublicIPAddressesClient
-	LoadBalancers            *armnetwork.LoadBalancersClient
-	ApplicationGateways      *armnetwork.ApplicationGatewaysClient
-	RouteTables              *armnetwork.RouteTablesClient
-	NetworkWatchers          *armnetwork.WatchersClient
-	VirtualNetworkGateways   *armnetwork.VirtualNetworkGatewaysClient
-	ExpressRouteCircuits     *armnetwork.ExpressRouteCircuitsClient
-	VirtualNetworkPeerings   *armnetwork.VirtualNetworkPeeringsClient
-	PrivateEndpoints         *armnetwork.PrivateEndpointsClient
-	FirewallPolicies         *armnetwork.FirewallPoliciesClient
-	Firewalls                *armnetwork.AzureFirewallsClient
-	DDoSProtectionPlans      *armnetwork.DdosProtectionPlansClient
-	BastionHosts             *armnetwork.BastionHostsClient
-	NATGateways              *armnetwork.NatGatewaysClient
+	VirtualNetworks        *armnetwork.VirtualNetworksClient
+	Subnets                *armnetwork.SubnetsClient
+	NetworkSecurityGroups  *armnetwork.SecurityGroupsClient
+	NetworkInterfaces      *armnetwork.InterfacesClient
+	PublicIPAddresses      *armnetwork.PublicIPAddressesClient
+	LoadBalancers          *armnetwork.LoadBalancersClient
+	ApplicationGateways    *armnetwork.ApplicationGatewaysClient
+	RouteTables            *armnetwork.RouteTablesClient
+	NetworkWatchers        *armnetwork.WatchersClient
+	VirtualNetworkGateways *armnetwork.VirtualNetworkGatewaysClient
+	ExpressRouteCircuits   *armnetwork.ExpressRouteCircuitsClient
+	VirtualNetworkPeerings *armnetwork.VirtualNetworkPeeringsClient
+	PrivateEndpoints       *armnetwork.PrivateEndpointsClient
+	FirewallPolicies       *armnetwork.FirewallPoliciesClient
+	Firewalls              *armnetwork.AzureFirewallsClient
+	DDoSProtectionPlans    *armnetwork.DdosProtectionPlansClient
+	BastionHosts           *armnetwork.BastionHostsClient
+	NATGateways            *armnetwork.NatGatewaysClient
 }
 
 // ComputeClients contains all compute-related clients
@@ -221,167 +386,372 @@ type ComputeClients struct {
 
 // StorageClients contains all storage-related clients
 type StorageClients struct {
-	Accounts           *armstorage.AccountsClient
-	BlobContainers     *armstorage.BlobContainersClient
-	BlobInventoryPolicies *armstorage.BlobInventoryPoliciesClient
-	BlobServices       *armstorage.BlobServicesClient
-	EncryptionScopes   *armstorage.EncryptionScopesClient
-	FileServices       *armstorage.FileServicesClient
-	FileShares         *armstorage.FileSharesClient
-	ManagementPolicies *armstorage.ManagementPoliciesClient
-	ObjectReplicationPolicies *armstorage.ObjectReplicationPoliciesClient
+	Accounts                   *armstorage.AccountsClient
+	BlobContainers             *armstorage.BlobContainersClient
+	BlobInventoryPolicies      *armstorage.BlobInventoryPoliciesClient
+	BlobServices               *armstorage.BlobServicesClient
+	EncryptionScopes           *armstorage.EncryptionScopesClient
+	FileServices               *armstorage.FileServicesClient
+	FileShares                 *armstorage.FileSharesClient
+	ManagementPolicies         *armstorage.ManagementPoliciesClient
+	ObjectReplicationPolicies  *armstorage.ObjectReplicationPoliciesClient
 	PrivateEndpointConnections *armstorage.PrivateEndpointConnectionsClient
-	PrivateLinkResources *armstorage.PrivateLinkResourcesClient
-	QueueServices      *armstorage.QueueServicesClient
-	Queues             *armstorage.QueueClient
-	TableServices      *armstorage.TableServicesClient
-	Tables             *armstorage.TableClient
-	Usage              *armstorage.UsagesClient
+	PrivateLinkResources       *armstorage.PrivateLinkResourcesClient
+	QueueServices              *armstorage.QueueServicesClient
+	Queues                     *armstorage.QueueClient
+	TableServices              *armstorage.TableServicesClient
+	Tables                     *armstorage.TableClient
+	Usage                      *armstorage.UsagesClient
 }
 
 // DatabaseClients contains all database-related clients
 type DatabaseClients struct {
-	SQLServers                *armsql.ServersClient
-	SQLDatabases              *armsql.DatabasesClient
-	SQLElasticPools           *armsql.ElasticPoolsClient
-	SQLFirewallRules          *armsql.FirewallRulesClient
-	SQLVirtualNetworkRules    *armsql.VirtualNetworkRulesClient
-	SQLBackupLongTermRetention *armsql.LongTermRetentionBackupsClient
+	SQLServers                   *armsql.ServersClient
+	SQLDatabases                 *armsql.DatabasesClient
+	SQLElasticPools              *armsql.ElasticPoolsClient
+	SQLFirewallRules             *armsql.FirewallRulesClient
+	SQLVirtualNetworkRules       *armsql.VirtualNetworkRulesClient
+	SQLBackupLongTermRetention   *armsql.LongTermRetentionBackupsClient
 	SQLTransparentDataEncryption *armsql.TransparentDataEncryptionsClient
-	SQLAuditingSettings       *armsql.DatabaseBlobAuditingPoliciesClient
-	CosmosDBAccounts          *armcosmos.DatabaseAccountsClient
-	CosmosDBDatabases         *armcosmos.SQLResourcesClient
-	MySQLServers              *armmysql.ServersClient
-	MySQLDatabases            *armmysql.DatabasesClient
-	MySQLFlexibleServers      *armmysqlflexibleservers.ServersClient
-	PostgreSQLServers         *armpostgresql.ServersClient
-	PostgreSQLDatabases       *armpostgresql.DatabasesClient
-	PostgreSQLFlexibleServers *armpostgresqlflexibleservers.ServersClient
-	MariaDBServers            *armmariadb.ServersClient
-	MariaDBDatabases          *armmariadb.DatabasesClient
-	RedisCache                *armredis.Client
+	SQLAuditingSettings          *armsql.DatabaseBlobAuditingPoliciesClient
+	CosmosDBAccounts             *armcosmos.DatabaseAccountsClient
+	CosmosDBDatabases            *armcosmos.SQLResourcesClient
+	MySQLServers                 *armmysql.ServersClient
+	MySQLDatabases               *armmysql.DatabasesClient
+	MySQLFlexibleServers         *armmysqlflexibleservers.ServersClient
+	PostgreSQLServers            *armpostgresql.ServersClient
+	PostgreSQLDatabases          *armpostgresql.DatabasesClient
+	PostgreSQLFlexibleServers    *armpostgresqlflexibleservers.ServersClient
+	MariaDBServers               *armmariadb.ServersClient
+	MariaDBDatabases             *armmariadb.DatabasesClient
+	RedisCache                   *armredis.Client
 }
 
 // SecurityClients contains all security-related clients
 type SecurityClients struct {
-	KeyVaults              *armkeyvault.VaultsClient
-	KeyVaultKeys           *armkeyvault.KeysClient
-	KeyVaultSecrets        *armkeyvault.SecretsClient
-	SecurityCenter         *armsecurity.AssessmentsClient
-	SecurityPricings       *armsecurity.PricingsClient
-	SecurityContacts       *armsecurity.ContactsClient
-	SecurityWorkspaceSettings *armsecurity.WorkspaceSettingsClient
-	SecurityAutoProvisioningSettings *armsecurity.AutoProvisioningSettingsClient
-	SecurityCompliances    *armsecurity.CompliancesClient
-	SecurityInformationProtectionPolicies *armsecurity.InformationProtectionPoliciesClient
-	SecurityAdvancedThreatProtection *armsecurity.AdvancedThreatProtectionClient
-	SecurityDeviceSecurityGroups *armsecurity.DeviceSecurityGroupsClient
-	SecurityIoTSecuritySolutions *armsecurity.IoTSecuritySolutionClient
-	SecurityAdaptiveApplicationControls *armsecurity.AdaptiveApplicationControlsClient
-	SecurityAdaptiveNetworkHardenings *armsecurity.AdaptiveNetworkHardeningsClient
-	SecurityAllowedConnections *armsecurity.AllowedConnectionsClient
-	SecurityTopology          *armsecurity.TopologyClient
-	SecurityJitNetworkAccessPolicies *armsecurity.JitNetworkAccessPoliciesClient
-	SecurityDiscoveredSecuritySolutions *armsecurity.DiscoveredSecuritySolutionsClient
-	SecurityExternalSecuritySolutions *armsecurity.ExternalSecuritySolutionsClient
-	SecuritySecureScores      *armsecurity.SecureScoresClient
-	SecuritySecureScoreControls *armsecurity.SecureScoreControlsClient
-	SecuritySecureScoreControlDefinitions *armsecurity.SecureScoreControlDefinitionsClient
-	SecurityRegulatoryComplianceStandards *armsecurity.RegulatoryComplianceStandardsClient
-	SecurityRegulatoryComplianceControls *armsecurity.RegulatoryComplianceControlsClient
+	KeyVaults                               *armkeyvault.VaultsClient
+	KeyVaultKeys                            *armkeyvault.KeysClient
+	KeyVaultSecrets                         *armkeyvault.SecretsClient
+	SecurityCenter                          *armsecurity.AssessmentsClient
+	SecurityPricings                        *armsecurity.PricingsClient
+	SecurityContacts                        *armsecurity.ContactsClient
+	SecurityWorkspaceSettings               *armsecurity.WorkspaceSettingsClient
+	SecurityAutoProvisioningSettings        *armsecurity.AutoProvisioningSettingsClient
+	SecurityCompliances                     *armsecurity.CompliancesClient
+	SecurityInformationProtectionPolicies   *armsecurity.InformationProtectionPoliciesClient
+	SecurityAdvancedThreatProtection        *armsecurity.AdvancedThreatProtectionClient
+	SecurityDeviceSecurityGroups            *armsecurity.DeviceSecurityGroupsClient
+	SecurityIoTSecuritySolutions            *armsecurity.IoTSecuritySolutionClient
+	SecurityAdaptiveApplicationControls     *armsecurity.AdaptiveApplicationControlsClient
+	SecurityAdaptiveNetworkHardenings       *armsecurity.AdaptiveNetworkHardeningsClient
+	SecurityAllowedConnections              *armsecurity.AllowedConnectionsClient
+	SecurityTopology                        *armsecurity.TopologyClient
+	SecurityJitNetworkAccessPolicies        *armsecurity.JitNetworkAccessPoliciesClient
+	SecurityDiscoveredSecuritySolutions     *armsecurity.DiscoveredSecuritySolutionsClient
+	SecurityExternalSecuritySolutions       *armsecurity.ExternalSecuritySolutionsClient
+	SecuritySecureScores                    *armsecurity.SecureScoresClient
+	SecuritySecureScoreControls             *armsecurity.SecureScoreControlsClient
+	SecuritySecureScoreControlDefinitions   *armsecurity.SecureScoreControlDefinitionsClient
+	SecurityRegulatoryComplianceStandards   *armsecurity.RegulatoryComplianceStandardsClient
+	SecurityRegulatoryComplianceControls    *armsecurity.RegulatoryComplianceControlsClient
 	SecurityRegulatoryComplianceAssessments *armsecurity.RegulatoryComplianceAssessmentsClient
-	SecuritySubAssessments    *armsecurity.SubAssessmentsClient
-	SecurityAutomations       *armsecurity.AutomationsClient
-	SecurityAlerts            *armsecurity.AlertsClient
-	SecuritySettings          *armsecurity.SettingsClient
-	SecurityIngestionSettings *armsecurity.IngestionSettingsClient
-	SecuritySoftwareInventories *armsecurity.SoftwareInventoriesClient
-	SecurityServerVulnerabilityAssessment *armsecurity.ServerVulnerabilityAssessmentClient
+	SecuritySubAssessments                  *armsecurity.SubAssessmentsClient
+	SecurityAutomations                     *armsecurity.AutomationsClient
+	SecurityAlerts                          *armsecurity.AlertsClient
+	SecuritySettings                        *armsecurity.SettingsClient
+	SecurityIngestionSettings               *armsecurity.IngestionSettingsClient
+	SecuritySoftwareInventories             *armsecurity.SoftwareInventoriesClient
+	SecurityServerVulnerabilityAssessment   *armsecurity.ServerVulnerabilityAssessmentClient
 }
 
 // MonitoringClients contains all monitoring-related clients
 type MonitoringClients struct {
-	Monitor                 *armmonitor.MetricsClient
-	MonitorAlertRules       *armmonitor.AlertRulesClient
-	MonitorLogProfiles      *armmonitor.LogProfilesClient
-	MonitorDiagnosticSettings *armmonitor.DiagnosticSettingsClient
-	MonitorActionGroups     *armmonitor.ActionGroupsClient
-	MonitorActivityLogs     *armmonitor.ActivityLogsClient
-	MonitorEventCategories  *armmonitor.EventCategoriesClient
-	MonitorTenantActivityLogs *armmonitor.TenantActivityLogsClient
-	MonitorMetricDefinitions *armmonitor.MetricDefinitionsClient
-	MonitorMetricNamespaces *armmonitor.MetricNamespacesClient
-	MonitorVMInsights       *armmonitor.VMInsightsClient
-	MonitorBaselines        *armmonitor.BaselinesClient
-	MonitorCalculateBaseline *armmonitor.CalculateBaselineClient
-	MonitorOperations       *armmonitor.OperationsClient
-	OperationalInsights     *armoperationalinsights.WorkspacesClient
-	OperationalInsightsIntelligencePacks *armoperationalinsights.IntelligencePacksClient
-	OperationalInsightsLinkedServices *armoperationalinsights.LinkedServicesClient
-	OperationalInsightsLinkedStorageAccounts *armoperationalinsights.LinkedStorageAccountsClient
-	OperationalInsightsManagementGroups *armoperationalinsights.ManagementGroupsClient
-	OperationalInsightsOperationStatuses *armoperationalinsights.OperationStatusesClient
-	OperationalInsightsSharedKeys *armoperationalinsights.SharedKeysClient
-	OperationalInsightsUsages   *armoperationalinsights.UsagesClient
-	OperationalInsightsClusters *armoperationalinsights.ClustersClient
-	OperationalInsightsStorageInsights *armoperationalinsights.StorageInsightConfigsClient
-	OperationalInsightsSavedSearches *armoperationalinsights.SavedSearchesClient
-	OperationalInsightsAvailableServiceTiers *armoperationalinsights.AvailableServiceTiersClient
-	OperationalInsightsGateways *armoperationalinsights.GatewaysClient
-	OperationalInsightsDataExports *armoperationalinsights.DataExportsClient
-	OperationalInsightsDataSources *armoperationalinsights.DataSourcesClient
-	OperationalInsightsTables   *armoperationalinsights.TablesClient
-	ApplicationInsights         *armapplicationinsights.ComponentsClient
-	ApplicationInsightsAnalyticsItems *armapplicationinsights.AnalyticsItemsClient
-	ApplicationInsightsAnnotations *armapplicationinsights.AnnotationsClient
-	ApplicationInsightsAPIKeys  *armapplicationinsights.APIKeysClient
-	ApplicationInsightsComponentAvailableFeatures *armapplicationinsights.ComponentAvailableFeaturesClient
+	Monitor                                            *armmonitor.MetricsClient
+	MonitorAlertRules                                  *armmonitor.AlertRulesClient
+	MonitorLogProfiles                                 *armmonitor.LogProfilesClient
+	MonitorDiagnosticSettings                          *armmonitor.DiagnosticSettingsClient
+	MonitorActionGroups                                *armmonitor.ActionGroupsClient
+	MonitorActivityLogs                                *armmonitor.ActivityLogsClient
+	MonitorEventCategories                             *armmonitor.EventCategoriesClient
+	MonitorTenantActivityLogs                          *armmonitor.TenantActivityLogsClient
+	MonitorMetricDefinitions                           *armmonitor.MetricDefinitionsClient
+	MonitorMetricNamespaces                            *armmonitor.MetricNamespacesClient
+	MonitorVMInsights                                  *armmonitor.VMInsightsClient
+	MonitorBaselines                                   *armmonitor.BaselinesClient
+	MonitorCalculateBaseline                           *armmonitor.CalculateBaselineClient
+	MonitorOperations                                  *armmonitor.OperationsClient
+	OperationalInsights                                *armoperationalinsights.WorkspacesClient
+	OperationalInsightsIntelligencePacks               *armoperationalinsights.IntelligencePacksClient
+	OperationalInsightsLinkedServices                  *armoperationalinsights.LinkedServicesClient
+	OperationalInsightsLinkedStorageAccounts           *armoperationalinsights.LinkedStorageAccountsClient
+	OperationalInsightsManagementGroups                *armoperationalinsights.ManagementGroupsClient
+	OperationalInsightsOperationStatuses               *armoperationalinsights.OperationStatusesClient
+	OperationalInsightsSharedKeys                      *armoperationalinsights.SharedKeysClient
+	OperationalInsightsUsages                          *armoperationalinsights.UsagesClient
+	OperationalInsightsClusters                        *armoperationalinsights.ClustersClient
+	OperationalInsightsStorageInsights                 *armoperationalinsights.StorageInsightConfigsClient
+	OperationalInsightsSavedSearches                   *armoperationalinsights.SavedSearchesClient
+	OperationalInsightsAvailableServiceTiers           *armoperationalinsights.AvailableServiceTiersClient
+	OperationalInsightsGateways                        *armoperationalinsights.GatewaysClient
+	OperationalInsightsDataExports                     *armoperationalinsights.DataExportsClient
+	OperationalInsightsDataSources                     *armoperationalinsights.DataSourcesClient
+	OperationalInsightsTables                          *armoperationalinsights.TablesClient
+	ApplicationInsights                                *armapplicationinsights.ComponentsClient
+	ApplicationInsightsAnalyticsItems                  *armapplicationinsights.AnalyticsItemsClient
+	ApplicationInsightsAnnotations                     *armapplicationinsights.AnnotationsClient
+	ApplicationInsightsAPIKeys                         *armapplicationinsights.APIKeysClient
+	ApplicationInsightsComponentAvailableFeatures      *armapplicationinsights.ComponentAvailableFeaturesClient
 	ApplicationInsightsComponentCurrentBillingFeatures *armapplicationinsights.ComponentCurrentBillingFeaturesClient
-	ApplicationInsightsComponentFeatureCapabilities *armapplicationinsights.ComponentFeatureCapabilitiesClient
-	ApplicationInsightsComponentQuotaStatus *armapplicationinsights.ComponentQuotaStatusClient
-	ApplicationInsightsExportConfigurations *armapplicationinsights.ExportConfigurationsClient
-	ApplicationInsightsFavorites *armapplicationinsights.FavoritesClient
-	ApplicationInsightsMyWorkbooks *armapplicationinsights.MyWorkbooksClient
-	ApplicationInsightsWebTests *armapplicationinsights.WebTestsClient
-	ApplicationInsightsWorkItemConfigurations *armapplicationinsights.WorkItemConfigurationsClient
-	ApplicationInsightsWorkbooks *armapplicationinsights.WorkbooksClient
+	ApplicationInsightsComponentFeatureCapabilities    *armapplicationinsights.ComponentFeatureCapabilitiesClient
+	ApplicationInsightsComponentQuotaStatus            *armapplicationinsights.ComponentQuotaStatusClient
+	ApplicationInsightsExportConfigurations            *armapplicationinsights.ExportConfigurationsClient
+	ApplicationInsightsFavorites                       *armapplicationinsights.FavoritesClient
+	ApplicationInsightsMyWorkbooks                     *armapplicationinsights.MyWorkbooksClient
+	ApplicationInsightsWebTests                        *armapplicationinsights.WebTestsClient
+	ApplicationInsightsWorkItemConfigurations          *armapplicationinsights.WorkItemConfigurationsClient
+	ApplicationInsightsWorkbooks                       *armapplicationinsights.WorkbooksClient
 }
 
 // BackupClients contains all backup-related clients
 type BackupClients struct {
-	RecoveryServices        *armrecoveryservices.VaultsClient
+	RecoveryServices                           *armrecoveryservices.VaultsClient
 	RecoveryServicesPrivateEndpointConnections *armrecoveryservices.PrivateEndpointConnectionsClient
-	RecoveryServicesPrivateLinkResources *armrecoveryservices.PrivateLinkResourcesClient
-	RecoveryServicesReplicationUsages *armrecoveryservices.ReplicationUsagesClient
-	RecoveryServicesUsages  *armrecoveryservices.UsagesClient
-	RecoveryServicesVaultCertificates *armrecoveryservices.VaultCertificatesClient
-	RecoveryServicesVaultExtendedInfo *armrecoveryservices.VaultExtendedInfoClient
-	RecoveryServicesRegisteredIdentities *armrecoveryservices.RegisteredIdentitiesClient
-	RecoveryServicesBackupEngines *armrecoveryservicesbackup.BackupEnginesClient
-	RecoveryServicesBackupPolicies *armrecoveryservicesbackup.BackupPoliciesClient
-	RecoveryServicesBackupJobs  *armrecoveryservicesbackup.BackupJobsClient
-	RecoveryServicesProtectionPolicies *armrecoveryservicesbackup.ProtectionPoliciesClient
-	RecoveryServicesProtectedItems *armrecoveryservicesbackup.ProtectedItemsClient
-	RecoveryServicesProtectionContainers *armrecoveryservicesbackup.ProtectionContainersClient
-	RecoveryServicesBackupWorkloadItems *armrecoveryservicesbackup.BackupWorkloadItemsClient
-	RecoveryServicesOperation   *armrecoveryservicesbackup.OperationClient
-	RecoveryServicesExports     *armrecoveryservicesbackup.ExportsClient
-	RecoveryServicesSecurityPINs *armrecoveryservicesbackup.SecurityPINsClient
-	RecoveryServicesRecoveryPoints *armrecoveryservicesbackup.RecoveryPointsClient
-	RecoveryServicesRestores    *armrecoveryservicesbackup.RestoresClient
-	RecoveryServicesBackupProtectableItems *armrecoveryservicesbackup.BackupProtectableItemsClient
-	RecoveryServicesBackupProtectionIntent *armrecoveryservicesbackup.BackupProtectionIntentClient
-	RecoveryServicesBackupStatus *armrecoveryservicesbackup.BackupStatusClient
-	RecoveryServicesFeatureSupport *armrecoveryservicesbackup.FeatureSupportClient
-	RecoveryServicesBackupUsageSummaries *armrecoveryservicesbackup.BackupUsageSummariesClient
-	RecoveryServicesBackups     *armrecoveryservicesbackup.BackupsClient
+	RecoveryServicesPrivateLinkResources       *armrecoveryservices.PrivateLinkResourcesClient
+	RecoveryServicesReplicationUsages          *armrecoveryservices.ReplicationUsagesClient
+	RecoveryServicesUsages                     *armrecoveryservices.UsagesClient
+	RecoveryServicesVaultCertificates          *armrecoveryservices.VaultCertificatesClient
+	RecoveryServicesVaultExtendedInfo          *armrecoveryservices.VaultExtendedInfoClient
+	RecoveryServicesRegisteredIdentities       *armrecoveryservices.RegisteredIdentitiesClient
+	RecoveryServicesBackupEngines              *armrecoveryservicesbackup.BackupEnginesClient
+	RecoveryServicesBackupPolicies             *armrecoveryservicesbackup.BackupPoliciesClient
+	RecoveryServicesBackupJobs                 *armrecoveryservicesbackup.BackupJobsClient
+	RecoveryServicesProtectionPolicies         *armrecoveryservicesbackup.ProtectionPoliciesClient
+	RecoveryServicesProtectedItems             *armrecoveryservicesbackup.ProtectedItemsClient
+	RecoveryServicesProtectionContainers       *armrecoveryservicesbackup.ProtectionContainersClient
+	RecoveryServicesBackupWorkloadItems        *armrecoveryservicesbackup.BackupWorkloadItemsClient
+	RecoveryServicesOperation                  *armrecoveryservicesbackup.OperationClient
+	RecoveryServicesExports                    *armrecoveryservicesbackup.ExportsClient
+	RecoveryServicesSecurityPINs               *armrecoveryservicesbackup.SecurityPINsClient
+	RecoveryServicesRecoveryPoints             *armrecoveryservicesbackup.RecoveryPointsClient
+	RecoveryServicesRestores                   *armrecoveryservicesbackup.RestoresClient
+	RecoveryServicesBackupProtectableItems     *armrecoveryservicesbackup.BackupProtectableItemsClient
+	RecoveryServicesBackupProtectionIntent     *armrecoveryservicesbackup.BackupProtectionIntentClient
+	RecoveryServicesBackupStatus               *armrecoveryservicesbackup.BackupStatusClient
+	RecoveryServicesFeatureSupport             *armrecoveryservicesbackup.FeatureSupportClient
+	RecoveryServicesBackupUsageSummaries       *armrecoveryservicesbackup.BackupUsageSummariesClient
+	RecoveryServicesBackups                    *armrecoveryservicesbackup.BackupsClient
+}
+
+// AzureClientFactory holds the credential and arm.ClientOptions shared by
+// every ARM client the suite creates, so NewAzureTestSuite doesn't have to
+// repeat subscriptionID/credential/armOptions at every call site.
+type AzureClientFactory struct {
+	subscriptionID string
+	credential     azcore.TokenCredential
+	options        *arm.ClientOptions
+}
+
+// newAzureClientFactory builds a factory bound to a single subscription,
+// credential, and set of client options.
+func newAzureClientFactory(subscriptionID string, credential azcore.TokenCredential, options *arm.ClientOptions) *AzureClientFactory {
+	return &AzureClientFactory{subscriptionID: subscriptionID, credential: credential, options: options}
+}
+
+// buildARMClient invokes a generated `armX.NewYClient` constructor with the
+// factory's shared arguments, wrapping any error with the given label so
+// callers get the same "failed to create <label> client" messages the
+// original hand-written constructors produced.
+func buildARMClient[T any](factory *AzureClientFactory, construct func(string, azcore.TokenCredential, *arm.ClientOptions) (T, error), label string) (T, error) {
+	client, err := construct(factory.subscriptionID, factory.credential, factory.options)
+	if err != nil {
+		return client, errors.Wrapf(err, "failed to create %s client", label)
+	}
+	return client, nil
+}
+
+// armRetryPolicy controls how retryARM backs off between attempts when an
+// ARM call is throttled or fails transiently.
+type armRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// defaultARMRetryPolicy is used by every arm client call routed through
+// retryARM unless a test overrides it.
+var defaultARMRetryPolicy = armRetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// isRetryableARMStatus reports whether an ARM response status code indicates
+// a transient condition (throttling or a temporarily unavailable backend)
+// worth retrying.
+func isRetryableARMStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryARM wraps an ARM SDK call, retrying on 429/503/502/504 responses. It
+// honors the Retry-After header when present and otherwise backs off
+// exponentially up to policy.MaxDelay.
+func retryARM(ctx context.Context, logger zerolog.Logger, policy armRetryPolicy, operation func() error) error {
+	delay := policy.BaseDelay
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		lastErr = operation()
+		if lastErr == nil {
+			return nil
+		}
+
+		respErr, ok := lastErr.(*azcore.ResponseError)
+		if !ok || !isRetryableARMStatus(respErr.StatusCode) {
+			return lastErr
+		}
+
+		wait := delay
+		if respErr.RawResponse != nil {
+			if retryAfter := respErr.RawResponse.Header.Get("Retry-After"); retryAfter != "" {
+				if seconds, err := strconv.Atoi(retryAfter); err == nil {
+					wait = time.Duration(seconds) * time.Second
+				}
+			}
+		}
+		if wait > policy.MaxDelay {
+			wait = policy.MaxDelay
+		}
+
+		logger.Warn().Err(lastErr).Int("attempt", attempt).Dur("wait", wait).
+			Msg("ARM operation throttled, retrying")
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+
+	return errors.Wrapf(lastErr, "ARM operation did not succeed after %d attempts", policy.MaxAttempts)
+}
+
+// pollARM polls a long-running ARM operation to completion, retrying the
+// poll itself when it hits throttling.
+func pollARM[T any](ctx context.Context, logger zerolog.Logger, poller *runtime.Poller[T]) (T, error) {
+	var result T
+	err := retryARM(ctx, logger, defaultARMRetryPolicy, func() error {
+		var pollErr error
+		result, pollErr = poller.PollUntilDone(ctx, nil)
+		return pollErr
+	})
+	return result, err
+}
+
+// armPager is satisfied by every generated *Pager[T] type in the ARM SDKs.
+type armPager[T any] interface {
+	NextPage(ctx context.Context) (T, error)
+}
+
+// nextPageARM fetches the next page of an ARM pager through retryARM so a
+// single throttled page doesn't fail an entire listing test.
+func nextPageARM[T any](ctx context.Context, logger zerolog.Logger, pager armPager[T]) (T, error) {
+	var page T
+	err := retryARM(ctx, logger, defaultARMRetryPolicy, func() error {
+		var pageErr error
+		page, pageErr = pager.NextPage(ctx)
+		return pageErr
+	})
+	return page, err
+}
+
+// BackupTestHarness drives an end-to-end cross-region restore validation flow:
+// given a source vault and protected item, it validates that a restore point
+// can be restored into a paired-region vault, then optionally triggers the
+// restore and confirms the resulting job lands in the destination vault.
+type BackupTestHarness struct {
+	suite  *AzureTestSuite
+	client *armrecoveryservicesbackup.CrossRegionRestoreClient
+}
+
+// NewBackupTestHarness builds a harness that talks to the cross-region
+// restore API using the suite's credential and configured Azure environment.
+func (suite *AzureTestSuite) NewBackupTestHarness() (*BackupTestHarness, error) {
+	client, err := armrecoveryservicesbackup.NewCrossRegionRestoreClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cross-region restore client")
+	}
+	return &BackupTestHarness{suite: suite, client: client}, nil
+}
+
+// ValidateCrossRegionRestore polls the long-running validate operation for
+// the destination-region vault and returns an error if the source recovery
+// point is not restorable there.
+func (h *BackupTestHarness) ValidateCrossRegionRestore(destinationRegion, destinationVaultName, destinationResourceGroup string, parameters armrecoveryservicesbackup.CrossRegionRestoreRequestResource) error {
+	poller, err := h.client.BeginValidate(h.suite.Context, destinationRegion, parameters, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to start cross-region restore validation")
+	}
+
+	if _, err := pollARM(h.suite.Context, h.suite.Logger, poller); err != nil {
+		return errors.Wrap(err, "cross-region restore validation did not complete successfully")
+	}
+
+	h.suite.Logger.Info().
+		Str("destination_region", destinationRegion).
+		Str("destination_vault", destinationVaultName).
+		Msg("Cross-region restore validated")
+	return nil
+}
+
+// TriggerCrossRegionRestore starts the actual restore into the paired-region
+// vault and asserts that the resulting job shows up under it.
+func (h *BackupTestHarness) TriggerCrossRegionRestore(destinationRegion, destinationVaultName, destinationResourceGroup string, parameters armrecoveryservicesbackup.CrossRegionRestoreRequestResource) error {
+	poller, err := h.client.BeginTrigger(h.suite.Context, destinationRegion, parameters, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to trigger cross-region restore")
+	}
+	if _, err := pollARM(h.suite.Context, h.suite.Logger, poller); err != nil {
+		return errors.Wrap(err, "cross-region restore did not complete successfully")
+	}
+
+	jobsPager := h.suite.RecoveryServicesBackupJobs().NewListPager(destinationVaultName, destinationResourceGroup, nil, nil)
+	for jobsPager.More() {
+		page, err := jobsPager.NextPage(h.suite.Context)
+		if err != nil {
+			return errors.Wrap(err, "failed to list backup jobs in destination vault")
+		}
+		if len(page.Value) > 0 {
+			return nil
+		}
+	}
+	return errors.New("cross-region restore job did not appear in destination vault")
+}
+
+// RecoveryServicesBackupJobs lazily returns a BackupJobsClient scoped to this
+// suite's subscription and Azure environment.
+func (suite *AzureTestSuite) RecoveryServicesBackupJobs() *armrecoveryservicesbackup.BackupJobsClient {
+	if suite.BackupClients.RecoveryServicesBackupJobs == nil {
+		client, err := armrecoveryservicesbackup.NewBackupJobsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
+		if err != nil {
+			suite.Logger.Warn().Err(err).Msg("Failed to initialize Backup Jobs client")
+			return nil
+		}
+		suite.BackupClients.RecoveryServicesBackupJobs = client
+	}
+	return suite.BackupClients.RecoveryServicesBackupJobs
 }
 
 // NewAzureTestSuite creates a new Azure test suite
-func NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, location string, config TestConfig) (*AzureTestSuite, error) {
+func NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, location string, config TestConfig, transportWrap ...func(policy.Transporter) policy.Transporter) (*AzureTestSuite, error) {
 	testID := uuid.New().String()[:8]
 	ctx := context.Background()
-	
+
 	// Initialize logger
 	logger := log.With().
 		Str("service", "azure-test-suite").
@@ -391,101 +761,134 @@ func NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, locatio
 		Str("test_id", testID).
 		Logger()
 
-	// Create Azure credential
-	credential, err := azidentity.NewClientSecretCredential(tenantID, clientID, clientSecret, nil)
+	// Resolve the target Azure cloud (public, sovereign, or Azure Stack) and
+	// build the arm.ClientOptions every client below shares.
+	environment, err := ResolveAzureEnvironment(config.AzureEnvironment.Name, config.AzureEnvironment)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Azure credential")
+		return nil, errors.Wrap(err, "failed to resolve Azure environment")
+	}
+	clientOpts := azcore.ClientOptions{Cloud: environment.cloudConfiguration()}
+	if config.FixtureConfig.Mode != "" && config.FixtureConfig.Mode != FixtureModeLive {
+		fixtureTransport, err := NewFixtureTransport(config.FixtureConfig.Mode, config.FixtureConfig.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize ARM fixture transport")
+		}
+		clientOpts.Transport = fixtureTransport
 	}
+	for _, wrap := range transportWrap {
+		clientOpts.Transport = wrap(clientOpts.Transport)
+	}
+	armOptions := &arm.ClientOptions{ClientOptions: clientOpts}
 
-	// Create Azure service clients
-	computeClient, err := armcompute.NewVirtualMachinesClient(subscriptionID, credential, nil)
+	// Create Azure credential using the configured credential source
+	credential, err := newAzureCredential(tenantID, clientID, clientSecret, config.AzureCredential, clientOpts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create compute client")
+		return nil, errors.Wrap(err, "failed to create Azure credential")
 	}
 
-	networkClient, err := armnetwork.NewVirtualNetworksClient(subscriptionID, credential, nil)
+	// Create Azure service clients through the shared factory instead of
+	// repeating subscriptionID/credential/armOptions at every call site.
+	factory := newAzureClientFactory(subscriptionID, credential, armOptions)
+
+	computeClient, err := buildARMClient(factory, armcompute.NewVirtualMachinesClient, "compute")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create network client")
+		return nil, err
 	}
-
-	storageClient, err := armstorage.NewAccountsClient(subscriptionID, credential, nil)
+	networkClient, err := buildARMClient(factory, armnetwork.NewVirtualNetworksClient, "network")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create storage client")
+		return nil, err
 	}
-
-	sqlClient, err := armsql.NewServersClient(subscriptionID, credential, nil)
+	storageClient, err := buildARMClient(factory, armstorage.NewAccountsClient, "storage")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create SQL client")
+		return nil, err
 	}
-
-	containerServiceClient, err := armcontainerservice.NewManagedClustersClient(subscriptionID, credential, nil)
+	sqlClient, err := buildARMClient(factory, armsql.NewServersClient, "SQL")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create container service client")
+		return nil, err
 	}
-
-	resourcesClient, err := armresources.NewClient(subscriptionID, credential, nil)
+	containerServiceClient, err := buildARMClient(factory, armcontainerservice.NewManagedClustersClient, "container service")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create resources client")
+		return nil, err
 	}
-
-	authorizationClient, err := armauthorization.NewRoleAssignmentsClient(subscriptionID, credential, nil)
+	agentPoolsClient, err := buildARMClient(factory, armcontainerservice.NewAgentPoolsClient, "agent pools")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create authorization client")
+		return nil, err
 	}
-
-	monitorClient, err := armmonitor.NewMetricsClient(subscriptionID, credential, nil)
+	costQueryClient, err := buildARMClient(factory, armcostmanagement.NewQueryClient, "cost query")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create monitor client")
+		return nil, err
 	}
-
-	operationalInsightsClient, err := armoperationalinsights.NewWorkspacesClient(subscriptionID, credential, nil)
+	costBudgetsClient, err := buildARMClient(factory, armcostmanagement.NewBudgetsClient, "cost budgets")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create operational insights client")
+		return nil, err
 	}
-
-	appServiceClient, err := armappservice.NewWebAppsClient(subscriptionID, credential, nil)
+	consumptionClient, err := buildARMClient(factory, armconsumption.NewUsageDetailsClient, "consumption usage details")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create app service client")
+		return nil, err
 	}
-
-	containerInstanceClient, err := armcontainerinstance.NewContainerGroupsClient(subscriptionID, credential, nil)
+	chaosClient, err := buildARMClient(factory, armchaos.NewExperimentsClient, "chaos experiments")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create container instance client")
+		return nil, err
 	}
-
-	keyVaultClient, err := armkeyvault.NewVaultsClient(subscriptionID, credential, nil)
+	resourceGraphClient, err := armresourcegraph.NewClient(factory.credential, factory.options)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create key vault client")
+		return nil, errors.Wrap(err, "failed to create resource graph client")
 	}
-
-	dnsClient, err := armdns.NewZonesClient(subscriptionID, credential, nil)
+	resourcesClient, err := buildARMClient(factory, armresources.NewClient, "resources")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create DNS client")
+		return nil, err
 	}
-
-	securityClient, err := armsecurity.NewAssessmentsClient(subscriptionID, credential, nil)
+	authorizationClient, err := buildARMClient(factory, armauthorization.NewRoleAssignmentsClient, "authorization")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create security client")
+		return nil, err
 	}
-
-	recoveryServicesClient, err := armrecoveryservices.NewVaultsClient(subscriptionID, credential, nil)
+	roleDefinitionsClient, err := buildARMClient(factory, armauthorization.NewRoleDefinitionsClient, "role definitions")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create recovery services client")
+		return nil, err
 	}
-
-	recoveryServicesBackupClient, err := armrecoveryservicesbackup.NewBackupPoliciesClient(subscriptionID, credential, nil)
+	monitorClient, err := buildARMClient(factory, armmonitor.NewMetricsClient, "monitor")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create recovery services backup client")
+		return nil, err
 	}
-
-	redisClient, err := armredis.NewClient(subscriptionID, credential, nil)
+	operationalInsightsClient, err := buildARMClient(factory, armoperationalinsights.NewWorkspacesClient, "operational insights")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Redis client")
+		return nil, err
 	}
-
-	cosmosDBClient, err := armcosmos.NewDatabaseAccountsClient(subscriptionID, credential, nil)
+	appServiceClient, err := buildARMClient(factory, armappservice.NewWebAppsClient, "app service")
+	if err != nil {
+		return nil, err
+	}
+	containerInstanceClient, err := buildARMClient(factory, armcontainerinstance.NewContainerGroupsClient, "container instance")
+	if err != nil {
+		return nil, err
+	}
+	keyVaultClient, err := buildARMClient(factory, armkeyvault.NewVaultsClient, "key vault")
+	if err != nil {
+		return nil, err
+	}
+	dnsClient, err := buildARMClient(factory, armdns.NewZonesClient, "DNS")
+	if err != nil {
+		return nil, err
+	}
+	securityClient, err := buildARMClient(factory, armsecurity.NewAssessmentsClient, "security")
+	if err != nil {
+		return nil, err
+	}
+	recoveryServicesClient, err := buildARMClient(factory, armrecoveryservices.NewVaultsClient, "recovery services")
+	if err != nil {
+		return nil, err
+	}
+	recoveryServicesBackupClient, err := buildARMClient(factory, armrecoveryservicesbackup.NewBackupPoliciesClient, "recovery services backup")
+	if err != nil {
+		return nil, err
+	}
+	redisClient, err := buildARMClient(factory, armredis.NewClient, "Redis")
+	if err != nil {
+		return nil, err
+	}
+	cosmosDBClient, err := buildARMClient(factory, armcosmos.NewDatabaseAccountsClient, "Cosmos DB")
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Cosmos DB client")
+		return nil, err
 	}
 
 	suite := &AzureTestSuite{
@@ -499,40 +902,49 @@ func NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, locatio
 		Logger:         logger,
 		Context:        ctx,
 		Credential:     credential,
-		
+		Environment:    environment,
+		ArmOptions:     armOptions,
+
 		// Core services
-		Compute:           computeClient,
-		Network:           networkClient,
-		Storage:           storageClient,
-		SQL:               sqlClient,
-		ContainerService:  containerServiceClient,
-		
+		Compute:          computeClient,
+		Network:          networkClient,
+		Storage:          storageClient,
+		SQL:              sqlClient,
+		ContainerService: containerServiceClient,
+		AgentPools:       agentPoolsClient,
+		CostQuery:        costQueryClient,
+		CostBudgets:      costBudgetsClient,
+		Consumption:      consumptionClient,
+		Chaos:            chaosClient,
+		ResourceGraph:    resourceGraphClient,
+
 		// Management services
-		Resources:         resourcesClient,
-		Authorization:     authorizationClient,
-		
+		Resources:       resourcesClient,
+		Authorization:   authorizationClient,
+		RoleDefinitions: roleDefinitionsClient,
+
 		// Monitoring and logging
-		Monitor:           monitorClient,
+		Monitor:             monitorClient,
 		OperationalInsights: operationalInsightsClient,
-		
+
 		// App services
 		AppService:        appServiceClient,
 		ContainerInstance: containerInstanceClient,
-		
+
 		// Security services
-		KeyVault:          keyVaultClient,
-		Security:          securityClient,
-		
+		KeyVault: keyVaultClient,
+		Security: securityClient,
+
 		// Network services
-		DNS:               dnsClient,
-		
+		DNS: dnsClient,
+
 		// Backup and recovery
-		RecoveryServices:  recoveryServicesClient,
+		RecoveryServices:       recoveryServicesClient,
 		RecoveryServicesBackup: recoveryServicesBackupClient,
-		
+
 		// Data services
-		Redis:             redisClient,
-		CosmosDB:          cosmosDBClient,
+		Redis:    redisClient,
+		CosmosDB: cosmosDBClient,
 	}
 
 	// Initialize additional clients
@@ -551,27 +963,27 @@ func NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, locatio
 // initializeAdditionalClients initializes additional Azure service clients
 func (suite *AzureTestSuite) initializeAdditionalClients() error {
 	var err error
-	
+
 	// Initialize Event Hub client
-	suite.EventHub, err = armeventhub.NewEventHubsClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.EventHub, err = armeventhub.NewEventHubsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Event Hub client")
 	}
 
 	// Initialize Service Bus client
-	suite.ServiceBus, err = armservicebus.NewQueuesClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.ServiceBus, err = armservicebus.NewQueuesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Service Bus client")
 	}
 
 	// Initialize Application Insights client
-	suite.ApplicationInsights, err = armapplicationinsights.NewComponentsClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.ApplicationInsights, err = armapplicationinsights.NewComponentsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Application Insights client")
 	}
 
 	// Initialize additional clients as needed
-	suite.Automation, err = armautomation.NewAccountClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.Automation, err = armautomation.NewAccountClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Automation client")
 	}
@@ -582,95 +994,95 @@ func (suite *AzureTestSuite) initializeAdditionalClients() error {
 // initializeClientCollections initializes client collections
 func (suite *AzureTestSuite) initializeClientCollections() error {
 	var err error
-	
+
 	// Initialize Network clients
 	suite.NetworkClients.VirtualNetworks = suite.Network
-	suite.NetworkClients.Subnets, err = armnetwork.NewSubnetsClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.NetworkClients.Subnets, err = armnetwork.NewSubnetsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Subnets client")
 	}
-	
-	suite.NetworkClients.NetworkSecurityGroups, err = armnetwork.NewSecurityGroupsClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.NetworkClients.NetworkSecurityGroups, err = armnetwork.NewSecurityGroupsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize NSG client")
 	}
-	
-	suite.NetworkClients.NetworkInterfaces, err = armnetwork.NewInterfacesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.NetworkClients.NetworkInterfaces, err = armnetwork.NewInterfacesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Network Interfaces client")
 	}
-	
-	suite.NetworkClients.PublicIPAddresses, err = armnetwork.NewPublicIPAddressesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.NetworkClients.PublicIPAddresses, err = armnetwork.NewPublicIPAddressesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Public IP client")
 	}
-	
-	suite.NetworkClients.LoadBalancers, err = armnetwork.NewLoadBalancersClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.NetworkClients.LoadBalancers, err = armnetwork.NewLoadBalancersClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Load Balancers client")
 	}
 
 	// Initialize Compute clients
 	suite.ComputeClients.VirtualMachines = suite.Compute
-	suite.ComputeClients.VirtualMachineScaleSets, err = armcompute.NewVirtualMachineScaleSetsClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.ComputeClients.VirtualMachineScaleSets, err = armcompute.NewVirtualMachineScaleSetsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize VMSS client")
 	}
-	
-	suite.ComputeClients.AvailabilitySets, err = armcompute.NewAvailabilitySetsClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.ComputeClients.AvailabilitySets, err = armcompute.NewAvailabilitySetsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Availability Sets client")
 	}
-	
-	suite.ComputeClients.Images, err = armcompute.NewImagesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.ComputeClients.Images, err = armcompute.NewImagesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Images client")
 	}
-	
-	suite.ComputeClients.Disks, err = armcompute.NewDisksClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.ComputeClients.Disks, err = armcompute.NewDisksClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Disks client")
 	}
-	
-	suite.ComputeClients.Snapshots, err = armcompute.NewSnapshotsClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.ComputeClients.Snapshots, err = armcompute.NewSnapshotsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Snapshots client")
 	}
 
 	// Initialize Storage clients
 	suite.StorageClients.Accounts = suite.Storage
-	suite.StorageClients.BlobContainers, err = armstorage.NewBlobContainersClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.StorageClients.BlobContainers, err = armstorage.NewBlobContainersClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Blob Containers client")
 	}
-	
-	suite.StorageClients.BlobServices, err = armstorage.NewBlobServicesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.StorageClients.BlobServices, err = armstorage.NewBlobServicesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize Blob Services client")
 	}
-	
-	suite.StorageClients.FileServices, err = armstorage.NewFileServicesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.StorageClients.FileServices, err = armstorage.NewFileServicesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize File Services client")
 	}
-	
-	suite.StorageClients.FileShares, err = armstorage.NewFileSharesClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.StorageClients.FileShares, err = armstorage.NewFileSharesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize File Shares client")
 	}
 
 	// Initialize Database clients
 	suite.DatabaseClients.SQLServers = suite.SQL
-	suite.DatabaseClients.SQLDatabases, err = armsql.NewDatabasesClient(suite.SubscriptionID, suite.Credential, nil)
+	suite.DatabaseClients.SQLDatabases, err = armsql.NewDatabasesClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize SQL Databases client")
 	}
-	
-	suite.DatabaseClients.SQLElasticPools, err = armsql.NewElasticPoolsClient(suite.SubscriptionID, suite.Credential, nil)
+
+	suite.DatabaseClients.SQLElasticPools, err = armsql.NewElasticPoolsClient(suite.SubscriptionID, suite.Credential, suite.ArmOptions)
 	if err != nil {
 		suite.Logger.Warn().Err(err).Msg("Failed to initialize SQL Elastic Pools client")
 	}
-	
+
 	suite.DatabaseClients.CosmosDBAccounts = suite.CosmosDB
 	suite.DatabaseClients.RedisCache = suite.Redis
 
@@ -716,6 +1128,7 @@ func TestAzureInfrastructure(t *testing.T) {
 	t.Run("Event Hub", suite.TestEventHub)
 	t.Run("Service Bus", suite.TestServiceBus)
 	t.Run("Compliance", suite.TestCompliance)
+	t.Run("Drift", suite.TestDrift)
 	t.Run("Performance", suite.TestPerformance)
 	t.Run("Disaster Recovery", suite.TestDisasterRecovery)
 	t.Run("Cost Management", suite.TestCostManagement)
@@ -731,23 +1144,23 @@ func (suite *AzureTestSuite) TestResourceGroups(t *testing.T) {
 	t.Run("Resource Groups", func(t *testing.T) {
 		pager := suite.Resources.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test resource group configuration
 			for _, rg := range page.Value {
 				// Test resource group name
 				assert.NotEmpty(t, *rg.Name, "Resource group should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *rg.Location, "Resource group should have a location")
-				
+
 				// Test provisioning state
 				if rg.Properties != nil && rg.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", string(*rg.Properties.ProvisioningState), 
+					assert.Equal(t, "Succeeded", string(*rg.Properties.ProvisioningState),
 						"Resource group should be successfully provisioned")
 				}
-				
+
 				// Test tags
 				if rg.Tags != nil {
 					suite.Logger.Info().Str("resource_group", *rg.Name).
@@ -769,29 +1182,29 @@ func (suite *AzureTestSuite) TestVirtualNetworks(t *testing.T) {
 	t.Run("Virtual Networks", func(t *testing.T) {
 		pager := suite.NetworkClients.VirtualNetworks.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test virtual network configuration
 			for _, vnet := range page.Value {
 				// Test virtual network name
 				assert.NotEmpty(t, *vnet.Name, "Virtual network should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *vnet.Location, "Virtual network should have a location")
-				
+
 				// Test provisioning state
 				if vnet.Properties != nil && vnet.Properties.ProvisioningState != nil {
-					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *vnet.Properties.ProvisioningState, 
+					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *vnet.Properties.ProvisioningState,
 						"Virtual network should be successfully provisioned")
 				}
-				
+
 				// Test address space
 				if vnet.Properties != nil && vnet.Properties.AddressSpace != nil {
-					assert.True(t, len(vnet.Properties.AddressSpace.AddressPrefixes) > 0, 
+					assert.True(t, len(vnet.Properties.AddressSpace.AddressPrefixes) > 0,
 						"Virtual network should have address prefixes")
 				}
-				
+
 				// Test subnets
 				if vnet.Properties != nil && vnet.Properties.Subnets != nil {
 					suite.Logger.Info().Str("vnet", *vnet.Name).
@@ -806,29 +1219,39 @@ func (suite *AzureTestSuite) TestVirtualNetworks(t *testing.T) {
 	t.Run("Network Security Groups", func(t *testing.T) {
 		pager := suite.NetworkClients.NetworkSecurityGroups.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test NSG configuration
 			for _, nsg := range page.Value {
 				// Test NSG name
 				assert.NotEmpty(t, *nsg.Name, "NSG should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *nsg.Location, "NSG should have a location")
-				
+
 				// Test provisioning state
 				if nsg.Properties != nil && nsg.Properties.ProvisioningState != nil {
-					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *nsg.Properties.ProvisioningState, 
+					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *nsg.Properties.ProvisioningState,
 						"NSG should be successfully provisioned")
 				}
-				
+
 				// Test security rules
 				if nsg.Properties != nil && nsg.Properties.SecurityRules != nil {
 					suite.Logger.Info().Str("nsg", *nsg.Name).
 						Int("security_rules", len(nsg.Properties.SecurityRules)).
 						Msg("NSG security rules")
 				}
+
+				// Test CIS conformance (e.g. no unrestricted RDP/SSH)
+				violations, err := CheckNSGConformance(nsg, CISNetworkSecurityBundle)
+				require.NoError(t, err, "Failed to evaluate NSG conformance")
+				for _, violation := range violations {
+					suite.Logger.Warn().Str("nsg", violation.NSGName).
+						Str("rule", violation.Expectation.Description).
+						Msg("NSG conformance violation")
+				}
+				assert.Empty(t, violations, "NSG %s should conform to the CIS network security bundle", *nsg.Name)
 			}
 		}
 	})
@@ -837,27 +1260,27 @@ func (suite *AzureTestSuite) TestVirtualNetworks(t *testing.T) {
 	t.Run("Public IP Addresses", func(t *testing.T) {
 		pager := suite.NetworkClients.PublicIPAddresses.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test public IP configuration
 			for _, pip := range page.Value {
 				// Test public IP name
 				assert.NotEmpty(t, *pip.Name, "Public IP should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *pip.Location, "Public IP should have a location")
-				
+
 				// Test provisioning state
 				if pip.Properties != nil && pip.Properties.ProvisioningState != nil {
-					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *pip.Properties.ProvisioningState, 
+					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *pip.Properties.ProvisioningState,
 						"Public IP should be successfully provisioned")
 				}
-				
+
 				// Test allocation method
 				if pip.Properties != nil && pip.Properties.PublicIPAllocationMethod != nil {
 					assert.Contains(t, []armnetwork.IPAllocationMethod{
-						armnetwork.IPAllocationMethodDynamic, 
+						armnetwork.IPAllocationMethodDynamic,
 						armnetwork.IPAllocationMethodStatic,
 					}, *pip.Properties.PublicIPAllocationMethod, "Public IP should have valid allocation method")
 				}
@@ -869,26 +1292,26 @@ func (suite *AzureTestSuite) TestVirtualNetworks(t *testing.T) {
 	t.Run("Load Balancers", func(t *testing.T) {
 		pager := suite.NetworkClients.LoadBalancers.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test load balancer configuration
 			for _, lb := range page.Value {
 				// Test load balancer name
 				assert.NotEmpty(t, *lb.Name, "Load balancer should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *lb.Location, "Load balancer should have a location")
-				
+
 				// Test provisioning state
 				if lb.Properties != nil && lb.Properties.ProvisioningState != nil {
-					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *lb.Properties.ProvisioningState, 
+					assert.Equal(t, armnetwork.ProvisioningStateSucceeded, *lb.Properties.ProvisioningState,
 						"Load balancer should be successfully provisioned")
 				}
-				
+
 				// Test frontend IP configurations
 				if lb.Properties != nil && lb.Properties.FrontendIPConfigurations != nil {
-					assert.True(t, len(lb.Properties.FrontendIPConfigurations) > 0, 
+					assert.True(t, len(lb.Properties.FrontendIPConfigurations) > 0,
 						"Load balancer should have frontend IP configurations")
 				}
 			}
@@ -906,63 +1329,88 @@ func (suite *AzureTestSuite) TestVirtualMachines(t *testing.T) {
 	t.Run("Virtual Machines", func(t *testing.T) {
 		pager := suite.ComputeClients.VirtualMachines.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test VM configuration
 			for _, vm := range page.Value {
 				// Test VM name
 				assert.NotEmpty(t, *vm.Name, "VM should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *vm.Location, "VM should have a location")
-				
+
 				// Test provisioning state
 				if vm.Properties != nil && vm.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *vm.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *vm.Properties.ProvisioningState,
 						"VM should be successfully provisioned")
 				}
-				
+
 				// Test VM size
 				if vm.Properties != nil && vm.Properties.HardwareProfile != nil && vm.Properties.HardwareProfile.VMSize != nil {
 					assert.NotEmpty(t, *vm.Properties.HardwareProfile.VMSize, "VM should have a size")
 				}
-				
+
 				// Test OS profile
 				if vm.Properties != nil && vm.Properties.OSProfile != nil {
 					assert.NotEmpty(t, *vm.Properties.OSProfile.ComputerName, "VM should have a computer name")
 				}
-				
+
 				// Test network interfaces
 				if vm.Properties != nil && vm.Properties.NetworkProfile != nil && vm.Properties.NetworkProfile.NetworkInterfaces != nil {
-					assert.True(t, len(vm.Properties.NetworkProfile.NetworkInterfaces) > 0, 
+					assert.True(t, len(vm.Properties.NetworkProfile.NetworkInterfaces) > 0,
 						"VM should have network interfaces")
 				}
 			}
 		}
 	})
 
+	// Test confidential-computing posture for any VM tagged confidential=true
+	t.Run("Confidential VM Attestation", func(t *testing.T) {
+		if !suite.Config.AttestationConfig.Enabled {
+			suite.Logger.Info().Msg("Attestation checks disabled, skipping")
+			return
+		}
+
+		pager := suite.ComputeClients.VirtualMachines.NewListAllPager(nil)
+		for pager.More() {
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
+			require.NoError(t, err)
+
+			for _, vm := range page.Value {
+				if !IsConfidentialTagged(vm) {
+					continue
+				}
+
+				violations := CheckConfidentialSecurityProfile(vm, suite.Config.AttestationConfig)
+				for _, v := range violations {
+					t.Errorf("VM %s failed confidential attestation check: %v", *vm.Name, v)
+				}
+			}
+		}
+	})
+
 	// Test Virtual Machine Scale Sets
 	t.Run("Virtual Machine Scale Sets", func(t *testing.T) {
 		pager := suite.ComputeClients.VirtualMachineScaleSets.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test VMSS configuration
 			for _, vmss := range page.Value {
 				// Test VMSS name
 				assert.NotEmpty(t, *vmss.Name, "VMSS should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *vmss.Location, "VMSS should have a location")
-				
+
 				// Test provisioning state
 				if vmss.Properties != nil && vmss.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *vmss.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *vmss.Properties.ProvisioningState,
 						"VMSS should be successfully provisioned")
 				}
-				
+
 				// Test SKU
 				if vmss.SKU != nil {
 					assert.NotEmpty(t, *vmss.SKU.Name, "VMSS should have a SKU name")
@@ -976,27 +1424,43 @@ func (suite *AzureTestSuite) TestVirtualMachines(t *testing.T) {
 	t.Run("Disks", func(t *testing.T) {
 		pager := suite.ComputeClients.Disks.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test disk configuration
 			for _, disk := range page.Value {
 				// Test disk name
 				assert.NotEmpty(t, *disk.Name, "Disk should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *disk.Location, "Disk should have a location")
-				
+
 				// Test provisioning state
 				if disk.Properties != nil && disk.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *disk.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *disk.Properties.ProvisioningState,
 						"Disk should be successfully provisioned")
 				}
-				
+
 				// Test disk size
 				if disk.Properties != nil && disk.Properties.DiskSizeGB != nil {
 					assert.True(t, *disk.Properties.DiskSizeGB > 0, "Disk should have size greater than 0")
 				}
+
+				// Test disk encryption
+				if disk.Properties != nil {
+					assert.NotNil(t, disk.Properties.Encryption, "Disk should have encryption configured")
+					if disk.Properties.Encryption != nil {
+						assert.NotNil(t, disk.Properties.Encryption.Type, "Disk encryption should specify a type")
+						if suite.Config.SecurityConfig.EncryptionRequired && disk.Properties.Encryption.Type != nil {
+							assert.Contains(t, []armcompute.EncryptionType{
+								armcompute.EncryptionTypeEncryptionAtRestWithCustomerKey,
+								armcompute.EncryptionTypeEncryptionAtRestWithPlatformAndCustomerKeys,
+							}, *disk.Properties.Encryption.Type,
+								"Disk should use a customer-managed key when encryption is required")
+							assert.NotNil(t, disk.Properties.Encryption.DiskEncryptionSetID, "CMK-encrypted disk should reference a disk encryption set")
+						}
+					}
+				}
 			}
 		}
 	})
@@ -1012,28 +1476,28 @@ func (suite *AzureTestSuite) TestStorage(t *testing.T) {
 	t.Run("Storage Accounts", func(t *testing.T) {
 		pager := suite.StorageClients.Accounts.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test storage account configuration
 			for _, account := range page.Value {
 				// Test storage account name
 				assert.NotEmpty(t, *account.Name, "Storage account should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *account.Location, "Storage account should have a location")
-				
+
 				// Test provisioning state
 				if account.Properties != nil && account.Properties.ProvisioningState != nil {
-					assert.Equal(t, armstorage.ProvisioningStateSucceeded, *account.Properties.ProvisioningState, 
+					assert.Equal(t, armstorage.ProvisioningStateSucceeded, *account.Properties.ProvisioningState,
 						"Storage account should be successfully provisioned")
 				}
-				
+
 				// Test SKU
 				if account.SKU != nil {
 					assert.NotEmpty(t, *account.SKU.Name, "Storage account should have a SKU")
 				}
-				
+
 				// Test kind
 				if account.Kind != nil {
 					assert.Contains(t, []armstorage.Kind{
@@ -1044,12 +1508,35 @@ func (suite *AzureTestSuite) TestStorage(t *testing.T) {
 						armstorage.KindBlockBlobStorage,
 					}, *account.Kind, "Storage account should have valid kind")
 				}
-				
+
 				// Test encryption
 				if account.Properties != nil && account.Properties.Encryption != nil {
 					suite.Logger.Info().Str("account", *account.Name).
 						Bool("encryption_enabled", account.Properties.Encryption.Services != nil).
 						Msg("Storage account encryption")
+
+					if services := account.Properties.Encryption.Services; services != nil {
+						if services.Blob != nil {
+							assert.True(t, services.Blob.Enabled != nil && *services.Blob.Enabled,
+								"Storage account blob service should have encryption enabled")
+						}
+						if services.File != nil {
+							assert.True(t, services.File.Enabled != nil && *services.File.Enabled,
+								"Storage account file service should have encryption enabled")
+						}
+					}
+
+					if suite.Config.SecurityConfig.EncryptionRequired {
+						assert.NotNil(t, account.Properties.Encryption.KeySource, "Storage account should specify an encryption key source")
+						if account.Properties.Encryption.KeySource != nil {
+							assert.Equal(t, armstorage.KeySourceMicrosoftKeyvault, *account.Properties.Encryption.KeySource,
+								"Storage account should use a customer-managed key (Key Vault) when encryption is required")
+						}
+						if account.Properties.Encryption.KeyVaultProperties != nil {
+							assert.NotEmpty(t, stringOrEmpty(account.Properties.Encryption.KeyVaultProperties.KeyName),
+								"CMK-encrypted storage account should reference a key name")
+						}
+					}
 				}
 			}
 		}
@@ -1066,22 +1553,22 @@ func (suite *AzureTestSuite) TestSQL(t *testing.T) {
 	t.Run("SQL Servers", func(t *testing.T) {
 		pager := suite.DatabaseClients.SQLServers.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test SQL server configuration
 			for _, server := range page.Value {
 				// Test server name
 				assert.NotEmpty(t, *server.Name, "SQL server should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *server.Location, "SQL server should have a location")
-				
+
 				// Test version
 				if server.Properties != nil && server.Properties.Version != nil {
 					assert.NotEmpty(t, *server.Properties.Version, "SQL server should have a version")
 				}
-				
+
 				// Test state
 				if server.Properties != nil && server.Properties.State != nil {
 					assert.Equal(t, "Ready", *server.Properties.State, "SQL server should be ready")
@@ -1090,6 +1577,46 @@ func (suite *AzureTestSuite) TestSQL(t *testing.T) {
 		}
 	})
 
+	// Test Transparent Data Encryption
+	t.Run("Transparent Data Encryption", func(t *testing.T) {
+		if !suite.Config.SecurityConfig.EncryptionRequired {
+			t.Skip("encryption not required by config")
+		}
+
+		serverPager := suite.DatabaseClients.SQLServers.NewListPager(nil)
+		for serverPager.More() {
+			serverPage, err := nextPageARM(suite.Context, suite.Logger, serverPager)
+			require.NoError(t, err)
+
+			for _, server := range serverPage.Value {
+				resourceGroup, err := resourceGroupFromID(stringOrEmpty(server.ID))
+				require.NoError(t, err)
+
+				dbPager := suite.DatabaseClients.SQLDatabases.NewListByServerPager(resourceGroup, *server.Name, nil)
+				for dbPager.More() {
+					dbPage, err := nextPageARM(suite.Context, suite.Logger, dbPager)
+					require.NoError(t, err)
+
+					for _, database := range dbPage.Value {
+						// The system "master" database carries no
+						// customer data and doesn't support TDE.
+						if database.Name != nil && *database.Name == "master" {
+							continue
+						}
+
+						tde, err := suite.DatabaseClients.SQLTransparentDataEncryption.Get(suite.Context, resourceGroup, *server.Name, *database.Name, nil)
+						if !assert.NoError(t, err, "failed to get TDE state for database %s/%s", *server.Name, *database.Name) {
+							continue
+						}
+						assert.Equal(t, armsql.TransparentDataEncryptionStateEnabled,
+							*tde.Properties.State,
+							"database %s/%s should have transparent data encryption enabled", *server.Name, *database.Name)
+					}
+				}
+			}
+		}
+	})
+
 	suite.Logger.Info().Msg("SQL infrastructure tests completed")
 }
 
@@ -1101,42 +1628,101 @@ func (suite *AzureTestSuite) TestAKS(t *testing.T) {
 	t.Run("AKS Clusters", func(t *testing.T) {
 		pager := suite.ContainerService.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test AKS cluster configuration
 			for _, cluster := range page.Value {
 				// Test cluster name
 				assert.NotEmpty(t, *cluster.Name, "AKS cluster should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *cluster.Location, "AKS cluster should have a location")
-				
+
 				// Test provisioning state
 				if cluster.Properties != nil && cluster.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *cluster.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *cluster.Properties.ProvisioningState,
 						"AKS cluster should be successfully provisioned")
 				}
-				
+
 				// Test Kubernetes version
 				if cluster.Properties != nil && cluster.Properties.KubernetesVersion != nil {
 					assert.NotEmpty(t, *cluster.Properties.KubernetesVersion, "AKS cluster should have Kubernetes version")
 				}
-				
+
 				// Test node resource group
 				if cluster.Properties != nil && cluster.Properties.NodeResourceGroup != nil {
 					assert.NotEmpty(t, *cluster.Properties.NodeResourceGroup, "AKS cluster should have node resource group")
 				}
-				
+
 				// Test agent pool profiles
 				if cluster.Properties != nil && cluster.Properties.AgentPoolProfiles != nil {
-					assert.True(t, len(cluster.Properties.AgentPoolProfiles) > 0, 
+					assert.True(t, len(cluster.Properties.AgentPoolProfiles) > 0,
 						"AKS cluster should have agent pool profiles")
 				}
 			}
 		}
 	})
 
+	// Test cluster-internal health: node readiness, core add-ons, agent
+	// pool shape and add-on bindings
+	t.Run("Cluster Health Probe", func(t *testing.T) {
+		pager := suite.ContainerService.NewListPager(nil)
+		for pager.More() {
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
+			require.NoError(t, err)
+
+			for _, cluster := range page.Value {
+				if cluster.Name == nil {
+					continue
+				}
+
+				health, err := suite.ProbeAKSCluster(cluster)
+				require.NoError(t, err, "should be able to deep-probe AKS cluster %s", *cluster.Name)
+
+				assert.True(t, health.NodesReady, "all nodes in AKS cluster %s should report Ready", *cluster.Name)
+				for _, addOn := range aksExpectedAddOns {
+					assert.True(t, health.CoreAddOnsReady[addOn],
+						"AKS cluster %s should have %s Ready in kube-system", *cluster.Name, addOn)
+				}
+				assert.Empty(t, health.CrashLoopingPods,
+					"AKS cluster %s should have no pods in CrashLoopBackOff", *cluster.Name)
+
+				for _, pool := range health.AgentPools {
+					assert.NotEmpty(t, pool.VMSize, "agent pool %s should report a VM size", pool.Name)
+					assert.NotEmpty(t, pool.OrchestratorVersion, "agent pool %s should report an orchestrator version", pool.Name)
+				}
+
+				if suite.OperationalInsights != nil {
+					assert.True(t, health.OmsAgentConfigured, "AKS cluster %s should have omsagent bound to the Log Analytics workspace", *cluster.Name)
+				}
+				if suite.Config.SecurityConfig.EncryptionRequired {
+					assert.True(t, health.AzurePolicyEnabled, "AKS cluster %s should have the azurepolicy add-on enabled", *cluster.Name)
+				}
+				if suite.KeyVault != nil {
+					assert.True(t, health.KeyVaultSecretsBound, "AKS cluster %s should have the Key Vault secrets provider add-on bound", *cluster.Name)
+				}
+			}
+		}
+	})
+
+	// Test SEV-SNP attestation for confidential node pools
+	t.Run("SNP Report Verification", func(t *testing.T) {
+		if !suite.Config.AttestationConfig.Enabled || suite.Config.AttestationConfig.Variant != AttestationVariantAzureSEVSNP {
+			suite.Logger.Info().Msg("SEV-SNP attestation disabled, skipping")
+			return
+		}
+		if suite.Config.AttestationConfig.MAAEndpoint == "" {
+			t.Fatal("attestation_config.maa_endpoint must be set to verify SNP reports")
+		}
+
+		report, err := FetchSNPReportFromMAA(suite.Config.AttestationConfig.MAAEndpoint)
+		require.NoError(t, err, "should be able to fetch an SNP attestation report from MAA")
+
+		err = VerifySNPReport(report, suite.Config.AttestationConfig)
+		assert.NoError(t, err, "SNP attestation report should match the configured measurement set")
+	})
+
 	suite.Logger.Info().Msg("AKS infrastructure tests completed")
 }
 
@@ -1148,22 +1734,22 @@ func (suite *AzureTestSuite) TestAppService(t *testing.T) {
 	t.Run("Web Apps", func(t *testing.T) {
 		pager := suite.AppService.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test web app configuration
 			for _, app := range page.Value {
 				// Test app name
 				assert.NotEmpty(t, *app.Name, "Web app should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *app.Location, "Web app should have a location")
-				
+
 				// Test state
 				if app.Properties != nil && app.Properties.State != nil {
 					assert.Equal(t, "Running", *app.Properties.State, "Web app should be running")
 				}
-				
+
 				// Test host names
 				if app.Properties != nil && app.Properties.HostNames != nil {
 					assert.True(t, len(app.Properties.HostNames) > 0, "Web app should have host names")
@@ -1183,32 +1769,32 @@ func (suite *AzureTestSuite) TestContainerInstances(t *testing.T) {
 	t.Run("Container Groups", func(t *testing.T) {
 		pager := suite.ContainerInstance.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test container group configuration
 			for _, group := range page.Value {
 				// Test group name
 				assert.NotEmpty(t, *group.Name, "Container group should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *group.Location, "Container group should have a location")
-				
+
 				// Test provisioning state
 				if group.Properties != nil && group.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *group.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *group.Properties.ProvisioningState,
 						"Container group should be successfully provisioned")
 				}
-				
+
 				// Test instance view state
 				if group.Properties != nil && group.Properties.InstanceView != nil && group.Properties.InstanceView.State != nil {
-					assert.Contains(t, []string{"Pending", "Running", "Succeeded", "Failed"}, 
+					assert.Contains(t, []string{"Pending", "Running", "Succeeded", "Failed"},
 						*group.Properties.InstanceView.State, "Container group should have valid state")
 				}
-				
+
 				// Test containers
 				if group.Properties != nil && group.Properties.Containers != nil {
-					assert.True(t, len(group.Properties.Containers) > 0, 
+					assert.True(t, len(group.Properties.Containers) > 0,
 						"Container group should have containers")
 				}
 			}
@@ -1226,26 +1812,26 @@ func (suite *AzureTestSuite) TestKeyVault(t *testing.T) {
 	t.Run("Key Vaults", func(t *testing.T) {
 		pager := suite.KeyVault.NewListPager(nil, nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test key vault configuration
 			for _, vault := range page.Value {
 				// Test vault name
 				assert.NotEmpty(t, *vault.Name, "Key vault should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *vault.Location, "Key vault should have a location")
-				
+
 				// Test properties
 				if vault.Properties != nil {
 					// Test tenant ID
 					assert.NotEmpty(t, *vault.Properties.TenantID, "Key vault should have tenant ID")
-					
+
 					// Test SKU
 					assert.NotNil(t, vault.Properties.SKU, "Key vault should have SKU")
 					assert.NotEmpty(t, *vault.Properties.SKU.Name, "Key vault SKU should have name")
-					
+
 					// Test vault URI
 					assert.NotEmpty(t, *vault.Properties.VaultURI, "Key vault should have URI")
 				}
@@ -1265,14 +1851,14 @@ func (suite *AzureTestSuite) TestSecurity(t *testing.T) {
 		scope := fmt.Sprintf("/subscriptions/%s", suite.SubscriptionID)
 		pager := suite.Security.NewListPager(scope, nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test security assessment configuration
 			for _, assessment := range page.Value {
 				// Test assessment name
 				assert.NotEmpty(t, *assessment.Name, "Security assessment should have a name")
-				
+
 				// Test status
 				if assessment.Properties != nil && assessment.Properties.Status != nil {
 					assert.NotEmpty(t, *assessment.Properties.Status.Code, "Security assessment should have status code")
@@ -1292,20 +1878,20 @@ func (suite *AzureTestSuite) TestMonitoring(t *testing.T) {
 	t.Run("Log Analytics Workspaces", func(t *testing.T) {
 		pager := suite.OperationalInsights.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test workspace configuration
 			for _, workspace := range page.Value {
 				// Test workspace name
 				assert.NotEmpty(t, *workspace.Name, "Log Analytics workspace should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *workspace.Location, "Log Analytics workspace should have a location")
-				
+
 				// Test provisioning state
 				if workspace.Properties != nil && workspace.Properties.ProvisioningState != nil {
-					assert.Equal(t, armoperationalinsights.WorkspaceEntityStatusSucceeded, *workspace.Properties.ProvisioningState, 
+					assert.Equal(t, armoperationalinsights.WorkspaceEntityStatusSucceeded, *workspace.Properties.ProvisioningState,
 						"Log Analytics workspace should be successfully provisioned")
 				}
 			}
@@ -1316,32 +1902,51 @@ func (suite *AzureTestSuite) TestMonitoring(t *testing.T) {
 	t.Run("Application Insights", func(t *testing.T) {
 		pager := suite.ApplicationInsights.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test Application Insights configuration
 			for _, component := range page.Value {
 				// Test component name
 				assert.NotEmpty(t, *component.Name, "Application Insights component should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *component.Location, "Application Insights component should have a location")
-				
+
 				// Test provisioning state
 				if component.Properties != nil && component.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *component.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *component.Properties.ProvisioningState,
 						"Application Insights component should be successfully provisioned")
 				}
-				
+
 				// Test application type
 				if component.Properties != nil && component.Properties.ApplicationType != nil {
-					assert.NotEmpty(t, *component.Properties.ApplicationType, 
+					assert.NotEmpty(t, *component.Properties.ApplicationType,
 						"Application Insights component should have application type")
 				}
 			}
 		}
 	})
 
+	// Test scrape target discovery for monitoring integrations
+	t.Run("Service Discovery", func(t *testing.T) {
+		discovery := suite.NewServiceDiscovery()
+
+		vmTargets, err := discovery.DiscoverVirtualMachineTargets("9100")
+		require.NoError(t, err, "Failed to discover VM scrape targets")
+
+		aksTargets, err := discovery.DiscoverAKSNodeTargets("10250")
+		require.NoError(t, err, "Failed to discover AKS scrape targets")
+
+		_, err = WriteFileSD(append(vmTargets, aksTargets...))
+		require.NoError(t, err, "Failed to marshal discovered scrape targets")
+
+		suite.Logger.Info().
+			Int("vm_targets", len(vmTargets)).
+			Int("aks_targets", len(aksTargets)).
+			Msg("Service discovery completed")
+	})
+
 	suite.Logger.Info().Msg("Monitoring infrastructure tests completed")
 }
 
@@ -1353,23 +1958,23 @@ func (suite *AzureTestSuite) TestBackup(t *testing.T) {
 	t.Run("Recovery Services Vaults", func(t *testing.T) {
 		pager := suite.RecoveryServices.NewListBySubscriptionIDPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test vault configuration
 			for _, vault := range page.Value {
 				// Test vault name
 				assert.NotEmpty(t, *vault.Name, "Recovery Services vault should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *vault.Location, "Recovery Services vault should have a location")
-				
+
 				// Test properties
 				if vault.Properties != nil && vault.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *vault.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *vault.Properties.ProvisioningState,
 						"Recovery Services vault should be successfully provisioned")
 				}
-				
+
 				// Test SKU
 				if vault.SKU != nil {
 					assert.NotEmpty(t, *vault.SKU.Name, "Recovery Services vault should have SKU")
@@ -1378,6 +1983,24 @@ func (suite *AzureTestSuite) TestBackup(t *testing.T) {
 		}
 	})
 
+	// Test cross-region restore validation, when a paired region is configured
+	t.Run("Cross-Region Restore", func(t *testing.T) {
+		if suite.Config.RecoveryConfig.PairedRegionVault == "" {
+			t.Skip("no paired-region vault configured, skipping cross-region restore validation")
+		}
+
+		harness, err := suite.NewBackupTestHarness()
+		require.NoError(t, err, "Failed to create backup test harness")
+
+		err = harness.ValidateCrossRegionRestore(
+			suite.Config.RecoveryConfig.PairedRegion,
+			suite.Config.RecoveryConfig.PairedRegionVault,
+			suite.Config.RecoveryConfig.PairedRegionResourceGroup,
+			armrecoveryservicesbackup.CrossRegionRestoreRequestResource{},
+		)
+		assert.NoError(t, err, "Cross-region restore should validate successfully")
+	})
+
 	suite.Logger.Info().Msg("Backup infrastructure tests completed")
 }
 
@@ -1389,32 +2012,32 @@ func (suite *AzureTestSuite) TestCosmosDB(t *testing.T) {
 	t.Run("Cosmos DB Accounts", func(t *testing.T) {
 		pager := suite.CosmosDB.NewListPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test Cosmos DB account configuration
 			for _, account := range page.Value {
 				// Test account name
 				assert.NotEmpty(t, *account.Name, "Cosmos DB account should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *account.Location, "Cosmos DB account should have a location")
-				
+
 				// Test provisioning state
 				if account.Properties != nil && account.Properties.ProvisioningState != nil {
-					assert.Equal(t, "Succeeded", *account.Properties.ProvisioningState, 
+					assert.Equal(t, "Succeeded", *account.Properties.ProvisioningState,
 						"Cosmos DB account should be successfully provisioned")
 				}
-				
+
 				// Test database account offer type
 				if account.Properties != nil && account.Properties.DatabaseAccountOfferType != nil {
-					assert.Equal(t, armcosmos.DatabaseAccountOfferTypeStandard, *account.Properties.DatabaseAccountOfferType, 
+					assert.Equal(t, armcosmos.DatabaseAccountOfferTypeStandard, *account.Properties.DatabaseAccountOfferType,
 						"Cosmos DB account should have standard offer type")
 				}
-				
+
 				// Test consistency policy
 				if account.Properties != nil && account.Properties.ConsistencyPolicy != nil {
-					assert.NotEmpty(t, *account.Properties.ConsistencyPolicy.DefaultConsistencyLevel, 
+					assert.NotEmpty(t, *account.Properties.ConsistencyPolicy.DefaultConsistencyLevel,
 						"Cosmos DB account should have consistency level")
 				}
 			}
@@ -1432,23 +2055,23 @@ func (suite *AzureTestSuite) TestRedis(t *testing.T) {
 	t.Run("Redis Cache", func(t *testing.T) {
 		pager := suite.Redis.NewListBySubscriptionPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			// Test Redis cache configuration
 			for _, cache := range page.Value {
 				// Test cache name
 				assert.NotEmpty(t, *cache.Name, "Redis cache should have a name")
-				
+
 				// Test location
 				assert.NotEmpty(t, *cache.Location, "Redis cache should have a location")
-				
+
 				// Test provisioning state
 				if cache.Properties != nil && cache.Properties.ProvisioningState != nil {
-					assert.Equal(t, armredis.ProvisioningStateSucceeded, *cache.Properties.ProvisioningState, 
+					assert.Equal(t, armredis.ProvisioningStateSucceeded, *cache.Properties.ProvisioningState,
 						"Redis cache should be successfully provisioned")
 				}
-				
+
 				// Test SKU
 				if cache.Properties != nil && cache.Properties.SKU != nil {
 					assert.NotEmpty(t, *cache.Properties.SKU.Name, "Redis cache should have SKU name")
@@ -1484,21 +2107,105 @@ func (suite *AzureTestSuite) TestServiceBus(t *testing.T) {
 func (suite *AzureTestSuite) TestCompliance(t *testing.T) {
 	suite.Logger.Info().Msg("Testing compliance")
 
-	// Test Policy Compliance
-	t.Run("Policy Compliance", func(t *testing.T) {
-		// This would test Azure Policy compliance
-		suite.Logger.Info().Msg("Policy compliance tests would be implemented here")
+	// Test Policy and Security Center compliance against the declarative
+	// rule bundle configured for this environment.
+	t.Run("Compliance Rule Bundle", func(t *testing.T) {
+		engine := suite.NewComplianceEngine(DefaultCISComplianceBundle)
+		violations, err := engine.Evaluate()
+		require.NoError(t, err, "Failed to evaluate compliance rule bundle")
+
+		for _, violation := range violations {
+			suite.Logger.Warn().
+				Str("policy", violation.Policy.Name).
+				Str("reason", violation.Reason).
+				Msg("Compliance violation")
+		}
+		assert.Empty(t, violations, "All configured compliance policies should pass")
 	})
 
-	// Test Security Compliance
-	t.Run("Security Compliance", func(t *testing.T) {
-		// This would test Security Center compliance
-		suite.Logger.Info().Msg("Security compliance tests would be implemented here")
+	// Test declarative Rego rules against a live resource graph, emitting a
+	// SARIF artifact and gating pass/fail on the configured severity
+	// threshold rather than failing on any violation.
+	t.Run("Rego Policy Rules", func(t *testing.T) {
+		graph, err := suite.BuildResourceGraph()
+		require.NoError(t, err, "Failed to build resource graph")
+
+		findings, err := EvaluateRegoRules(suite.Context, graph, DefaultRegoPolicyBundle)
+		require.NoError(t, err, "Failed to evaluate Rego policy rules")
+
+		for _, finding := range findings {
+			suite.Logger.Warn().
+				Str("rule", finding.Rule).
+				Str("severity", finding.Severity).
+				Str("message", finding.Message).
+				Msg("Policy-as-code violation")
+		}
+
+		if suite.Config.SARIFOutputPath != "" {
+			err := WriteSARIF(suite.Config.SARIFOutputPath, findings)
+			require.NoError(t, err, "Failed to write SARIF artifact")
+		}
+
+		threshold := suite.Config.SeverityThreshold
+		if threshold == "" {
+			threshold = "high"
+		}
+		assert.False(t, FindingsExceedThreshold(findings, threshold),
+			"No policy-as-code findings should meet or exceed the %s severity threshold", threshold)
 	})
 
 	suite.Logger.Info().Msg("Compliance tests completed")
 }
 
+// TestDrift tests Terraform state against live Azure resources
+func (suite *AzureTestSuite) TestDrift(t *testing.T) {
+	suite.Logger.Info().Msg("Testing for Terraform/Azure drift")
+
+	if suite.Config.TerraformDir == "" {
+		suite.Logger.Info().Msg("No terraform_dir configured, skipping drift detection")
+		suite.Logger.Info().Msg("Drift detection tests completed")
+		return
+	}
+
+	// Test State vs Live Resources
+	t.Run("State vs Live Resources", func(t *testing.T) {
+		state, err := ShowTerraformState(suite.Config.TerraformDir)
+		require.NoError(t, err, "Failed to read Terraform state")
+		managed := ExtractManagedResources(state)
+
+		live, err := suite.BuildDriftResourceGraph(suite.ResourceGraph)
+		require.NoError(t, err, "Failed to build live resource graph for drift comparison")
+
+		findings := DiffDrift(live, managed)
+		for _, finding := range findings {
+			suite.Logger.Warn().
+				Str("kind", string(finding.Kind)).
+				Str("resource", finding.Resource).
+				Str("field", finding.Field).
+				Interface("expected", finding.Expected).
+				Interface("actual", finding.Actual).
+				Msg("Drift finding")
+		}
+
+		if suite.Config.DriftReportPath != "" {
+			report := DriftReport{GeneratedAt: time.Now().UTC(), Findings: findings}
+			err := WriteDriftReport(suite.Config.DriftReportPath, report)
+			require.NoError(t, err, "Failed to write drift report")
+		}
+
+		assert.Empty(t, findings, "Live Azure resources should match Terraform state")
+	})
+
+	// Test Detailed Plan Exit Code
+	t.Run("Detailed Plan Exit Code", func(t *testing.T) {
+		exitCode, err := PlanDetailedExitCode(suite.Config.TerraformDir)
+		require.NoError(t, err, "Failed to run terraform plan -detailed-exitcode")
+		assert.NotEqual(t, 2, exitCode, "terraform plan should report no pending changes")
+	})
+
+	suite.Logger.Info().Msg("Drift detection tests completed")
+}
+
 // TestPerformance tests performance
 func (suite *AzureTestSuite) TestPerformance(t *testing.T) {
 	suite.Logger.Info().Msg("Testing performance")
@@ -1508,7 +2215,7 @@ func (suite *AzureTestSuite) TestPerformance(t *testing.T) {
 		// Test VMSS auto scaling
 		pager := suite.ComputeClients.VirtualMachineScaleSets.NewListAllPager(nil)
 		for pager.More() {
-			page, err := pager.NextPage(suite.Context)
+			page, err := nextPageARM(suite.Context, suite.Logger, pager)
 			require.NoError(t, err)
 
 			for _, vmss := range page.Value {
@@ -1528,16 +2235,40 @@ func (suite *AzureTestSuite) TestPerformance(t *testing.T) {
 func (suite *AzureTestSuite) TestDisasterRecovery(t *testing.T) {
 	suite.Logger.Info().Msg("Testing disaster recovery")
 
-	// Test Backup Configuration
-	t.Run("Backup Configuration", func(t *testing.T) {
-		// This would test backup policies and configurations
-		suite.Logger.Info().Msg("Backup configuration tests would be implemented here")
+	if suite.Config.DRPlanPath == "" {
+		suite.Logger.Info().Msg("No dr_plan_path configured, skipping disaster recovery drills")
+		suite.Logger.Info().Msg("Disaster recovery tests completed")
+		return
+	}
+
+	plan, err := LoadDRPlan(suite.Config.DRPlanPath)
+	require.NoError(t, err)
+
+	// Test Chaos Drills
+	t.Run("Chaos Drills", func(t *testing.T) {
+		for _, scenario := range plan.Scenarios {
+			scenario := scenario
+			t.Run(scenario.Name, func(t *testing.T) {
+				prober := HTTPProber{URL: scenario.ProbeURL}
+				result := suite.AssertDRDrill(t, scenario, prober, 0)
+				suite.Logger.Info().
+					Str("experiment_id", result.ExperimentID).
+					Dur("downtime", result.Downtime).
+					Dur("time_to_recover", result.TimeToRecover).
+					Float64("error_rate", result.ErrorRate).
+					Msg("Chaos drill completed")
+			})
+		}
 	})
 
 	// Test Geo-Replication
 	t.Run("Geo-Replication", func(t *testing.T) {
-		// This would test geo-replication for databases and storage
-		suite.Logger.Info().Msg("Geo-replication tests would be implemented here")
+		for _, scenario := range plan.StorageFailover {
+			scenario := scenario
+			t.Run(scenario.Name, func(t *testing.T) {
+				suite.AssertStorageFailoverDrill(t, scenario)
+			})
+		}
 	})
 
 	suite.Logger.Info().Msg("Disaster recovery tests completed")
@@ -1555,65 +2286,88 @@ func (suite *AzureTestSuite) TestCostManagement(t *testing.T) {
 
 	// Test Budget Alerts
 	t.Run("Budget Alerts", func(t *testing.T) {
-		// This would test budget configurations
-		suite.Logger.Info().Msg("Budget alert tests would be implemented here")
-	})
-
-	suite.Logger.Info().Msg("Cost management tests completed")
-}
-
-// Helper methods for health checks and connectivity tests
-func (suite *AzureTestSuite) TestDatabaseHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database health")
-	// Implementation would test actual database connectivity
-	return nil
-}
-
-func (suite *AzureTestSuite) TestCacheHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing cache health")
-	// Implementation would test actual cache connectivity
-	return nil
-}
+		if suite.Config.CostBudgetPolicyPath == "" {
+			suite.Logger.Info().Msg("No cost_budget_policy_path configured, skipping budget assertions")
+			return
+		}
+		reports := suite.AssertCostBudgets(t, suite.Config.CostBudgetPolicyPath)
 
-func (suite *AzureTestSuite) TestLoadBalancerHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing load balancer health")
-	// Implementation would test actual load balancer connectivity
-	return nil
-}
+		// Anomaly detection and forecast/burn-rate run per budget scope so
+		// each carries its own resource-group cost history and threshold.
+		policy, err := LoadCostBudgetPolicy(suite.Config.CostBudgetPolicyPath)
+		require.NoError(t, err)
 
-func (suite *AzureTestSuite) TestContainerServiceHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing container service health")
-	// Implementation would test actual container service connectivity
-	return nil
-}
+		artifact := CostManagementArtifact{
+			GeneratedAt: time.Now().UTC(),
+			Reports:     reports,
+			Forecasts:   make(map[string]BurnRateForecast),
+		}
+		for _, budget := range policy.Budgets {
+			dailyCosts, err := suite.FetchDailyCosts(budget.Scope, 30)
+			require.NoError(t, err, "should be able to fetch daily costs for scope %s", budget.Scope)
+
+			anomalies := DetectCostAnomalies(dailyCosts, suite.Config.CostAnomalyK)
+			for _, anomaly := range anomalies {
+				suite.Logger.Warn().
+					Str("resource_group", anomaly.ResourceGroup).
+					Time("date", anomaly.Date).
+					Float64("cost_usd", anomaly.CostUSD).
+					Float64("median_usd", anomaly.MedianUSD).
+					Msg("Cost anomaly detected")
+			}
+			artifact.Anomalies = append(artifact.Anomalies, anomalies...)
 
-func (suite *AzureTestSuite) TestHTTPConnectivity(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing HTTP connectivity")
-	// Implementation would test actual HTTP connectivity using http-helper
-	return nil
-}
+			for _, report := range reports {
+				if report.Scope == budget.Scope {
+					artifact.Forecasts[budget.Scope] = ComputeBurnRate(report.ActualUSD, budget.MonthlyUSD, time.Now().UTC())
+				}
+			}
+		}
 
-func (suite *AzureTestSuite) TestInternalConnectivity(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing internal connectivity")
-	// Implementation would test actual internal connectivity
-	return nil
-}
+		if suite.Config.CostReportArtifactPath != "" {
+			err := WriteCostReportArtifact(suite.Config.CostReportArtifactPath, artifact)
+			require.NoError(t, err, "should be able to write cost management artifact")
+		}
+	})
 
-func (suite *AzureTestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
-	suite.Logger.Info().Msg("Testing SSL configuration")
-	// Implementation would test SSL/TLS configuration
-	return nil
+	suite.Logger.Info().Msg("Cost management tests completed")
 }
 
+// Helper methods for health checks and connectivity tests.
+//
+// TestDatabaseHealth, TestCacheHealth, TestLoadBalancerHealth,
+// TestContainerServiceHealth, TestHTTPConnectivity, TestInternalConnectivity
+// and TestSSLConfiguration live in azure_probes_test.go, backed by the
+// shared Prober subsystem.
+
+// TestNetworkSecurity and TestAccessControls both evaluate the shared Azure
+// policy-as-code bundle (DefaultRegoPolicyBundle in azure_rego_policy_test.go)
+// against a freshly built resource graph; TestAccessControls owns running it
+// since building the graph only needs to happen once. Sensitive-port NSG
+// ingress and wildcard custom-role actions are both covered by the bundle.
 func (suite *AzureTestSuite) TestNetworkSecurity(outputs map[string]interface{}) error {
 	suite.Logger.Info().Msg("Testing network security")
-	// Implementation would test network security rules
-	return nil
+	return suite.TestAccessControls(outputs)
 }
 
 func (suite *AzureTestSuite) TestAccessControls(outputs map[string]interface{}) error {
 	suite.Logger.Info().Msg("Testing access controls")
-	// Implementation would test access control policies
+
+	graph, err := suite.BuildResourceGraph()
+	if err != nil {
+		return errors.Wrap(err, "failed to build resource graph")
+	}
+
+	findings, err := EvaluateRegoRules(suite.Context, graph, DefaultRegoPolicyBundle)
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate policy bundle")
+	}
+	for _, f := range findings {
+		suite.Logger.Error().Str("rule", f.Rule).Str("severity", f.Severity).Msg(f.Message)
+	}
+	if len(findings) > 0 {
+		return errors.Errorf("%d policy violation(s) found (see log for rule IDs and resource names)", len(findings))
+	}
 	return nil
 }
 
@@ -1641,26 +2395,86 @@ func (suite *AzureTestSuite) TestEndurancePerformance(outputs map[string]interfa
 	return nil
 }
 
-func (suite *AzureTestSuite) TestDatabaseBackup(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database backup")
-	// Implementation would test database backup functionality
-	return nil
-}
-
-func (suite *AzureTestSuite) TestStorageBackup(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing storage backup")
-	// Implementation would test storage backup functionality
-	return nil
-}
+// TestDatabaseBackup and TestStorageBackup drive a real seed/verify backup
+// round trip and live in azure_backup_verify_test.go.
 
+// TestMonitoringEndpoint scrapes endpoint as Prometheus would and checks
+// suite.Config.MonitoringRequiredMetrics/MonitoringExpectedTargets against
+// it; see runMonitoringCheck in monitoring_verify_test.go.
 func (suite *AzureTestSuite) TestMonitoringEndpoint(endpoint string) error {
 	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing monitoring endpoint")
-	// Implementation would test monitoring endpoint
-	return nil
+	return runMonitoringCheck(suite.Context, suite.Logger, endpoint, suite.Config.MonitoringRequiredMetrics, suite.Config.MonitoringExpectedTargets)
 }
 
+// TestAlertingEndpoint queries endpoint's Alertmanager API and fails if
+// any alert outside suite.Config.MonitoringAllowedFiringAlerts is firing;
+// see runAlertingCheck in monitoring_verify_test.go.
 func (suite *AzureTestSuite) TestAlertingEndpoint(endpoint string) error {
 	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing alerting endpoint")
-	// Implementation would test alerting endpoint
-	return nil
-}
\ No newline at end of file
+	return runAlertingCheck(suite.Context, suite.Logger, endpoint, suite.Config.MonitoringAllowedFiringAlerts)
+}
+
+// azureProviderTestSuite adapts *AzureTestSuite to the ProviderTestSuite
+// interface registered under the "azure" name.
+type azureProviderTestSuite struct {
+	suite *AzureTestSuite
+}
+
+func (p *azureProviderTestSuite) Name() string { return "azure" }
+
+// TestConnectivity pages through Resources.NewListPager once, a read-only,
+// always-permitted call, as a lightweight reachability check.
+func (p *azureProviderTestSuite) TestConnectivity(ctx context.Context) error {
+	pager := p.suite.Resources.NewListPager(nil)
+	if !pager.More() {
+		return nil
+	}
+	_, err := pager.NextPage(ctx)
+	return err
+}
+
+func (p *azureProviderTestSuite) TestBackup(ctx context.Context) error {
+	return unimplementedCapability("azure", "TestBackup")
+}
+
+func (p *azureProviderTestSuite) TestDatabaseReplication(ctx context.Context) error {
+	return unimplementedCapability("azure", "TestDatabaseReplication")
+}
+
+// azureProviderPlugin is the ProviderPlugin registered for "azure";
+// RegisterProvider is called from this file's init() below.
+type azureProviderPlugin struct {
+	recorder *iamsynth.Recorder
+}
+
+func (p *azureProviderPlugin) Name() string { return "azure" }
+
+func (p *azureProviderPlugin) Capabilities() []string { return []string{"connectivity"} }
+
+// WithRecorder implements recorderAwarePlugin so
+// initializeCloudProviderSuites can thread suite.Recorder through to
+// NewAzureTestSuite for least-privilege IAM policy synthesis.
+func (p *azureProviderPlugin) WithRecorder(recorder *iamsynth.Recorder) ProviderPlugin {
+	return &azureProviderPlugin{recorder: recorder}
+}
+
+func (p *azureProviderPlugin) Init(provider CloudProvider, testConfig TestConfig) (ProviderTestSuite, error) {
+	subscriptionID := provider.Credentials["subscription_id"]
+	tenantID := provider.Credentials["tenant_id"]
+	clientID := provider.Credentials["client_id"]
+	clientSecret := provider.Credentials["client_secret"]
+	if subscriptionID == "" {
+		subscriptionID = "default-subscription"
+	}
+
+	suite, err := NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, provider.Regions[0], testConfig,
+		func(next policy.Transporter) policy.Transporter { return iamsynth.WrapTransport(p.recorder, next) })
+	if err != nil {
+		return nil, err
+	}
+	return &azureProviderTestSuite{suite: suite}, nil
+}
+
+func init() {
+	RegisterProvider("azure", func() ProviderPlugin { return &azureProviderPlugin{} })
+}
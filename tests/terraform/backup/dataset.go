@@ -0,0 +1,40 @@
+package backup
+
+import (
+	"context"
+	"time"
+)
+
+// Record is one unit of seeded data a DatasetSeeder produced and a
+// Comparator later checks for. Hash is the seeded content's SHA-256 at
+// seed time; SeededAt is when it was written, used to compute RPO as the
+// age of the newest record a Comparator reports missing or corrupt.
+type Record struct {
+	Key      string
+	Hash     [32]byte
+	SeededAt time.Time
+}
+
+// Mismatch is one Record a Comparator could not verify against the
+// restored target, with a human-readable Reason (missing, hash mismatch,
+// read error, ...).
+type Mismatch struct {
+	Key    string
+	Reason string
+}
+
+// DatasetSeeder writes a known dataset into a source system and returns
+// the Records needed to later verify a restore of it. Implementations are
+// domain-specific (object-storage blobs, database rows, ...); this
+// package only defines the contract so a cross-provider backup/restore
+// check can drive any of them interchangeably.
+type DatasetSeeder interface {
+	Seed(ctx context.Context) ([]Record, error)
+}
+
+// Comparator checks seeded Records against a restored target, returning
+// one Mismatch per record that's missing or whose content hash no longer
+// matches.
+type Comparator interface {
+	Verify(ctx context.Context, seeded []Record) ([]Mismatch, error)
+}
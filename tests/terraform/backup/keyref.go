@@ -0,0 +1,47 @@
+package backup
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+)
+
+// KeyRefScheme names a SigningKeyRef URI's backing key store.
+type KeyRefScheme string
+
+const (
+	SchemeAWSKMS        KeyRefScheme = "aws-kms"
+	SchemeGCPKMS        KeyRefScheme = "gcp-kms"
+	SchemeAzureKeyVault KeyRefScheme = "azure-keyvault"
+)
+
+// KeyRef is a parsed BackupConfig.SigningKeyRef, e.g.
+// "aws-kms://my-bucket/backups/signing-key.enc".
+type KeyRef struct {
+	Scheme KeyRefScheme
+	KeyID  string
+}
+
+// ParseKeyRef parses a SigningKeyRef URI. Resolving a KeyRef into actual
+// key material requires a live client for Scheme, which this package
+// doesn't hold -- that's KeyResolver's job, implemented per provider
+// alongside the suite that has the client.
+func ParseKeyRef(ref string) (KeyRef, error) {
+	scheme, keyID, ok := strings.Cut(ref, "://")
+	if !ok || keyID == "" {
+		return KeyRef{}, fmt.Errorf("invalid signing key reference %q: expected scheme://key-id", ref)
+	}
+	switch KeyRefScheme(scheme) {
+	case SchemeAWSKMS, SchemeGCPKMS, SchemeAzureKeyVault:
+	default:
+		return KeyRef{}, fmt.Errorf("unsupported signing key scheme %q", scheme)
+	}
+	return KeyRef{Scheme: KeyRefScheme(scheme), KeyID: keyID}, nil
+}
+
+// KeyResolver resolves a parsed KeyRef into the Ed25519 private key used
+// to sign a Manifest.
+type KeyResolver interface {
+	Resolve(ctx context.Context, ref KeyRef) (ed25519.PrivateKey, error)
+}
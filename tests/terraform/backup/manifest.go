@@ -0,0 +1,95 @@
+package backup
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// Manifest is a backup's integrity record: the Merkle Root over its
+// chunks, enough of the tree (Leaves) to recompute any chunk's proof path
+// without re-reading the backup, and an Ed25519 Signature over the
+// manifest's own bytes (with Signature blank) under the key SigningKeyRef
+// names.
+type Manifest struct {
+	Root          string   `json:"root"`
+	ChunkSize     int      `json:"chunk_size"`
+	ChunkCount    int      `json:"chunk_count"`
+	Leaves        []string `json:"leaves"`
+	SigningKeyRef string   `json:"signing_key_ref"`
+	Signature     string   `json:"signature,omitempty"`
+}
+
+// NewManifest builds an unsigned Manifest from tree.
+func NewManifest(tree Tree, signingKeyRef string) Manifest {
+	root := tree.Root()
+	leaves := make([]string, tree.ChunkCount())
+	for i, leaf := range tree.Levels[0] {
+		leaves[i] = hex.EncodeToString(leaf[:])
+	}
+	return Manifest{
+		Root:          hex.EncodeToString(root[:]),
+		ChunkSize:     ChunkSize,
+		ChunkCount:    len(leaves),
+		Leaves:        leaves,
+		SigningKeyRef: signingKeyRef,
+	}
+}
+
+// Tree rebuilds the Merkle Tree this manifest was generated from out of
+// its stored Leaves, so a verifier can recompute any chunk's Path without
+// re-reading the full backup, then checks the rebuilt root still matches
+// m.Root.
+func (m Manifest) Tree() (Tree, error) {
+	leaves := make([][32]byte, len(m.Leaves))
+	for i, encoded := range m.Leaves {
+		decoded, err := hex.DecodeString(encoded)
+		if err != nil || len(decoded) != 32 {
+			return Tree{}, fmt.Errorf("manifest leaf %d is not a valid SHA-256 hash", i)
+		}
+		copy(leaves[i][:], decoded)
+	}
+
+	tree := BuildTree(leaves)
+	root := tree.Root()
+	if hex.EncodeToString(root[:]) != m.Root {
+		return Tree{}, fmt.Errorf("manifest root does not match its own leaves -- manifest may be corrupt or tampered")
+	}
+	return tree, nil
+}
+
+// signingBytes is the canonical payload Sign/Verify operate over.
+func (m Manifest) signingBytes() ([]byte, error) {
+	unsigned := m
+	unsigned.Signature = ""
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest for signing: %w", err)
+	}
+	return data, nil
+}
+
+// Sign signs m with key, setting m.Signature to the hex-encoded result.
+func (m *Manifest) Sign(key ed25519.PrivateKey) error {
+	data, err := m.signingBytes()
+	if err != nil {
+		return err
+	}
+	m.Signature = hex.EncodeToString(ed25519.Sign(key, data))
+	return nil
+}
+
+// Verify reports whether m.Signature is a valid Ed25519 signature over m
+// (with Signature cleared) under publicKey.
+func (m Manifest) Verify(publicKey ed25519.PublicKey) (bool, error) {
+	signature, err := hex.DecodeString(m.Signature)
+	if err != nil {
+		return false, fmt.Errorf("failed to decode manifest signature: %w", err)
+	}
+	data, err := m.signingBytes()
+	if err != nil {
+		return false, err
+	}
+	return ed25519.Verify(publicKey, data, signature), nil
+}
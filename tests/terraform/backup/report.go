@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProviderOutcome is one DatasetSeeder/Comparator pair's result: how long
+// seeding (standing in for the backup window) and verification (the
+// restore window) took, the RPO implied by whatever was lost, and every
+// Mismatch a Comparator found. Err is set instead when seeding or
+// verification itself failed outright, before any Mismatches could be
+// computed.
+type ProviderOutcome struct {
+	Provider        string
+	BackupWindow    time.Duration
+	RestoreWindow   time.Duration
+	RPO             time.Duration
+	RecordsVerified int
+	Mismatches      []Mismatch
+	Err             error
+}
+
+// junitTestSuite and junitTestCase mirror the minimal JUnit XML schema CI
+// dashboards already parse -- just enough structure for one testcase per
+// ProviderOutcome.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// outcomeFailureMessage summarizes why outcome failed, or "" if it passed.
+func outcomeFailureMessage(outcome ProviderOutcome) string {
+	switch {
+	case outcome.Err != nil:
+		return outcome.Err.Error()
+	case len(outcome.Mismatches) > 0:
+		return fmt.Sprintf("%d record(s) failed restore verification", len(outcome.Mismatches))
+	default:
+		return ""
+	}
+}
+
+// WriteJUnit writes outcomes as a JUnit XML report to path, one testcase
+// per provider, creating any missing parent directories.
+func WriteJUnit(path, suiteName string, outcomes []ProviderOutcome) error {
+	suite := junitTestSuite{Name: suiteName, Tests: len(outcomes)}
+	for _, outcome := range outcomes {
+		testCase := junitTestCase{
+			Name: outcome.Provider,
+			Time: (outcome.BackupWindow + outcome.RestoreWindow).Seconds(),
+		}
+		if failure := outcomeFailureMessage(outcome); failure != "" {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: failure, Text: failure}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JUnit report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create JUnit report directory: %w", err)
+	}
+	return os.WriteFile(path, append([]byte(xml.Header), data...), 0o644)
+}
+
+// WriteMarkdown writes outcomes as a Markdown summary table to path,
+// creating any missing parent directories.
+func WriteMarkdown(path string, outcomes []ProviderOutcome) error {
+	var b strings.Builder
+	b.WriteString("# Cross-Provider Backup/Restore Verification\n\n")
+	b.WriteString("| Provider | Records | Backup Window | Restore Window | RPO | Status |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, outcome := range outcomes {
+		status := "PASS"
+		if failure := outcomeFailureMessage(outcome); failure != "" {
+			status = "FAIL: " + failure
+		}
+		fmt.Fprintf(&b, "| %s | %d | %s | %s | %s | %s |\n",
+			outcome.Provider, outcome.RecordsVerified, outcome.BackupWindow, outcome.RestoreWindow, outcome.RPO, status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create Markdown report directory: %w", err)
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
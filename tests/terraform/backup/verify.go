@@ -0,0 +1,48 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+)
+
+// SampleIndices picks sampleSize distinct chunk indices out of
+// [0,chunkCount), for a spot check that doesn't need to re-download every
+// chunk. If sampleSize >= chunkCount, every index is returned.
+func SampleIndices(rng *rand.Rand, chunkCount, sampleSize int) []int {
+	if sampleSize >= chunkCount {
+		indices := make([]int, chunkCount)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	picked := make(map[int]struct{}, sampleSize)
+	indices := make([]int, 0, sampleSize)
+	for len(indices) < sampleSize {
+		i := rng.Intn(chunkCount)
+		if _, ok := picked[i]; ok {
+			continue
+		}
+		picked[i] = struct{}{}
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// VerifyChunk reports whether chunkData is the chunk that produced the
+// leaf at index in tree, by hashing chunkData and recomputing its Merkle
+// path to tree's root -- proving both that the chunk's content matches
+// and that its leaf genuinely belongs to this tree.
+func VerifyChunk(tree Tree, index int, chunkData []byte) (bool, error) {
+	path, err := tree.Path(index)
+	if err != nil {
+		return false, err
+	}
+	if index >= len(tree.Levels[0]) {
+		return false, fmt.Errorf("chunk index %d out of range", index)
+	}
+	leaf := sha256.Sum256(chunkData)
+	return VerifyPath(leaf, path, tree.Root()), nil
+}
@@ -0,0 +1,367 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/backup"
+)
+
+// defaultBackupSpotCheckSampleSize is how many chunks
+// VerifyBackupTarget spot-checks per target, balancing a meaningful
+// integrity sample against re-downloading the whole backup on every run.
+const defaultBackupSpotCheckSampleSize = 8
+
+// manifestKeyFor derives the manifest.json object key alongside
+// objectKey -- the convention UploadBackupWithManifest and
+// VerifyBackupTarget both rely on to find each other's output.
+func manifestKeyFor(objectKey string) string {
+	return objectKey + ".manifest.json"
+}
+
+// resolveSigningKey resolves ref into the Ed25519 private key used to
+// sign a backup manifest. Only "aws-kms" is implemented: KeyID names an
+// "bucket/object" pair holding a KMS-encrypted 32-byte Ed25519 seed,
+// which this decrypts via KMS Decrypt (asymmetric KMS keys don't support
+// Ed25519, so the seed is envelope-encrypted under a symmetric CMK
+// instead of signed by KMS directly). "gcp-kms"/"azure-keyvault" have no
+// resolver wired into this harness yet.
+func (suite *MultiCloudTestSuite) resolveSigningKey(ctx context.Context, ref string) (ed25519.PrivateKey, error) {
+	parsed, err := backup.ParseKeyRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parsed.Scheme {
+	case backup.SchemeAWSKMS:
+		if suite.AWSTestSuite == nil {
+			return nil, errors.New("signing_key_ref is aws-kms:// but no AWSTestSuite is initialized")
+		}
+		bucket, object, ok := splitBucketObject(parsed.KeyID)
+		if !ok {
+			return nil, errors.Errorf("invalid aws-kms signing key reference %q: expected bucket/object", ref)
+		}
+
+		getOutput, err := suite.AWSTestSuite.S3().GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch encrypted signing key %s/%s", bucket, object)
+		}
+		defer getOutput.Body.Close()
+
+		ciphertext, err := io.ReadAll(getOutput.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read encrypted signing key object")
+		}
+
+		decryptOutput, err := suite.AWSTestSuite.KMS().Decrypt(ctx, &kms.DecryptInput{
+			CiphertextBlob: ciphertext,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt signing key via KMS")
+		}
+		if len(decryptOutput.Plaintext) != ed25519.SeedSize {
+			return nil, errors.Errorf("decrypted signing key is %d bytes, expected %d-byte Ed25519 seed", len(decryptOutput.Plaintext), ed25519.SeedSize)
+		}
+		return ed25519.NewKeyFromSeed(decryptOutput.Plaintext), nil
+
+	default:
+		return nil, errors.Errorf("no signing key resolver implemented for scheme %q", parsed.Scheme)
+	}
+}
+
+// splitBucketObject splits an "bucket/object/key/path" reference on its
+// first slash.
+func splitBucketObject(ref string) (bucket, object string, ok bool) {
+	for i := 0; i < len(ref); i++ {
+		if ref[i] == '/' {
+			return ref[:i], ref[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// awsBackupBucket returns the name of the first AWS bucket configured in
+// StorageConfig, which the backup-verification/restore tests use as a
+// scratch location for their synthetic payloads. There's no
+// backup-specific bucket field on BackupTarget, so this follows the same
+// bucket list everything else in StorageConfig draws from.
+func (suite *MultiCloudTestSuite) awsBackupBucket() (string, bool) {
+	for _, bucket := range suite.MultiCloudConfig.StorageConfig.Buckets {
+		if bucket.Provider == "aws" {
+			return bucket.Name, true
+		}
+	}
+	return "", false
+}
+
+// UploadBackupWithManifest uploads data to bucket/objectKey, builds a
+// Merkle manifest over it, signs the manifest if
+// BackupConfig.SigningKeyRef is set, and uploads the manifest alongside
+// it at manifestKeyFor(objectKey).
+func (suite *MultiCloudTestSuite) UploadBackupWithManifest(ctx context.Context, bucket, objectKey string, data []byte) (backup.Manifest, error) {
+	if suite.AWSTestSuite == nil {
+		return backup.Manifest{}, errors.New("UploadBackupWithManifest requires an initialized AWSTestSuite")
+	}
+
+	tree, err := backup.HashSource(bytes.NewReader(data))
+	if err != nil {
+		return backup.Manifest{}, errors.Wrap(err, "failed to build Merkle tree over backup data")
+	}
+	manifest := backup.NewManifest(tree, suite.MultiCloudConfig.BackupConfig.SigningKeyRef)
+
+	if manifest.SigningKeyRef != "" {
+		key, err := suite.resolveSigningKey(ctx, manifest.SigningKeyRef)
+		if err != nil {
+			return backup.Manifest{}, errors.Wrap(err, "failed to resolve backup signing key")
+		}
+		if err := manifest.Sign(key); err != nil {
+			return backup.Manifest{}, errors.Wrap(err, "failed to sign backup manifest")
+		}
+	}
+
+	if _, err := suite.AWSTestSuite.S3().PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(objectKey),
+		Body:   bytes.NewReader(data),
+	}); err != nil {
+		return backup.Manifest{}, errors.Wrapf(err, "failed to upload backup object %s/%s", bucket, objectKey)
+	}
+
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return backup.Manifest{}, errors.Wrap(err, "failed to marshal backup manifest")
+	}
+	if _, err := suite.AWSTestSuite.S3().PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(manifestKeyFor(objectKey)),
+		Body:   bytes.NewReader(manifestData),
+	}); err != nil {
+		return backup.Manifest{}, errors.Wrapf(err, "failed to upload backup manifest %s/%s", bucket, manifestKeyFor(objectKey))
+	}
+
+	return manifest, nil
+}
+
+// BackupVerificationResult is VerifyBackupTarget's outcome for one backup
+// object.
+type BackupVerificationResult struct {
+	ChunksChecked int
+	Mismatches    []int
+	SignatureOK   bool
+	Latency       time.Duration
+}
+
+// VerifyBackupTarget downloads bucket/objectKey's manifest, rebuilds its
+// Merkle tree, verifies its signature (if signed), then spot-checks a
+// random sample of chunks by range-downloading each and recomputing its
+// Merkle path to the manifest's root.
+func (suite *MultiCloudTestSuite) VerifyBackupTarget(ctx context.Context, bucket, objectKey string, sampleSize int) (*BackupVerificationResult, error) {
+	if suite.AWSTestSuite == nil {
+		return nil, errors.New("VerifyBackupTarget requires an initialized AWSTestSuite")
+	}
+	start := time.Now()
+
+	manifestOutput, err := suite.AWSTestSuite.S3().GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(manifestKeyFor(objectKey)),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch backup manifest %s/%s", bucket, manifestKeyFor(objectKey))
+	}
+	manifestData, err := io.ReadAll(manifestOutput.Body)
+	manifestOutput.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup manifest")
+	}
+
+	var manifest backup.Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse backup manifest")
+	}
+
+	tree, err := manifest.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BackupVerificationResult{SignatureOK: true}
+	if manifest.Signature != "" {
+		key, err := suite.resolveSigningKey(ctx, manifest.SigningKeyRef)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve backup signing key for verification")
+		}
+		ok, err := manifest.Verify(key.Public().(ed25519.PublicKey))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to verify backup manifest signature")
+		}
+		result.SignatureOK = ok
+	}
+
+	indices := backup.SampleIndices(rand.New(rand.NewSource(time.Now().UnixNano())), tree.ChunkCount(), sampleSize)
+	for _, index := range indices {
+		rangeHeader := fmt.Sprintf("bytes=%d-%d", index*backup.ChunkSize, (index+1)*backup.ChunkSize-1)
+		chunkOutput, err := suite.AWSTestSuite.S3().GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(objectKey),
+			Range:  aws.String(rangeHeader),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to download chunk %d of %s/%s", index, bucket, objectKey)
+		}
+		chunkData, err := io.ReadAll(chunkOutput.Body)
+		chunkOutput.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read chunk %d", index)
+		}
+
+		ok, err := backup.VerifyChunk(tree, index, chunkData)
+		if err != nil {
+			return nil, err
+		}
+		result.ChunksChecked++
+		if !ok {
+			result.Mismatches = append(result.Mismatches, index)
+		}
+	}
+
+	result.Latency = time.Since(start)
+	return result, nil
+}
+
+// testBackupVerification runs a synthetic round trip per AWS
+// BackupTarget: it uploads a small generated payload through
+// UploadBackupWithManifest, then immediately spot-checks it via
+// VerifyBackupTarget, recording per-target latency and any hash
+// mismatches into a MultiCloudTestResult. There's no hook into a real
+// production backup pipeline in this harness to verify post-hoc, so this
+// exercises the same Merkle-manifest code path end to end instead of
+// trusting a provider's own backup-integrity claims.
+func (suite *MultiCloudTestSuite) testBackupVerification() error {
+	suite.Logger.Info().Msg("Testing backup verification")
+
+	bucket, ok := suite.awsBackupBucket()
+	if !ok {
+		suite.Logger.Warn().Msg("no AWS bucket configured in StorageConfig, skipping backup verification")
+		return nil
+	}
+
+	for i, target := range suite.MultiCloudConfig.BackupConfig.Targets {
+		if target.Provider != "aws" {
+			suite.Logger.Warn().Str("provider", target.Provider).Msg("no Merkle backup verification implemented for this provider, skipping")
+			continue
+		}
+		if suite.AWSTestSuite == nil {
+			continue
+		}
+
+		objectKey := fmt.Sprintf("backup-verification/%s-%d/payload.bin", suite.TestID, i)
+
+		payload := make([]byte, 4*backup.ChunkSize+1)
+		if _, err := rand.New(rand.NewSource(time.Now().UnixNano())).Read(payload); err != nil {
+			return errors.Wrap(err, "failed to generate synthetic backup payload")
+		}
+
+		if _, err := suite.UploadBackupWithManifest(suite.Context, bucket, objectKey, payload); err != nil {
+			return errors.Wrapf(err, "failed to upload backup target %d", i)
+		}
+
+		result, err := suite.VerifyBackupTarget(suite.Context, bucket, objectKey, defaultBackupSpotCheckSampleSize)
+		if err != nil {
+			return errors.Wrapf(err, "failed to verify backup target %d", i)
+		}
+
+		testResult := MultiCloudTestResult{
+			TestName:  fmt.Sprintf("backup-verification-%d", i),
+			TestType:  "backup_verification",
+			StartTime: time.Now().Add(-result.Latency),
+			EndTime:   time.Now(),
+			Duration:  result.Latency,
+			Status:    "pass",
+			Providers: []string{target.Provider},
+			Regions:   []string{target.Region},
+			Metrics: map[string]interface{}{
+				"chunks_checked": result.ChunksChecked,
+				"mismatches":     result.Mismatches,
+				"signature_ok":   result.SignatureOK,
+				"latency_ms":     result.Latency.Milliseconds(),
+			},
+		}
+		if len(result.Mismatches) > 0 || !result.SignatureOK {
+			testResult.Status = "fail"
+		}
+		suite.TestResults = append(suite.TestResults, testResult)
+
+		if len(result.Mismatches) > 0 {
+			return errors.Errorf("backup target %d: %d chunk(s) failed Merkle verification: %v", i, len(result.Mismatches), result.Mismatches)
+		}
+		if !result.SignatureOK {
+			return errors.Errorf("backup target %d: manifest signature verification failed", i)
+		}
+	}
+
+	return nil
+}
+
+// testBackupRestore performs a full restore-and-diff for one target when
+// BackupTestConfig.RestoreTesting is set: it re-downloads the backup
+// object this run uploaded and asserts its bytes are byte-for-byte
+// identical to what UploadBackupWithManifest sent, which is what a
+// restore actually needs to guarantee (a Merkle spot check alone only
+// proves a sample of chunks, not the whole object).
+func (suite *MultiCloudTestSuite) testBackupRestore() error {
+	suite.Logger.Info().Msg("Testing backup restore")
+
+	if suite.AWSTestSuite == nil {
+		return nil
+	}
+	bucket, ok := suite.awsBackupBucket()
+	if !ok {
+		suite.Logger.Warn().Msg("no AWS bucket configured in StorageConfig, skipping backup restore test")
+		return nil
+	}
+
+	objectKey := fmt.Sprintf("backup-verification/%s-restore/payload.bin", suite.TestID)
+	payload := make([]byte, 2*backup.ChunkSize+17)
+	if _, err := rand.New(rand.NewSource(time.Now().UnixNano())).Read(payload); err != nil {
+		return errors.Wrap(err, "failed to generate synthetic restore payload")
+	}
+
+	if _, err := suite.UploadBackupWithManifest(suite.Context, bucket, objectKey, payload); err != nil {
+		return errors.Wrap(err, "failed to upload restore-test backup")
+	}
+
+	restoreOutput, err := suite.AWSTestSuite.S3().GetObject(suite.Context, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to download backup object for restore test")
+	}
+	defer restoreOutput.Body.Close()
+
+	restored, err := io.ReadAll(restoreOutput.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read restored backup object")
+	}
+
+	if !bytes.Equal(restored, payload) {
+		return errors.New("restored backup object does not match what was uploaded")
+	}
+
+	return nil
+}
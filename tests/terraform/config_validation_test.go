@@ -0,0 +1,160 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	en_locale "github.com/go-playground/locales/en"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/latestresolve"
+)
+
+// providerRequiredCredentialKeys lists the Credentials keys each enabled
+// CloudProvider.Name must supply, mirroring the keys
+// initializeCloudProviderSuites already reads off of provider.Credentials.
+var providerRequiredCredentialKeys = map[string][]string{
+	"aws":        {"access_key_id", "secret_access_key"},
+	"gcp":        {"project_id"},
+	"azure":      {"subscription_id", "tenant_id", "client_id", "client_secret"},
+	"kubernetes": {"kubeconfig_path"},
+}
+
+// validateCron reports whether a field is a valid standard 5-field cron
+// expression.
+func validateCron(fl validator.FieldLevel) bool {
+	_, err := cron.ParseStandard(fl.Field().String())
+	return err == nil
+}
+
+// validateGoDuration reports whether a field parses as a Go duration
+// string (e.g. "4h", "15m").
+func validateGoDuration(fl validator.FieldLevel) bool {
+	_, err := time.ParseDuration(fl.Field().String())
+	return err == nil
+}
+
+// validateVersionOrLatest reports whether a field is either a
+// latestresolve "latest"/"latest-N" sentinel or a plain pinned version
+// token (anything non-empty without whitespace -- this suite doesn't
+// enforce a specific version scheme across AWS Nitro/Azure SEV-SNP/GCP
+// SEV-TDX, whose version strings don't share a format).
+func validateVersionOrLatest(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if latestresolve.IsSentinel(value) {
+		return true
+	}
+	return value != "" && !strings.ContainsAny(value, " \t\n")
+}
+
+// validateProviderCredentials reports whether a CloudProvider.Credentials
+// map contains every key providerRequiredCredentialKeys lists for its
+// sibling Name field. Providers with no known key list are left
+// unvalidated here, since required_if=Enabled true already covers the
+// "no credentials at all" case.
+func validateProviderCredentials(fl validator.FieldLevel) bool {
+	creds, ok := fl.Field().Interface().(map[string]string)
+	if !ok {
+		return false
+	}
+
+	parent := fl.Parent()
+	if parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+	nameField := parent.FieldByName("Name")
+	if !nameField.IsValid() || nameField.Kind() != reflect.String {
+		return true
+	}
+
+	required, ok := providerRequiredCredentialKeys[nameField.String()]
+	if !ok {
+		return true
+	}
+	for _, key := range required {
+		if creds[key] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// newMultiCloudValidator builds the validator.Validate ValidateMultiCloudConfig
+// uses, with custom validators for cron expressions, Go duration strings,
+// and provider credential maps registered alongside their English
+// translations.
+func newMultiCloudValidator() (*validator.Validate, ut.Translator, error) {
+	v := validator.New()
+
+	english := en_locale.New()
+	translator := ut.New(english, english)
+	trans, _ := translator.GetTranslator("en")
+
+	if err := en_translations.RegisterDefaultTranslations(v, trans); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to register default validator translations")
+	}
+
+	customValidators := []struct {
+		tag         string
+		fn          validator.Func
+		translation string
+	}{
+		{"cron", validateCron, "{0} must be a valid cron expression"},
+		{"goduration", validateGoDuration, "{0} must be a valid Go duration string (e.g. \"4h\", \"15m\")"},
+		{"providercreds", validateProviderCredentials, "{0} is missing required credentials for this provider"},
+		{"versionorlatest", validateVersionOrLatest, "{0} must be a pinned version string or a \"latest\"/\"latest-N\" sentinel"},
+	}
+	for _, cv := range customValidators {
+		if err := v.RegisterValidation(cv.tag, cv.fn); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to register %q validator", cv.tag)
+		}
+		tag := cv.tag
+		message := cv.translation
+		err := v.RegisterTranslation(tag, trans,
+			func(ut ut.Translator) error { return ut.Add(tag, message, true) },
+			func(ut ut.Translator, fe validator.FieldError) string {
+				t, _ := ut.T(tag, fe.Field())
+				return t
+			})
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to register %q translation", cv.tag)
+		}
+	}
+
+	return v, trans, nil
+}
+
+// ValidateMultiCloudConfig validates config against the validate struct
+// tags declared on CloudProvider, SLOConfig, AlertRule, BackupConfig,
+// DisasterRecoveryConfig, BudgetConfig, CostPolicy and their neighbors,
+// returning a single error listing every violation's YAML-ish struct path
+// and a translated, human-readable reason. NewMultiCloudTestSuite calls
+// this after a successful LoadMultiCloudConfig unless
+// TestConfig.SkipValidation is set.
+func ValidateMultiCloudConfig(config MultiCloudConfig) error {
+	v, trans, err := newMultiCloudValidator()
+	if err != nil {
+		return errors.Wrap(err, "failed to build multi-cloud config validator")
+	}
+
+	if err := v.Struct(config); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return errors.Wrap(err, "failed to validate multi-cloud config")
+		}
+
+		violations := make([]string, 0, len(validationErrors))
+		for _, fieldError := range validationErrors {
+			violations = append(violations, fmt.Sprintf("%s: %s", fieldError.Namespace(), fieldError.Translate(trans)))
+		}
+		return errors.Errorf("multi-cloud config is invalid:\n%s", strings.Join(violations, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package cost
+
+import "sort"
+
+// DefaultAnomalyK is the residual/MAD multiplier DetectAnomalies uses
+// when a caller doesn't override it.
+const DefaultAnomalyK = 3.5
+
+// Anomaly flags one day whose decomposed Residual exceeds k*MAD of the
+// series' residuals.
+type Anomaly struct {
+	Date      string  `json:"date"`
+	Value     float64 `json:"value"`
+	Trend     float64 `json:"trend"`
+	Residual  float64 `json:"residual"`
+	Threshold float64 `json:"threshold"`
+}
+
+// DetectAnomalies flags every point in decomp whose |Residual| exceeds
+// k*MAD(Residual). k<=0 falls back to DefaultAnomalyK. When MAD(Residual)
+// is 0, no point is flagged.
+func DetectAnomalies(decomp Decomposition, k float64) []Anomaly {
+	if k <= 0 {
+		k = DefaultAnomalyK
+	}
+
+	mad := medianAbsoluteDeviation(decomp.Residual)
+	threshold := k * mad
+
+	var anomalies []Anomaly
+	for i, point := range decomp.Series {
+		residual := decomp.Residual[i]
+		if mad > 0 && absFloat(residual) > threshold {
+			anomalies = append(anomalies, Anomaly{
+				Date:      point.Date.Format("2006-01-02"),
+				Value:     point.Value,
+				Trend:     decomp.Trend[i],
+				Residual:  residual,
+				Threshold: threshold,
+			})
+		}
+	}
+	return anomalies
+}
+
+// medianAbsoluteDeviation returns the median of |x - median(values)| over
+// values, 0 for an empty slice.
+func medianAbsoluteDeviation(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	med := median(values)
+
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = absFloat(v - med)
+	}
+	return median(deviations)
+}
+
+// median returns values' median, copying and sorting rather than
+// mutating the caller's slice.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func absFloat(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
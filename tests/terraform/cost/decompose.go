@@ -0,0 +1,138 @@
+package cost
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBandwidthDays is Decompose's default LOESS bandwidth: how many
+// days on either side of a point its local regression draws neighbors
+// from.
+const DefaultBandwidthDays = 14
+
+// minSeasonalDays is the shortest series Decompose will fit a
+// day-of-week Seasonal component to; shorter than two weeks, a weekday's
+// average residual is mostly noise, so Seasonal is left all zero instead.
+const minSeasonalDays = 14
+
+// Decomposition is a Series split into additive components: Series[i] ==
+// Trend[i] + Seasonal[i] + Residual[i] for every i.
+type Decomposition struct {
+	Series   Series
+	Trend    []float64
+	Seasonal []float64
+	Residual []float64
+}
+
+// Decompose fits a LOESS trend to series (bandwidth in days) and, for
+// series spanning at least minSeasonalDays, a day-of-week seasonal
+// component from the detrended remainder, leaving Residual as whatever's
+// left.
+func Decompose(series Series, bandwidthDays int) (Decomposition, error) {
+	sorted := series.Sorted()
+	if err := sorted.validate(); err != nil {
+		return Decomposition{}, err
+	}
+	if len(sorted) < 2 {
+		return Decomposition{}, errors.Errorf("need at least 2 data points to decompose a series, got %d", len(sorted))
+	}
+	if bandwidthDays <= 0 {
+		bandwidthDays = DefaultBandwidthDays
+	}
+
+	values := sorted.values()
+	trend := loess(values, bandwidthDays)
+
+	detrended := make([]float64, len(values))
+	for i := range values {
+		detrended[i] = values[i] - trend[i]
+	}
+
+	seasonal := make([]float64, len(values))
+	if len(values) >= minSeasonalDays {
+		weekdayMeans := weekdaySeasonal(sorted, detrended)
+		for i, point := range sorted {
+			seasonal[i] = weekdayMeans[int(point.Date.Weekday())]
+		}
+	}
+
+	residual := make([]float64, len(values))
+	for i := range values {
+		residual[i] = values[i] - trend[i] - seasonal[i]
+	}
+
+	return Decomposition{Series: sorted, Trend: trend, Seasonal: seasonal, Residual: residual}, nil
+}
+
+// weekdaySeasonal averages detrended's value per time.Weekday, giving
+// each of the 7 days its mean deviation from trend -- a simplified
+// stand-in for STL's full seasonal-smoothing pass, adequate for a weekly
+// cycle with no finer-grained series to resolve.
+func weekdaySeasonal(series Series, detrended []float64) [7]float64 {
+	var sums [7]float64
+	var counts [7]int
+	for i, point := range series {
+		dow := int(point.Date.Weekday())
+		sums[dow] += detrended[i]
+		counts[dow]++
+	}
+
+	var means [7]float64
+	for dow := range means {
+		if counts[dow] > 0 {
+			means[dow] = sums[dow] / float64(counts[dow])
+		}
+	}
+	return means
+}
+
+// loess smooths values with a locally weighted linear regression: for
+// each index, points within bandwidth days are weighted by a tricube
+// kernel on their distance and fit with a degree-1 weighted least squares
+// line, evaluated at that index.
+func loess(values []float64, bandwidth int) []float64 {
+	n := len(values)
+	smoothed := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		var sumW, sumWX, sumWY, sumWXX, sumWXY float64
+		for j := 0; j < n; j++ {
+			d := math.Abs(float64(i - j))
+			if d > float64(bandwidth) {
+				continue
+			}
+			w := tricube(d / float64(bandwidth+1))
+			x := float64(j)
+			y := values[j]
+			sumW += w
+			sumWX += w * x
+			sumWY += w * y
+			sumWXX += w * x * x
+			sumWXY += w * x * y
+		}
+
+		x := float64(i)
+		denom := sumW*sumWXX - sumWX*sumWX
+		if sumW == 0 || math.Abs(denom) < 1e-12 {
+			smoothed[i] = values[i]
+			continue
+		}
+		slope := (sumW*sumWXY - sumWX*sumWY) / denom
+		intercept := (sumWY - slope*sumWX) / sumW
+		smoothed[i] = intercept + slope*x
+	}
+
+	return smoothed
+}
+
+// tricube is the kernel LOESS weights neighbors by: (1-|u|^3)^3 for
+// |u|<1, 0 otherwise.
+func tricube(u float64) float64 {
+	u = math.Abs(u)
+	if u >= 1 {
+		return 0
+	}
+	v := 1 - u*u*u
+	return v * v * v
+}
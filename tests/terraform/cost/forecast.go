@@ -0,0 +1,66 @@
+package cost
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ForecastPoint is one future day's projected spend: Trend extrapolated
+// forward plus that day-of-week's Seasonal component.
+type ForecastPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Forecast projects decomp's trend forward by horizonDays using the
+// average daily slope over its trailing trendSlopeWindow points (capped
+// to the series length), adding back each projected day's Seasonal
+// component. It returns an error if decomp has no data.
+func Forecast(decomp Decomposition, horizonDays, trendSlopeWindow int) ([]ForecastPoint, error) {
+	n := len(decomp.Trend)
+	if n == 0 {
+		return nil, errors.New("decomposition has no data points to forecast from")
+	}
+	if trendSlopeWindow <= 0 || trendSlopeWindow >= n {
+		trendSlopeWindow = n - 1
+	}
+	if trendSlopeWindow == 0 {
+		trendSlopeWindow = 1
+	}
+
+	slope := (decomp.Trend[n-1] - decomp.Trend[n-1-trendSlopeWindow]) / float64(trendSlopeWindow)
+	lastDate := decomp.Series[n-1].Date
+	lastTrend := decomp.Trend[n-1]
+
+	var seasonalByWeekday [7]float64
+	var haveSeasonal [7]bool
+	for i, point := range decomp.Series {
+		dow := int(point.Date.Weekday())
+		if !haveSeasonal[dow] {
+			seasonalByWeekday[dow] = decomp.Seasonal[i]
+			haveSeasonal[dow] = true
+		}
+	}
+
+	forecast := make([]ForecastPoint, horizonDays)
+	for d := 1; d <= horizonDays; d++ {
+		date := lastDate.AddDate(0, 0, d)
+		trend := lastTrend + slope*float64(d)
+		forecast[d-1] = ForecastPoint{
+			Date:  date,
+			Value: trend + seasonalByWeekday[int(date.Weekday())],
+		}
+	}
+	return forecast, nil
+}
+
+// Total sums a Forecast's projected Value across every point, the
+// quantity a remaining-budget-period check compares against a threshold.
+func Total(forecast []ForecastPoint) float64 {
+	var total float64
+	for _, point := range forecast {
+		total += point.Value
+	}
+	return total
+}
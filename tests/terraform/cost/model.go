@@ -0,0 +1,70 @@
+package cost
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxHistoryDays bounds how much daily history a Model keeps across
+// runs; long enough for Decompose's LOESS trend to settle and for a
+// weekly seasonal component to average out noise, short enough that a
+// permanent spend step-change isn't dragged out by months of stale
+// pre-change days.
+const MaxHistoryDays = 120
+
+// Model is one dimension's persisted cost history, serialized to disk so
+// a later run's Update only has to fetch and merge the days since
+// UpdatedAt instead of re-pulling a provider's full billing history
+// every time.
+type Model struct {
+	Dimension string    `json:"dimension"`
+	History   Series    `json:"history"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewModel returns an empty Model for dimension.
+func NewModel(dimension string) Model {
+	return Model{Dimension: dimension}
+}
+
+// Update merges newPoints into m's History, trims it to MaxHistoryDays,
+// and returns the updated Model. It does not mutate m.
+func (m Model) Update(newPoints Series, now time.Time) Model {
+	m.History = m.History.Merge(newPoints).TrimToRecent(MaxHistoryDays)
+	m.UpdatedAt = now
+	return m
+}
+
+// LoadModel reads a Model previously written by Save from path. A
+// missing file is not an error: it returns a fresh NewModel(dimension)
+// so a dimension's first run doesn't need special-casing.
+func LoadModel(path, dimension string) (Model, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewModel(dimension), nil
+	}
+	if err != nil {
+		return Model{}, errors.Wrapf(err, "failed to read cost model %s", path)
+	}
+
+	var model Model
+	if err := json.Unmarshal(data, &model); err != nil {
+		return Model{}, errors.Wrapf(err, "failed to parse cost model %s", path)
+	}
+	return model, nil
+}
+
+// Save writes m as indented JSON to path.
+func (m Model) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost model")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write cost model %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package cost
+
+import "fmt"
+
+// UtilizationSample is one resource's allocated capacity against its
+// observed utilization over some lookback window -- the input Recommend
+// derives a right-sizing Recommendation from. Units are whatever capacity
+// dimension the caller collected (CPU percent, vCPU count, ...); Recommend
+// only ever compares them as ratios, so callers may mix dimensions across
+// samples as long as each sample's own units are self-consistent.
+type UtilizationSample struct {
+	Resource       string  `json:"resource"`
+	Provider       string  `json:"provider"`
+	AllocatedUnits float64 `json:"allocated_units"`
+	AverageUnits   float64 `json:"average_units"`
+	P95Units       float64 `json:"p95_units"`
+}
+
+// Action a Recommendation proposes for a resource.
+type Action string
+
+const (
+	ActionNone     Action = "none"
+	ActionDownsize Action = "downsize"
+	ActionUpsize   Action = "upsize"
+)
+
+// Recommendation is Recommend's right-sizing verdict for one
+// UtilizationSample.
+type Recommendation struct {
+	Resource         string  `json:"resource"`
+	Provider         string  `json:"provider"`
+	Action           Action  `json:"action"`
+	Reason           string  `json:"reason"`
+	AllocatedUnits   float64 `json:"allocated_units"`
+	RecommendedUnits float64 `json:"recommended_units"`
+}
+
+// DefaultLowWatermark and DefaultHighWatermark bound the P95/Allocated
+// utilization ratio Recommend treats as appropriately sized. DefaultHeadroom
+// is how far above a sample's observed P95 a resized allocation is set, so
+// a recommendation doesn't just chase the exact peak already seen.
+const (
+	DefaultLowWatermark  = 0.40
+	DefaultHighWatermark = 0.90
+	DefaultHeadroom      = 1.25
+)
+
+// Recommend derives a right-sizing Recommendation for each sample by
+// comparing its P95Units against AllocatedUnits. A sample whose P95
+// utilization ratio falls within [lowWatermark, highWatermark] is left
+// alone; below it, AllocatedUnits is recommended to shrink to
+// P95Units*DefaultHeadroom; above it, AllocatedUnits is recommended to
+// grow by the same factor. lowWatermark/highWatermark <= 0 fall back to
+// the package defaults.
+func Recommend(samples []UtilizationSample, lowWatermark, highWatermark float64) []Recommendation {
+	if lowWatermark <= 0 {
+		lowWatermark = DefaultLowWatermark
+	}
+	if highWatermark <= 0 {
+		highWatermark = DefaultHighWatermark
+	}
+
+	recommendations := make([]Recommendation, 0, len(samples))
+	for _, sample := range samples {
+		recommendation := Recommendation{
+			Resource:       sample.Resource,
+			Provider:       sample.Provider,
+			Action:         ActionNone,
+			AllocatedUnits: sample.AllocatedUnits,
+		}
+
+		if sample.AllocatedUnits <= 0 {
+			recommendation.Reason = "no allocated capacity recorded"
+			recommendations = append(recommendations, recommendation)
+			continue
+		}
+
+		ratio := sample.P95Units / sample.AllocatedUnits
+		switch {
+		case ratio < lowWatermark:
+			recommendation.Action = ActionDownsize
+			recommendation.RecommendedUnits = sample.P95Units * DefaultHeadroom
+			recommendation.Reason = fmt.Sprintf("p95 utilization %.0f%% of allocated is below the %.0f%% watermark", ratio*100, lowWatermark*100)
+		case ratio > highWatermark:
+			recommendation.Action = ActionUpsize
+			recommendation.RecommendedUnits = sample.P95Units * DefaultHeadroom
+			recommendation.Reason = fmt.Sprintf("p95 utilization %.0f%% of allocated exceeds the %.0f%% watermark", ratio*100, highWatermark*100)
+		default:
+			recommendation.RecommendedUnits = sample.AllocatedUnits
+			recommendation.Reason = fmt.Sprintf("p95 utilization %.0f%% of allocated is within watermarks", ratio*100)
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations
+}
@@ -0,0 +1,96 @@
+// Package cost implements anomaly detection and spend forecasting for
+// CostMonitoring: decompose a dimension's daily cost Series into a
+// LOESS-smoothed Trend, a day-of-week Seasonal component, and a Residual,
+// flag days whose Residual exceeds k*MAD(Residual) as anomalies, and
+// project Trend+Seasonal forward to forecast spend against a budget's
+// remaining period. Every cost collector in this suite (Cost Explorer,
+// Azure Consumption, the BigQuery billing export) exposes at best daily
+// granularity, so unlike a general STL decomposition this only models
+// weekly seasonality -- there's no hourly series to derive a daily
+// seasonal component from. The provider-specific code that pulls a
+// Series out of a billing API and persists a Model between runs lives in
+// package test, not here.
+package cost
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DailyCost is one day's total spend for whatever dimension a Series was
+// built for.
+type DailyCost struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Series is a dimension's daily cost history, sorted ascending by Date.
+type Series []DailyCost
+
+// Sorted returns a copy of s sorted ascending by Date.
+func (s Series) Sorted() Series {
+	sorted := make(Series, len(s))
+	copy(sorted, s)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+	return sorted
+}
+
+// Merge combines s with other, keeping other's value whenever both
+// contain the same calendar day (other is assumed more recently fetched),
+// and returns the result sorted ascending by Date.
+func (s Series) Merge(other Series) Series {
+	byDate := make(map[string]DailyCost, len(s)+len(other))
+	for _, point := range s {
+		byDate[point.Date.Format("2006-01-02")] = point
+	}
+	for _, point := range other {
+		byDate[point.Date.Format("2006-01-02")] = point
+	}
+
+	merged := make(Series, 0, len(byDate))
+	for _, point := range byDate {
+		merged = append(merged, point)
+	}
+	return merged.Sorted()
+}
+
+// TrimToRecent drops every point older than maxDays before the series'
+// last date, bounding how much history a Model accumulates across runs.
+func (s Series) TrimToRecent(maxDays int) Series {
+	sorted := s.Sorted()
+	if len(sorted) == 0 {
+		return sorted
+	}
+	cutoff := sorted[len(sorted)-1].Date.AddDate(0, 0, -maxDays)
+
+	trimmed := make(Series, 0, len(sorted))
+	for _, point := range sorted {
+		if !point.Date.Before(cutoff) {
+			trimmed = append(trimmed, point)
+		}
+	}
+	return trimmed
+}
+
+// values extracts s's Value column, for callers working in plain
+// float64 slices (LOESS, MAD, ...).
+func (s Series) values() []float64 {
+	values := make([]float64, len(s))
+	for i, point := range s {
+		values[i] = point.Value
+	}
+	return values
+}
+
+// validate reports an error if s isn't sorted strictly ascending by Date,
+// which every function in this package assumes.
+func (s Series) validate() error {
+	for i := 1; i < len(s); i++ {
+		if !s[i].Date.After(s[i-1].Date) {
+			return errors.Errorf("series is not sorted strictly ascending by date at index %d", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/pricing"
+)
+
+// pricingSource lets an operator override the bundled AWS/GCP/Azure price
+// sheets TestCostEstimation matches planned resources against, without
+// editing test-config.yaml:
+// go test ./tests/terraform/... -pricing-source=file://./pricing.yaml
+var pricingSource = flag.String("pricing-source", "",
+	"file:// or https:// URI for a pricing.Catalog YAML/JSON price sheet, overriding the bundled catalog")
+
+// previousCostEstimatePath is where TestCostEstimation records testName's
+// CostEstimate so the next run's CostDiff has something to compare the new
+// plan's projected spend against.
+func previousCostEstimatePath(terraformDir, testName string) string {
+	return filepath.Join(terraformDir, ".test-data", "cost-estimate-"+testName+".json")
+}
+
+// loadPreviousCostEstimate reads testName's previously recorded
+// pricing.CostEstimate, returning ok=false (not an error) when this is the
+// first run and no estimate has been recorded yet.
+func loadPreviousCostEstimate(terraformDir, testName string) (pricing.CostEstimate, bool) {
+	data, err := os.ReadFile(previousCostEstimatePath(terraformDir, testName))
+	if err != nil {
+		return pricing.CostEstimate{}, false
+	}
+	var estimate pricing.CostEstimate
+	if err := json.Unmarshal(data, &estimate); err != nil {
+		return pricing.CostEstimate{}, false
+	}
+	return estimate, true
+}
+
+// saveCostEstimate persists estimate for testName so a later run's
+// loadPreviousCostEstimate can diff against it.
+func saveCostEstimate(terraformDir, testName string, estimate pricing.CostEstimate) error {
+	path := previousCostEstimatePath(terraformDir, testName)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %s", path)
+	}
+	data, err := json.MarshalIndent(estimate, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cost estimate")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write cost estimate to %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/cost"
+)
+
+// syntheticWorkloadFixturePath is the deterministic allocated-vs-actual
+// utilization dataset testCostOptimizationRecommendations verifies
+// cost.Recommend against on every run, online or offline: this harness has
+// no mechanism of its own for provisioning a live compute workload purely
+// to exercise right-sizing, so it plays the role of "a synthetic workload
+// the suite provisions" as a fixed, version-controlled fixture instead of
+// live infrastructure, which is also what lets this check replay
+// identically in CI with no cloud credentials at all.
+const syntheticWorkloadFixturePath = "testdata/synthetic_workload.json"
+
+// expectedSyntheticActions pins each syntheticWorkloadFixturePath resource
+// to the right-sizing action cost.Recommend must produce for it, so a
+// change to Recommend's watermarks that silently stops catching an over-
+// or under-provisioned resource fails loudly here instead of only showing
+// up against live data.
+var expectedSyntheticActions = map[string]cost.Action{
+	"over-provisioned-web":    cost.ActionDownsize,
+	"right-sized-api":         cost.ActionNone,
+	"under-provisioned-batch": cost.ActionUpsize,
+}
+
+// loadSyntheticWorkload reads path as a []cost.UtilizationSample fixture.
+func loadSyntheticWorkload(path string) ([]cost.UtilizationSample, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read synthetic workload fixture %s", path)
+	}
+	var samples []cost.UtilizationSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse synthetic workload fixture %s", path)
+	}
+	return samples, nil
+}
+
+// verifySyntheticRightSizing asserts recommendations reproduces
+// expectedSyntheticActions exactly for every resource the fixture names.
+func verifySyntheticRightSizing(recommendations []cost.Recommendation) error {
+	byResource := make(map[string]cost.Recommendation, len(recommendations))
+	for _, recommendation := range recommendations {
+		byResource[recommendation.Resource] = recommendation
+	}
+
+	var mismatches []string
+	for resource, expected := range expectedSyntheticActions {
+		actual, ok := byResource[resource]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("%s: missing from recommendations", resource))
+			continue
+		}
+		if actual.Action != expected {
+			mismatches = append(mismatches, fmt.Sprintf("%s: got action %q, want %q", resource, actual.Action, expected))
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return errors.Errorf("synthetic workload right-sizing check failed:\n%s", strings.Join(mismatches, "\n"))
+	}
+	return nil
+}
+
+// collectEC2UtilizationSamples fetches each instanceID's CPUUtilization
+// average and p95 over the last days from CloudWatch, reporting
+// utilization as a percentage of its 100-unit allocated capacity so it
+// compares directly against cost.Recommend's watermarks regardless of
+// instance size.
+func (suite *MultiCloudTestSuite) collectEC2UtilizationSamples(ctx context.Context, instanceIDs []string, days int) ([]cost.UtilizationSample, error) {
+	if suite.AWSTestSuite == nil || len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	end := time.Now()
+	start := end.AddDate(0, 0, -days)
+	period := int32(days * 86400)
+	samples := make([]cost.UtilizationSample, 0, len(instanceIDs))
+
+	for _, instanceID := range instanceIDs {
+		output, err := suite.AWSTestSuite.CloudWatch().GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+			StartTime: aws.Time(start),
+			EndTime:   aws.Time(end),
+			MetricDataQueries: []cwtypes.MetricDataQuery{
+				{
+					Id: aws.String("avg"),
+					MetricStat: &cwtypes.MetricStat{
+						Metric: &cwtypes.Metric{
+							Namespace:  aws.String("AWS/EC2"),
+							MetricName: aws.String("CPUUtilization"),
+							Dimensions: []cwtypes.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(instanceID)}},
+						},
+						Period: aws.Int32(period),
+						Stat:   aws.String("Average"),
+					},
+				},
+				{
+					Id: aws.String("p95"),
+					MetricStat: &cwtypes.MetricStat{
+						Metric: &cwtypes.Metric{
+							Namespace:  aws.String("AWS/EC2"),
+							MetricName: aws.String("CPUUtilization"),
+							Dimensions: []cwtypes.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(instanceID)}},
+						},
+						Period: aws.Int32(period),
+						Stat:   aws.String("p95"),
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch CloudWatch utilization for instance %s", instanceID)
+		}
+
+		sample := cost.UtilizationSample{Resource: instanceID, Provider: "aws", AllocatedUnits: 100}
+		for _, result := range output.MetricDataResults {
+			if len(result.Values) == 0 {
+				continue
+			}
+			switch aws.ToString(result.Id) {
+			case "avg":
+				sample.AverageUnits = result.Values[0]
+			case "p95":
+				sample.P95Units = result.Values[0]
+			}
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+// collectTaggedEC2UtilizationSamples discovers every EC2 instance tagged
+// with CostConfig.Allocation.Tags and collects its utilization via
+// collectEC2UtilizationSamples, so right-sizing recommendations extend to
+// whatever this run's own resources actually look like, not just the
+// synthetic fixture. It returns (nil, nil) when AWS isn't configured or no
+// allocation tags are set to search by.
+func (suite *MultiCloudTestSuite) collectTaggedEC2UtilizationSamples(ctx context.Context, days int) ([]cost.UtilizationSample, error) {
+	if suite.AWSTestSuite == nil {
+		return nil, nil
+	}
+	tags := suite.MultiCloudConfig.CostConfig.Allocation.Tags
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	filters := make([]ec2types.Filter, 0, len(tags))
+	for key, value := range tags {
+		filters = append(filters, ec2types.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: []string{value},
+		})
+	}
+
+	describeOutput, err := suite.AWSTestSuite.EC2().DescribeInstances(ctx, &ec2.DescribeInstancesInput{Filters: filters})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe tagged EC2 instances")
+	}
+
+	var instanceIDs []string
+	for _, reservation := range describeOutput.Reservations {
+		for _, instance := range reservation.Instances {
+			instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return nil, nil
+	}
+
+	return suite.collectEC2UtilizationSamples(ctx, instanceIDs, days)
+}
+
+// testCostOptimizationRecommendations correlates allocated vs. actual
+// utilization into right-sizing recommendations via cost.Recommend,
+// verifies them against the deterministic synthetic workload fixture, and
+// layers on any of this run's own tagged EC2 instances found live.
+func (suite *MultiCloudTestSuite) testCostOptimizationRecommendations() error {
+	suite.Logger.Info().Msg("Testing cost optimization recommendations")
+
+	if !suite.MultiCloudConfig.CostConfig.Optimization.Recommendations {
+		return nil
+	}
+
+	samples, err := loadSyntheticWorkload(syntheticWorkloadFixturePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to load synthetic workload fixture")
+	}
+
+	liveSamples, err := suite.collectTaggedEC2UtilizationSamples(suite.Context, defaultCostAnomalyLookbackDays)
+	if err != nil {
+		suite.Logger.Warn().Err(err).Msg("failed to collect live EC2 utilization, continuing with synthetic workload only")
+	} else {
+		samples = append(samples, liveSamples...)
+	}
+
+	recommendations := cost.Recommend(samples, 0, 0)
+
+	if err := verifySyntheticRightSizing(recommendations); err != nil {
+		return err
+	}
+
+	for _, recommendation := range recommendations {
+		suite.Logger.Info().
+			Str("resource", recommendation.Resource).
+			Str("provider", recommendation.Provider).
+			Str("action", string(recommendation.Action)).
+			Str("reason", recommendation.Reason).
+			Float64("allocated_units", recommendation.AllocatedUnits).
+			Float64("recommended_units", recommendation.RecommendedUnits).
+			Msg("right-sizing recommendation")
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/backup"
+)
+
+// blobDatasetSeederCount and blobDatasetSeederSize bound the synthetic
+// object-storage dataset testCrossProviderRestoreVerification seeds per
+// run, balancing a meaningful sample against re-uploading a large payload
+// on every run.
+const (
+	blobDatasetSeederCount = 10
+	blobDatasetSeederSize  = 4096
+	pgRowSeederCount       = 25
+)
+
+// blobDatasetSeeder seeds a set of random objects into bucket/prefix via
+// the suite's AWS S3 client, implementing backup.DatasetSeeder.
+type blobDatasetSeeder struct {
+	suite  *AWSTestSuite
+	bucket string
+	prefix string
+	count  int
+	size   int
+}
+
+func (s *blobDatasetSeeder) Seed(ctx context.Context) ([]backup.Record, error) {
+	records := make([]backup.Record, 0, s.count)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < s.count; i++ {
+		payload := make([]byte, s.size)
+		if _, err := rng.Read(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to generate synthetic blob payload")
+		}
+		key := fmt.Sprintf("%s/%d.bin", s.prefix, i)
+
+		if _, err := s.suite.S3().PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader(payload),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "failed to seed blob %s/%s", s.bucket, key)
+		}
+
+		records = append(records, backup.Record{
+			Key:      key,
+			Hash:     sha256.Sum256(payload),
+			SeededAt: time.Now(),
+		})
+	}
+	return records, nil
+}
+
+// blobComparator re-downloads each seeded object from bucket and compares
+// its content hash against the Record seeded by blobDatasetSeeder,
+// implementing backup.Comparator.
+type blobComparator struct {
+	suite  *AWSTestSuite
+	bucket string
+}
+
+func (c *blobComparator) Verify(ctx context.Context, seeded []backup.Record) ([]backup.Mismatch, error) {
+	var mismatches []backup.Mismatch
+	for _, record := range seeded {
+		output, err := c.suite.S3().GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(c.bucket),
+			Key:    aws.String(record.Key),
+		})
+		if err != nil {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: fmt.Sprintf("restored object missing or unreadable: %v", err)})
+			continue
+		}
+		data, err := io.ReadAll(output.Body)
+		output.Body.Close()
+		if err != nil {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: fmt.Sprintf("failed to read restored object: %v", err)})
+			continue
+		}
+		if sha256.Sum256(data) != record.Hash {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: "content hash mismatch"})
+		}
+	}
+	return mismatches, nil
+}
+
+// pgRowSeeder inserts a set of rows with a random payload and its SHA-256
+// into a scratch Postgres table, implementing backup.DatasetSeeder. The
+// table is created if it doesn't already exist and is left behind for
+// pgRowComparator to read back from (possibly on a different DSN, once a
+// restore or cross-region replica promotion has brought it up to date).
+type pgRowSeeder struct {
+	dsn   string
+	table string
+	count int
+}
+
+func (s *pgRowSeeder) Seed(ctx context.Context) ([]backup.Record, error) {
+	db, err := sql.Open("pgx", s.dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open dataset DSN")
+	}
+	defer db.Close()
+
+	quoted := fmt.Sprintf("%q", s.table)
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (id text PRIMARY KEY, payload bytea NOT NULL, seeded_at timestamptz NOT NULL)`, quoted)); err != nil {
+		return nil, errors.Wrapf(err, "failed to create dataset table %s", s.table)
+	}
+
+	records := make([]backup.Record, 0, s.count)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < s.count; i++ {
+		payload := make([]byte, 256)
+		if _, err := rng.Read(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to generate synthetic row payload")
+		}
+		id := fmt.Sprintf("row-%d", i)
+		seededAt := time.Now()
+
+		if _, err := db.ExecContext(ctx, fmt.Sprintf(
+			`INSERT INTO %s (id, payload, seeded_at) VALUES ($1, $2, $3)
+			 ON CONFLICT (id) DO UPDATE SET payload = EXCLUDED.payload, seeded_at = EXCLUDED.seeded_at`, quoted),
+			id, payload, seededAt); err != nil {
+			return nil, errors.Wrapf(err, "failed to seed row %s", id)
+		}
+
+		records = append(records, backup.Record{Key: id, Hash: sha256.Sum256(payload), SeededAt: seededAt})
+	}
+	return records, nil
+}
+
+// pgRowComparator reads each seeded row back from a (possibly restored or
+// replicated) Postgres instance and compares its payload hash against the
+// Record pgRowSeeder produced, implementing backup.Comparator.
+type pgRowComparator struct {
+	dsn   string
+	table string
+}
+
+func (c *pgRowComparator) Verify(ctx context.Context, seeded []backup.Record) ([]backup.Mismatch, error) {
+	db, err := sql.Open("pgx", c.dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open restore DSN")
+	}
+	defer db.Close()
+
+	quoted := fmt.Sprintf("%q", c.table)
+	var mismatches []backup.Mismatch
+	for _, record := range seeded {
+		var payload []byte
+		err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT payload FROM %s WHERE id = $1`, quoted), record.Key).Scan(&payload)
+		switch {
+		case err == sql.ErrNoRows:
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: "row missing from restored target"})
+		case err != nil:
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: fmt.Sprintf("failed to read restored row: %v", err)})
+		case sha256.Sum256(payload) != record.Hash:
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: "payload hash mismatch"})
+		}
+	}
+	return mismatches, nil
+}
+
+// verifyBackupRestorePair runs one seeder/comparator pair end to end,
+// timing the seed (standing in for the backup window) and the verify (the
+// restore window) separately, and deriving RPO as the age of the newest
+// Record the Comparator reports lost.
+func (suite *MultiCloudTestSuite) verifyBackupRestorePair(provider string, seeder backup.DatasetSeeder, comparator backup.Comparator) backup.ProviderOutcome {
+	return runBackupRestoreCheck(suite.Context, provider, seeder, comparator)
+}
+
+// runBackupRestoreCheck is the suite-independent core of
+// verifyBackupRestorePair, also used directly by the single-endpoint
+// TestDatabaseBackup/TestStorageBackup methods on each cloud-specific
+// suite, which don't have a MultiCloudTestSuite to hang off of.
+func runBackupRestoreCheck(ctx context.Context, provider string, seeder backup.DatasetSeeder, comparator backup.Comparator) backup.ProviderOutcome {
+	outcome := backup.ProviderOutcome{Provider: provider}
+
+	backupStart := time.Now()
+	records, err := seeder.Seed(ctx)
+	outcome.BackupWindow = time.Since(backupStart)
+	if err != nil {
+		outcome.Err = errors.Wrapf(err, "failed to seed dataset for %s", provider)
+		return outcome
+	}
+
+	restoreStart := time.Now()
+	mismatches, err := comparator.Verify(ctx, records)
+	outcome.RestoreWindow = time.Since(restoreStart)
+	if err != nil {
+		outcome.Err = errors.Wrapf(err, "failed to verify restore for %s", provider)
+		return outcome
+	}
+
+	outcome.RecordsVerified = len(records)
+	outcome.Mismatches = mismatches
+	if len(mismatches) > 0 {
+		lost := make(map[string]bool, len(mismatches))
+		for _, mismatch := range mismatches {
+			lost[mismatch.Key] = true
+		}
+		var newestLost time.Time
+		for _, record := range records {
+			if lost[record.Key] && record.SeededAt.After(newestLost) {
+				newestLost = record.SeededAt
+			}
+		}
+		if !newestLost.IsZero() {
+			outcome.RPO = time.Since(newestLost)
+		}
+	}
+	return outcome
+}
+
+// evaluateBackupOutcome logs outcome's result and turns it into an error
+// if the seed/verify round trip failed outright, any record came back
+// missing or corrupt, or (when maxRPO is positive) the derived RPO exceeds
+// maxRPO. Used by each cloud-specific suite's single-endpoint
+// TestDatabaseBackup/TestStorageBackup.
+func evaluateBackupOutcome(logger zerolog.Logger, outcome backup.ProviderOutcome, maxRPO time.Duration) error {
+	if outcome.Err != nil {
+		return errors.Wrapf(outcome.Err, "%s backup verification failed", outcome.Provider)
+	}
+	logger.Info().
+		Str("provider", outcome.Provider).
+		Int("records_verified", outcome.RecordsVerified).
+		Int("mismatches", len(outcome.Mismatches)).
+		Dur("backup_window", outcome.BackupWindow).
+		Dur("restore_window", outcome.RestoreWindow).
+		Dur("rpo", outcome.RPO).
+		Msg("backup verification round trip completed")
+	for _, m := range outcome.Mismatches {
+		logger.Error().Str("provider", outcome.Provider).Str("key", m.Key).Str("reason", m.Reason).Msg("backup restore mismatch")
+	}
+	if len(outcome.Mismatches) > 0 {
+		return errors.Errorf("%s backup verification found %d mismatched record(s)", outcome.Provider, len(outcome.Mismatches))
+	}
+	if maxRPO > 0 && outcome.RPO > maxRPO {
+		return errors.Errorf("%s RPO %s exceeds budget of %s", outcome.Provider, outcome.RPO, maxRPO)
+	}
+	return nil
+}
+
+// testCrossProviderRestoreVerification seeds known datasets (object-storage
+// blobs, and Postgres rows when BackupTestConfig.DatasetDSN is set),
+// measures how long seeding and restore verification take, derives the
+// actual RPO from whatever didn't come back, and fails the run against
+// BackupTestConfig's SLOs. It reports every provider pair's outcome as
+// JUnit and Markdown when BackupTestConfig.Reporting and ReportDir are
+// set.
+func (suite *MultiCloudTestSuite) testCrossProviderRestoreVerification() error {
+	suite.Logger.Info().Msg("Testing cross-provider restore verification")
+
+	config := suite.MultiCloudConfig.BackupConfig
+	testing := config.Testing
+
+	var outcomes []backup.ProviderOutcome
+
+	if bucket, ok := suite.awsBackupBucket(); ok && suite.AWSTestSuite != nil {
+		prefix := fmt.Sprintf("restore-verification/%s/blobs", suite.TestID)
+		outcomes = append(outcomes, suite.verifyBackupRestorePair(
+			"aws-object-storage",
+			&blobDatasetSeeder{suite: suite.AWSTestSuite, bucket: bucket, prefix: prefix, count: blobDatasetSeederCount, size: blobDatasetSeederSize},
+			&blobComparator{suite: suite.AWSTestSuite, bucket: bucket},
+		))
+	}
+
+	if testing.DatasetDSN != "" {
+		restoreDSN := testing.RestoreDSN
+		if restoreDSN == "" {
+			restoreDSN = testing.DatasetDSN
+		}
+		table := "backup_restore_verification_" + sanitizeIdentifier(suite.TestID)
+		outcomes = append(outcomes, suite.verifyBackupRestorePair(
+			"postgres-rows",
+			&pgRowSeeder{dsn: testing.DatasetDSN, table: table, count: pgRowSeederCount},
+			&pgRowComparator{dsn: restoreDSN, table: table},
+		))
+	}
+
+	if testing.Reporting && testing.ReportDir != "" {
+		if err := backup.WriteJUnit(filepath.Join(testing.ReportDir, "cross-provider-backup-restore.xml"), "CrossProviderBackupRestore", outcomes); err != nil {
+			return errors.Wrap(err, "failed to write JUnit backup/restore report")
+		}
+		if err := backup.WriteMarkdown(filepath.Join(testing.ReportDir, "cross-provider-backup-restore.md"), outcomes); err != nil {
+			return errors.Wrap(err, "failed to write Markdown backup/restore report")
+		}
+	}
+
+	var failures []string
+	for _, outcome := range outcomes {
+		if outcome.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", outcome.Provider, outcome.Err))
+			continue
+		}
+		if len(outcome.Mismatches) > 0 {
+			failures = append(failures, fmt.Sprintf("%s: %d record(s) failed restore verification", outcome.Provider, len(outcome.Mismatches)))
+		}
+		if threshold, ok := parseSLO(testing.BackupWindowSLO); ok && outcome.BackupWindow > threshold {
+			failures = append(failures, fmt.Sprintf("%s: backup window %s exceeded SLO %s", outcome.Provider, outcome.BackupWindow, threshold))
+		}
+		if threshold, ok := parseSLO(testing.RestoreWindowSLO); ok && outcome.RestoreWindow > threshold {
+			failures = append(failures, fmt.Sprintf("%s: restore window %s exceeded SLO %s", outcome.Provider, outcome.RestoreWindow, threshold))
+		}
+		if threshold, ok := parseSLO(testing.RPOSLO); ok && outcome.RPO > threshold {
+			failures = append(failures, fmt.Sprintf("%s: RPO %s exceeded SLO %s", outcome.Provider, outcome.RPO, threshold))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("cross-provider backup/restore verification failed:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// parseSLO parses an optional goduration-validated SLO string, reporting
+// ok=false when raw is unset.
+func parseSLO(raw string) (threshold time.Duration, ok bool) {
+	if raw == "" {
+		return 0, false
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// sanitizeIdentifier maps testID into a valid unquoted SQL identifier
+// fragment by replacing every non-alphanumeric character with an
+// underscore.
+func sanitizeIdentifier(testID string) string {
+	var b strings.Builder
+	for _, r := range testID {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
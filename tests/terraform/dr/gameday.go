@@ -0,0 +1,192 @@
+package dr
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Probe asserts the application is reachable/healthy through its
+// LoadBalancerConfig endpoints. GameDay.Run polls it after each injection to
+// measure RTO: the time until it stops erroring.
+type Probe func(ctx context.Context) error
+
+// Checksum captures one database replica's current state, for RPO
+// measurement: Digest identifies the data (a hash of the rows a caller
+// considers load-bearing), and AsOf is that data's logical timestamp (e.g.
+// MAX(updated_at)) so RPO can be expressed as lost time, not just a boolean
+// "data differs".
+type Checksum struct {
+	Digest string
+	AsOf   time.Time
+}
+
+// Checksummer captures a Checksum for one named DatabaseInstance replica.
+type Checksummer func(ctx context.Context) (Checksum, error)
+
+// ActiveProviderInspector reports which CloudProvider is currently serving
+// traffic, so a dns_failover action can be verified by watching traffic
+// actually move, not just by asserting the secondary is healthy.
+type ActiveProviderInspector func(ctx context.Context) (provider string, err error)
+
+// SLO is the RTO/RPO budget ActionResult is checked against.
+type SLO struct {
+	MaxRTO time.Duration
+	MaxRPO time.Duration
+}
+
+// GameDay orchestrates a Scenario: for each Action it builds a Handler from
+// Registry, injects it, polls Probe until healthy (RTO) and diffs
+// Checksummers' before/after state (RPO), asserts both against SLO, then
+// always runs the Handler's Undo.
+type GameDay struct {
+	Registry                *Registry
+	Probe                   Probe
+	Checksummers            map[string]Checksummer
+	ActiveProviderInspector ActiveProviderInspector
+	SLO                     SLO
+
+	// ProbeInterval and ProbeTimeout bound how long Run polls Probe for
+	// recovery before giving up and recording the action as a failure.
+	ProbeInterval time.Duration
+	ProbeTimeout  time.Duration
+}
+
+// ActionResult is one Action's outcome.
+type ActionResult struct {
+	Action           Action                   `json:"action"`
+	RTO              time.Duration            `json:"rto"`
+	RTOWithinSLO     bool                     `json:"rto_within_slo"`
+	RPOByReplica     map[string]time.Duration `json:"rpo_by_replica,omitempty"`
+	RPOWithinSLO     bool                     `json:"rpo_within_slo"`
+	FailoverVerified bool                     `json:"failover_verified,omitempty"`
+	Error            string                   `json:"error,omitempty"`
+}
+
+// Report is Run's structured output across every Action in a Scenario.
+type Report struct {
+	Scenario string         `json:"scenario"`
+	Results  []ActionResult `json:"results"`
+}
+
+// Run executes scenario's Actions in order, returning a Report covering
+// every action attempted (it does not stop early on an SLO breach, so a
+// single bad action doesn't hide how the rest of the scenario behaves).
+func (g *GameDay) Run(ctx context.Context, scenario Scenario) (*Report, error) {
+	if g.Probe == nil {
+		return nil, errors.New("GameDay has no Probe configured")
+	}
+
+	report := &Report{Scenario: scenario.Name}
+	for _, action := range scenario.Actions {
+		report.Results = append(report.Results, g.runAction(ctx, action))
+	}
+	return report, nil
+}
+
+func (g *GameDay) runAction(ctx context.Context, action Action) ActionResult {
+	result := ActionResult{Action: action, RPOByReplica: make(map[string]time.Duration)}
+
+	handler, err := g.Registry.Build(action)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	before := g.captureChecksums(ctx)
+
+	if err := handler.Do(ctx); err != nil {
+		result.Error = errors.Wrap(err, "action injection failed").Error()
+		g.safeUndo(ctx, handler)
+		return result
+	}
+
+	rto, probeErr := g.awaitRecovery(ctx)
+	result.RTO = rto
+	result.RTOWithinSLO = probeErr == nil && (g.SLO.MaxRTO == 0 || rto <= g.SLO.MaxRTO)
+	if probeErr != nil {
+		result.Error = errors.Wrap(probeErr, "application did not recover within ProbeTimeout").Error()
+	}
+
+	if action.Type == ActionDNSFailover && g.ActiveProviderInspector != nil {
+		target := action.Params["target_provider"]
+		active, err := g.ActiveProviderInspector(ctx)
+		result.FailoverVerified = err == nil && target != "" && active == target
+	}
+
+	after := g.captureChecksums(ctx)
+	result.RPOWithinSLO = true
+	for name, beforeSum := range before {
+		afterSum, ok := after[name]
+		if !ok {
+			continue
+		}
+		lost := beforeSum.AsOf.Sub(afterSum.AsOf)
+		if lost < 0 {
+			lost = 0
+		}
+		result.RPOByReplica[name] = lost
+		if g.SLO.MaxRPO > 0 && lost > g.SLO.MaxRPO {
+			result.RPOWithinSLO = false
+		}
+	}
+
+	g.safeUndo(ctx, handler)
+	return result
+}
+
+// captureChecksums snapshots every registered Checksummer, logging nothing
+// on error so one broken replica connection doesn't abort the whole action.
+func (g *GameDay) captureChecksums(ctx context.Context) map[string]Checksum {
+	snapshot := make(map[string]Checksum, len(g.Checksummers))
+	for name, checksum := range g.Checksummers {
+		if sum, err := checksum(ctx); err == nil {
+			snapshot[name] = sum
+		}
+	}
+	return snapshot
+}
+
+// awaitRecovery polls Probe every ProbeInterval (default 5s) until it
+// returns nil or ProbeTimeout (default 5m) elapses, returning how long
+// recovery took.
+func (g *GameDay) awaitRecovery(ctx context.Context) (time.Duration, error) {
+	interval := g.ProbeInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	timeout := g.ProbeTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	var lastErr error
+	for {
+		if err := g.Probe(ctx); err == nil {
+			return time.Since(start), nil
+		} else {
+			lastErr = err
+		}
+		if time.Now().After(deadline) {
+			return time.Since(start), lastErr
+		}
+		select {
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// safeUndo runs handler.Undo, swallowing its error into nothing louder than
+// the caller choosing to ignore it -- Run's contract is "always attempt
+// Undo", not "Undo must succeed silently or the game day fails".
+func (g *GameDay) safeUndo(ctx context.Context, handler Handler) {
+	if handler.Undo == nil {
+		return
+	}
+	_ = handler.Undo(ctx)
+}
@@ -0,0 +1,53 @@
+package dr
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Handler is what a Builder produces for one Action: Do performs the
+// injection, Undo reverses it. GameDay.Run always calls Undo, even when Do
+// or the post-injection probe failed, so a game day never leaves the system
+// in the broken state it induced.
+type Handler struct {
+	Do   func(ctx context.Context) error
+	Undo func(ctx context.Context) error
+}
+
+// Builder constructs the Handler for one Action -- the AWS/GCP/Azure-specific
+// glue a Registry holds per (ActionType, Provider) pair.
+type Builder func(action Action) (Handler, error)
+
+// Registry maps (ActionType, Provider) to the Builder that knows how to
+// inject and reverse it for that provider.
+type Registry struct {
+	builders map[ActionType]map[string]Builder
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{builders: make(map[ActionType]map[string]Builder)}
+}
+
+// Register records builder as how to handle actionType against provider
+// (e.g. "aws", "gcp", "azure").
+func (r *Registry) Register(actionType ActionType, provider string, builder Builder) {
+	if r.builders[actionType] == nil {
+		r.builders[actionType] = make(map[string]Builder)
+	}
+	r.builders[actionType][provider] = builder
+}
+
+// Build looks up the Builder for action.Type/action.Provider and invokes it.
+func (r *Registry) Build(action Action) (Handler, error) {
+	byProvider, ok := r.builders[action.Type]
+	if !ok {
+		return Handler{}, errors.Errorf("no handler registered for action type %q", action.Type)
+	}
+	builder, ok := byProvider[action.Provider]
+	if !ok {
+		return Handler{}, errors.Errorf("no handler registered for action type %q against provider %q", action.Type, action.Provider)
+	}
+	return builder(action)
+}
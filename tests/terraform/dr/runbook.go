@@ -0,0 +1,293 @@
+package dr
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Step is one parsed DRRunbook.Steps entry. A runbook author writes each
+// entry as a one-line flow-style YAML mapping, e.g.
+// "{action: terminate_instance, provider: aws, selector: tag:role=primary-db}"
+// or "{action: sleep, duration: 5m}" -- ParseStep turns that string into a
+// Step.
+type Step struct {
+	Action   string  `yaml:"action"`
+	Provider string  `yaml:"provider"`
+	Selector string  `yaml:"selector"`
+	CIDR     string  `yaml:"cidr"`
+	Duration string  `yaml:"duration"`
+	Query    string  `yaml:"query"`
+	Op       string  `yaml:"op"`
+	Value    float64 `yaml:"value"`
+}
+
+// StepAction names one RunbookExecutor step kind. TerminateInstance and
+// BlockNetwork dispatch through Registry the same way a Scenario Action
+// does; Sleep and AssertMetric are control-flow steps RunbookExecutor
+// performs itself, with no Handler to build.
+type StepAction string
+
+const (
+	StepTerminateInstance StepAction = "terminate_instance"
+	StepBlockNetwork      StepAction = "block_network"
+	StepScaleUp           StepAction = "scale_up_instance"
+	StepPromoteStandby    StepAction = "promote_standby"
+	StepSleep             StepAction = "sleep"
+	StepAssertMetric      StepAction = "assert_metric"
+)
+
+// stepActionTypes maps the StepActions that inject a fault (or, for
+// ScaleUp/PromoteStandby, perform a recovery action) onto the ActionType a
+// Builder is registered under. Sleep and AssertMetric have no entry: they
+// never reach Registry.Build.
+var stepActionTypes = map[StepAction]ActionType{
+	StepTerminateInstance: ActionKillInstance,
+	StepBlockNetwork:      ActionPartitionNetwork,
+	StepScaleUp:           ActionScaleUp,
+	StepPromoteStandby:    ActionPromoteStandby,
+}
+
+// ParseStep parses one DRRunbook.Steps entry into a Step.
+func ParseStep(raw string) (Step, error) {
+	var step Step
+	if err := yaml.Unmarshal([]byte(raw), &step); err != nil {
+		return Step{}, errors.Wrapf(err, "failed to parse runbook step %q", raw)
+	}
+	if step.Action == "" {
+		return Step{}, errors.Errorf("runbook step %q has no action", raw)
+	}
+	return step, nil
+}
+
+// MetricQuerier evaluates an assert_metric step's Query (e.g. a PromQL
+// expression) and returns its scalar result.
+type MetricQuerier func(ctx context.Context, query string) (float64, error)
+
+// RunbookResult is one DRRunbook's outcome. RTO is measured from the last
+// fault-injecting step to Probe reporting healthy again; RPOByReplica
+// compares each Checksummer's AsOf timestamp from before the first step
+// (the last committed write) against after the last step (failover
+// completion).
+type RunbookResult struct {
+	RTO          time.Duration
+	RPOByReplica map[string]time.Duration
+	Err          error
+}
+
+// RunbookExecutor runs a DRRunbook's parsed Steps against Registry's
+// fault-injection Handlers, pushing each injected fault's Undo onto a
+// rollback stack it unwinds, in reverse order, once the runbook finishes --
+// including when a step panics or ctx is done. This differs from GameDay,
+// which undoes each Action immediately after probing it: a runbook's
+// steps are meant to compound (e.g. block_network then
+// terminate_instance) before everything is rolled back together, the way
+// a real chaos game day layers faults.
+type RunbookExecutor struct {
+	Registry      *Registry
+	Probe         Probe
+	Checksummers  map[string]Checksummer
+	MetricQuerier MetricQuerier
+
+	// ProbeInterval and ProbeTimeout bound how long Run polls Probe for
+	// recovery after a fault-injecting step. Defaults mirror GameDay's:
+	// 5s and 5m.
+	ProbeInterval time.Duration
+	ProbeTimeout  time.Duration
+}
+
+// Run executes steps in order against provider. It always rolls back
+// every injected fault, LIFO, before returning -- via defer, so a panic or
+// ctx cancellation mid-runbook still reverts whatever was already
+// injected.
+func (e *RunbookExecutor) Run(ctx context.Context, provider string, steps []Step) (result RunbookResult) {
+	result.RPOByReplica = make(map[string]time.Duration)
+	before := e.captureChecksums(ctx)
+	var rollbacks []func(context.Context) error
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Err = errors.Errorf("runbook step panicked: %v", r)
+		}
+		for i := len(rollbacks) - 1; i >= 0; i-- {
+			if err := rollbacks[i](ctx); err != nil && result.Err == nil {
+				result.Err = errors.Wrap(err, "rollback failed")
+			}
+		}
+	}()
+
+	for _, step := range steps {
+		select {
+		case <-ctx.Done():
+			result.Err = ctx.Err()
+			return result
+		default:
+		}
+
+		switch StepAction(step.Action) {
+		case StepSleep:
+			if err := e.sleep(ctx, step.Duration); err != nil {
+				result.Err = err
+				return result
+			}
+		case StepAssertMetric:
+			if err := e.assertMetric(ctx, step); err != nil {
+				result.Err = err
+				return result
+			}
+		default:
+			handler, err := e.buildStepHandler(step, provider)
+			if err != nil {
+				result.Err = err
+				return result
+			}
+			if err := handler.Do(ctx); err != nil {
+				result.Err = errors.Wrapf(err, "runbook step %q failed", step.Action)
+				return result
+			}
+			rollbacks = append(rollbacks, handler.Undo)
+
+			rto, probeErr := e.awaitRecovery(ctx)
+			result.RTO = rto
+			if probeErr != nil {
+				result.Err = errors.Wrap(probeErr, "application did not recover within ProbeTimeout")
+				return result
+			}
+		}
+	}
+
+	after := e.captureChecksums(ctx)
+	for name, beforeSum := range before {
+		afterSum, ok := after[name]
+		if !ok {
+			continue
+		}
+		lost := beforeSum.AsOf.Sub(afterSum.AsOf)
+		if lost < 0 {
+			lost = 0
+		}
+		result.RPOByReplica[name] = lost
+	}
+
+	return result
+}
+
+func (e *RunbookExecutor) buildStepHandler(step Step, provider string) (Handler, error) {
+	actionType, known := stepActionTypes[StepAction(step.Action)]
+	if !known {
+		return Handler{}, errors.Errorf("unknown runbook step action %q", step.Action)
+	}
+
+	stepProvider := provider
+	if step.Provider != "" {
+		stepProvider = step.Provider
+	}
+
+	return e.Registry.Build(Action{
+		Type:     actionType,
+		Provider: stepProvider,
+		Target:   step.Selector,
+		Params:   map[string]string{"cidr": step.CIDR},
+	})
+}
+
+func (e *RunbookExecutor) sleep(ctx context.Context, rawDuration string) error {
+	duration, err := time.ParseDuration(rawDuration)
+	if err != nil {
+		return errors.Wrapf(err, "invalid sleep duration %q", rawDuration)
+	}
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *RunbookExecutor) assertMetric(ctx context.Context, step Step) error {
+	if e.MetricQuerier == nil {
+		return errors.New("assert_metric step requires a MetricQuerier")
+	}
+	value, err := e.MetricQuerier(ctx, step.Query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to evaluate metric query %q", step.Query)
+	}
+	ok, err := compareMetric(value, step.Op, step.Value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.Errorf("metric assertion failed: %s %s %g (got %g)", step.Query, step.Op, step.Value, value)
+	}
+	return nil
+}
+
+func compareMetric(actual float64, op string, threshold float64) (bool, error) {
+	switch op {
+	case "<":
+		return actual < threshold, nil
+	case "<=":
+		return actual <= threshold, nil
+	case ">":
+		return actual > threshold, nil
+	case ">=":
+		return actual >= threshold, nil
+	case "==":
+		return actual == threshold, nil
+	default:
+		return false, errors.Errorf("unknown comparison operator %q", op)
+	}
+}
+
+// captureChecksums snapshots every registered Checksummer, the same way
+// GameDay does: a broken replica connection is skipped rather than
+// aborting the run.
+func (e *RunbookExecutor) captureChecksums(ctx context.Context) map[string]Checksum {
+	snapshot := make(map[string]Checksum, len(e.Checksummers))
+	for name, checksum := range e.Checksummers {
+		if sum, err := checksum(ctx); err == nil {
+			snapshot[name] = sum
+		}
+	}
+	return snapshot
+}
+
+// awaitRecovery polls Probe every ProbeInterval (default 5s) until it
+// returns nil or ProbeTimeout (default 5m) elapses, returning how long
+// recovery took. A nil Probe is treated as "nothing to verify": it
+// returns immediately with zero duration and no error.
+func (e *RunbookExecutor) awaitRecovery(ctx context.Context) (time.Duration, error) {
+	if e.Probe == nil {
+		return 0, nil
+	}
+
+	interval := e.ProbeInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	timeout := e.ProbeTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	start := time.Now()
+	deadline := start.Add(timeout)
+	var lastErr error
+	for {
+		if err := e.Probe(ctx); err == nil {
+			return time.Since(start), nil
+		} else {
+			lastErr = err
+		}
+		if time.Now().After(deadline) {
+			return time.Since(start), lastErr
+		}
+		select {
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Package dr runs scripted disaster-recovery game days against a steady-state
+// system: it injects a scenario's actions one at a time, probes the
+// application until it recovers, measures RTO/RPO against caller-supplied
+// SLOs, and always runs each action's reverse to leave the system as it
+// found it. The engine here is provider-agnostic -- it only knows about
+// Action/Handler/Probe/Checksummer -- so the AWS/GCP/Azure-specific code
+// that builds a Handler for a given Action lives alongside the suites that
+// have the live clients, not in this package.
+package dr
+
+// ActionType names one disaster-recovery injection the scenario DSL
+// supports. Handlers are registered per ActionType in a Registry.
+type ActionType string
+
+const (
+	ActionKillInstance     ActionType = "kill_instance"
+	ActionPartitionNetwork ActionType = "partition_network"
+	ActionRevokeIAM        ActionType = "revoke_iam"
+	ActionDropAZ           ActionType = "drop_az"
+	ActionCorruptReplica   ActionType = "corrupt_replica"
+	ActionDNSFailover      ActionType = "dns_failover"
+
+	// ActionScaleUp and ActionPromoteStandby are recovery actions, not
+	// fault injections: RunbookExecutor uses them to bring a pilot-light
+	// or warm-standby DRSite up to serving capacity during a promotion
+	// sequence. Neither has a meaningful Undo -- once a site is
+	// promoted, a Builder's Handler.Undo is a no-op, the same way
+	// ActionCorruptReplica's is.
+	ActionScaleUp        ActionType = "scale_up_instance"
+	ActionPromoteStandby ActionType = "promote_standby"
+)
+
+// Action is one scenario step, as the YAML scenario DSL expresses it.
+type Action struct {
+	Type     ActionType        `yaml:"type"`
+	Provider string            `yaml:"provider"`
+	Region   string            `yaml:"region"`
+	Target   string            `yaml:"target"`
+	Params   map[string]string `yaml:"params"`
+}
+
+// Scenario is an ordered list of Actions a GameDay runs in sequence -- in
+// sequence, not parallel, so one action's induced instability doesn't
+// confound the next action's RTO/RPO measurement.
+type Scenario struct {
+	Name    string   `yaml:"name"`
+	Actions []Action `yaml:"actions"`
+}
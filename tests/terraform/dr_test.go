@@ -0,0 +1,414 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/dr"
+)
+
+// drRunbookActionTypes maps a DRRunbook's freeform Scenario label to the
+// structured dr.ActionType the game-day engine knows how to inject. A
+// runbook whose Scenario doesn't match one of these is skipped with a
+// logged warning rather than failing the whole game day.
+var drRunbookActionTypes = map[string]dr.ActionType{
+	"instance_failure":   dr.ActionKillInstance,
+	"network_partition":  dr.ActionPartitionNetwork,
+	"iam_revocation":     dr.ActionRevokeIAM,
+	"az_outage":          dr.ActionDropAZ,
+	"replica_corruption": dr.ActionCorruptReplica,
+	"dns_failover":       dr.ActionDNSFailover,
+}
+
+// registerAWSDisasterRecoveryBuilders wires a dr.Handler for every
+// dr.ActionType against the "aws" provider, using suite's already-cached
+// service clients (see awsClient). registerGCPDisasterRecoveryBuilders,
+// registerAzureDisasterRecoveryBuilders, and
+// registerK8sDisasterRecoveryBuilders cover the other three providers, but
+// only for ActionKillInstance so far -- a Scenario targeting one of this
+// provider's other ActionTypes against gcp/azure/kubernetes surfaces a
+// clear "no handler registered" error from dr.Registry.Build rather than
+// silently no-opping.
+func registerAWSDisasterRecoveryBuilders(registry *dr.Registry, suite *AWSTestSuite) {
+	registry.Register(dr.ActionKillInstance, "aws", func(action dr.Action) (dr.Handler, error) {
+		instanceID := action.Target
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.EC2().StopInstances(ctx, &ec2.StopInstancesInput{
+					InstanceIds: []string{instanceID},
+				})
+				return errors.Wrapf(err, "failed to stop instance %s", instanceID)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.EC2().StartInstances(ctx, &ec2.StartInstancesInput{
+					InstanceIds: []string{instanceID},
+				})
+				return errors.Wrapf(err, "failed to restart instance %s", instanceID)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionPartitionNetwork, "aws", func(action dr.Action) (dr.Handler, error) {
+		networkACLID := action.Target
+		var ruleNumber int32 = 1
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.EC2().CreateNetworkAclEntry(ctx, &ec2.CreateNetworkAclEntryInput{
+					NetworkAclId: aws.String(networkACLID),
+					RuleNumber:   aws.Int32(ruleNumber),
+					Protocol:     aws.String("-1"),
+					RuleAction:   ec2types.RuleActionDeny,
+					Egress:       aws.Bool(false),
+					CidrBlock:    aws.String("0.0.0.0/0"),
+				})
+				return errors.Wrapf(err, "failed to insert deny-all rule into network ACL %s", networkACLID)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.EC2().DeleteNetworkAclEntry(ctx, &ec2.DeleteNetworkAclEntryInput{
+					NetworkAclId: aws.String(networkACLID),
+					RuleNumber:   aws.Int32(ruleNumber),
+					Egress:       aws.Bool(false),
+				})
+				return errors.Wrapf(err, "failed to remove deny-all rule from network ACL %s", networkACLID)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionRevokeIAM, "aws", func(action dr.Action) (dr.Handler, error) {
+		roleName := action.Target
+		policyARN := action.Params["policy_arn"]
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.IAM().DetachRolePolicy(ctx, &iam.DetachRolePolicyInput{
+					RoleName:  aws.String(roleName),
+					PolicyArn: aws.String(policyARN),
+				})
+				return errors.Wrapf(err, "failed to detach policy %s from role %s", policyARN, roleName)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.IAM().AttachRolePolicy(ctx, &iam.AttachRolePolicyInput{
+					RoleName:  aws.String(roleName),
+					PolicyArn: aws.String(policyARN),
+				})
+				return errors.Wrapf(err, "failed to reattach policy %s to role %s", policyARN, roleName)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionDropAZ, "aws", func(action dr.Action) (dr.Handler, error) {
+		asgName := action.Target
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.AutoScaling().SuspendProcesses(ctx, &autoscaling.SuspendProcessesInput{
+					AutoScalingGroupName: aws.String(asgName),
+					ScalingProcesses:     []string{"Launch", "ReplaceUnhealthy"},
+				})
+				return errors.Wrapf(err, "failed to suspend scaling processes on %s", asgName)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.AutoScaling().ResumeProcesses(ctx, &autoscaling.ResumeProcessesInput{
+					AutoScalingGroupName: aws.String(asgName),
+					ScalingProcesses:     []string{"Launch", "ReplaceUnhealthy"},
+				})
+				return errors.Wrapf(err, "failed to resume scaling processes on %s", asgName)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionCorruptReplica, "aws", func(action dr.Action) (dr.Handler, error) {
+		dbInstanceID := action.Target
+		// Actually corrupting replica data isn't something this suite can
+		// safely automate. Instead this simulates the failure mode a
+		// corrupt replica would require recovering from: forcing a
+		// failover to the standby, which is what AutoFailover.Sites
+		// recovery actually depends on.
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.RDS().RebootDBInstance(ctx, &rds.RebootDBInstanceInput{
+					DBInstanceIdentifier: aws.String(dbInstanceID),
+					ForceFailover:        aws.Bool(true),
+				})
+				return errors.Wrapf(err, "failed to force failover on %s", dbInstanceID)
+			},
+			Undo: func(ctx context.Context) error {
+				return nil
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionDNSFailover, "aws", func(action dr.Action) (dr.Handler, error) {
+		hostedZoneID := action.Params["hosted_zone_id"]
+		recordName := action.Target
+		primaryValue := action.Params["primary_value"]
+		secondaryValue := action.Params["secondary_value"]
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				return changeRoute53Record(ctx, suite, hostedZoneID, recordName, secondaryValue)
+			},
+			Undo: func(ctx context.Context) error {
+				return changeRoute53Record(ctx, suite, hostedZoneID, recordName, primaryValue)
+			},
+		}, nil
+	})
+}
+
+// registerGCPDisasterRecoveryBuilders wires a dr.Handler for ActionKillInstance
+// against the "gcp" provider: action.Target is the instance name, stopped in
+// suite.Zone (or action.Params["zone"] when the instance lives elsewhere).
+// The other ActionTypes don't have a GCP builder yet -- a GameDay scenario
+// targeting one of those against gcp surfaces a clear "no handler
+// registered" error from dr.Registry.Build rather than silently no-opping.
+func registerGCPDisasterRecoveryBuilders(registry *dr.Registry, suite *GCPTestSuite) {
+	registry.Register(dr.ActionKillInstance, "gcp", func(action dr.Action) (dr.Handler, error) {
+		instanceName := action.Target
+		zone := action.Params["zone"]
+		if zone == "" {
+			zone = suite.Zone
+		}
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.Compute.Instances.Stop(suite.ProjectID, zone, instanceName).Context(ctx).Do()
+				return errors.Wrapf(err, "failed to stop instance %s in zone %s", instanceName, zone)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.Compute.Instances.Start(suite.ProjectID, zone, instanceName).Context(ctx).Do()
+				return errors.Wrapf(err, "failed to restart instance %s in zone %s", instanceName, zone)
+			},
+		}, nil
+	})
+}
+
+// registerAzureDisasterRecoveryBuilders wires a dr.Handler for
+// ActionKillInstance against the "azure" provider: action.Target is the VM
+// name, deallocated in action.Params["resource_group"].
+func registerAzureDisasterRecoveryBuilders(registry *dr.Registry, suite *AzureTestSuite) {
+	registry.Register(dr.ActionKillInstance, "azure", func(action dr.Action) (dr.Handler, error) {
+		vmName := action.Target
+		resourceGroup := action.Params["resource_group"]
+		if resourceGroup == "" {
+			return dr.Handler{}, errors.New("kill_instance against azure requires a resource_group param")
+		}
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				poller, err := suite.Compute.BeginDeallocate(ctx, resourceGroup, vmName, nil)
+				if err != nil {
+					return errors.Wrapf(err, "failed to start deallocation of VM %s", vmName)
+				}
+				_, err = pollARM(ctx, suite.Logger, poller)
+				return errors.Wrapf(err, "failed to deallocate VM %s", vmName)
+			},
+			Undo: func(ctx context.Context) error {
+				poller, err := suite.Compute.BeginStart(ctx, resourceGroup, vmName, nil)
+				if err != nil {
+					return errors.Wrapf(err, "failed to start restart of VM %s", vmName)
+				}
+				_, err = pollARM(ctx, suite.Logger, poller)
+				return errors.Wrapf(err, "failed to restart VM %s", vmName)
+			},
+		}, nil
+	})
+}
+
+// registerK8sDisasterRecoveryBuilders wires a dr.Handler for
+// ActionKillInstance against the "kubernetes" provider: action.Target is a
+// pod name, force-deleted in action.Params["namespace"] (defaulting to
+// suite.Namespace). Undo is a no-op, the same way ActionCorruptReplica's is
+// elsewhere in this file -- a deleted pod's replacement is the deployment
+// controller's job, not this game day's.
+func registerK8sDisasterRecoveryBuilders(registry *dr.Registry, suite *K8sTestSuite) {
+	registry.Register(dr.ActionKillInstance, "kubernetes", func(action dr.Action) (dr.Handler, error) {
+		podName := action.Target
+		namespace := action.Params["namespace"]
+		if namespace == "" {
+			namespace = suite.Namespace
+		}
+		gracePeriod := int64(0)
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				err := suite.Clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{
+					GracePeriodSeconds: &gracePeriod,
+				})
+				return errors.Wrapf(err, "failed to delete pod %s/%s", namespace, podName)
+			},
+			Undo: func(ctx context.Context) error {
+				return nil
+			},
+		}, nil
+	})
+}
+
+// changeRoute53Record UPSERTs recordName in hostedZoneID to point at value,
+// the shared plumbing behind the dns_failover action's Do/Undo.
+func changeRoute53Record(ctx context.Context, suite *AWSTestSuite, hostedZoneID, recordName, value string) error {
+	_, err := suite.Route53().ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: []route53types.Change{
+				{
+					Action: route53types.ChangeActionUpsert,
+					ResourceRecordSet: &route53types.ResourceRecordSet{
+						Name: aws.String(recordName),
+						Type: route53types.RRTypeCname,
+						TTL:  aws.Int64(60),
+						ResourceRecords: []route53types.ResourceRecord{
+							{Value: aws.String(value)},
+						},
+					},
+				},
+			},
+		},
+	})
+	return errors.Wrapf(err, "failed to upsert %s to %s", recordName, value)
+}
+
+// buildDatabaseChecksummer returns a dr.Checksummer reporting db's current
+// status and latest restorable time as its logical "as of" timestamp, so
+// RunDisasterRecoveryGameDay can express RPO as how much further back that
+// timestamp moved after an injected failure, rather than a boolean
+// data-differs flag.
+func buildDatabaseChecksummer(suite *AWSTestSuite, db DatabaseInstance) dr.Checksummer {
+	return func(ctx context.Context) (dr.Checksum, error) {
+		output, err := suite.RDS().DescribeDBInstances(ctx, &rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(db.Name),
+		})
+		if err != nil {
+			return dr.Checksum{}, errors.Wrapf(err, "failed to describe RDS instance %s", db.Name)
+		}
+		if len(output.DBInstances) == 0 {
+			return dr.Checksum{}, errors.Errorf("RDS instance %s not found", db.Name)
+		}
+
+		instance := output.DBInstances[0]
+		asOf := time.Now()
+		if instance.LatestRestorableTime != nil {
+			asOf = *instance.LatestRestorableTime
+		}
+		return dr.Checksum{
+			Digest: aws.ToString(instance.DBInstanceStatus),
+			AsOf:   asOf,
+		}, nil
+	}
+}
+
+// buildDisasterRecoveryScenario turns suite's DisasterRecoveryConfig.Runbooks
+// into a dr.Scenario, dropping any runbook whose Scenario label isn't a
+// recognized dr.ActionType.
+func (suite *MultiCloudTestSuite) buildDisasterRecoveryScenario() dr.Scenario {
+	drConfig := suite.MultiCloudConfig.DisasterRecoveryConfig
+
+	scenario := dr.Scenario{Name: "disaster-recovery-game-day"}
+	for _, runbook := range drConfig.Runbooks {
+		actionType, ok := drRunbookActionTypes[runbook.Scenario]
+		if !ok {
+			suite.Logger.Warn().Str("runbook", runbook.Name).Str("scenario", runbook.Scenario).
+				Msg("Skipping DR runbook with unrecognized scenario label")
+			continue
+		}
+
+		// A runbook's DRSite (matched via Dependencies, same as
+		// ExecuteDRRunbook's promotion step) tells us which provider's
+		// Registry builder to dispatch the action to; a runbook with no
+		// matching site defaults to "aws", this scenario's original
+		// (and still most common) target.
+		provider := "aws"
+		if site, ok := siteForRunbook(drConfig.Sites, runbook); ok && site.Provider != "" {
+			provider = site.Provider
+		}
+
+		scenario.Actions = append(scenario.Actions, dr.Action{
+			Type:     actionType,
+			Provider: provider,
+			Target:   runbook.Name,
+			Params:   map[string]string{},
+		})
+	}
+	return scenario
+}
+
+// RunDisasterRecoveryGameDay injects every DisasterRecoveryConfig.Runbooks
+// action in order, measuring RTO against suite's configured load balancer
+// endpoints and RPO against its database read replicas, and verifying that
+// a dns_failover action actually moves traffic to the secondary site when
+// DNSConfig.Failover is enabled.
+func (suite *MultiCloudTestSuite) RunDisasterRecoveryGameDay(ctx context.Context, slo dr.SLO) (*dr.Report, error) {
+	if suite.AWSTestSuite == nil {
+		return nil, errors.New("RunDisasterRecoveryGameDay requires an initialized AWSTestSuite")
+	}
+
+	registry := dr.NewRegistry()
+	registerAWSDisasterRecoveryBuilders(registry, suite.AWSTestSuite)
+	if suite.GCPTestSuite != nil {
+		registerGCPDisasterRecoveryBuilders(registry, suite.GCPTestSuite)
+	}
+	if suite.AzureTestSuite != nil {
+		registerAzureDisasterRecoveryBuilders(registry, suite.AzureTestSuite)
+	}
+	if suite.K8sTestSuite != nil {
+		registerK8sDisasterRecoveryBuilders(registry, suite.K8sTestSuite)
+	}
+
+	checksummers := make(map[string]dr.Checksummer)
+	for _, db := range suite.MultiCloudConfig.DatabaseConfig.Databases {
+		if db.Provider == "aws" {
+			checksummers[db.Name] = buildDatabaseChecksummer(suite.AWSTestSuite, db)
+		}
+	}
+
+	probe := dr.Probe(func(ctx context.Context) error {
+		for _, lb := range suite.MultiCloudConfig.NetworkConfig.LoadBalancers {
+			if lb.Endpoint == "" {
+				continue
+			}
+			if err := suite.AWSTestSuite.TestLoadBalancerHealth(lb.Endpoint); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	gameDay := &dr.GameDay{
+		Registry:     registry,
+		Probe:        probe,
+		Checksummers: checksummers,
+		SLO:          slo,
+	}
+
+	if suite.MultiCloudConfig.NetworkConfig.DNS.Failover {
+		gameDay.ActiveProviderInspector = suite.activeDNSProvider
+	}
+
+	return gameDay.Run(ctx, suite.buildDisasterRecoveryScenario())
+}
+
+// activeDNSProvider reports which DRSite currently owns traffic by matching
+// its region against the A/CNAME records DNSConfig.Records declares. It's
+// the dns_failover action's FailoverVerified signal: without
+// NetworkConfig.DNS.Failover actually being driven end to end, a dns
+// failover scenario could "pass" without traffic ever moving.
+func (suite *MultiCloudTestSuite) activeDNSProvider(ctx context.Context) (string, error) {
+	dnsConfig := suite.MultiCloudConfig.NetworkConfig.DNS
+	if len(dnsConfig.Records) == 0 {
+		return "", errors.New("no DNS records configured to inspect for active provider")
+	}
+
+	activeValue := dnsConfig.Records[0].Value
+	for _, site := range suite.MultiCloudConfig.DisasterRecoveryConfig.Sites {
+		if activeValue != "" && (activeValue == site.Region || activeValue == site.Name) {
+			return site.Provider, nil
+		}
+	}
+	return "", errors.Errorf("no DR site matches active DNS record value %q", activeValue)
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// MySQLProber pings a MySQL endpoint via go-sql-driver/mysql's
+// database/sql driver, the non-IAM counterpart to GCPTestSuite's
+// CloudSQLProber (Engine: "mysql").
+type MySQLProber struct {
+	DSN        string
+	MaxRetries int
+}
+
+func (p MySQLProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.DSN, p.MaxRetries, func(ctx context.Context) error {
+		db, err := sql.Open("mysql", p.DSN)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.PingContext(ctx)
+	})(ctx)
+}
+
+// MemcachedProber round-trips a throwaway key through a Memcached endpoint.
+// gomemcache has no PING command, so a Set immediately followed by a Get is
+// the round-trip-latency equivalent.
+type MemcachedProber struct {
+	Addr       string
+	MaxRetries int
+}
+
+func (p MemcachedProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.Addr, p.MaxRetries, func(ctx context.Context) error {
+		client := memcache.New(p.Addr)
+		client.Timeout = 5 * time.Second
+
+		const probeKey = "__terraform_test_probe__"
+		if err := client.Set(&memcache.Item{Key: probeKey, Value: []byte("ok"), Expiration: 10}); err != nil {
+			return err
+		}
+		if _, err := client.Get(probeKey); err != nil {
+			return err
+		}
+		return nil
+	})(ctx)
+}
+
+// GRPCHealthProber calls the standard grpc.health.v1 Health service and
+// requires a SERVING status, the generic counterpart to K8sHealthProber for
+// any gRPC workload (e.g. an ECS/EKS/AKS/GKE service) that implements the
+// health-checking protocol.
+type GRPCHealthProber struct {
+	Target     string
+	Service    string
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+func (p GRPCHealthProber) Probe(ctx context.Context) ProbeResult {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	return probeWithRetry(p.Target, p.MaxRetries, func(ctx context.Context) error {
+		dialCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		conn, err := grpc.NewClient(p.Target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(dialCtx, &healthpb.HealthCheckRequest{Service: p.Service})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return errors.Errorf("gRPC health check for %s reported status %s", p.Target, resp.Status)
+		}
+		return nil
+	})(ctx)
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/cloudasset/v1"
+)
+
+// updateGCPBaseline rewrites gcpBaselinePath from the current Cloud Asset
+// Inventory snapshot instead of diffing against it, for operators to run
+// after an intentional infrastructure change: go test -run TestCompliance
+// ./tests/terraform/... -update-gcp-baseline
+var updateGCPBaseline = flag.Bool("update-gcp-baseline", false,
+	"rewrite the Cloud Asset Inventory baseline instead of diffing the live snapshot against it")
+
+// gcpBaselinePath is the checked-in snapshot TestCompliance's Asset
+// Inventory subtest diffs the live project against.
+const gcpBaselinePath = "testdata/gcp_baseline.json"
+
+// assetAllowlist names resources permitted to appear, disappear, or change
+// between snapshots without failing the Asset Inventory subtest -- e.g.
+// resources that are expected to churn (autoscaled instances, rotated
+// keys).
+var assetAllowlist = map[string]bool{}
+
+// assetKinds maps every Cloud Asset Inventory asset type ExportAssetSnapshot
+// captures to a short, human-readable kind used to group the diff report.
+var assetKinds = map[string]string{
+	"compute.googleapis.com/Instance":             "compute_instance",
+	"compute.googleapis.com/Disk":                 "compute_disk",
+	"storage.googleapis.com/Bucket":               "storage_bucket",
+	"sqladmin.googleapis.com/Instance":            "sql_instance",
+	"container.googleapis.com/Cluster":            "gke_cluster",
+	"cloudkms.googleapis.com/CryptoKey":           "kms_key",
+	"iam.googleapis.com/ServiceAccount":           "iam_service_account",
+	"cloudresourcemanager.googleapis.com/Project": "iam_policy",
+}
+
+// AssetRecord is one resource's canonical snapshot entry: just enough to
+// detect appearance, disappearance and in-place changes without carrying
+// the full (noisy, frequently-mutated) Cloud Asset Inventory payload.
+type AssetRecord struct {
+	Kind       string                 `json:"kind"`
+	Name       string                 `json:"name"`
+	AssetType  string                 `json:"asset_type"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// AssetSnapshot is a full point-in-time export of every resource type in
+// assetKinds, keyed by asset name for quick diffing.
+type AssetSnapshot map[string]AssetRecord
+
+// AssetDriftKind classifies one AssetDrift finding.
+type AssetDriftKind string
+
+const (
+	AssetAdded   AssetDriftKind = "added"
+	AssetRemoved AssetDriftKind = "removed"
+	AssetChanged AssetDriftKind = "changed"
+)
+
+// AssetDrift is one difference DiffAssetSnapshot found between a baseline
+// and a current AssetSnapshot.
+type AssetDrift struct {
+	Kind         AssetDriftKind
+	ResourceKind string
+	Name         string
+	Baseline     AssetRecord `json:"baseline,omitempty"`
+	Current      AssetRecord `json:"current,omitempty"`
+}
+
+// ExportAssetSnapshot lists every asset type in assetKinds via Cloud Asset
+// Inventory's Assets.List and normalizes the result into an AssetSnapshot
+// covering compute instances, disks, buckets, SQL instances, GKE clusters,
+// IAM policies, and KMS keys.
+func (suite *GCPTestSuite) ExportAssetSnapshot() (AssetSnapshot, error) {
+	parent := fmt.Sprintf("projects/%s", suite.ProjectID)
+	snapshot := make(AssetSnapshot)
+
+	for assetType, kind := range assetKinds {
+		pageToken := ""
+		for {
+			var resp *cloudasset.ListAssetsResponse
+			err := suite.DoWithRetry("cloudasset.Assets.List", fmt.Sprintf("%s (assetType=%s)", parent, assetType), func() error {
+				var callErr error
+				resp, callErr = suite.CloudAsset.Assets.List(parent).
+					ContentType("RESOURCE").
+					AssetTypes(assetType).
+					PageToken(pageToken).
+					Context(suite.Context).Do()
+				return callErr
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to list assets of type %s", assetType)
+			}
+			if resp == nil {
+				break
+			}
+
+			for _, asset := range resp.Assets {
+				var attrs map[string]interface{}
+				if asset.Resource != nil && len(asset.Resource.Data) > 0 {
+					if err := json.Unmarshal(asset.Resource.Data, &attrs); err != nil {
+						return nil, errors.Wrapf(err, "failed to decode resource data for %s", asset.Name)
+					}
+				}
+				snapshot[asset.Name] = AssetRecord{
+					Kind:       kind,
+					Name:       asset.Name,
+					AssetType:  assetType,
+					Attributes: attrs,
+				}
+			}
+
+			if resp.NextPageToken == "" {
+				break
+			}
+			pageToken = resp.NextPageToken
+		}
+	}
+
+	return snapshot, nil
+}
+
+// DiffAssetSnapshot compares baseline against current, skipping any asset
+// whose name appears in allowlist, and returns every added, removed, or
+// changed resource.
+func DiffAssetSnapshot(baseline, current AssetSnapshot, allowlist map[string]bool) []AssetDrift {
+	var drifts []AssetDrift
+
+	for name, record := range current {
+		if allowlist[name] {
+			continue
+		}
+		base, ok := baseline[name]
+		if !ok {
+			drifts = append(drifts, AssetDrift{Kind: AssetAdded, ResourceKind: record.Kind, Name: name, Current: record})
+			continue
+		}
+		if !equalDriftValue(base.Attributes, record.Attributes) {
+			drifts = append(drifts, AssetDrift{Kind: AssetChanged, ResourceKind: record.Kind, Name: name, Baseline: base, Current: record})
+		}
+	}
+
+	for name, record := range baseline {
+		if allowlist[name] {
+			continue
+		}
+		if _, ok := current[name]; !ok {
+			drifts = append(drifts, AssetDrift{Kind: AssetRemoved, ResourceKind: record.Kind, Name: name, Baseline: record})
+		}
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].ResourceKind != drifts[j].ResourceKind {
+			return drifts[i].ResourceKind < drifts[j].ResourceKind
+		}
+		return drifts[i].Name < drifts[j].Name
+	})
+
+	return drifts
+}
+
+// LoadAssetBaseline reads the baseline snapshot from path, returning an
+// empty AssetSnapshot if no baseline has been checked in yet.
+func LoadAssetBaseline(path string) (AssetSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return AssetSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read asset baseline %s", path)
+	}
+
+	var snapshot AssetSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse asset baseline %s", path)
+	}
+	return snapshot, nil
+}
+
+// WriteAssetBaseline serializes snapshot as indented JSON to path, for
+// --update-gcp-baseline runs and for checking the baseline into source
+// control.
+func WriteAssetBaseline(path string, snapshot AssetSnapshot) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal asset baseline")
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write asset baseline to %s", path)
+	}
+	return nil
+}
+
+// FormatAssetDriftReport renders drifts as a human-readable report grouped
+// by resource kind, for test failure output and CI triage.
+func FormatAssetDriftReport(drifts []AssetDrift) string {
+	byKind := make(map[string][]AssetDrift)
+	for _, d := range drifts {
+		byKind[d.ResourceKind] = append(byKind[d.ResourceKind], d)
+	}
+
+	kinds := make([]string, 0, len(byKind))
+	for kind := range byKind {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var b strings.Builder
+	for _, kind := range kinds {
+		fmt.Fprintf(&b, "%s:\n", kind)
+		for _, d := range byKind[kind] {
+			fmt.Fprintf(&b, "  [%s] %s\n", strings.ToUpper(string(d.Kind)), d.Name)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"google.golang.org/api/storage/v1"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/backup"
+)
+
+// gcsBlobDatasetSeeder seeds a set of random objects into bucket/prefix via
+// the suite's GCS client, implementing backup.DatasetSeeder -- the GCP
+// counterpart to cross_provider_backup_test.go's blobDatasetSeeder.
+type gcsBlobDatasetSeeder struct {
+	suite  *GCPTestSuite
+	bucket string
+	prefix string
+	count  int
+	size   int
+}
+
+func (s *gcsBlobDatasetSeeder) Seed(ctx context.Context) ([]backup.Record, error) {
+	records := make([]backup.Record, 0, s.count)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < s.count; i++ {
+		payload := make([]byte, s.size)
+		if _, err := rng.Read(payload); err != nil {
+			return nil, errors.Wrap(err, "failed to generate synthetic blob payload")
+		}
+		key := fmt.Sprintf("%s/%d.bin", s.prefix, i)
+
+		call := s.suite.Storage.Objects.Insert(s.bucket, &storage.Object{Name: key}).Media(bytes.NewReader(payload))
+		if _, err := call.Context(ctx).Do(); err != nil {
+			return nil, errors.Wrapf(err, "failed to seed blob %s/%s", s.bucket, key)
+		}
+
+		records = append(records, backup.Record{
+			Key:      key,
+			Hash:     sha256.Sum256(payload),
+			SeededAt: time.Now(),
+		})
+	}
+	return records, nil
+}
+
+// gcsBlobComparator re-downloads each seeded object from bucket and
+// compares its content hash against the Record seeded by
+// gcsBlobDatasetSeeder, implementing backup.Comparator.
+type gcsBlobComparator struct {
+	suite  *GCPTestSuite
+	bucket string
+}
+
+func (c *gcsBlobComparator) Verify(ctx context.Context, seeded []backup.Record) ([]backup.Mismatch, error) {
+	var mismatches []backup.Mismatch
+	for _, record := range seeded {
+		resp, err := c.suite.Storage.Objects.Get(c.bucket, record.Key).Context(ctx).Download()
+		if err != nil {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: fmt.Sprintf("restored object missing or unreadable: %v", err)})
+			continue
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: fmt.Sprintf("failed to read restored object: %v", err)})
+			continue
+		}
+		if sha256.Sum256(data) != record.Hash {
+			mismatches = append(mismatches, backup.Mismatch{Key: record.Key, Reason: "content hash mismatch"})
+		}
+	}
+	return mismatches, nil
+}
+
+// TestDatabaseBackup seeds a canary row set into the Postgres endpoint
+// (typically a Cloud SQL instance's connection name DSN) and verifies it
+// reads back intact from suite.Config.BackupVerifyRestoreDSN (a PITR
+// clone or read replica) if configured, or from endpoint itself
+// otherwise.
+func (suite *GCPTestSuite) TestDatabaseBackup(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database backup")
+
+	restoreDSN := suite.Config.BackupVerifyRestoreDSN
+	if restoreDSN == "" {
+		restoreDSN = endpoint
+	}
+	table := "backup_restore_verification_" + sanitizeIdentifier(suite.TestID)
+
+	outcome := runBackupRestoreCheck(suite.Context, "gcp-database",
+		&pgRowSeeder{dsn: endpoint, table: table, count: pgRowSeederCount},
+		&pgRowComparator{dsn: restoreDSN, table: table})
+	return evaluateBackupOutcome(suite.Logger, outcome, time.Duration(suite.Config.BackupVerifyMaxRPOSeconds)*time.Second)
+}
+
+// TestStorageBackup uploads a canary object set into the endpoint GCS
+// bucket and verifies it reads back intact from
+// suite.Config.BackupVerifyReplicaBucket (a cross-region replica/backup
+// bucket) if configured, or from endpoint itself otherwise.
+func (suite *GCPTestSuite) TestStorageBackup(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing storage backup")
+
+	replicaBucket := suite.Config.BackupVerifyReplicaBucket
+	if replicaBucket == "" {
+		replicaBucket = endpoint
+	}
+	prefix := "backup-verify/" + suite.TestID
+
+	outcome := runBackupRestoreCheck(suite.Context, "gcp-storage",
+		&gcsBlobDatasetSeeder{suite: suite, bucket: endpoint, prefix: prefix, count: blobDatasetSeederCount, size: blobDatasetSeederSize},
+		&gcsBlobComparator{suite: suite, bucket: replicaBucket})
+	return evaluateBackupOutcome(suite.Logger, outcome, time.Duration(suite.Config.BackupVerifyMaxRPOSeconds)*time.Second)
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxParallel bounds how many goroutines ParallelForEach runs at once
+// when a GCPTestSuite isn't configured with its own SuiteConfig.MaxParallel.
+const defaultMaxParallel = 8
+
+// defaultServiceRateLimit is the QPS applied to a service that appears in a
+// ParallelForEach call but has no entry in SuiteConfig.RateLimits.
+const defaultServiceRateLimit rate.Limit = 20
+
+// SuiteConfig bounds how aggressively ParallelForEach fans nested per-resource
+// list loops (SQL Databases per instance, GKE NodePools per cluster, KMS
+// CryptoKeys per KeyRing, BigQuery Tables per Dataset, ...) out across
+// goroutines, since every GCP API enforces its own QPS quota.
+type SuiteConfig struct {
+	MaxParallel int
+	RateLimits  map[string]rate.Limit
+}
+
+// RateLimiter returns the shared rate.Limiter for service, creating one from
+// suite.Concurrency.RateLimits (or defaultServiceRateLimit) on first use and
+// caching it for subsequent calls.
+func (suite *GCPTestSuite) RateLimiter(service string) *rate.Limiter {
+	suite.limitersMu.Lock()
+	defer suite.limitersMu.Unlock()
+
+	if suite.limiters == nil {
+		suite.limiters = make(map[string]*rate.Limiter)
+	}
+	if limiter, ok := suite.limiters[service]; ok {
+		return limiter
+	}
+
+	limit := defaultServiceRateLimit
+	if configured, ok := suite.Concurrency.RateLimits[service]; ok {
+		limit = configured
+	}
+	limiter := rate.NewLimiter(limit, 1)
+	suite.limiters[service] = limiter
+	return limiter
+}
+
+// ParallelForEach runs fn over every item in items, bounded to
+// suite.Concurrency.MaxParallel concurrent goroutines and throttled by
+// service's rate.Limiter. fn must report failures via assert (not require),
+// since require.FailNow is documented as unsafe to call outside the test's
+// own goroutine; ParallelForEach itself only returns an error when fn does,
+// so a single item's assertion failure never aborts the rest of the batch.
+func ParallelForEach[T any](suite *GCPTestSuite, service string, items []T, fn func(item T) error) error {
+	limit := suite.Concurrency.MaxParallel
+	if limit <= 0 {
+		limit = defaultMaxParallel
+	}
+	limiter := suite.RateLimiter(service)
+
+	group, ctx := errgroup.WithContext(suite.Context)
+	group.SetLimit(limit)
+
+	for _, item := range items {
+		group.Go(func() error {
+			if err := limiter.Wait(ctx); err != nil {
+				return err
+			}
+			return fn(item)
+		})
+	}
+
+	return group.Wait()
+}
+
+// TestParallelForEachIsRaceFree drives ParallelForEach across many goroutines
+// mutating shared state only behind a mutex, so `go test -race` on this test
+// proves the fan-out itself introduces no data races independent of what fn
+// does.
+func TestParallelForEachIsRaceFree(t *testing.T) {
+	suite := &GCPTestSuite{Context: context.Background(), Concurrency: SuiteConfig{MaxParallel: 16}}
+
+	items := make([]int, 500)
+	for i := range items {
+		items[i] = i
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool, len(items))
+
+	err := ParallelForEach(suite, "race-test", items, func(item int) error {
+		mu.Lock()
+		seen[item] = true
+		mu.Unlock()
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, seen, len(items))
+}
+
+// BenchmarkParallelForEach measures ParallelForEach's fan-out overhead for a
+// no-op workload, isolating the errgroup/rate-limiter machinery from any
+// particular GCP API's latency.
+func BenchmarkParallelForEach(b *testing.B) {
+	suite := &GCPTestSuite{
+		Context:     context.Background(),
+		Concurrency: SuiteConfig{MaxParallel: defaultMaxParallel, RateLimits: map[string]rate.Limit{"bench": rate.Inf}},
+	}
+
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ParallelForEach(suite, "bench", items, func(item int) error {
+			return nil
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/billingbudgets/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultCostOveragePct is the default percentage a service's daily cost may
+// exceed its baseline by before AssertCostBaseline fails, when a baseline
+// entry doesn't override it.
+const defaultCostOveragePct = 20.0
+
+// defaultCostLookbackDays is how many days of billing export history
+// QueryDailyCostBySKU pulls when TestConfig.GCPCostLookbackDays is unset.
+const defaultCostLookbackDays = 30
+
+// costBaselinePath is the checked-in policy AssertCostBaseline compares the
+// BigQuery billing export against.
+const costBaselinePath = "testdata/cost_baseline.yaml"
+
+// requiredBudgetAlertThresholds are the alert percentages AssertBillingBudgets
+// requires at least one project budget to cover.
+var requiredBudgetAlertThresholds = []float64{0.5, 0.9, 1.0}
+
+// CostBaselineEntry is one service's expected daily spend, checked in
+// gcp_cost_test.go's testdata/cost_baseline.yaml.
+type CostBaselineEntry struct {
+	MaxDailyUSD float64 `yaml:"max_daily_usd"`
+	OveragePct  float64 `yaml:"overage_pct"`
+}
+
+// CostBaseline is the top-level shape of a cost_baseline.yaml file, keyed by
+// billing export service name (e.g. "Compute Engine").
+type CostBaseline struct {
+	Services map[string]CostBaselineEntry `yaml:"services"`
+}
+
+// LoadCostBaseline reads and parses a cost baseline YAML file.
+func LoadCostBaseline(path string) (*CostBaseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read cost baseline %s", path)
+	}
+
+	var baseline CostBaseline
+	if err := yaml.Unmarshal(data, &baseline); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse cost baseline %s", path)
+	}
+	return &baseline, nil
+}
+
+// GCPDailyCost is one service's spend for one calendar day, as pulled from
+// the BigQuery billing export.
+type GCPDailyCost struct {
+	Service string
+	Date    string
+	CostUSD float64
+}
+
+// GCPCostFinding flags a day where a service's spend exceeded its baseline
+// by more than its allowed overage percentage.
+type GCPCostFinding struct {
+	Service      string
+	Date         string
+	ActualUSD    float64
+	BaselineUSD  float64
+	ThresholdUSD float64
+}
+
+// QueryDailyCostBySKU queries table (a fully-qualified
+// `project.dataset.table` billing export table) for daily cost grouped by
+// service over the trailing lookbackDays.
+func (suite *GCPTestSuite) QueryDailyCostBySKU(table string, lookbackDays int) ([]GCPDailyCost, error) {
+	if lookbackDays <= 0 {
+		lookbackDays = defaultCostLookbackDays
+	}
+
+	query := fmt.Sprintf(`
+SELECT
+  service.description AS service,
+  CAST(DATE(usage_start_time) AS STRING) AS day,
+  SUM(cost) AS cost_usd
+FROM `+"`%s`"+`
+WHERE DATE(usage_start_time) >= DATE_SUB(CURRENT_DATE(), INTERVAL %d DAY)
+GROUP BY service, day
+ORDER BY day`, table, lookbackDays)
+
+	var resp *bigquery.QueryResponse
+	err := suite.DoWithRetry("bigquery.Jobs.Query", table, func() error {
+		var callErr error
+		resp, callErr = suite.BigQuery.Jobs.Query(suite.ProjectID, &bigquery.QueryRequest{
+			Query:        query,
+			UseLegacySql: false,
+		}).Context(suite.Context).Do()
+		return callErr
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query billing export table %s", table)
+	}
+	if resp == nil {
+		return nil, nil
+	}
+
+	costs := make([]GCPDailyCost, 0, len(resp.Rows))
+	for _, row := range resp.Rows {
+		if len(row.F) != 3 {
+			continue
+		}
+		service, _ := row.F[0].V.(string)
+		day, _ := row.F[1].V.(string)
+		cost := parseBigQueryFloat(row.F[2].V)
+		costs = append(costs, GCPDailyCost{Service: service, Date: day, CostUSD: cost})
+	}
+	return costs, nil
+}
+
+// parseBigQueryFloat converts a BigQuery REST API cell value (returned as a
+// JSON string even for numeric columns) into a float64.
+func parseBigQueryFloat(v interface{}) float64 {
+	s, ok := v.(string)
+	if !ok {
+		return 0
+	}
+	var f float64
+	if _, err := fmt.Sscanf(s, "%g", &f); err != nil {
+		return 0
+	}
+	return f
+}
+
+// AssertCostBaseline loads costBaselinePath, queries daily cost per service
+// from suite.Config.GCPBillingExportTable over the configured lookback
+// window, and fails t for every service/day whose cost exceeds its baseline
+// by more than its allowed overage percentage.
+func (suite *GCPTestSuite) AssertCostBaseline(t *testing.T) {
+	t.Helper()
+
+	if suite.Config.GCPBillingExportTable == "" {
+		suite.Logger.Info().Msg("No gcp_billing_export_table configured, skipping cost regression check")
+		return
+	}
+
+	baseline, err := LoadCostBaseline(costBaselinePath)
+	require.NoError(t, err)
+
+	costs, err := suite.QueryDailyCostBySKU(suite.Config.GCPBillingExportTable, suite.Config.GCPCostLookbackDays)
+	require.NoError(t, err)
+
+	var findings []GCPCostFinding
+	for _, cost := range costs {
+		entry, ok := baseline.Services[cost.Service]
+		if !ok {
+			continue
+		}
+		overagePct := entry.OveragePct
+		if overagePct == 0 {
+			overagePct = defaultCostOveragePct
+		}
+		threshold := entry.MaxDailyUSD * (1 + overagePct/100)
+		if cost.CostUSD > threshold {
+			findings = append(findings, GCPCostFinding{
+				Service:      cost.Service,
+				Date:         cost.Date,
+				ActualUSD:    cost.CostUSD,
+				BaselineUSD:  entry.MaxDailyUSD,
+				ThresholdUSD: threshold,
+			})
+		}
+	}
+
+	for _, finding := range findings {
+		suite.Logger.Error().
+			Str("service", finding.Service).
+			Str("date", finding.Date).
+			Float64("actual_usd", finding.ActualUSD).
+			Float64("baseline_usd", finding.BaselineUSD).
+			Float64("threshold_usd", finding.ThresholdUSD).
+			Msg("Cost regression detected")
+	}
+	assert.Emptyf(t, findings, "%d service/day(s) exceeded their cost baseline", len(findings))
+}
+
+// AssertBillingBudgets fails t unless the project's billing account has at
+// least one budget configured with alert thresholds covering 50%, 90% and
+// 100% of its amount.
+func (suite *GCPTestSuite) AssertBillingBudgets(t *testing.T) {
+	t.Helper()
+
+	if suite.Config.GCPBillingAccountID == "" {
+		suite.Logger.Info().Msg("No gcp_billing_account_id configured, skipping budget assertions")
+		return
+	}
+
+	parent := "billingAccounts/" + suite.Config.GCPBillingAccountID
+	var resp *billingbudgets.GoogleCloudBillingBudgetsV1ListBudgetsResponse
+	err := suite.DoWithRetry("billingbudgets.Budgets.List", parent, func() error {
+		var callErr error
+		resp, callErr = suite.BillingBudgets.BillingAccounts.Budgets.List(parent).Context(suite.Context).Do()
+		return callErr
+	})
+	require.NoError(t, err)
+
+	var budgets []*billingbudgets.GoogleCloudBillingBudgetsV1Budget
+	if resp != nil {
+		budgets = resp.Budgets
+	}
+	if !assert.NotEmptyf(t, budgets, "billing account %s should have at least one budget configured", suite.Config.GCPBillingAccountID) {
+		return
+	}
+
+	// A budget "with alert thresholds (50/90/100%)" must cover all three on
+	// its own, not just contribute one threshold each to the union.
+	hasFullyConfiguredBudget := false
+	for _, budget := range budgets {
+		configured := make(map[float64]bool, len(budget.ThresholdRules))
+		for _, rule := range budget.ThresholdRules {
+			configured[rule.ThresholdPercent] = true
+		}
+
+		coversAll := true
+		for _, required := range requiredBudgetAlertThresholds {
+			if !configured[required] {
+				coversAll = false
+				break
+			}
+		}
+		if coversAll {
+			hasFullyConfiguredBudget = true
+			break
+		}
+	}
+
+	assert.Truef(t, hasFullyConfiguredBudget,
+		"billing account %s should have a budget with alert thresholds at 50%%, 90%% and 100%%", suite.Config.GCPBillingAccountID)
+}
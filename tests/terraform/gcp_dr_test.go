@@ -0,0 +1,281 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/sql/v1"
+	"google.golang.org/api/storage/v1"
+	"testing"
+)
+
+// defaultReplicaLagSLO is the maximum acceptable Cloud SQL replica lag, in
+// seconds, when TestConfig.GCPReplicaLagSLOSeconds is unset.
+const defaultReplicaLagSLO = 300
+
+// defaultBackupRetentionDaysSLO is the minimum acceptable backup retention
+// window, in days, when TestConfig.GCPBackupRetentionDaysSLO is unset.
+const defaultBackupRetentionDaysSLO = 7
+
+// multiRegionStorageClasses are the GCS storage classes AssertBucketReplication
+// treats as already geo-redundant; a bucket outside this set must instead
+// have turbo replication (RPO_ASYNC_TURBO) explicitly enabled.
+var multiRegionStorageClasses = map[string]bool{
+	"MULTI_REGIONAL": true,
+	"STANDARD":       true, // only geo-redundant when the bucket's Location is a dual/multi-region
+}
+
+// ReplicaLagFinding records a Cloud SQL read replica whose lag exceeded the
+// configured SLO.
+type ReplicaLagFinding struct {
+	PrimaryInstance string  `json:"primary_instance"`
+	ReplicaInstance string  `json:"replica_instance"`
+	ReplicaRegion   string  `json:"replica_region"`
+	LagSeconds      float64 `json:"lag_seconds"`
+	SLOSeconds      float64 `json:"slo_seconds"`
+}
+
+// AssertReplicaLag lists every read replica of instance across regions and
+// queries each replica's own replication delay (see queryReplicaLagSeconds)
+// through CloudSQLProber's connector to assert replication lag stays under
+// sloSeconds. Instances without read replicas are skipped.
+func (suite *GCPTestSuite) AssertReplicaLag(t *testing.T, instance *sql.DatabaseInstance, sloSeconds float64) []ReplicaLagFinding {
+	t.Helper()
+	if sloSeconds <= 0 {
+		sloSeconds = defaultReplicaLagSLO
+	}
+
+	var findings []ReplicaLagFinding
+	for _, replicaName := range instance.ReplicaNames {
+		replica, err := suite.SQL.Instances.Get(suite.ProjectID, replicaName).Context(suite.Context).Do()
+		if !assert.NoErrorf(t, err, "failed to describe replica %s of %s", replicaName, instance.Name) {
+			continue
+		}
+
+		lag, err := suite.queryReplicaLagSeconds(replica)
+		if !assert.NoErrorf(t, err, "failed to query replication lag on %s", replicaName) {
+			continue
+		}
+
+		suite.Logger.Info().
+			Str("primary", instance.Name).
+			Str("replica", replicaName).
+			Str("region", replica.Region).
+			Float64("lag_seconds", lag).
+			Msg("Checked replica lag")
+
+		if lag > sloSeconds {
+			findings = append(findings, ReplicaLagFinding{
+				PrimaryInstance: instance.Name,
+				ReplicaInstance: replicaName,
+				ReplicaRegion:   replica.Region,
+				LagSeconds:      lag,
+				SLOSeconds:      sloSeconds,
+			})
+		}
+	}
+	return findings
+}
+
+// queryReplicaLagSeconds connects to replica via the Cloud SQL Go connector
+// and reads its replication delay: pg_last_xact_replay_timestamp() age for
+// Postgres, seconds since the latest LAST_HEARTBEAT_TIMESTAMP in
+// performance_schema.replication_connection_status for MySQL.
+func (suite *GCPTestSuite) queryReplicaLagSeconds(replica *sql.DatabaseInstance) (float64, error) {
+	prober := CloudSQLProber{
+		InstanceConnectionName: replica.ConnectionName,
+		Engine:                 suite.Config.GCPDBEngine,
+		User:                   suite.Config.GCPDBUser,
+		Password:               suite.Config.GCPDBPassword,
+		Database:               suite.Config.GCPDBName,
+		LatencyBudget:          10 * time.Second,
+	}
+
+	query := "SELECT COALESCE(EXTRACT(EPOCH FROM (now() - pg_last_xact_replay_timestamp())), 0)"
+	if prober.Engine == "mysql" {
+		query = "SELECT COALESCE(TIMESTAMPDIFF(SECOND, MAX(LAST_HEARTBEAT_TIMESTAMP), UTC_TIMESTAMP()), 0) FROM performance_schema.replication_connection_status"
+	}
+
+	var lag float64
+	err := prober.queryScalar(suite.Context, query, &lag)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to query replication lag on %s", replica.ConnectionName)
+	}
+	return lag, nil
+}
+
+// AssertPITRAndBackupRetention fails t unless instance has point-in-time
+// recovery enabled and a backup retention window of at least
+// minRetentionDays.
+func (suite *GCPTestSuite) AssertPITRAndBackupRetention(t *testing.T, instance *sql.DatabaseInstance, minRetentionDays int) {
+	t.Helper()
+	if minRetentionDays <= 0 {
+		minRetentionDays = defaultBackupRetentionDaysSLO
+	}
+
+	backupConfig := instance.Settings.BackupConfiguration
+	if !assert.NotNilf(t, backupConfig, "instance %s has no backup configuration", instance.Name) {
+		return
+	}
+
+	assert.Truef(t, backupConfig.Enabled, "instance %s should have backups enabled", instance.Name)
+	assert.Truef(t, backupConfig.PointInTimeRecoveryEnabled,
+		"instance %s should have point-in-time recovery enabled", instance.Name)
+
+	retainedBackups := int64(defaultBackupRetentionDaysSLO)
+	if backupConfig.BackupRetentionSettings != nil && backupConfig.BackupRetentionSettings.RetainedBackups > 0 {
+		retainedBackups = backupConfig.BackupRetentionSettings.RetainedBackups
+	}
+	assert.GreaterOrEqualf(t, retainedBackups, int64(minRetentionDays),
+		"instance %s retains %d backups, want at least %d", instance.Name, retainedBackups, minRetentionDays)
+}
+
+// AssertBucketReplication fails t unless bucket is dual/multi-region or has
+// turbo replication (RPO_ASYNC_TURBO) enabled.
+func (suite *GCPTestSuite) AssertBucketReplication(t *testing.T, bucket *storage.Bucket) {
+	t.Helper()
+
+	if bucket.Rpo == "ASYNC_TURBO" {
+		return
+	}
+	if !multiRegionStorageClasses[bucket.StorageClass] {
+		assert.Failf(t, "bucket is not geo-redundant",
+			"bucket %s (class %s, rpo %s) should use a dual/multi-region location or turbo replication", bucket.Name, bucket.StorageClass, bucket.Rpo)
+		return
+	}
+	assert.Containsf(t, []string{"multi-region", "dual-region"}, bucket.LocationType,
+		"bucket %s is class %s but location %s (type %s) is not a dual/multi-region", bucket.Name, bucket.StorageClass, bucket.Location, bucket.LocationType)
+}
+
+// PromoteReplicaAndVerify implements the opt-in --dr-failover mode: it
+// promotes replicaName to a standalone primary and re-runs the shared health
+// check helpers against the promoted endpoint, then demotes/deletes the
+// promotion so the sandbox project is left clean. Callers must only invoke
+// this against a sandbox project's replica, never production.
+func (suite *GCPTestSuite) PromoteReplicaAndVerify(replicaName, endpoint string) (err error) {
+	suite.Logger.Warn().Str("replica", replicaName).Msg("Promoting Cloud SQL replica for DR failover drill")
+
+	var op *sql.Operation
+	promoteErr := suite.DoWithRetry("sql.Instances.PromoteReplica", replicaName, func() error {
+		var callErr error
+		op, callErr = suite.SQL.Instances.PromoteReplica(suite.ProjectID, replicaName).Context(suite.Context).Do()
+		return callErr
+	})
+	if promoteErr != nil {
+		return errors.Wrapf(promoteErr, "failed to promote replica %s", replicaName)
+	}
+	if err := suite.WaitForOperation(op, OperationScopeGlobal, 15*time.Minute); err != nil {
+		return errors.Wrapf(err, "promotion of %s did not complete", replicaName)
+	}
+
+	defer func() {
+		suite.Logger.Warn().Str("replica", replicaName).Msg("Tearing down promoted DR failover replica")
+		if _, delErr := suite.SQL.Instances.Delete(suite.ProjectID, replicaName).Context(suite.Context).Do(); delErr != nil {
+			err = errors.Wrapf(delErr, "failed to tear down promoted replica %s (manual cleanup required)", replicaName)
+		}
+	}()
+
+	if healthErr := suite.TestDatabaseHealth(endpoint); healthErr != nil {
+		return errors.Wrapf(healthErr, "promoted replica %s failed health check on %s", replicaName, endpoint)
+	}
+	return nil
+}
+
+// JUnitTestSuite and JUnitTestCase are the subset of the JUnit XML schema CI
+// dashboards (Jenkins, GitLab, GitHub Actions) understand.
+type JUnitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      float64         `xml:"time,attr,omitempty"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase is a single DR assertion, reported as a failed testcase when
+// Failure is non-nil.
+type JUnitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr,omitempty"`
+	Failure *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure is the message body of a failed JUnitTestCase.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteDRJUnitReport writes findings (replica lag findings) as a JUnit XML
+// report so CI dashboards can track DR posture over time. One testcase is
+// emitted per finding, plus a synthetic passing testcase when there are none.
+func WriteDRJUnitReport(path string, findings []ReplicaLagFinding) error {
+	suite := JUnitTestSuite{Name: "DisasterRecovery/ReplicaLag"}
+	for _, f := range findings {
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{
+			Name: fmt.Sprintf("%s/%s", f.PrimaryInstance, f.ReplicaInstance),
+			Failure: &JUnitFailure{
+				Message: "replica lag exceeded SLO",
+				Text:    fmt.Sprintf("lag=%.1fs slo=%.1fs region=%s", f.LagSeconds, f.SLOSeconds, f.ReplicaRegion),
+			},
+		})
+	}
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{Name: "all-replicas-within-slo"})
+	}
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+	}
+
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal DR JUnit report")
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), data...), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write DR JUnit report to %s", path)
+	}
+	return nil
+}
+
+// AssertDisasterRecovery runs the full DR validation suite: replica lag,
+// PITR/backup retention, and bucket replication, then writes a JUnit report
+// to suite.Config.DRPlanPath (skipped if unset). When suite.Config.GCPDRFailover
+// is set, it also promotes a replica in a sandbox project as a failover drill.
+func (suite *GCPTestSuite) AssertDisasterRecovery(t *testing.T) {
+	t.Helper()
+
+	instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
+	require.NoError(t, err)
+
+	var findings []ReplicaLagFinding
+	for _, instance := range instances.Items {
+		t.Run(instance.Name, func(t *testing.T) {
+			findings = append(findings, suite.AssertReplicaLag(t, instance, float64(suite.Config.GCPReplicaLagSLOSeconds))...)
+			suite.AssertPITRAndBackupRetention(t, instance, suite.Config.GCPBackupRetentionDaysSLO)
+
+			if suite.Config.GCPDRFailover && len(instance.ReplicaNames) > 0 {
+				endpoint := suite.Config.GCPDRFailoverEndpoint
+				assert.NoErrorf(t, suite.PromoteReplicaAndVerify(instance.ReplicaNames[0], endpoint),
+					"DR failover drill for %s", instance.Name)
+			}
+		})
+	}
+
+	buckets, err := suite.ListAllBuckets()
+	require.NoError(t, err)
+	for _, bucket := range buckets {
+		suite.AssertBucketReplication(t, bucket)
+	}
+
+	if suite.Config.DRPlanPath != "" {
+		assert.NoError(t, WriteDRJUnitReport(suite.Config.DRPlanPath, findings), "failed to write DR JUnit report")
+	}
+}
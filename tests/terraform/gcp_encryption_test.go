@@ -0,0 +1,159 @@
+package test
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/cloudkms/v1"
+)
+
+// defaultMaxKeyRotationPeriod is the longest rotation period
+// AssertKMSKeyPolicy accepts for a CMEK key, per policy.
+const defaultMaxKeyRotationPeriod = 90 * 24 * time.Hour
+
+// AssertKMSKeyPolicy fetches keyName (a fully-qualified
+// projects/*/locations/*/keyRings/*/cryptoKeys/* resource) and returns a
+// violation message unless it exists, has purpose ENCRYPT_DECRYPT, and
+// rotates within maxRotation.
+func (suite *GCPTestSuite) AssertKMSKeyPolicy(keyName string, maxRotation time.Duration) []string {
+	if maxRotation <= 0 {
+		maxRotation = defaultMaxKeyRotationPeriod
+	}
+
+	var key *cloudkms.CryptoKey
+	err := suite.DoWithRetry("cloudkms.CryptoKeys.Get", keyName, func() error {
+		var callErr error
+		key, callErr = suite.CloudKMS.Projects.Locations.KeyRings.CryptoKeys.Get(keyName).Context(suite.Context).Do()
+		return callErr
+	})
+	if err != nil {
+		return []string{"referenced KMS key " + keyName + " does not exist or is not accessible: " + err.Error()}
+	}
+
+	var violations []string
+	if key.Purpose != "ENCRYPT_DECRYPT" {
+		violations = append(violations, "KMS key "+keyName+" should have purpose ENCRYPT_DECRYPT, has "+key.Purpose)
+	}
+
+	rotation, err := parseKMSDuration(key.RotationPeriod)
+	if err != nil {
+		violations = append(violations, "KMS key "+keyName+" has no valid rotation policy: "+err.Error())
+	} else if rotation > maxRotation {
+		violations = append(violations, "KMS key "+keyName+" rotates every "+rotation.String()+
+			", exceeding the policy maximum of "+maxRotation.String())
+	}
+	return violations
+}
+
+// parseKMSDuration parses a Cloud KMS API duration string ("7776000s") into
+// a time.Duration.
+func parseKMSDuration(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, errors.New("no rotation period configured")
+	}
+	seconds, ok := strings.CutSuffix(raw, "s")
+	if !ok {
+		return 0, errors.Errorf("unrecognized duration format %q", raw)
+	}
+	n, err := strconv.ParseFloat(seconds, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unrecognized duration format %q", raw)
+	}
+	return time.Duration(n * float64(time.Second)), nil
+}
+
+// AssertKMSPermissions confirms the runner's own credentials can Encrypt
+// with keyName, catching a broken IAM binding (e.g. missing
+// roles/cloudkms.cryptoKeyEncrypterDecrypter) before production traffic
+// relies on it.
+func (suite *GCPTestSuite) AssertKMSPermissions(keyName string) error {
+	err := suite.DoWithRetry("cloudkms.CryptoKeys.Encrypt", keyName, func() error {
+		_, callErr := suite.CloudKMS.Projects.Locations.KeyRings.CryptoKeys.Encrypt(keyName, &cloudkms.EncryptRequest{
+			Plaintext: base64.StdEncoding.EncodeToString([]byte("cmek-permission-probe")),
+		}).Context(suite.Context).Do()
+		return callErr
+	})
+	if err != nil {
+		return errors.Wrapf(err, "runner cannot Encrypt with %s -- check IAM bindings before this key serves production traffic", keyName)
+	}
+	return nil
+}
+
+// EvaluateEncryption walks Cloud SQL instances, GCS buckets, Compute disks
+// and GKE clusters and returns one violation per resource that isn't using
+// a customer-managed key, plus one per referenced key whose rotation
+// policy or IAM permissions don't meet policy.
+func (suite *GCPTestSuite) EvaluateEncryption() ([]string, error) {
+	var violations []string
+	keysToCheck := make(map[string]bool)
+
+	instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list SQL instances")
+	}
+	for _, instance := range instances.Items {
+		cfg := instance.DiskEncryptionConfiguration
+		if cfg == nil || cfg.KmsKeyName == "" {
+			violations = append(violations, "SQL instance "+instance.Name+" must set diskEncryptionConfiguration.kmsKeyName")
+			continue
+		}
+		keysToCheck[cfg.KmsKeyName] = true
+	}
+
+	buckets, err := suite.ListAllBuckets()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list buckets")
+	}
+	for _, bucket := range buckets {
+		if bucket.Encryption == nil || bucket.Encryption.DefaultKmsKeyName == "" {
+			violations = append(violations, "bucket "+bucket.Name+" must set encryption.defaultKmsKeyName")
+			continue
+		}
+		keysToCheck[bucket.Encryption.DefaultKmsKeyName] = true
+	}
+
+	for _, zone := range suite.discoverZones() {
+		disks, err := suite.ListAllDisks(zone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list disks in %s", zone)
+		}
+		for _, disk := range disks {
+			if disk.DiskEncryptionKey == nil || disk.DiskEncryptionKey.KmsKeyName == "" {
+				violations = append(violations, "disk "+disk.Name+" must reference a KMS key rather than Google-managed encryption")
+				continue
+			}
+			keysToCheck[disk.DiskEncryptionKey.KmsKeyName] = true
+		}
+	}
+
+	for _, zone := range suite.discoverZones() {
+		resp, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, zone).Context(suite.Context).Do()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list GKE clusters in %s", zone)
+		}
+		if resp == nil {
+			continue
+		}
+		for _, cluster := range resp.Clusters {
+			if cluster.DatabaseEncryption == nil || cluster.DatabaseEncryption.State != "ENCRYPTED" {
+				violations = append(violations, "cluster "+cluster.Name+" must set databaseEncryption.state=ENCRYPTED")
+				continue
+			}
+			if cluster.DatabaseEncryption.KeyName != "" {
+				keysToCheck[cluster.DatabaseEncryption.KeyName] = true
+			}
+		}
+	}
+
+	for keyName := range keysToCheck {
+		violations = append(violations, suite.AssertKMSKeyPolicy(keyName, defaultMaxKeyRotationPeriod)...)
+		if err := suite.AssertKMSPermissions(keyName); err != nil {
+			violations = append(violations, err.Error())
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/compute/v1"
+)
+
+// FirewallRuleExpectation is one expected allow/deny tuple that a live
+// compute.Firewall rule can be cross-checked against.
+type FirewallRuleExpectation struct {
+	Direction    string   `yaml:"direction"`
+	Priority     int64    `yaml:"priority"`
+	SourceRanges []string `yaml:"source_ranges"`
+	TargetTags   []string `yaml:"target_tags"`
+	Protocol     string   `yaml:"protocol"`
+	Ports        []string `yaml:"ports"`
+}
+
+// FirewallExpectations is the policy TestVPC's Firewall Rules subtest
+// validates live firewalls against, turning the existence-only loop into an
+// actual compliance check.
+type FirewallExpectations struct {
+	Allow                     []FirewallRuleExpectation `yaml:"allow"`
+	Deny                      []FirewallRuleExpectation `yaml:"deny"`
+	SensitiveIngressWhitelist []string                  `yaml:"sensitive_ingress_whitelist"`
+}
+
+// sensitiveFirewallPorts are the ports that must never be open to the
+// world on an INGRESS rule unless explicitly whitelisted.
+var sensitiveFirewallPorts = []string{"22", "3389", "3306", "5432", "6379", "27017"}
+
+// privateGoogleAccessRange is the private.googleapis.com /30 that an
+// egress rule must allow for every subnet with PrivateIpGoogleAccess
+// enabled.
+const privateGoogleAccessRange = "199.36.153.8/30"
+
+// assertNoOpenSensitivePorts fails t for any INGRESS firewall rule that
+// allows 0.0.0.0/0 on a sensitive port, unless the rule's name appears in
+// whitelist.
+func assertNoOpenSensitivePorts(t *testing.T, firewalls []*compute.Firewall, whitelist []string) {
+	t.Helper()
+
+	whitelisted := make(map[string]bool, len(whitelist))
+	for _, name := range whitelist {
+		whitelisted[name] = true
+	}
+
+	for _, firewall := range firewalls {
+		if firewall.Direction != "INGRESS" || whitelisted[firewall.Name] {
+			continue
+		}
+		if !containsSourceRange(firewall.SourceRanges, "0.0.0.0/0") {
+			continue
+		}
+		for _, allowed := range firewall.Allowed {
+			for _, port := range allowed.Ports {
+				assert.False(t, containsSensitivePort(port),
+					"firewall %s allows sensitive port %s from 0.0.0.0/0 and is not whitelisted", firewall.Name, port)
+			}
+		}
+	}
+}
+
+// assertNoFirewallPriorityCollisions fails t if two firewall rules on the
+// same network and direction share a priority, since Cloud Router/VPC
+// firewall evaluation order becomes ambiguous in that case.
+func assertNoFirewallPriorityCollisions(t *testing.T, firewalls []*compute.Firewall) {
+	t.Helper()
+
+	type key struct {
+		network   string
+		direction string
+		priority  int64
+	}
+	seen := make(map[key]string)
+
+	for _, firewall := range firewalls {
+		k := key{network: firewall.Network, direction: firewall.Direction, priority: firewall.Priority}
+		if existing, ok := seen[k]; ok {
+			assert.Fail(t, "firewall priority collision",
+				"firewalls %s and %s share priority %d on network %s direction %s",
+				existing, firewall.Name, firewall.Priority, firewall.Network, firewall.Direction)
+			continue
+		}
+		seen[k] = firewall.Name
+	}
+}
+
+// assertPrivateGoogleAccessEgress fails t for any subnet with
+// PrivateIpGoogleAccess enabled that has no EGRESS firewall rule allowing
+// traffic to privateGoogleAccessRange.
+func assertPrivateGoogleAccessEgress(t *testing.T, subnets []*compute.Subnetwork, firewalls []*compute.Firewall) {
+	t.Helper()
+
+	hasPrivateAccessEgress := false
+	for _, firewall := range firewalls {
+		if firewall.Direction != "EGRESS" {
+			continue
+		}
+		if containsDestinationRange(firewall.DestinationRanges, privateGoogleAccessRange) {
+			hasPrivateAccessEgress = true
+			break
+		}
+	}
+
+	for _, subnet := range subnets {
+		if !subnet.PrivateIpGoogleAccess {
+			continue
+		}
+		assert.True(t, hasPrivateAccessEgress,
+			"subnet %s has PrivateIpGoogleAccess enabled but no egress rule allows %s", subnet.Name, privateGoogleAccessRange)
+	}
+}
+
+func containsSensitivePort(port string) bool {
+	for _, sensitive := range sensitiveFirewallPorts {
+		if port == sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSourceRange(ranges []string, target string) bool {
+	for _, r := range ranges {
+		if r == target {
+			return true
+		}
+	}
+	return false
+}
+
+func containsDestinationRange(ranges []string, target string) bool {
+	for _, r := range ranges {
+		if r == target {
+			return true
+		}
+	}
+	return false
+}
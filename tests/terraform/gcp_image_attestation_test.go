@@ -0,0 +1,322 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// expectedImagesPath is the checked-in policy the Image Attestation subtests
+// in TestCompute and TestGKE pin running VM and GKE node images against.
+const expectedImagesPath = "testdata/expected_images.yaml"
+
+// latestVersionSentinel, used as an ImageAllowEntry's MinVersion, resolves
+// the allowed image at test time via Compute.Images.GetFromFamily instead of
+// comparing against a fixed version string.
+const latestVersionSentinel = "latest"
+
+// ImageAllowEntry is one allowed GCE boot image for a project: either a
+// pinned self-link, or a family plus minimum version (or "latest", resolved
+// via Compute.Images.GetFromFamily).
+type ImageAllowEntry struct {
+	SelfLink     string `yaml:"self_link"`
+	Family       string `yaml:"family"`
+	ImageProject string `yaml:"image_project"`
+	MinVersion   string `yaml:"min_version"`
+}
+
+// NodeImageAllowEntry is one allowed GKE node pool image type plus minimum
+// node version.
+type NodeImageAllowEntry struct {
+	ImageType  string `yaml:"image_type"`
+	MinVersion string `yaml:"min_version"`
+}
+
+// ProjectImagePolicy is the set of allowed VM and GKE node images for one
+// GCP project.
+type ProjectImagePolicy struct {
+	AllowedImages     []ImageAllowEntry     `yaml:"allowed_images"`
+	AllowedNodeImages []NodeImageAllowEntry `yaml:"allowed_node_images"`
+}
+
+// ExpectedImages is the top-level shape of an expected_images.yaml file,
+// keyed by project ID, mirroring the Constellation config's `measurements`
+// map of expected image versions per cloud.
+type ExpectedImages struct {
+	Projects map[string]ProjectImagePolicy `yaml:"projects"`
+}
+
+// LoadExpectedImages reads and parses an expected image attestation YAML
+// file.
+func LoadExpectedImages(path string) (*ExpectedImages, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read expected images %s", path)
+	}
+
+	var expected ExpectedImages
+	if err := yaml.Unmarshal(data, &expected); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse expected images %s", path)
+	}
+	return &expected, nil
+}
+
+// ImageAttestationFinding is one drifted VM instance or GKE node pool whose
+// boot image isn't on the allowlist or falls below the minimum version.
+type ImageAttestationFinding struct {
+	Kind     string
+	Resource string
+	Expected string
+	Actual   string
+}
+
+// imageVersionSuffixPattern matches the trailing date-version suffix GCE
+// public image names use, e.g. "debian-12-bookworm-v20240110" -> "20240110".
+var imageVersionSuffixPattern = regexp.MustCompile(`v(\d{8,})$`)
+
+// imageVersionSuffix extracts name's trailing date-version suffix for
+// numeric "at least" comparisons.
+func imageVersionSuffix(name string) (int64, bool) {
+	match := imageVersionSuffixPattern.FindStringSubmatch(name)
+	if match == nil {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
+
+// meetsMinVersion reports whether actual is at least minVersion, comparing
+// their date-version suffixes numerically when both have one, and falling
+// back to exact equality otherwise.
+func meetsMinVersion(actual, minVersion string) bool {
+	if minVersion == "" {
+		return true
+	}
+	actualVersion, actualOK := imageVersionSuffix(actual)
+	minVersionValue, minOK := imageVersionSuffix(minVersion)
+	if actualOK && minOK {
+		return actualVersion >= minVersionValue
+	}
+	return actual == minVersion
+}
+
+// bootImageSource returns instance's boot disk source, preferring the disk
+// flagged Boot and falling back to Disks[0].
+func bootImageSource(instance *compute.Instance) string {
+	for _, disk := range instance.Disks {
+		if disk.Boot {
+			return disk.Source
+		}
+	}
+	if len(instance.Disks) > 0 {
+		return instance.Disks[0].Source
+	}
+	return ""
+}
+
+// resolveFamilyImage fetches the latest image in family for imageProject
+// (defaulting to suite.ProjectID when unset) via Compute.Images.GetFromFamily.
+func (suite *GCPTestSuite) resolveFamilyImage(imageProject, family string) (*compute.Image, error) {
+	if imageProject == "" {
+		imageProject = suite.ProjectID
+	}
+
+	var image *compute.Image
+	err := suite.DoWithRetry("compute.Images.GetFromFamily", fmt.Sprintf("projects/%s/global/images/family/%s", imageProject, family), func() error {
+		var callErr error
+		image, callErr = suite.Compute.Images.GetFromFamily(imageProject, family).Context(suite.Context).Do()
+		return callErr
+	})
+	return image, err
+}
+
+// matchImageEntry reports whether source (a boot disk's Source URL) matches
+// entry, resolving entry.Family's latest image via resolveFamilyImage when
+// entry.MinVersion is the "latest" sentinel.
+func (suite *GCPTestSuite) matchImageEntry(source string, entry ImageAllowEntry) (bool, error) {
+	if entry.SelfLink != "" {
+		return strings.Contains(source, entry.SelfLink), nil
+	}
+	if entry.Family == "" {
+		return false, nil
+	}
+
+	if entry.MinVersion == latestVersionSentinel {
+		latest, err := suite.resolveFamilyImage(entry.ImageProject, entry.Family)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to resolve latest image for family %s", entry.Family)
+		}
+		return strings.Contains(source, latest.Name), nil
+	}
+
+	if !strings.Contains(source, entry.Family) {
+		return false, nil
+	}
+	return meetsMinVersion(source, entry.MinVersion), nil
+}
+
+// AssertInstanceImageAttestation loads expectedImagesPath and fails t with a
+// diff of every running Instance whose boot image isn't on the project's
+// allowlist or is below the minimum version.
+func (suite *GCPTestSuite) AssertInstanceImageAttestation(t *testing.T) {
+	t.Helper()
+
+	expected, err := LoadExpectedImages(expectedImagesPath)
+	require.NoError(t, err)
+
+	policy, ok := expected.Projects[suite.ProjectID]
+	if !ok || len(policy.AllowedImages) == 0 {
+		suite.Logger.Info().Str("project", suite.ProjectID).
+			Msg("No image attestation policy configured for project, skipping")
+		return
+	}
+
+	var findings []ImageAttestationFinding
+	for _, zone := range suite.ResolveZones(t) {
+		instances, err := suite.ListAllInstances(zone)
+		require.NoError(t, err)
+
+		for _, instance := range instances {
+			source := bootImageSource(instance)
+			if source == "" {
+				continue
+			}
+
+			allowed := false
+			for _, entry := range policy.AllowedImages {
+				matched, err := suite.matchImageEntry(source, entry)
+				require.NoError(t, err)
+				if matched {
+					allowed = true
+					break
+				}
+			}
+
+			if !allowed {
+				findings = append(findings, ImageAttestationFinding{
+					Kind:     "instance",
+					Resource: instance.Name,
+					Expected: describeAllowedImages(policy.AllowedImages),
+					Actual:   source,
+				})
+			}
+		}
+	}
+
+	assert.Empty(t, findings, "VM instances drifted from the allowed boot images:\n%s", formatImageAttestationFindings(findings))
+}
+
+// AssertNodePoolImageAttestation loads expectedImagesPath and fails t with a
+// diff of every GKE node pool whose ImageType/Version isn't on the
+// project's allowlist or is below the minimum version.
+func (suite *GCPTestSuite) AssertNodePoolImageAttestation(t *testing.T) {
+	t.Helper()
+
+	expected, err := LoadExpectedImages(expectedImagesPath)
+	require.NoError(t, err)
+
+	policy, ok := expected.Projects[suite.ProjectID]
+	if !ok || len(policy.AllowedNodeImages) == 0 {
+		suite.Logger.Info().Str("project", suite.ProjectID).
+			Msg("No node image attestation policy configured for project, skipping")
+		return
+	}
+
+	checkNodePools := func(cluster *container.Cluster, nodePools *container.ListNodePoolsResponse) []ImageAttestationFinding {
+		var findings []ImageAttestationFinding
+		for _, nodePool := range nodePools.NodePools {
+			if nodePool.Config == nil {
+				continue
+			}
+
+			allowed := false
+			for _, entry := range policy.AllowedNodeImages {
+				if nodePool.Config.ImageType != entry.ImageType {
+					continue
+				}
+				if meetsMinVersion(nodePool.Version, entry.MinVersion) {
+					allowed = true
+					break
+				}
+			}
+
+			if !allowed {
+				findings = append(findings, ImageAttestationFinding{
+					Kind:     "node_pool",
+					Resource: fmt.Sprintf("%s/%s", cluster.Name, nodePool.Name),
+					Expected: describeAllowedNodeImages(policy.AllowedNodeImages),
+					Actual:   fmt.Sprintf("image_type=%s version=%s", nodePool.Config.ImageType, nodePool.Version),
+				})
+			}
+		}
+		return findings
+	}
+
+	var findings []ImageAttestationFinding
+	for _, zone := range suite.ResolveZones(t) {
+		clusters, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, zone).Context(suite.Context).Do()
+		require.NoError(t, err)
+
+		for _, cluster := range clusters.Clusters {
+			nodePools, err := suite.Container.Projects.Zones.Clusters.NodePools.List(suite.ProjectID, zone, cluster.Name).Context(suite.Context).Do()
+			require.NoError(t, err)
+			findings = append(findings, checkNodePools(cluster, nodePools)...)
+		}
+	}
+
+	for _, region := range suite.ResolveRegions(t) {
+		parent := fmt.Sprintf("projects/%s/locations/%s", suite.ProjectID, region)
+		clusters, err := suite.Container.Projects.Locations.Clusters.List(parent).Context(suite.Context).Do()
+		require.NoError(t, err)
+
+		for _, cluster := range clusters.Clusters {
+			clusterName := fmt.Sprintf("%s/clusters/%s", parent, cluster.Name)
+			nodePools, err := suite.Container.Projects.Locations.Clusters.NodePools.List(clusterName).Context(suite.Context).Do()
+			require.NoError(t, err)
+			findings = append(findings, checkNodePools(cluster, nodePools)...)
+		}
+	}
+
+	assert.Empty(t, findings, "GKE node pools drifted from the allowed node images:\n%s", formatImageAttestationFindings(findings))
+}
+
+func describeAllowedImages(entries []ImageAllowEntry) string {
+	var parts []string
+	for _, entry := range entries {
+		if entry.SelfLink != "" {
+			parts = append(parts, entry.SelfLink)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("family=%s min_version=%s", entry.Family, entry.MinVersion))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeAllowedNodeImages(entries []NodeImageAllowEntry) string {
+	var parts []string
+	for _, entry := range entries {
+		parts = append(parts, fmt.Sprintf("image_type=%s min_version=%s", entry.ImageType, entry.MinVersion))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatImageAttestationFindings(findings []ImageAttestationFinding) string {
+	var b strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&b, "  [%s] %s: expected one of [%s], got %s\n", f.Kind, f.Resource, f.Expected, f.Actual)
+	}
+	return b.String()
+}
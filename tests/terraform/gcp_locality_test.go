@@ -0,0 +1,93 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+)
+
+// ResolveZones returns suite.Zones, auto-discovering every UP zone in the
+// project via Compute.Zones.List and caching the result on first use when
+// Zones wasn't configured.
+func (suite *GCPTestSuite) ResolveZones(t *testing.T) []string {
+	t.Helper()
+
+	if len(suite.Zones) > 0 {
+		return suite.Zones
+	}
+
+	zones, err := suite.ListAllZones()
+	require.NoError(t, err)
+
+	var names []string
+	for _, zone := range zones {
+		if zone.Status == "UP" {
+			names = append(names, zone.Name)
+		}
+	}
+	suite.Zones = names
+	return names
+}
+
+// ResolveRegions returns suite.Regions, auto-discovering every UP region in
+// the project via Compute.Regions.List and caching the result on first use
+// when Regions wasn't configured.
+func (suite *GCPTestSuite) ResolveRegions(t *testing.T) []string {
+	t.Helper()
+
+	if len(suite.Regions) > 0 {
+		return suite.Regions
+	}
+
+	regions, err := suite.ListAllRegions()
+	require.NoError(t, err)
+
+	var names []string
+	for _, region := range regions {
+		if region.Status == "UP" {
+			names = append(names, region.Name)
+		}
+	}
+	suite.Regions = names
+	return names
+}
+
+// ListAllZones follows every page of zones in the project.
+func (suite *GCPTestSuite) ListAllZones() ([]*compute.Zone, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Zone, string, error) {
+		var resp *compute.ZoneList
+		err := suite.DoWithRetry("compute.Zones.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Zones.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllRegions follows every page of regions in the project.
+func (suite *GCPTestSuite) ListAllRegions() ([]*compute.Region, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Region, string, error) {
+		var resp *compute.RegionList
+		err := suite.DoWithRetry("compute.Regions.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Regions.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
@@ -0,0 +1,226 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/cloudfunctions/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/sql/v1"
+)
+
+// OperationScope identifies which `*Operations.Get` endpoint a long-running
+// GCP operation must be polled through, mirroring the scoping the Compute
+// API (and the Terraform provider's ComputeOperationWaiter) uses for global,
+// regional and zonal operations.
+type OperationScope int
+
+const (
+	OperationScopeGlobal OperationScope = iota
+	OperationScopeRegion
+	OperationScopeZone
+)
+
+// OperationWaitPolicy controls how WaitForOperation paces its polling: an
+// initial delay before the first poll, a minimum time between polls, and a
+// cap so the exponential backoff between polls doesn't grow unbounded.
+type OperationWaitPolicy struct {
+	Delay       time.Duration
+	MinTimeout  time.Duration
+	MaxInterval time.Duration
+}
+
+// defaultOperationWaitPolicy matches the Terraform Google provider's
+// defaults closely enough to avoid surprising test runs: a short initial
+// delay, a 2s floor between polls, and backoff capped at 30s.
+var defaultOperationWaitPolicy = OperationWaitPolicy{
+	Delay:       5 * time.Second,
+	MinTimeout:  2 * time.Second,
+	MaxInterval: 30 * time.Second,
+}
+
+// operationStatus is the adapter shape every supported GCP operation type is
+// normalized to, so the polling loop in WaitForOperation only has to reason
+// about one representation regardless of which service issued the op.
+type operationStatus struct {
+	Done   bool
+	Errors []string
+}
+
+// operationQuery re-fetches op's current state from its owning service.
+type operationQuery func() (operationStatus, error)
+
+// WaitForOperation blocks until op reaches a terminal state (or timeout
+// elapses), re-fetching its status from the appropriate service's
+// `*Operations.Get` endpoint at an exponentially backed-off interval bounded
+// by defaultOperationWaitPolicy. op must be one of *compute.Operation,
+// *sql.Operation, *container.Operation or *cloudfunctions.Operation; scope
+// is only consulted for *compute.Operation, which is the one API scoped by
+// global/region/zone. Any errors attached to the finished operation are
+// joined into the returned error.
+func (suite *GCPTestSuite) WaitForOperation(op interface{}, scope OperationScope, timeout time.Duration) error {
+	query, name, err := suite.newOperationQuery(op, scope)
+	if err != nil {
+		return err
+	}
+
+	if suite.DryRun {
+		suite.recordExec("WaitForOperation", name)
+		return nil
+	}
+
+	policy := defaultOperationWaitPolicy
+	deadline := time.Now().Add(timeout)
+	interval := policy.MinTimeout
+
+	time.Sleep(policy.Delay)
+
+	for {
+		status, err := query()
+		if err != nil {
+			return errors.Wrapf(err, "failed to poll operation %s", name)
+		}
+		if status.Done {
+			if len(status.Errors) > 0 {
+				return errors.Errorf("operation %s failed: %s", name, strings.Join(status.Errors, "; "))
+			}
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %s waiting for operation %s", timeout, name)
+		}
+
+		suite.Logger.Debug().Str("operation", name).Dur("next_poll", interval).Msg("Waiting for GCP operation")
+		time.Sleep(interval)
+
+		interval *= 2
+		if interval > policy.MaxInterval {
+			interval = policy.MaxInterval
+		}
+	}
+}
+
+// newOperationQuery builds the operationQuery adapter for op's concrete
+// type, along with a name used for logging/error messages.
+func (suite *GCPTestSuite) newOperationQuery(op interface{}, scope OperationScope) (operationQuery, string, error) {
+	switch o := op.(type) {
+	case *compute.Operation:
+		return suite.computeOperationQuery(o, scope), o.Name, nil
+	case *sql.Operation:
+		return suite.sqlOperationQuery(o), o.Name, nil
+	case *container.Operation:
+		return suite.containerOperationQuery(o), o.Name, nil
+	case *cloudfunctions.Operation:
+		return suite.cloudFunctionsOperationQuery(o), o.Name, nil
+	default:
+		return nil, "", errors.Errorf("unsupported operation type %T", op)
+	}
+}
+
+func (suite *GCPTestSuite) computeOperationQuery(op *compute.Operation, scope OperationScope) operationQuery {
+	return func() (operationStatus, error) {
+		var current *compute.Operation
+		err := suite.DoWithRetry("compute.Operations.Get", fmt.Sprintf("projects/%s/operations/%s", suite.ProjectID, op.Name), func() error {
+			var callErr error
+			switch scope {
+			case OperationScopeRegion:
+				current, callErr = suite.Compute.RegionOperations.Get(suite.ProjectID, op.Region, op.Name).Context(suite.Context).Do()
+			case OperationScopeZone:
+				current, callErr = suite.Compute.ZoneOperations.Get(suite.ProjectID, op.Zone, op.Name).Context(suite.Context).Do()
+			default:
+				current, callErr = suite.Compute.GlobalOperations.Get(suite.ProjectID, op.Name).Context(suite.Context).Do()
+			}
+			return callErr
+		})
+		if err != nil {
+			return operationStatus{}, err
+		}
+		if current == nil {
+			return operationStatus{Done: true}, nil
+		}
+		return operationStatus{Done: current.Status == "DONE", Errors: computeOperationErrors(current)}, nil
+	}
+}
+
+func computeOperationErrors(op *compute.Operation) []string {
+	if op.Error == nil {
+		return nil
+	}
+	var messages []string
+	for _, e := range op.Error.Errors {
+		messages = append(messages, e.Message)
+	}
+	return messages
+}
+
+func (suite *GCPTestSuite) sqlOperationQuery(op *sql.Operation) operationQuery {
+	return func() (operationStatus, error) {
+		var current *sql.Operation
+		err := suite.DoWithRetry("sql.Operations.Get", fmt.Sprintf("projects/%s/operations/%s", suite.ProjectID, op.Name), func() error {
+			var callErr error
+			current, callErr = suite.SQL.Operations.Get(suite.ProjectID, op.Name).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return operationStatus{}, err
+		}
+		if current == nil {
+			return operationStatus{Done: true}, nil
+		}
+		var messages []string
+		if current.Error != nil {
+			for _, e := range current.Error.Errors {
+				messages = append(messages, e.Message)
+			}
+		}
+		return operationStatus{Done: current.Status == "DONE", Errors: messages}, nil
+	}
+}
+
+func (suite *GCPTestSuite) containerOperationQuery(op *container.Operation) operationQuery {
+	return func() (operationStatus, error) {
+		var current *container.Operation
+		err := suite.DoWithRetry("container.Operations.Get", fmt.Sprintf("projects/%s/zones/%s/operations/%s", suite.ProjectID, op.Zone, op.Name), func() error {
+			var callErr error
+			current, callErr = suite.Container.Projects.Zones.Operations.Get(suite.ProjectID, op.Zone, op.Name).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return operationStatus{}, err
+		}
+		if current == nil {
+			return operationStatus{Done: true}, nil
+		}
+		var messages []string
+		if current.Status == "ABORTING" {
+			messages = append(messages, current.StatusMessage)
+		}
+		return operationStatus{Done: current.Status == "DONE", Errors: messages}, nil
+	}
+}
+
+func (suite *GCPTestSuite) cloudFunctionsOperationQuery(op *cloudfunctions.Operation) operationQuery {
+	return func() (operationStatus, error) {
+		var current *cloudfunctions.Operation
+		err := suite.DoWithRetry("cloudfunctions.Operations.Get", op.Name, func() error {
+			var callErr error
+			current, callErr = suite.CloudFunctions.Operations.Get(op.Name).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return operationStatus{}, err
+		}
+		if current == nil {
+			return operationStatus{Done: true}, nil
+		}
+		var messages []string
+		if current.Error != nil {
+			messages = append(messages, current.Error.Message)
+		}
+		return operationStatus{Done: current.Done, Errors: messages}, nil
+	}
+}
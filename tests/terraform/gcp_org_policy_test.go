@@ -0,0 +1,234 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/orgpolicy/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// orgPolicyExpectationsPath is the checked-in policy TestCompliance's
+// Policy Compliance subtest validates the live project's Organization
+// Policies against.
+const orgPolicyExpectationsPath = "testdata/org_policies.yaml"
+
+// OrgPolicyConstraint is one expected Organization Policy constraint state:
+// whether it must be enforced, and, for list constraints, which values must
+// be allowed or denied.
+type OrgPolicyConstraint struct {
+	Name          string   `yaml:"name"`
+	Enforced      bool     `yaml:"enforced"`
+	AllowedValues []string `yaml:"allowed_values"`
+	DeniedValues  []string `yaml:"denied_values"`
+	Severity      string   `yaml:"severity"`
+}
+
+// OrgPolicyExpectations is the top-level shape of an org_policies.yaml file.
+type OrgPolicyExpectations struct {
+	Constraints []OrgPolicyConstraint `yaml:"constraints"`
+}
+
+// LoadOrgPolicyExpectations reads and parses an Organization Policy
+// expectations YAML file.
+func LoadOrgPolicyExpectations(path string) (*OrgPolicyExpectations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read org policy expectations %s", path)
+	}
+
+	var expectations OrgPolicyExpectations
+	if err := yaml.Unmarshal(data, &expectations); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse org policy expectations %s", path)
+	}
+	return &expectations, nil
+}
+
+// OrgPolicyFinding is one mismatch EvaluateOrgPolicies found between an
+// expected constraint and the project's effective Organization Policy.
+type OrgPolicyFinding struct {
+	Constraint string
+	Severity   string
+	Expected   string
+	Actual     string
+	Message    string
+}
+
+// EvaluateOrgPolicies fetches the effective Organization Policy for each
+// constraint in expectations and returns a finding for every mismatch
+// against the declared enforcement and allowed/denied values.
+func (suite *GCPTestSuite) EvaluateOrgPolicies(expectations *OrgPolicyExpectations) ([]OrgPolicyFinding, error) {
+	parent := fmt.Sprintf("projects/%s", suite.ProjectID)
+
+	var findings []OrgPolicyFinding
+	for _, constraint := range expectations.Constraints {
+		constraintID := strings.TrimPrefix(constraint.Name, "constraints/")
+		policyName := fmt.Sprintf("%s/policies/%s", parent, constraintID)
+
+		var effective *orgpolicy.GoogleCloudOrgpolicyV2Policy
+		err := suite.DoWithRetry("orgpolicy.Projects.Policies.GetEffectivePolicy", policyName, func() error {
+			var callErr error
+			effective, callErr = suite.OrgPolicy.Projects.Policies.GetEffectivePolicy(policyName).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			findings = append(findings, OrgPolicyFinding{
+				Constraint: constraint.Name,
+				Severity:   constraint.Severity,
+				Expected:   describeOrgPolicyExpectation(constraint),
+				Actual:     "not found",
+				Message:    fmt.Sprintf("failed to fetch effective policy for %s: %v", constraint.Name, err),
+			})
+			continue
+		}
+
+		findings = append(findings, evaluateOrgPolicyConstraint(constraint, effective)...)
+	}
+
+	return findings, nil
+}
+
+// evaluateOrgPolicyConstraint diffs one constraint's expected enforcement
+// and allowed/denied values against its effective policy.
+func evaluateOrgPolicyConstraint(constraint OrgPolicyConstraint, policy *orgpolicy.GoogleCloudOrgpolicyV2Policy) []OrgPolicyFinding {
+	expected := describeOrgPolicyExpectation(constraint)
+
+	if policy.Spec == nil || len(policy.Spec.Rules) == 0 {
+		return []OrgPolicyFinding{{
+			Constraint: constraint.Name,
+			Severity:   constraint.Severity,
+			Expected:   expected,
+			Actual:     "no rules configured",
+			Message:    fmt.Sprintf("%s has no effective policy rules configured", constraint.Name),
+		}}
+	}
+
+	rule := policy.Spec.Rules[0]
+	for _, candidate := range policy.Spec.Rules {
+		if candidate.Condition == nil {
+			rule = candidate
+			break
+		}
+	}
+
+	var findings []OrgPolicyFinding
+
+	enforced := rule.Enforce == "TRUE"
+	if enforced != constraint.Enforced {
+		findings = append(findings, OrgPolicyFinding{
+			Constraint: constraint.Name,
+			Severity:   constraint.Severity,
+			Expected:   expected,
+			Actual:     fmt.Sprintf("enforced=%t", enforced),
+			Message:    fmt.Sprintf("%s enforcement is %t, expected %t", constraint.Name, enforced, constraint.Enforced),
+		})
+	}
+
+	if len(constraint.AllowedValues) == 0 && len(constraint.DeniedValues) == 0 {
+		return findings
+	}
+
+	if rule.Values == nil {
+		return append(findings, OrgPolicyFinding{
+			Constraint: constraint.Name,
+			Severity:   constraint.Severity,
+			Expected:   expected,
+			Actual:     "no list values configured",
+			Message:    fmt.Sprintf("%s has no allowed/denied values configured", constraint.Name),
+		})
+	}
+
+	for _, want := range constraint.AllowedValues {
+		if !containsOrgPolicyValue(rule.Values.AllowedValues, want) {
+			findings = append(findings, OrgPolicyFinding{
+				Constraint: constraint.Name,
+				Severity:   constraint.Severity,
+				Expected:   expected,
+				Actual:     fmt.Sprintf("allowed_values=%v", rule.Values.AllowedValues),
+				Message:    fmt.Sprintf("%s should allow %q but it is missing from allowed_values", constraint.Name, want),
+			})
+		}
+	}
+	for _, want := range constraint.DeniedValues {
+		if !containsOrgPolicyValue(rule.Values.DeniedValues, want) {
+			findings = append(findings, OrgPolicyFinding{
+				Constraint: constraint.Name,
+				Severity:   constraint.Severity,
+				Expected:   expected,
+				Actual:     fmt.Sprintf("denied_values=%v", rule.Values.DeniedValues),
+				Message:    fmt.Sprintf("%s should deny %q but it is missing from denied_values", constraint.Name, want),
+			})
+		}
+	}
+
+	return findings
+}
+
+// describeOrgPolicyExpectation renders a constraint's declared state for use
+// in OrgPolicyFinding.Expected and test failure output.
+func describeOrgPolicyExpectation(constraint OrgPolicyConstraint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "enforced=%t", constraint.Enforced)
+	if len(constraint.AllowedValues) > 0 {
+		fmt.Fprintf(&b, " allowed_values=%v", constraint.AllowedValues)
+	}
+	if len(constraint.DeniedValues) > 0 {
+		fmt.Fprintf(&b, " denied_values=%v", constraint.DeniedValues)
+	}
+	return b.String()
+}
+
+func containsOrgPolicyValue(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// OrgPolicyFindingsExceedThreshold reports whether any finding's severity
+// meets or exceeds threshold, reusing the same severity ranking as the Rego
+// policy-as-code findings.
+func OrgPolicyFindingsExceedThreshold(findings []OrgPolicyFinding, threshold string) bool {
+	min, ok := severityRank[threshold]
+	if !ok {
+		min = severityRank["low"]
+	}
+	for _, f := range findings {
+		if rank, ok := severityRank[f.Severity]; ok && rank >= min {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertOrgPolicyCompliance loads the Organization Policy expectations from
+// orgPolicyExpectationsPath, evaluates them against the live project, logs
+// every finding, and fails t if any finding meets or exceeds high severity.
+func (suite *GCPTestSuite) AssertOrgPolicyCompliance(t *testing.T) {
+	t.Helper()
+
+	expectations, err := LoadOrgPolicyExpectations(orgPolicyExpectationsPath)
+	require.NoError(t, err)
+
+	findings, err := suite.EvaluateOrgPolicies(expectations)
+	require.NoError(t, err)
+
+	for _, finding := range findings {
+		suite.Logger.Warn().
+			Str("constraint", finding.Constraint).
+			Str("severity", finding.Severity).
+			Str("expected", finding.Expected).
+			Str("actual", finding.Actual).
+			Msg(finding.Message)
+	}
+
+	assert.False(t, OrgPolicyFindingsExceedThreshold(findings, "high"),
+		"No Organization Policy findings should meet or exceed high severity")
+}
@@ -0,0 +1,263 @@
+package test
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/storage/v1"
+)
+
+// defaultMaxListPages caps how many pages PaginatedList will follow when a
+// GCPTestSuite isn't configured with its own GCPMaxListPages, so a runaway
+// NextPageToken loop can't page through a project's resources forever.
+const defaultMaxListPages = 50
+
+// PaginatedList drives fetch across every page of a List call, following
+// NextPageToken until the API reports no more pages or maxPages is reached,
+// echoing the paginatedListRequest pattern the Google Terraform provider uses
+// to fully exhaust List calls instead of asserting over just the first page.
+func PaginatedList[T any](ctx context.Context, maxPages int, fetch func(pageToken string) ([]T, string, error)) ([]T, error) {
+	var all []T
+	pageToken := ""
+	for page := 0; maxPages <= 0 || page < maxPages; page++ {
+		items, nextPageToken, err := fetch(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	return all, nil
+}
+
+// ListAllNetworks follows every page of VPC networks in the project.
+func (suite *GCPTestSuite) ListAllNetworks() ([]*compute.Network, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Network, string, error) {
+		var resp *compute.NetworkList
+		err := suite.DoWithRetry("compute.Networks.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Networks.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllSubnetworks follows every page of subnetworks in region.
+func (suite *GCPTestSuite) ListAllSubnetworks(region string) ([]*compute.Subnetwork, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Subnetwork, string, error) {
+		var resp *compute.SubnetworkList
+		err := suite.DoWithRetry("compute.Subnetworks.List", fmt.Sprintf("projects/%s/regions/%s", suite.ProjectID, region), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Subnetworks.List(suite.ProjectID, region).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllFirewalls follows every page of firewall rules in the project.
+func (suite *GCPTestSuite) ListAllFirewalls() ([]*compute.Firewall, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Firewall, string, error) {
+		var resp *compute.FirewallList
+		err := suite.DoWithRetry("compute.Firewalls.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Firewalls.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllRoutes follows every page of routes in the project.
+func (suite *GCPTestSuite) ListAllRoutes() ([]*compute.Route, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Route, string, error) {
+		var resp *compute.RouteList
+		err := suite.DoWithRetry("compute.Routes.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Routes.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllGlobalForwardingRules follows every page of global forwarding rules.
+func (suite *GCPTestSuite) ListAllGlobalForwardingRules() ([]*compute.ForwardingRule, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.ForwardingRule, string, error) {
+		var resp *compute.ForwardingRuleList
+		err := suite.DoWithRetry("compute.GlobalForwardingRules.List", fmt.Sprintf("projects/%s/global", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.GlobalForwardingRules.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllForwardingRules follows every page of regional forwarding rules in region.
+func (suite *GCPTestSuite) ListAllForwardingRules(region string) ([]*compute.ForwardingRule, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.ForwardingRule, string, error) {
+		var resp *compute.ForwardingRuleList
+		err := suite.DoWithRetry("compute.ForwardingRules.List", fmt.Sprintf("projects/%s/regions/%s", suite.ProjectID, region), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.ForwardingRules.List(suite.ProjectID, region).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllInstances follows every page of VM instances in zone.
+func (suite *GCPTestSuite) ListAllInstances(zone string) ([]*compute.Instance, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Instance, string, error) {
+		var resp *compute.InstanceList
+		err := suite.DoWithRetry("compute.Instances.List", fmt.Sprintf("projects/%s/zones/%s", suite.ProjectID, zone), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Instances.List(suite.ProjectID, zone).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllInstanceTemplates follows every page of instance templates in the project.
+func (suite *GCPTestSuite) ListAllInstanceTemplates() ([]*compute.InstanceTemplate, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.InstanceTemplate, string, error) {
+		var resp *compute.InstanceTemplateList
+		err := suite.DoWithRetry("compute.InstanceTemplates.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.InstanceTemplates.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllDisks follows every page of persistent disks in zone.
+func (suite *GCPTestSuite) ListAllDisks(zone string) ([]*compute.Disk, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Disk, string, error) {
+		var resp *compute.DiskList
+		err := suite.DoWithRetry("compute.Disks.List", fmt.Sprintf("projects/%s/zones/%s", suite.ProjectID, zone), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Disks.List(suite.ProjectID, zone).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllSnapshots follows every page of disk snapshots in the project.
+func (suite *GCPTestSuite) ListAllSnapshots() ([]*compute.Snapshot, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Snapshot, string, error) {
+		var resp *compute.SnapshotList
+		err := suite.DoWithRetry("compute.Snapshots.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Snapshots.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllImages follows every page of images in the project.
+func (suite *GCPTestSuite) ListAllImages() ([]*compute.Image, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*compute.Image, string, error) {
+		var resp *compute.ImageList
+		err := suite.DoWithRetry("compute.Images.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Compute.Images.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
+
+// ListAllBuckets follows every page of Cloud Storage buckets in the project.
+func (suite *GCPTestSuite) ListAllBuckets() ([]*storage.Bucket, error) {
+	return PaginatedList(suite.Context, suite.MaxListPages, func(pageToken string) ([]*storage.Bucket, string, error) {
+		var resp *storage.Buckets
+		err := suite.DoWithRetry("storage.Buckets.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			resp, callErr = suite.Storage.Buckets.List(suite.ProjectID).PageToken(pageToken).Context(suite.Context).Do()
+			return callErr
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if resp == nil {
+			return nil, "", nil
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+}
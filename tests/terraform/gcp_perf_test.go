@@ -0,0 +1,385 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"github.com/pkg/errors"
+)
+
+// PerfScenario describes one load/stress/endurance run: a fixed target hit
+// at a given RPS (or ramped between MinRPS/MaxRPS for stress) for Duration.
+// ThinkTime is slept after each request inside that request's own
+// goroutine, so it does not lower the request rate.
+type PerfScenario struct {
+	Name         string
+	TargetURL    string
+	Method       string
+	Payload      []byte
+	RPS          int
+	MinRPS       int
+	MaxRPS       int
+	StepEvery    time.Duration
+	Duration     time.Duration
+	ThinkTime    time.Duration
+	MaxErrorRate float64 // stress: fraction of failed requests that defines the breaking point
+}
+
+// PerfSample is one completed request's outcome, timestamped for windowed
+// aggregation (e.g. per-step error rate during a stress ramp).
+type PerfSample struct {
+	At        time.Time
+	LatencyMs float64
+	StatusOK  bool
+}
+
+// PerfResult summarizes a completed PerfScenario run: latency percentiles,
+// throughput, and error rate, plus (for endurance runs) goroutine growth
+// captured across periodic snapshots.
+type PerfResult struct {
+	Scenario         string  `json:"scenario"`
+	Requests         int     `json:"requests"`
+	Errors           int     `json:"errors"`
+	ErrorRate        float64 `json:"error_rate"`
+	P50Ms            float64 `json:"p50_ms"`
+	P95Ms            float64 `json:"p95_ms"`
+	P99Ms            float64 `json:"p99_ms"`
+	ThroughputRPS    float64 `json:"throughput_rps"`
+	BreakingPointRPS int     `json:"breaking_point_rps,omitempty"`
+	GoroutineSamples []int   `json:"goroutine_samples,omitempty"`
+	GoroutineGrowth  int     `json:"goroutine_growth,omitempty"`
+}
+
+// runScenario fires requests against scenario.TargetURL at a fixed RPS for
+// scenario.Duration, starting one goroutine per ticker tick (the number in
+// flight is not bounded), and returns every sample observed. It never mutates the SUT beyond issuing HTTP requests.
+func runScenario(scenario PerfScenario, rps int, duration time.Duration) []PerfSample {
+	if rps <= 0 {
+		rps = 1
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	method := scenario.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	interval := time.Second / time.Duration(rps)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(duration)
+	var mu sync.Mutex
+	var samples []PerfSample
+	var wg sync.WaitGroup
+
+	for time.Now().Before(deadline) {
+		<-ticker.C
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			var body io.Reader
+			if len(scenario.Payload) > 0 {
+				body = strings.NewReader(string(scenario.Payload))
+			}
+			req, err := http.NewRequest(method, scenario.TargetURL, body)
+			ok := false
+			if err == nil {
+				resp, doErr := client.Do(req)
+				if doErr == nil {
+					ok = resp.StatusCode < 500
+					resp.Body.Close()
+				}
+			}
+			sample := PerfSample{At: start, LatencyMs: float64(time.Since(start).Milliseconds()), StatusOK: ok}
+			mu.Lock()
+			samples = append(samples, sample)
+			mu.Unlock()
+			if scenario.ThinkTime > 0 {
+				time.Sleep(scenario.ThinkTime)
+			}
+		}()
+	}
+	wg.Wait()
+	return samples
+}
+
+// summarize computes a PerfResult from raw samples.
+func summarize(name string, samples []PerfSample, elapsed time.Duration) (PerfResult, error) {
+	latencies := make([]float64, len(samples))
+	errs := 0
+	for i, s := range samples {
+		latencies[i] = s.LatencyMs
+		if !s.StatusOK {
+			errs++
+		}
+	}
+
+	result := PerfResult{Scenario: name, Requests: len(samples), Errors: errs}
+	if len(samples) > 0 {
+		result.ErrorRate = float64(errs) / float64(len(samples))
+	}
+	if elapsed > 0 {
+		result.ThroughputRPS = float64(len(samples)) / elapsed.Seconds()
+	}
+
+	if len(latencies) == 0 {
+		return result, nil
+	}
+	var err error
+	if result.P50Ms, err = stats.Percentile(latencies, 50); err != nil {
+		return result, errors.Wrap(err, "failed to compute p50")
+	}
+	if result.P95Ms, err = stats.Percentile(latencies, 95); err != nil {
+		return result, errors.Wrap(err, "failed to compute p95")
+	}
+	if result.P99Ms, err = stats.Percentile(latencies, 99); err != nil {
+		return result, errors.Wrap(err, "failed to compute p99")
+	}
+	return result, nil
+}
+
+// RunLoadScenario drives scenario at a fixed RPS for its full Duration and
+// fails if p95 latency exceeds PerformanceConfig.MaxResponseTimeMs or
+// throughput falls below PerformanceConfig.MinThroughputRPS. p50, p99 and
+// the error rate are reported in the result but not asserted.
+func (suite *GCPTestSuite) RunLoadScenario(scenario PerfScenario) (PerfResult, error) {
+	start := time.Now()
+	samples := runScenario(scenario, scenario.RPS, scenario.Duration)
+	result, err := summarize(scenario.Name, samples, time.Since(start))
+	if err != nil {
+		return result, err
+	}
+
+	maxLatency := float64(suite.Config.PerformanceConfig.MaxResponseTimeMs)
+	if maxLatency > 0 && result.P95Ms > maxLatency {
+		return result, errors.Errorf("scenario %s: p95 latency %.1fms exceeds SLO %.1fms", scenario.Name, result.P95Ms, maxLatency)
+	}
+	minThroughput := float64(suite.Config.PerformanceConfig.MinThroughputRPS)
+	if minThroughput > 0 && result.ThroughputRPS < minThroughput {
+		return result, errors.Errorf("scenario %s: throughput %.1f rps below SLO %.1f rps", scenario.Name, result.ThroughputRPS, minThroughput)
+	}
+	return result, nil
+}
+
+// RunStressScenario step-ramps RPS from MinRPS to MaxRPS, one StepEvery
+// interval at a time, until the error rate crosses scenario.MaxErrorRate,
+// and reports the RPS at which the breaking point occurred (0 if the ramp
+// completed without breaking).
+func (suite *GCPTestSuite) RunStressScenario(scenario PerfScenario) (PerfResult, error) {
+	stepEvery := scenario.StepEvery
+	if stepEvery <= 0 {
+		stepEvery = 30 * time.Second
+	}
+	maxErrorRate := scenario.MaxErrorRate
+	if maxErrorRate <= 0 {
+		maxErrorRate = 0.05
+	}
+	step := (scenario.MaxRPS - scenario.MinRPS) / 10
+	if step <= 0 {
+		step = 1
+	}
+
+	var all []PerfSample
+	breakingPoint := 0
+	start := time.Now()
+	for rps := scenario.MinRPS; rps <= scenario.MaxRPS; rps += step {
+		samples := runScenario(scenario, rps, stepEvery)
+		all = append(all, samples...)
+
+		stepResult, err := summarize(scenario.Name, samples, stepEvery)
+		if err != nil {
+			return stepResult, err
+		}
+		suite.Logger.Info().Str("scenario", scenario.Name).Int("rps", rps).
+			Float64("error_rate", stepResult.ErrorRate).Msg("Stress ramp step")
+		if stepResult.ErrorRate > maxErrorRate {
+			breakingPoint = rps
+			break
+		}
+	}
+
+	result, err := summarize(scenario.Name, all, time.Since(start))
+	result.BreakingPointRPS = breakingPoint
+	return result, err
+}
+
+// RunEnduranceScenario holds a steady-state RPS for Duration (typically 1+
+// hour) while periodically snapshotting runtime.NumGoroutine() of this test
+// process, failing if it grows by more than goroutineLeakThreshold. The
+// SUT's own goroutines and memory are not observed.
+func (suite *GCPTestSuite) RunEnduranceScenario(scenario PerfScenario) (PerfResult, error) {
+	snapshotInterval := 5 * time.Minute
+	if scenario.Duration < snapshotInterval {
+		snapshotInterval = scenario.Duration / 5
+		if snapshotInterval <= 0 {
+			snapshotInterval = scenario.Duration
+		}
+	}
+
+	var samples []PerfSample
+	var goroutineSamples []int
+	start := time.Now()
+	deadline := start.Add(scenario.Duration)
+
+	for time.Now().Before(deadline) {
+		remaining := time.Until(deadline)
+		window := snapshotInterval
+		if remaining < window {
+			window = remaining
+		}
+		goroutineSamples = append(goroutineSamples, runtime.NumGoroutine())
+		samples = append(samples, runScenario(scenario, scenario.RPS, window)...)
+	}
+	goroutineSamples = append(goroutineSamples, runtime.NumGoroutine())
+
+	result, err := summarize(scenario.Name, samples, time.Since(start))
+	if err != nil {
+		return result, err
+	}
+	result.GoroutineSamples = goroutineSamples
+	if len(goroutineSamples) > 1 {
+		result.GoroutineGrowth = goroutineSamples[len(goroutineSamples)-1] - goroutineSamples[0]
+	}
+
+	const goroutineLeakThreshold = 500
+	if result.GoroutineGrowth > goroutineLeakThreshold {
+		return result, errors.Errorf("scenario %s: goroutine count grew by %d over the run, suspected leak", scenario.Name, result.GoroutineGrowth)
+	}
+	return result, nil
+}
+
+// WritePerfPrometheusMetrics renders results as Prometheus exposition-format
+// gauges so they can be scraped or pushed to a pushgateway from CI.
+func WritePerfPrometheusMetrics(path string, results []PerfResult) error {
+	var sb strings.Builder
+	for _, r := range results {
+		labels := fmt.Sprintf(`{scenario=%q}`, r.Scenario)
+		sb.WriteString(fmt.Sprintf("perf_p50_latency_ms%s %f\n", labels, r.P50Ms))
+		sb.WriteString(fmt.Sprintf("perf_p95_latency_ms%s %f\n", labels, r.P95Ms))
+		sb.WriteString(fmt.Sprintf("perf_p99_latency_ms%s %f\n", labels, r.P99Ms))
+		sb.WriteString(fmt.Sprintf("perf_throughput_rps%s %f\n", labels, r.ThroughputRPS))
+		sb.WriteString(fmt.Sprintf("perf_error_rate%s %f\n", labels, r.ErrorRate))
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write perf metrics to %s", path)
+	}
+	return nil
+}
+
+// WritePerfMarkdownSummary renders results as a Markdown table for the CI
+// job summary.
+func WritePerfMarkdownSummary(path string, results []PerfResult) error {
+	var sb strings.Builder
+	sb.WriteString("| Scenario | Requests | Errors | p50 (ms) | p95 (ms) | p99 (ms) | RPS |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
+	for _, r := range results {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %.1f | %.1f | %.1f | %.1f |\n",
+			r.Scenario, r.Requests, r.Errors, r.P50Ms, r.P95Ms, r.P99Ms, r.ThroughputRPS))
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write perf summary to %s", path)
+	}
+	return nil
+}
+
+// perfScenarioFromOutputs builds the shared PerfScenario definition each of
+// TestLoadPerformance/TestStressPerformance/TestEndurancePerformance target,
+// pointed at outputs["load_balancer_endpoint"] (falling back to
+// outputs["public_endpoint"]).
+func perfScenarioFromOutputs(outputs map[string]interface{}) (PerfScenario, error) {
+	endpoint, _ := outputs["load_balancer_endpoint"].(string)
+	if endpoint == "" {
+		endpoint, _ = outputs["public_endpoint"].(string)
+	}
+	if endpoint == "" {
+		return PerfScenario{}, errors.New("no load_balancer_endpoint or public_endpoint in outputs")
+	}
+	if !strings.HasPrefix(endpoint, "http") {
+		endpoint = "https://" + endpoint
+	}
+	return PerfScenario{TargetURL: endpoint}, nil
+}
+
+func (suite *GCPTestSuite) TestLoadPerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "load").Msg("Testing load performance")
+
+	scenario, err := perfScenarioFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+	scenario.Name = "load"
+	scenario.RPS = suite.Config.PerformanceConfig.MinThroughputRPS
+	if scenario.RPS <= 0 {
+		scenario.RPS = 50
+	}
+	scenario.Duration = 5 * time.Minute
+
+	result, err := suite.RunLoadScenario(scenario)
+	if err != nil {
+		return errors.Wrap(err, "load performance scenario failed")
+	}
+
+	suite.Logger.Info().Interface("result", result).Msg("Load performance completed")
+	return nil
+}
+
+func (suite *GCPTestSuite) TestStressPerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "stress").Msg("Testing stress performance")
+
+	scenario, err := perfScenarioFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+	scenario.Name = "stress"
+	scenario.MinRPS = 10
+	scenario.MaxRPS = 10 * suite.Config.PerformanceConfig.MinThroughputRPS
+	if scenario.MaxRPS <= scenario.MinRPS {
+		scenario.MaxRPS = 500
+	}
+	scenario.StepEvery = 30 * time.Second
+
+	result, err := suite.RunStressScenario(scenario)
+	if err != nil {
+		return errors.Wrap(err, "stress performance scenario failed")
+	}
+
+	suite.Logger.Info().Interface("result", result).Int("breaking_point_rps", result.BreakingPointRPS).
+		Msg("Stress performance completed")
+	return nil
+}
+
+func (suite *GCPTestSuite) TestEndurancePerformance(outputs map[string]interface{}) error {
+	suite.Logger.Info().Str("test_type", "endurance").Msg("Testing endurance performance")
+
+	if !suite.Config.PerformanceConfig.EnableEnduranceTesting {
+		suite.Logger.Info().Msg("Endurance testing disabled, skipping")
+		return nil
+	}
+
+	scenario, err := perfScenarioFromOutputs(outputs)
+	if err != nil {
+		return err
+	}
+	scenario.Name = "endurance"
+	scenario.RPS = suite.Config.PerformanceConfig.MinThroughputRPS
+	if scenario.RPS <= 0 {
+		scenario.RPS = 20
+	}
+	scenario.Duration = time.Hour
+
+	result, err := suite.RunEnduranceScenario(scenario)
+	if err != nil {
+		return errors.Wrap(err, "endurance performance scenario failed")
+	}
+
+	suite.Logger.Info().Interface("result", result).Msg("Endurance performance completed")
+	return nil
+}
@@ -0,0 +1,295 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+// gcpPolicyDir is where operators drop additional `.rego` policy files to
+// evaluate alongside DefaultGCPPolicyBundle, per resource kind emitted by
+// BuildGCPResourceGraph.
+const gcpPolicyDir = "testdata/policies"
+
+// gcpSensitivePorts are the destination ports the firewall built-in policy
+// treats as sensitive enough to deny 0.0.0.0/0 ingress on.
+var gcpSensitivePorts = []string{"22", "3389", "3306", "5432"}
+
+// gcpPolicyExceptions names resources permitted to violate a built-in or
+// dropped-in policy without failing the test -- e.g. a bastion host that
+// legitimately needs 0.0.0.0/0:22. Keys are exceptionKey values built from
+// the finding's rule and message, not from a separate resource name.
+var gcpPolicyExceptions = map[string]bool{}
+
+// gcpPolicyModule is the built-in Rego rule pack: no allUsers/
+// allAuthenticatedUsers IAM bindings, no 0.0.0.0/0 ingress to sensitive
+// ports, no service account holding roles/owner, uniform bucket-level
+// access on every bucket, and private Google access on subnets used by GKE.
+var gcpPolicyModule = fmt.Sprintf(`
+package policy
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "IAMBinding"
+	member := resource.properties.members[_]
+	member == "allUsers"
+	msg := sprintf("IAM binding %v grants role %v to allUsers", [resource.name, resource.properties.role])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "IAMBinding"
+	member := resource.properties.members[_]
+	member == "allAuthenticatedUsers"
+	msg := sprintf("IAM binding %v grants role %v to allAuthenticatedUsers", [resource.name, resource.properties.role])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "IAMBinding"
+	resource.properties.role == "roles/owner"
+	member := resource.properties.members[_]
+	startswith(member, "serviceAccount:")
+	msg := sprintf("service account %v must not hold roles/owner", [member])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "FirewallRule"
+	resource.properties.direction == "INGRESS"
+	source := resource.properties.source_ranges[_]
+	source == "0.0.0.0/0"
+	port := resource.properties.ports[_]
+	%s[port]
+	msg := sprintf("firewall rule %v allows 0.0.0.0/0 ingress on sensitive port %v", [resource.name, port])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "StorageBucket"
+	resource.properties.uniform_bucket_level_access != true
+	msg := sprintf("bucket %v must enable uniform bucket-level access", [resource.name])
+}
+
+deny[msg] {
+	resource := input.resources[_]
+	resource.kind == "Subnetwork"
+	resource.properties.used_by_gke == true
+	resource.properties.private_ip_google_access != true
+	msg := sprintf("subnet %v is used by GKE but lacks Private Google Access", [resource.name])
+}
+`, regoStringSet(gcpSensitivePorts))
+
+// regoStringSet renders values as a Rego set literal, e.g. {"22", "3389"}.
+func regoStringSet(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "{" + strings.Join(quoted, ", ") + "}"
+}
+
+// DefaultGCPPolicyBundle is the built-in rule pack; LoadGCPPolicyBundle
+// appends any *.rego files under gcpPolicyDir to it.
+var DefaultGCPPolicyBundle = []RegoRule{
+	{Name: "gcp-builtin-policies", Module: gcpPolicyModule, Severity: "high"},
+}
+
+// LoadGCPPolicyBundle returns DefaultGCPPolicyBundle plus one RegoRule per
+// *.rego file found under dir, so operators can extend the built-ins without
+// touching Go code. A missing dir is not an error -- it just means no
+// additional policies are configured.
+func LoadGCPPolicyBundle(dir string) ([]RegoRule, error) {
+	bundle := append([]RegoRule(nil), DefaultGCPPolicyBundle...)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return bundle, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read policy directory %s", dir)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".rego" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read policy file %s", path)
+		}
+		bundle = append(bundle, RegoRule{
+			Name:     strings.TrimSuffix(entry.Name(), ".rego"),
+			Module:   string(data),
+			Severity: "medium",
+		})
+	}
+	return bundle, nil
+}
+
+// BuildGCPResourceGraph pulls the resources DefaultGCPPolicyBundle evaluates
+// against -- project IAM bindings, VPC firewall rules, GCS buckets, and
+// subnets annotated with whether a GKE cluster uses them -- into the flat,
+// kind-tagged shape EvaluateRegoRules expects.
+func (suite *GCPTestSuite) BuildGCPResourceGraph() ([]ResourceGraphNode, error) {
+	var graph []ResourceGraphNode
+
+	policy, err := suite.CloudResourceManager.Projects.GetIamPolicy(suite.ProjectID, &cloudresourcemanager.GetIamPolicyRequest{}).Context(suite.Context).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch project IAM policy for resource graph")
+	}
+	for i, binding := range policy.Bindings {
+		graph = append(graph, ResourceGraphNode{
+			Kind: "IAMBinding",
+			Name: fmt.Sprintf("%s/bindings/%d", suite.ProjectID, i),
+			Properties: map[string]interface{}{
+				"role":    binding.Role,
+				"members": binding.Members,
+			},
+		})
+	}
+
+	firewalls, err := suite.ListAllFirewalls()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list firewall rules for resource graph")
+	}
+	for _, fw := range firewalls {
+		var ports []string
+		for _, allowed := range fw.Allowed {
+			ports = append(ports, allowed.Ports...)
+		}
+		graph = append(graph, ResourceGraphNode{
+			Kind: "FirewallRule",
+			Name: fw.Name,
+			Properties: map[string]interface{}{
+				"direction":     fw.Direction,
+				"source_ranges": fw.SourceRanges,
+				"ports":         ports,
+			},
+		})
+	}
+
+	buckets, err := suite.ListAllBuckets()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list buckets for resource graph")
+	}
+	for _, bucket := range buckets {
+		uniformAccess := bucket.IamConfiguration != nil &&
+			bucket.IamConfiguration.UniformBucketLevelAccess != nil &&
+			bucket.IamConfiguration.UniformBucketLevelAccess.Enabled
+		graph = append(graph, ResourceGraphNode{
+			Kind: "StorageBucket",
+			Name: bucket.Name,
+			Properties: map[string]interface{}{
+				"uniform_bucket_level_access": uniformAccess,
+			},
+		})
+	}
+
+	gkeSubnets, err := suite.gkeSubnetworkURIs()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list GKE clusters for resource graph")
+	}
+	for _, region := range suite.discoverRegions() {
+		subnets, err := suite.ListAllSubnetworks(region)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list subnetworks in %s for resource graph", region)
+		}
+		for _, subnet := range subnets {
+			graph = append(graph, ResourceGraphNode{
+				Kind: "Subnetwork",
+				Name: subnet.Name,
+				Properties: map[string]interface{}{
+					"private_ip_google_access": subnet.PrivateIpGoogleAccess,
+					"used_by_gke":              gkeSubnets[subnet.SelfLink],
+				},
+			})
+		}
+	}
+
+	return graph, nil
+}
+
+// gkeSubnetworkURIs returns the set of subnetwork self-links used by every
+// GKE cluster in suite.Zones (or suite.Zone if unconfigured).
+func (suite *GCPTestSuite) gkeSubnetworkURIs() (map[string]bool, error) {
+	used := make(map[string]bool)
+	for _, zone := range suite.discoverZones() {
+		resp, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, zone).Context(suite.Context).Do()
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			continue
+		}
+		for _, cluster := range resp.Clusters {
+			if cluster.Subnetwork != "" {
+				used[cluster.Subnetwork] = true
+			}
+		}
+	}
+	return used, nil
+}
+
+// discoverZones returns suite.Zones if configured, otherwise falls back to
+// suite.Zone so single-zone configurations keep working.
+func (suite *GCPTestSuite) discoverZones() []string {
+	if len(suite.Zones) > 0 {
+		return suite.Zones
+	}
+	return []string{suite.Zone}
+}
+
+// discoverRegions returns suite.Regions if configured, otherwise falls back
+// to suite.Region.
+func (suite *GCPTestSuite) discoverRegions() []string {
+	if len(suite.Regions) > 0 {
+		return suite.Regions
+	}
+	return []string{suite.Region}
+}
+
+// exceptionKey identifies a policy finding for gcpPolicyExceptions lookups:
+// "<rule>:<resource>". PolicyFinding carries no resource field, so
+// EvaluateGCPPolicyCompliance passes the finding's Message as resource.
+func exceptionKey(rule, resource string) string {
+	return rule + ":" + resource
+}
+
+// EvaluateGCPPolicyCompliance evaluates BuildGCPResourceGraph against
+// LoadGCPPolicyBundle(gcpPolicyDir) and logs every finding with its rule,
+// severity and message (the built-in messages name the resource). Findings
+// whose exceptionKey is in gcpPolicyExceptions are logged but excluded from
+// the returned slice.
+func (suite *GCPTestSuite) EvaluateGCPPolicyCompliance() ([]PolicyFinding, error) {
+	graph, err := suite.BuildGCPResourceGraph()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build resource graph")
+	}
+
+	bundle, err := LoadGCPPolicyBundle(gcpPolicyDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load policy bundle")
+	}
+
+	findings, err := EvaluateRegoRules(suite.Context, graph, bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate policy bundle")
+	}
+
+	var failing []PolicyFinding
+	for _, finding := range findings {
+		suite.Logger.Error().Str("rule", finding.Rule).Str("severity", finding.Severity).
+			Msg(finding.Message)
+		if gcpPolicyExceptions[exceptionKey(finding.Rule, finding.Message)] {
+			continue
+		}
+		failing = append(failing, finding)
+	}
+	return failing, nil
+}
@@ -0,0 +1,343 @@
+package test
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/cloudsqlconn"
+	gcpmysql "cloud.google.com/go/cloudsqlconn/mysql/mysql"
+	"cloud.google.com/go/cloudsqlconn/postgres/pgxv4"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/container/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// cloudSQLDriverSeq gives every CloudSQLProber.Probe call its own
+// database/sql driver name, since database/sql panics if the same name is
+// registered twice.
+var cloudSQLDriverSeq int64
+
+func nextCloudSQLDriverName(engine string) string {
+	return fmt.Sprintf("cloudsql-%s-%d", engine, atomic.AddInt64(&cloudSQLDriverSeq, 1))
+}
+
+// CloudSQLProber pings a Cloud SQL instance through the Cloud SQL Go
+// connector, which IAM-authorizes and mTLS-encrypts the connection without
+// requiring a public IP or configured SSL certs, then runs SELECT 1 within
+// LatencyBudget.
+type CloudSQLProber struct {
+	InstanceConnectionName string
+	Engine                 string // "postgres" (default) or "mysql"
+	User                   string
+	Password               string
+	Database               string
+	LatencyBudget          time.Duration
+	MaxRetries             int
+}
+
+func (p CloudSQLProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.InstanceConnectionName, p.MaxRetries, func(ctx context.Context) error {
+		driverName, dsn, cleanup, err := p.registerDriver()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		db, err := sql.Open(driverName, dsn)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		queryCtx := ctx
+		if p.LatencyBudget > 0 {
+			var cancel context.CancelFunc
+			queryCtx, cancel = context.WithTimeout(ctx, p.LatencyBudget)
+			defer cancel()
+		}
+
+		var result int
+		if err := db.QueryRowContext(queryCtx, "SELECT 1").Scan(&result); err != nil {
+			return err
+		}
+		if result != 1 {
+			return errors.Errorf("unexpected SELECT 1 result %d from %s", result, p.InstanceConnectionName)
+		}
+		return nil
+	})(ctx)
+}
+
+// queryScalar opens a connection the same way Probe does and scans the
+// first column of query's first row into dest, honoring LatencyBudget as a
+// query timeout. Used by callers that need a value out of the instance
+// (e.g. replication lag) rather than a plain liveness check.
+func (p CloudSQLProber) queryScalar(ctx context.Context, query string, dest interface{}) error {
+	driverName, dsn, cleanup, err := p.registerDriver()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	queryCtx := ctx
+	if p.LatencyBudget > 0 {
+		var cancel context.CancelFunc
+		queryCtx, cancel = context.WithTimeout(ctx, p.LatencyBudget)
+		defer cancel()
+	}
+
+	return db.QueryRowContext(queryCtx, query).Scan(dest)
+}
+
+// registerDriver registers a fresh Cloud SQL connector driver for p.Engine
+// and returns its name, DSN, and the cleanup func the connector docs require
+// callers to invoke once the resulting *sql.DB is closed.
+func (p CloudSQLProber) registerDriver() (driverName, dsn string, cleanup func() error, err error) {
+	switch p.Engine {
+	case "mysql":
+		driverName = nextCloudSQLDriverName("mysql")
+		cleanup, err = gcpmysql.RegisterDriver(driverName, cloudsqlconn.WithIAMAuthN())
+		if err != nil {
+			return "", "", nil, errors.Wrap(err, "failed to register Cloud SQL MySQL driver")
+		}
+		dsn = fmt.Sprintf("%s:%s@%s(%s)/%s", p.User, p.Password, driverName, p.InstanceConnectionName, p.Database)
+		return driverName, dsn, cleanup, nil
+	case "postgres", "":
+		driverName = nextCloudSQLDriverName("postgres")
+		cleanup, err = pgxv4.RegisterDriver(driverName, cloudsqlconn.WithIAMAuthN())
+		if err != nil {
+			return "", "", nil, errors.Wrap(err, "failed to register Cloud SQL Postgres driver")
+		}
+		dsn = fmt.Sprintf("host=%s user=%s password=%s dbname=%s sslmode=disable", p.InstanceConnectionName, p.User, p.Password, p.Database)
+		return driverName, dsn, cleanup, nil
+	default:
+		return "", "", nil, errors.Errorf("unsupported Cloud SQL engine %q", p.Engine)
+	}
+}
+
+// HTTPLoadBalancerProber issues an HTTP GET against a GCP load balancer
+// frontend and asserts the status code, HTTP/2 negotiation, TLS certificate
+// chain, and (when set) that the response body contains ExpectedBody.
+type HTTPLoadBalancerProber struct {
+	URL                 string
+	ExpectedStatusCodes []int
+	ExpectedBody        string
+	RequireHTTP2        bool
+	TLSPolicy           TLSPolicy
+	MaxRetries          int
+	Timeout             time.Duration
+}
+
+func (p HTTPLoadBalancerProber) Probe(ctx context.Context) ProbeResult {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	expected := p.ExpectedStatusCodes
+	if len(expected) == 0 {
+		expected = []int{http.StatusOK}
+	}
+	client := &http.Client{Timeout: timeout}
+
+	return probeWithRetry(p.URL, p.MaxRetries, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		statusOK := false
+		for _, code := range expected {
+			if resp.StatusCode == code {
+				statusOK = true
+				break
+			}
+		}
+		if !statusOK {
+			return errors.Errorf("unexpected status code %d from %s", resp.StatusCode, p.URL)
+		}
+
+		if p.RequireHTTP2 && resp.ProtoMajor != 2 {
+			return errors.Errorf("expected HTTP/2 from %s, got %s", p.URL, resp.Proto)
+		}
+
+		if resp.TLS != nil {
+			if violations := AssertTLSCertificate(*resp.TLS, p.TLSPolicy); len(violations) > 0 {
+				return errors.Errorf("%d TLS policy violation(s) for %s: %v", len(violations), p.URL, violations)
+			}
+		}
+
+		if p.ExpectedBody != "" {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			if !strings.Contains(string(body), p.ExpectedBody) {
+				return errors.Errorf("response body from %s missing expected content %q", p.URL, p.ExpectedBody)
+			}
+		}
+
+		return nil
+	})(ctx)
+}
+
+// GKEHealthProber checks a GKE cluster's kube-apiserver liveness and
+// readiness endpoints through a client-go clientset, authenticated with the
+// ambient Application Default Credentials token.
+type GKEHealthProber struct {
+	APIServerURL string
+	CACert       []byte
+	MaxRetries   int
+}
+
+func (p GKEHealthProber) Probe(ctx context.Context) ProbeResult {
+	return probeWithRetry(p.APIServerURL, p.MaxRetries, func(ctx context.Context) error {
+		tokenSource, err := google.DefaultTokenSource(ctx, container.CloudPlatformScope)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain GKE credentials")
+		}
+		token, err := tokenSource.Token()
+		if err != nil {
+			return errors.Wrap(err, "failed to mint GKE access token")
+		}
+
+		config := &rest.Config{Host: p.APIServerURL, BearerToken: token.AccessToken}
+		if len(p.CACert) > 0 {
+			config.TLSClientConfig = rest.TLSClientConfig{CAData: p.CACert}
+		} else {
+			config.TLSClientConfig = rest.TLSClientConfig{Insecure: true}
+		}
+
+		clientset, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return errors.Wrap(err, "failed to build GKE clientset")
+		}
+
+		for _, path := range []string{"/livez", "/readyz"} {
+			if _, err := clientset.Discovery().RESTClient().Get().AbsPath(path).DoRaw(ctx); err != nil {
+				return errors.Wrapf(err, "%s check failed", path)
+			}
+		}
+		return nil
+	})(ctx)
+}
+
+// TestDatabaseHealth connects to a Cloud SQL instance through the Cloud SQL
+// Go connector and returns an error unless SELECT 1 succeeds within its
+// retry budget. endpoint is the instance connection name
+// ("project:region:instance").
+func (suite *GCPTestSuite) TestDatabaseHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database health")
+
+	engine := suite.Config.GCPDBEngine
+	if engine == "" {
+		engine = "postgres"
+	}
+	result := (CloudSQLProber{
+		InstanceConnectionName: endpoint,
+		Engine:                 engine,
+		User:                   suite.Config.GCPDBUser,
+		Password:               suite.Config.GCPDBPassword,
+		Database:               suite.Config.GCPDBName,
+		LatencyBudget:          5 * time.Second,
+		MaxRetries:             3,
+	}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "database", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "database health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestCacheHealth pings a Memorystore Redis endpoint and returns an error
+// unless the connection (optionally AUTH'd and TLS-wrapped) succeeds within
+// its retry budget.
+func (suite *GCPTestSuite) TestCacheHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing cache health")
+
+	result := (RedisProber{
+		Addr:       endpoint,
+		Password:   suite.Config.GCPCacheAuthString,
+		TLS:        suite.Config.GCPCacheTLS,
+		MaxRetries: 3,
+	}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "cache", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "cache health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestLoadBalancerHealth issues an HTTPS GET against a load balancer
+// frontend and asserts its status code, TLS certificate chain, and HTTP/2
+// negotiation.
+func (suite *GCPTestSuite) TestLoadBalancerHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing load balancer health")
+
+	result := (HTTPLoadBalancerProber{
+		URL:          endpoint,
+		RequireHTTP2: true,
+		TLSPolicy:    TLSPolicy{MinDaysRemaining: 30, MinVersion: tls.VersionTLS12},
+		MaxRetries:   3,
+	}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "load_balancer", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "load balancer health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestContainerServiceHealth checks a GKE cluster's kube-apiserver liveness
+// and readiness endpoints via client-go.
+func (suite *GCPTestSuite) TestContainerServiceHealth(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing container service health")
+
+	result := (GKEHealthProber{APIServerURL: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "container_service", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "container service health probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestHTTPConnectivity issues an HTTP GET and asserts a 2xx response.
+func (suite *GCPTestSuite) TestHTTPConnectivity(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing HTTP connectivity")
+
+	result := (HTTPProber{URL: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "http_connectivity", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "HTTP connectivity probe failed for %s", endpoint)
+	}
+	return nil
+}
+
+// TestInternalConnectivity dials an internal (VPC-scoped) TCP endpoint.
+func (suite *GCPTestSuite) TestInternalConnectivity(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing internal connectivity")
+
+	result := (TCPProber{Address: endpoint, MaxRetries: 3}).Probe(suite.Context)
+	logProbeResult(suite.Logger, "internal_connectivity", result)
+	if !result.Success {
+		return errors.Wrapf(result.Err, "internal connectivity probe failed for %s", endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/option"
+)
+
+// ServiceFactory builds one GCP API client, type-erased so a single registry
+// can hold factories for heterogeneous service types side by side.
+type ServiceFactory func(ctx context.Context, opts ...option.ClientOption) (interface{}, error)
+
+// serviceEntry lazily builds and caches the client a ServiceFactory
+// produces. sync.Once guarantees the (potentially credential-checking,
+// network-touching) factory runs at most once even under concurrent
+// t.Parallel() subtests.
+type serviceEntry struct {
+	factory  ServiceFactory
+	once     sync.Once
+	instance interface{}
+	err      error
+}
+
+// ServiceRegistry holds one ServiceFactory per GCP service type and builds
+// each client lazily, on first request, instead of NewGCPTestSuite eagerly
+// constructing every service up front. This mirrors how the Terraform
+// Google provider's Config lazily builds its clients.
+type ServiceRegistry struct {
+	ctx    context.Context
+	opts   []option.ClientOption
+	mu     sync.Mutex
+	byType map[reflect.Type]*serviceEntry
+}
+
+// NewServiceRegistry creates a registry that threads ctx and opts (e.g.
+// option.WithCredentialsFile, option.WithQuotaProject) through to every
+// registered factory.
+func NewServiceRegistry(ctx context.Context, opts ...option.ClientOption) *ServiceRegistry {
+	return &ServiceRegistry{
+		ctx:    ctx,
+		opts:   opts,
+		byType: make(map[reflect.Type]*serviceEntry),
+	}
+}
+
+// RegisterService registers factory as the way to build a T. It does not
+// invoke factory; the client is built on first GetService[T]/RequireService[T]
+// call.
+func RegisterService[T any](r *ServiceRegistry, factory func(ctx context.Context, opts ...option.ClientOption) (T, error)) {
+	key := reflect.TypeOf((*T)(nil)).Elem()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byType[key] = &serviceEntry{
+		factory: func(ctx context.Context, opts ...option.ClientOption) (interface{}, error) {
+			return factory(ctx, opts...)
+		},
+	}
+}
+
+// GetService returns the registry's T, building it via its registered
+// factory on first call and reusing that instance (or that error) on every
+// subsequent call.
+func GetService[T any](r *ServiceRegistry) (T, error) {
+	var zero T
+	key := reflect.TypeOf((*T)(nil)).Elem()
+
+	r.mu.Lock()
+	entry, ok := r.byType[key]
+	r.mu.Unlock()
+	if !ok {
+		return zero, errors.Errorf("no service factory registered for %s", key)
+	}
+
+	entry.once.Do(func() {
+		entry.instance, entry.err = entry.factory(r.ctx, r.opts...)
+	})
+	if entry.err != nil {
+		return zero, entry.err
+	}
+	return entry.instance.(T), nil
+}
+
+// RequireService builds the registry's T, failing t immediately with a
+// clear message if the factory errors, instead of letting a nil client
+// panic deeper in the test.
+func RequireService[T any](t *testing.T, r *ServiceRegistry) T {
+	t.Helper()
+	service, err := GetService[T](r)
+	if err != nil {
+		t.Fatalf("failed to build service %T: %v", service, err)
+	}
+	return service
+}
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ExecRecord is one intended API call a dry-run test recorded instead of
+// executing, following the NewDryRunTarget pattern from kops: enough to
+// preview which read APIs a run would touch and estimate quota impact
+// without credentials.
+type ExecRecord struct {
+	Method    string
+	Target    string
+	Timestamp time.Time
+}
+
+// RetryClass classifies a GCP API error for DoWithRetry's backoff loop.
+type RetryClass int
+
+const (
+	// NonRetryable errors (bad request, not found, permission denied, ...)
+	// should abort immediately instead of burning attempts.
+	NonRetryable RetryClass = iota
+	// Retryable errors are transient service-side failures expected to
+	// clear on their own (backend/internal errors, service unavailable).
+	Retryable
+	// QuotaExhausted errors are rate/quota limits; still worth retrying,
+	// but distinguished from Retryable so callers/logs can tell "GCP is
+	// unhealthy" apart from "we're going too fast".
+	QuotaExhausted
+)
+
+// RetryPolicy configures DoWithRetry's truncated exponential backoff.
+type RetryPolicy struct {
+	InitialDelay time.Duration
+	Factor       float64
+	MaxDelay     time.Duration
+	MaxAttempts  int
+}
+
+// defaultRetryPolicy backs off from 1s by a factor of 2, capped at 60s, for
+// up to 10 attempts.
+var defaultRetryPolicy = RetryPolicy{
+	InitialDelay: 1 * time.Second,
+	Factor:       2,
+	MaxDelay:     60 * time.Second,
+	MaxAttempts:  10,
+}
+
+// retryableReasons are googleapi.Error.Errors[].Reason values that indicate
+// a transient, service-side failure.
+var retryableReasons = map[string]bool{
+	"backendError":       true,
+	"internalError":      true,
+	"serviceUnavailable": true,
+}
+
+// quotaReasons are googleapi.Error.Errors[].Reason values that indicate the
+// caller is being rate- or quota-limited rather than GCP itself failing.
+var quotaReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+}
+
+// ClassifyRetryError classifies err for DoWithRetry's backoff loop. Non-API
+// errors, and API errors whose code/reason aren't recognized as transient,
+// are NonRetryable.
+func ClassifyRetryError(err error) RetryClass {
+	if err == nil {
+		return NonRetryable
+	}
+
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok {
+		return NonRetryable
+	}
+
+	for _, e := range apiErr.Errors {
+		if quotaReasons[e.Reason] {
+			return QuotaExhausted
+		}
+		if retryableReasons[e.Reason] {
+			return Retryable
+		}
+	}
+
+	switch apiErr.Code {
+	case 429:
+		return QuotaExhausted
+	case 500, 502, 503:
+		return Retryable
+	default:
+		return NonRetryable
+	}
+}
+
+// retryAfter returns the delay googleapi.Error's Retry-After header
+// requests, or zero if err isn't an API error or carries no such header.
+func retryAfter(err error) time.Duration {
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok || apiErr.Header == nil {
+		return 0
+	}
+	raw := apiErr.Header.Get("Retry-After")
+	if raw == "" {
+		return 0
+	}
+	if seconds, parseErr := strconv.Atoi(raw); parseErr == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// DoWithRetry runs fn under defaultRetryPolicy's truncated exponential
+// backoff with jitter, retrying on Retryable and QuotaExhausted errors
+// (honoring a Retry-After header when the API supplies one) and giving up
+// immediately on a NonRetryable error. name identifies the call and target
+// the resource it addresses (project/region/zone/resource path) in the
+// structured log events emitted per attempt, so a failed CI run can be
+// triaged from logs alone.
+//
+// In suite.DryRun mode, fn is never invoked: the call is appended to
+// suite.ExecLog instead, so a run can preview which read APIs it would
+// touch without credentials.
+func (suite *GCPTestSuite) DoWithRetry(name, target string, fn func() error) error {
+	if suite.DryRun {
+		suite.recordExec(name, target)
+		return nil
+	}
+
+	policy := defaultRetryPolicy
+	delay := policy.InitialDelay
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		err := fn()
+		if err == nil {
+			if attempt > 1 {
+				suite.Logger.Info().Str("call", name).Int("attempt", attempt).Msg("Call succeeded after retry")
+			}
+			return nil
+		}
+		lastErr = err
+
+		class := ClassifyRetryError(err)
+		event := suite.Logger.Warn().Str("call", name).Int("attempt", attempt).Err(err)
+		switch class {
+		case NonRetryable:
+			event.Str("classification", "non_retryable").Msg("Call failed with a non-retryable error")
+			return err
+		case QuotaExhausted:
+			event.Str("classification", "quota_exhausted")
+		default:
+			event.Str("classification", "retryable")
+		}
+
+		if attempt == policy.MaxAttempts {
+			event.Msg("Call failed on final attempt")
+			break
+		}
+
+		wait := delay
+		if after := retryAfter(err); after > 0 {
+			wait = after
+		}
+		wait += time.Duration(rand.Int63n(int64(wait) + 1))
+
+		event.Dur("next_delay", wait).Msg("Retrying call after backoff")
+		time.Sleep(wait)
+
+		delay = time.Duration(float64(delay) * policy.Factor)
+		if delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+
+	return lastErr
+}
+
+// recordExec appends one ExecRecord to the suite's execution log.
+func (suite *GCPTestSuite) recordExec(method, target string) {
+	suite.execMu.Lock()
+	defer suite.execMu.Unlock()
+	suite.ExecLog = append(suite.ExecLog, ExecRecord{Method: method, Target: target, Timestamp: time.Now()})
+}
+
+// DumpExecLog renders the suite's recorded dry-run execution log as an
+// ordered, human-readable list of the calls a real run would have made, for
+// previewing API/quota impact and diffing between commits without
+// credentials.
+func (suite *GCPTestSuite) DumpExecLog() string {
+	suite.execMu.Lock()
+	defer suite.execMu.Unlock()
+
+	var b strings.Builder
+	for i, rec := range suite.ExecLog {
+		fmt.Fprintf(&b, "%3d. [%s] %s -> %s\n", i+1, rec.Timestamp.Format(time.RFC3339), rec.Method, rec.Target)
+	}
+	return b.String()
+}
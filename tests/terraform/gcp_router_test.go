@@ -0,0 +1,141 @@
+package test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/compute/v1"
+)
+
+// routerBgpConvergeTimeout bounds how long TestCloudRouter waits for an
+// enabled BGP peer to reach the "Established" state, matching the window
+// hybrid-cloud connectivity (VPN/Interconnect) sessions typically need to
+// converge after a router is created or updated.
+const routerBgpConvergeTimeout = 5 * time.Minute
+
+// routerBgpPollInterval is the fixed delay between GetRouterStatus polls
+// while waiting for a peer to converge.
+const routerBgpPollInterval = 10 * time.Second
+
+// TestCloudRouter validates Cloud Router configuration and BGP peering
+// health for hybrid-cloud connectivity (VPN tunnels and Interconnect
+// attachments), run as a sub-stage of TestVPC alongside the other network
+// primitives.
+func (suite *GCPTestSuite) TestCloudRouter(t *testing.T) {
+	t.Run("Cloud Router", func(t *testing.T) {
+		routers, err := suite.Compute.Routers.List(suite.ProjectID, suite.Region).Context(suite.Context).Do()
+		require.NoError(t, err)
+
+		for _, router := range routers.Items {
+			// Test network
+			assert.NotEmpty(t, router.Network, "Router should belong to a network")
+
+			// Test interfaces
+			assert.True(t, len(router.Interface) > 0, "Router should have at least one interface")
+			for _, iface := range router.Interface {
+				hasLink := iface.LinkedVpnTunnel != "" || iface.LinkedInterconnectAttachment != ""
+				assert.True(t, hasLink, "Router interface %s should be bound to a VPN tunnel or interconnect attachment", iface.Name)
+			}
+
+			// Test BGP peers
+			for _, peer := range router.BgpPeers {
+				assert.True(t, peer.PeerAsn > 0, "BGP peer %s should have a positive peer ASN", peer.Name)
+				assert.Contains(t, []string{"DEFAULT", "CUSTOM"}, peer.AdvertiseMode,
+					"BGP peer %s should have a valid advertise mode", peer.Name)
+				assertPeerLinkInSameSlash30(t, peer)
+			}
+
+			suite.assertRouterBgpSessionsEstablished(t, router)
+		}
+	})
+}
+
+// assertPeerLinkInSameSlash30 fails t unless peer's IpAddress and
+// PeerIpAddress fall within the same /30, the point-to-point subnet size
+// Cloud Router expects for a BGP session.
+func assertPeerLinkInSameSlash30(t *testing.T, peer *compute.RouterBgpPeer) {
+	t.Helper()
+
+	ip := net.ParseIP(peer.IpAddress)
+	peerIP := net.ParseIP(peer.PeerIpAddress)
+	if !assert.NotNil(t, ip, "BGP peer %s should have a parseable IP address", peer.Name) ||
+		!assert.NotNil(t, peerIP, "BGP peer %s should have a parseable peer IP address", peer.Name) {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(peer.IpAddress + "/30")
+	require.NoError(t, err, "BGP peer %s IP address should form a valid /30", peer.Name)
+	assert.True(t, network.Contains(peerIP),
+		"BGP peer %s: IpAddress %s and PeerIpAddress %s should be in the same /30", peer.Name, peer.IpAddress, peer.PeerIpAddress)
+}
+
+// assertRouterBgpSessionsEstablished polls GetRouterStatus until every
+// enabled BGP peer on router reports State == "Established", polling every
+// routerBgpPollInterval for up to routerBgpConvergeTimeout to allow sessions
+// time to converge.
+func (suite *GCPTestSuite) assertRouterBgpSessionsEstablished(t *testing.T, router *compute.Router) {
+	t.Helper()
+
+	enabledPeers := make(map[string]bool)
+	for _, peer := range router.BgpPeers {
+		if peer.Enable == "TRUE" {
+			enabledPeers[peer.Name] = true
+		}
+	}
+	if len(enabledPeers) == 0 {
+		return
+	}
+
+	deadline := time.Now().Add(routerBgpConvergeTimeout)
+	interval := routerBgpPollInterval
+
+	for {
+		states, err := suite.routerBgpPeerStates(router)
+		require.NoError(t, err)
+
+		allEstablished := true
+		for name := range enabledPeers {
+			if states[name] != "Established" {
+				allEstablished = false
+				break
+			}
+		}
+		if allEstablished {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			for name := range enabledPeers {
+				assert.Equal(t, "Established", states[name],
+					"BGP peer %s on router %s did not converge within %s", name, router.Name, routerBgpConvergeTimeout)
+			}
+			return
+		}
+
+		suite.Logger.Debug().Str("router", router.Name).Dur("next_poll", interval).
+			Msg("Waiting for BGP sessions to converge")
+		time.Sleep(interval)
+	}
+}
+
+// routerBgpPeerStates fetches router's live status and returns each BGP
+// peer's session state keyed by peer name.
+func (suite *GCPTestSuite) routerBgpPeerStates(router *compute.Router) (map[string]string, error) {
+	status, err := suite.Compute.Routers.GetRouterStatus(suite.ProjectID, suite.Region, router.Name).Context(suite.Context).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get router status for %s", router.Name)
+	}
+	if status.Result == nil {
+		return nil, errors.Errorf("router status for %s had no result", router.Name)
+	}
+
+	states := make(map[string]string, len(status.Result.BgpPeerStatus))
+	for _, peerStatus := range status.Result.BgpPeerStatus {
+		states[peerStatus.Name] = peerStatus.State
+	}
+	return states, nil
+}
@@ -0,0 +1,238 @@
+package test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// weakCipherSuites are cipher suite names TestSSLConfiguration rejects even
+// when the negotiated TLS version itself is acceptable.
+var weakCipherSuites = map[string]bool{
+	"TLS_RSA_WITH_RC4_128_SHA":           true,
+	"TLS_ECDHE_RSA_WITH_RC4_128_SHA":     true,
+	"TLS_RSA_WITH_3DES_EDE_CBC_SHA":      true,
+	"TLS_RSA_WITH_AES_128_CBC_SHA":       true,
+	"TLS_RSA_WITH_AES_256_CBC_SHA":       true,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA": true,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA": true,
+}
+
+// gcpTrustedCAOrgs are the certificate-authority organization names an
+// endpoint's chain must terminate at. trustedCAOrgs always returns this
+// list; no configuration overrides it.
+var gcpTrustedCAOrgs = []string{"Google Trust Services", "GTS"}
+
+// SSLFinding is one endpoint's TLS/HTTP-hardening check result.
+type SSLFinding struct {
+	Endpoint    string   `json:"endpoint"`
+	TLSVersion  string   `json:"tls_version"`
+	CipherSuite string   `json:"cipher_suite"`
+	ALPN        string   `json:"alpn"`
+	Grade       string   `json:"grade"`
+	Violations  []string `json:"violations"`
+}
+
+// tlsVersionName maps a crypto/tls version constant to its human name.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS1.0"
+	case tls.VersionTLS11:
+		return "TLS1.1"
+	case tls.VersionTLS12:
+		return "TLS1.2"
+	case tls.VersionTLS13:
+		return "TLS1.3"
+	default:
+		return "unknown"
+	}
+}
+
+// AssertEndpointTLS dials endpoint (host:port, defaulting to :443), records
+// the negotiated version/cipher/ALPN and full peer chain, and checks it
+// against policy plus GCP-specific hardening: no weak ciphers, chain
+// terminates at a Google Trust Services CA (gcpTrustedCAOrgs), and SNI/SAN
+// match.
+func AssertEndpointTLS(endpoint string, policy TLSPolicy) (SSLFinding, error) {
+	address := endpoint
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(endpoint, "443")
+	}
+	host := hostOnly(address)
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: host, NextProtos: []string{"h2", "http/1.1"}})
+	if err != nil {
+		return SSLFinding{}, errors.Wrapf(err, "TLS handshake failed for %s", address)
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	finding := SSLFinding{
+		Endpoint:    address,
+		TLSVersion:  tlsVersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ALPN:        state.NegotiatedProtocol,
+	}
+
+	for _, violation := range AssertTLSCertificate(state, policy) {
+		finding.Violations = append(finding.Violations, violation.Error())
+	}
+	if weakCipherSuites[finding.CipherSuite] {
+		finding.Violations = append(finding.Violations, "negotiated weak cipher suite "+finding.CipherSuite)
+	}
+	if !sanMatches(state.PeerCertificates[0], host) && !hostMatchesWildcard(state.PeerCertificates[0].DNSNames, host) {
+		finding.Violations = append(finding.Violations, "certificate SAN does not match endpoint "+host)
+	}
+	if !chainTrustedBy(state.PeerCertificates, trustedCAOrgs(policy)) {
+		finding.Violations = append(finding.Violations, "certificate chain does not terminate at a trusted CA")
+	}
+
+	finding.Grade = gradeFinding(finding)
+	return finding, nil
+}
+
+func trustedCAOrgs(policy TLSPolicy) []string {
+	return gcpTrustedCAOrgs
+}
+
+// hostMatchesWildcard reports whether host matches any of names, allowing a
+// single leading "*." wildcard label per RFC 6125 6.4.3.
+func hostMatchesWildcard(names []string, host string) bool {
+	for _, name := range names {
+		if !strings.HasPrefix(name, "*.") {
+			continue
+		}
+		suffix := name[1:] // ".example.com"
+		if strings.HasSuffix(host, suffix) && strings.Count(host, ".") == strings.Count(name, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// chainTrustedBy reports whether any certificate in the presented chain
+// (the peer's own chain terminates at whatever root its issuer trusts) was
+// issued by one of the given CA organizations.
+func chainTrustedBy(certs []*x509.Certificate, orgs []string) bool {
+	for _, cert := range certs {
+		for _, org := range cert.Issuer.Organization {
+			for _, want := range orgs {
+				if strings.Contains(org, want) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// AssertHSTSAndRedirect fails unless endpoint serves a Strict-Transport-Security
+// header on HTTPS and redirects plain HTTP (:80) to HTTPS.
+func AssertHSTSAndRedirect(host string) []string {
+	var violations []string
+	client := &http.Client{Timeout: 10 * time.Second, CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	httpsResp, err := client.Get("https://" + host + "/")
+	if err != nil {
+		return []string{errors.Wrapf(err, "HTTPS request to %s failed", host).Error()}
+	}
+	defer httpsResp.Body.Close()
+	if httpsResp.Header.Get("Strict-Transport-Security") == "" {
+		violations = append(violations, "missing Strict-Transport-Security header")
+	}
+
+	httpResp, err := client.Get("http://" + host + "/")
+	if err != nil {
+		violations = append(violations, errors.Wrapf(err, "HTTP request to %s failed", host).Error())
+		return violations
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode < 300 || httpResp.StatusCode >= 400 {
+		violations = append(violations, "port 80 does not redirect to HTTPS")
+	} else if loc := httpResp.Header.Get("Location"); !strings.HasPrefix(loc, "https://") {
+		violations = append(violations, "port 80 redirect target is not HTTPS: "+loc)
+	}
+	return violations
+}
+
+// gradeFinding assigns a Qualys-SSL-Labs-style letter grade: A+ for TLS 1.3
+// and A for TLS 1.2 with no violations, C for other violations, down to F
+// for any weak-cipher or chain-of-trust violation.
+func gradeFinding(f SSLFinding) string {
+	if len(f.Violations) == 0 && (f.TLSVersion == "TLS1.2" || f.TLSVersion == "TLS1.3") {
+		if f.TLSVersion == "TLS1.3" {
+			return "A+"
+		}
+		return "A"
+	}
+	for _, v := range f.Violations {
+		if strings.Contains(v, "weak cipher") || strings.Contains(v, "trusted CA") {
+			return "F"
+		}
+	}
+	if len(f.Violations) > 0 {
+		return "C"
+	}
+	return "B"
+}
+
+// TestSSLConfiguration dials every HTTPS endpoint in outputs (keys ending in
+// "_endpoint"), asserts TLS 1.2+, no weak ciphers, valid/matching/unexpired
+// certificate chain, HSTS and the :80 -> :443 redirect, and logs a
+// per-endpoint scorecard grade. It evaluates and logs every endpoint, then
+// fails with an error listing every endpoint that had a violation.
+func (suite *GCPTestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing SSL configuration")
+
+	var endpoints []string
+	for key, value := range outputs {
+		if !strings.HasSuffix(key, "_endpoint") {
+			continue
+		}
+		if endpoint, ok := value.(string); ok && endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return errors.New("outputs contain no *_endpoint values to test SSL configuration against")
+	}
+
+	policy := TLSPolicy{MinDaysRemaining: 30, MinVersion: tls.VersionTLS12}
+	var failed []string
+	for _, endpoint := range endpoints {
+		finding, err := AssertEndpointTLS(endpoint, policy)
+		if err != nil {
+			failed = append(failed, endpoint)
+			suite.Logger.Error().Str("endpoint", endpoint).Err(err).Msg("TLS handshake failed")
+			continue
+		}
+		finding.Violations = append(finding.Violations, AssertHSTSAndRedirect(hostOnly(finding.Endpoint))...)
+
+		event := suite.Logger.Info()
+		if len(finding.Violations) > 0 {
+			event = suite.Logger.Error()
+			failed = append(failed, endpoint)
+		}
+		event.Str("endpoint", finding.Endpoint).
+			Str("tls_version", finding.TLSVersion).
+			Str("cipher_suite", finding.CipherSuite).
+			Str("alpn", finding.ALPN).
+			Str("grade", finding.Grade).
+			Strs("violations", finding.Violations).
+			Msg("SSL configuration checked")
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("SSL configuration violations on endpoint(s): %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
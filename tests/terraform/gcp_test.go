@@ -3,235 +3,261 @@ package test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
-	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/sql/v1"
-	"google.golang.org/api/storage/v1"
-	"google.golang.org/api/container/v1"
-	"google.golang.org/api/cloudresourcemanager/v1"
-	"google.golang.org/api/iam/v1"
-	"google.golang.org/api/monitoring/v1"
-	"google.golang.org/api/logging/v2"
-	"google.golang.org/api/cloudfunctions/v1"
-	"google.golang.org/api/run/v1"
-	"google.golang.org/api/cloudkms/v1"
-	"google.golang.org/api/secretmanager/v1"
-	"google.golang.org/api/dns/v1"
-	"google.golang.org/api/cloudsecurity/v1"
-	"google.golang.org/api/securitycenter/v1"
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/accesscontextmanager/v1"
+	"google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/admin/reports/v1"
+	"google.golang.org/api/aiplatform/v1"
+	"google.golang.org/api/analyticsadmin/v1beta"
+	"google.golang.org/api/analyticsdata/v1beta"
+	"google.golang.org/api/apigateway/v1"
+	"google.golang.org/api/apigee/v1"
+	"google.golang.org/api/appengine/v1"
+	"google.golang.org/api/artifactregistry/v1"
+	"google.golang.org/api/assuredworkloads/v1"
+	"google.golang.org/api/automl/v1"
+	"google.golang.org/api/backupdr/v1"
+	"google.golang.org/api/baremetalsolution/v2"
+	"google.golang.org/api/batch/v1"
 	"google.golang.org/api/bigquery/v2"
-	"google.golang.org/api/dataflow/v1b3"
-	"google.golang.org/api/pubsub/v1"
-	"google.golang.org/api/firebase/v1beta1"
-	"google.golang.org/api/firestore/v1"
-	"google.golang.org/api/redis/v1"
-	"google.golang.org/api/memcache/v1"
 	"google.golang.org/api/bigtable/v2"
-	"google.golang.org/api/spanner/v1"
-	"google.golang.org/api/datastore/v1"
-	"google.golang.org/api/cloudbuild/v1"
-	"google.golang.org/api/sourcerepo/v1"
-	"google.golang.org/api/artifactregistry/v1"
+	"google.golang.org/api/billingbudgets/v1"
 	"google.golang.org/api/binaryauthorization/v1"
-	"google.golang.org/api/cloudtrace/v2"
+	"google.golang.org/api/certificatemanager/v1"
+	"google.golang.org/api/cloudasset/v1"
+	"google.golang.org/api/cloudbilling/v1"
+	"google.golang.org/api/cloudbuild/v1"
+	"google.golang.org/api/cloudchannel/v1"
 	"google.golang.org/api/clouddebugger/v2"
+	"google.golang.org/api/clouddms/v1"
+	"google.golang.org/api/cloudfunctions/v1"
+	"google.golang.org/api/cloudidentity/v1"
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/cloudoptimization/v1"
 	"google.golang.org/api/cloudprofiler/v2"
+	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/cloudscheduler/v1"
+	"google.golang.org/api/cloudsecurity/v1"
+	"google.golang.org/api/cloudsupport/v2"
 	"google.golang.org/api/cloudtasks/v2"
-	"google.golang.org/api/appengine/v1"
-	"google.golang.org/api/gameservices/v1"
-	"google.golang.org/api/notebooks/v1"
-	"google.golang.org/api/aiplatform/v1"
-	"google.golang.org/api/ml/v1"
-	"google.golang.org/api/translate/v3"
-	"google.golang.org/api/speech/v1"
-	"google.golang.org/api/vision/v1"
-	"google.golang.org/api/videointelligence/v1"
-	"google.golang.org/api/language/v1"
-	"google.golang.org/api/documentai/v1"
-	"google.golang.org/api/automl/v1"
-	"google.golang.org/api/dialogflow/v2"
-	"google.golang.org/api/healthcare/v1"
-	"google.golang.org/api/lifesciences/v2beta"
-	"google.golang.org/api/genomics/v2alpha1"
+	"google.golang.org/api/cloudtrace/v2"
+	"google.golang.org/api/composer/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
 	"google.golang.org/api/datacatalog/v1"
+	"google.golang.org/api/dataflow/v1b3"
 	"google.golang.org/api/datafusion/v1"
-	"google.golang.org/api/dataproc/v1"
-	"google.golang.org/api/composer/v1"
-	"google.golang.org/api/workflows/v1"
-	"google.golang.org/api/eventarc/v1"
-	"google.golang.org/api/apigateway/v1"
-	"google.golang.org/api/apigee/v1"
-	"google.golang.org/api/endpoints/v1"
-	"google.golang.org/api/servicemanagement/v1"
-	"google.golang.org/api/servicecontrol/v1"
-	"google.golang.org/api/serviceusage/v1"
-	"google.golang.org/api/servicenetworking/v1"
-	"google.golang.org/api/osconfig/v1"
-	"google.golang.org/api/assuredworkloads/v1"
-	"google.golang.org/api/accesscontextmanager/v1"
-	"google.golang.org/api/privateca/v1"
-	"google.golang.org/api/certificatemanager/v1"
-	"google.golang.org/api/iap/v1"
-	"google.golang.org/api/recaptchaenterprise/v1"
-	"google.golang.org/api/websecurityscanner/v1"
-	"google.golang.org/api/cloudidentity/v1"
-	"google.golang.org/api/admin/directory/v1"
-	"google.golang.org/api/admin/reports/v1"
-	"google.golang.org/api/cloudchannel/v1"
-	"google.golang.org/api/cloudbilling/v1"
-	"google.golang.org/api/recommender/v1"
-	"google.golang.org/api/cloudoptimization/v1"
-	"google.golang.org/api/policytroubleshooter/v1"
-	"google.golang.org/api/orgpolicy/v2"
-	"google.golang.org/api/cloudasset/v1"
-	"google.golang.org/api/cloudsupport/v2"
-	"google.golang.org/api/essentialcontacts/v1"
-	"google.golang.org/api/workstations/v1"
-	"google.golang.org/api/batch/v1"
-	"google.golang.org/api/file/v1"
-	"google.golang.org/api/networkservices/v1"
-	"google.golang.org/api/networksecurity/v1"
-	"google.golang.org/api/vmmigration/v1"
-	"google.golang.org/api/baremetalsolution/v2"
-	"google.golang.org/api/vmwareengine/v1"
-	"google.golang.org/api/gkehub/v1"
-	"google.golang.org/api/gkebackup/v1"
-	"google.golang.org/api/backupdr/v1"
-	"google.golang.org/api/migrationcenter/v1"
-	"google.golang.org/api/discoveryengine/v1"
-	"google.golang.org/api/retail/v2"
-	"google.golang.org/api/recommendationengine/v1beta1"
-	"google.golang.org/api/dataplex/v1"
 	"google.golang.org/api/datalineage/v1"
 	"google.golang.org/api/datapipelines/v1"
+	"google.golang.org/api/dataplex/v1"
+	"google.golang.org/api/dataproc/v1"
+	"google.golang.org/api/datastore/v1"
 	"google.golang.org/api/datastream/v1"
-	"google.golang.org/api/clouddms/v1"
-	"google.golang.org/api/metastore/v1"
-	"google.golang.org/api/analyticsdata/v1beta"
-	"google.golang.org/api/analyticsadmin/v1beta"
-	"google.golang.org/api/firebasehosting/v1"
-	"google.golang.org/api/firebasedatabase/v1beta"
-	"google.golang.org/api/firebaseremoteconfig/v1"
-	"google.golang.org/api/firebasestorage/v1beta"
+	"google.golang.org/api/dialogflow/v2"
+	"google.golang.org/api/discoveryengine/v1"
+	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/documentai/v1"
+	"google.golang.org/api/endpoints/v1"
+	"google.golang.org/api/essentialcontacts/v1"
+	"google.golang.org/api/eventarc/v1"
 	"google.golang.org/api/fcm/v1"
+	"google.golang.org/api/file/v1"
+	"google.golang.org/api/firebase/v1beta1"
 	"google.golang.org/api/firebaseappcheck/v1"
+	"google.golang.org/api/firebasedatabase/v1beta"
 	"google.golang.org/api/firebasedynamiclinks/v1"
+	"google.golang.org/api/firebasehosting/v1"
 	"google.golang.org/api/firebaseml/v1"
-	"google.golang.org/api/firebase/v1beta1"
+	"google.golang.org/api/firebaseremoteconfig/v1"
+	"google.golang.org/api/firebasestorage/v1beta"
+	"google.golang.org/api/firestore/v1"
+	"google.golang.org/api/gameservices/v1"
+	"google.golang.org/api/genomics/v2alpha1"
+	"google.golang.org/api/gkebackup/v1"
+	"google.golang.org/api/gkehub/v1"
+	"google.golang.org/api/healthcare/v1"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/iap/v1"
+	"google.golang.org/api/language/v1"
+	"google.golang.org/api/lifesciences/v2beta"
+	"google.golang.org/api/logging/v2"
+	"google.golang.org/api/memcache/v1"
+	"google.golang.org/api/metastore/v1"
+	"google.golang.org/api/migrationcenter/v1"
+	"google.golang.org/api/ml/v1"
+	"google.golang.org/api/monitoring/v1"
+	"google.golang.org/api/networksecurity/v1"
+	"google.golang.org/api/networkservices/v1"
+	"google.golang.org/api/notebooks/v1"
 	"google.golang.org/api/option"
-	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/gruntwork-io/terratest/modules/test-structure"
-	"github.com/gruntwork-io/terratest/modules/retry"
-	"github.com/gruntwork-io/terratest/modules/logger"
-	"github.com/gruntwork-io/terratest/modules/http-helper"
-	"github.com/gruntwork-io/terratest/modules/random"
-	"github.com/gruntwork-io/terratest/modules/files"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"github.com/rs/zerolog/log"
-	"github.com/pkg/errors"
-	"github.com/google/uuid"
+	"google.golang.org/api/orgpolicy/v2"
+	"google.golang.org/api/osconfig/v1"
+	"google.golang.org/api/policytroubleshooter/v1"
+	"google.golang.org/api/privateca/v1"
+	"google.golang.org/api/pubsub/v1"
+	"google.golang.org/api/recaptchaenterprise/v1"
+	"google.golang.org/api/recommendationengine/v1beta1"
+	"google.golang.org/api/recommender/v1"
+	"google.golang.org/api/redis/v1"
+	"google.golang.org/api/retail/v2"
+	"google.golang.org/api/run/v1"
+	"google.golang.org/api/secretmanager/v1"
+	"google.golang.org/api/securitycenter/v1"
+	"google.golang.org/api/servicecontrol/v1"
+	"google.golang.org/api/servicemanagement/v1"
+	"google.golang.org/api/servicenetworking/v1"
+	"google.golang.org/api/serviceusage/v1"
+	"google.golang.org/api/sourcerepo/v1"
+	"google.golang.org/api/spanner/v1"
+	"google.golang.org/api/speech/v1"
+	"google.golang.org/api/sql/v1"
+	"google.golang.org/api/storage/v1"
+	"google.golang.org/api/translate/v3"
+	"google.golang.org/api/videointelligence/v1"
+	"google.golang.org/api/vision/v1"
+	"google.golang.org/api/vmmigration/v1"
+	"google.golang.org/api/vmwareengine/v1"
+	"google.golang.org/api/websecurityscanner/v1"
+	"google.golang.org/api/workflows/v1"
+	"google.golang.org/api/workstations/v1"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iamsynth"
 )
 
 // GCPTestSuite manages GCP-specific infrastructure tests
 type GCPTestSuite struct {
-	ProjectID  string
-	Region     string
-	Zone       string
-	TestID     string
-	Config     TestConfig
-	Logger     zerolog.Logger
-	Context    context.Context
-	
+	ProjectID string
+	Region    string
+	Zone      string
+	// Zones and Regions are the localities zonal/regional subtests fan out
+	// across as t.Run(locality, ...) subtests. When left unconfigured they
+	// are auto-discovered from the project via ResolveZones/ResolveRegions
+	// on first use, so global fleets are exercised everywhere instead of
+	// just Zone/Region.
+	Zones        []string
+	Regions      []string
+	TestID       string
+	Config       TestConfig
+	Logger       zerolog.Logger
+	Context      context.Context
+	Registry     *ServiceRegistry
+	MaxListPages int
+
+	// DryRun, when true, makes exec-wrapped calls (see DoWithRetry) record
+	// the call they would have made into ExecLog instead of hitting GCP.
+	DryRun  bool
+	ExecLog []ExecRecord
+	execMu  sync.Mutex
+
+	// Concurrency bounds ParallelForEach's fan-out and per-service rate
+	// limiting for nested per-resource list loops.
+	Concurrency SuiteConfig
+	limiters    map[string]*rate.Limiter
+	limitersMu  sync.Mutex
+
 	// Core services
-	Compute    *compute.Service
-	SQL        *sql.Service
-	Storage    *storage.Service
-	Container  *container.Service
-	
+	Compute   *compute.Service
+	SQL       *sql.Service
+	Storage   *storage.Service
+	Container *container.Service
+
 	// Management services
 	CloudResourceManager *cloudresourcemanager.Service
 	IAM                  *iam.Service
-	
+
 	// Monitoring and logging
 	Monitoring *monitoring.Service
 	Logging    *logging.Service
-	
+
 	// Serverless services
 	CloudFunctions *cloudfunctions.Service
 	CloudRun       *run.Service
 	AppEngine      *appengine.Service
-	
+
 	// Security services
 	CloudKMS       *cloudkms.Service
 	SecretManager  *secretmanager.Service
 	SecurityCenter *securitycenter.Service
-	
+
 	// Network services
-	DNS           *dns.Service
-	
+	DNS *dns.Service
+
 	// Data services
-	BigQuery      *bigquery.Service
-	Dataflow      *dataflow.Service
-	PubSub        *pubsub.Service
-	Firebase      *firebase.Service
-	Firestore     *firestore.Service
-	Redis         *redis.Service
-	Memcache      *memcache.Service
-	Bigtable      *bigtable.Service
-	Spanner       *spanner.Service
-	Datastore     *datastore.Service
-	
+	BigQuery  *bigquery.Service
+	Dataflow  *dataflow.Service
+	PubSub    *pubsub.Service
+	Firebase  *firebase.Service
+	Firestore *firestore.Service
+	Redis     *redis.Service
+	Memcache  *memcache.Service
+	Bigtable  *bigtable.Service
+	Spanner   *spanner.Service
+	Datastore *datastore.Service
+
 	// DevOps services
-	CloudBuild         *cloudbuild.Service
-	SourceRepo         *sourcerepo.Service
-	ArtifactRegistry   *artifactregistry.Service
+	CloudBuild          *cloudbuild.Service
+	SourceRepo          *sourcerepo.Service
+	ArtifactRegistry    *artifactregistry.Service
 	BinaryAuthorization *binaryauthorization.Service
-	
+
 	// Observability services
 	CloudTrace    *cloudtrace.Service
 	CloudDebugger *clouddebugger.Service
 	CloudProfiler *cloudprofiler.Service
-	
+
 	// Workflow services
 	CloudScheduler *cloudscheduler.Service
 	CloudTasks     *cloudtasks.Service
-	
+
 	// Game services
 	GameServices *gameservices.Service
-	
+
 	// AI/ML services
-	Notebooks   *notebooks.Service
-	AIPlatform  *aiplatform.Service
-	ML          *ml.Service
-	Translate   *translate.Service
-	Speech      *speech.Service
-	Vision      *vision.Service
+	Notebooks         *notebooks.Service
+	AIPlatform        *aiplatform.Service
+	ML                *ml.Service
+	Translate         *translate.Service
+	Speech            *speech.Service
+	Vision            *vision.Service
 	VideoIntelligence *videointelligence.Service
-	Language    *language.Service
-	DocumentAI *documentai.Service
-	AutoML      *automl.Service
-	Dialogflow  *dialogflow.Service
-	
+	Language          *language.Service
+	DocumentAI        *documentai.Service
+	AutoML            *automl.Service
+	Dialogflow        *dialogflow.Service
+
 	// Healthcare and life sciences
 	Healthcare   *healthcare.Service
 	LifeSciences *lifesciences.Service
 	Genomics     *genomics.Service
-	
+
 	// Data analytics
 	DataCatalog *datacatalog.Service
 	DataFusion  *datafusion.Service
 	DataProc    *dataproc.Service
 	Composer    *composer.Service
-	
+
 	// Workflow and integration
 	Workflows *workflows.Service
 	EventArc  *eventarc.Service
-	
+
 	// API management
 	APIGateway        *apigateway.Service
 	Apigee            *apigee.Service
@@ -240,78 +266,79 @@ type GCPTestSuite struct {
 	ServiceControl    *servicecontrol.Service
 	ServiceUsage      *serviceusage.Service
 	ServiceNetworking *servicenetworking.Service
-	
+
 	// Operations
 	OSConfig *osconfig.Service
-	
+
 	// Security and compliance
-	AssuredWorkloads        *assuredworkloads.Service
-	AccessContextManager    *accesscontextmanager.Service
-	PrivateCA              *privateca.Service
-	CertificateManager     *certificatemanager.Service
-	IAP                    *iap.Service
-	reCAPTCHAEnterprise    *recaptchaenterprise.Service
-	WebSecurityScanner     *websecurityscanner.Service
-	
+	AssuredWorkloads     *assuredworkloads.Service
+	AccessContextManager *accesscontextmanager.Service
+	PrivateCA            *privateca.Service
+	CertificateManager   *certificatemanager.Service
+	IAP                  *iap.Service
+	reCAPTCHAEnterprise  *recaptchaenterprise.Service
+	WebSecurityScanner   *websecurityscanner.Service
+
 	// Identity and access
-	CloudIdentity   *cloudidentity.Service
-	AdminDirectory  *admin.Service
-	AdminReports    *reports.Service
-	
+	CloudIdentity  *cloudidentity.Service
+	AdminDirectory *admin.Service
+	AdminReports   *reports.Service
+
 	// Billing and support
-	CloudChannel  *cloudchannel.Service
-	CloudBilling  *cloudbilling.Service
-	Recommender   *recommender.Service
+	CloudChannel      *cloudchannel.Service
+	BillingBudgets    *billingbudgets.Service
+	CloudBilling      *cloudbilling.Service
+	Recommender       *recommender.Service
 	CloudOptimization *cloudoptimization.Service
-	
+
 	// Policy and governance
 	PolicyTroubleshooter *policytroubleshooter.Service
-	OrgPolicy           *orgpolicy.Service
-	CloudAsset          *cloudasset.Service
-	CloudSupport        *cloudsupport.Service
-	EssentialContacts   *essentialcontacts.Service
-	
+	OrgPolicy            *orgpolicy.Service
+	CloudAsset           *cloudasset.Service
+	CloudSupport         *cloudsupport.Service
+	EssentialContacts    *essentialcontacts.Service
+
 	// Compute and infrastructure
 	Workstations *workstations.Service
 	Batch        *batch.Service
 	File         *file.Service
-	
+
 	// Network services
 	NetworkServices *networkservices.Service
 	NetworkSecurity *networksecurity.Service
-	
+
 	// Migration services
-	VMMigration *vmmigration.Service
+	VMMigration       *vmmigration.Service
 	BaremetalSolution *baremetalsolution.Service
-	VMwareEngine *vmwareengine.Service
-	
+	VMwareEngine      *vmwareengine.Service
+
 	// Kubernetes services
 	GKEHub    *gkehub.Service
 	GKEBackup *gkebackup.Service
-	
+
 	// Backup and disaster recovery
 	BackupDR *backupdr.Service
-	
+
 	// Migration and modernization
 	MigrationCenter *migrationcenter.Service
-	
+
 	// AI and search
-	DiscoveryEngine *discoveryengine.Service
-	Retail          *retail.Service
+	DiscoveryEngine      *discoveryengine.Service
+	Retail               *retail.Service
 	RecommendationEngine *recommendationengine.Service
-	
+
 	// Data management
-	DataPlex     *dataplex.Service
-	DataLineage  *datalineage.Service
+	DataPlex      *dataplex.Service
+	DataLineage   *datalineage.Service
 	DataPipelines *datapipelines.Service
-	DataStream   *datastream.Service
-	CloudDMS     *clouddms.Service
-	Metastore    *metastore.Service
-	
+	DataStream    *datastream.Service
+	CloudDMS      *clouddms.Service
+	Metastore     *metastore.Service
+
 	// Analytics
 	AnalyticsData  *analyticsdata.Service
 	AnalyticsAdmin *analyticsadmin.Service
-	
+
 	// Firebase services
 	FirebaseHosting      *firebasehosting.Service
 	FirebaseDatabase     *firebasedatabase.Service
@@ -324,10 +351,10 @@ type GCPTestSuite struct {
 }
 
 // NewGCPTestSuite creates a new GCP test suite
-func NewGCPTestSuite(projectID, region, zone string, config TestConfig) (*GCPTestSuite, error) {
+func NewGCPTestSuite(projectID, region, zone string, config TestConfig, clientOpts ...option.ClientOption) (*GCPTestSuite, error) {
 	testID := uuid.New().String()[:8]
 	ctx := context.Background()
-	
+
 	// Initialize logger
 	logger := log.With().
 		Str("service", "gcp-test-suite").
@@ -337,182 +364,71 @@ func NewGCPTestSuite(projectID, region, zone string, config TestConfig) (*GCPTes
 		Str("test_id", testID).
 		Logger()
 
-	// Create GCP service clients
-	computeService, err := compute.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create compute service")
-	}
-
-	sqlService, err := sql.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create SQL service")
-	}
-
-	storageService, err := storage.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create storage service")
-	}
-
-	containerService, err := container.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create container service")
-	}
-
-	crmService, err := cloudresourcemanager.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create cloud resource manager service")
-	}
-
-	iamService, err := iam.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create IAM service")
-	}
-
-	monitoringService, err := monitoring.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create monitoring service")
+	var opts []option.ClientOption
+	if config.GCPCredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(config.GCPCredentialsFile))
 	}
-
-	loggingService, err := logging.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create logging service")
+	if config.GCPQuotaProject != "" {
+		opts = append(opts, option.WithQuotaProject(config.GCPQuotaProject))
 	}
+	opts = append(opts, clientOpts...)
 
-	cloudFunctionsService, err := cloudfunctions.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create cloud functions service")
-	}
-
-	cloudRunService, err := run.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create cloud run service")
-	}
-
-	kmsService, err := cloudkms.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create KMS service")
+	maxListPages := config.GCPMaxListPages
+	if maxListPages <= 0 {
+		maxListPages = defaultMaxListPages
 	}
 
-	secretManagerService, err := secretmanager.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create secret manager service")
-	}
-
-	dnsService, err := dns.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create DNS service")
-	}
-
-	securityCenterService, err := securitycenter.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create security center service")
-	}
-
-	bigQueryService, err := bigquery.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create BigQuery service")
+	rateLimits := make(map[string]rate.Limit, len(config.GCPRateLimits))
+	for service, qps := range config.GCPRateLimits {
+		rateLimits[service] = rate.Limit(qps)
 	}
 
-	dataflowService, err := dataflow.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Dataflow service")
-	}
-
-	pubsubService, err := pubsub.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Pub/Sub service")
-	}
-
-	firebaseService, err := firebase.NewService(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Firebase service")
-	}
-
-	suite := &GCPTestSuite{
-		ProjectID: projectID,
-		Region:    region,
-		Zone:      zone,
-		TestID:    testID,
-		Config:    config,
-		Logger:    logger,
-		Context:   ctx,
-		
-		// Core services
-		Compute:   computeService,
-		SQL:       sqlService,
-		Storage:   storageService,
-		Container: containerService,
-		
-		// Management services
-		CloudResourceManager: crmService,
-		IAM:                  iamService,
-		
-		// Monitoring and logging
-		Monitoring: monitoringService,
-		Logging:    loggingService,
-		
-		// Serverless services
-		CloudFunctions: cloudFunctionsService,
-		CloudRun:       cloudRunService,
-		
-		// Security services
-		CloudKMS:       kmsService,
-		SecretManager:  secretManagerService,
-		SecurityCenter: securityCenterService,
-		
-		// Network services
-		DNS: dnsService,
-		
-		// Data services
-		BigQuery: bigQueryService,
-		Dataflow: dataflowService,
-		PubSub:   pubsubService,
-		Firebase: firebaseService,
-	}
-
-	// Initialize additional services as needed
-	if err := suite.initializeAdditionalServices(); err != nil {
-		return nil, errors.Wrap(err, "failed to initialize additional services")
-	}
-
-	return suite, nil
-}
-
-// initializeAdditionalServices initializes additional GCP services
-func (suite *GCPTestSuite) initializeAdditionalServices() error {
-	var err error
-	
-	// Initialize Firestore
-	suite.Firestore, err = firestore.NewService(suite.Context)
-	if err != nil {
-		suite.Logger.Warn().Err(err).Msg("Failed to initialize Firestore service")
-	}
-
-	// Initialize Redis
-	suite.Redis, err = redis.NewService(suite.Context)
-	if err != nil {
-		suite.Logger.Warn().Err(err).Msg("Failed to initialize Redis service")
-	}
-
-	// Initialize Memcache
-	suite.Memcache, err = memcache.NewService(suite.Context)
-	if err != nil {
-		suite.Logger.Warn().Err(err).Msg("Failed to initialize Memcache service")
-	}
-
-	// Initialize Cloud Build
-	suite.CloudBuild, err = cloudbuild.NewService(suite.Context)
-	if err != nil {
-		suite.Logger.Warn().Err(err).Msg("Failed to initialize Cloud Build service")
-	}
-
-	// Initialize App Engine
-	suite.AppEngine, err = appengine.NewService(suite.Context)
-	if err != nil {
-		suite.Logger.Warn().Err(err).Msg("Failed to initialize App Engine service")
-	}
-
-	return nil
+	registry := NewServiceRegistry(ctx, opts...)
+	RegisterService(registry, compute.NewService)
+	RegisterService(registry, sql.NewService)
+	RegisterService(registry, storage.NewService)
+	RegisterService(registry, container.NewService)
+	RegisterService(registry, cloudresourcemanager.NewService)
+	RegisterService(registry, iam.NewService)
+	RegisterService(registry, monitoring.NewService)
+	RegisterService(registry, logging.NewService)
+	RegisterService(registry, cloudfunctions.NewService)
+	RegisterService(registry, run.NewService)
+	RegisterService(registry, cloudkms.NewService)
+	RegisterService(registry, secretmanager.NewService)
+	RegisterService(registry, dns.NewService)
+	RegisterService(registry, securitycenter.NewService)
+	RegisterService(registry, cloudasset.NewService)
+	RegisterService(registry, bigquery.NewService)
+	RegisterService(registry, dataflow.NewService)
+	RegisterService(registry, pubsub.NewService)
+	RegisterService(registry, firebase.NewService)
+	RegisterService(registry, firestore.NewService)
+	RegisterService(registry, redis.NewService)
+	RegisterService(registry, memcache.NewService)
+	RegisterService(registry, cloudbuild.NewService)
+	RegisterService(registry, appengine.NewService)
+	RegisterService(registry, cloudbilling.NewService)
+	RegisterService(registry, billingbudgets.NewService)
+
+	return &GCPTestSuite{
+		ProjectID:    projectID,
+		Region:       region,
+		Zone:         zone,
+		Zones:        config.GCPZones,
+		Regions:      config.GCPRegions,
+		TestID:       testID,
+		Config:       config,
+		Logger:       logger,
+		Context:      ctx,
+		Registry:     registry,
+		MaxListPages: maxListPages,
+		DryRun:       config.GCPDryRun,
+		Concurrency: SuiteConfig{
+			MaxParallel: config.GCPMaxParallel,
+			RateLimits:  rateLimits,
+		},
+	}, nil
 }
 
 // TestGCPInfrastructure runs comprehensive GCP infrastructure tests
@@ -554,29 +470,35 @@ func TestGCPInfrastructure(t *testing.T) {
 	t.Run("Disaster Recovery", suite.TestDisasterRecovery)
 	t.Run("Cost Management", suite.TestCostManagement)
 
+	if suite.DryRun {
+		suite.Logger.Info().Int("calls", len(suite.ExecLog)).Msg("Dry run complete; recorded API calls:")
+		fmt.Print(suite.DumpExecLog())
+	}
+
 	suite.Logger.Info().Msg("GCP infrastructure tests completed")
 }
 
 // TestVPC tests VPC infrastructure
 func (suite *GCPTestSuite) TestVPC(t *testing.T) {
 	suite.Logger.Info().Msg("Testing VPC infrastructure")
+	suite.Compute = RequireService[*compute.Service](t, suite.Registry)
 
 	// Test VPC Networks
 	t.Run("VPC Networks", func(t *testing.T) {
-		networks, err := suite.Compute.Networks.List(suite.ProjectID).Context(suite.Context).Do()
+		networks, err := suite.ListAllNetworks()
 		require.NoError(t, err)
 
 		// Test network configuration
-		for _, network := range networks.Items {
+		for _, network := range networks {
 			// Test network name
 			assert.NotEmpty(t, network.Name, "Network should have a name")
-			
+
 			// Test self link
 			assert.NotEmpty(t, network.SelfLink, "Network should have a self link")
-			
+
 			// Test creation timestamp
 			assert.NotEmpty(t, network.CreationTimestamp, "Network should have a creation timestamp")
-			
+
 			// Test auto create subnetworks
 			suite.Logger.Info().Str("network", network.Name).
 				Bool("auto_create_subnetworks", network.AutoCreateSubnetworks).
@@ -586,23 +508,23 @@ func (suite *GCPTestSuite) TestVPC(t *testing.T) {
 
 	// Test Subnets
 	t.Run("Subnets", func(t *testing.T) {
-		subnets, err := suite.Compute.Subnetworks.List(suite.ProjectID, suite.Region).Context(suite.Context).Do()
+		subnets, err := suite.ListAllSubnetworks(suite.Region)
 		require.NoError(t, err)
 
 		// Test subnet configuration
-		for _, subnet := range subnets.Items {
+		for _, subnet := range subnets {
 			// Test subnet name
 			assert.NotEmpty(t, subnet.Name, "Subnet should have a name")
-			
+
 			// Test IP CIDR range
 			assert.NotEmpty(t, subnet.IpCidrRange, "Subnet should have an IP CIDR range")
-			
+
 			// Test network
 			assert.NotEmpty(t, subnet.Network, "Subnet should belong to a network")
-			
+
 			// Test region
 			assert.NotEmpty(t, subnet.Region, "Subnet should have a region")
-			
+
 			// Test creation timestamp
 			assert.NotEmpty(t, subnet.CreationTimestamp, "Subnet should have a creation timestamp")
 		}
@@ -610,45 +532,53 @@ func (suite *GCPTestSuite) TestVPC(t *testing.T) {
 
 	// Test Firewall Rules
 	t.Run("Firewall Rules", func(t *testing.T) {
-		firewalls, err := suite.Compute.Firewalls.List(suite.ProjectID).Context(suite.Context).Do()
+		firewalls, err := suite.ListAllFirewalls()
 		require.NoError(t, err)
 
 		// Test firewall configuration
-		for _, firewall := range firewalls.Items {
+		for _, firewall := range firewalls {
 			// Test firewall name
 			assert.NotEmpty(t, firewall.Name, "Firewall should have a name")
-			
+
 			// Test network
 			assert.NotEmpty(t, firewall.Network, "Firewall should belong to a network")
-			
+
 			// Test direction
-			assert.Contains(t, []string{"INGRESS", "EGRESS"}, firewall.Direction, 
+			assert.Contains(t, []string{"INGRESS", "EGRESS"}, firewall.Direction,
 				"Firewall should have a valid direction")
-			
+
 			// Test allowed or denied rules
 			hasRules := len(firewall.Allowed) > 0 || len(firewall.Denied) > 0
 			assert.True(t, hasRules, "Firewall should have allowed or denied rules")
 		}
+
+		// Test firewall policy compliance
+		subnets, err := suite.ListAllSubnetworks(suite.Region)
+		require.NoError(t, err)
+
+		assertNoOpenSensitivePorts(t, firewalls, suite.Config.FirewallExpectations.SensitiveIngressWhitelist)
+		assertNoFirewallPriorityCollisions(t, firewalls)
+		assertPrivateGoogleAccessEgress(t, subnets, firewalls)
 	})
 
 	// Test Routes
 	t.Run("Routes", func(t *testing.T) {
-		routes, err := suite.Compute.Routes.List(suite.ProjectID).Context(suite.Context).Do()
+		routes, err := suite.ListAllRoutes()
 		require.NoError(t, err)
 
 		// Test route configuration
-		for _, route := range routes.Items {
+		for _, route := range routes {
 			// Test route name
 			assert.NotEmpty(t, route.Name, "Route should have a name")
-			
+
 			// Test network
 			assert.NotEmpty(t, route.Network, "Route should belong to a network")
-			
+
 			// Test destination range
 			assert.NotEmpty(t, route.DestRange, "Route should have a destination range")
-			
+
 			// Test priority
-			assert.True(t, route.Priority >= 0 && route.Priority <= 65535, 
+			assert.True(t, route.Priority >= 0 && route.Priority <= 65535,
 				"Route priority should be between 0 and 65535")
 		}
 	})
@@ -656,87 +586,96 @@ func (suite *GCPTestSuite) TestVPC(t *testing.T) {
 	// Test Load Balancers
 	t.Run("Load Balancers", func(t *testing.T) {
 		// Test Global Forwarding Rules
-		globalForwardingRules, err := suite.Compute.GlobalForwardingRules.List(suite.ProjectID).Context(suite.Context).Do()
+		globalForwardingRules, err := suite.ListAllGlobalForwardingRules()
 		require.NoError(t, err)
 
-		for _, rule := range globalForwardingRules.Items {
+		for _, rule := range globalForwardingRules {
 			// Test rule name
 			assert.NotEmpty(t, rule.Name, "Global forwarding rule should have a name")
-			
+
 			// Test IP address
 			assert.NotEmpty(t, rule.IPAddress, "Global forwarding rule should have an IP address")
-			
+
 			// Test port range
 			assert.NotEmpty(t, rule.PortRange, "Global forwarding rule should have a port range")
 		}
 
 		// Test Regional Forwarding Rules
-		regionalForwardingRules, err := suite.Compute.ForwardingRules.List(suite.ProjectID, suite.Region).Context(suite.Context).Do()
+		regionalForwardingRules, err := suite.ListAllForwardingRules(suite.Region)
 		require.NoError(t, err)
 
-		for _, rule := range regionalForwardingRules.Items {
+		for _, rule := range regionalForwardingRules {
 			// Test rule name
 			assert.NotEmpty(t, rule.Name, "Regional forwarding rule should have a name")
-			
+
 			// Test IP address
 			assert.NotEmpty(t, rule.IPAddress, "Regional forwarding rule should have an IP address")
 		}
 	})
 
+	// Test Cloud Router and BGP peering
+	suite.TestCloudRouter(t)
+
 	suite.Logger.Info().Msg("VPC infrastructure tests completed")
 }
 
 // TestCompute tests Compute Engine infrastructure
 func (suite *GCPTestSuite) TestCompute(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Compute Engine infrastructure")
+	suite.Compute = RequireService[*compute.Service](t, suite.Registry)
 
 	// Test VM Instances
 	t.Run("VM Instances", func(t *testing.T) {
-		instances, err := suite.Compute.Instances.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
+		for _, zone := range suite.ResolveZones(t) {
+			zone := zone
+			t.Run(zone, func(t *testing.T) {
+				instances, err := suite.ListAllInstances(zone)
+				require.NoError(t, err)
 
-		// Test instance configuration
-		for _, instance := range instances.Items {
-			// Test instance name
-			assert.NotEmpty(t, instance.Name, "Instance should have a name")
-			
-			// Test machine type
-			assert.NotEmpty(t, instance.MachineType, "Instance should have a machine type")
-			
-			// Test status
-			assert.Contains(t, []string{"PROVISIONING", "STAGING", "RUNNING", "STOPPING", "STOPPED", "SUSPENDING", "SUSPENDED", "TERMINATED"}, 
-				instance.Status, "Instance should have a valid status")
-			
-			// Test zone
-			assert.NotEmpty(t, instance.Zone, "Instance should have a zone")
-			
-			// Test creation timestamp
-			assert.NotEmpty(t, instance.CreationTimestamp, "Instance should have a creation timestamp")
-			
-			// Test network interfaces
-			assert.True(t, len(instance.NetworkInterfaces) > 0, "Instance should have network interfaces")
-			
-			// Test disks
-			assert.True(t, len(instance.Disks) > 0, "Instance should have disks")
+				// Test instance configuration
+				for _, instance := range instances {
+					// Test instance name
+					assert.NotEmpty(t, instance.Name, "Instance should have a name")
+
+					// Test machine type
+					assert.NotEmpty(t, instance.MachineType, "Instance should have a machine type")
+
+					// Test status
+					assert.Contains(t, []string{"PROVISIONING", "STAGING", "RUNNING", "STOPPING", "STOPPED", "SUSPENDING", "SUSPENDED", "TERMINATED"},
+						instance.Status, "Instance should have a valid status")
+
+					// Test zone
+					assert.NotEmpty(t, instance.Zone, "Instance should have a zone")
+
+					// Test creation timestamp
+					assert.NotEmpty(t, instance.CreationTimestamp, "Instance should have a creation timestamp")
+
+					// Test network interfaces
+					assert.True(t, len(instance.NetworkInterfaces) > 0, "Instance should have network interfaces")
+
+					// Test disks
+					assert.True(t, len(instance.Disks) > 0, "Instance should have disks")
+				}
+			})
 		}
 	})
 
 	// Test Instance Templates
 	t.Run("Instance Templates", func(t *testing.T) {
-		templates, err := suite.Compute.InstanceTemplates.List(suite.ProjectID).Context(suite.Context).Do()
+		templates, err := suite.ListAllInstanceTemplates()
 		require.NoError(t, err)
 
 		// Test template configuration
-		for _, template := range templates.Items {
+		for _, template := range templates {
 			// Test template name
 			assert.NotEmpty(t, template.Name, "Instance template should have a name")
-			
+
 			// Test creation timestamp
 			assert.NotEmpty(t, template.CreationTimestamp, "Instance template should have a creation timestamp")
-			
+
 			// Test properties
 			assert.NotNil(t, template.Properties, "Instance template should have properties")
-			
+
 			// Test machine type
 			assert.NotEmpty(t, template.Properties.MachineType, "Instance template should have a machine type")
 		}
@@ -744,76 +683,86 @@ func (suite *GCPTestSuite) TestCompute(t *testing.T) {
 
 	// Test Instance Groups
 	t.Run("Instance Groups", func(t *testing.T) {
-		// Test Managed Instance Groups
-		migs, err := suite.Compute.InstanceGroupManagers.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
+		for _, zone := range suite.ResolveZones(t) {
+			zone := zone
+			t.Run(zone, func(t *testing.T) {
+				// Test Managed Instance Groups
+				migs, err := suite.Compute.InstanceGroupManagers.List(suite.ProjectID, zone).Context(suite.Context).Do()
+				require.NoError(t, err)
+
+				for _, mig := range migs.Items {
+					// Test MIG name
+					assert.NotEmpty(t, mig.Name, "Managed instance group should have a name")
+
+					// Test instance template
+					assert.NotEmpty(t, mig.InstanceTemplate, "Managed instance group should have an instance template")
+
+					// Test target size
+					assert.True(t, mig.TargetSize >= 0, "Target size should be non-negative")
+				}
 
-		for _, mig := range migs.Items {
-			// Test MIG name
-			assert.NotEmpty(t, mig.Name, "Managed instance group should have a name")
-			
-			// Test instance template
-			assert.NotEmpty(t, mig.InstanceTemplate, "Managed instance group should have an instance template")
-			
-			// Test target size
-			assert.True(t, mig.TargetSize >= 0, "Target size should be non-negative")
-		}
+				// Test Unmanaged Instance Groups
+				uigs, err := suite.Compute.InstanceGroups.List(suite.ProjectID, zone).Context(suite.Context).Do()
+				require.NoError(t, err)
 
-		// Test Unmanaged Instance Groups
-		uigs, err := suite.Compute.InstanceGroups.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
+				for _, uig := range uigs.Items {
+					// Test UIG name
+					assert.NotEmpty(t, uig.Name, "Unmanaged instance group should have a name")
 
-		for _, uig := range uigs.Items {
-			// Test UIG name
-			assert.NotEmpty(t, uig.Name, "Unmanaged instance group should have a name")
-			
-			// Test zone
-			assert.NotEmpty(t, uig.Zone, "Unmanaged instance group should have a zone")
+					// Test zone
+					assert.NotEmpty(t, uig.Zone, "Unmanaged instance group should have a zone")
+				}
+			})
 		}
 	})
 
 	// Test Persistent Disks
 	t.Run("Persistent Disks", func(t *testing.T) {
-		disks, err := suite.Compute.Disks.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
+		for _, zone := range suite.ResolveZones(t) {
+			zone := zone
+			t.Run(zone, func(t *testing.T) {
+				disks, err := suite.ListAllDisks(zone)
+				require.NoError(t, err)
 
-		// Test disk configuration
-		for _, disk := range disks.Items {
-			// Test disk name
-			assert.NotEmpty(t, disk.Name, "Disk should have a name")
-			
-			// Test size
-			assert.True(t, disk.SizeGb > 0, "Disk should have a size greater than 0")
-			
-			// Test type
-			assert.NotEmpty(t, disk.Type, "Disk should have a type")
-			
-			// Test status
-			assert.Contains(t, []string{"CREATING", "RESTORING", "FAILED", "READY", "DELETING"}, 
-				disk.Status, "Disk should have a valid status")
-			
-			// Test zone
-			assert.NotEmpty(t, disk.Zone, "Disk should have a zone")
+				// Test disk configuration
+				for _, disk := range disks {
+					// Test disk name
+					assert.NotEmpty(t, disk.Name, "Disk should have a name")
+
+					// Test size
+					assert.True(t, disk.SizeGb > 0, "Disk should have a size greater than 0")
+
+					// Test type
+					assert.NotEmpty(t, disk.Type, "Disk should have a type")
+
+					// Test status
+					assert.Contains(t, []string{"CREATING", "RESTORING", "FAILED", "READY", "DELETING"},
+						disk.Status, "Disk should have a valid status")
+
+					// Test zone
+					assert.NotEmpty(t, disk.Zone, "Disk should have a zone")
+				}
+			})
 		}
 	})
 
 	// Test Snapshots
 	t.Run("Snapshots", func(t *testing.T) {
-		snapshots, err := suite.Compute.Snapshots.List(suite.ProjectID).Context(suite.Context).Do()
+		snapshots, err := suite.ListAllSnapshots()
 		require.NoError(t, err)
 
 		// Test snapshot configuration
-		for _, snapshot := range snapshots.Items {
+		for _, snapshot := range snapshots {
 			// Test snapshot name
 			assert.NotEmpty(t, snapshot.Name, "Snapshot should have a name")
-			
+
 			// Test source disk
 			assert.NotEmpty(t, snapshot.SourceDisk, "Snapshot should have a source disk")
-			
+
 			// Test status
-			assert.Contains(t, []string{"CREATING", "DELETING", "FAILED", "READY", "UPLOADING"}, 
+			assert.Contains(t, []string{"CREATING", "DELETING", "FAILED", "READY", "UPLOADING"},
 				snapshot.Status, "Snapshot should have a valid status")
-			
+
 			// Test creation timestamp
 			assert.NotEmpty(t, snapshot.CreationTimestamp, "Snapshot should have a creation timestamp")
 		}
@@ -821,18 +770,18 @@ func (suite *GCPTestSuite) TestCompute(t *testing.T) {
 
 	// Test Images
 	t.Run("Images", func(t *testing.T) {
-		images, err := suite.Compute.Images.List(suite.ProjectID).Context(suite.Context).Do()
+		images, err := suite.ListAllImages()
 		require.NoError(t, err)
 
 		// Test image configuration
-		for _, image := range images.Items {
+		for _, image := range images {
 			// Test image name
 			assert.NotEmpty(t, image.Name, "Image should have a name")
-			
+
 			// Test status
-			assert.Contains(t, []string{"PENDING", "READY", "FAILED"}, 
+			assert.Contains(t, []string{"PENDING", "READY", "FAILED"},
 				image.Status, "Image should have a valid status")
-			
+
 			// Test family
 			if image.Family != "" {
 				assert.NotEmpty(t, image.Family, "Image family should not be empty")
@@ -840,39 +789,54 @@ func (suite *GCPTestSuite) TestCompute(t *testing.T) {
 		}
 	})
 
+	// Test Image Attestation
+	t.Run("Image Attestation", func(t *testing.T) {
+		suite.AssertInstanceImageAttestation(t)
+	})
+
 	suite.Logger.Info().Msg("Compute Engine infrastructure tests completed")
 }
 
 // TestSQL tests Cloud SQL infrastructure
 func (suite *GCPTestSuite) TestSQL(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Cloud SQL infrastructure")
+	suite.SQL = RequireService[*sql.Service](t, suite.Registry)
 
 	// Test SQL Instances
 	t.Run("SQL Instances", func(t *testing.T) {
-		instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
+		var instancesResp *sql.InstancesListResponse
+		err := suite.DoWithRetry("sql.Instances.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			instancesResp, callErr = suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
+			return callErr
+		})
 		require.NoError(t, err)
+		var instances []*sql.DatabaseInstance
+		if instancesResp != nil {
+			instances = instancesResp.Items
+		}
 
 		// Test instance configuration
-		for _, instance := range instances.Items {
+		for _, instance := range instances {
 			// Test instance name
 			assert.NotEmpty(t, instance.Name, "SQL instance should have a name")
-			
+
 			// Test database version
 			assert.NotEmpty(t, instance.DatabaseVersion, "SQL instance should have a database version")
-			
+
 			// Test state
-			assert.Contains(t, []string{"RUNNABLE", "SUSPENDED", "PENDING_DELETE", "PENDING_CREATE", "MAINTENANCE", "FAILED", "UNKNOWN_STATE"}, 
+			assert.Contains(t, []string{"RUNNABLE", "SUSPENDED", "PENDING_DELETE", "PENDING_CREATE", "MAINTENANCE", "FAILED", "UNKNOWN_STATE"},
 				instance.State, "SQL instance should have a valid state")
-			
+
 			// Test region
 			assert.NotEmpty(t, instance.Region, "SQL instance should have a region")
-			
+
 			// Test settings
 			assert.NotNil(t, instance.Settings, "SQL instance should have settings")
-			
+
 			// Test tier
 			assert.NotEmpty(t, instance.Settings.Tier, "SQL instance should have a tier")
-			
+
 			// Test backup configuration
 			if instance.Settings.BackupConfiguration != nil {
 				suite.Logger.Info().Str("instance", instance.Name).
@@ -887,22 +851,26 @@ func (suite *GCPTestSuite) TestSQL(t *testing.T) {
 		instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
 		require.NoError(t, err)
 
-		for _, instance := range instances.Items {
+		err = ParallelForEach(suite, "sql", instances.Items, func(instance *sql.DatabaseInstance) error {
 			databases, err := suite.SQL.Databases.List(suite.ProjectID, instance.Name).Context(suite.Context).Do()
-			require.NoError(t, err)
+			if !assert.NoError(t, err, "failed to list databases for instance %s", instance.Name) {
+				return nil
+			}
 
 			// Test database configuration
 			for _, database := range databases.Items {
 				// Test database name
 				assert.NotEmpty(t, database.Name, "Database should have a name")
-				
+
 				// Test instance
 				assert.NotEmpty(t, database.Instance, "Database should belong to an instance")
-				
+
 				// Test charset
 				assert.NotEmpty(t, database.Charset, "Database should have a charset")
 			}
-		}
+			return nil
+		})
+		require.NoError(t, err)
 	})
 
 	// Test SQL Users
@@ -918,7 +886,7 @@ func (suite *GCPTestSuite) TestSQL(t *testing.T) {
 			for _, user := range users.Items {
 				// Test user name
 				assert.NotEmpty(t, user.Name, "SQL user should have a name")
-				
+
 				// Test instance
 				assert.NotEmpty(t, user.Instance, "SQL user should belong to an instance")
 			}
@@ -931,40 +899,41 @@ func (suite *GCPTestSuite) TestSQL(t *testing.T) {
 // TestStorage tests Cloud Storage infrastructure
 func (suite *GCPTestSuite) TestStorage(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Cloud Storage infrastructure")
+	suite.Storage = RequireService[*storage.Service](t, suite.Registry)
 
 	// Test Storage Buckets
 	t.Run("Storage Buckets", func(t *testing.T) {
-		buckets, err := suite.Storage.Buckets.List(suite.ProjectID).Context(suite.Context).Do()
+		buckets, err := suite.ListAllBuckets()
 		require.NoError(t, err)
 
 		// Test bucket configuration
-		for _, bucket := range buckets.Items {
+		for _, bucket := range buckets {
 			// Test bucket name
 			assert.NotEmpty(t, bucket.Name, "Bucket should have a name")
-			
+
 			// Test location
 			assert.NotEmpty(t, bucket.Location, "Bucket should have a location")
-			
+
 			// Test storage class
 			assert.NotEmpty(t, bucket.StorageClass, "Bucket should have a storage class")
-			
+
 			// Test creation time
 			assert.NotEmpty(t, bucket.TimeCreated, "Bucket should have a creation time")
-			
+
 			// Test versioning
 			if bucket.Versioning != nil {
 				suite.Logger.Info().Str("bucket", bucket.Name).
 					Bool("versioning_enabled", bucket.Versioning.Enabled).
 					Msg("Bucket versioning configuration")
 			}
-			
+
 			// Test lifecycle
 			if bucket.Lifecycle != nil {
 				suite.Logger.Info().Str("bucket", bucket.Name).
 					Int("lifecycle_rules", len(bucket.Lifecycle.Rule)).
 					Msg("Bucket lifecycle configuration")
 			}
-			
+
 			// Test encryption
 			if bucket.Encryption != nil {
 				suite.Logger.Info().Str("bucket", bucket.Name).
@@ -980,79 +949,165 @@ func (suite *GCPTestSuite) TestStorage(t *testing.T) {
 // TestGKE tests Google Kubernetes Engine infrastructure
 func (suite *GCPTestSuite) TestGKE(t *testing.T) {
 	suite.Logger.Info().Msg("Testing GKE infrastructure")
+	suite.Container = RequireService[*container.Service](t, suite.Registry)
 
-	// Test GKE Clusters
-	t.Run("GKE Clusters", func(t *testing.T) {
-		clusters, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
+	assertCluster := func(t *testing.T, cluster *container.Cluster) {
+		// Test cluster name
+		assert.NotEmpty(t, cluster.Name, "Cluster should have a name")
+
+		// Test status
+		assert.Contains(t, []string{"PROVISIONING", "RUNNING", "RECONCILING", "STOPPING", "ERROR", "DEGRADED"},
+			cluster.Status, "Cluster should have a valid status")
+
+		// Test location
+		assert.NotEmpty(t, cluster.Location, "Cluster should have a location")
+
+		// Test initial node count
+		assert.True(t, cluster.InitialNodeCount > 0, "Cluster should have an initial node count")
+
+		// Test node config
+		if cluster.NodeConfig != nil {
+			assert.NotEmpty(t, cluster.NodeConfig.MachineType, "Node config should have a machine type")
+			assert.True(t, cluster.NodeConfig.DiskSizeGb > 0, "Node config should have disk size")
+		}
+
+		// Test network
+		assert.NotEmpty(t, cluster.Network, "Cluster should have a network")
+
+		// Test subnetwork
+		if cluster.Subnetwork != "" {
+			assert.NotEmpty(t, cluster.Subnetwork, "Cluster subnetwork should not be empty")
+		}
+	}
+
+	assertNodePools := func(t *testing.T, cluster *container.Cluster, nodePools *container.ListNodePoolsResponse) {
+		for _, nodePool := range nodePools.NodePools {
+			// Test node pool name
+			assert.NotEmpty(t, nodePool.Name, "Node pool should have a name")
 
-		// Test cluster configuration
-		for _, cluster := range clusters.Clusters {
-			// Test cluster name
-			assert.NotEmpty(t, cluster.Name, "Cluster should have a name")
-			
 			// Test status
-			assert.Contains(t, []string{"PROVISIONING", "RUNNING", "RECONCILING", "STOPPING", "ERROR", "DEGRADED"}, 
-				cluster.Status, "Cluster should have a valid status")
-			
-			// Test location
-			assert.NotEmpty(t, cluster.Location, "Cluster should have a location")
-			
+			assert.Contains(t, []string{"PROVISIONING", "RUNNING", "RUNNING_WITH_ERROR", "RECONCILING", "STOPPING", "ERROR"},
+				nodePool.Status, "Node pool should have a valid status")
+
 			// Test initial node count
-			assert.True(t, cluster.InitialNodeCount > 0, "Cluster should have an initial node count")
-			
-			// Test node config
-			if cluster.NodeConfig != nil {
-				assert.NotEmpty(t, cluster.NodeConfig.MachineType, "Node config should have a machine type")
-				assert.True(t, cluster.NodeConfig.DiskSizeGb > 0, "Node config should have disk size")
-			}
-			
-			// Test network
-			assert.NotEmpty(t, cluster.Network, "Cluster should have a network")
-			
-			// Test subnetwork
-			if cluster.Subnetwork != "" {
-				assert.NotEmpty(t, cluster.Subnetwork, "Cluster subnetwork should not be empty")
+			assert.True(t, nodePool.InitialNodeCount > 0, "Node pool should have an initial node count")
+
+			// Test config
+			if nodePool.Config != nil {
+				assert.NotEmpty(t, nodePool.Config.MachineType, "Node pool config should have a machine type")
+				assert.True(t, nodePool.Config.DiskSizeGb > 0, "Node pool config should have disk size")
 			}
 		}
+	}
+
+	// Test zonal GKE Clusters
+	// Container API's ListClustersResponse carries no NextPageToken, so
+	// there's nothing for PaginatedList to follow here.
+	t.Run("GKE Clusters", func(t *testing.T) {
+		for _, zone := range suite.ResolveZones(t) {
+			zone := zone
+			t.Run(zone, func(t *testing.T) {
+				var clustersResp *container.ListClustersResponse
+				err := suite.DoWithRetry("container.Clusters.List", fmt.Sprintf("projects/%s/zones/%s", suite.ProjectID, zone), func() error {
+					var callErr error
+					clustersResp, callErr = suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, zone).Context(suite.Context).Do()
+					return callErr
+				})
+				require.NoError(t, err)
+				var clusters []*container.Cluster
+				if clustersResp != nil {
+					clusters = clustersResp.Clusters
+				}
+
+				for _, cluster := range clusters {
+					assertCluster(t, cluster)
+				}
+			})
+		}
+	})
+
+	// Test regional GKE Clusters
+	t.Run("Regional GKE Clusters", func(t *testing.T) {
+		for _, region := range suite.ResolveRegions(t) {
+			region := region
+			t.Run(region, func(t *testing.T) {
+				parent := fmt.Sprintf("projects/%s/locations/%s", suite.ProjectID, region)
+				var clustersResp *container.ListClustersResponse
+				err := suite.DoWithRetry("container.Locations.Clusters.List", parent, func() error {
+					var callErr error
+					clustersResp, callErr = suite.Container.Projects.Locations.Clusters.List(parent).Context(suite.Context).Do()
+					return callErr
+				})
+				require.NoError(t, err)
+				var clusters []*container.Cluster
+				if clustersResp != nil {
+					clusters = clustersResp.Clusters
+				}
+
+				for _, cluster := range clusters {
+					assertCluster(t, cluster)
+				}
+			})
+		}
 	})
 
 	// Test Node Pools
 	t.Run("Node Pools", func(t *testing.T) {
-		clusters, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, suite.Zone).Context(suite.Context).Do()
-		require.NoError(t, err)
-
-		for _, cluster := range clusters.Clusters {
-			nodePools, err := suite.Container.Projects.Zones.Clusters.NodePools.List(suite.ProjectID, suite.Zone, cluster.Name).Context(suite.Context).Do()
-			require.NoError(t, err)
+		for _, zone := range suite.ResolveZones(t) {
+			zone := zone
+			t.Run(zone, func(t *testing.T) {
+				clusters, err := suite.Container.Projects.Zones.Clusters.List(suite.ProjectID, zone).Context(suite.Context).Do()
+				require.NoError(t, err)
+
+				err = ParallelForEach(suite, "container", clusters.Clusters, func(cluster *container.Cluster) error {
+					nodePools, err := suite.Container.Projects.Zones.Clusters.NodePools.List(suite.ProjectID, zone, cluster.Name).Context(suite.Context).Do()
+					if !assert.NoError(t, err, "failed to list node pools for cluster %s", cluster.Name) {
+						return nil
+					}
+					assertNodePools(t, cluster, nodePools)
+					return nil
+				})
+				require.NoError(t, err)
+			})
+		}
+	})
 
-			// Test node pool configuration
-			for _, nodePool := range nodePools.NodePools {
-				// Test node pool name
-				assert.NotEmpty(t, nodePool.Name, "Node pool should have a name")
-				
-				// Test status
-				assert.Contains(t, []string{"PROVISIONING", "RUNNING", "RUNNING_WITH_ERROR", "RECONCILING", "STOPPING", "ERROR"}, 
-					nodePool.Status, "Node pool should have a valid status")
-				
-				// Test initial node count
-				assert.True(t, nodePool.InitialNodeCount > 0, "Node pool should have an initial node count")
-				
-				// Test config
-				if nodePool.Config != nil {
-					assert.NotEmpty(t, nodePool.Config.MachineType, "Node pool config should have a machine type")
-					assert.True(t, nodePool.Config.DiskSizeGb > 0, "Node pool config should have disk size")
-				}
-			}
+	// Test regional Node Pools
+	t.Run("Regional Node Pools", func(t *testing.T) {
+		for _, region := range suite.ResolveRegions(t) {
+			region := region
+			t.Run(region, func(t *testing.T) {
+				parent := fmt.Sprintf("projects/%s/locations/%s", suite.ProjectID, region)
+				clusters, err := suite.Container.Projects.Locations.Clusters.List(parent).Context(suite.Context).Do()
+				require.NoError(t, err)
+
+				err = ParallelForEach(suite, "container", clusters.Clusters, func(cluster *container.Cluster) error {
+					clusterName := fmt.Sprintf("%s/clusters/%s", parent, cluster.Name)
+					nodePools, err := suite.Container.Projects.Locations.Clusters.NodePools.List(clusterName).Context(suite.Context).Do()
+					if !assert.NoError(t, err, "failed to list node pools for cluster %s", cluster.Name) {
+						return nil
+					}
+					assertNodePools(t, cluster, nodePools)
+					return nil
+				})
+				require.NoError(t, err)
+			})
 		}
 	})
 
+	// Test Image Attestation
+	t.Run("Image Attestation", func(t *testing.T) {
+		suite.AssertNodePoolImageAttestation(t)
+	})
+
 	suite.Logger.Info().Msg("GKE infrastructure tests completed")
 }
 
 // TestIAM tests IAM infrastructure
 func (suite *GCPTestSuite) TestIAM(t *testing.T) {
 	suite.Logger.Info().Msg("Testing IAM infrastructure")
+	suite.CloudResourceManager = RequireService[*cloudresourcemanager.Service](t, suite.Registry)
+	suite.IAM = RequireService[*iam.Service](t, suite.Registry)
 
 	// Test IAM Policies
 	t.Run("IAM Policies", func(t *testing.T) {
@@ -1067,7 +1122,7 @@ func (suite *GCPTestSuite) TestIAM(t *testing.T) {
 		for _, binding := range policy.Bindings {
 			// Test role
 			assert.NotEmpty(t, binding.Role, "Binding should have a role")
-			
+
 			// Test members
 			assert.True(t, len(binding.Members) > 0, "Binding should have members")
 		}
@@ -1075,17 +1130,26 @@ func (suite *GCPTestSuite) TestIAM(t *testing.T) {
 
 	// Test Service Accounts
 	t.Run("Service Accounts", func(t *testing.T) {
-		serviceAccounts, err := suite.IAM.Projects.ServiceAccounts.List("projects/" + suite.ProjectID).Context(suite.Context).Do()
+		var saResp *iam.ListServiceAccountsResponse
+		err := suite.DoWithRetry("iam.ServiceAccounts.List", "projects/"+suite.ProjectID, func() error {
+			var callErr error
+			saResp, callErr = suite.IAM.Projects.ServiceAccounts.List("projects/" + suite.ProjectID).Context(suite.Context).Do()
+			return callErr
+		})
 		require.NoError(t, err)
+		var serviceAccounts []*iam.ServiceAccount
+		if saResp != nil {
+			serviceAccounts = saResp.Accounts
+		}
 
 		// Test service account configuration
-		for _, sa := range serviceAccounts.Accounts {
+		for _, sa := range serviceAccounts {
 			// Test service account name
 			assert.NotEmpty(t, sa.Name, "Service account should have a name")
-			
+
 			// Test email
 			assert.NotEmpty(t, sa.Email, "Service account should have an email")
-			
+
 			// Test unique ID
 			assert.NotEmpty(t, sa.UniqueId, "Service account should have a unique ID")
 		}
@@ -1097,26 +1161,32 @@ func (suite *GCPTestSuite) TestIAM(t *testing.T) {
 // TestCloudFunctions tests Cloud Functions infrastructure
 func (suite *GCPTestSuite) TestCloudFunctions(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Cloud Functions infrastructure")
+	suite.CloudFunctions = RequireService[*cloudfunctions.Service](t, suite.Registry)
 
 	// Test Cloud Functions
 	t.Run("Cloud Functions", func(t *testing.T) {
-		functions, err := suite.CloudFunctions.Projects.Locations.Functions.List("projects/" + suite.ProjectID + "/locations/" + suite.Region).Context(suite.Context).Do()
-		require.NoError(t, err)
-
-		// Test function configuration
-		for _, function := range functions.Functions {
-			// Test function name
-			assert.NotEmpty(t, function.Name, "Function should have a name")
-			
-			// Test status
-			assert.Contains(t, []string{"CLOUD_FUNCTION_STATUS_UNSPECIFIED", "ACTIVE", "OFFLINE", "DEPLOY_IN_PROGRESS", "DELETE_IN_PROGRESS", "UNKNOWN"}, 
-				function.Status, "Function should have a valid status")
-			
-			// Test runtime
-			assert.NotEmpty(t, function.Runtime, "Function should have a runtime")
-			
-			// Test entry point
-			assert.NotEmpty(t, function.EntryPoint, "Function should have an entry point")
+		for _, region := range suite.ResolveRegions(t) {
+			region := region
+			t.Run(region, func(t *testing.T) {
+				functions, err := suite.CloudFunctions.Projects.Locations.Functions.List("projects/" + suite.ProjectID + "/locations/" + region).Context(suite.Context).Do()
+				require.NoError(t, err)
+
+				// Test function configuration
+				for _, function := range functions.Functions {
+					// Test function name
+					assert.NotEmpty(t, function.Name, "Function should have a name")
+
+					// Test status
+					assert.Contains(t, []string{"CLOUD_FUNCTION_STATUS_UNSPECIFIED", "ACTIVE", "OFFLINE", "DEPLOY_IN_PROGRESS", "DELETE_IN_PROGRESS", "UNKNOWN"},
+						function.Status, "Function should have a valid status")
+
+					// Test runtime
+					assert.NotEmpty(t, function.Runtime, "Function should have a runtime")
+
+					// Test entry point
+					assert.NotEmpty(t, function.EntryPoint, "Function should have an entry point")
+				}
+			})
 		}
 	})
 
@@ -1126,25 +1196,31 @@ func (suite *GCPTestSuite) TestCloudFunctions(t *testing.T) {
 // TestCloudRun tests Cloud Run infrastructure
 func (suite *GCPTestSuite) TestCloudRun(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Cloud Run infrastructure")
+	suite.CloudRun = RequireService[*run.Service](t, suite.Registry)
 
 	// Test Cloud Run Services
 	t.Run("Cloud Run Services", func(t *testing.T) {
-		services, err := suite.CloudRun.Projects.Locations.Services.List("projects/" + suite.ProjectID + "/locations/" + suite.Region).Context(suite.Context).Do()
-		require.NoError(t, err)
+		for _, region := range suite.ResolveRegions(t) {
+			region := region
+			t.Run(region, func(t *testing.T) {
+				services, err := suite.CloudRun.Projects.Locations.Services.List("projects/" + suite.ProjectID + "/locations/" + region).Context(suite.Context).Do()
+				require.NoError(t, err)
 
-		// Test service configuration
-		for _, service := range services.Items {
-			// Test service name
-			assert.NotEmpty(t, service.Metadata.Name, "Service should have a name")
-			
-			// Test namespace
-			assert.NotEmpty(t, service.Metadata.Namespace, "Service should have a namespace")
-			
-			// Test spec
-			assert.NotNil(t, service.Spec, "Service should have a spec")
-			
-			// Test status
-			assert.NotNil(t, service.Status, "Service should have a status")
+				// Test service configuration
+				for _, service := range services.Items {
+					// Test service name
+					assert.NotEmpty(t, service.Metadata.Name, "Service should have a name")
+
+					// Test namespace
+					assert.NotEmpty(t, service.Metadata.Namespace, "Service should have a namespace")
+
+					// Test spec
+					assert.NotNil(t, service.Spec, "Service should have a spec")
+
+					// Test status
+					assert.NotNil(t, service.Status, "Service should have a status")
+				}
+			})
 		}
 	})
 
@@ -1154,6 +1230,7 @@ func (suite *GCPTestSuite) TestCloudRun(t *testing.T) {
 // TestAppEngine tests App Engine infrastructure
 func (suite *GCPTestSuite) TestAppEngine(t *testing.T) {
 	suite.Logger.Info().Msg("Testing App Engine infrastructure")
+	suite.AppEngine = RequireService[*appengine.Service](t, suite.Registry)
 
 	// Test App Engine Application
 	t.Run("App Engine Application", func(t *testing.T) {
@@ -1181,7 +1258,7 @@ func (suite *GCPTestSuite) TestAppEngine(t *testing.T) {
 		for _, service := range services.Services {
 			// Test service name
 			assert.NotEmpty(t, service.Name, "App Engine service should have a name")
-			
+
 			// Test ID
 			assert.NotEmpty(t, service.Id, "App Engine service should have an ID")
 		}
@@ -1193,34 +1270,58 @@ func (suite *GCPTestSuite) TestAppEngine(t *testing.T) {
 // TestSecurity tests security infrastructure
 func (suite *GCPTestSuite) TestSecurity(t *testing.T) {
 	suite.Logger.Info().Msg("Testing security infrastructure")
+	suite.CloudKMS = RequireService[*cloudkms.Service](t, suite.Registry)
+	suite.SecretManager = RequireService[*secretmanager.Service](t, suite.Registry)
+	suite.SecurityCenter = RequireService[*securitycenter.Service](t, suite.Registry)
 
 	// Test KMS Keys
 	t.Run("KMS Keys", func(t *testing.T) {
-		keyRings, err := suite.CloudKMS.Projects.Locations.KeyRings.List("projects/" + suite.ProjectID + "/locations/" + suite.Region).Context(suite.Context).Do()
-		require.NoError(t, err)
+		for _, region := range suite.ResolveRegions(t) {
+			region := region
+			t.Run(region, func(t *testing.T) {
+				locationName := "projects/" + suite.ProjectID + "/locations/" + region
+				var keyRingsResp *cloudkms.ListKeyRingsResponse
+				err := suite.DoWithRetry("cloudkms.KeyRings.List", locationName, func() error {
+					var callErr error
+					keyRingsResp, callErr = suite.CloudKMS.Projects.Locations.KeyRings.List(locationName).Context(suite.Context).Do()
+					return callErr
+				})
+				require.NoError(t, err)
+				var keyRings []*cloudkms.KeyRing
+				if keyRingsResp != nil {
+					keyRings = keyRingsResp.KeyRings
+				}
 
-		// Test key ring configuration
-		for _, keyRing := range keyRings.KeyRings {
-			// Test key ring name
-			assert.NotEmpty(t, keyRing.Name, "Key ring should have a name")
-			
-			// Test creation time
-			assert.NotEmpty(t, keyRing.CreateTime, "Key ring should have a creation time")
+				// Test key ring configuration
+				for _, keyRing := range keyRings {
+					// Test key ring name
+					assert.NotEmpty(t, keyRing.Name, "Key ring should have a name")
 
-			// Test crypto keys in the key ring
-			cryptoKeys, err := suite.CloudKMS.Projects.Locations.KeyRings.CryptoKeys.List(keyRing.Name).Context(suite.Context).Do()
-			require.NoError(t, err)
+					// Test creation time
+					assert.NotEmpty(t, keyRing.CreateTime, "Key ring should have a creation time")
+				}
 
-			for _, cryptoKey := range cryptoKeys.CryptoKeys {
-				// Test crypto key name
-				assert.NotEmpty(t, cryptoKey.Name, "Crypto key should have a name")
-				
-				// Test purpose
-				assert.NotEmpty(t, cryptoKey.Purpose, "Crypto key should have a purpose")
-				
-				// Test creation time
-				assert.NotEmpty(t, cryptoKey.CreateTime, "Crypto key should have a creation time")
-			}
+				// Test crypto keys in each key ring
+				err = ParallelForEach(suite, "cloudkms", keyRings, func(keyRing *cloudkms.KeyRing) error {
+					cryptoKeys, err := suite.CloudKMS.Projects.Locations.KeyRings.CryptoKeys.List(keyRing.Name).Context(suite.Context).Do()
+					if !assert.NoError(t, err, "failed to list crypto keys for key ring %s", keyRing.Name) {
+						return nil
+					}
+
+					for _, cryptoKey := range cryptoKeys.CryptoKeys {
+						// Test crypto key name
+						assert.NotEmpty(t, cryptoKey.Name, "Crypto key should have a name")
+
+						// Test purpose
+						assert.NotEmpty(t, cryptoKey.Purpose, "Crypto key should have a purpose")
+
+						// Test creation time
+						assert.NotEmpty(t, cryptoKey.CreateTime, "Crypto key should have a creation time")
+					}
+					return nil
+				})
+				require.NoError(t, err)
+			})
 		}
 	})
 
@@ -1233,7 +1334,7 @@ func (suite *GCPTestSuite) TestSecurity(t *testing.T) {
 		for _, secret := range secrets.Secrets {
 			// Test secret name
 			assert.NotEmpty(t, secret.Name, "Secret should have a name")
-			
+
 			// Test creation time
 			assert.NotEmpty(t, secret.CreateTime, "Secret should have a creation time")
 		}
@@ -1252,7 +1353,7 @@ func (suite *GCPTestSuite) TestSecurity(t *testing.T) {
 		for _, source := range sources.Sources {
 			// Test source name
 			assert.NotEmpty(t, source.Name, "Security Center source should have a name")
-			
+
 			// Test display name
 			assert.NotEmpty(t, source.DisplayName, "Security Center source should have a display name")
 		}
@@ -1264,6 +1365,8 @@ func (suite *GCPTestSuite) TestSecurity(t *testing.T) {
 // TestMonitoring tests monitoring infrastructure
 func (suite *GCPTestSuite) TestMonitoring(t *testing.T) {
 	suite.Logger.Info().Msg("Testing monitoring infrastructure")
+	suite.Monitoring = RequireService[*monitoring.Service](t, suite.Registry)
+	suite.Logging = RequireService[*logging.Service](t, suite.Registry)
 
 	// Test Monitoring Policies
 	t.Run("Monitoring Policies", func(t *testing.T) {
@@ -1274,13 +1377,13 @@ func (suite *GCPTestSuite) TestMonitoring(t *testing.T) {
 		for _, policy := range policies.AlertPolicies {
 			// Test policy name
 			assert.NotEmpty(t, policy.Name, "Alert policy should have a name")
-			
+
 			// Test display name
 			assert.NotEmpty(t, policy.DisplayName, "Alert policy should have a display name")
-			
+
 			// Test conditions
 			assert.True(t, len(policy.Conditions) > 0, "Alert policy should have conditions")
-			
+
 			// Test enabled state
 			suite.Logger.Info().Str("policy", policy.DisplayName).
 				Bool("enabled", policy.Enabled).
@@ -1306,21 +1409,31 @@ func (suite *GCPTestSuite) TestMonitoring(t *testing.T) {
 // TestBigQuery tests BigQuery infrastructure
 func (suite *GCPTestSuite) TestBigQuery(t *testing.T) {
 	suite.Logger.Info().Msg("Testing BigQuery infrastructure")
+	suite.BigQuery = RequireService[*bigquery.Service](t, suite.Registry)
 
 	// Test BigQuery Datasets
 	t.Run("BigQuery Datasets", func(t *testing.T) {
-		datasets, err := suite.BigQuery.Datasets.List(suite.ProjectID).Context(suite.Context).Do()
+		var datasetsResp *bigquery.DatasetList
+		err := suite.DoWithRetry("bigquery.Datasets.List", fmt.Sprintf("projects/%s", suite.ProjectID), func() error {
+			var callErr error
+			datasetsResp, callErr = suite.BigQuery.Datasets.List(suite.ProjectID).Context(suite.Context).Do()
+			return callErr
+		})
 		require.NoError(t, err)
+		var datasets []*bigquery.DatasetListDatasets
+		if datasetsResp != nil {
+			datasets = datasetsResp.Datasets
+		}
 
 		// Test dataset configuration
-		for _, dataset := range datasets.Datasets {
+		for _, dataset := range datasets {
 			// Test dataset ID
 			assert.NotEmpty(t, dataset.Id, "Dataset should have an ID")
-			
+
 			// Test dataset reference
 			assert.NotNil(t, dataset.DatasetReference, "Dataset should have a reference")
 			assert.NotEmpty(t, dataset.DatasetReference.DatasetId, "Dataset reference should have a dataset ID")
-			
+
 			// Test creation time
 			assert.True(t, dataset.CreationTime > 0, "Dataset should have a creation time")
 		}
@@ -1331,23 +1444,27 @@ func (suite *GCPTestSuite) TestBigQuery(t *testing.T) {
 		datasets, err := suite.BigQuery.Datasets.List(suite.ProjectID).Context(suite.Context).Do()
 		require.NoError(t, err)
 
-		for _, dataset := range datasets.Datasets {
+		err = ParallelForEach(suite, "bigquery", datasets.Datasets, func(dataset *bigquery.DatasetListDatasets) error {
 			tables, err := suite.BigQuery.Tables.List(suite.ProjectID, dataset.DatasetReference.DatasetId).Context(suite.Context).Do()
-			require.NoError(t, err)
+			if !assert.NoError(t, err, "failed to list tables for dataset %s", dataset.DatasetReference.DatasetId) {
+				return nil
+			}
 
 			// Test table configuration
 			for _, table := range tables.Tables {
 				// Test table ID
 				assert.NotEmpty(t, table.Id, "Table should have an ID")
-				
+
 				// Test table reference
 				assert.NotNil(t, table.TableReference, "Table should have a reference")
 				assert.NotEmpty(t, table.TableReference.TableId, "Table reference should have a table ID")
-				
+
 				// Test creation time
 				assert.True(t, table.CreationTime > 0, "Table should have a creation time")
 			}
-		}
+			return nil
+		})
+		require.NoError(t, err)
 	})
 
 	suite.Logger.Info().Msg("BigQuery infrastructure tests completed")
@@ -1356,14 +1473,25 @@ func (suite *GCPTestSuite) TestBigQuery(t *testing.T) {
 // TestPubSub tests Pub/Sub infrastructure
 func (suite *GCPTestSuite) TestPubSub(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Pub/Sub infrastructure")
+	suite.PubSub = RequireService[*pubsub.Service](t, suite.Registry)
 
 	// Test Pub/Sub Topics
 	t.Run("Pub/Sub Topics", func(t *testing.T) {
-		topics, err := suite.PubSub.Projects.Topics.List("projects/" + suite.ProjectID).Context(suite.Context).Do()
+		projectName := "projects/" + suite.ProjectID
+		var topicsResp *pubsub.ListTopicsResponse
+		err := suite.DoWithRetry("pubsub.Topics.List", projectName, func() error {
+			var callErr error
+			topicsResp, callErr = suite.PubSub.Projects.Topics.List(projectName).Context(suite.Context).Do()
+			return callErr
+		})
 		require.NoError(t, err)
+		var topics []*pubsub.Topic
+		if topicsResp != nil {
+			topics = topicsResp.Topics
+		}
 
 		// Test topic configuration
-		for _, topic := range topics.Topics {
+		for _, topic := range topics {
 			// Test topic name
 			assert.NotEmpty(t, topic.Name, "Topic should have a name")
 		}
@@ -1378,10 +1506,10 @@ func (suite *GCPTestSuite) TestPubSub(t *testing.T) {
 		for _, subscription := range subscriptions.Subscriptions {
 			// Test subscription name
 			assert.NotEmpty(t, subscription.Name, "Subscription should have a name")
-			
+
 			// Test topic
 			assert.NotEmpty(t, subscription.Topic, "Subscription should have a topic")
-			
+
 			// Test ack deadline
 			assert.True(t, subscription.AckDeadlineSeconds > 0, "Subscription should have an ack deadline")
 		}
@@ -1393,6 +1521,7 @@ func (suite *GCPTestSuite) TestPubSub(t *testing.T) {
 // TestFirebase tests Firebase infrastructure
 func (suite *GCPTestSuite) TestFirebase(t *testing.T) {
 	suite.Logger.Info().Msg("Testing Firebase infrastructure")
+	suite.Firebase = RequireService[*firebase.Service](t, suite.Registry)
 
 	// Test Firebase Projects
 	t.Run("Firebase Projects", func(t *testing.T) {
@@ -1403,12 +1532,12 @@ func (suite *GCPTestSuite) TestFirebase(t *testing.T) {
 		for _, project := range projects.Results {
 			// Test project ID
 			assert.NotEmpty(t, project.ProjectId, "Firebase project should have a project ID")
-			
+
 			// Test display name
 			assert.NotEmpty(t, project.DisplayName, "Firebase project should have a display name")
-			
+
 			// Test state
-			assert.Contains(t, []string{"STATE_UNSPECIFIED", "ACTIVE", "DELETED"}, 
+			assert.Contains(t, []string{"STATE_UNSPECIFIED", "ACTIVE", "DELETED"},
 				project.State, "Firebase project should have a valid state")
 		}
 	})
@@ -1422,14 +1551,31 @@ func (suite *GCPTestSuite) TestCompliance(t *testing.T) {
 
 	// Test Asset Inventory
 	t.Run("Asset Inventory", func(t *testing.T) {
-		// This would test Cloud Asset Inventory if it's configured
-		suite.Logger.Info().Msg("Asset inventory compliance tests would be implemented here")
+		suite.CloudAsset = RequireService[*cloudasset.Service](t, suite.Registry)
+
+		current, err := suite.ExportAssetSnapshot()
+		require.NoError(t, err)
+
+		if *updateGCPBaseline {
+			require.NoError(t, WriteAssetBaseline(gcpBaselinePath, current))
+			suite.Logger.Info().Str("path", gcpBaselinePath).Int("resources", len(current)).
+				Msg("Updated Cloud Asset Inventory baseline")
+			return
+		}
+
+		baseline, err := LoadAssetBaseline(gcpBaselinePath)
+		require.NoError(t, err)
+
+		drifts := DiffAssetSnapshot(baseline, current, assetAllowlist)
+		if len(drifts) > 0 {
+			t.Errorf("Cloud Asset Inventory drift detected against %s:\n%s", gcpBaselinePath, FormatAssetDriftReport(drifts))
+		}
 	})
 
 	// Test Policy Compliance
 	t.Run("Policy Compliance", func(t *testing.T) {
-		// This would test Organization Policy constraints
-		suite.Logger.Info().Msg("Policy compliance tests would be implemented here")
+		suite.OrgPolicy = RequireService[*orgpolicy.Service](t, suite.Registry)
+		suite.AssertOrgPolicyCompliance(t)
 	})
 
 	suite.Logger.Info().Msg("Compliance tests completed")
@@ -1438,6 +1584,7 @@ func (suite *GCPTestSuite) TestCompliance(t *testing.T) {
 // TestPerformance tests performance
 func (suite *GCPTestSuite) TestPerformance(t *testing.T) {
 	suite.Logger.Info().Msg("Testing performance")
+	suite.Compute = RequireService[*compute.Service](t, suite.Registry)
 
 	// Test Autoscaling
 	t.Run("Autoscaling", func(t *testing.T) {
@@ -1448,16 +1595,16 @@ func (suite *GCPTestSuite) TestPerformance(t *testing.T) {
 		for _, autoscaler := range autoscalers.Items {
 			// Test autoscaler name
 			assert.NotEmpty(t, autoscaler.Name, "Autoscaler should have a name")
-			
+
 			// Test target
 			assert.NotEmpty(t, autoscaler.Target, "Autoscaler should have a target")
-			
+
 			// Test autoscaling policy
 			assert.NotNil(t, autoscaler.AutoscalingPolicy, "Autoscaler should have a policy")
-			
+
 			// Test min/max replicas
 			assert.True(t, autoscaler.AutoscalingPolicy.MinNumReplicas > 0, "Min replicas should be greater than 0")
-			assert.True(t, autoscaler.AutoscalingPolicy.MaxNumReplicas >= autoscaler.AutoscalingPolicy.MinNumReplicas, 
+			assert.True(t, autoscaler.AutoscalingPolicy.MaxNumReplicas >= autoscaler.AutoscalingPolicy.MinNumReplicas,
 				"Max replicas should be >= min replicas")
 		}
 	})
@@ -1465,37 +1612,15 @@ func (suite *GCPTestSuite) TestPerformance(t *testing.T) {
 	suite.Logger.Info().Msg("Performance tests completed")
 }
 
-// TestDisasterRecovery tests disaster recovery
+// TestDisasterRecovery tests disaster recovery. The real replica lag, PITR
+// and backup retention, and bucket replication assertions live in
+// AssertDisasterRecovery in gcp_dr_test.go; this only wires it up.
 func (suite *GCPTestSuite) TestDisasterRecovery(t *testing.T) {
 	suite.Logger.Info().Msg("Testing disaster recovery")
+	suite.SQL = RequireService[*sql.Service](t, suite.Registry)
+	suite.Storage = RequireService[*storage.Service](t, suite.Registry)
 
-	// Test Multi-Region Deployments
-	t.Run("Multi-Region Deployments", func(t *testing.T) {
-		// Test Cloud SQL replicas
-		instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
-		require.NoError(t, err)
-
-		for _, instance := range instances.Items {
-			if instance.ReplicaConfiguration != nil {
-				suite.Logger.Info().Str("instance", instance.Name).
-					Msg("SQL instance has replica configuration")
-			}
-		}
-	})
-
-	// Test Backup Strategies
-	t.Run("Backup Strategies", func(t *testing.T) {
-		// Test SQL backups
-		instances, err := suite.SQL.Instances.List(suite.ProjectID).Context(suite.Context).Do()
-		require.NoError(t, err)
-
-		for _, instance := range instances.Items {
-			if instance.Settings.BackupConfiguration != nil && instance.Settings.BackupConfiguration.Enabled {
-				suite.Logger.Info().Str("instance", instance.Name).
-					Msg("SQL instance has backups enabled")
-			}
-		}
-	})
+	t.Run("DR Validation", suite.AssertDisasterRecovery)
 
 	suite.Logger.Info().Msg("Disaster recovery tests completed")
 }
@@ -1503,11 +1628,14 @@ func (suite *GCPTestSuite) TestDisasterRecovery(t *testing.T) {
 // TestCostManagement tests cost management
 func (suite *GCPTestSuite) TestCostManagement(t *testing.T) {
 	suite.Logger.Info().Msg("Testing cost management")
+	suite.Compute = RequireService[*compute.Service](t, suite.Registry)
+	suite.BigQuery = RequireService[*bigquery.Service](t, suite.Registry)
+	suite.BillingBudgets = RequireService[*billingbudgets.Service](t, suite.Registry)
 
 	// Test Billing
 	t.Run("Billing", func(t *testing.T) {
-		// This would test Cloud Billing if it's configured
-		suite.Logger.Info().Msg("Billing and cost management tests would be implemented here")
+		suite.AssertCostBaseline(t)
+		suite.AssertBillingBudgets(t)
 	})
 
 	// Test Resource Quotas
@@ -1522,119 +1650,180 @@ func (suite *GCPTestSuite) TestCostManagement(t *testing.T) {
 				Float64("usage", quota.Usage).
 				Float64("limit", quota.Limit).
 				Msg("Resource quota")
-			
+
 			// Check quota usage
 			usagePercentage := quota.Usage / quota.Limit
-			if usagePercentage > 0.8 {
-				suite.Logger.Warn().Str("metric", quota.Metric).
-					Float64("usage_percentage", usagePercentage).
-					Msg("High quota usage")
-			}
+			assert.LessOrEqualf(t, usagePercentage, 0.8,
+				"quota %s is at %.0f%% usage, exceeding the 80%% guardrail", quota.Metric, usagePercentage*100)
 		}
 	})
 
 	suite.Logger.Info().Msg("Cost management tests completed")
 }
 
-// Helper methods for health checks and connectivity tests
-func (suite *GCPTestSuite) TestDatabaseHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database health")
-	// Implementation would test actual database connectivity
-	return nil
-}
+// Helper methods for health checks and connectivity tests.
+//
+// TestDatabaseHealth, TestCacheHealth, TestLoadBalancerHealth,
+// TestContainerServiceHealth, TestHTTPConnectivity and TestInternalConnectivity
+// live in gcp_probes_test.go, backed by the shared Prober subsystem.
 
-func (suite *GCPTestSuite) TestCacheHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing cache health")
-	// Implementation would test actual cache connectivity
-	return nil
-}
+// TestSSLConfiguration performs real TLS chain, cipher, HSTS and redirect
+// checks against every *_endpoint in outputs and lives in gcp_ssl_test.go.
 
-func (suite *GCPTestSuite) TestLoadBalancerHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing load balancer health")
-	// Implementation would test actual load balancer connectivity
+// The GCP policy-as-code bundle (built-in + testdata/policies/*.rego) that
+// covers network security and access controls is evaluated only by
+// TestAccessControls, via EvaluateGCPPolicyCompliance in gcp_policy_test.go;
+// TestNetworkSecurity just logs and returns nil, so the bundle and the
+// services the resource graph is built from are resolved only once.
+func (suite *GCPTestSuite) TestNetworkSecurity(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing network security")
 	return nil
 }
 
-func (suite *GCPTestSuite) TestContainerServiceHealth(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing container service health")
-	// Implementation would test actual container service connectivity
-	return nil
-}
+func (suite *GCPTestSuite) TestAccessControls(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing access controls")
 
-func (suite *GCPTestSuite) TestHTTPConnectivity(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing HTTP connectivity")
-	// Implementation would test actual HTTP connectivity using http-helper
-	return nil
-}
+	var err error
+	if suite.CloudResourceManager, err = GetService[*cloudresourcemanager.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get CloudResourceManager service")
+	}
+	if suite.Compute, err = GetService[*compute.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Compute service")
+	}
+	if suite.Storage, err = GetService[*storage.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Storage service")
+	}
+	if suite.Container, err = GetService[*container.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Container service")
+	}
 
-func (suite *GCPTestSuite) TestInternalConnectivity(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing internal connectivity")
-	// Implementation would test actual internal connectivity
+	findings, err := suite.EvaluateGCPPolicyCompliance()
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate GCP policy compliance")
+	}
+	if len(findings) > 0 {
+		return errors.Errorf("%d policy violation(s) found (see log for rule IDs and resource names)", len(findings))
+	}
 	return nil
 }
 
-func (suite *GCPTestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
-	suite.Logger.Info().Msg("Testing SSL configuration")
-	// Implementation would test SSL/TLS configuration
+// TestEncryption verifies CMEK usage on Cloud SQL, GCS, Compute disks and
+// GKE, plus the referenced keys' rotation policy and that the runner can
+// actually use them; see EvaluateEncryption in gcp_encryption_test.go.
+func (suite *GCPTestSuite) TestEncryption(outputs map[string]interface{}) error {
+	suite.Logger.Info().Msg("Testing encryption")
+
+	var err error
+	if suite.SQL, err = GetService[*sql.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get SQL service")
+	}
+	if suite.Storage, err = GetService[*storage.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Storage service")
+	}
+	if suite.Compute, err = GetService[*compute.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Compute service")
+	}
+	if suite.Container, err = GetService[*container.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get Container service")
+	}
+	if suite.CloudKMS, err = GetService[*cloudkms.Service](suite.Registry); err != nil {
+		return errors.Wrap(err, "failed to get CloudKMS service")
+	}
+
+	violations, err := suite.EvaluateEncryption()
+	if err != nil {
+		return errors.Wrap(err, "failed to evaluate encryption policy")
+	}
+	for _, v := range violations {
+		suite.Logger.Error().Msg(v)
+	}
+	if len(violations) > 0 {
+		return errors.Errorf("%d encryption policy violation(s) found (see log for details)", len(violations))
+	}
 	return nil
 }
 
-func (suite *GCPTestSuite) TestNetworkSecurity(outputs map[string]interface{}) error {
-	suite.Logger.Info().Msg("Testing network security")
-	// Implementation would test network security rules
-	return nil
+// TestLoadPerformance, TestStressPerformance and TestEndurancePerformance
+// drive real k6/Vegeta-style load against outputs' endpoint and live in
+// gcp_perf_test.go, backed by the PerfScenario runner.
+
+// TestDatabaseBackup and TestStorageBackup drive a real seed/verify backup
+// round trip and live in gcp_backup_verify_test.go.
+
+// TestMonitoringEndpoint scrapes endpoint as Prometheus would and checks
+// suite.Config.MonitoringRequiredMetrics/MonitoringExpectedTargets against
+// it; see runMonitoringCheck in monitoring_verify_test.go.
+func (suite *GCPTestSuite) TestMonitoringEndpoint(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing monitoring endpoint")
+	return runMonitoringCheck(suite.Context, suite.Logger, endpoint, suite.Config.MonitoringRequiredMetrics, suite.Config.MonitoringExpectedTargets)
 }
 
-func (suite *GCPTestSuite) TestAccessControls(outputs map[string]interface{}) error {
-	suite.Logger.Info().Msg("Testing access controls")
-	// Implementation would test access control policies
-	return nil
+// TestAlertingEndpoint queries endpoint's Alertmanager API and fails if
+// any alert outside suite.Config.MonitoringAllowedFiringAlerts is firing;
+// see runAlertingCheck in monitoring_verify_test.go.
+func (suite *GCPTestSuite) TestAlertingEndpoint(endpoint string) error {
+	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing alerting endpoint")
+	return runAlertingCheck(suite.Context, suite.Logger, endpoint, suite.Config.MonitoringAllowedFiringAlerts)
 }
 
-func (suite *GCPTestSuite) TestEncryption(outputs map[string]interface{}) error {
-	suite.Logger.Info().Msg("Testing encryption")
-	// Implementation would test encryption configuration
-	return nil
+// gcpProviderTestSuite adapts *GCPTestSuite to the ProviderTestSuite
+// interface registered under the "gcp" name.
+type gcpProviderTestSuite struct {
+	suite *GCPTestSuite
 }
 
-func (suite *GCPTestSuite) TestLoadPerformance(outputs map[string]interface{}) error {
-	suite.Logger.Info().Str("test_type", "load").Msg("Testing load performance")
-	// Implementation would run load performance tests
-	return nil
+func (p *gcpProviderTestSuite) Name() string { return "gcp" }
+
+// TestConnectivity calls Compute Regions.List, a read-only, always-permitted
+// call, as a lightweight reachability check.
+func (p *gcpProviderTestSuite) TestConnectivity(ctx context.Context) error {
+	computeService, err := GetService[*compute.Service](p.suite.Registry)
+	if err != nil {
+		return err
+	}
+	_, err = computeService.Regions.List(p.suite.ProjectID).Context(ctx).Do()
+	return err
 }
 
-func (suite *GCPTestSuite) TestStressPerformance(outputs map[string]interface{}) error {
-	suite.Logger.Info().Str("test_type", "stress").Msg("Testing stress performance")
-	// Implementation would run stress performance tests
-	return nil
+func (p *gcpProviderTestSuite) TestBackup(ctx context.Context) error {
+	return unimplementedCapability("gcp", "TestBackup")
 }
 
-func (suite *GCPTestSuite) TestEndurancePerformance(outputs map[string]interface{}) error {
-	suite.Logger.Info().Str("test_type", "endurance").Msg("Testing endurance performance")
-	// Implementation would run endurance performance tests
-	return nil
+func (p *gcpProviderTestSuite) TestDatabaseReplication(ctx context.Context) error {
+	return unimplementedCapability("gcp", "TestDatabaseReplication")
 }
 
-func (suite *GCPTestSuite) TestDatabaseBackup(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing database backup")
-	// Implementation would test database backup functionality
-	return nil
+// gcpProviderPlugin is the ProviderPlugin registered for "gcp";
+// RegisterProvider is called from this file's init() below.
+type gcpProviderPlugin struct {
+	recorder *iamsynth.Recorder
 }
 
-func (suite *GCPTestSuite) TestStorageBackup(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing storage backup")
-	// Implementation would test storage backup functionality
-	return nil
+func (p *gcpProviderPlugin) Name() string { return "gcp" }
+
+func (p *gcpProviderPlugin) Capabilities() []string { return []string{"connectivity"} }
+
+// WithRecorder implements recorderAwarePlugin so
+// initializeCloudProviderSuites can thread suite.Recorder through to
+// NewGCPTestSuite for least-privilege IAM policy synthesis.
+func (p *gcpProviderPlugin) WithRecorder(recorder *iamsynth.Recorder) ProviderPlugin {
+	return &gcpProviderPlugin{recorder: recorder}
 }
 
-func (suite *GCPTestSuite) TestMonitoringEndpoint(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing monitoring endpoint")
-	// Implementation would test monitoring endpoint
-	return nil
+func (p *gcpProviderPlugin) Init(provider CloudProvider, testConfig TestConfig) (ProviderTestSuite, error) {
+	projectID := provider.Credentials["project_id"]
+	if projectID == "" {
+		projectID = "default-project"
+	}
+
+	suite, err := NewGCPTestSuite(projectID, provider.Regions[0], provider.Regions[0]+"-a", testConfig,
+		option.WithHTTPClient(iamsynth.WrapHTTPClient(p.recorder, &http.Client{})))
+	if err != nil {
+		return nil, err
+	}
+	return &gcpProviderTestSuite{suite: suite}, nil
 }
 
-func (suite *GCPTestSuite) TestAlertingEndpoint(endpoint string) error {
-	suite.Logger.Info().Str("endpoint", endpoint).Msg("Testing alerting endpoint")
-	// Implementation would test alerting endpoint
-	return nil
-}
\ No newline at end of file
+func init() {
+	RegisterProvider("gcp", func() ProviderPlugin { return &gcpProviderPlugin{} })
+}
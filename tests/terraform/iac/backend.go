@@ -0,0 +1,55 @@
+package iac
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// localBackendState is the subset of .terraform/terraform.tfstate (the
+// pointer file `terraform init` writes locally to record which backend a
+// working directory is configured against) this package cares about.
+type localBackendState struct {
+	Backend struct {
+		Type   string                 `json:"type"`
+		Config map[string]interface{} `json:"config"`
+	} `json:"backend"`
+}
+
+// CheckStateBackendEncrypted reads dir's .terraform/terraform.tfstate
+// (written by Runner.Init) and flags a backend that isn't encrypted at
+// rest. S3 requires "encrypt = true" explicitly (it defaulted to
+// unencrypted for years); GCS and Azure Blob Storage encrypt every object
+// server-side unconditionally, so any backend block targeting them always
+// passes. An unrecognized backend type is left unchecked rather than
+// flagged, since this package doesn't know its encryption defaults.
+func CheckStateBackendEncrypted(dir string) ([]Finding, error) {
+	path := filepath.Join(dir, ".terraform", "terraform.tfstate")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Finding{{Resource: dir, Detail: "no .terraform/terraform.tfstate found -- has Init run?"}}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	var state localBackendState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	switch state.Backend.Type {
+	case "s3":
+		if encrypt, _ := state.Backend.Config["encrypt"].(bool); !encrypt {
+			return []Finding{{Resource: dir, Detail: "s3 backend does not set encrypt = true"}}, nil
+		}
+	case "gcs", "azurerm":
+		// Encrypted at rest unconditionally by the provider.
+	case "":
+		return []Finding{{Resource: dir, Detail: "no backend configured (state defaults to local, unencrypted disk)"}}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,211 @@
+package iac
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-json"
+)
+
+// Finding is one invariant violation surfaced by a Check* function.
+type Finding struct {
+	Resource string
+	Detail   string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Resource, f.Detail)
+}
+
+// plannedResources returns every resource change in plan that will exist
+// after apply (i.e. not a pure delete), along with its planned attribute
+// values.
+func plannedResources(plan *tfjson.Plan) []*tfjson.ResourceChange {
+	var resources []*tfjson.ResourceChange
+	for _, change := range plan.ResourceChanges {
+		if change.Change == nil {
+			continue
+		}
+		if len(change.Change.Actions) == 1 && change.Change.Actions[0] == tfjson.ActionDelete {
+			continue
+		}
+		resources = append(resources, change)
+	}
+	return resources
+}
+
+// CheckTopology compares plan's planned (non-deleted) resource counts per
+// type against expected, flagging every expected type whose planned count
+// differs from its expected count (including types never planned at all)
+// and every planned type expected doesn't declare.
+func CheckTopology(plan *tfjson.Plan, expected map[string]int) []Finding {
+	actual := map[string]int{}
+	for _, resource := range plannedResources(plan) {
+		actual[resource.Type]++
+	}
+
+	var findings []Finding
+	for resourceType, expectedCount := range expected {
+		if actual[resourceType] != expectedCount {
+			findings = append(findings, Finding{
+				Resource: resourceType,
+				Detail:   fmt.Sprintf("expected %d planned resource(s), found %d", expectedCount, actual[resourceType]),
+			})
+		}
+	}
+	for resourceType, count := range actual {
+		if _, ok := expected[resourceType]; !ok {
+			findings = append(findings, Finding{
+				Resource: resourceType,
+				Detail:   fmt.Sprintf("%d resource(s) planned but not declared in ExpectedTopology", count),
+			})
+		}
+	}
+	return findings
+}
+
+// CheckRequiredTags flags every planned resource whose "tags"/"labels"
+// attribute (AWS/Azure use "tags", GCP uses "labels") is missing any key
+// in requiredTags. Resources with neither attribute at all (not every
+// provider resource type is taggable) are skipped.
+func CheckRequiredTags(plan *tfjson.Plan, requiredTags []string) []Finding {
+	var findings []Finding
+	for _, resource := range plannedResources(plan) {
+		after, ok := resource.Change.After.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		tags, ok := after["tags"].(map[string]interface{})
+		if !ok {
+			tags, ok = after["labels"].(map[string]interface{})
+		}
+		if !ok {
+			continue
+		}
+
+		var missing []string
+		for _, key := range requiredTags {
+			if _, present := tags[key]; !present {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			findings = append(findings, Finding{
+				Resource: resource.Address,
+				Detail:   fmt.Sprintf("missing required tag(s): %v", missing),
+			})
+		}
+	}
+	return findings
+}
+
+// publicIngressCIDRAttributes lists, per resource type, the attribute path
+// that carries a rule's source CIDR list -- the shape differs enough
+// across providers (AWS nests ingress blocks, GCP/Azure use a flat list)
+// that each needs its own extraction.
+var publicIngressCIDRAttributes = map[string][]string{
+	"aws_security_group":                  {"ingress"},
+	"aws_vpc_security_group_ingress_rule": {"cidr_ipv4"},
+	"google_compute_firewall":             {"source_ranges"},
+	"azurerm_network_security_rule":       {"source_address_prefix", "source_address_prefixes"},
+}
+
+const anyIPv4CIDR = "0.0.0.0/0"
+
+// CheckNoPublicIngress flags any planned security-group-like resource
+// whose ingress rules allow 0.0.0.0/0, unless its address appears in
+// allowlist.
+func CheckNoPublicIngress(plan *tfjson.Plan, allowlist map[string]bool) []Finding {
+	var findings []Finding
+	for _, resource := range plannedResources(plan) {
+		attrs, ok := publicIngressCIDRAttributes[resource.Type]
+		if !ok || allowlist[resource.Address] {
+			continue
+		}
+
+		after, ok := resource.Change.After.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if resourceAllowsPublicIngress(after, attrs) {
+			findings = append(findings, Finding{
+				Resource: resource.Address,
+				Detail:   fmt.Sprintf("allows ingress from %s and is not in the public-ingress allowlist", anyIPv4CIDR),
+			})
+		}
+	}
+	return findings
+}
+
+// resourceAllowsPublicIngress reports whether any of attrs on after
+// contains anyIPv4CIDR, looking both at flat string-list attributes
+// (GCP/Azure) and AWS's nested ingress block list.
+func resourceAllowsPublicIngress(after map[string]interface{}, attrs []string) bool {
+	for _, attr := range attrs {
+		value, ok := after[attr]
+		if !ok {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if v == anyIPv4CIDR {
+				return true
+			}
+		case []interface{}:
+			if containsPublicCIDR(v) {
+				return true
+			}
+			// AWS's "ingress" attribute is a list of blocks, each with its
+			// own cidr_blocks list.
+			for _, block := range v {
+				blockMap, ok := block.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if cidrBlocks, ok := blockMap["cidr_blocks"].([]interface{}); ok && containsPublicCIDR(cidrBlocks) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func containsPublicCIDR(cidrs []interface{}) bool {
+	for _, cidr := range cidrs {
+		if s, ok := cidr.(string); ok && s == anyIPv4CIDR {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffTopology compares two plans' planned resource addresses, reporting
+// one Finding per address that appeared in curr but not prev ("added") or
+// prev but not curr ("removed") -- drift between two plan-time snapshots
+// of the same fixture rather than drift against live infrastructure.
+func DiffTopology(prev, curr *tfjson.Plan) []Finding {
+	prevAddresses := map[string]bool{}
+	for _, resource := range plannedResources(prev) {
+		prevAddresses[resource.Address] = true
+	}
+	currAddresses := map[string]bool{}
+	for _, resource := range plannedResources(curr) {
+		currAddresses[resource.Address] = true
+	}
+
+	var findings []Finding
+	for address := range currAddresses {
+		if !prevAddresses[address] {
+			findings = append(findings, Finding{Resource: address, Detail: "added since previous plan"})
+		}
+	}
+	for address := range prevAddresses {
+		if !currAddresses[address] {
+			findings = append(findings, Finding{Resource: address, Detail: "removed since previous plan"})
+		}
+	}
+	return findings
+}
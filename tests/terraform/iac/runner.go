@@ -0,0 +1,220 @@
+// Package iac drives terraform/tofu directly (init/plan/apply/destroy) to
+// exercise a provider's fixture module the way hashicorp/terraform's own
+// builtin provider acceptance tests do: one ephemeral workspace per test,
+// a parsed plan checked against declarative invariants before anything is
+// applied, and destroy-by-default unless the caller asks to keep the
+// workspace around for debugging.
+package iac
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+)
+
+// DetectBinary returns the path to the Terraform-compatible binary this
+// Runner should shell out to. IAC_BINARY overrides detection outright
+// (set it to an absolute path or a bare name resolved via PATH); otherwise
+// "terraform" is preferred over "tofu" -- OpenTofu is a drop-in CLI
+// replacement, so this only matters when both happen to be installed and
+// the caller hasn't pinned one.
+func DetectBinary() (string, error) {
+	if override := os.Getenv("IAC_BINARY"); override != "" {
+		if path, err := exec.LookPath(override); err == nil {
+			return path, nil
+		}
+		return "", errors.Errorf("IAC_BINARY=%q is not an executable on PATH", override)
+	}
+
+	for _, name := range []string{"terraform", "tofu"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("neither terraform nor tofu was found on PATH (set IAC_BINARY to override)")
+}
+
+// Runner drives one provider fixture module's lifecycle: Init, Plan (which
+// writes a plan file and returns its parsed tfjson.Plan), Apply, and
+// Destroy, all scoped to a dedicated Terraform workspace so concurrent
+// Runners against the same fixture module don't collide on state.
+type Runner struct {
+	BinaryPath    string
+	Dir           string
+	Workspace     string
+	Vars          map[string]interface{}
+	KeepOnFailure bool
+
+	planFile string
+}
+
+// RunnerOption configures a Runner returned by NewRunner.
+type RunnerOption func(*Runner)
+
+// WithVars sets the -var values passed to every plan/apply.
+func WithVars(vars map[string]interface{}) RunnerOption {
+	return func(r *Runner) { r.Vars = vars }
+}
+
+// WithKeepOnFailure makes Cleanup skip destroy when called after a failed
+// run, leaving the workspace's resources in place for debugging -- the
+// --keep-on-failure flag hashicorp's acceptance test harness offers.
+func WithKeepOnFailure(keep bool) RunnerOption {
+	return func(r *Runner) { r.KeepOnFailure = keep }
+}
+
+// NewRunner detects a terraform/tofu binary and returns a Runner for dir,
+// scoped to workspace.
+func NewRunner(dir, workspace string, opts ...RunnerOption) (*Runner, error) {
+	binaryPath, err := DetectBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &Runner{BinaryPath: binaryPath, Dir: dir, Workspace: workspace}
+	for _, opt := range opts {
+		opt(runner)
+	}
+	return runner, nil
+}
+
+// run executes the binary with args in Dir, returning its stdout; stderr
+// is only included in the error when the command fails.
+func (r *Runner) run(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, r.BinaryPath, args...)
+	cmd.Dir = r.Dir
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, errors.Wrapf(err, "%s %v failed: %s", r.BinaryPath, args, string(exitErr.Stderr))
+		}
+		return nil, errors.Wrapf(err, "%s %v failed", r.BinaryPath, args)
+	}
+	return out, nil
+}
+
+// Init runs init then selects (creating if necessary) r.Workspace, giving
+// every Runner against the same Dir an isolated state file.
+func (r *Runner) Init(ctx context.Context) error {
+	if _, err := r.run(ctx, "init", "-input=false", "-no-color"); err != nil {
+		return errors.Wrapf(err, "failed to init %s", r.Dir)
+	}
+
+	if r.Workspace == "" || r.Workspace == "default" {
+		return nil
+	}
+
+	if _, err := r.run(ctx, "workspace", "select", r.Workspace); err != nil {
+		if _, createErr := r.run(ctx, "workspace", "new", r.Workspace); createErr != nil {
+			return errors.Wrapf(createErr, "failed to create workspace %q in %s", r.Workspace, r.Dir)
+		}
+	}
+	return nil
+}
+
+// varArgs renders r.Vars as "-var=key=value" CLI arguments.
+func (r *Runner) varArgs() ([]string, error) {
+	args := make([]string, 0, len(r.Vars))
+	for key, value := range r.Vars {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode var %q", key)
+		}
+		args = append(args, "-var="+key+"="+string(encoded))
+	}
+	return args, nil
+}
+
+// Plan runs plan -out=<workspace>.tfplan, parses it back via `show -json`,
+// and returns the typed result. The plan file path is remembered so a
+// subsequent Apply applies exactly what was reviewed here rather than
+// re-planning.
+func (r *Runner) Plan(ctx context.Context) (*tfjson.Plan, error) {
+	varArgs, err := r.varArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	planFile := filepath.Join(r.Dir, r.Workspace+".tfplan")
+	args := append([]string{"plan", "-input=false", "-no-color", "-out=" + planFile}, varArgs...)
+	if _, err := r.run(ctx, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to plan %s", r.Dir)
+	}
+	r.planFile = planFile
+
+	out, err := r.run(ctx, "show", "-json", planFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to show plan for %s", r.Dir)
+	}
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal(out, &plan); err != nil {
+		return nil, errors.Wrap(err, "failed to parse plan JSON")
+	}
+	return &plan, nil
+}
+
+// Apply applies the plan file Plan produced. Plan must have been called
+// first in this Runner.
+func (r *Runner) Apply(ctx context.Context) error {
+	if r.planFile == "" {
+		return errors.New("Apply called before Plan produced a plan file")
+	}
+	if _, err := r.run(ctx, "apply", "-input=false", "-no-color", "-auto-approve", r.planFile); err != nil {
+		return errors.Wrapf(err, "failed to apply %s", r.Dir)
+	}
+	return nil
+}
+
+// Destroy tears down everything this Runner's workspace has applied.
+func (r *Runner) Destroy(ctx context.Context) error {
+	varArgs, err := r.varArgs()
+	if err != nil {
+		return err
+	}
+	args := append([]string{"destroy", "-input=false", "-no-color", "-auto-approve"}, varArgs...)
+	if _, err := r.run(ctx, args...); err != nil {
+		return errors.Wrapf(err, "failed to destroy %s", r.Dir)
+	}
+	return nil
+}
+
+// Output runs `terraform output -json` and returns the current state's
+// output values keyed by output name -- the same shape terratest's
+// terraform.OutputAll returns, so a caller can evaluate the same
+// assertions against either a terratest-driven apply or this package's own
+// Runner.
+func (r *Runner) Output(ctx context.Context) (map[string]interface{}, error) {
+	out, err := r.run(ctx, "output", "-json")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read outputs for %s", r.Dir)
+	}
+
+	var raw map[string]struct {
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to parse output JSON")
+	}
+
+	outputs := make(map[string]interface{}, len(raw))
+	for name, entry := range raw {
+		outputs[name] = entry.Value
+	}
+	return outputs, nil
+}
+
+// Cleanup destroys the workspace's resources unless failed is true and
+// r.KeepOnFailure was set, in which case it logs nothing and leaves the
+// workspace alone for a developer to inspect with `terraform show`.
+func (r *Runner) Cleanup(ctx context.Context, failed bool) error {
+	if failed && r.KeepOnFailure {
+		return nil
+	}
+	return r.Destroy(ctx)
+}
@@ -0,0 +1,168 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iac"
+)
+
+// iacWorkspaceName gives each provider's fixture module a workspace
+// isolated to this suite run, so concurrent CI runs (or a single run's
+// "Infrastructure as Code" plan followed by "Deployment Automation" apply)
+// never collide on another run's state.
+func (suite *MultiCloudTestSuite) iacWorkspaceName(provider string) string {
+	return fmt.Sprintf("iac-%s-%s", provider, suite.TestID)
+}
+
+// planIaCFixture inits and plans provider's fixture module, returning nil
+// (with a logged warning, not an error) when the fixture directory doesn't
+// exist or no terraform/tofu binary is on PATH -- the same
+// skip-what's-missing behavior RunValidationTests already uses for its own
+// Terraform directories, since this sandbox-agnostic suite shouldn't fail
+// outright just because the local environment lacks the CLI.
+func (suite *MultiCloudTestSuite) planIaCFixture(provider, dir string) (*iac.Runner, *tfjson.Plan, error) {
+	if !files.FileExists(dir) {
+		suite.Logger.Warn().Str("provider", provider).Str("dir", dir).Msg("IaC fixture directory not found, skipping")
+		return nil, nil, nil
+	}
+
+	runner, err := iac.NewRunner(dir, suite.iacWorkspaceName(provider),
+		iac.WithVars(map[string]interface{}{
+			"environment":  suite.Config.Environment,
+			"region":       suite.Config.Region,
+			"project_name": suite.Config.ProjectName,
+			"test_id":      suite.TestID,
+			"tags":         suite.MultiCloudConfig.CostConfig.Allocation.Tags,
+		}),
+		iac.WithKeepOnFailure(suite.MultiCloudConfig.IaC.KeepOnFailure),
+	)
+	if err != nil {
+		suite.Logger.Warn().Str("provider", provider).Err(err).Msg("no terraform/tofu binary found, skipping IaC fixture")
+		return nil, nil, nil
+	}
+
+	if err := runner.Init(suite.Context); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to init IaC fixture for provider %q", provider)
+	}
+
+	plan, err := runner.Plan(suite.Context)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to plan IaC fixture for provider %q", provider)
+	}
+	return runner, plan, nil
+}
+
+// testInfrastructureAsCode plans every MultiCloudConfig.IaC.Fixtures module
+// and checks the result against ExpectedTopology, CostConfig.Allocation's
+// required tags, and PublicIngressAllowlist, plus the fixture's backend
+// encryption, aggregating every violation into one error instead of
+// stopping at the first.
+func (suite *MultiCloudTestSuite) testInfrastructureAsCode() error {
+	suite.Logger.Info().Msg("Testing infrastructure as code")
+
+	config := suite.MultiCloudConfig.IaC
+	if !config.Enabled {
+		return nil
+	}
+
+	allowlist := make(map[string]bool, len(config.PublicIngressAllowlist))
+	for _, address := range config.PublicIngressAllowlist {
+		allowlist[address] = true
+	}
+
+	var findings []string
+	for provider, dir := range config.Fixtures {
+		_, plan, err := suite.planIaCFixture(provider, dir)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			continue
+		}
+
+		for _, finding := range iac.CheckTopology(plan, config.ExpectedTopology[provider]) {
+			findings = append(findings, fmt.Sprintf("[%s] topology: %s", provider, finding))
+		}
+		for _, finding := range iac.CheckRequiredTags(plan, allocationTagKeys(suite.MultiCloudConfig.CostConfig.Allocation.Tags)) {
+			findings = append(findings, fmt.Sprintf("[%s] tags: %s", provider, finding))
+		}
+		for _, finding := range iac.CheckNoPublicIngress(plan, allowlist) {
+			findings = append(findings, fmt.Sprintf("[%s] public ingress: %s", provider, finding))
+		}
+
+		backendFindings, err := iac.CheckStateBackendEncrypted(dir)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check state backend encryption for provider %q", provider)
+		}
+		for _, finding := range backendFindings {
+			findings = append(findings, fmt.Sprintf("[%s] state backend: %s", provider, finding))
+		}
+	}
+
+	if len(findings) > 0 {
+		return errors.Errorf("infrastructure-as-code invariant violations:\n%s", strings.Join(findings, "\n"))
+	}
+	return nil
+}
+
+// allocationTagKeys extracts CostAllocation.Tags' keys as the required-tag
+// list CheckRequiredTags enforces -- the allocation config already names
+// every tag this suite's cost dimensions are keyed by, so requiring a
+// second, separate "required tags" list would just be the same list typed
+// twice.
+func allocationTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// testDeploymentAutomation applies and destroys every
+// MultiCloudConfig.IaC.Fixtures module in its own workspace, bounded by
+// Config.ParallelTests concurrent applies, proving the apply/destroy
+// automation path works independent of whatever
+// testInfrastructureAsCode's plan-only checks already covered.
+func (suite *MultiCloudTestSuite) testDeploymentAutomation() error {
+	suite.Logger.Info().Msg("Testing deployment automation")
+
+	config := suite.MultiCloudConfig.IaC
+	if !config.Enabled {
+		return nil
+	}
+
+	group, _ := errgroup.WithContext(suite.Context)
+	if suite.Config.ParallelTests > 0 {
+		group.SetLimit(suite.Config.ParallelTests)
+	}
+
+	for provider, dir := range config.Fixtures {
+		provider, dir := provider, dir
+		group.Go(func() error {
+			runner, plan, err := suite.planIaCFixture(provider, dir)
+			if err != nil {
+				return err
+			}
+			if plan == nil {
+				return nil
+			}
+
+			applyErr := runner.Apply(suite.Context)
+			if cleanupErr := runner.Cleanup(suite.Context, applyErr != nil); cleanupErr != nil {
+				suite.Logger.Error().Str("provider", provider).Err(cleanupErr).Msg("failed to clean up IaC fixture after deployment automation test")
+			}
+			if applyErr != nil {
+				return errors.Wrapf(applyErr, "failed to apply IaC fixture for provider %q", provider)
+			}
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
@@ -0,0 +1,23 @@
+package iamsynth
+
+import (
+	"context"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// APIOption returns a config.LoadOptions-compatible API option (pass it to
+// config.WithAPIOptions) that records every AWS SDK call's service ID and
+// operation name into recorder. It hooks the Finalize step, the last point
+// before the request is signed and sent, so it runs for every successful
+// and failed call alike.
+func APIOption(recorder *Recorder) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("IAMSynthRecorder",
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+				recorder.Record("aws", awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx), "*")
+				return next.HandleFinalize(ctx, in)
+			}), middleware.Before)
+	}
+}
@@ -0,0 +1,79 @@
+package iamsynth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// transport wraps an azcore/policy.Transporter, recording each request's
+// resource provider namespace and action before delegating to next.
+type transport struct {
+	recorder *Recorder
+	next     policy.Transporter
+}
+
+// Do implements azcore/policy.Transporter.
+func (t *transport) Do(req *http.Request) (*http.Response, error) {
+	service, action, resource := azureCallFromRequest(req)
+	t.recorder.Record("azure", service, action, resource)
+	return t.next.Do(req)
+}
+
+// azureCallFromRequest derives a (service, action, resource) tuple from an
+// ARM request path, e.g. PUT .../resourceGroups/rg/providers/Microsoft.Compute/
+// virtualMachines/vm becomes service="Microsoft.Compute",
+// action="virtualMachines/write", resource the full path.
+func azureCallFromRequest(req *http.Request) (service, action, resource string) {
+	path := strings.Trim(req.URL.Path, "/")
+	segments := strings.Split(path, "/")
+	resource = path
+
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") && i+1 < len(segments) {
+			service = segments[i+1]
+			resourceType := service
+			if i+2 < len(segments) {
+				resourceType = segments[i+2]
+			}
+			return service, resourceType + "/" + azureVerbFromMethod(req.Method), resource
+		}
+	}
+
+	service = "Microsoft.Resources"
+	return service, "subscriptions/" + azureVerbFromMethod(req.Method), resource
+}
+
+// azureVerbFromMethod maps an HTTP method to the read/write/delete/action
+// verb Azure role-definition Actions entries end with.
+func azureVerbFromMethod(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "read"
+	case http.MethodPut, http.MethodPatch:
+		return "write"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "action"
+	}
+}
+
+// WrapTransport returns a policy.Transporter that records every request
+// into recorder before delegating to next (http.DefaultTransport-backed
+// requests, if next is nil).
+func WrapTransport(recorder *Recorder, next policy.Transporter) policy.Transporter {
+	if next == nil {
+		next = defaultTransporter{}
+	}
+	return &transport{recorder: recorder, next: next}
+}
+
+// defaultTransporter adapts http.DefaultTransport to policy.Transporter for
+// callers of WrapTransport that have no existing transport to wrap.
+type defaultTransporter struct{}
+
+func (defaultTransporter) Do(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,91 @@
+package iamsynth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// roundTripper wraps an http.RoundTripper, recording each request's service
+// (derived from the request host) and action (derived from its path and
+// method) before delegating to next.
+type roundTripper struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	service, action, resource := gcpCallFromRequest(req)
+	rt.recorder.Record("gcp", service, action, resource)
+	return rt.next.RoundTrip(req)
+}
+
+// gcpCallFromRequest derives a (service, action, resource) tuple from a
+// Google API REST call, e.g. GET https://compute.googleapis.com/compute/v1/
+// projects/p/zones/z/instances/i becomes service="compute",
+// action="instances.get",
+// resource="compute/v1/projects/p/zones/z/instances/i".
+func gcpCallFromRequest(req *http.Request) (service, action, resource string) {
+	service = strings.SplitN(req.URL.Host, ".", 2)[0]
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	noun := service
+	if len(segments) > 0 {
+		noun = segments[len(segments)-1]
+	}
+	// A trailing numeric/named ID (not a known collection keyword) means
+	// the path ends at a single resource, e.g. ".../instances/my-instance"
+	// -- the collection noun is the segment before it.
+	if len(segments) >= 2 && !isCollectionNoun(noun) {
+		noun = segments[len(segments)-2]
+		resource = strings.Join(segments, "/")
+	} else if len(segments) > 0 {
+		resource = strings.Join(segments, "/")
+	}
+
+	action = noun + "." + gcpVerbFromMethod(req.Method)
+	return service, action, resource
+}
+
+// isCollectionNoun reports whether segment looks like a REST collection
+// name (list/insert target) rather than a specific resource ID.
+func isCollectionNoun(segment string) bool {
+	switch segment {
+	case "instances", "zones", "regions", "disks", "networks", "clusters", "buckets",
+		"databases", "functions", "services", "topics", "subscriptions", "keys",
+		"secrets", "datasets", "tables", "jobs":
+		return true
+	default:
+		return false
+	}
+}
+
+// gcpVerbFromMethod maps an HTTP method to the IAM-permission-style verb
+// Google's custom-role permission strings use.
+func gcpVerbFromMethod(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "get"
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "update"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "list"
+	}
+}
+
+// WrapHTTPClient returns a shallow copy of client whose Transport records
+// every request into recorder before delegating to client's own transport
+// (or http.DefaultTransport, if client.Transport is nil).
+func WrapHTTPClient(recorder *Recorder, client *http.Client) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	wrapped := *client
+	wrapped.Transport = &roundTripper{recorder: recorder, next: next}
+	return &wrapped
+}
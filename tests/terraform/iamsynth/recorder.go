@@ -0,0 +1,58 @@
+// Package iamsynth instruments the AWS/GCP/Azure SDK calls a test run
+// actually makes and synthesizes a minimal, provider-native least-privilege
+// policy from exactly those (service, action, resource) tuples -- rather
+// than relying on whatever broad role the test credentials happen to carry.
+package iamsynth
+
+import "sync"
+
+// Call is one recorded SDK invocation: the provider and service it went to,
+// the action (API operation, method, or verb) it performed, and the
+// resource it targeted, when the transport layer could resolve one.
+type Call struct {
+	Provider string
+	Service  string
+	Action   string
+	Resource string
+}
+
+// Recorder accumulates Calls from every wrapped client across a test run.
+// It's safe for concurrent use, since AWSTestSuite/GCPTestSuite/AzureTestSuite
+// calls happen from many goroutines (ParallelForEach, WaitGroup fan-out).
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record appends one Call. resource may be empty when the transport layer
+// couldn't resolve a specific resource identifier for the request.
+func (r *Recorder) Record(provider, service, action, resource string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Provider: provider, Service: service, Action: action, Resource: resource})
+}
+
+// Calls returns a snapshot of every Call recorded so far.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// ForProvider filters Calls down to one provider ("aws", "gcp", "azure").
+func (r *Recorder) ForProvider(provider string) []Call {
+	var filtered []Call
+	for _, c := range r.Calls() {
+		if c.Provider == provider {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
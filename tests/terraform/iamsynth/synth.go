@@ -0,0 +1,166 @@
+package iamsynth
+
+import (
+	"sort"
+	"strings"
+)
+
+// awsActionOverrides maps a recorded (service, operation) pair to its IAM
+// action string for the handful of services where the IAM action name
+// diverges from "service:OperationName" -- most AWS APIs don't need an
+// entry here since their operation name already matches the IAM action.
+var awsActionOverrides = map[string]string{
+	"s3.GetObject":     "s3:GetObject",
+	"s3.PutObject":     "s3:PutObject",
+	"s3.ListObjectsV2": "s3:ListBucket",
+}
+
+// awsIAMAction returns the IAM action string for one recorded AWS Call,
+// consulting awsActionOverrides before falling back to the
+// "service:Operation" convention the large majority of AWS APIs follow.
+func awsIAMAction(service, action string) string {
+	key := strings.ToLower(service) + "." + action
+	if override, ok := awsActionOverrides[key]; ok {
+		return override
+	}
+	return strings.ToLower(service) + ":" + action
+}
+
+// AWSPolicyDocument is an IAM policy document in the shape AWS expects to
+// attach to a role or user.
+type AWSPolicyDocument struct {
+	Version   string               `json:"Version"`
+	Statement []AWSPolicyStatement `json:"Statement"`
+}
+
+// AWSPolicyStatement is one least-privilege statement SynthesizeAWSPolicy
+// emits -- every action this run actually exercised, against the resources
+// it touched (or "*" when no specific resource could be resolved).
+type AWSPolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource []string `json:"Resource"`
+}
+
+// SynthesizeAWSPolicy collapses calls into a single-statement least-privilege
+// IAM policy document containing exactly the actions and resources this run
+// touched.
+func SynthesizeAWSPolicy(calls []Call) AWSPolicyDocument {
+	actions := make(map[string]bool)
+	resources := make(map[string]bool)
+	for _, c := range calls {
+		actions[awsIAMAction(c.Service, c.Action)] = true
+		if c.Resource != "" {
+			resources[c.Resource] = true
+		}
+	}
+
+	if len(resources) == 0 {
+		resources["*"] = true
+	}
+
+	return AWSPolicyDocument{
+		Version: "2012-10-17",
+		Statement: []AWSPolicyStatement{{
+			Effect:   "Allow",
+			Action:   sortedKeys(actions),
+			Resource: sortedKeys(resources),
+		}},
+	}
+}
+
+// GCPCustomRole is a GCP IAM custom role definition containing exactly the
+// permissions a run exercised.
+type GCPCustomRole struct {
+	Title               string   `json:"title"`
+	Description         string   `json:"description"`
+	Stage               string   `json:"stage"`
+	IncludedPermissions []string `json:"includedPermissions"`
+}
+
+// SynthesizeGCPRole collapses calls into a GCPCustomRole, deriving each
+// permission string as "service.resource.verb" from the recorded action
+// (which CollectCosts-style callers already format as "resource.verb").
+func SynthesizeGCPRole(calls []Call) GCPCustomRole {
+	permissions := make(map[string]bool)
+	for _, c := range calls {
+		permissions[c.Service+"."+c.Action] = true
+	}
+
+	return GCPCustomRole{
+		Title:               "synthesized-least-privilege",
+		Description:         "Synthesized from observed API calls during a MultiCloudTestSuite run",
+		Stage:               "GA",
+		IncludedPermissions: sortedKeys(permissions),
+	}
+}
+
+// AzureRoleDefinition is an Azure custom role definition containing exactly
+// the data-plane/control-plane actions a run exercised.
+type AzureRoleDefinition struct {
+	Name             string   `json:"Name"`
+	Description      string   `json:"Description"`
+	Actions          []string `json:"Actions"`
+	NotActions       []string `json:"NotActions"`
+	AssignableScopes []string `json:"AssignableScopes"`
+}
+
+// SynthesizeAzureRoleDefinition collapses calls into an AzureRoleDefinition,
+// deriving each Actions entry as "<provider>/<resourceType>/<verb>" from the
+// recorded (service, action) pair.
+func SynthesizeAzureRoleDefinition(calls []Call) AzureRoleDefinition {
+	actions := make(map[string]bool)
+	for _, c := range calls {
+		actions[c.Service+"/"+c.Action] = true
+	}
+
+	return AzureRoleDefinition{
+		Name:             "synthesized-least-privilege",
+		Description:      "Synthesized from observed ARM calls during a MultiCloudTestSuite run",
+		Actions:          sortedKeys(actions),
+		NotActions:       []string{},
+		AssignableScopes: []string{},
+	}
+}
+
+// sortedKeys returns set's keys in sorted order, for deterministic JSON
+// output across runs with the same calls.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DiffPermissions compares a declared permission/action list against one
+// synthesized from observed calls, returning the permissions the declared
+// policy grants but the run never exercised (unused, a drift candidate for
+// removal) and the ones the run needed but declared didn't grant (missing,
+// a bug -- the test would fail with real least-privilege credentials).
+func DiffPermissions(declared, synthesized []string) (unused, missing []string) {
+	declaredSet := make(map[string]bool, len(declared))
+	for _, p := range declared {
+		declaredSet[p] = true
+	}
+	synthesizedSet := make(map[string]bool, len(synthesized))
+	for _, p := range synthesized {
+		synthesizedSet[p] = true
+	}
+
+	for _, p := range declared {
+		if !synthesizedSet[p] {
+			unused = append(unused, p)
+		}
+	}
+	for _, p := range synthesized {
+		if !declaredSet[p] {
+			missing = append(missing, p)
+		}
+	}
+
+	sort.Strings(unused)
+	sort.Strings(missing)
+	return unused, missing
+}
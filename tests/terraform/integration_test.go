@@ -2,74 +2,140 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/gruntwork-io/terratest/modules/test-structure"
-	"github.com/gruntwork-io/terratest/modules/retry"
-	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/files"
 	"github.com/gruntwork-io/terratest/modules/http-helper"
+	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
-	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/rs/zerolog/log"
-	"github.com/pkg/errors"
-	"github.com/google/uuid"
 	"gopkg.in/yaml.v3"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/dr"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iamsynth"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/resourceid"
 )
 
 // MultiCloudTestSuite manages multi-cloud integration tests
 type MultiCloudTestSuite struct {
-	TestID         string
-	Config         TestConfig
-	Logger         zerolog.Logger
-	Context        context.Context
-	
+	TestID  string
+	Config  TestConfig
+	Logger  zerolog.Logger
+	Context context.Context
+
 	// Cloud provider test suites
 	AWSTestSuite   *AWSTestSuite
 	GCPTestSuite   *GCPTestSuite
 	AzureTestSuite *AzureTestSuite
 	K8sTestSuite   *K8sTestSuite
-	
+
+	// ProviderSuites holds every provider initializeCloudProviderSuites
+	// brought up via the ProviderPlugin registry, keyed by
+	// CloudProvider.Name -- including providers with no dedicated
+	// suite.XTestSuite field above (anything registered by an
+	// out-of-tree MultiCloudConfig.PluginDir plugin).
+	ProviderSuites map[string]ProviderTestSuite
+
 	// Test configuration
 	MultiCloudConfig MultiCloudConfig
-	
+
 	// Test results
-	TestResults    []MultiCloudTestResult
-	
+	TestResults []MultiCloudTestResult
+
+	// Recorder captures every AWS/GCP/Azure SDK call this suite's provider
+	// clients make, so least-privilege policies can be synthesized from what
+	// the run actually exercised (see ExportLeastPrivilegePolicies).
+	Recorder *iamsynth.Recorder
+
+	// drGameDayReport caches RunDisasterRecoveryGameDay's last result so
+	// testFailoverProcedures and testRTORPOValidation can share one game-day
+	// run instead of injecting every DR runbook's failure twice.
+	drGameDayReport *dr.Report
+
+	// traceIDsByProbe records the trace ID TracedHTTPGet minted for each
+	// probeID, so a later AssertTrace(t, probeID, ...) call knows which
+	// trace to fetch from the tracing backend. Guarded by Mutex.
+	traceIDsByProbe map[string]string
+
 	// Synchronization
-	Mutex          sync.RWMutex
-	WaitGroup      sync.WaitGroup
+	Mutex     sync.RWMutex
+	WaitGroup sync.WaitGroup
 }
 
 // MultiCloudConfig defines multi-cloud test configuration
 type MultiCloudConfig struct {
-	Providers           []CloudProvider           `yaml:"providers"`
-	Regions             []RegionConfig            `yaml:"regions"`
-	NetworkConfig       NetworkConfig             `yaml:"network"`
-	DatabaseConfig      DatabaseConfig            `yaml:"database"`
-	StorageConfig       StorageConfig             `yaml:"storage"`
-	ComputeConfig       ComputeConfig             `yaml:"compute"`
-	SecurityConfig      MultiCloudSecurityConfig  `yaml:"security"`
-	MonitoringConfig    MonitoringConfig          `yaml:"monitoring"`
-	BackupConfig        BackupConfig              `yaml:"backup"`
-	DisasterRecoveryConfig DisasterRecoveryConfig `yaml:"disaster_recovery"`
-	ComplianceConfig    ComplianceConfig          `yaml:"compliance"`
-	CostConfig          CostConfig                `yaml:"cost"`
-	IntegrationTests    []IntegrationTest         `yaml:"integration_tests"`
+	Providers              []CloudProvider          `yaml:"providers"`
+	Regions                []RegionConfig           `yaml:"regions"`
+	NetworkConfig          NetworkConfig            `yaml:"network"`
+	DatabaseConfig         DatabaseConfig           `yaml:"database"`
+	StorageConfig          StorageConfig            `yaml:"storage"`
+	ComputeConfig          ComputeConfig            `yaml:"compute"`
+	SecurityConfig         MultiCloudSecurityConfig `yaml:"security"`
+	MonitoringConfig       MonitoringConfig         `yaml:"monitoring"`
+	BackupConfig           BackupConfig             `yaml:"backup"`
+	DisasterRecoveryConfig DisasterRecoveryConfig   `yaml:"disaster_recovery"`
+	ComplianceConfig       ComplianceConfig         `yaml:"compliance"`
+	CostConfig             CostConfig               `yaml:"cost"`
+	IntegrationTests       []IntegrationTest        `yaml:"integration_tests"`
+	TracingExpectations    TracingExpectations      `yaml:"tracing_expectations"`
+	IaC                    IaCConfig                `yaml:"iac"`
+
+	// PluginDir, when set, is scanned for out-of-tree provider plugins
+	// (Go plugin package *.so files, one ProviderPlugin per file) that
+	// loadExternalProviderPlugins registers before
+	// initializeCloudProviderSuites runs, mirroring how Terraform
+	// resolves third-party providers without the core repo knowing about
+	// them in advance.
+	PluginDir string `yaml:"plugin_dir"`
+}
+
+// TracingExpectations declares the expected service graph for each named
+// probe scenario MultiCloudTestSuite.AssertTrace checks a distributed trace
+// against -- which services (and in which region) a probe is expected to
+// have touched, so an unexpected or cross-region span fails the assertion.
+type TracingExpectations struct {
+	Scenarios []TracingScenario `yaml:"scenarios"`
+}
+
+// TracingScenario is one probe's expected service graph.
+type TracingScenario struct {
+	ProbeID          string                   `yaml:"probe_id"`
+	ExpectedServices []TracingExpectedService `yaml:"expected_services"`
+}
+
+// TracingExpectedService is one service/region pair a TracingScenario's
+// probe is expected to have produced spans for.
+type TracingExpectedService struct {
+	Service string `yaml:"service"`
+	Region  string `yaml:"region"`
 }
 
 // CloudProvider defines a cloud provider configuration
 type CloudProvider struct {
-	Name        string            `yaml:"name"` // aws, gcp, azure
+	// Name identifies which registered ProviderPlugin initializes this
+	// provider (see RegisterProvider); aws/gcp/azure/kubernetes are
+	// registered by this repo's built-in plugins, and any other name is
+	// valid as long as a matching plugin is registered, whether compiled
+	// in or loaded from MultiCloudConfig.PluginDir.
+	Name        string            `yaml:"name" validate:"required"`
 	Enabled     bool              `yaml:"enabled"`
 	Primary     bool              `yaml:"primary"`
 	Regions     []string          `yaml:"regions"`
-	Credentials map[string]string `yaml:"credentials"`
+	Credentials map[string]string `yaml:"credentials" validate:"required_if=Enabled true,omitempty,providercreds"`
 	Services    []string          `yaml:"services"`
 	Quotas      ResourceQuotas    `yaml:"quotas"`
 	Tags        map[string]string `yaml:"tags"`
@@ -77,81 +143,90 @@ type CloudProvider struct {
 
 // RegionConfig defines region-specific configuration
 type RegionConfig struct {
-	Provider    string   `yaml:"provider"`
-	Region      string   `yaml:"region"`
-	Primary     bool     `yaml:"primary"`
-	Zones       []string `yaml:"zones"`
-	Compliance  []string `yaml:"compliance"`
-	Services    []string `yaml:"services"`
-	Redundancy  bool     `yaml:"redundancy"`
+	Provider   string   `yaml:"provider"`
+	Region     string   `yaml:"region"`
+	Primary    bool     `yaml:"primary"`
+	Zones      []string `yaml:"zones"`
+	Compliance []string `yaml:"compliance"`
+	Services   []string `yaml:"services"`
+	Redundancy bool     `yaml:"redundancy"`
 }
 
 // NetworkConfig defines multi-cloud network configuration
 type NetworkConfig struct {
-	VPCPeering       bool                    `yaml:"vpc_peering"`
-	VPNConnections   []VPNConnection         `yaml:"vpn_connections"`
-	DirectConnect    []DirectConnection      `yaml:"direct_connect"`
-	CDN              CDNConfig               `yaml:"cdn"`
-	LoadBalancers    []LoadBalancerConfig    `yaml:"load_balancers"`
-	DNS              DNSConfig               `yaml:"dns"`
-	Firewall         FirewallConfig          `yaml:"firewall"`
-	NetworkSecurity  NetworkSecurityConfig   `yaml:"network_security"`
+	VPCPeering      bool                  `yaml:"vpc_peering"`
+	VPNConnections  []VPNConnection       `yaml:"vpn_connections"`
+	DirectConnect   []DirectConnection    `yaml:"direct_connect"`
+	CDN             CDNConfig             `yaml:"cdn"`
+	LoadBalancers   []LoadBalancerConfig  `yaml:"load_balancers"`
+	DNS             DNSConfig             `yaml:"dns"`
+	Firewall        FirewallConfig        `yaml:"firewall"`
+	NetworkSecurity NetworkSecurityConfig `yaml:"network_security"`
 }
 
 // VPNConnection defines VPN connection configuration
 type VPNConnection struct {
-	Name         string `yaml:"name"`
-	Source       string `yaml:"source"`       // provider:region
-	Destination  string `yaml:"destination"`  // provider:region
-	Type         string `yaml:"type"`         // site-to-site, point-to-site
-	Encryption   string `yaml:"encryption"`
-	Redundant    bool   `yaml:"redundant"`
-	Bandwidth    string `yaml:"bandwidth"`
+	Name        string `yaml:"name"`
+	Source      string `yaml:"source"`      // provider:region
+	Destination string `yaml:"destination"` // provider:region
+	Type        string `yaml:"type"`        // site-to-site, point-to-site
+	Encryption  string `yaml:"encryption"`
+	Redundant   bool   `yaml:"redundant"`
+	Bandwidth   string `yaml:"bandwidth"`
+
+	// URN is this connection's canonical cross-provider identifier,
+	// resolved by ResolveResourceURNs once the connection exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // DirectConnection defines direct connection configuration
 type DirectConnection struct {
-	Name         string `yaml:"name"`
-	Provider     string `yaml:"provider"`
-	Location     string `yaml:"location"`
-	Bandwidth    string `yaml:"bandwidth"`
-	Redundant    bool   `yaml:"redundant"`
-	VLAN         int    `yaml:"vlan"`
+	Name      string `yaml:"name"`
+	Provider  string `yaml:"provider"`
+	Location  string `yaml:"location"`
+	Bandwidth string `yaml:"bandwidth"`
+	Redundant bool   `yaml:"redundant"`
+	VLAN      int    `yaml:"vlan"`
 }
 
 // CDNConfig defines CDN configuration
 type CDNConfig struct {
-	Enabled      bool     `yaml:"enabled"`
-	Provider     string   `yaml:"provider"` // cloudflare, aws-cloudfront, azure-cdn, gcp-cdn
-	Origins      []string `yaml:"origins"`
-	Caching      bool     `yaml:"caching"`
-	Compression  bool     `yaml:"compression"`
-	SSL          bool     `yaml:"ssl"`
-	WAF          bool     `yaml:"waf"`
+	Enabled     bool     `yaml:"enabled"`
+	Provider    string   `yaml:"provider"` // cloudflare, aws-cloudfront, azure-cdn, gcp-cdn
+	Origins     []string `yaml:"origins"`
+	Caching     bool     `yaml:"caching"`
+	Compression bool     `yaml:"compression"`
+	SSL         bool     `yaml:"ssl"`
+	WAF         bool     `yaml:"waf"`
 }
 
 // LoadBalancerConfig defines load balancer configuration
 type LoadBalancerConfig struct {
-	Name         string   `yaml:"name"`
-	Type         string   `yaml:"type"`         // global, regional
-	Provider     string   `yaml:"provider"`
-	Regions      []string `yaml:"regions"`
-	HealthCheck  bool     `yaml:"health_check"`
-	SSL          bool     `yaml:"ssl"`
-	WAF          bool     `yaml:"waf"`
-	AutoScaling  bool     `yaml:"auto_scaling"`
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"` // global, regional
+	Provider    string   `yaml:"provider"`
+	Regions     []string `yaml:"regions"`
+	HealthCheck bool     `yaml:"health_check"`
+	SSL         bool     `yaml:"ssl"`
+	WAF         bool     `yaml:"waf"`
+	AutoScaling bool     `yaml:"auto_scaling"`
+
+	// Endpoint is the load balancer's reachable hostname or URL, used by
+	// probes (e.g. the disaster-recovery game day) to assert the
+	// application stays reachable through this load balancer.
+	Endpoint string `yaml:"endpoint"`
 }
 
 // DNSConfig defines DNS configuration
 type DNSConfig struct {
-	Provider        string            `yaml:"provider"`
-	Zones           []string          `yaml:"zones"`
-	GeolocationRouting bool           `yaml:"geolocation_routing"`
-	HealthChecks    bool              `yaml:"health_checks"`
-	Failover        bool              `yaml:"failover"`
-	LoadBalancing   bool              `yaml:"load_balancing"`
-	DNSSEC          bool              `yaml:"dnssec"`
-	Records         []DNSRecord       `yaml:"records"`
+	Provider           string      `yaml:"provider"`
+	Zones              []string    `yaml:"zones"`
+	GeolocationRouting bool        `yaml:"geolocation_routing"`
+	HealthChecks       bool        `yaml:"health_checks"`
+	Failover           bool        `yaml:"failover"`
+	LoadBalancing      bool        `yaml:"load_balancing"`
+	DNSSEC             bool        `yaml:"dnssec"`
+	Records            []DNSRecord `yaml:"records"`
 }
 
 // DNSRecord defines DNS record configuration
@@ -165,13 +240,13 @@ type DNSRecord struct {
 
 // FirewallConfig defines firewall configuration
 type FirewallConfig struct {
-	Enabled       bool              `yaml:"enabled"`
-	Type          string            `yaml:"type"` // cloud-native, third-party
-	Provider      string            `yaml:"provider"`
-	Rules         []FirewallRule    `yaml:"rules"`
-	Logging       bool              `yaml:"logging"`
-	Monitoring    bool              `yaml:"monitoring"`
-	ThreatIntel   bool              `yaml:"threat_intel"`
+	Enabled     bool           `yaml:"enabled"`
+	Type        string         `yaml:"type"` // cloud-native, third-party
+	Provider    string         `yaml:"provider"`
+	Rules       []FirewallRule `yaml:"rules"`
+	Logging     bool           `yaml:"logging"`
+	Monitoring  bool           `yaml:"monitoring"`
+	ThreatIntel bool           `yaml:"threat_intel"`
 }
 
 // FirewallRule defines firewall rule configuration
@@ -187,60 +262,64 @@ type FirewallRule struct {
 
 // NetworkSecurityConfig defines network security configuration
 type NetworkSecurityConfig struct {
-	DDoSProtection   bool   `yaml:"ddos_protection"`
-	WAF              bool   `yaml:"waf"`
-	VPN              bool   `yaml:"vpn"`
-	PrivateEndpoints bool   `yaml:"private_endpoints"`
-	NetworkACLs      bool   `yaml:"network_acls"`
-	FlowLogs         bool   `yaml:"flow_logs"`
+	DDoSProtection     bool `yaml:"ddos_protection"`
+	WAF                bool `yaml:"waf"`
+	VPN                bool `yaml:"vpn"`
+	PrivateEndpoints   bool `yaml:"private_endpoints"`
+	NetworkACLs        bool `yaml:"network_acls"`
+	FlowLogs           bool `yaml:"flow_logs"`
 	IntrusionDetection bool `yaml:"intrusion_detection"`
 }
 
 // DatabaseConfig defines multi-cloud database configuration
 type DatabaseConfig struct {
-	PrimaryProvider   string                    `yaml:"primary_provider"`
-	ReplicationMode   string                    `yaml:"replication_mode"` // sync, async, none
-	Databases         []DatabaseInstance        `yaml:"databases"`
-	Backup            DatabaseBackupConfig      `yaml:"backup"`
-	Monitoring        DatabaseMonitoringConfig  `yaml:"monitoring"`
-	Security          DatabaseSecurityConfig    `yaml:"security"`
-	Performance       DatabasePerformanceConfig `yaml:"performance"`
+	PrimaryProvider string                    `yaml:"primary_provider"`
+	ReplicationMode string                    `yaml:"replication_mode"` // sync, async, none
+	Databases       []DatabaseInstance        `yaml:"databases"`
+	Backup          DatabaseBackupConfig      `yaml:"backup"`
+	Monitoring      DatabaseMonitoringConfig  `yaml:"monitoring"`
+	Security        DatabaseSecurityConfig    `yaml:"security"`
+	Performance     DatabasePerformanceConfig `yaml:"performance"`
 }
 
 // DatabaseInstance defines database instance configuration
 type DatabaseInstance struct {
-	Name           string            `yaml:"name"`
-	Provider       string            `yaml:"provider"`
-	Region         string            `yaml:"region"`
-	Engine         string            `yaml:"engine"`
-	Version        string            `yaml:"version"`
-	InstanceClass  string            `yaml:"instance_class"`
-	Storage        int               `yaml:"storage"`
-	Encrypted      bool              `yaml:"encrypted"`
-	BackupRetention int              `yaml:"backup_retention"`
-	MultiAZ        bool              `yaml:"multi_az"`
-	ReadReplicas   []ReadReplica     `yaml:"read_replicas"`
-	Parameters     map[string]string `yaml:"parameters"`
+	Name            string            `yaml:"name"`
+	Provider        string            `yaml:"provider"`
+	Region          string            `yaml:"region"`
+	Engine          string            `yaml:"engine"`
+	Version         string            `yaml:"version"`
+	InstanceClass   string            `yaml:"instance_class"`
+	Storage         int               `yaml:"storage"`
+	Encrypted       bool              `yaml:"encrypted"`
+	BackupRetention int               `yaml:"backup_retention"`
+	MultiAZ         bool              `yaml:"multi_az"`
+	ReadReplicas    []ReadReplica     `yaml:"read_replicas"`
+	Parameters      map[string]string `yaml:"parameters"`
+
+	// URN is this instance's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the instance exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // ReadReplica defines read replica configuration
 type ReadReplica struct {
-	Name           string `yaml:"name"`
-	Provider       string `yaml:"provider"`
-	Region         string `yaml:"region"`
-	InstanceClass  string `yaml:"instance_class"`
-	Encrypted      bool   `yaml:"encrypted"`
+	Name          string `yaml:"name"`
+	Provider      string `yaml:"provider"`
+	Region        string `yaml:"region"`
+	InstanceClass string `yaml:"instance_class"`
+	Encrypted     bool   `yaml:"encrypted"`
 }
 
 // DatabaseBackupConfig defines database backup configuration
 type DatabaseBackupConfig struct {
-	Enabled           bool   `yaml:"enabled"`
-	RetentionDays     int    `yaml:"retention_days"`
-	CrossRegion       bool   `yaml:"cross_region"`
-	CrossProvider     bool   `yaml:"cross_provider"`
-	PointInTimeRecovery bool `yaml:"point_in_time_recovery"`
-	Automated         bool   `yaml:"automated"`
-	Schedule          string `yaml:"schedule"`
+	Enabled             bool   `yaml:"enabled"`
+	RetentionDays       int    `yaml:"retention_days"`
+	CrossRegion         bool   `yaml:"cross_region"`
+	CrossProvider       bool   `yaml:"cross_provider"`
+	PointInTimeRecovery bool   `yaml:"point_in_time_recovery"`
+	Automated           bool   `yaml:"automated"`
+	Schedule            string `yaml:"schedule"`
 }
 
 // DatabaseMonitoringConfig defines database monitoring configuration
@@ -264,102 +343,106 @@ type DatabaseSecurityConfig struct {
 
 // DatabasePerformanceConfig defines database performance configuration
 type DatabasePerformanceConfig struct {
-	ConnectionPooling   bool              `yaml:"connection_pooling"`
-	ReadReplicas        bool              `yaml:"read_replicas"`
-	Caching             bool              `yaml:"caching"`
-	QueryOptimization   bool              `yaml:"query_optimization"`
-	IndexOptimization   bool              `yaml:"index_optimization"`
-	PerformanceMetrics  []string          `yaml:"performance_metrics"`
+	ConnectionPooling     bool               `yaml:"connection_pooling"`
+	ReadReplicas          bool               `yaml:"read_replicas"`
+	Caching               bool               `yaml:"caching"`
+	QueryOptimization     bool               `yaml:"query_optimization"`
+	IndexOptimization     bool               `yaml:"index_optimization"`
+	PerformanceMetrics    []string           `yaml:"performance_metrics"`
 	PerformanceThresholds map[string]float64 `yaml:"performance_thresholds"`
 }
 
 // StorageConfig defines multi-cloud storage configuration
 type StorageConfig struct {
-	PrimaryProvider    string                 `yaml:"primary_provider"`
-	ReplicationStrategy string                `yaml:"replication_strategy"` // multi-region, cross-provider
-	Buckets            []StorageBucket        `yaml:"buckets"`
-	Backup             StorageBackupConfig    `yaml:"backup"`
-	Security           StorageSecurityConfig  `yaml:"security"`
-	Performance        StoragePerformanceConfig `yaml:"performance"`
-	Lifecycle          StorageLifecycleConfig `yaml:"lifecycle"`
+	PrimaryProvider     string                   `yaml:"primary_provider"`
+	ReplicationStrategy string                   `yaml:"replication_strategy"` // multi-region, cross-provider
+	Buckets             []StorageBucket          `yaml:"buckets"`
+	Backup              StorageBackupConfig      `yaml:"backup"`
+	Security            StorageSecurityConfig    `yaml:"security"`
+	Performance         StoragePerformanceConfig `yaml:"performance"`
+	Lifecycle           StorageLifecycleConfig   `yaml:"lifecycle"`
 }
 
 // StorageBucket defines storage bucket configuration
 type StorageBucket struct {
-	Name           string            `yaml:"name"`
-	Provider       string            `yaml:"provider"`
-	Region         string            `yaml:"region"`
-	StorageClass   string            `yaml:"storage_class"`
-	Versioning     bool              `yaml:"versioning"`
-	Encryption     bool              `yaml:"encryption"`
-	PublicAccess   bool              `yaml:"public_access"`
-	CORS           bool              `yaml:"cors"`
-	Lifecycle      bool              `yaml:"lifecycle"`
-	Logging        bool              `yaml:"logging"`
-	Replication    []StorageReplication `yaml:"replication"`
-	Metadata       map[string]string `yaml:"metadata"`
+	Name         string               `yaml:"name"`
+	Provider     string               `yaml:"provider"`
+	Region       string               `yaml:"region"`
+	StorageClass string               `yaml:"storage_class"`
+	Versioning   bool                 `yaml:"versioning"`
+	Encryption   bool                 `yaml:"encryption"`
+	PublicAccess bool                 `yaml:"public_access"`
+	CORS         bool                 `yaml:"cors"`
+	Lifecycle    bool                 `yaml:"lifecycle"`
+	Logging      bool                 `yaml:"logging"`
+	Replication  []StorageReplication `yaml:"replication"`
+	Metadata     map[string]string    `yaml:"metadata"`
+
+	// URN is this bucket's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the bucket exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // StorageReplication defines storage replication configuration
 type StorageReplication struct {
-	Provider       string `yaml:"provider"`
-	Region         string `yaml:"region"`
-	StorageClass   string `yaml:"storage_class"`
-	Encrypted      bool   `yaml:"encrypted"`
+	Provider     string `yaml:"provider"`
+	Region       string `yaml:"region"`
+	StorageClass string `yaml:"storage_class"`
+	Encrypted    bool   `yaml:"encrypted"`
 }
 
 // StorageBackupConfig defines storage backup configuration
 type StorageBackupConfig struct {
-	Enabled        bool   `yaml:"enabled"`
-	Schedule       string `yaml:"schedule"`
-	RetentionDays  int    `yaml:"retention_days"`
-	CrossRegion    bool   `yaml:"cross_region"`
-	CrossProvider  bool   `yaml:"cross_provider"`
-	Incremental    bool   `yaml:"incremental"`
-	Compression    bool   `yaml:"compression"`
+	Enabled       bool   `yaml:"enabled"`
+	Schedule      string `yaml:"schedule"`
+	RetentionDays int    `yaml:"retention_days"`
+	CrossRegion   bool   `yaml:"cross_region"`
+	CrossProvider bool   `yaml:"cross_provider"`
+	Incremental   bool   `yaml:"incremental"`
+	Compression   bool   `yaml:"compression"`
 }
 
 // StorageSecurityConfig defines storage security configuration
 type StorageSecurityConfig struct {
-	Encryption         bool     `yaml:"encryption"`
-	AccessLogging      bool     `yaml:"access_logging"`
-	IAMPolicies        bool     `yaml:"iam_policies"`
-	BucketPolicies     bool     `yaml:"bucket_policies"`
-	MFA                bool     `yaml:"mfa"`
-	VPCEndpoints       bool     `yaml:"vpc_endpoints"`
+	Encryption          bool     `yaml:"encryption"`
+	AccessLogging       bool     `yaml:"access_logging"`
+	IAMPolicies         bool     `yaml:"iam_policies"`
+	BucketPolicies      bool     `yaml:"bucket_policies"`
+	MFA                 bool     `yaml:"mfa"`
+	VPCEndpoints        bool     `yaml:"vpc_endpoints"`
 	ComplianceStandards []string `yaml:"compliance_standards"`
 }
 
 // StoragePerformanceConfig defines storage performance configuration
 type StoragePerformanceConfig struct {
-	CDN               bool              `yaml:"cdn"`
-	Caching           bool              `yaml:"caching"`
-	Compression       bool              `yaml:"compression"`
-	MultipartUpload   bool              `yaml:"multipart_upload"`
-	TransferAcceleration bool           `yaml:"transfer_acceleration"`
-	PerformanceMetrics []string         `yaml:"performance_metrics"`
+	CDN                   bool               `yaml:"cdn"`
+	Caching               bool               `yaml:"caching"`
+	Compression           bool               `yaml:"compression"`
+	MultipartUpload       bool               `yaml:"multipart_upload"`
+	TransferAcceleration  bool               `yaml:"transfer_acceleration"`
+	PerformanceMetrics    []string           `yaml:"performance_metrics"`
 	PerformanceThresholds map[string]float64 `yaml:"performance_thresholds"`
 }
 
 // StorageLifecycleConfig defines storage lifecycle configuration
 type StorageLifecycleConfig struct {
-	Enabled              bool `yaml:"enabled"`
-	TransitionToIA       int  `yaml:"transition_to_ia"`       // days
-	TransitionToGlacier  int  `yaml:"transition_to_glacier"`  // days
-	TransitionToDeepArchive int `yaml:"transition_to_deep_archive"` // days
-	DeleteIncompleteUploads int `yaml:"delete_incomplete_uploads"` // days
-	DeleteOldVersions    int  `yaml:"delete_old_versions"`    // days
+	Enabled                 bool `yaml:"enabled"`
+	TransitionToIA          int  `yaml:"transition_to_ia"`           // days
+	TransitionToGlacier     int  `yaml:"transition_to_glacier"`      // days
+	TransitionToDeepArchive int  `yaml:"transition_to_deep_archive"` // days
+	DeleteIncompleteUploads int  `yaml:"delete_incomplete_uploads"`  // days
+	DeleteOldVersions       int  `yaml:"delete_old_versions"`        // days
 }
 
 // ComputeConfig defines multi-cloud compute configuration
 type ComputeConfig struct {
-	PrimaryProvider    string              `yaml:"primary_provider"`
-	LoadBalancing      bool                `yaml:"load_balancing"`
-	AutoScaling        bool                `yaml:"auto_scaling"`
-	Instances          []ComputeInstance   `yaml:"instances"`
-	Containers         []ContainerConfig   `yaml:"containers"`
-	Serverless         []ServerlessConfig  `yaml:"serverless"`
-	Kubernetes         []KubernetesConfig  `yaml:"kubernetes"`
+	PrimaryProvider string             `yaml:"primary_provider"`
+	LoadBalancing   bool               `yaml:"load_balancing"`
+	AutoScaling     bool               `yaml:"auto_scaling"`
+	Instances       []ComputeInstance  `yaml:"instances"`
+	Containers      []ContainerConfig  `yaml:"containers"`
+	Serverless      []ServerlessConfig `yaml:"serverless"`
+	Kubernetes      []KubernetesConfig `yaml:"kubernetes"`
 }
 
 // ComputeInstance defines compute instance configuration
@@ -375,67 +458,79 @@ type ComputeInstance struct {
 	Monitoring     bool              `yaml:"monitoring"`
 	Backup         bool              `yaml:"backup"`
 	Metadata       map[string]string `yaml:"metadata"`
+
+	// URN is this instance's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the instance exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // ContainerConfig defines container configuration
 type ContainerConfig struct {
-	Name           string            `yaml:"name"`
-	Provider       string            `yaml:"provider"`
-	Region         string            `yaml:"region"`
-	Image          string            `yaml:"image"`
-	CPU            float64           `yaml:"cpu"`
-	Memory         int               `yaml:"memory"`
-	Port           int               `yaml:"port"`
-	Environment    map[string]string `yaml:"environment"`
-	Secrets        map[string]string `yaml:"secrets"`
-	Volumes        []VolumeConfig    `yaml:"volumes"`
-	Networking     NetworkingConfig  `yaml:"networking"`
-	Scaling        ScalingConfig     `yaml:"scaling"`
+	Name        string            `yaml:"name"`
+	Provider    string            `yaml:"provider"`
+	Region      string            `yaml:"region"`
+	Image       string            `yaml:"image"`
+	CPU         float64           `yaml:"cpu"`
+	Memory      int               `yaml:"memory"`
+	Port        int               `yaml:"port"`
+	Environment map[string]string `yaml:"environment"`
+	Secrets     map[string]string `yaml:"secrets"`
+	Volumes     []VolumeConfig    `yaml:"volumes"`
+	Networking  NetworkingConfig  `yaml:"networking"`
+	Scaling     ScalingConfig     `yaml:"scaling"`
+
+	// URN is this container's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the container exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // VolumeConfig defines volume configuration
 type VolumeConfig struct {
-	Name       string `yaml:"name"`
-	MountPath  string `yaml:"mount_path"`
-	Size       int    `yaml:"size"`
-	Type       string `yaml:"type"`
-	Encrypted  bool   `yaml:"encrypted"`
-	Backup     bool   `yaml:"backup"`
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mount_path"`
+	Size      int    `yaml:"size"`
+	Type      string `yaml:"type"`
+	Encrypted bool   `yaml:"encrypted"`
+	Backup    bool   `yaml:"backup"`
 }
 
 // NetworkingConfig defines networking configuration
 type NetworkingConfig struct {
-	VPC        string   `yaml:"vpc"`
-	Subnets    []string `yaml:"subnets"`
-	PublicIP   bool     `yaml:"public_ip"`
-	LoadBalancer bool   `yaml:"load_balancer"`
-	DNS        string   `yaml:"dns"`
+	VPC          string   `yaml:"vpc"`
+	Subnets      []string `yaml:"subnets"`
+	PublicIP     bool     `yaml:"public_ip"`
+	LoadBalancer bool     `yaml:"load_balancer"`
+	DNS          string   `yaml:"dns"`
 }
 
 // ScalingConfig defines scaling configuration
 type ScalingConfig struct {
-	Enabled     bool `yaml:"enabled"`
-	MinReplicas int  `yaml:"min_replicas"`
-	MaxReplicas int  `yaml:"max_replicas"`
-	TargetCPU   int  `yaml:"target_cpu"`
-	TargetMemory int `yaml:"target_memory"`
+	Enabled      bool `yaml:"enabled"`
+	MinReplicas  int  `yaml:"min_replicas"`
+	MaxReplicas  int  `yaml:"max_replicas"`
+	TargetCPU    int  `yaml:"target_cpu"`
+	TargetMemory int  `yaml:"target_memory"`
 }
 
 // ServerlessConfig defines serverless configuration
 type ServerlessConfig struct {
-	Name           string            `yaml:"name"`
-	Provider       string            `yaml:"provider"`
-	Region         string            `yaml:"region"`
-	Runtime        string            `yaml:"runtime"`
-	Handler        string            `yaml:"handler"`
-	Code           string            `yaml:"code"`
-	Timeout        int               `yaml:"timeout"`
-	Memory         int               `yaml:"memory"`
-	Environment    map[string]string `yaml:"environment"`
-	Triggers       []TriggerConfig   `yaml:"triggers"`
-	Layers         []string          `yaml:"layers"`
-	VPC            bool              `yaml:"vpc"`
-	Monitoring     bool              `yaml:"monitoring"`
+	Name        string            `yaml:"name"`
+	Provider    string            `yaml:"provider"`
+	Region      string            `yaml:"region"`
+	Runtime     string            `yaml:"runtime"`
+	Handler     string            `yaml:"handler"`
+	Code        string            `yaml:"code"`
+	Timeout     int               `yaml:"timeout"`
+	Memory      int               `yaml:"memory"`
+	Environment map[string]string `yaml:"environment"`
+	Triggers    []TriggerConfig   `yaml:"triggers"`
+	Layers      []string          `yaml:"layers"`
+	VPC         bool              `yaml:"vpc"`
+	Monitoring  bool              `yaml:"monitoring"`
+
+	// URN is this function's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the function exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // TriggerConfig defines trigger configuration
@@ -448,59 +543,63 @@ type TriggerConfig struct {
 
 // KubernetesConfig defines Kubernetes configuration
 type KubernetesConfig struct {
-	Name           string            `yaml:"name"`
-	Provider       string            `yaml:"provider"`
-	Region         string            `yaml:"region"`
-	Version        string            `yaml:"version"`
-	NodePools      []NodePoolConfig  `yaml:"node_pools"`
-	Networking     K8sNetworkingConfig `yaml:"networking"`
-	Security       K8sSecurityConfig `yaml:"security"`
-	Monitoring     K8sMonitoringConfig `yaml:"monitoring"`
-	Backup         K8sBackupConfig   `yaml:"backup"`
+	Name       string              `yaml:"name"`
+	Provider   string              `yaml:"provider"`
+	Region     string              `yaml:"region"`
+	Version    string              `yaml:"version"`
+	NodePools  []NodePoolConfig    `yaml:"node_pools"`
+	Networking K8sNetworkingConfig `yaml:"networking"`
+	Security   K8sSecurityConfig   `yaml:"security"`
+	Monitoring K8sMonitoringConfig `yaml:"monitoring"`
+	Backup     K8sBackupConfig     `yaml:"backup"`
+
+	// URN is this cluster's canonical cross-provider identifier, resolved
+	// by ResolveResourceURNs once the cluster exists.
+	URN resourceid.URN `yaml:"-"`
 }
 
 // NodePoolConfig defines node pool configuration
 type NodePoolConfig struct {
-	Name         string   `yaml:"name"`
-	InstanceType string   `yaml:"instance_type"`
-	MinNodes     int      `yaml:"min_nodes"`
-	MaxNodes     int      `yaml:"max_nodes"`
-	Zones        []string `yaml:"zones"`
-	Taints       []string `yaml:"taints"`
+	Name         string            `yaml:"name"`
+	InstanceType string            `yaml:"instance_type"`
+	MinNodes     int               `yaml:"min_nodes"`
+	MaxNodes     int               `yaml:"max_nodes"`
+	Zones        []string          `yaml:"zones"`
+	Taints       []string          `yaml:"taints"`
 	Labels       map[string]string `yaml:"labels"`
 }
 
 // K8sNetworkingConfig defines Kubernetes networking configuration
 type K8sNetworkingConfig struct {
-	NetworkPlugin    string `yaml:"network_plugin"`
-	PodCIDR          string `yaml:"pod_cidr"`
-	ServiceCIDR      string `yaml:"service_cidr"`
-	LoadBalancer     bool   `yaml:"load_balancer"`
-	Ingress          bool   `yaml:"ingress"`
-	NetworkPolicies  bool   `yaml:"network_policies"`
-	ServiceMesh      bool   `yaml:"service_mesh"`
+	NetworkPlugin   string `yaml:"network_plugin"`
+	PodCIDR         string `yaml:"pod_cidr"`
+	ServiceCIDR     string `yaml:"service_cidr"`
+	LoadBalancer    bool   `yaml:"load_balancer"`
+	Ingress         bool   `yaml:"ingress"`
+	NetworkPolicies bool   `yaml:"network_policies"`
+	ServiceMesh     bool   `yaml:"service_mesh"`
 }
 
 // K8sSecurityConfig defines Kubernetes security configuration
 type K8sSecurityConfig struct {
-	RBAC                bool   `yaml:"rbac"`
-	PodSecurityPolicies bool   `yaml:"pod_security_policies"`
-	NetworkPolicies     bool   `yaml:"network_policies"`
-	Secrets             bool   `yaml:"secrets"`
-	ImageScanning       bool   `yaml:"image_scanning"`
-	RuntimeSecurity     bool   `yaml:"runtime_security"`
-	ComplianceScanning  bool   `yaml:"compliance_scanning"`
+	RBAC                bool `yaml:"rbac"`
+	PodSecurityPolicies bool `yaml:"pod_security_policies"`
+	NetworkPolicies     bool `yaml:"network_policies"`
+	Secrets             bool `yaml:"secrets"`
+	ImageScanning       bool `yaml:"image_scanning"`
+	RuntimeSecurity     bool `yaml:"runtime_security"`
+	ComplianceScanning  bool `yaml:"compliance_scanning"`
 }
 
 // K8sMonitoringConfig defines Kubernetes monitoring configuration
 type K8sMonitoringConfig struct {
-	Prometheus bool `yaml:"prometheus"`
-	Grafana    bool `yaml:"grafana"`
+	Prometheus   bool `yaml:"prometheus"`
+	Grafana      bool `yaml:"grafana"`
 	Alertmanager bool `yaml:"alertmanager"`
-	Jaeger     bool `yaml:"jaeger"`
-	Logging    bool `yaml:"logging"`
-	Metrics    bool `yaml:"metrics"`
-	Tracing    bool `yaml:"tracing"`
+	Jaeger       bool `yaml:"jaeger"`
+	Logging      bool `yaml:"logging"`
+	Metrics      bool `yaml:"metrics"`
+	Tracing      bool `yaml:"tracing"`
 }
 
 // K8sBackupConfig defines Kubernetes backup configuration
@@ -515,24 +614,25 @@ type K8sBackupConfig struct {
 
 // MultiCloudSecurityConfig defines multi-cloud security configuration
 type MultiCloudSecurityConfig struct {
-	IAM              IAMConfig              `yaml:"iam"`
-	KeyManagement    KeyManagementConfig    `yaml:"key_management"`
-	SecretManagement SecretManagementConfig `yaml:"secret_management"`
-	Compliance       ComplianceConfig       `yaml:"compliance"`
-	ThreatDetection  ThreatDetectionConfig  `yaml:"threat_detection"`
-	VulnerabilityScanning VulnerabilityConfig `yaml:"vulnerability_scanning"`
-	SecurityMonitoring SecurityMonitoringConfig `yaml:"security_monitoring"`
+	IAM                   IAMConfig                `yaml:"iam"`
+	KeyManagement         KeyManagementConfig      `yaml:"key_management"`
+	SecretManagement      SecretManagementConfig   `yaml:"secret_management"`
+	Compliance            ComplianceConfig         `yaml:"compliance"`
+	ThreatDetection       ThreatDetectionConfig    `yaml:"threat_detection"`
+	VulnerabilityScanning VulnerabilityConfig      `yaml:"vulnerability_scanning"`
+	SecurityMonitoring    SecurityMonitoringConfig `yaml:"security_monitoring"`
+	Attestation           AttestationConfig        `yaml:"attestation"`
 }
 
 // IAMConfig defines IAM configuration
 type IAMConfig struct {
-	Centralized      bool              `yaml:"centralized"`
-	Federation       bool              `yaml:"federation"`
-	MFA              bool              `yaml:"mfa"`
-	PasswordPolicy   PasswordPolicy    `yaml:"password_policy"`
-	AccessReview     bool              `yaml:"access_review"`
-	RoleRotation     bool              `yaml:"role_rotation"`
-	PrivilegedAccess bool              `yaml:"privileged_access"`
+	Centralized      bool           `yaml:"centralized"`
+	Federation       bool           `yaml:"federation"`
+	MFA              bool           `yaml:"mfa"`
+	PasswordPolicy   PasswordPolicy `yaml:"password_policy"`
+	AccessReview     bool           `yaml:"access_review"`
+	RoleRotation     bool           `yaml:"role_rotation"`
+	PrivilegedAccess bool           `yaml:"privileged_access"`
 }
 
 // PasswordPolicy defines password policy configuration
@@ -548,42 +648,42 @@ type PasswordPolicy struct {
 
 // KeyManagementConfig defines key management configuration
 type KeyManagementConfig struct {
-	Provider         string `yaml:"provider"` // aws-kms, azure-keyvault, gcp-kms, hashicorp-vault
-	CrossProvider    bool   `yaml:"cross_provider"`
-	KeyRotation      bool   `yaml:"key_rotation"`
-	Hardware         bool   `yaml:"hardware"` // HSM
-	Backup           bool   `yaml:"backup"`
-	AuditLogging     bool   `yaml:"audit_logging"`
+	Provider      string `yaml:"provider"` // aws-kms, azure-keyvault, gcp-kms, hashicorp-vault
+	CrossProvider bool   `yaml:"cross_provider"`
+	KeyRotation   bool   `yaml:"key_rotation"`
+	Hardware      bool   `yaml:"hardware"` // HSM
+	Backup        bool   `yaml:"backup"`
+	AuditLogging  bool   `yaml:"audit_logging"`
 }
 
 // SecretManagementConfig defines secret management configuration
 type SecretManagementConfig struct {
-	Provider         string `yaml:"provider"`
-	CrossProvider    bool   `yaml:"cross_provider"`
-	Encryption       bool   `yaml:"encryption"`
-	Versioning       bool   `yaml:"versioning"`
-	AccessLogging    bool   `yaml:"access_logging"`
-	AutoRotation     bool   `yaml:"auto_rotation"`
+	Provider      string `yaml:"provider"`
+	CrossProvider bool   `yaml:"cross_provider"`
+	Encryption    bool   `yaml:"encryption"`
+	Versioning    bool   `yaml:"versioning"`
+	AccessLogging bool   `yaml:"access_logging"`
+	AutoRotation  bool   `yaml:"auto_rotation"`
 }
 
 // ThreatDetectionConfig defines threat detection configuration
 type ThreatDetectionConfig struct {
-	Enabled          bool     `yaml:"enabled"`
-	Providers        []string `yaml:"providers"`
-	MachineLearning  bool     `yaml:"machine_learning"`
-	BehavioralAnalysis bool   `yaml:"behavioral_analysis"`
-	ThreatIntelligence bool   `yaml:"threat_intelligence"`
-	ResponseAutomation bool   `yaml:"response_automation"`
+	Enabled            bool     `yaml:"enabled"`
+	Providers          []string `yaml:"providers"`
+	MachineLearning    bool     `yaml:"machine_learning"`
+	BehavioralAnalysis bool     `yaml:"behavioral_analysis"`
+	ThreatIntelligence bool     `yaml:"threat_intelligence"`
+	ResponseAutomation bool     `yaml:"response_automation"`
 }
 
 // VulnerabilityConfig defines vulnerability scanning configuration
 type VulnerabilityConfig struct {
-	Enabled          bool     `yaml:"enabled"`
-	Scanners         []string `yaml:"scanners"`
-	Schedule         string   `yaml:"schedule"`
-	AutoRemediation  bool     `yaml:"auto_remediation"`
-	Reporting        bool     `yaml:"reporting"`
-	Integration      bool     `yaml:"integration"`
+	Enabled         bool     `yaml:"enabled"`
+	Scanners        []string `yaml:"scanners"`
+	Schedule        string   `yaml:"schedule"`
+	AutoRemediation bool     `yaml:"auto_remediation"`
+	Reporting       bool     `yaml:"reporting"`
+	Integration     bool     `yaml:"integration"`
 }
 
 // SecurityMonitoringConfig defines security monitoring configuration
@@ -597,64 +697,90 @@ type SecurityMonitoringConfig struct {
 	Compliance       []string `yaml:"compliance"`
 }
 
+// AttestationConfig defines confidential-computing attestation
+// verification, mirroring Constellation's config/attestation model: one
+// Measurements baseline per TEE variant (e.g. "aws-nitro", "azure-sev-snp",
+// "gcp-sev-tdx"), checked against a live sample workload's attestation
+// report by testUnifiedIAM and testSecurityMonitoring.
+type AttestationConfig struct {
+	Enabled  bool                               `yaml:"enabled"`
+	Variants map[string]AttestationMeasurements `yaml:"variants" validate:"required_if=Enabled true,dive"`
+}
+
+// AttestationMeasurements is one TEE variant's expected runtime
+// measurements. BootloaderVersion and TEEVersion accept latestresolve's
+// "latest"/"latest-N" sentinels in addition to a pinned version string.
+type AttestationMeasurements struct {
+	PCRs              map[string]string `yaml:"pcrs" validate:"required,min=1"`
+	Microcode         string            `yaml:"microcode" validate:"required"`
+	BootloaderVersion string            `yaml:"bootloader_version" validate:"required,versionorlatest"`
+	TEEVersion        string            `yaml:"tee_version" validate:"required,versionorlatest"`
+}
+
 // MonitoringConfig defines monitoring configuration
 type MonitoringConfig struct {
-	Provider         string                 `yaml:"provider"`
-	CrossProvider    bool                   `yaml:"cross_provider"`
-	Metrics          MetricsConfig          `yaml:"metrics"`
-	Logging          LoggingConfig          `yaml:"logging"`
-	Tracing          TracingConfig          `yaml:"tracing"`
-	Alerting         AlertingConfig         `yaml:"alerting"`
-	Dashboards       DashboardConfig        `yaml:"dashboards"`
-	SLI              []SLIConfig            `yaml:"sli"`
-	SLO              []SLOConfig            `yaml:"slo"`
+	Provider      string          `yaml:"provider"`
+	CrossProvider bool            `yaml:"cross_provider"`
+	Metrics       MetricsConfig   `yaml:"metrics"`
+	Logging       LoggingConfig   `yaml:"logging"`
+	Tracing       TracingConfig   `yaml:"tracing"`
+	Alerting      AlertingConfig  `yaml:"alerting"`
+	Dashboards    DashboardConfig `yaml:"dashboards"`
+	SLI           []SLIConfig     `yaml:"sli"`
+	SLO           []SLOConfig     `yaml:"slo"`
 }
 
 // MetricsConfig defines metrics configuration
 type MetricsConfig struct {
-	Provider         string   `yaml:"provider"`
-	RetentionDays    int      `yaml:"retention_days"`
-	HighAvailability bool     `yaml:"high_availability"`
-	Scraping         bool     `yaml:"scraping"`
-	CustomMetrics    bool     `yaml:"custom_metrics"`
-	Federation       bool     `yaml:"federation"`
+	Provider         string `yaml:"provider"`
+	RetentionDays    int    `yaml:"retention_days"`
+	HighAvailability bool   `yaml:"high_availability"`
+	Scraping         bool   `yaml:"scraping"`
+	CustomMetrics    bool   `yaml:"custom_metrics"`
+	Federation       bool   `yaml:"federation"`
 }
 
 // LoggingConfig defines logging configuration
 type LoggingConfig struct {
-	Provider         string   `yaml:"provider"`
-	RetentionDays    int      `yaml:"retention_days"`
-	Structured       bool     `yaml:"structured"`
-	Encryption       bool     `yaml:"encryption"`
-	Forwarding       bool     `yaml:"forwarding"`
-	Analysis         bool     `yaml:"analysis"`
+	Provider      string `yaml:"provider"`
+	RetentionDays int    `yaml:"retention_days"`
+	Structured    bool   `yaml:"structured"`
+	Encryption    bool   `yaml:"encryption"`
+	Forwarding    bool   `yaml:"forwarding"`
+	Analysis      bool   `yaml:"analysis"`
 }
 
 // TracingConfig defines tracing configuration
 type TracingConfig struct {
-	Provider         string   `yaml:"provider"`
-	SamplingRate     float64  `yaml:"sampling_rate"`
-	Instrumentation  bool     `yaml:"instrumentation"`
-	ServiceMap       bool     `yaml:"service_map"`
-	Performance      bool     `yaml:"performance"`
+	Provider        string  `yaml:"provider"`
+	SamplingRate    float64 `yaml:"sampling_rate"`
+	Instrumentation bool    `yaml:"instrumentation"`
+	ServiceMap      bool    `yaml:"service_map"`
+	Performance     bool    `yaml:"performance"`
+
+	// QueryEndpoint is the Jaeger (or OTLP-compatible) query API's base
+	// URL, used by MultiCloudTestSuite.AssertTrace to fetch a probe's
+	// resulting trace. Left empty, AssertTrace fails with a clear error
+	// rather than silently skipping.
+	QueryEndpoint string `yaml:"query_endpoint"`
 }
 
 // AlertingConfig defines alerting configuration
 type AlertingConfig struct {
-	Provider         string         `yaml:"provider"`
-	Channels         []string       `yaml:"channels"`
-	Escalation       bool           `yaml:"escalation"`
-	Suppression      bool           `yaml:"suppression"`
-	Rules            []AlertRule    `yaml:"rules"`
+	Provider    string      `yaml:"provider"`
+	Channels    []string    `yaml:"channels"`
+	Escalation  bool        `yaml:"escalation"`
+	Suppression bool        `yaml:"suppression"`
+	Rules       []AlertRule `yaml:"rules"`
 }
 
 // AlertRule defines alert rule configuration
 type AlertRule struct {
-	Name        string            `yaml:"name"`
-	Query       string            `yaml:"query"`
+	Name        string            `yaml:"name" validate:"required"`
+	Query       string            `yaml:"query" validate:"required"`
 	Threshold   float64           `yaml:"threshold"`
 	Duration    string            `yaml:"duration"`
-	Severity    string            `yaml:"severity"`
+	Severity    string            `yaml:"severity" validate:"omitempty,oneof=info warning critical page"`
 	Labels      map[string]string `yaml:"labels"`
 	Annotations map[string]string `yaml:"annotations"`
 }
@@ -670,42 +796,49 @@ type DashboardConfig struct {
 
 // SLIConfig defines Service Level Indicator configuration
 type SLIConfig struct {
-	Name        string  `yaml:"name"`
-	Type        string  `yaml:"type"` // availability, latency, throughput, error_rate
-	Query       string  `yaml:"query"`
-	Threshold   float64 `yaml:"threshold"`
-	Window      string  `yaml:"window"`
+	Name      string  `yaml:"name" validate:"required"`
+	Type      string  `yaml:"type" validate:"omitempty,oneof=availability latency throughput error_rate"` // availability, latency, throughput, error_rate
+	Query     string  `yaml:"query" validate:"required"`
+	Threshold float64 `yaml:"threshold"`
+	Window    string  `yaml:"window" validate:"omitempty,goduration"`
 }
 
 // SLOConfig defines Service Level Objective configuration
 type SLOConfig struct {
-	Name        string  `yaml:"name"`
-	SLI         string  `yaml:"sli"`
-	Target      float64 `yaml:"target"`
-	Period      string  `yaml:"period"`
-	ErrorBudget float64 `yaml:"error_budget"`
+	Name        string  `yaml:"name" validate:"required"`
+	SLI         string  `yaml:"sli" validate:"required"`
+	Target      float64 `yaml:"target" validate:"gte=0,lte=1"`
+	Period      string  `yaml:"period" validate:"omitempty,goduration"`
+	ErrorBudget float64 `yaml:"error_budget" validate:"gte=0,lte=1"`
 }
 
 // BackupConfig defines backup configuration
 type BackupConfig struct {
-	Strategy         string              `yaml:"strategy"` // 3-2-1, multi-region, cross-provider
-	Schedule         string              `yaml:"schedule"`
-	RetentionPolicy  RetentionPolicy     `yaml:"retention_policy"`
-	Encryption       bool                `yaml:"encryption"`
-	Compression      bool                `yaml:"compression"`
-	Deduplication    bool                `yaml:"deduplication"`
-	Verification     bool                `yaml:"verification"`
-	CrossProvider    bool                `yaml:"cross_provider"`
-	Targets          []BackupTarget      `yaml:"targets"`
-	Testing          BackupTestConfig    `yaml:"testing"`
+	Strategy        string           `yaml:"strategy" validate:"omitempty,oneof=3-2-1 multi-region cross-provider"` // 3-2-1, multi-region, cross-provider
+	Schedule        string           `yaml:"schedule" validate:"omitempty,cron"`
+	RetentionPolicy RetentionPolicy  `yaml:"retention_policy"`
+	Encryption      bool             `yaml:"encryption"`
+	Compression     bool             `yaml:"compression"`
+	Deduplication   bool             `yaml:"deduplication"`
+	Verification    bool             `yaml:"verification"`
+	CrossProvider   bool             `yaml:"cross_provider"`
+	Targets         []BackupTarget   `yaml:"targets"`
+	Testing         BackupTestConfig `yaml:"testing"`
+
+	// SigningKeyRef names the Ed25519 key backup.Verifier signs each
+	// target's manifest.json with, as a "scheme://key-id" URI
+	// ("aws-kms://", "gcp-kms://", "azure-keyvault://" -- see
+	// backup.ParseKeyRef). Left empty, manifests are written unsigned and
+	// testBackupVerification skips signature verification.
+	SigningKeyRef string `yaml:"signing_key_ref"`
 }
 
 // RetentionPolicy defines retention policy configuration
 type RetentionPolicy struct {
-	Daily   int `yaml:"daily"`
-	Weekly  int `yaml:"weekly"`
-	Monthly int `yaml:"monthly"`
-	Yearly  int `yaml:"yearly"`
+	Daily   int `yaml:"daily" validate:"gte=0"`
+	Weekly  int `yaml:"weekly" validate:"gte=0"`
+	Monthly int `yaml:"monthly" validate:"gte=0"`
+	Yearly  int `yaml:"yearly" validate:"gte=0"`
 }
 
 // BackupTarget defines backup target configuration
@@ -719,136 +852,222 @@ type BackupTarget struct {
 
 // BackupTestConfig defines backup testing configuration
 type BackupTestConfig struct {
-	Enabled       bool   `yaml:"enabled"`
-	Schedule      string `yaml:"schedule"`
-	RestoreTesting bool  `yaml:"restore_testing"`
-	Automated     bool   `yaml:"automated"`
-	Reporting     bool   `yaml:"reporting"`
+	Enabled        bool   `yaml:"enabled"`
+	Schedule       string `yaml:"schedule"`
+	RestoreTesting bool   `yaml:"restore_testing"`
+	Automated      bool   `yaml:"automated"`
+	Reporting      bool   `yaml:"reporting"`
+
+	// DatasetDSN and RestoreDSN are Postgres connection strings
+	// testCrossProviderRestoreVerification seeds a known row set into and
+	// verifies a point-in-time restore against, respectively. Left blank,
+	// only the object-storage half of the restore check runs. RestoreDSN
+	// defaults to DatasetDSN when unset (a restore verified in place).
+	DatasetDSN string `yaml:"dataset_dsn"`
+	RestoreDSN string `yaml:"restore_dsn"`
+
+	// BackupWindowSLO, RestoreWindowSLO, and RPOSLO bound how long
+	// seeding, restore verification, and the newest-lost-record gap may
+	// take before testCrossProviderRestoreVerification fails the run.
+	BackupWindowSLO  string `yaml:"backup_window_slo" validate:"omitempty,goduration"`
+	RestoreWindowSLO string `yaml:"restore_window_slo" validate:"omitempty,goduration"`
+	RPOSLO           string `yaml:"rpo_slo" validate:"omitempty,goduration"`
+
+	// ReportDir, if set, is where testCrossProviderRestoreVerification
+	// writes its JUnit and Markdown reports.
+	ReportDir string `yaml:"report_dir"`
 }
 
 // DisasterRecoveryConfig defines disaster recovery configuration
 type DisasterRecoveryConfig struct {
-	Strategy         string              `yaml:"strategy"` // active-passive, active-active, pilot-light
-	RPO              string              `yaml:"rpo"`      // Recovery Point Objective
-	RTO              string              `yaml:"rto"`      // Recovery Time Objective
-	AutoFailover     bool                `yaml:"auto_failover"`
-	Testing          DRTestConfig        `yaml:"testing"`
-	Sites            []DRSite            `yaml:"sites"`
-	Runbooks         []DRRunbook         `yaml:"runbooks"`
+	Strategy     string       `yaml:"strategy" validate:"omitempty,oneof=active-passive active-active pilot-light"` // active-passive, active-active, pilot-light
+	RPO          string       `yaml:"rpo" validate:"omitempty,goduration"`                                          // Recovery Point Objective
+	RTO          string       `yaml:"rto" validate:"omitempty,goduration"`                                          // Recovery Time Objective
+	AutoFailover bool         `yaml:"auto_failover"`
+	Testing      DRTestConfig `yaml:"testing"`
+	Sites        []DRSite     `yaml:"sites"`
+	Runbooks     []DRRunbook  `yaml:"runbooks"`
 }
 
 // DRTestConfig defines disaster recovery testing configuration
 type DRTestConfig struct {
-	Enabled       bool   `yaml:"enabled"`
-	Schedule      string `yaml:"schedule"`
-	Automated     bool   `yaml:"automated"`
-	Validation    bool   `yaml:"validation"`
-	Reporting     bool   `yaml:"reporting"`
+	Enabled    bool   `yaml:"enabled"`
+	Schedule   string `yaml:"schedule" validate:"omitempty,cron"`
+	Automated  bool   `yaml:"automated"`
+	Validation bool   `yaml:"validation"`
+	Reporting  bool   `yaml:"reporting"`
 }
 
 // DRSite defines disaster recovery site configuration
 type DRSite struct {
-	Name         string `yaml:"name"`
-	Provider     string `yaml:"provider"`
-	Region       string `yaml:"region"`
-	Type         string `yaml:"type"` // primary, secondary, tertiary
-	Capacity     string `yaml:"capacity"` // full, pilot-light, warm-standby
-	Automated    bool   `yaml:"automated"`
+	Name         string   `yaml:"name"`
+	Provider     string   `yaml:"provider"`
+	Region       string   `yaml:"region"`
+	Type         string   `yaml:"type"`     // primary, secondary, tertiary
+	Capacity     string   `yaml:"capacity"` // full, pilot-light, warm-standby
+	Automated    bool     `yaml:"automated"`
 	Dependencies []string `yaml:"dependencies"`
 }
 
 // DRRunbook defines disaster recovery runbook configuration
 type DRRunbook struct {
-	Name         string   `yaml:"name"`
-	Scenario     string   `yaml:"scenario"`
-	Steps        []string `yaml:"steps"`
-	Automation   bool     `yaml:"automation"`
-	Testing      bool     `yaml:"testing"`
-	Owner        string   `yaml:"owner"`
+	Name       string   `yaml:"name"`
+	Scenario   string   `yaml:"scenario"`
+	Steps      []string `yaml:"steps"`
+	Automation bool     `yaml:"automation"`
+	Testing    bool     `yaml:"testing"`
+	Owner      string   `yaml:"owner"`
+
+	// SignatureHash is an HMAC-SHA256 of Steps (keyed by the
+	// CHAOS_SIGNING_KEY environment variable), hex-encoded. ExecuteDRRunbook
+	// refuses to run Steps against live infrastructure unless it matches,
+	// so editing a runbook's destructive steps always requires
+	// re-signing it deliberately.
+	SignatureHash string `yaml:"signature_hash"`
 }
 
 // CostConfig defines cost configuration
 type CostConfig struct {
-	Budgets          []BudgetConfig      `yaml:"budgets"`
-	Optimization     CostOptimization    `yaml:"optimization"`
-	Monitoring       CostMonitoring      `yaml:"monitoring"`
-	Allocation       CostAllocation      `yaml:"allocation"`
-	Governance       CostGovernance      `yaml:"governance"`
+	Budgets      []BudgetConfig   `yaml:"budgets"`
+	Optimization CostOptimization `yaml:"optimization"`
+	Monitoring   CostMonitoring   `yaml:"monitoring"`
+	Allocation   CostAllocation   `yaml:"allocation"`
+	Governance   CostGovernance   `yaml:"governance"`
+
+	// GCPBillingExportTable is the fully qualified "project.dataset.table"
+	// BigQuery billing export table MultiCloudCostAnalyzer queries for GCP
+	// spend. Left empty, GCP cost collection is skipped.
+	GCPBillingExportTable string `yaml:"gcp_billing_export_table"`
+
+	// KubecostPrometheusURL is the base URL of the Prometheus deployed
+	// alongside a KubernetesConfig cluster with Monitoring.Prometheus set;
+	// MultiCloudCostAnalyzer.CollectKubecostAllocation queries it directly
+	// rather than running a Kubecost/OpenCost deployment.
+	KubecostPrometheusURL string `yaml:"kubecost_prometheus_url"`
+
+	// NodePricing gives CollectKubecostAllocation a provider's per-core and
+	// per-GiB hourly rate, keyed by CloudProvider.Name/KubernetesConfig.Provider.
+	NodePricing map[string]NodePricing `yaml:"node_pricing"`
+
+	// ModelStateDir is where testCostAnomalyDetection persists each cost
+	// dimension's cost.Model JSON between runs, so an anomaly/forecast run
+	// only has to fetch the days since the last run rather than the whole
+	// lookback window every time. Left empty, detection still runs but
+	// starts from a fresh model on every invocation.
+	ModelStateDir string `yaml:"model_state_dir"`
+}
+
+// NodePricing is one provider's per-core and per-GiB hourly compute rate,
+// used to price Kubecost-style container resource usage into a cost.
+type NodePricing struct {
+	CPUCoreHourUSD   float64 `yaml:"cpu_core_hour_usd"`
+	MemoryGiBHourUSD float64 `yaml:"memory_gib_hour_usd"`
 }
 
 // BudgetConfig defines budget configuration
 type BudgetConfig struct {
-	Name         string            `yaml:"name"`
-	Amount       float64           `yaml:"amount"`
-	Period       string            `yaml:"period"` // monthly, quarterly, yearly
-	Currency     string            `yaml:"currency"`
-	Alerts       []float64         `yaml:"alerts"` // percentage thresholds
-	Scope        map[string]string `yaml:"scope"`  // tags, services, etc.
-	Actions      []string          `yaml:"actions"` // notify, restrict, shutdown
+	Name     string            `yaml:"name" validate:"required"`
+	Amount   float64           `yaml:"amount" validate:"gt=0"`
+	Period   string            `yaml:"period" validate:"required,oneof=monthly quarterly yearly"` // monthly, quarterly, yearly
+	Currency string            `yaml:"currency"`
+	Alerts   []float64         `yaml:"alerts" validate:"dive,gte=0,lte=100"`                   // percentage thresholds
+	Scope    map[string]string `yaml:"scope"`                                                  // tags, services, etc.
+	Actions  []string          `yaml:"actions" validate:"dive,oneof=notify restrict shutdown"` // notify, restrict, shutdown
 }
 
 // CostOptimization defines cost optimization configuration
 type CostOptimization struct {
-	RightSizing      bool   `yaml:"right_sizing"`
-	ReservedInstances bool  `yaml:"reserved_instances"`
-	SpotInstances    bool   `yaml:"spot_instances"`
-	AutoShutdown     bool   `yaml:"auto_shutdown"`
-	StorageTiering   bool   `yaml:"storage_tiering"`
-	Recommendations  bool   `yaml:"recommendations"`
+	RightSizing       bool `yaml:"right_sizing"`
+	ReservedInstances bool `yaml:"reserved_instances"`
+	SpotInstances     bool `yaml:"spot_instances"`
+	AutoShutdown      bool `yaml:"auto_shutdown"`
+	StorageTiering    bool `yaml:"storage_tiering"`
+	Recommendations   bool `yaml:"recommendations"`
 }
 
 // CostMonitoring defines cost monitoring configuration
 type CostMonitoring struct {
-	RealTime         bool     `yaml:"real_time"`
-	Forecasting      bool     `yaml:"forecasting"`
-	Anomaly          bool     `yaml:"anomaly"`
-	Reporting        bool     `yaml:"reporting"`
-	Dashboards       bool     `yaml:"dashboards"`
-	Alerts           []string `yaml:"alerts"`
+	RealTime    bool     `yaml:"real_time"`
+	Forecasting bool     `yaml:"forecasting"`
+	Anomaly     bool     `yaml:"anomaly"`
+	Reporting   bool     `yaml:"reporting"`
+	Dashboards  bool     `yaml:"dashboards"`
+	Alerts      []string `yaml:"alerts"`
 }
 
 // CostAllocation defines cost allocation configuration
 type CostAllocation struct {
-	Enabled          bool              `yaml:"enabled"`
-	Method           string            `yaml:"method"` // tags, usage, equal
-	Granularity      string            `yaml:"granularity"` // daily, weekly, monthly
-	Dimensions       []string          `yaml:"dimensions"`
-	Chargeback       bool              `yaml:"chargeback"`
-	Showback         bool              `yaml:"showback"`
-	Tags             map[string]string `yaml:"tags"`
+	Enabled     bool              `yaml:"enabled"`
+	Method      string            `yaml:"method"`      // tags, usage, equal
+	Granularity string            `yaml:"granularity"` // daily, weekly, monthly
+	Dimensions  []string          `yaml:"dimensions"`
+	Chargeback  bool              `yaml:"chargeback"`
+	Showback    bool              `yaml:"showback"`
+	Tags        map[string]string `yaml:"tags"`
 }
 
 // CostGovernance defines cost governance configuration
 type CostGovernance struct {
-	Policies         []CostPolicy      `yaml:"policies"`
-	Approval         bool              `yaml:"approval"`
-	Quotas           bool              `yaml:"quotas"`
-	Tagging          bool              `yaml:"tagging"`
-	Compliance       bool              `yaml:"compliance"`
+	Policies   []CostPolicy `yaml:"policies"`
+	Approval   bool         `yaml:"approval"`
+	Quotas     bool         `yaml:"quotas"`
+	Tagging    bool         `yaml:"tagging"`
+	Compliance bool         `yaml:"compliance"`
 }
 
 // CostPolicy defines cost policy configuration
 type CostPolicy struct {
-	Name         string            `yaml:"name"`
-	Type         string            `yaml:"type"` // spending_limit, resource_limit, approval_required
-	Scope        map[string]string `yaml:"scope"`
-	Threshold    float64           `yaml:"threshold"`
-	Action       string            `yaml:"action"` // block, approve, notify
-	Exceptions   []string          `yaml:"exceptions"`
+	Name       string            `yaml:"name" validate:"required"`
+	Type       string            `yaml:"type" validate:"required,oneof=spending_limit resource_limit approval_required"` // spending_limit, resource_limit, approval_required
+	Scope      map[string]string `yaml:"scope"`
+	Threshold  float64           `yaml:"threshold" validate:"gte=0"`
+	Action     string            `yaml:"action" validate:"required,oneof=block approve notify"` // block, approve, notify
+	Exceptions []string          `yaml:"exceptions"`
+}
+
+// IaCConfig drives tests/terraform/iac.Runner for TestMultiCloudOrchestration's
+// "Infrastructure as Code" and "Deployment Automation" subtests: one
+// terraform/tofu fixture module per provider, planned and checked against
+// declarative invariants before Deployment Automation applies and
+// destroys it in an isolated workspace.
+type IaCConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Fixtures maps a CloudProvider.Name to its fixture module directory,
+	// e.g. {"aws": "../../test/fixtures/iac/aws"}.
+	Fixtures map[string]string `yaml:"fixtures"`
+
+	// ExpectedTopology maps a provider to its expected planned resource
+	// count per resource type, e.g. {"aws": {"aws_security_group": 1}}.
+	ExpectedTopology map[string]map[string]int `yaml:"expected_topology"`
+
+	// PublicIngressAllowlist lists resource addresses (e.g.
+	// "aws_security_group.bastion") CheckNoPublicIngress should not flag
+	// even though they allow 0.0.0.0/0.
+	PublicIngressAllowlist []string `yaml:"public_ingress_allowlist"`
+
+	// KeepOnFailure skips destroy after a failed apply, leaving the
+	// workspace's resources in place for debugging.
+	KeepOnFailure bool `yaml:"keep_on_failure"`
 }
 
 // IntegrationTest defines integration test configuration
 type IntegrationTest struct {
-	Name         string            `yaml:"name"`
-	Description  string            `yaml:"description"`
-	Type         string            `yaml:"type"` // connectivity, performance, security, backup, disaster_recovery
-	Providers    []string          `yaml:"providers"`
-	Dependencies []string          `yaml:"dependencies"`
-	Steps        []TestStep        `yaml:"steps"`
-	Validation   []TestValidation  `yaml:"validation"`
-	Cleanup      bool              `yaml:"cleanup"`
-	Timeout      time.Duration     `yaml:"timeout"`
-	Retry        TestRetry         `yaml:"retry"`
+	Name         string           `yaml:"name"`
+	Description  string           `yaml:"description"`
+	Type         string           `yaml:"type"` // connectivity, performance, security, backup, disaster_recovery
+	Providers    []string         `yaml:"providers"`
+	Dependencies []string         `yaml:"dependencies"`
+	Steps        []TestStep       `yaml:"steps"`
+	Validation   []TestValidation `yaml:"validation"`
+	Cleanup      bool             `yaml:"cleanup"`
+	Timeout      time.Duration    `yaml:"timeout"`
+	Retry        TestRetry        `yaml:"retry"`
+
+	// Parameters holds free-form string values for this test, e.g. image
+	// or runtime versions ("k8s": "latest", "latest-1") that
+	// ResolveLatestVersions expands into a concrete pinned value.
+	Parameters map[string]string `yaml:"parameters"`
 }
 
 // TestStep defines test step configuration
@@ -864,12 +1083,12 @@ type TestStep struct {
 
 // TestValidation defines test validation configuration
 type TestValidation struct {
-	Name         string      `yaml:"name"`
-	Type         string      `yaml:"type"` // http, tcp, dns, metric, log
-	Target       string      `yaml:"target"`
-	Expected     interface{} `yaml:"expected"`
-	Timeout      time.Duration `yaml:"timeout"`
-	Retry        TestRetry   `yaml:"retry"`
+	Name     string        `yaml:"name"`
+	Type     string        `yaml:"type"` // http, tcp, dns, metric, log
+	Target   string        `yaml:"target"`
+	Expected interface{}   `yaml:"expected"`
+	Timeout  time.Duration `yaml:"timeout"`
+	Retry    TestRetry     `yaml:"retry"`
 }
 
 // TestRetry defines test retry configuration
@@ -881,36 +1100,49 @@ type TestRetry struct {
 
 // MultiCloudTestResult represents the result of a multi-cloud test
 type MultiCloudTestResult struct {
-	TestName      string                 `json:"test_name"`
-	TestType      string                 `json:"test_type"`
-	StartTime     time.Time             `json:"start_time"`
-	EndTime       time.Time             `json:"end_time"`
-	Duration      time.Duration         `json:"duration"`
-	Status        string                 `json:"status"` // pass, fail, skip
-	Error         error                 `json:"error,omitempty"`
-	Providers     []string              `json:"providers"`
-	Regions       []string              `json:"regions"`
-	Metrics       map[string]interface{} `json:"metrics"`
-	Logs          []string              `json:"logs"`
-	Artifacts     []string              `json:"artifacts"`
+	TestName  string                 `json:"test_name"`
+	TestType  string                 `json:"test_type"`
+	StartTime time.Time              `json:"start_time"`
+	EndTime   time.Time              `json:"end_time"`
+	Duration  time.Duration          `json:"duration"`
+	Status    string                 `json:"status"` // pass, fail, skip
+	Error     error                  `json:"error,omitempty"`
+	Providers []string               `json:"providers"`
+	Regions   []string               `json:"regions"`
+	Metrics   map[string]interface{} `json:"metrics"`
+	Logs      []string               `json:"logs"`
+	Artifacts []string               `json:"artifacts"`
 }
 
 // NewMultiCloudTestSuite creates a new multi-cloud test suite
 func NewMultiCloudTestSuite(config TestConfig) (*MultiCloudTestSuite, error) {
 	testID := uuid.New().String()[:8]
 	ctx := context.Background()
-	
+
 	// Initialize logger
 	logger := log.With().
 		Str("service", "multi-cloud-test-suite").
 		Str("test_id", testID).
 		Logger()
 
-	// Load multi-cloud configuration
-	multiCloudConfig, err := LoadMultiCloudConfig("multi-cloud-config.yaml")
+	// A previously resolved config takes priority over
+	// multi-cloud-config.yaml, so a "planning" run's pinned
+	// regions/storage-classes/versions are consumed verbatim rather than
+	// re-resolved against live provider SDKs on every run.
+	multiCloudConfig, resolved, err := LoadResolvedMultiCloudConfig(resolvedMultiCloudConfigPath)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to load multi-cloud config, using defaults")
-		multiCloudConfig = getDefaultMultiCloudConfig()
+		return nil, errors.Wrap(err, "failed to load resolved multi-cloud config")
+	}
+	if !resolved {
+		multiCloudConfig, err = LoadMultiCloudConfig("multi-cloud-config.yaml")
+		if err != nil {
+			logger.Warn().Err(err).Msg("Failed to load multi-cloud config, using defaults")
+			multiCloudConfig = getDefaultMultiCloudConfig()
+		} else if !config.SkipValidation {
+			if err := ValidateMultiCloudConfig(multiCloudConfig); err != nil {
+				return nil, errors.Wrap(err, "multi-cloud config failed validation")
+			}
+		}
 	}
 
 	suite := &MultiCloudTestSuite{
@@ -920,6 +1152,7 @@ func NewMultiCloudTestSuite(config TestConfig) (*MultiCloudTestSuite, error) {
 		Context:          ctx,
 		MultiCloudConfig: multiCloudConfig,
 		TestResults:      make([]MultiCloudTestResult, 0),
+		Recorder:         iamsynth.NewRecorder(),
 	}
 
 	// Initialize cloud provider test suites
@@ -927,6 +1160,12 @@ func NewMultiCloudTestSuite(config TestConfig) (*MultiCloudTestSuite, error) {
 		return nil, errors.Wrap(err, "failed to initialize cloud provider test suites")
 	}
 
+	if !resolved {
+		if err := suite.ResolveLatestVersions(ctx); err != nil {
+			return nil, errors.Wrap(err, "failed to resolve \"latest\" sentinels in multi-cloud config")
+		}
+	}
+
 	return suite, nil
 }
 
@@ -955,24 +1194,24 @@ func getDefaultMultiCloudConfig() MultiCloudConfig {
 	return MultiCloudConfig{
 		Providers: []CloudProvider{
 			{
-				Name:    "aws",
-				Enabled: true,
-				Primary: true,
-				Regions: []string{"us-east-1", "us-west-2"},
+				Name:     "aws",
+				Enabled:  true,
+				Primary:  true,
+				Regions:  []string{"us-east-1", "us-west-2"},
 				Services: []string{"ec2", "s3", "rds", "lambda"},
 			},
 			{
-				Name:    "gcp",
-				Enabled: true,
-				Primary: false,
-				Regions: []string{"us-central1", "us-west1"},
+				Name:     "gcp",
+				Enabled:  true,
+				Primary:  false,
+				Regions:  []string{"us-central1", "us-west1"},
 				Services: []string{"compute", "storage", "sql", "functions"},
 			},
 			{
-				Name:    "azure",
-				Enabled: true,
-				Primary: false,
-				Regions: []string{"eastus", "westus2"},
+				Name:     "azure",
+				Enabled:  true,
+				Primary:  false,
+				Regions:  []string{"eastus", "westus2"},
 				Services: []string{"vm", "storage", "sql", "functions"},
 			},
 		},
@@ -993,52 +1232,59 @@ func getDefaultMultiCloudConfig() MultiCloudConfig {
 	}
 }
 
-// initializeCloudProviderSuites initializes cloud provider test suites
+// initializeCloudProviderSuites brings up a ProviderTestSuite for every
+// enabled CloudProvider via the ProviderPlugin registry (see
+// provider_registry_test.go), instead of a hard-coded switch over
+// provider names -- a new cloud joins this suite by registering a
+// ProviderPlugin (compiled in via init(), or loaded from
+// MultiCloudConfig.PluginDir) rather than by editing this function.
 func (suite *MultiCloudTestSuite) initializeCloudProviderSuites() error {
-	var err error
+	if err := loadExternalProviderPlugins(suite.MultiCloudConfig.PluginDir); err != nil {
+		return errors.Wrap(err, "failed to load external provider plugins")
+	}
+
+	suite.ProviderSuites = make(map[string]ProviderTestSuite)
 
-	// Initialize AWS test suite if enabled
 	for _, provider := range suite.MultiCloudConfig.Providers {
 		if !provider.Enabled {
 			continue
 		}
 
-		switch provider.Name {
-		case "aws":
-			suite.AWSTestSuite, err = NewAWSTestSuite(provider.Regions[0], suite.Config)
-			if err != nil {
-				suite.Logger.Warn().Err(err).Msg("Failed to initialize AWS test suite")
-			}
-		case "gcp":
-			projectID := provider.Credentials["project_id"]
-			if projectID == "" {
-				projectID = "default-project"
-			}
-			suite.GCPTestSuite, err = NewGCPTestSuite(projectID, provider.Regions[0], provider.Regions[0]+"-a", suite.Config)
-			if err != nil {
-				suite.Logger.Warn().Err(err).Msg("Failed to initialize GCP test suite")
-			}
-		case "azure":
-			subscriptionID := provider.Credentials["subscription_id"]
-			tenantID := provider.Credentials["tenant_id"]
-			clientID := provider.Credentials["client_id"]
-			clientSecret := provider.Credentials["client_secret"]
-			if subscriptionID == "" {
-				subscriptionID = "default-subscription"
-			}
-			suite.AzureTestSuite, err = NewAzureTestSuite(subscriptionID, tenantID, clientID, clientSecret, provider.Regions[0], suite.Config)
-			if err != nil {
-				suite.Logger.Warn().Err(err).Msg("Failed to initialize Azure test suite")
-			}
-		case "kubernetes":
-			kubeconfigPath := provider.Credentials["kubeconfig_path"]
-			if kubeconfigPath == "" {
-				kubeconfigPath = "~/.kube/config"
-			}
-			suite.K8sTestSuite, err = NewK8sTestSuite(kubeconfigPath, "default", suite.Config)
-			if err != nil {
-				suite.Logger.Warn().Err(err).Msg("Failed to initialize Kubernetes test suite")
-			}
+		factory, ok := lookupProviderFactory(provider.Name)
+		if !ok {
+			suite.Logger.Warn().Str("provider", provider.Name).Msg("no provider plugin registered for this provider, skipping")
+			continue
+		}
+
+		plugin := factory()
+		if aware, ok := plugin.(recorderAwarePlugin); ok {
+			plugin = aware.WithRecorder(suite.Recorder)
+		}
+
+		providerSuite, err := plugin.Init(provider, suite.Config)
+		if err != nil {
+			suite.Logger.Warn().Err(err).Str("provider", provider.Name).Msg("failed to initialize provider test suite")
+			continue
+		}
+		suite.ProviderSuites[provider.Name] = providerSuite
+
+		// The bulk of this suite's cross-provider tests still reference
+		// suite.AWSTestSuite/.GCPTestSuite/.AzureTestSuite/.K8sTestSuite
+		// directly rather than going through ProviderTestSuite, so the
+		// four built-in plugins' concrete wrapper types are unwrapped
+		// back into those fields here too. A provider registered only
+		// through the plugin registry (anything loaded from PluginDir)
+		// is reachable solely via suite.ProviderSuites until those call
+		// sites are migrated onto the capability interface.
+		switch concrete := providerSuite.(type) {
+		case *awsProviderTestSuite:
+			suite.AWSTestSuite = concrete.suite
+		case *gcpProviderTestSuite:
+			suite.GCPTestSuite = concrete.suite
+		case *azureProviderTestSuite:
+			suite.AzureTestSuite = concrete.suite
+		case *k8sProviderTestSuite:
+			suite.K8sTestSuite = concrete.suite
 		}
 	}
 
@@ -1296,6 +1542,19 @@ func (suite *MultiCloudTestSuite) TestCrossProviderBackup(t *testing.T) {
 		}
 	})
 
+	// Test cross-provider restore verification: seeded datasets, measured
+	// backup/restore windows, and actual RPO against the configured SLOs.
+	t.Run("Cross-Provider Restore Verification", func(t *testing.T) {
+		if suite.MultiCloudConfig.BackupConfig.CrossProvider && suite.MultiCloudConfig.BackupConfig.Testing.RestoreTesting {
+			err := suite.testCrossProviderRestoreVerification()
+			if err != nil {
+				t.Errorf("Cross-provider restore verification test failed: %v", err)
+			} else {
+				suite.Logger.Info().Msg("Cross-provider restore verification test successful")
+			}
+		}
+	})
+
 	suite.Logger.Info().Msg("Cross-provider backup tests completed")
 }
 
@@ -1335,6 +1594,30 @@ func (suite *MultiCloudTestSuite) TestCrossProviderDisasterRecovery(t *testing.T
 		}
 	})
 
+	// Runbooks with structured Steps execute through ExecuteDRRunbook
+	// (dr.RunbookExecutor) rather than the Scenario-label game day above,
+	// so each gets its own subtest and can trigger its DRSite's
+	// pilot-light/warm-standby/active-active promotion sequence.
+	for _, runbook := range suite.MultiCloudConfig.DisasterRecoveryConfig.Runbooks {
+		if len(runbook.Steps) == 0 {
+			continue
+		}
+		runbook := runbook
+		t.Run("Runbook/"+runbook.Name, func(t *testing.T) {
+			if !runbook.Testing {
+				t.Skip("runbook is not marked for testing")
+			}
+			result, err := suite.ExecuteDRRunbook(suite.Context, runbook)
+			if err != nil {
+				t.Errorf("runbook %q failed to execute: %v", runbook.Name, err)
+				return
+			}
+			if result.Status != "pass" {
+				t.Errorf("runbook %q did not pass: %v", runbook.Name, result.Error)
+			}
+		})
+	}
+
 	suite.Logger.Info().Msg("Cross-provider disaster recovery tests completed")
 }
 
@@ -1495,6 +1778,19 @@ func (suite *MultiCloudTestSuite) TestCrossProviderCostOptimization(t *testing.T
 		}
 	})
 
+	// Test cost anomaly detection and forecasting
+	t.Run("Cost Anomaly Detection", func(t *testing.T) {
+		monitoring := suite.MultiCloudConfig.CostConfig.Monitoring
+		if monitoring.Anomaly || monitoring.Forecasting {
+			err := suite.testCostAnomalyDetection()
+			if err != nil {
+				t.Errorf("Cost anomaly detection test failed: %v", err)
+			} else {
+				suite.Logger.Info().Msg("Cost anomaly detection test successful")
+			}
+		}
+	})
+
 	suite.Logger.Info().Msg("Cross-provider cost optimization tests completed")
 }
 
@@ -1768,17 +2064,11 @@ func (suite *MultiCloudTestSuite) testCrossProviderBackupStrategy() error {
 	return nil
 }
 
-func (suite *MultiCloudTestSuite) testBackupVerification() error {
-	suite.Logger.Info().Msg("Testing backup verification")
-	// Implementation would test backup verification functionality
-	return nil
-}
-
-func (suite *MultiCloudTestSuite) testBackupRestore() error {
-	suite.Logger.Info().Msg("Testing backup restore")
-	// Implementation would test backup restore functionality
-	return nil
-}
+// testBackupVerification and testBackupRestore are implemented in
+// backup_test.go, alongside the rest of the Merkle-manifest backup glue.
+// testCrossProviderRestoreVerification is implemented in
+// cross_provider_backup_test.go, driving pluggable DatasetSeeder/Comparator
+// pairs and reporting via the backup package.
 
 func (suite *MultiCloudTestSuite) testDRStrategy() error {
 	suite.Logger.Info().Msg("Testing DR strategy")
@@ -1788,16 +2078,83 @@ func (suite *MultiCloudTestSuite) testDRStrategy() error {
 
 func (suite *MultiCloudTestSuite) testFailoverProcedures() error {
 	suite.Logger.Info().Msg("Testing failover procedures")
-	// Implementation would test failover procedures
+
+	report, err := suite.runOrReuseDisasterRecoveryGameDay()
+	if err != nil {
+		return errors.Wrap(err, "disaster recovery game day failed")
+	}
+
+	for _, result := range report.Results {
+		if !result.RTOWithinSLO {
+			return errors.Errorf("runbook %q breached its RTO SLO (took %s): %s", result.Action.Target, result.RTO, result.Error)
+		}
+		if result.Action.Type == dr.ActionDNSFailover && !result.FailoverVerified {
+			return errors.Errorf("dns failover runbook %q did not move traffic to the secondary site", result.Action.Target)
+		}
+	}
 	return nil
 }
 
 func (suite *MultiCloudTestSuite) testRTORPOValidation() error {
 	suite.Logger.Info().Msg("Testing RTO/RPO validation")
-	// Implementation would test RTO/RPO validation
+
+	report, err := suite.runOrReuseDisasterRecoveryGameDay()
+	if err != nil {
+		return errors.Wrap(err, "disaster recovery game day failed")
+	}
+
+	for _, result := range report.Results {
+		if !result.RPOWithinSLO {
+			return errors.Errorf("runbook %q breached its RPO SLO", result.Action.Target)
+		}
+	}
 	return nil
 }
 
+// runOrReuseDisasterRecoveryGameDay runs RunDisasterRecoveryGameDay once and
+// caches the report on suite, since testFailoverProcedures and
+// testRTORPOValidation both need it but injecting every DR runbook's
+// failure twice to answer two questions about the same run is wasteful.
+func (suite *MultiCloudTestSuite) runOrReuseDisasterRecoveryGameDay() (*dr.Report, error) {
+	if suite.drGameDayReport != nil {
+		return suite.drGameDayReport, nil
+	}
+
+	slo, err := parseDisasterRecoverySLO(suite.MultiCloudConfig.DisasterRecoveryConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := suite.RunDisasterRecoveryGameDay(suite.Context, slo)
+	if err != nil {
+		return nil, err
+	}
+	suite.drGameDayReport = report
+	return report, nil
+}
+
+// parseDisasterRecoverySLO converts DisasterRecoveryConfig's human-entered
+// RTO/RPO duration strings (e.g. "4h") into a dr.SLO, leaving either bound
+// unset (no limit) if it's empty or unparseable.
+func parseDisasterRecoverySLO(config DisasterRecoveryConfig) (dr.SLO, error) {
+	var slo dr.SLO
+	if config.RTO != "" {
+		maxRTO, err := time.ParseDuration(config.RTO)
+		if err != nil {
+			return slo, errors.Wrapf(err, "invalid disaster recovery RTO %q", config.RTO)
+		}
+		slo.MaxRTO = maxRTO
+	}
+	if config.RPO != "" {
+		maxRPO, err := time.ParseDuration(config.RPO)
+		if err != nil {
+			return slo, errors.Wrapf(err, "invalid disaster recovery RPO %q", config.RPO)
+		}
+		slo.MaxRPO = maxRPO
+	}
+	return slo, nil
+}
+
 func (suite *MultiCloudTestSuite) testUnifiedMonitoring() error {
 	suite.Logger.Info().Msg("Testing unified monitoring")
 	// Implementation would test unified monitoring across providers
@@ -1810,15 +2167,12 @@ func (suite *MultiCloudTestSuite) testCrossProviderAlerting() error {
 	return nil
 }
 
-func (suite *MultiCloudTestSuite) testSLISLOMonitoring() error {
-	suite.Logger.Info().Msg("Testing SLI/SLO monitoring")
-	// Implementation would test SLI/SLO monitoring
-	return nil
-}
-
 func (suite *MultiCloudTestSuite) testUnifiedIAM() error {
 	suite.Logger.Info().Msg("Testing unified IAM")
 	// Implementation would test unified IAM across providers
+	if err := suite.testWorkloadAttestation(); err != nil {
+		return errors.Wrap(err, "workload attestation check failed")
+	}
 	return nil
 }
 
@@ -1831,6 +2185,9 @@ func (suite *MultiCloudTestSuite) testCrossProviderKeyManagement() error {
 func (suite *MultiCloudTestSuite) testSecurityMonitoring() error {
 	suite.Logger.Info().Msg("Testing security monitoring")
 	// Implementation would test security monitoring across providers
+	if err := suite.testWorkloadAttestation(); err != nil {
+		return errors.Wrap(err, "workload attestation check failed")
+	}
 	return nil
 }
 
@@ -1854,22 +2211,84 @@ func (suite *MultiCloudTestSuite) testAutoScalingPerformance() error {
 
 func (suite *MultiCloudTestSuite) testCostAllocation() error {
 	suite.Logger.Info().Msg("Testing cost allocation")
-	// Implementation would test cost allocation across providers
+
+	analyzer := NewMultiCloudCostAnalyzer(suite)
+	report, err := analyzer.CollectCosts(defaultCostAnomalyLookbackDays)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect multi-cloud cost report")
+	}
+
+	suite.Logger.Info().
+		Float64("total_usd", report.TotalUSD).
+		Interface("by_provider", report.ByProvider).
+		Interface("by_category", report.ByCategory).
+		Msg("Collected multi-cloud cost report")
+
 	return nil
 }
 
 func (suite *MultiCloudTestSuite) testBudgetMonitoring() error {
 	suite.Logger.Info().Msg("Testing budget monitoring")
-	// Implementation would test budget monitoring across providers
+
+	analyzer := NewMultiCloudCostAnalyzer(suite)
+	report, err := analyzer.CollectCosts(defaultCostAnomalyLookbackDays)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect multi-cloud cost report")
+	}
+
+	if violations := analyzer.EvaluateBudgets(report); len(violations) > 0 {
+		return errors.Errorf("%d budget(s) exceeded: %s", len(violations), strings.Join(violations, "; "))
+	}
+
 	return nil
 }
 
-func (suite *MultiCloudTestSuite) testCostOptimizationRecommendations() error {
-	suite.Logger.Info().Msg("Testing cost optimization recommendations")
-	// Implementation would test cost optimization recommendations
+// ExportLeastPrivilegePolicies synthesizes a least-privilege IAM policy per
+// enabled provider from the AWS/GCP/Azure calls this suite's clients have
+// actually made so far (via suite.Recorder), and writes each to dir as
+// "<provider>-policy.json". It's meant to be called after a test run so the
+// output reflects everything that run exercised, not a point-in-time guess.
+func (suite *MultiCloudTestSuite) ExportLeastPrivilegePolicies(dir string) error {
+	if suite.AWSTestSuite != nil {
+		awsPolicy := iamsynth.SynthesizeAWSPolicy(suite.Recorder.ForProvider("aws"))
+		data, err := json.MarshalIndent(awsPolicy, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal AWS policy document")
+		}
+		if err := os.WriteFile(filepath.Join(dir, "aws-policy.json"), data, 0o644); err != nil {
+			return errors.Wrap(err, "failed to write AWS policy document")
+		}
+	}
+
+	if suite.GCPTestSuite != nil {
+		role := iamsynth.SynthesizeGCPRole(suite.Recorder.ForProvider("gcp"))
+		data, err := json.MarshalIndent(role, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal GCP custom role")
+		}
+		if err := os.WriteFile(filepath.Join(dir, "gcp-role.json"), data, 0o644); err != nil {
+			return errors.Wrap(err, "failed to write GCP custom role")
+		}
+	}
+
+	if suite.AzureTestSuite != nil {
+		roleDef := iamsynth.SynthesizeAzureRoleDefinition(suite.Recorder.ForProvider("azure"))
+		data, err := json.MarshalIndent(roleDef, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal Azure role definition")
+		}
+		if err := os.WriteFile(filepath.Join(dir, "azure-role.json"), data, 0o644); err != nil {
+			return errors.Wrap(err, "failed to write Azure role definition")
+		}
+	}
+
 	return nil
 }
 
+// testCostOptimizationRecommendations is implemented in
+// cost_rightsizing_test.go, correlating allocated vs. actual utilization
+// into right-sizing recommendations via cost.Recommend.
+
 func (suite *MultiCloudTestSuite) testComplianceStandards() error {
 	suite.Logger.Info().Msg("Testing compliance standards")
 	// Implementation would test compliance standards across providers
@@ -1894,17 +2313,9 @@ func (suite *MultiCloudTestSuite) testWorkloadOrchestration() error {
 	return nil
 }
 
-func (suite *MultiCloudTestSuite) testDeploymentAutomation() error {
-	suite.Logger.Info().Msg("Testing deployment automation")
-	// Implementation would test deployment automation across providers
-	return nil
-}
-
-func (suite *MultiCloudTestSuite) testInfrastructureAsCode() error {
-	suite.Logger.Info().Msg("Testing infrastructure as code")
-	// Implementation would test infrastructure as code across providers
-	return nil
-}
+// testDeploymentAutomation and testInfrastructureAsCode are defined in
+// iac_test.go, driving tests/terraform/iac.Runner against
+// MultiCloudConfig.IaC.Fixtures.
 
 func (suite *MultiCloudTestSuite) testEdgeDeployment() error {
 	suite.Logger.Info().Msg("Testing edge deployment")
@@ -1968,18 +2379,18 @@ func (suite *MultiCloudTestSuite) Cleanup() {
 	if suite.AWSTestSuite != nil {
 		// AWS cleanup would go here
 	}
-	
+
 	if suite.GCPTestSuite != nil {
 		// GCP cleanup would go here
 	}
-	
+
 	if suite.AzureTestSuite != nil {
 		// Azure cleanup would go here
 	}
-	
+
 	if suite.K8sTestSuite != nil {
 		suite.K8sTestSuite.Cleanup()
 	}
 
 	suite.Logger.Info().Msg("Multi-cloud test cleanup completed")
-}
\ No newline at end of file
+}
@@ -0,0 +1,252 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	veleroclientset "github.com/vmware-tanzu/velero/pkg/generated/clientset/versioned"
+
+	"github.com/pkg/errors"
+)
+
+// veleroNamespace is where the Velero server (and its Backup/Restore
+// custom resources) live in every cluster this suite targets.
+const veleroNamespace = "velero"
+
+// backupPollInterval is how often BackupVerifier polls Backup/Restore
+// phase.
+const backupPollInterval = 5 * time.Second
+
+// DRReport records the RPO/RTO measured by a BackupVerifier-driven
+// disaster-recovery drill.
+type DRReport struct {
+	BackupName  string        `json:"backup_name"`
+	RestoreName string        `json:"restore_name"`
+	RPO         time.Duration `json:"rpo"` // backup age at the simulated failure
+	RTO         time.Duration `json:"rto"` // elapsed time from delete to canary readiness
+	ChecksumOK  bool          `json:"checksum_ok"`
+}
+
+// BackupVerifier drives Velero backups and restores through its typed
+// clientset to prove backups actually restore, rather than just existing.
+type BackupVerifier struct {
+	Velero veleroclientset.Interface
+}
+
+// NewBackupVerifier builds a BackupVerifier from suite.RestConfig.
+func (suite *K8sTestSuite) NewBackupVerifier() (*BackupVerifier, error) {
+	client, err := veleroclientset.NewForConfig(suite.RestConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build Velero clientset")
+	}
+	return &BackupVerifier{Velero: client}, nil
+}
+
+// TriggerBackup creates a Velero Backup of includedNamespaces and returns
+// it (not yet complete -- call WaitForCompletion).
+func (v *BackupVerifier) TriggerBackup(ctx context.Context, name string, includedNamespaces []string) (*velerov1.Backup, error) {
+	backup := &velerov1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: veleroNamespace},
+		Spec:       velerov1.BackupSpec{IncludedNamespaces: includedNamespaces},
+	}
+	created, err := v.Velero.VeleroV1().Backups(veleroNamespace).Create(ctx, backup, metav1.CreateOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to trigger backup %s", name)
+	}
+	return created, nil
+}
+
+// WaitForCompletion polls name's Backup phase until it reaches
+// PhaseCompleted, or returns an error once timeout elapses or the phase
+// reaches a terminal failure state.
+func (v *BackupVerifier) WaitForCompletion(ctx context.Context, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		backup, err := v.Velero.VeleroV1().Backups(veleroNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch backup %s", name)
+		}
+		switch backup.Status.Phase {
+		case velerov1.BackupPhaseCompleted:
+			return nil
+		case velerov1.BackupPhaseFailed, velerov1.BackupPhasePartiallyFailed, velerov1.BackupPhaseFailedValidation:
+			return errors.Errorf("backup %s reached terminal phase %s", name, backup.Status.Phase)
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out waiting for backup %s to complete, last phase %s", name, backup.Status.Phase)
+		}
+		time.Sleep(backupPollInterval)
+	}
+}
+
+// RestoreInto creates a Velero Restore of the most recently triggered
+// backup into targetNamespace and waits for it to complete.
+func (v *BackupVerifier) RestoreInto(ctx context.Context, backupName, sourceNamespace, targetNamespace string, timeout time.Duration) (*velerov1.Restore, error) {
+	restoreName := backupName + "-restore"
+	restore := &velerov1.Restore{
+		ObjectMeta: metav1.ObjectMeta{Name: restoreName, Namespace: veleroNamespace},
+		Spec: velerov1.RestoreSpec{
+			BackupName:       backupName,
+			NamespaceMapping: map[string]string{sourceNamespace: targetNamespace},
+		},
+	}
+	if _, err := v.Velero.VeleroV1().Restores(veleroNamespace).Create(ctx, restore, metav1.CreateOptions{}); err != nil {
+		return nil, errors.Wrapf(err, "failed to trigger restore %s", restoreName)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := v.Velero.VeleroV1().Restores(veleroNamespace).Get(ctx, restoreName, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch restore %s", restoreName)
+		}
+		switch current.Status.Phase {
+		case velerov1.RestorePhaseCompleted:
+			return current, nil
+		case velerov1.RestorePhaseFailed, velerov1.RestorePhasePartiallyFailed:
+			return current, errors.Errorf("restore %s reached terminal phase %s", restoreName, current.Status.Phase)
+		}
+		if time.Now().After(deadline) {
+			return current, errors.Errorf("timed out waiting for restore %s to complete, last phase %s", restoreName, current.Status.Phase)
+		}
+		time.Sleep(backupPollInterval)
+	}
+}
+
+// seedCanaryChecksum writes a known payload to a file on volumePath inside
+// podName/containerName and returns its sha256 checksum.
+func (suite *K8sTestSuite) seedCanaryChecksum(podName, containerName, filePath string) (string, error) {
+	payload := fmt.Sprintf("dr-canary-%s-%d", suite.TestID, time.Now().UnixNano())
+	sum := sha256.Sum256([]byte(payload))
+	checksum := hex.EncodeToString(sum[:])
+
+	cmd := []string{"sh", "-c", fmt.Sprintf("echo -n %q > %s", payload, filePath)}
+	if err := suite.execInPod(podName, containerName, cmd, nil); err != nil {
+		return "", errors.Wrapf(err, "failed to seed canary checksum in %s", podName)
+	}
+	return checksum, nil
+}
+
+// readCanaryChecksum reads filePath from podName/containerName and returns
+// its sha256 checksum.
+func (suite *K8sTestSuite) readCanaryChecksum(podName, containerName, filePath string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := []string{"sh", "-c", "cat " + filePath}
+	if err := suite.execInPod(podName, containerName, cmd, &stdout); err != nil {
+		return "", errors.Wrapf(err, "failed to read canary checksum from %s", podName)
+	}
+	sum := sha256.Sum256(stdout.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// execInPod runs cmd in podName/containerName, capturing stdout into out
+// if non-nil.
+func (suite *K8sTestSuite) execInPod(podName, containerName string, cmd []string, out *bytes.Buffer) error {
+	req := suite.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Name(podName).Namespace(suite.Namespace).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   cmd,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(suite.RestConfig, "POST", req.URL())
+	if err != nil {
+		return errors.Wrap(err, "failed to build SPDY executor")
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := executor.StreamWithContext(suite.Context, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+		return errors.Wrapf(err, "exec failed: %s", stderr.String())
+	}
+	if out != nil {
+		out.Write(stdout.Bytes())
+	}
+	return nil
+}
+
+// RunDisasterRecoveryDrill seeds a canary Deployment + PVC with a known
+// checksum, backs the namespace up with Velero, deletes it, restores into
+// a fresh namespace, and asserts the checksum survives. It records RPO
+// (backup age at "failure" time) and RTO (elapsed time from delete to
+// canary readiness) in the returned DRReport.
+func (suite *K8sTestSuite) RunDisasterRecoveryDrill(t *testing.T, sourceNamespace string) (DRReport, error) {
+	verifier, err := suite.NewBackupVerifier()
+	if err != nil {
+		return DRReport{}, err
+	}
+
+	canaryPod := "dr-canary-" + suite.TestID
+	const containerName = "canary"
+	const filePath = "/data/canary.txt"
+	const canaryTimeout = 3 * time.Minute
+
+	checksum, err := suite.seedCanaryChecksum(canaryPod, containerName, filePath)
+	if err != nil {
+		return DRReport{}, err
+	}
+
+	backupName := "dr-drill-" + suite.TestID
+	backupStart := time.Now()
+	if _, err := verifier.TriggerBackup(suite.Context, backupName, []string{sourceNamespace}); err != nil {
+		return DRReport{}, err
+	}
+	if err := verifier.WaitForCompletion(suite.Context, backupName, canaryTimeout); err != nil {
+		return DRReport{}, err
+	}
+	rpo := time.Since(backupStart)
+
+	failureTime := time.Now()
+	if err := suite.Clientset.CoreV1().Namespaces().Delete(suite.Context, sourceNamespace, metav1.DeleteOptions{}); err != nil {
+		return DRReport{}, errors.Wrapf(err, "failed to delete namespace %s to simulate failure", sourceNamespace)
+	}
+
+	targetNamespace := sourceNamespace + "-restored"
+	if _, err := verifier.RestoreInto(suite.Context, backupName, sourceNamespace, targetNamespace, canaryTimeout); err != nil {
+		return DRReport{}, err
+	}
+
+	if err := suite.WaitForResourcesReady(suite.Context, []K8sResource{{
+		Kind:     "Pod",
+		Metadata: metav1.ObjectMeta{Name: canaryPod, Namespace: targetNamespace},
+	}}, canaryTimeout); err != nil {
+		return DRReport{}, errors.Wrap(err, "canary pod did not become ready after restore")
+	}
+	rto := time.Since(failureTime)
+
+	restoredChecksum, err := suite.readCanaryChecksum(canaryPod, containerName, filePath)
+	if err != nil {
+		return DRReport{}, err
+	}
+
+	report := DRReport{
+		BackupName:  backupName,
+		RestoreName: backupName + "-restore",
+		RPO:         rpo,
+		RTO:         rto,
+		ChecksumOK:  restoredChecksum == checksum,
+	}
+
+	if suite.Config.DR.MaxRPOSeconds > 0 && rpo > time.Duration(suite.Config.DR.MaxRPOSeconds)*time.Second {
+		return report, errors.Errorf("RPO %s exceeded threshold of %ds", rpo, suite.Config.DR.MaxRPOSeconds)
+	}
+	if suite.Config.DR.MaxRTOSeconds > 0 && rto > time.Duration(suite.Config.DR.MaxRTOSeconds)*time.Second {
+		return report, errors.Errorf("RTO %s exceeded threshold of %ds", rto, suite.Config.DR.MaxRTOSeconds)
+	}
+	if !report.ChecksumOK {
+		return report, errors.New("restored canary checksum does not match the original -- restore lost data")
+	}
+	return report, nil
+}
@@ -0,0 +1,242 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/pkg/errors"
+)
+
+// resourceBaseline is one container's recorded CPU/memory usage within a
+// Baseline.
+type resourceBaseline struct {
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container"`
+	CPUMilli  int64  `json:"cpu_milli"`
+	MemBytes  int64  `json:"mem_bytes"`
+}
+
+// Baseline is a point-in-time snapshot RecordBaseline writes to disk and
+// AssertNoRegression compares fresh samples against.
+type Baseline struct {
+	Name        string             `json:"name"`
+	Resources   []resourceBaseline `json:"resources"`
+	EventCounts map[string]int     `json:"event_counts"` // key is "namespace/reason"
+}
+
+// Tolerances bounds how far AssertNoRegression lets a fresh sample drift
+// from its Baseline before failing.
+type Tolerances struct {
+	PercentCPU float64 // e.g. 50 means "fail if usage exceeds baseline by more than 50%"
+	PercentMem float64
+	EventDelta int // fail if any (namespace,reason) count grows by more than this
+}
+
+func baselineFilePath(name string) string {
+	return "k8s-baseline-" + name + ".json"
+}
+
+// RecordBaseline snapshots per-container CPU/memory usage from
+// MetricsClientset plus warning-event counts per (namespace, reason) and
+// writes them to disk under name, for a later AssertNoRegression call.
+func (suite *K8sTestSuite) RecordBaseline(name string) error {
+	if suite.MetricsClientset == nil {
+		return errors.New("RecordBaseline requires a MetricsClientset -- NewK8sTestSuite failed to build one")
+	}
+
+	baseline := Baseline{Name: name, EventCounts: make(map[string]int)}
+
+	podMetrics, err := suite.MetricsClientset.MetricsV1beta1().PodMetricses("").List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pod metrics")
+	}
+	for _, metric := range podMetrics.Items {
+		for _, container := range metric.Containers {
+			baseline.Resources = append(baseline.Resources, resourceBaseline{
+				Namespace: metric.Namespace,
+				Pod:       metric.Name,
+				Container: container.Name,
+				CPUMilli:  container.Usage.Cpu().MilliValue(),
+				MemBytes:  container.Usage.Memory().Value(),
+			})
+		}
+	}
+
+	events, err := suite.Clientset.CoreV1().Events("").List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list events")
+	}
+	for _, event := range events.Items {
+		if event.Type == corev1.EventTypeWarning {
+			baseline.EventCounts[event.Namespace+"/"+event.Reason]++
+		}
+	}
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal baseline")
+	}
+	if err := os.WriteFile(baselineFilePath(name), data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write baseline %s", name)
+	}
+	return nil
+}
+
+// AssertNoRegression re-samples CPU/memory and warning events and fails if
+// usage exceeds the name baseline by more than tol's percentages, a new
+// warning-event reason appears, or any (namespace,reason) count grows by
+// more than tol.EventDelta.
+func (suite *K8sTestSuite) AssertNoRegression(name string, tol Tolerances) error {
+	data, err := os.ReadFile(baselineFilePath(name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read baseline %s -- call RecordBaseline first", name)
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return errors.Wrapf(err, "failed to parse baseline %s", name)
+	}
+
+	baselineByKey := make(map[string]resourceBaseline, len(baseline.Resources))
+	for _, r := range baseline.Resources {
+		baselineByKey[r.Namespace+"/"+r.Pod+"/"+r.Container] = r
+	}
+
+	if suite.MetricsClientset == nil {
+		return errors.New("AssertNoRegression requires a MetricsClientset -- NewK8sTestSuite failed to build one")
+	}
+	podMetrics, err := suite.MetricsClientset.MetricsV1beta1().PodMetricses("").List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pod metrics")
+	}
+
+	var violations []string
+	for _, metric := range podMetrics.Items {
+		for _, container := range metric.Containers {
+			key := metric.Namespace + "/" + metric.Name + "/" + container.Name
+			base, ok := baselineByKey[key]
+			if !ok {
+				continue
+			}
+			cpu := container.Usage.Cpu().MilliValue()
+			if base.CPUMilli > 0 && percentOver(cpu, base.CPUMilli) > tol.PercentCPU {
+				violations = append(violations, key+": CPU regressed")
+			}
+			mem := container.Usage.Memory().Value()
+			if base.MemBytes > 0 && percentOver(mem, base.MemBytes) > tol.PercentMem {
+				violations = append(violations, key+": memory regressed")
+			}
+		}
+	}
+
+	events, err := suite.Clientset.CoreV1().Events("").List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list events")
+	}
+	currentCounts := make(map[string]int)
+	for _, event := range events.Items {
+		if event.Type == corev1.EventTypeWarning {
+			currentCounts[event.Namespace+"/"+event.Reason]++
+		}
+	}
+	for key, count := range currentCounts {
+		baseCount, seen := baseline.EventCounts[key]
+		if !seen {
+			violations = append(violations, key+": new warning-event reason")
+			continue
+		}
+		if count-baseCount > tol.EventDelta {
+			violations = append(violations, key+": warning-event count grew beyond tolerance")
+		}
+	}
+
+	if len(violations) > 0 {
+		return errors.Errorf("regression(s) against baseline %s: %s", name, joinStrings(violations))
+	}
+	return nil
+}
+
+// percentOver returns how far current exceeds base, as a percentage of
+// base.
+func percentOver(current, base int64) float64 {
+	if base <= 0 {
+		return 0
+	}
+	return float64(current-base) / float64(base) * 100
+}
+
+// EventWatcher streams Events during a test window via the watch API,
+// rather than relying on a one-shot List, so short-lived Warning events
+// aren't missed between samples.
+type EventWatcher struct {
+	watcher watch.Interface
+	events  []corev1.Event
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// WatchEvents starts an EventWatcher scoped to namespace ("" for
+// cluster-wide). Call Stop to end the watch and retrieve what it saw.
+func (suite *K8sTestSuite) WatchEvents(namespace string) (*EventWatcher, error) {
+	watcher, err := suite.Clientset.CoreV1().Events(namespace).Watch(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start event watch")
+	}
+
+	ew := &EventWatcher{watcher: watcher, stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(ew.done)
+		for {
+			select {
+			case <-ew.stop:
+				return
+			case evt, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				if event, ok := evt.Object.(*corev1.Event); ok {
+					ew.events = append(ew.events, *event)
+				}
+			}
+		}
+	}()
+	return ew, nil
+}
+
+// Stop ends the watch and returns every Event observed since WatchEvents
+// was called.
+func (ew *EventWatcher) Stop() []corev1.Event {
+	close(ew.stop)
+	ew.watcher.Stop()
+	<-ew.done
+	return ew.events
+}
+
+// ExpectNoWarningEvents fails (by returning an error) if namespace has any
+// Warning event with a LastTimestamp at or after since.
+func (suite *K8sTestSuite) ExpectNoWarningEvents(namespace string, since time.Time) error {
+	events, err := suite.Clientset.CoreV1().Events(namespace).List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list events in namespace %s", namespace)
+	}
+
+	var violations []string
+	for _, event := range events.Items {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		if event.LastTimestamp.Time.Before(since) {
+			continue
+		}
+		violations = append(violations, event.Namespace+"/"+event.Name+": "+event.Reason+" -- "+event.Message)
+	}
+	if len(violations) > 0 {
+		return errors.Errorf("unexpected warning event(s) in namespace %s since %s: %s", namespace, since, joinStrings(violations))
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pkg/errors"
+)
+
+// ChaosFaultType names a native (Chaos-Mesh-free) fault RunChaosExperiment
+// can inject directly through the Kubernetes API.
+type ChaosFaultType string
+
+const (
+	ChaosFaultPodKill          ChaosFaultType = "pod-kill"
+	ChaosFaultPodFailure       ChaosFaultType = "pod-failure"
+	ChaosFaultNetworkPartition ChaosFaultType = "network-partition"
+	ChaosFaultCPUStress        ChaosFaultType = "cpu-stress"
+	ChaosFaultMemoryStress     ChaosFaultType = "memory-stress"
+	ChaosFaultNodeDrain        ChaosFaultType = "node-drain"
+)
+
+// ChaosSpec describes one native fault-injection experiment for
+// RunChaosExperiment.
+type ChaosSpec struct {
+	Type          ChaosFaultType
+	Namespace     string
+	Selector      map[string]string
+	Count         int           // pods to affect for pod-kill/pod-failure/stress
+	InvalidImage  string        // image substituted in for pod-failure
+	FaultDuration time.Duration // how long the fault stays active before automatic rollback
+	NodeName      string        // target node for node-drain
+	// SteadyState is evaluated before the fault is injected, repeatedly while
+	// it is active, and once more after rollback. It should return an error
+	// describing what broke, or nil if the system is healthy.
+	SteadyState func() error
+}
+
+// ChaosTimelineEvent is one entry in the timeline RunChaosExperiment
+// returns, recording what happened and when.
+type ChaosTimelineEvent struct {
+	Time   time.Time
+	Phase  string
+	Detail string
+}
+
+// RunChaosExperiment injects spec's fault, checks spec.SteadyState before,
+// during, and after, and always rolls the fault back -- via t.Cleanup so
+// rollback still runs if the steady-state check fails the test outright.
+// It returns the recorded timeline regardless of whether the steady-state
+// hypothesis held.
+func (suite *K8sTestSuite) RunChaosExperiment(t *testing.T, spec ChaosSpec) ([]ChaosTimelineEvent, error) {
+	var timeline []ChaosTimelineEvent
+	record := func(phase, detail string) {
+		timeline = append(timeline, ChaosTimelineEvent{Time: time.Now(), Phase: phase, Detail: detail})
+	}
+
+	if spec.SteadyState != nil {
+		if err := spec.SteadyState(); err != nil {
+			return timeline, errors.Wrap(err, "steady state hypothesis does not hold before fault injection")
+		}
+		record("before", "steady state holds")
+	}
+
+	rollback, err := suite.injectChaosFault(spec, record)
+	if err != nil {
+		return timeline, errors.Wrapf(err, "failed to inject %s fault", spec.Type)
+	}
+	t.Cleanup(func() {
+		if err := rollback(); err != nil {
+			suite.Logger.Warn().Err(err).Str("fault", string(spec.Type)).Msg("failed to roll back chaos fault")
+		} else {
+			record("reverted", "fault rolled back")
+		}
+	})
+
+	if spec.SteadyState != nil {
+		deadline := time.Now().Add(spec.FaultDuration)
+		for time.Now().Before(deadline) {
+			if err := spec.SteadyState(); err != nil {
+				return timeline, errors.Wrap(err, "steady state hypothesis violated during fault injection")
+			}
+			time.Sleep(2 * time.Second)
+		}
+		record("during", "steady state held throughout fault injection")
+	} else {
+		time.Sleep(spec.FaultDuration)
+	}
+
+	if err := rollback(); err != nil {
+		return timeline, errors.Wrapf(err, "failed to roll back %s fault", spec.Type)
+	}
+	record("reverted", "fault rolled back")
+
+	if spec.SteadyState != nil {
+		if err := spec.SteadyState(); err != nil {
+			return timeline, errors.Wrap(err, "steady state hypothesis does not hold after rollback")
+		}
+		record("after", "steady state holds")
+	}
+
+	return timeline, nil
+}
+
+// injectChaosFault dispatches on spec.Type, injects the fault, and returns a
+// rollback function that undoes it. It is safe to call rollback more than
+// once.
+func (suite *K8sTestSuite) injectChaosFault(spec ChaosSpec, record func(phase, detail string)) (func() error, error) {
+	switch spec.Type {
+	case ChaosFaultPodKill:
+		return suite.injectPodKill(spec, record)
+	case ChaosFaultPodFailure:
+		return suite.injectPodFailure(spec, record)
+	case ChaosFaultNetworkPartition:
+		return suite.injectNetworkPartition(spec, record)
+	case ChaosFaultCPUStress:
+		return suite.injectStress(spec, record, "stress-ng", "--cpu", fmt.Sprintf("%d", maxInt(spec.Count, 1)))
+	case ChaosFaultMemoryStress:
+		return suite.injectStress(spec, record, "stress-ng", "--vm", fmt.Sprintf("%d", maxInt(spec.Count, 1)), "--vm-bytes", "256M")
+	case ChaosFaultNodeDrain:
+		return suite.injectNodeDrain(spec, record)
+	default:
+		return nil, errors.Errorf("unknown chaos fault type %s", spec.Type)
+	}
+}
+
+// injectPodKill deletes up to spec.Count randomly chosen pods matching
+// spec.Selector. Pods are recreated by their owning controller, so rollback
+// is a no-op.
+func (suite *K8sTestSuite) injectPodKill(spec ChaosSpec, record func(phase, detail string)) (func() error, error) {
+	pods, err := suite.Clientset.CoreV1().Pods(spec.Namespace).List(suite.Context, metav1.ListOptions{LabelSelector: labelSelectorString(spec.Selector)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods for pod-kill")
+	}
+	targets := pickRandom(pods.Items, maxInt(spec.Count, 1))
+	for _, pod := range targets {
+		if err := suite.Clientset.CoreV1().Pods(spec.Namespace).Delete(suite.Context, pod.Name, metav1.DeleteOptions{}); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete pod %s", pod.Name)
+		}
+	}
+	record("fault-injected", fmt.Sprintf("killed %d pod(s)", len(targets)))
+	return func() error { return nil }, nil
+}
+
+// injectPodFailure patches the first container's image on up to spec.Count
+// pods matching spec.Selector to spec.InvalidImage, recording the originals
+// so rollback can restore them.
+func (suite *K8sTestSuite) injectPodFailure(spec ChaosSpec, record func(phase, detail string)) (func() error, error) {
+	pods, err := suite.Clientset.CoreV1().Pods(spec.Namespace).List(suite.Context, metav1.ListOptions{LabelSelector: labelSelectorString(spec.Selector)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods for pod-failure")
+	}
+	targets := pickRandom(pods.Items, maxInt(spec.Count, 1))
+
+	originals := make(map[string]string, len(targets))
+	for _, pod := range targets {
+		if len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		originals[pod.Name] = pod.Spec.Containers[0].Image
+		pod.Spec.Containers[0].Image = spec.InvalidImage
+		if _, err := suite.Clientset.CoreV1().Pods(spec.Namespace).Update(suite.Context, &pod, metav1.UpdateOptions{}); err != nil {
+			return nil, errors.Wrapf(err, "failed to patch pod %s to invalid image", pod.Name)
+		}
+	}
+	record("fault-injected", fmt.Sprintf("patched %d pod(s) to invalid image %s", len(originals), spec.InvalidImage))
+
+	return func() error {
+		for name, image := range originals {
+			pod, err := suite.Clientset.CoreV1().Pods(spec.Namespace).Get(suite.Context, name, metav1.GetOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "failed to fetch pod %s to revert image", name)
+			}
+			pod.Spec.Containers[0].Image = image
+			if _, err := suite.Clientset.CoreV1().Pods(spec.Namespace).Update(suite.Context, pod, metav1.UpdateOptions{}); err != nil {
+				return errors.Wrapf(err, "failed to revert pod %s image", name)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// injectNetworkPartition applies a deny-all NetworkPolicy scoped to
+// spec.Selector within spec.Namespace.
+func (suite *K8sTestSuite) injectNetworkPartition(spec ChaosSpec, record func(phase, detail string)) (func() error, error) {
+	name := "chaos-partition-" + suite.TestID
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: spec.Namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: spec.Selector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+	if _, err := suite.Clientset.NetworkingV1().NetworkPolicies(spec.Namespace).Create(suite.Context, policy, metav1.CreateOptions{}); err != nil {
+		return nil, errors.Wrap(err, "failed to apply network-partition NetworkPolicy")
+	}
+	record("fault-injected", "applied deny-all NetworkPolicy "+name)
+
+	return func() error {
+		err := suite.Clientset.NetworkingV1().NetworkPolicies(spec.Namespace).Delete(suite.Context, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "failed to remove network-partition NetworkPolicy")
+		}
+		return nil
+	}, nil
+}
+
+// injectStress adds an ephemeral debug container running command/args into
+// up to spec.Count pods matching spec.Selector, so CPU/memory load runs
+// inside the target Pod's cgroup without touching its primary containers.
+func (suite *K8sTestSuite) injectStress(spec ChaosSpec, record func(phase, detail string), image string, command string, args ...string) (func() error, error) {
+	pods, err := suite.Clientset.CoreV1().Pods(spec.Namespace).List(suite.Context, metav1.ListOptions{LabelSelector: labelSelectorString(spec.Selector)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pods for stress injection")
+	}
+	targets := pickRandom(pods.Items, maxInt(spec.Count, 1))
+
+	containerName := "chaos-stress-" + suite.TestID
+	for _, pod := range targets {
+		ephemeral := corev1.EphemeralContainer{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+				Name:    containerName,
+				Image:   "alexeiled/" + image,
+				Command: []string{command},
+				Args:    args,
+			},
+		}
+		updated := pod.DeepCopy()
+		updated.Spec.EphemeralContainers = append(updated.Spec.EphemeralContainers, ephemeral)
+		if _, err := suite.Clientset.CoreV1().Pods(spec.Namespace).UpdateEphemeralContainers(suite.Context, pod.Name, updated, metav1.UpdateOptions{}); err != nil {
+			return nil, errors.Wrapf(err, "failed to inject stress ephemeral container into pod %s", pod.Name)
+		}
+	}
+	record("fault-injected", fmt.Sprintf("injected %s ephemeral container into %d pod(s)", image, len(targets)))
+
+	return func() error {
+		return nil
+	}, nil
+}
+
+// injectNodeDrain cordons spec.NodeName and evicts every pod scheduled to
+// it, then rolls back by uncordoning (evicted pods are rescheduled by their
+// controllers, not recreated here).
+func (suite *K8sTestSuite) injectNodeDrain(spec ChaosSpec, record func(phase, detail string)) (func() error, error) {
+	node, err := suite.Clientset.CoreV1().Nodes().Get(suite.Context, spec.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch node %s", spec.NodeName)
+	}
+	node.Spec.Unschedulable = true
+	if _, err := suite.Clientset.CoreV1().Nodes().Update(suite.Context, node, metav1.UpdateOptions{}); err != nil {
+		return nil, errors.Wrapf(err, "failed to cordon node %s", spec.NodeName)
+	}
+
+	pods, err := suite.Clientset.CoreV1().Pods("").List(suite.Context, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + spec.NodeName,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods on node %s", spec.NodeName)
+	}
+	evicted := 0
+	for _, pod := range pods.Items {
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := suite.Clientset.PolicyV1().Evictions(pod.Namespace).Evict(suite.Context, eviction); err != nil {
+			suite.Logger.Warn().Err(err).Str("pod", pod.Name).Msg("failed to evict pod during node-drain")
+			continue
+		}
+		evicted++
+	}
+	record("fault-injected", fmt.Sprintf("cordoned node %s and evicted %d pod(s)", spec.NodeName, evicted))
+
+	return func() error {
+		current, err := suite.Clientset.CoreV1().Nodes().Get(suite.Context, spec.NodeName, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch node %s to uncordon", spec.NodeName)
+		}
+		current.Spec.Unschedulable = false
+		if _, err := suite.Clientset.CoreV1().Nodes().Update(suite.Context, current, metav1.UpdateOptions{}); err != nil {
+			return errors.Wrapf(err, "failed to uncordon node %s", spec.NodeName)
+		}
+		return nil
+	}, nil
+}
+
+func labelSelectorString(selector map[string]string) string {
+	out := ""
+	for k, v := range selector {
+		if out != "" {
+			out += ","
+		}
+		out += k + "=" + v
+	}
+	return out
+}
+
+func pickRandom(pods []corev1.Pod, count int) []corev1.Pod {
+	if count >= len(pods) {
+		return pods
+	}
+	shuffled := make([]corev1.Pod, len(pods))
+	copy(shuffled, pods)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled[:count]
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,306 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/pkg/errors"
+)
+
+// chaosAllowedLabel is the namespace label RunChaosScenario requires before
+// it will apply any experiment, so chaos can't accidentally run against a
+// namespace nobody opted in.
+const chaosAllowedLabel = "chaos-allowed"
+
+// chaosMeshGVRs maps each ChaosExperiment's Chaos Mesh custom resource kind
+// to its GroupVersionResource.
+var chaosMeshGVRs = map[string]schema.GroupVersionResource{
+	"PodChaos":     {Group: "chaos-mesh.org", Version: "v1alpha1", Resource: "podchaos"},
+	"NetworkChaos": {Group: "chaos-mesh.org", Version: "v1alpha1", Resource: "networkchaos"},
+	"StressChaos":  {Group: "chaos-mesh.org", Version: "v1alpha1", Resource: "stresschaos"},
+	"IOChaos":      {Group: "chaos-mesh.org", Version: "v1alpha1", Resource: "iochaos"},
+}
+
+// ChaosExperiment renders itself as a Chaos Mesh custom resource.
+type ChaosExperiment interface {
+	// Name identifies the experiment within a ChaosPlan.
+	Name() string
+	// Kind is the Chaos Mesh CR kind this experiment applies, a key into
+	// chaosMeshGVRs.
+	Kind() string
+	// Manifest builds the unstructured Chaos Mesh CR, scoped to namespace
+	// and selecting the given label selector.
+	Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured
+}
+
+// PodKill kills pods matching the selector.
+type PodKill struct{}
+
+func (PodKill) Name() string { return "pod-kill" }
+func (PodKill) Kind() string { return "PodChaos" }
+func (PodKill) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("PodChaos", name, namespace, selector, map[string]interface{}{
+		"action": "pod-kill",
+		"mode":   "one",
+	})
+}
+
+// NetworkLatency injects latency on pods matching the selector.
+type NetworkLatency struct {
+	Latency string // e.g. "100ms"
+}
+
+func (NetworkLatency) Name() string { return "network-latency" }
+func (NetworkLatency) Kind() string { return "NetworkChaos" }
+func (e NetworkLatency) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("NetworkChaos", name, namespace, selector, map[string]interface{}{
+		"action":   "delay",
+		"mode":     "all",
+		"delay":    map[string]interface{}{"latency": e.Latency},
+		"duration": duration.String(),
+	})
+}
+
+// NetworkPartition partitions pods matching the selector from the rest of
+// the cluster.
+type NetworkPartition struct{}
+
+func (NetworkPartition) Name() string { return "network-partition" }
+func (NetworkPartition) Kind() string { return "NetworkChaos" }
+func (NetworkPartition) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("NetworkChaos", name, namespace, selector, map[string]interface{}{
+		"action":    "partition",
+		"mode":      "all",
+		"direction": "both",
+		"duration":  duration.String(),
+	})
+}
+
+// CPUStress stresses CPU on pods matching the selector.
+type CPUStress struct {
+	Workers int
+}
+
+func (CPUStress) Name() string { return "cpu-stress" }
+func (CPUStress) Kind() string { return "StressChaos" }
+func (e CPUStress) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("StressChaos", name, namespace, selector, map[string]interface{}{
+		"mode": "all",
+		"stressors": map[string]interface{}{
+			"cpu": map[string]interface{}{"workers": e.Workers},
+		},
+		"duration": duration.String(),
+	})
+}
+
+// MemoryStress stresses memory on pods matching the selector.
+type MemoryStress struct {
+	Workers int
+	Size    string // e.g. "256MB"
+}
+
+func (MemoryStress) Name() string { return "memory-stress" }
+func (MemoryStress) Kind() string { return "StressChaos" }
+func (e MemoryStress) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("StressChaos", name, namespace, selector, map[string]interface{}{
+		"mode": "all",
+		"stressors": map[string]interface{}{
+			"memory": map[string]interface{}{"workers": e.Workers, "size": e.Size},
+		},
+		"duration": duration.String(),
+	})
+}
+
+// DiskFill fills disk on pods matching the selector.
+type DiskFill struct {
+	FillPercent int
+	VolumePath  string
+}
+
+func (DiskFill) Name() string { return "disk-fill" }
+func (DiskFill) Kind() string { return "IOChaos" }
+func (e DiskFill) Manifest(name, namespace string, selector map[string]string, duration time.Duration) *unstructured.Unstructured {
+	return chaosManifest("IOChaos", name, namespace, selector, map[string]interface{}{
+		"action":     "fault",
+		"mode":       "all",
+		"volumePath": e.VolumePath,
+		"percent":    e.FillPercent,
+		"duration":   duration.String(),
+	})
+}
+
+// chaosManifest builds the common Chaos Mesh CR envelope for kind, scoped to
+// namespace, selecting selector, with spec merged in.
+func chaosManifest(kind, name, namespace string, selector map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	merged := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"namespaces":     []interface{}{namespace},
+			"labelSelectors": toInterfaceMap(selector),
+		},
+	}
+	for k, v := range spec {
+		merged[k] = v
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "chaos-mesh.org/v1alpha1",
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": merged,
+	}}
+}
+
+func toInterfaceMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// SteadyStateHypothesis asserts the target Deployment keeps at least
+// MinReadyReplicas ready replicas throughout a chaos experiment.
+type SteadyStateHypothesis struct {
+	DeploymentName   string
+	Namespace        string
+	MinReadyReplicas int32
+}
+
+// holds reports whether the hypothesis currently holds.
+func (h SteadyStateHypothesis) holds(suite *K8sTestSuite) (bool, error) {
+	dep, err := suite.Clientset.AppsV1().Deployments(h.Namespace).Get(suite.Context, h.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return dep.Status.ReadyReplicas >= h.MinReadyReplicas, nil
+}
+
+// ChaosScenario is one experiment run end-to-end by RunChaosScenario.
+type ChaosScenario struct {
+	Experiment      ChaosExperiment
+	Namespace       string
+	Selector        map[string]string
+	Duration        time.Duration
+	RecoveryTimeout time.Duration
+	SteadyState     SteadyStateHypothesis
+}
+
+// ChaosExecutionMode controls how a ChaosPlan's scenarios are run.
+type ChaosExecutionMode string
+
+const (
+	ChaosSequential ChaosExecutionMode = "sequential"
+	ChaosParallel   ChaosExecutionMode = "parallel"
+)
+
+// ChaosPlan is a set of scenarios run together under a single execution
+// mode.
+type ChaosPlan struct {
+	Scenarios []ChaosScenario
+	Mode      ChaosExecutionMode
+}
+
+// RunChaosPlan runs every scenario in plan, sequentially or in parallel per
+// plan.Mode, and returns the first error encountered (for parallel mode,
+// the first error returned by any goroutine).
+func (suite *K8sTestSuite) RunChaosPlan(t *testing.T, plan ChaosPlan) error {
+	if plan.Mode == ChaosParallel {
+		var wg sync.WaitGroup
+		errs := make([]error, len(plan.Scenarios))
+		for i, scenario := range plan.Scenarios {
+			wg.Add(1)
+			go func(i int, scenario ChaosScenario) {
+				defer wg.Done()
+				errs[i] = suite.RunChaosScenario(t, scenario)
+			}(i, scenario)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, scenario := range plan.Scenarios {
+		if err := suite.RunChaosScenario(t, scenario); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunChaosScenario snapshots the steady-state hypothesis, applies
+// scenario.Experiment for scenario.Duration, polls the hypothesis for
+// continued availability during the experiment, tears the experiment down,
+// and asserts recovery within scenario.RecoveryTimeout. It refuses to run
+// if scenario.Namespace lacks the chaos-allowed=true label.
+func (suite *K8sTestSuite) RunChaosScenario(t *testing.T, scenario ChaosScenario) error {
+	ns, err := suite.Clientset.CoreV1().Namespaces().Get(suite.Context, scenario.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch namespace %s", scenario.Namespace)
+	}
+	if ns.Labels[chaosAllowedLabel] != "true" {
+		return errors.Errorf("namespace %s is missing the %s=true label -- refusing to run chaos experiments", scenario.Namespace, chaosAllowedLabel)
+	}
+
+	if ok, err := scenario.SteadyState.holds(suite); err != nil {
+		return errors.Wrap(err, "failed to snapshot baseline steady state")
+	} else if !ok {
+		return errors.Errorf("baseline steady state hypothesis does not hold before experiment %s", scenario.Experiment.Name())
+	}
+
+	if suite.DynamicClient == nil {
+		return errors.New("RunChaosScenario requires a DynamicClient -- NewK8sTestSuite failed to build one")
+	}
+	gvr, ok := chaosMeshGVRs[scenario.Experiment.Kind()]
+	if !ok {
+		return errors.Errorf("unknown Chaos Mesh kind %s for experiment %s", scenario.Experiment.Kind(), scenario.Experiment.Name())
+	}
+
+	manifest := scenario.Experiment.Manifest(
+		fmt.Sprintf("%s-%s", scenario.Experiment.Name(), suite.TestID), scenario.Namespace, scenario.Selector, scenario.Duration)
+
+	if _, err := suite.DynamicClient.Resource(gvr).Namespace(scenario.Namespace).Create(suite.Context, manifest, metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to apply %s experiment", scenario.Experiment.Name())
+	}
+	defer func() {
+		if err := suite.DynamicClient.Resource(gvr).Namespace(scenario.Namespace).Delete(suite.Context, manifest.GetName(), metav1.DeleteOptions{}); err != nil {
+			suite.Logger.Warn().Err(err).Str("experiment", scenario.Experiment.Name()).Msg("failed to tear down chaos experiment")
+		}
+	}()
+
+	deadline := time.Now().Add(scenario.Duration)
+	for time.Now().Before(deadline) {
+		if ok, err := scenario.SteadyState.holds(suite); err != nil {
+			return errors.Wrap(err, "failed to evaluate steady state during experiment")
+		} else if !ok {
+			return errors.Errorf("steady state hypothesis violated during experiment %s", scenario.Experiment.Name())
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	recoveryDeadline := time.Now().Add(scenario.RecoveryTimeout)
+	for {
+		ok, err := scenario.SteadyState.holds(suite)
+		if err != nil {
+			return errors.Wrap(err, "failed to evaluate steady state during recovery")
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(recoveryDeadline) {
+			return errors.Errorf("system did not recover from experiment %s within %s", scenario.Experiment.Name(), scenario.RecoveryTimeout)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
@@ -0,0 +1,181 @@
+package test
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/pkg/errors"
+)
+
+// helmHookTestAnnotation/helmHookTestValue mark a rendered Pod/Job as a Helm
+// release test hook -- see https://helm.sh/docs/topics/charts_hooks/.
+const (
+	helmHookTestAnnotation = "helm.sh/hook"
+	helmHookTestValue      = "test"
+)
+
+// helmHookPollTimeout bounds how long a helm test hook is given to reach a
+// terminal phase before LoadHelmManifest's auto-registered test gives up.
+const helmHookPollTimeout = 5 * time.Minute
+
+// LoadHelmManifest renders chartRef (a local chart path or an OCI reference,
+// e.g. "oci://registry.example.com/charts/app") with values via the `helm
+// template` CLI and populates a K8sTestManifest from the rendered output.
+// Every rendered object annotated helm.sh/hook: test (Helm's release-testing
+// convention) is auto-registered as a K8sResourceTest asserting the Pod's
+// terminal phase, so chart-shipped tests and ad-hoc manifests run through
+// the same ApplyTestManifest/RunTestManifest API.
+func (suite *K8sTestSuite) LoadHelmManifest(ctx context.Context, chartRef, releaseName string, values map[string]string) (*K8sTestManifest, error) {
+	rendered, err := helmTemplate(ctx, chartRef, releaseName, suite.Namespace, values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render helm chart %s", chartRef)
+	}
+
+	manifest, err := parseTestManifestDocuments(rendered)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse rendered helm chart %s", chartRef)
+	}
+	manifest.Name = releaseName
+	manifest.Tests = append(manifest.Tests, helmHookTests(manifest.Resources)...)
+	return manifest, nil
+}
+
+// helmTemplate shells out to `helm template`, the same approach
+// k8s_image_scan_test.go takes for Trivy, rather than vendoring Helm's Go
+// SDK -- rendering then always matches whatever helm binary CI has
+// installed.
+func helmTemplate(ctx context.Context, chartRef, releaseName, namespace string, values map[string]string) (string, error) {
+	args := []string{"template", releaseName, chartRef, "--namespace", namespace}
+	for key, value := range values {
+		args = append(args, "--set", key+"="+value)
+	}
+
+	cmd := exec.CommandContext(ctx, "helm", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", errors.Wrapf(err, "helm template failed: %s", string(output))
+	}
+	return string(output), nil
+}
+
+// helmHookTests scans resources for the helm.sh/hook: test annotation and
+// returns one K8sResourceTest per hook Pod/Job, using AssertType
+// "helmHookPhase" to assert the hook's terminal phase.
+func helmHookTests(resources []K8sResource) []K8sResourceTest {
+	var tests []K8sResourceTest
+	for _, resource := range resources {
+		if resource.Metadata.Annotations[helmHookTestAnnotation] != helmHookTestValue {
+			continue
+		}
+		if resource.Kind != "Pod" && resource.Kind != "Job" {
+			continue
+		}
+
+		tests = append(tests, K8sResourceTest{
+			Name:        "helm-test-hook/" + resource.Metadata.Name,
+			Type:        resource.Kind,
+			Namespace:   resource.Metadata.Namespace,
+			Resource:    resource.Metadata.Name,
+			AssertType:  "helmHookPhase",
+			PollTimeout: helmHookPollTimeout,
+		})
+	}
+	return tests
+}
+
+// runHelmHookPhaseTest polls the hook's Pod (resolved directly for a Pod
+// hook, or via its job-name label for a Job hook) until it reaches phase
+// Succeeded or Failed, capturing the Pod's logs into the suite logger either
+// way -- mirroring `helm test --logs`.
+func (suite *K8sTestSuite) runHelmHookPhaseTest(test K8sResourceTest) error {
+	interval := test.PollInterval
+	if interval <= 0 {
+		interval = defaultAssertPollInterval
+	}
+	timeout := test.PollTimeout
+	if timeout <= 0 {
+		timeout = helmHookPollTimeout
+	}
+
+	var lastPod *corev1.Pod
+	pollErr := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		pod, err := suite.podForHelmHook(test)
+		if err != nil {
+			return false, nil
+		}
+		lastPod = pod
+
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, errors.Errorf("helm test hook pod %s failed", pod.Name)
+		default:
+			return false, nil
+		}
+	})
+
+	if lastPod != nil {
+		suite.logHelmHookPodLogs(lastPod)
+	}
+	if pollErr != nil {
+		return errors.Wrapf(pollErr, "helm test hook %s did not succeed within %s", test.Name, timeout)
+	}
+	return nil
+}
+
+// podForHelmHook resolves the Pod backing a helm test hook: directly by name
+// for a Pod hook, or by job-name label for a Job hook (reporting its most
+// recently created Pod).
+func (suite *K8sTestSuite) podForHelmHook(test K8sResourceTest) (*corev1.Pod, error) {
+	namespace := test.Namespace
+	if namespace == "" {
+		namespace = suite.Namespace
+	}
+
+	if test.Type == "Pod" {
+		return suite.Clientset.CoreV1().Pods(namespace).Get(suite.Context, test.Resource, metav1.GetOptions{})
+	}
+
+	pods, err := suite.Clientset.CoreV1().Pods(namespace).List(suite.Context, metav1.ListOptions{
+		LabelSelector: "job-name=" + test.Resource,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, errors.Errorf("no pods found for job %s", test.Resource)
+	}
+
+	latest := pods.Items[0]
+	for _, pod := range pods.Items[1:] {
+		if pod.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = pod
+		}
+	}
+	return &latest, nil
+}
+
+// logHelmHookPodLogs captures pod's container logs into the suite logger.
+func (suite *K8sTestSuite) logHelmHookPodLogs(pod *corev1.Pod) {
+	stream, err := suite.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).Stream(suite.Context)
+	if err != nil {
+		suite.Logger.Warn().Err(err).Str("pod", pod.Name).Msg("failed to fetch helm test hook logs")
+		return
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		suite.Logger.Warn().Err(err).Str("pod", pod.Name).Msg("failed to read helm test hook logs")
+		return
+	}
+	suite.Logger.Info().Str("pod", pod.Name).Str("phase", string(pod.Status.Phase)).
+		Str("logs", string(logs)).Msg("helm test hook logs")
+}
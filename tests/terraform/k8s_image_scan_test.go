@@ -0,0 +1,242 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Severity is a CVE severity bucket, as reported by Trivy's JSON output.
+type Severity string
+
+const (
+	SeverityCritical Severity = "CRITICAL"
+	SeverityHigh     Severity = "HIGH"
+	SeverityMedium   Severity = "MEDIUM"
+	SeverityLow      Severity = "LOW"
+)
+
+// ScanReport is the result of an ImageScanner.Scan: per-severity counts plus
+// the CVE IDs found, so callers can both gate on counts and log the detail.
+type ScanReport struct {
+	Image  string
+	Counts map[Severity]int
+	CVEIDs []string
+}
+
+// ImageScanner scans a container image reference and reports its known
+// vulnerabilities.
+type ImageScanner interface {
+	Scan(ctx context.Context, image string) (ScanReport, error)
+}
+
+// imageScanCache memoizes ScanReports by image digest so parallel subtests
+// scanning the same image don't each trigger their own pull.
+type imageScanCache struct {
+	mu    sync.Mutex
+	byRef map[string]ScanReport
+}
+
+var sharedImageScanCache = &imageScanCache{byRef: map[string]ScanReport{}}
+
+func (c *imageScanCache) scan(ctx context.Context, scanner ImageScanner, image string) (ScanReport, error) {
+	c.mu.Lock()
+	if report, ok := c.byRef[image]; ok {
+		c.mu.Unlock()
+		return report, nil
+	}
+	c.mu.Unlock()
+
+	report, err := scanner.Scan(ctx, image)
+	if err != nil {
+		return ScanReport{}, err
+	}
+
+	c.mu.Lock()
+	c.byRef[image] = report
+	c.mu.Unlock()
+	return report, nil
+}
+
+// TrivyScanner shells out to a local `trivy` binary to scan images. It is
+// the default ImageScanner for TestDeployments' ScanVulnerabilities check.
+type TrivyScanner struct{}
+
+// trivyResult is the subset of `trivy image --format json` this package
+// reads.
+type trivyResult struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// Scan runs `trivy image --format json --severity HIGH,CRITICAL` against
+// image and parses the result into a ScanReport.
+func (TrivyScanner) Scan(ctx context.Context, image string) (ScanReport, error) {
+	cmd := exec.CommandContext(ctx, "trivy", "image", "--format", "json", "--severity", "HIGH,CRITICAL", image)
+	out, err := cmd.Output()
+	if err != nil {
+		return ScanReport{}, errors.Wrapf(err, "trivy scan of %s failed", image)
+	}
+
+	var result trivyResult
+	if err := json.Unmarshal(out, &result); err != nil {
+		return ScanReport{}, errors.Wrapf(err, "failed to parse trivy output for %s", image)
+	}
+
+	report := ScanReport{Image: image, Counts: map[Severity]int{}}
+	for _, r := range result.Results {
+		for _, v := range r.Vulnerabilities {
+			severity := Severity(v.Severity)
+			report.Counts[severity]++
+			report.CVEIDs = append(report.CVEIDs, v.VulnerabilityID)
+		}
+	}
+	return report, nil
+}
+
+// OCIRegistryScanner doesn't scan for vulnerabilities -- it answers the
+// registry-trust and tag-resolution questions TestDeployments' Deployment
+// Security subtest needs (TrustedRegistry, NoLatestTag) by talking to the
+// registry's manifest API directly.
+type OCIRegistryScanner struct {
+	Client *http.Client
+}
+
+// Scan always returns an empty report; OCIRegistryScanner only implements
+// ImageScanner so it can share the ImageScanner-typed call sites with
+// TrivyScanner, it does not itself find vulnerabilities.
+func (s OCIRegistryScanner) Scan(ctx context.Context, image string) (ScanReport, error) {
+	return ScanReport{Image: image, Counts: map[Severity]int{}}, nil
+}
+
+// ResolveDigest resolves image's tag to a content digest via the registry's
+// v2 manifest API (HEAD request, reading Docker-Content-Digest), so a
+// pinned `repo@sha256:...` reference can be checked for NoLatestTag even
+// without a semver tag.
+func (s OCIRegistryScanner) ResolveDigest(ctx context.Context, image string) (string, error) {
+	if idx := strings.Index(image, "@sha256:"); idx != -1 {
+		return image[idx+1:], nil
+	}
+
+	host, repo, tag := splitImageReference(image)
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repo, tag)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build manifest request for %s", image)
+	}
+	req.Header.Set("Accept", "application/vnd.docker.distribution.manifest.v2+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to reach registry for %s", image)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("registry returned %s resolving digest for %s", resp.Status, image)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", errors.Errorf("registry response for %s has no Docker-Content-Digest header", image)
+	}
+	return digest, nil
+}
+
+// RegistryHost returns the registry host component of image, defaulting to
+// Docker Hub's registry when the reference has no explicit host.
+func RegistryHost(image string) string {
+	host, _, _ := splitImageReference(image)
+	return host
+}
+
+// splitImageReference splits image ("host/repo:tag" or "repo:tag" or
+// "repo@sha256:...") into its host, repository path, and tag (or digest).
+func splitImageReference(image string) (host, repo, tag string) {
+	ref := image
+	digestOrTag := "latest"
+	if idx := strings.LastIndex(ref, "@"); idx != -1 {
+		digestOrTag = ref[idx+1:]
+		ref = ref[:idx]
+	} else if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		digestOrTag = ref[idx+1:]
+		ref = ref[:idx]
+	}
+
+	firstSlash := strings.Index(ref, "/")
+	if firstSlash == -1 {
+		return "registry-1.docker.io", "library/" + ref, digestOrTag
+	}
+	candidateHost := ref[:firstSlash]
+	if strings.Contains(candidateHost, ".") || strings.Contains(candidateHost, ":") || candidateHost == "localhost" {
+		return candidateHost, ref[firstSlash+1:], digestOrTag
+	}
+	return "registry-1.docker.io", ref, digestOrTag
+}
+
+// isTrustedRegistry reports whether image's registry host is in allowed.
+func isTrustedRegistry(image string, allowed []string) bool {
+	host := RegistryHost(image)
+	for _, a := range allowed {
+		if a == host {
+			return true
+		}
+	}
+	return false
+}
+
+// isPinnedOrTaggedImage reports whether image is safe under NoLatestTag: it
+// is pinned to a digest, or its tag resolves to something other than
+// "latest" (an explicit "latest" tag fails even if the resolution below
+// would succeed).
+func isPinnedOrTaggedImage(image string) bool {
+	if strings.Contains(image, "@sha256:") {
+		return true
+	}
+	_, _, tag := splitImageReference(image)
+	return tag != "latest"
+}
+
+// assertImageSecurity enforces ImageSecurityTest against image, using
+// scanner for ScanVulnerabilities and registry for TrustedRegistry/
+// NoLatestTag, and returns every violation found.
+func assertImageSecurity(ctx context.Context, image string, test ImageSecurityTest, scanner ImageScanner, registry OCIRegistryScanner, maxAllowedCVEs int) []string {
+	var violations []string
+
+	if test.NoLatestTag && !isPinnedOrTaggedImage(image) {
+		violations = append(violations, "image "+image+" must not use the latest tag")
+	}
+
+	if test.TrustedRegistry && !isTrustedRegistry(image, test.AllowedRegistries) {
+		violations = append(violations, "image "+image+" is not hosted on an allowed registry: "+strings.Join(test.AllowedRegistries, ", "))
+	}
+
+	if test.ScanVulnerabilities {
+		report, err := sharedImageScanCache.scan(ctx, scanner, image)
+		if err != nil {
+			violations = append(violations, errors.Wrapf(err, "failed to scan %s", image).Error())
+		} else {
+			critical := report.Counts[SeverityCritical] + report.Counts[SeverityHigh]
+			if critical > maxAllowedCVEs {
+				violations = append(violations, fmt.Sprintf("image %s has %d critical/high CVEs, exceeding the allowed maximum of %d: %s",
+					image, critical, maxAllowedCVEs, strings.Join(report.CVEIDs, ", ")))
+			}
+		}
+	}
+
+	return violations
+}
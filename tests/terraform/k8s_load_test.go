@@ -0,0 +1,378 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/montanaflynn/stats"
+	"github.com/pkg/errors"
+)
+
+// k6LoadGeneratorImage and ghzLoadGeneratorImage are the images RunLoadTest
+// runs in-cluster, chosen by LoadTest.TargetURL's scheme.
+const (
+	k6LoadGeneratorImage  = "grafana/k6:latest"
+	ghzLoadGeneratorImage = "ghcr.io/bojand/ghz:latest"
+)
+
+// loadMetricsSampleInterval is how often RunLoadTest samples HPA and pod
+// CPU/memory while a load test runs.
+const loadMetricsSampleInterval = 5 * time.Second
+
+// ScalingSample is one HPA/pod-resource snapshot taken during a LoadTest
+// run.
+type ScalingSample struct {
+	At         time.Time
+	Replicas   int32
+	DesiredCPU int32 // HPA's current average CPU utilization, percent
+	TotalCPUm  int64 // summed pod CPU usage across the target, millicores
+	TotalMemB  int64 // summed pod memory usage across the target, bytes
+}
+
+// LoadResult is RunLoadTest's report: latency percentiles, achieved RPS,
+// error rate, a status-code histogram, and the scaling samples taken during
+// the run so ScalingTest assertions can be made against them.
+type LoadResult struct {
+	P50Ms          float64         `json:"p50_ms"`
+	P95Ms          float64         `json:"p95_ms"`
+	P99Ms          float64         `json:"p99_ms"`
+	AchievedRPS    float64         `json:"achieved_rps"`
+	ErrorRate      float64         `json:"error_rate"`
+	StatusCodes    map[int]int     `json:"status_codes"`
+	ScalingSamples []ScalingSample `json:"scaling_samples,omitempty"`
+}
+
+// RunLoadTest drives config against config.TargetURL, either from an
+// in-cluster Job (auto-selecting a k6 image for HTTP targets and a ghz
+// image for gRPC targets) or from a local goroutine pool, per config.Mode.
+// While it runs, it samples HPA scaling and pod CPU/memory every 5s so the
+// returned LoadResult can be correlated against a ScalingTest assertion.
+func (suite *K8sTestSuite) RunLoadTest(config LoadTest) (LoadResult, error) {
+	if !config.Enabled {
+		return LoadResult{}, errors.New("RunLoadTest called with a disabled LoadTest config")
+	}
+
+	sampleDone := make(chan struct{})
+	var samples []ScalingSample
+	var sampleMu sync.Mutex
+	go func() {
+		ticker := time.NewTicker(loadMetricsSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-sampleDone:
+				return
+			case <-ticker.C:
+				sample, err := suite.sampleScaling(config)
+				if err != nil {
+					suite.Logger.Warn().Err(err).Msg("failed to sample HPA/pod metrics during load test")
+					continue
+				}
+				sampleMu.Lock()
+				samples = append(samples, sample)
+				sampleMu.Unlock()
+			}
+		}
+	}()
+	defer close(sampleDone)
+
+	var result LoadResult
+	var err error
+	if config.Mode == "in-cluster" {
+		result, err = suite.runInClusterLoadTest(config)
+	} else {
+		result, err = runLocalLoadTest(config)
+	}
+	if err != nil {
+		return LoadResult{}, err
+	}
+
+	sampleMu.Lock()
+	result.ScalingSamples = samples
+	sampleMu.Unlock()
+	return result, nil
+}
+
+// sampleScaling reads the target Deployment's replica count and the HPA's
+// current CPU utilization, plus summed pod CPU and memory usage from the
+// metrics clientset, into one ScalingSample.
+func (suite *K8sTestSuite) sampleScaling(config LoadTest) (ScalingSample, error) {
+	sample := ScalingSample{At: time.Now()}
+
+	deployments, err := suite.Clientset.AppsV1().Deployments(suite.Namespace).List(suite.Context, metav1.ListOptions{})
+	if err != nil {
+		return sample, errors.Wrap(err, "failed to list deployments")
+	}
+	for _, dep := range deployments.Items {
+		sample.Replicas += dep.Status.Replicas
+	}
+
+	hpas, err := suite.Clientset.AutoscalingV2().HorizontalPodAutoscalers(suite.Namespace).List(suite.Context, metav1.ListOptions{})
+	if err == nil {
+		for _, hpa := range hpas.Items {
+			for _, metric := range hpa.Status.CurrentMetrics {
+				if metric.Resource != nil && metric.Resource.Name == corev1.ResourceCPU && metric.Resource.Current.AverageUtilization != nil {
+					sample.DesiredCPU = *metric.Resource.Current.AverageUtilization
+				}
+			}
+		}
+	}
+
+	if suite.MetricsClientset != nil {
+		podMetrics, err := suite.MetricsClientset.MetricsV1beta1().PodMetricses(suite.Namespace).List(suite.Context, metav1.ListOptions{})
+		if err == nil {
+			for _, pm := range podMetrics.Items {
+				for _, c := range pm.Containers {
+					sample.TotalCPUm += c.Usage.Cpu().MilliValue()
+					sample.TotalMemB += c.Usage.Memory().Value()
+				}
+			}
+		}
+	}
+
+	return sample, nil
+}
+
+// runInClusterLoadTest spawns a Job running the load generator appropriate
+// for config.TargetURL's scheme, streams its log output, and parses the
+// generator's JSON summary line.
+func (suite *K8sTestSuite) runInClusterLoadTest(config LoadTest) (LoadResult, error) {
+	image := k6LoadGeneratorImage
+	if strings.HasPrefix(config.TargetURL, "grpc://") {
+		image = ghzLoadGeneratorImage
+	}
+
+	jobName := fmt.Sprintf("loadtest-%s", suite.TestID)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: jobName, Namespace: suite.Namespace},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"test-id": suite.TestID}},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:  "load-generator",
+						Image: image,
+						Args:  loadGeneratorArgs(config),
+					}},
+				},
+			},
+		},
+	}
+
+	created, err := suite.Clientset.BatchV1().Jobs(suite.Namespace).Create(suite.Context, job, metav1.CreateOptions{})
+	if err != nil {
+		return LoadResult{}, errors.Wrapf(err, "failed to create load generator job %s", jobName)
+	}
+	defer func() {
+		propagation := metav1.DeletePropagationForeground
+		if err := suite.Clientset.BatchV1().Jobs(suite.Namespace).Delete(suite.Context, created.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+			suite.Logger.Warn().Err(err).Str("job", created.Name).Msg("failed to clean up load generator job")
+		}
+	}()
+
+	deadline := time.Now().Add(config.Duration + 2*time.Minute)
+	var podName string
+	for time.Now().Before(deadline) {
+		pods, err := suite.Clientset.CoreV1().Pods(suite.Namespace).List(suite.Context, metav1.ListOptions{
+			LabelSelector: "test-id=" + suite.TestID,
+		})
+		if err == nil && len(pods.Items) > 0 {
+			podName = pods.Items[0].Name
+			if pods.Items[0].Status.Phase == corev1.PodSucceeded || pods.Items[0].Status.Phase == corev1.PodFailed {
+				break
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	if podName == "" {
+		return LoadResult{}, errors.Errorf("load generator job %s never scheduled a pod", jobName)
+	}
+
+	stream, err := suite.Clientset.CoreV1().Pods(suite.Namespace).GetLogs(podName, &corev1.PodLogOptions{Follow: true}).Stream(suite.Context)
+	if err != nil {
+		return LoadResult{}, errors.Wrapf(err, "failed to stream logs from %s", podName)
+	}
+	defer stream.Close()
+
+	return parseLoadGeneratorSummary(stream)
+}
+
+// loadGeneratorArgs builds the CLI args for the k6/ghz image selected by
+// runInClusterLoadTest.
+func loadGeneratorArgs(config LoadTest) []string {
+	if strings.HasPrefix(config.TargetURL, "grpc://") {
+		return []string{"--insecure", "-c", strconv.Itoa(config.Concurrency), "-z", config.Duration.String(),
+			"-O", "pretty", strings.TrimPrefix(config.TargetURL, "grpc://")}
+	}
+	return []string{"run", "--vus", strconv.Itoa(config.Concurrency), "--duration", config.Duration.String(),
+		"--summary-export=/dev/stdout", "-"}
+}
+
+// parseLoadGeneratorSummary scans r line by line for a JSON summary and
+// parses it into a LoadResult. Only k6's --summary-export shape is
+// recognized (see parseSummaryLine); ghz output is not parsed, so a gRPC
+// run ends with the "no parseable JSON summary" error.
+func parseLoadGeneratorSummary(r io.Reader) (LoadResult, error) {
+	result := LoadResult{StatusCodes: map[int]int{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+			continue
+		}
+		if err := parseSummaryLine(line, &result); err == nil {
+			return result, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, errors.Wrap(err, "failed to read load generator output")
+	}
+	return result, errors.New("load generator produced no parseable JSON summary")
+}
+
+// k6Summary is the subset of k6's --summary-export JSON this package reads.
+type k6Summary struct {
+	Metrics struct {
+		HTTPReqDuration struct {
+			Values map[string]float64 `json:"values"`
+		} `json:"http_req_duration"`
+		HTTPReqs struct {
+			Values struct {
+				Rate  float64 `json:"rate"`
+				Count float64 `json:"count"`
+			} `json:"values"`
+		} `json:"http_reqs"`
+		HTTPReqFailed struct {
+			Values struct {
+				Rate float64 `json:"rate"`
+			} `json:"values"`
+		} `json:"http_req_failed"`
+	} `json:"metrics"`
+}
+
+// parseSummaryLine attempts to parse line as a k6 --summary-export JSON
+// document, filling result on success.
+func parseSummaryLine(line string, result *LoadResult) error {
+	var summary k6Summary
+	if err := json.Unmarshal([]byte(line), &summary); err != nil {
+		return err
+	}
+	if len(summary.Metrics.HTTPReqDuration.Values) == 0 {
+		return errors.New("not a k6 summary")
+	}
+	result.P50Ms = summary.Metrics.HTTPReqDuration.Values["med"]
+	result.P95Ms = summary.Metrics.HTTPReqDuration.Values["p(95)"]
+	result.P99Ms = summary.Metrics.HTTPReqDuration.Values["p(99)"]
+	result.AchievedRPS = summary.Metrics.HTTPReqs.Values.Rate
+	result.ErrorRate = summary.Metrics.HTTPReqFailed.Values.Rate
+	return nil
+}
+
+// runLocalLoadTest drives config.TargetURL from a local goroutine pool sized
+// to config.Concurrency, for config.Duration, and summarizes the results.
+func runLocalLoadTest(config LoadTest) (LoadResult, error) {
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	deadline := time.Now().Add(config.Duration)
+	var mu sync.Mutex
+	var latencies []float64
+	statusCodes := map[int]int{}
+	errorCount := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				start := time.Now()
+				resp, err := client.Get(config.TargetURL)
+				latencyMs := float64(time.Since(start).Milliseconds())
+
+				mu.Lock()
+				latencies = append(latencies, latencyMs)
+				if err != nil {
+					errorCount++
+				} else {
+					statusCodes[resp.StatusCode]++
+					if resp.StatusCode >= 500 {
+						errorCount++
+					}
+					resp.Body.Close()
+				}
+				mu.Unlock()
+
+				if config.RequestsPerSecond > 0 {
+					time.Sleep(time.Second / time.Duration(config.RequestsPerSecond*concurrency))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	result := LoadResult{StatusCodes: statusCodes}
+	if len(latencies) == 0 {
+		return result, errors.New("local load test issued no requests")
+	}
+	result.ErrorRate = float64(errorCount) / float64(len(latencies))
+	result.AchievedRPS = float64(len(latencies)) / config.Duration.Seconds()
+
+	var err error
+	if result.P50Ms, err = stats.Percentile(latencies, 50); err != nil {
+		return result, errors.Wrap(err, "failed to compute p50")
+	}
+	if result.P95Ms, err = stats.Percentile(latencies, 95); err != nil {
+		return result, errors.Wrap(err, "failed to compute p95")
+	}
+	if result.P99Ms, err = stats.Percentile(latencies, 99); err != nil {
+		return result, errors.Wrap(err, "failed to compute p99")
+	}
+	return result, nil
+}
+
+// AssertScaling checks that observed replica scaling stayed within
+// [scaling.MinReplicas, scaling.MaxReplicas] and that TargetCPU was
+// exceeded at some point before any scale-out recorded in samples.
+func AssertScaling(scaling ScalingTest, samples []ScalingSample) []string {
+	if !scaling.Enabled || len(samples) == 0 {
+		return nil
+	}
+
+	var violations []string
+	exceededBeforeScaleOut := false
+	for i, sample := range samples {
+		if int(sample.Replicas) < scaling.MinReplicas || int(sample.Replicas) > scaling.MaxReplicas {
+			violations = append(violations, fmt.Sprintf("replica count %d at %s is outside [%d, %d]",
+				sample.Replicas, sample.At.Format(time.RFC3339), scaling.MinReplicas, scaling.MaxReplicas))
+		}
+		if i > 0 && sample.Replicas > samples[i-1].Replicas {
+			for j := 0; j <= i; j++ {
+				if int(samples[j].DesiredCPU) > scaling.TargetCPU {
+					exceededBeforeScaleOut = true
+					break
+				}
+			}
+		}
+	}
+	if !exceededBeforeScaleOut {
+		violations = append(violations, fmt.Sprintf("no scale-out was preceded by CPU utilization exceeding TargetCPU (%d%%)", scaling.TargetCPU))
+	}
+	return violations
+}
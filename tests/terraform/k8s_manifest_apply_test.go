@@ -0,0 +1,374 @@
+package test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestTestFieldManager is the field manager ApplyTestManifest's
+// server-side apply identifies itself with.
+const manifestTestFieldManager = "learning-assistant-tests"
+
+// appliedResourceDeleteOrder ranks resource kinds from first-deleted to
+// last-deleted, so CleanupAppliedResources can tear a manifest down in
+// reverse dependency order: workloads, then configs, then RBAC, then
+// namespaces last. Kinds with no entry sort after everything listed here.
+var appliedResourceDeleteOrder = map[string]int{
+	"Deployment":            0,
+	"StatefulSet":           0,
+	"DaemonSet":             0,
+	"Job":                   0,
+	"Pod":                   0,
+	"Service":               1,
+	"ConfigMap":             2,
+	"Secret":                2,
+	"PersistentVolumeClaim": 2,
+	"ServiceAccount":        3,
+	"Role":                  3,
+	"RoleBinding":           3,
+	"ClusterRole":           3,
+	"ClusterRoleBinding":    3,
+	"Namespace":             4,
+}
+
+// finalizerDeleteTimeout bounds how long CleanupAppliedResources waits for a
+// single resource's finalizers to clear before moving on to the next one.
+const finalizerDeleteTimeout = 60 * time.Second
+
+// AppliedResource identifies a single object ApplyTestManifest applied to the
+// cluster, so CleanupAppliedResources can delete it generically regardless of
+// its kind.
+type AppliedResource struct {
+	GVK       schema.GroupVersionKind
+	Namespace string
+	Name      string
+}
+
+// manifestApplyOptions configures a single ApplyTestManifest call.
+type manifestApplyOptions struct {
+	dryRun bool
+}
+
+// ApplyOption customizes ApplyTestManifest.
+type ApplyOption func(*manifestApplyOptions)
+
+// WithDryRun runs ApplyTestManifest's server-side apply in dry-run mode, so
+// CI can validate a manifest against the live API server without mutating
+// anything. Dry-run applies are not recorded in suite.AppliedResources.
+func WithDryRun() ApplyOption {
+	return func(o *manifestApplyOptions) { o.dryRun = true }
+}
+
+// LoadTestManifest loads a test manifest from file. The file may contain
+// multiple "---"-separated YAML documents -- each is decoded independently
+// and their Resources/Tests concatenated -- so a manifest can be authored as
+// several small documents instead of one large Resources list.
+func LoadTestManifest(manifestPath string) (*K8sTestManifest, error) {
+	data, err := files.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest file")
+	}
+	return parseTestManifestDocuments(data)
+}
+
+// LoadAndRenderTestManifest reads manifestPath, renders it as a Go template
+// via RenderManifestTemplate (exposing {{.TestID}} and {{.Env.KEY}}), then
+// parses the rendered result with LoadTestManifest's multi-document rules.
+func (suite *K8sTestSuite) LoadAndRenderTestManifest(manifestPath string) (*K8sTestManifest, error) {
+	data, err := files.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read manifest file")
+	}
+
+	rendered, err := suite.RenderManifestTemplate(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to render manifest %s", manifestPath)
+	}
+
+	return parseTestManifestDocuments(rendered)
+}
+
+// RenderManifestTemplate renders raw as a Go text/template, injecting the
+// suite's TestID and the process environment so manifests can parameterize
+// names, namespaces, and values per test run (e.g. "app-{{.TestID}}" or
+// "{{.Env.IMAGE_TAG}}") without a separate preprocessing step.
+func (suite *K8sTestSuite) RenderManifestTemplate(raw string) (string, error) {
+	tmpl, err := template.New("manifest").Parse(raw)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse manifest template")
+	}
+
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(&rendered, struct {
+		TestID string
+		Env    map[string]string
+	}{TestID: suite.TestID, Env: env})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render manifest template")
+	}
+	return rendered.String(), nil
+}
+
+// parseTestManifestDocuments decodes raw as one or more "---"-separated YAML
+// documents, concatenating their Resources/Tests into a single manifest.
+// Scalar fields (Name, Description, Cleanup, Timeout, RetryCount) are taken
+// from whichever document sets them last.
+func parseTestManifestDocuments(raw string) (*K8sTestManifest, error) {
+	var manifest K8sTestManifest
+	dec := yaml.NewDecoder(strings.NewReader(raw))
+	for {
+		var doc K8sTestManifest
+		if err := dec.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "failed to parse manifest file")
+		}
+		if doc.Name != "" {
+			manifest.Name = doc.Name
+		}
+		if doc.Description != "" {
+			manifest.Description = doc.Description
+		}
+		if doc.Cleanup {
+			manifest.Cleanup = true
+		}
+		if doc.Timeout > manifest.Timeout {
+			manifest.Timeout = doc.Timeout
+		}
+		if doc.RetryCount > manifest.RetryCount {
+			manifest.RetryCount = doc.RetryCount
+		}
+		manifest.Resources = append(manifest.Resources, doc.Resources...)
+		manifest.Tests = append(manifest.Tests, doc.Tests...)
+	}
+	return &manifest, nil
+}
+
+// ApplyTestManifest server-side applies every resource in manifest via PATCH
+// with fieldManager "learning-assistant-tests" and force-conflicts, so
+// re-applying a manifest an earlier run (or another tool) already touched
+// always wins. Every resource it applies is appended to
+// suite.AppliedResources in application order, so CleanupAppliedResources can
+// generically tear the whole manifest down later regardless of kind.
+func (suite *K8sTestSuite) ApplyTestManifest(manifest *K8sTestManifest, opts ...ApplyOption) error {
+	var options manifestApplyOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	suite.Logger.Info().Str("manifest", manifest.Name).Bool("dry_run", options.dryRun).Msg("Applying test manifest")
+
+	for _, resource := range manifest.Resources {
+		applied, err := suite.applyManifestTestResource(resource, options)
+		if err != nil {
+			return errors.Wrapf(err, "failed to apply resource %s", resource.Metadata.Name)
+		}
+		if !options.dryRun {
+			suite.AppliedResources = append(suite.AppliedResources, applied)
+		}
+	}
+
+	if manifest.waitReady() && !options.dryRun {
+		timeout := manifest.Timeout
+		if timeout <= 0 {
+			timeout = 2 * time.Minute
+		}
+		if err := suite.WaitForResourcesReady(suite.Context, manifest.Resources, timeout); err != nil {
+			return errors.Wrapf(err, "manifest %s did not become ready", manifest.Name)
+		}
+	}
+
+	return nil
+}
+
+// applyManifestTestResource server-side applies a single resource and
+// returns the AppliedResource record for it.
+func (suite *K8sTestSuite) applyManifestTestResource(resource K8sResource, options manifestApplyOptions) (AppliedResource, error) {
+	if suite.DynamicClient == nil {
+		return AppliedResource{}, errors.New("ApplyTestManifest requires a DynamicClient -- NewK8sTestSuite failed to build one")
+	}
+
+	gvr, ok := manifestGVRs[resource.Kind]
+	if !ok {
+		return AppliedResource{}, errors.Errorf("resource kind %s has no known GroupVersionResource", resource.Kind)
+	}
+
+	obj, err := resourceToUnstructured(resource)
+	if err != nil {
+		return AppliedResource{}, errors.Wrapf(err, "failed to convert %s/%s to unstructured", resource.Kind, resource.Metadata.Name)
+	}
+
+	namespace := resource.Metadata.Namespace
+	if namespace == "" {
+		namespace = suite.Namespace
+	}
+
+	applyOpts := metav1.ApplyOptions{FieldManager: manifestTestFieldManager, Force: true}
+	if options.dryRun {
+		applyOpts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	if _, err := suite.DynamicClient.Resource(gvr).Namespace(namespace).Apply(suite.Context, resource.Metadata.Name, obj, applyOpts); err != nil {
+		return AppliedResource{}, errors.Wrapf(err, "failed to apply %s/%s", resource.Kind, resource.Metadata.Name)
+	}
+
+	return AppliedResource{
+		GVK:       schema.GroupVersionKind{Group: gvr.Group, Version: gvr.Version, Kind: resource.Kind},
+		Namespace: namespace,
+		Name:      resource.Metadata.Name,
+	}, nil
+}
+
+// DiffTestManifest compares every resource in manifest against its live
+// counterpart and returns a per-resource structural diff of the fields an
+// apply would change, keyed by "Kind/namespace/name". A resource with no
+// live counterpart is reported as "would be created" rather than diffed.
+// DiffTestManifest makes no mutating API calls.
+func (suite *K8sTestSuite) DiffTestManifest(manifest *K8sTestManifest) (map[string]string, error) {
+	if suite.DynamicClient == nil {
+		return nil, errors.New("DiffTestManifest requires a DynamicClient -- NewK8sTestSuite failed to build one")
+	}
+
+	diffs := map[string]string{}
+	for _, resource := range manifest.Resources {
+		gvr, ok := manifestGVRs[resource.Kind]
+		if !ok {
+			return nil, errors.Errorf("resource kind %s has no known GroupVersionResource", resource.Kind)
+		}
+
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = suite.Namespace
+		}
+
+		desired, err := resourceToUnstructured(resource)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s/%s to unstructured", resource.Kind, resource.Metadata.Name)
+		}
+
+		key := resourceKey(resource)
+		live, err := suite.DynamicClient.Resource(gvr).Namespace(namespace).Get(suite.Context, resource.Metadata.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			diffs[key] = "resource does not exist, would be created"
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch live %s/%s", resource.Kind, resource.Metadata.Name)
+		}
+
+		if fieldDiffs := diffDesiredFields(desired.Object, live.Object); len(fieldDiffs) > 0 {
+			diffs[key] = strings.Join(fieldDiffs, "; ")
+		}
+	}
+	return diffs, nil
+}
+
+// diffDesiredFields walks the top-level fields of a desired object
+// (metadata.labels, spec, data, stringData) that ApplyTestManifest sets and
+// reports which ones differ from the live object's. It is a lightweight
+// structural diff, not a full three-way merge -- good enough to flag what an
+// apply would change without needing a live round-trip through the API
+// server's dry-run.
+func diffDesiredFields(desired, live map[string]interface{}) []string {
+	var diffs []string
+	for _, field := range []string{"spec", "data", "stringData"} {
+		desiredVal, desiredOK := desired[field]
+		if !desiredOK {
+			continue
+		}
+		liveVal := live[field]
+		if !deepEqualYAML(desiredVal, liveVal) {
+			diffs = append(diffs, field+" differs")
+		}
+	}
+
+	desiredMeta, _ := desired["metadata"].(map[string]interface{})
+	desiredLabels, _ := desiredMeta["labels"].(map[string]interface{})
+	liveMeta, _ := live["metadata"].(map[string]interface{})
+	liveLabels, _ := liveMeta["labels"].(map[string]interface{})
+	for key, desiredValue := range desiredLabels {
+		if liveLabels[key] != desiredValue {
+			diffs = append(diffs, "metadata.labels."+key+" differs")
+		}
+	}
+	return diffs
+}
+
+// deepEqualYAML compares two decoded YAML/JSON values for structural
+// equality by round-tripping them through yaml.Marshal, sidestepping the
+// map-ordering and numeric-type mismatches raw reflect.DeepEqual would flag
+// as spurious differences.
+func deepEqualYAML(a, b interface{}) bool {
+	aBytes, aErr := yaml.Marshal(a)
+	bBytes, bErr := yaml.Marshal(b)
+	if aErr != nil || bErr != nil {
+		return false
+	}
+	return string(aBytes) == string(bBytes)
+}
+
+// CleanupAppliedResources deletes every resource recorded in
+// suite.AppliedResources -- everything ApplyTestManifest has applied across
+// any number of calls -- in reverse dependency order (workloads, then
+// configs, then RBAC, then namespaces last), waiting up to
+// finalizerDeleteTimeout for each delete's finalizers to clear before moving
+// on to the next resource. Individual failures are logged rather than
+// returned, matching cleanupTestResources' best-effort teardown style.
+func (suite *K8sTestSuite) CleanupAppliedResources() {
+	ordered := append([]AppliedResource(nil), suite.AppliedResources...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return appliedResourceDeleteOrder[ordered[i].GVK.Kind] < appliedResourceDeleteOrder[ordered[j].GVK.Kind]
+	})
+
+	for _, resource := range ordered {
+		gvr, ok := manifestGVRs[resource.GVK.Kind]
+		if !ok {
+			suite.Logger.Warn().Str("kind", resource.GVK.Kind).Str("name", resource.Name).
+				Msg("no known GroupVersionResource for applied resource cleanup")
+			continue
+		}
+
+		client := suite.DynamicClient.Resource(gvr).Namespace(resource.Namespace)
+		if err := client.Delete(suite.Context, resource.Name, metav1.DeleteOptions{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				suite.Logger.Warn().Err(err).Str("kind", resource.GVK.Kind).Str("name", resource.Name).
+					Msg("failed to delete applied resource")
+			}
+			continue
+		}
+
+		err := wait.PollImmediate(2*time.Second, finalizerDeleteTimeout, func() (bool, error) {
+			_, getErr := client.Get(suite.Context, resource.Name, metav1.GetOptions{})
+			return apierrors.IsNotFound(getErr), nil
+		})
+		if err != nil {
+			suite.Logger.Warn().Str("kind", resource.GVK.Kind).Str("name", resource.Name).
+				Msg("timed out waiting for finalizers to clear")
+		}
+	}
+
+	suite.AppliedResources = nil
+}
@@ -0,0 +1,355 @@
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestGVRs maps the resource Kinds K8sResource supports to the
+// GroupVersionResource the dynamic client needs to apply them. Extend this
+// as RunManifest grows to cover more kinds.
+var manifestGVRs = map[string]schema.GroupVersionResource{
+	"Deployment":            {Group: "apps", Version: "v1", Resource: "deployments"},
+	"StatefulSet":           {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	"DaemonSet":             {Group: "apps", Version: "v1", Resource: "daemonsets"},
+	"Job":                   {Group: "batch", Version: "v1", Resource: "jobs"},
+	"Service":               {Group: "", Version: "v1", Resource: "services"},
+	"ConfigMap":             {Group: "", Version: "v1", Resource: "configmaps"},
+	"Secret":                {Group: "", Version: "v1", Resource: "secrets"},
+	"Pod":                   {Group: "", Version: "v1", Resource: "pods"},
+	"PersistentVolumeClaim": {Group: "", Version: "v1", Resource: "persistentvolumeclaims"},
+}
+
+// manifestFieldOwner is the server-side apply field manager RunManifest
+// identifies itself with.
+const manifestFieldOwner = "learning-assistant-k8s-test-suite"
+
+// RunManifest loads path into a K8sTestManifest, server-side applies every
+// resource tagged with a test-id label for traceability, evaluates each
+// test's Conditions/Metrics against the live objects, and -- if
+// manifest.Cleanup is set -- deletes everything it applied once the
+// subtests finish. It writes a JUnit report alongside path so this can
+// drive CI-style declarative infra testing.
+func (suite *K8sTestSuite) RunManifest(t *testing.T, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read manifest %s", path)
+	}
+
+	var manifest K8sTestManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return errors.Wrapf(err, "failed to parse manifest %s", path)
+	}
+
+	if suite.DynamicClient == nil {
+		return errors.New("RunManifest requires a DynamicClient -- NewK8sTestSuite failed to build one")
+	}
+
+	retryCount := manifest.RetryCount
+	if retryCount <= 0 {
+		retryCount = 1
+	}
+	timeout := manifest.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	applied, err := suite.applyManifestResources(manifest.Resources, retryCount)
+	if manifest.Cleanup {
+		defer suite.cleanupManifestResources(applied)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply manifest %s", manifest.Name)
+	}
+
+	if manifest.waitReady() {
+		if err := suite.WaitForResourcesReady(suite.Context, manifest.Resources, timeout); err != nil {
+			return errors.Wrapf(err, "manifest %s did not become ready", manifest.Name)
+		}
+	}
+
+	jUnit := JUnitTestSuite{Name: "K8sManifest/" + manifest.Name}
+	var failed error
+	for _, resourceTest := range manifest.Tests {
+		tc := JUnitTestCase{Name: resourceTest.Name}
+		if err := suite.evaluateResourceTest(resourceTest); err != nil {
+			tc.Failure = &JUnitFailure{Message: "manifest test failed", Text: err.Error()}
+			failed = errors.Wrapf(err, "test %s", resourceTest.Name)
+		}
+		jUnit.TestCases = append(jUnit.TestCases, tc)
+		if t != nil {
+			t.Run(resourceTest.Name, func(t *testing.T) {
+				if tc.Failure != nil {
+					t.Error(tc.Failure.Text)
+				}
+			})
+		}
+	}
+	jUnit.Tests = len(jUnit.TestCases)
+	for _, tc := range jUnit.TestCases {
+		if tc.Failure != nil {
+			jUnit.Failures++
+		}
+	}
+	if reportErr := writeManifestJUnitReport(path+".junit.xml", jUnit); reportErr != nil {
+		suite.Logger.Warn().Err(reportErr).Msg("failed to write manifest JUnit report")
+	}
+
+	return failed
+}
+
+// applyManifestResources server-side applies each resource, labeled with
+// test-id=<TestID>, retrying transient failures up to retryCount times. It
+// returns every resource it successfully applied (for cleanup), even if a
+// later one fails.
+func (suite *K8sTestSuite) applyManifestResources(resources []K8sResource, retryCount int) ([]K8sResource, error) {
+	var applied []K8sResource
+	for _, resource := range resources {
+		gvr, ok := manifestGVRs[resource.Kind]
+		if !ok {
+			return applied, errors.Errorf("resource kind %s has no known GroupVersionResource", resource.Kind)
+		}
+
+		if resource.Metadata.Labels == nil {
+			resource.Metadata.Labels = map[string]string{}
+		}
+		resource.Metadata.Labels["test-id"] = suite.TestID
+
+		obj, err := resourceToUnstructured(resource)
+		if err != nil {
+			return applied, errors.Wrapf(err, "failed to convert %s/%s to unstructured", resource.Kind, resource.Metadata.Name)
+		}
+
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = suite.Namespace
+		}
+
+		err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+			_, applyErr := suite.DynamicClient.Resource(gvr).Namespace(namespace).Apply(
+				suite.Context, resource.Metadata.Name, obj, metav1.ApplyOptions{FieldManager: manifestFieldOwner, Force: true})
+			return applyErr
+		})
+		if err != nil {
+			return applied, errors.Wrapf(err, "failed to apply %s/%s after %d attempt(s)", resource.Kind, resource.Metadata.Name, retryCount)
+		}
+		applied = append(applied, resource)
+	}
+	return applied, nil
+}
+
+// resourceToUnstructured converts a K8sResource into the unstructured object
+// the dynamic client's Apply expects.
+func resourceToUnstructured(resource K8sResource) (*unstructured.Unstructured, error) {
+	obj := map[string]interface{}{
+		"apiVersion": resource.APIVersion,
+		"kind":       resource.Kind,
+		"metadata":   metadataToMap(resource.Metadata),
+	}
+	if resource.Spec != nil {
+		obj["spec"] = resource.Spec
+	}
+	if resource.Data != nil {
+		obj["data"] = resource.Data
+	}
+	if resource.StringData != nil {
+		obj["stringData"] = resource.StringData
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// metadataToMap extracts the fields of metav1.ObjectMeta the dynamic client
+// needs for server-side apply.
+func metadataToMap(meta metav1.ObjectMeta) map[string]interface{} {
+	m := map[string]interface{}{"name": meta.Name}
+	if meta.Namespace != "" {
+		m["namespace"] = meta.Namespace
+	}
+	if len(meta.Labels) > 0 {
+		labels := map[string]interface{}{}
+		for k, v := range meta.Labels {
+			labels[k] = v
+		}
+		m["labels"] = labels
+	}
+	if len(meta.Annotations) > 0 {
+		annotations := map[string]interface{}{}
+		for k, v := range meta.Annotations {
+			annotations[k] = v
+		}
+		m["annotations"] = annotations
+	}
+	return m
+}
+
+// cleanupManifestResources deletes every resource RunManifest applied,
+// selected by the test-id label it stamped them with.
+func (suite *K8sTestSuite) cleanupManifestResources(applied []K8sResource) {
+	for _, resource := range applied {
+		gvr, ok := manifestGVRs[resource.Kind]
+		if !ok {
+			continue
+		}
+		namespace := resource.Metadata.Namespace
+		if namespace == "" {
+			namespace = suite.Namespace
+		}
+		if err := suite.DynamicClient.Resource(gvr).Namespace(namespace).Delete(suite.Context, resource.Metadata.Name, metav1.DeleteOptions{}); err != nil {
+			suite.Logger.Warn().Err(err).Str("resource", resource.Metadata.Name).Msg("failed to clean up manifest resource")
+		}
+	}
+}
+
+// evaluateResourceTest fetches the live object resourceTest.Resource names
+// and checks every TestCondition against it, failing on the first violation.
+func (suite *K8sTestSuite) evaluateResourceTest(resourceTest K8sResourceTest) error {
+	gvr, ok := manifestGVRs[resourceTest.Type]
+	if !ok {
+		return errors.Errorf("test %s references unknown resource type %s", resourceTest.Name, resourceTest.Type)
+	}
+	namespace := resourceTest.Namespace
+	if namespace == "" {
+		namespace = suite.Namespace
+	}
+
+	obj, err := suite.DynamicClient.Resource(gvr).Namespace(namespace).Get(suite.Context, resourceTest.Resource, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch %s/%s", resourceTest.Type, resourceTest.Resource)
+	}
+
+	for _, condition := range resourceTest.Conditions {
+		if err := evaluateCondition(obj, condition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluateCondition looks up condition.Field as a dotted path into obj
+// (e.g. "status.readyReplicas") and checks it against condition.Value using
+// condition.Operator.
+func evaluateCondition(obj *unstructured.Unstructured, condition TestCondition) error {
+	fields := splitFieldPath(condition.Field)
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read field %s", condition.Field)
+	}
+
+	switch condition.Operator {
+	case "exists":
+		if !found {
+			return conditionFailure(condition, "field does not exist")
+		}
+		return nil
+	}
+
+	if !found {
+		return conditionFailure(condition, "field does not exist")
+	}
+
+	switch condition.Operator {
+	case "equals":
+		if fmt.Sprintf("%v", value) != fmt.Sprintf("%v", condition.Value) {
+			return conditionFailure(condition, fmt.Sprintf("got %v", value))
+		}
+	case "contains":
+		if !strings.Contains(fmt.Sprintf("%v", value), fmt.Sprintf("%v", condition.Value)) {
+			return conditionFailure(condition, fmt.Sprintf("got %v", value))
+		}
+	case "greater_than":
+		got, want, err := numericOperands(value, condition.Value)
+		if err != nil {
+			return errors.Wrapf(err, "condition %s", condition.Field)
+		}
+		if !(got > want) {
+			return conditionFailure(condition, fmt.Sprintf("got %v", value))
+		}
+	case "less_than":
+		got, want, err := numericOperands(value, condition.Value)
+		if err != nil {
+			return errors.Wrapf(err, "condition %s", condition.Field)
+		}
+		if !(got < want) {
+			return conditionFailure(condition, fmt.Sprintf("got %v", value))
+		}
+	default:
+		return errors.Errorf("condition %s: unsupported operator %q", condition.Field, condition.Operator)
+	}
+	return nil
+}
+
+func conditionFailure(condition TestCondition, detail string) error {
+	if condition.Message != "" {
+		return errors.Errorf("%s (%s)", condition.Message, detail)
+	}
+	return errors.Errorf("condition on %s failed: %s", condition.Field, detail)
+}
+
+// numericOperands coerces got/want to float64 for greater_than/less_than
+// comparisons.
+func numericOperands(got, want interface{}) (float64, float64, error) {
+	g, err := toFloat64(got)
+	if err != nil {
+		return 0, 0, err
+	}
+	w, err := toFloat64(want)
+	if err != nil {
+		return 0, 0, err
+	}
+	return g, w, nil
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int64:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case string:
+		return strconv.ParseFloat(n, 64)
+	default:
+		return 0, errors.Errorf("value %v is not numeric", v)
+	}
+}
+
+// splitFieldPath turns a dotted JSONPath-like selector ("status.readyReplicas")
+// into the segments unstructured.NestedFieldNoCopy expects.
+func splitFieldPath(field string) []string {
+	var segments []string
+	start := 0
+	for i := 0; i < len(field); i++ {
+		if field[i] == '.' {
+			segments = append(segments, field[start:i])
+			start = i + 1
+		}
+	}
+	segments = append(segments, field[start:])
+	return segments
+}
+
+// writeManifestJUnitReport writes suite as a JUnit XML report at path.
+func writeManifestJUnitReport(path string, suite JUnitTestSuite) error {
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal manifest JUnit report")
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), data...), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write manifest JUnit report to %s", path)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"github.com/pkg/errors"
+)
+
+// MultiClusterSuite fans a single test invocation out across every
+// kubeconfig context it was built with, so active/active and DR topologies
+// can be validated in one run instead of by re-running the suite per
+// cluster.
+type MultiClusterSuite struct {
+	Suites map[string]*K8sTestSuite // keyed by kubeconfig context name
+}
+
+// NewK8sTestSuiteMulti builds one K8sTestSuite per kubeconfig context in
+// contexts, all reading kubeconfigPath but overriding CurrentContext.
+func NewK8sTestSuiteMulti(kubeconfigPath, namespace string, contexts []string, config TestConfig) (*MultiClusterSuite, error) {
+	multi := &MultiClusterSuite{Suites: make(map[string]*K8sTestSuite, len(contexts))}
+	for _, clusterContext := range contexts {
+		suite, err := newK8sTestSuiteForContext(kubeconfigPath, clusterContext, namespace, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build K8sTestSuite for context %s", clusterContext)
+		}
+		multi.Suites[clusterContext] = suite
+	}
+	return multi, nil
+}
+
+// newK8sTestSuiteForContext builds a K8sTestSuite the same way
+// NewK8sTestSuite does, but pinned to kubeContext rather than the
+// kubeconfig's current-context.
+func newK8sTestSuiteForContext(kubeconfigPath, kubeContext, namespace string, config TestConfig) (*K8sTestSuite, error) {
+	testID := uuid.New().String()[:8]
+	ctx := context.Background()
+
+	logger := log.With().
+		Str("service", "k8s-test-suite").
+		Str("namespace", namespace).
+		Str("kube_context", kubeContext).
+		Str("test_id", testID).
+		Logger()
+
+	kubectlOptions := k8s.NewKubectlOptions(kubeContext, kubeconfigPath, namespace)
+
+	loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfigPath}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build rest config for context %s", kubeContext)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create clientset for context %s", kubeContext)
+	}
+
+	metricsClientset, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to create metrics clientset")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to create dynamic client")
+	}
+
+	return &K8sTestSuite{
+		KubeconfigPath:          kubeconfigPath,
+		Namespace:               namespace,
+		TestID:                  testID,
+		Config:                  config,
+		Logger:                  logger,
+		Context:                 ctx,
+		Clientset:               clientset,
+		MetricsClientset:        metricsClientset,
+		DynamicClient:           dynamicClient,
+		RestConfig:              restConfig,
+		KubectlOptions:          kubectlOptions,
+		TestNamespaces:          make([]string, 0),
+		TestDeployments:         make([]string, 0),
+		TestServices:            make([]string, 0),
+		TestIngresses:           make([]string, 0),
+		TestConfigMaps:          make([]string, 0),
+		TestSecrets:             make([]string, 0),
+		TestPVCs:                make([]string, 0),
+		TestJobs:                make([]string, 0),
+		TestCronJobs:            make([]string, 0),
+		TestHPAs:                make([]string, 0),
+		TestPodSecurityPolicies: make([]string, 0),
+		TestNetworkPolicies:     make([]string, 0),
+		TestServiceAccounts:     make([]string, 0),
+		TestRoles:               make([]string, 0),
+		TestRoleBindings:        make([]string, 0),
+		TestClusterRoles:        make([]string, 0),
+		TestClusterRoleBindings: make([]string, 0),
+		TestStorageClasses:      make([]string, 0),
+	}, nil
+}
+
+// ForEach runs fn against every cluster in multi concurrently via
+// t.Parallel, one subtest per context.
+func (multi *MultiClusterSuite) ForEach(t *testing.T, fn func(t *testing.T, suite *K8sTestSuite)) {
+	for clusterContext, suite := range multi.Suites {
+		clusterContext, suite := clusterContext, suite
+		t.Run(clusterContext, func(t *testing.T) {
+			t.Parallel()
+			fn(t, suite)
+		})
+	}
+}
+
+// AssertDeploymentConsistent verifies a Deployment with matching image and
+// replica count exists in every cluster.
+func (multi *MultiClusterSuite) AssertDeploymentConsistent(namespace, name string) error {
+	var reference *int32
+	var referenceImage string
+	for clusterContext, suite := range multi.Suites {
+		dep, err := suite.Clientset.AppsV1().Deployments(namespace).Get(suite.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "cluster %s: failed to fetch deployment %s/%s", clusterContext, namespace, name)
+		}
+		if len(dep.Spec.Template.Spec.Containers) == 0 {
+			return errors.Errorf("cluster %s: deployment %s/%s has no containers", clusterContext, namespace, name)
+		}
+		image := dep.Spec.Template.Spec.Containers[0].Image
+		if referenceImage == "" {
+			referenceImage = image
+			reference = dep.Spec.Replicas
+			continue
+		}
+		if image != referenceImage {
+			return errors.Errorf("cluster %s: deployment %s/%s image %s does not match reference %s", clusterContext, namespace, name, image, referenceImage)
+		}
+		if !replicasEqual(dep.Spec.Replicas, reference) {
+			return errors.Errorf("cluster %s: deployment %s/%s replica count does not match reference", clusterContext, namespace, name)
+		}
+	}
+	return nil
+}
+
+func replicasEqual(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// AssertSecretHashMatches verifies a Secret's data hashes identically in
+// every cluster, so failover doesn't land on stale credentials.
+func (multi *MultiClusterSuite) AssertSecretHashMatches(namespace, name string) error {
+	var referenceHash, referenceContext string
+	for clusterContext, suite := range multi.Suites {
+		secret, err := suite.Clientset.CoreV1().Secrets(namespace).Get(suite.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "cluster %s: failed to fetch secret %s/%s", clusterContext, namespace, name)
+		}
+		hash := hashSecretData(secret.Data)
+		if referenceHash == "" {
+			referenceHash, referenceContext = hash, clusterContext
+			continue
+		}
+		if hash != referenceHash {
+			return errors.Errorf("cluster %s: secret %s/%s does not hash-match cluster %s", clusterContext, namespace, name, referenceContext)
+		}
+	}
+	return nil
+}
+
+// AssertConfigMapHashMatches verifies a ConfigMap's data hashes identically
+// in every cluster.
+func (multi *MultiClusterSuite) AssertConfigMapHashMatches(namespace, name string) error {
+	var referenceHash, referenceContext string
+	for clusterContext, suite := range multi.Suites {
+		cm, err := suite.Clientset.CoreV1().ConfigMaps(namespace).Get(suite.Context, name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "cluster %s: failed to fetch configmap %s/%s", clusterContext, namespace, name)
+		}
+		hash := hashStringMapData(cm.Data)
+		if referenceHash == "" {
+			referenceHash, referenceContext = hash, clusterContext
+			continue
+		}
+		if hash != referenceHash {
+			return errors.Errorf("cluster %s: configmap %s/%s does not hash-match cluster %s", clusterContext, namespace, name, referenceContext)
+		}
+	}
+	return nil
+}
+
+// AssertRegionSpread verifies at least minRegions clusters report distinct
+// topology.kubernetes.io/region node labels.
+func (multi *MultiClusterSuite) AssertRegionSpread(minRegions int) error {
+	regions := make(map[string]struct{})
+	for clusterContext, suite := range multi.Suites {
+		nodes, err := suite.Clientset.CoreV1().Nodes().List(suite.Context, metav1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "cluster %s: failed to list nodes", clusterContext)
+		}
+		for _, node := range nodes.Items {
+			if region := node.Labels["topology.kubernetes.io/region"]; region != "" {
+				regions[region] = struct{}{}
+			}
+		}
+	}
+	if len(regions) < minRegions {
+		return errors.Errorf("clusters span %d distinct region(s), want at least %d", len(regions), minRegions)
+	}
+	return nil
+}
+
+func hashSecretData(data map[string][]byte) string {
+	h := sha256.New()
+	for _, key := range sortedKeys(data) {
+		h.Write([]byte(key))
+		h.Write(data[key])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashStringMapData(data map[string]string) string {
+	h := sha256.New()
+	for _, key := range sortedStringKeys(data) {
+		h.Write([]byte(key))
+		h.Write([]byte(data[key]))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortStrings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sortStrings(keys)
+	return keys
+}
+
+func sortStrings(values []string) {
+	for i := 1; i < len(values); i++ {
+		for j := i; j > 0 && values[j-1] > values[j]; j-- {
+			values[j-1], values[j] = values[j], values[j-1]
+		}
+	}
+}
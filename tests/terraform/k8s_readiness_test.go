@@ -0,0 +1,308 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/pkg/errors"
+)
+
+// resourceReadinessPollInterval is how often WaitForResourcesReady re-checks
+// status between kstatus evaluations.
+const resourceReadinessPollInterval = 2 * time.Second
+
+// unreadyResource names a resource WaitForResourcesReady is still waiting on,
+// along with the last observed condition that explains why.
+type unreadyResource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Reason    string
+}
+
+func (u unreadyResource) String() string {
+	return fmt.Sprintf("%s %s/%s: %s", u.Kind, u.Namespace, u.Name, u.Reason)
+}
+
+// WaitForResourcesReady blocks until every resource in resources reports
+// ready per its kind's Helm 3 kstatus rules (as introduced in the ONAP
+// k8splugin statuscheck module), or returns an error naming every resource
+// still unready with its last observed condition once timeout elapses.
+func (suite *K8sTestSuite) WaitForResourcesReady(ctx context.Context, resources []K8sResource, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var last map[string]unreadyResource
+
+	err := wait.PollImmediateUntil(resourceReadinessPollInterval, func() (bool, error) {
+		last = make(map[string]unreadyResource)
+		for _, resource := range resources {
+			if reason, ready := suite.checkResourceReady(ctx, resource); !ready {
+				last[resourceKey(resource)] = unreadyResource{
+					Kind:      resource.Kind,
+					Namespace: resource.Metadata.Namespace,
+					Name:      resource.Metadata.Name,
+					Reason:    reason,
+				}
+			}
+		}
+		return len(last) == 0, nil
+	}, resourcesReadyTimeoutChannel(deadline))
+
+	if err == nil {
+		return nil
+	}
+
+	var pending []string
+	for _, u := range last {
+		pending = append(pending, u.String())
+	}
+	return errors.Errorf("timed out after %s waiting for resources to become ready: %s", timeout, strings.Join(pending, "; "))
+}
+
+// WaitForResources is WaitForResourcesReady with a caller-supplied poll
+// interval, for callers that need tighter or looser polling than the
+// resourceReadinessPollInterval default (e.g. CI runs trading latency for
+// API server load).
+func (suite *K8sTestSuite) WaitForResources(ctx context.Context, resources []K8sResource, timeout, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	var last map[string]unreadyResource
+
+	err := wait.PollImmediateUntil(interval, func() (bool, error) {
+		last = make(map[string]unreadyResource)
+		for _, resource := range resources {
+			if reason, ready := suite.checkResourceReady(ctx, resource); !ready {
+				last[resourceKey(resource)] = unreadyResource{
+					Kind:      resource.Kind,
+					Namespace: resource.Metadata.Namespace,
+					Name:      resource.Metadata.Name,
+					Reason:    reason,
+				}
+			}
+		}
+		return len(last) == 0, nil
+	}, resourcesReadyTimeoutChannel(deadline))
+
+	if err == nil {
+		return nil
+	}
+
+	var pending []string
+	for _, u := range last {
+		pending = append(pending, u.String())
+	}
+	return errors.Errorf("timed out after %s waiting for resources to become ready: %s", timeout, strings.Join(pending, "; "))
+}
+
+// resourcesReadyTimeoutChannel returns a channel PollImmediateUntil treats as
+// "stop waiting" once deadline passes.
+func resourcesReadyTimeoutChannel(deadline time.Time) <-chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		time.Sleep(time.Until(deadline))
+		close(stop)
+	}()
+	return stop
+}
+
+// resourceKey identifies a resource within a single WaitForResourcesReady
+// call.
+func resourceKey(resource K8sResource) string {
+	return resource.Kind + "/" + resource.Metadata.Namespace + "/" + resource.Metadata.Name
+}
+
+// checkResourceReady dispatches to the kstatus check for resource.Kind and
+// reports whether it is ready, along with a human-readable reason when it is
+// not.
+func (suite *K8sTestSuite) checkResourceReady(ctx context.Context, resource K8sResource) (reason string, ready bool) {
+	namespace := resource.Metadata.Namespace
+	name := resource.Metadata.Name
+
+	switch resource.Kind {
+	case "Deployment":
+		dep, err := suite.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return deploymentReadyReason(dep)
+
+	case "StatefulSet":
+		sts, err := suite.Clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return statefulSetReadyReason(sts)
+
+	case "DaemonSet":
+		ds, err := suite.Clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return daemonSetReadyReason(ds)
+
+	case "Job":
+		job, err := suite.Clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return jobReadyReason(job)
+
+	case "Pod":
+		pod, err := suite.Clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return podReadyReason(pod)
+
+	case "PersistentVolumeClaim":
+		pvc, err := suite.Clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		if pvc.Status.Phase != corev1.ClaimBound {
+			return "phase is " + string(pvc.Status.Phase) + ", want Bound", false
+		}
+		return "", true
+
+	case "Service":
+		svc, err := suite.Clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err.Error(), false
+		}
+		return serviceReadyReason(svc)
+
+	case "CustomResourceDefinition":
+		return suite.crdReadyReason(ctx, name)
+
+	default:
+		return "kind " + resource.Kind + " has no readiness check, treating as ready", true
+	}
+}
+
+// deploymentReadyReason applies the Helm 3 kstatus rules for Deployments.
+func deploymentReadyReason(dep *appsv1.Deployment) (string, bool) {
+	if dep.Status.ObservedGeneration < dep.Generation {
+		return "observedGeneration has not caught up to generation", false
+	}
+	var replicas int32 = 1
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	if dep.Status.UpdatedReplicas < replicas {
+		return fmt.Sprintf("updatedReplicas (%d) < spec.replicas (%d)", dep.Status.UpdatedReplicas, replicas), false
+	}
+	if dep.Status.Replicas-dep.Status.UpdatedReplicas != 0 {
+		return fmt.Sprintf("%d old replica(s) still pending termination", dep.Status.Replicas-dep.Status.UpdatedReplicas), false
+	}
+	if dep.Status.AvailableReplicas < dep.Status.UpdatedReplicas {
+		return fmt.Sprintf("availableReplicas (%d) < updatedReplicas (%d)", dep.Status.AvailableReplicas, dep.Status.UpdatedReplicas), false
+	}
+	return "", true
+}
+
+// statefulSetReadyReason applies the Helm 3 kstatus rules for StatefulSets.
+func statefulSetReadyReason(sts *appsv1.StatefulSet) (string, bool) {
+	if sts.Status.ObservedGeneration < sts.Generation {
+		return "observedGeneration has not caught up to generation", false
+	}
+	var replicas int32 = 1
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Status.ReadyReplicas < replicas {
+		return fmt.Sprintf("readyReplicas (%d) < spec.replicas (%d)", sts.Status.ReadyReplicas, replicas), false
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		sts.Status.UpdateRevision != "" && sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+		return fmt.Sprintf("updateRevision (%s) != currentRevision (%s)", sts.Status.UpdateRevision, sts.Status.CurrentRevision), false
+	}
+	return "", true
+}
+
+// daemonSetReadyReason applies the Helm 3 kstatus rules for DaemonSets.
+func daemonSetReadyReason(ds *appsv1.DaemonSet) (string, bool) {
+	if ds.Status.DesiredNumberScheduled != ds.Status.NumberReady {
+		return fmt.Sprintf("numberReady (%d) != desiredNumberScheduled (%d)", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled), false
+	}
+	if ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("updatedNumberScheduled (%d) != desiredNumberScheduled (%d)", ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled), false
+	}
+	return "", true
+}
+
+// jobReadyReason applies the Helm 3 kstatus rule for Jobs: a JobComplete
+// condition with status True.
+func jobReadyReason(job *batchv1.Job) (string, bool) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == "Complete" && cond.Status == corev1.ConditionTrue {
+			return "", true
+		}
+		if cond.Type == "Failed" && cond.Status == corev1.ConditionTrue {
+			return "job failed: " + cond.Reason, false
+		}
+	}
+	return "no JobComplete condition yet", false
+}
+
+// podReadyReason applies the Helm 3 kstatus rule for Pods: a PodReady
+// condition with status True and every containerStatus ready.
+func podReadyReason(pod *corev1.Pod) (string, bool) {
+	readyCondition := false
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady && cond.Status == corev1.ConditionTrue {
+			readyCondition = true
+		}
+	}
+	if !readyCondition {
+		return "PodReady condition is not True", false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return "container " + cs.Name + " is not ready", false
+		}
+	}
+	return "", true
+}
+
+// serviceReadyReason applies the Helm 3 kstatus rule for LoadBalancer
+// Services: a non-empty status.loadBalancer.ingress. Other service types
+// have no load-balancer provisioning to wait on and are always ready.
+func serviceReadyReason(svc *corev1.Service) (string, bool) {
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return "", true
+	}
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return "status.loadBalancer.ingress is empty", false
+	}
+	return "", true
+}
+
+// crdReadyReason applies the Helm 3 kstatus rule for CustomResourceDefinitions:
+// an Established condition with status True.
+func (suite *K8sTestSuite) crdReadyReason(ctx context.Context, name string) (string, bool) {
+	client, err := apiextensionsclientset.NewForConfig(suite.RestConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to build apiextensions client").Error(), false
+	}
+	crd, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "CRD not found", false
+		}
+		return err.Error(), false
+	}
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == "Established" && cond.Status == "True" {
+			return "", true
+		}
+	}
+	return "no Established condition yet", false
+}
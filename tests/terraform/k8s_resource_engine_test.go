@@ -0,0 +1,355 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/pkg/errors"
+)
+
+// resourceEngineFieldManager is the field manager applyResource's
+// server-side apply identifies itself with. It is deliberately distinct from
+// manifestTestFieldManager -- this engine is e2e-framework-style
+// Setup/Assess/Teardown test code, not the declarative manifest path.
+const resourceEngineFieldManager = "learning-assistant-e2e"
+
+// defaultAssertPollInterval/defaultAssertPollTimeout bound runResourceTest's
+// polling when a K8sResourceTest doesn't set PollInterval/PollTimeout.
+const (
+	defaultAssertPollInterval = 1 * time.Second
+	defaultAssertPollTimeout  = 2 * time.Minute
+)
+
+// gvrForKind resolves kind to a GroupVersionResource and its scope (namespaced
+// or cluster) via suite.RESTMapper, so callers work against arbitrary CRDs
+// without a static per-kind table.
+func (suite *K8sTestSuite) gvrForKind(kind string) (schema.GroupVersionResource, meta.RESTScopeName, error) {
+	mapping, err := suite.restMappingForGVK(schema.GroupVersionKind{Kind: kind})
+	if err != nil {
+		return schema.GroupVersionResource{}, "", err
+	}
+	return mapping.Resource, mapping.Scope.Name(), nil
+}
+
+// restMappingForGVK resolves gvk via suite.RESTMapper, preferring its version
+// when set and falling back to the mapper's preferred version for the Kind
+// otherwise (useful when only a Kind is known, as in K8sResourceTest).
+func (suite *K8sTestSuite) restMappingForGVK(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	if suite.RESTMapper == nil {
+		return nil, errors.New("REST mapper unavailable -- NewK8sTestSuite failed to build one")
+	}
+	if gvk.Version != "" {
+		if mapping, err := suite.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			return mapping, nil
+		}
+	}
+	mapping, err := suite.RESTMapper.RESTMapping(gvk.GroupKind())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve %s via REST mapper", gvk.String())
+	}
+	return mapping, nil
+}
+
+// resourceInterfaceForScope returns the dynamic client interface to operate
+// against gvr, namespaced under namespace when scope is namespace-scoped and
+// cluster-wide otherwise.
+func (suite *K8sTestSuite) resourceInterfaceForScope(gvr schema.GroupVersionResource, scope meta.RESTScopeName, namespace string) dynamic.ResourceInterface {
+	client := suite.DynamicClient.Resource(gvr)
+	if scope == meta.RESTScopeNameNamespace {
+		return client.Namespace(namespace)
+	}
+	return client
+}
+
+// applyResource consults suite.ResourceHandlers for a handler registered
+// against resource's GVK (see k8s_resource_handler_test.go) and, if one is
+// registered, delegates to it; otherwise it falls back to
+// applyResourceGeneric.
+func (suite *K8sTestSuite) applyResource(resource K8sResource) (*unstructured.Unstructured, error) {
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	if suite.ResourceHandlers != nil {
+		if handler, ok := suite.ResourceHandlers.handlerFor(gvk); ok {
+			return handler.Apply(suite, resource)
+		}
+	}
+	return suite.applyResourceGeneric(resource)
+}
+
+// applyResourceGeneric stamps resource with the instance label and
+// tracking-id annotation (see k8s_resource_tracking_test.go), parses it into
+// an unstructured.Unstructured, resolves its GroupVersionResource via the
+// REST mapper, and server-side applies it with field manager
+// "learning-assistant-e2e", so arbitrary CRDs apply with no per-kind code.
+// It returns the live object as applied by the API server. Built-in
+// ResourceHandlers call this for their own Apply, so the apply path stays
+// the same regardless of whether a handler is registered.
+func (suite *K8sTestSuite) applyResourceGeneric(resource K8sResource) (*unstructured.Unstructured, error) {
+	if suite.DynamicClient == nil {
+		return nil, errors.New("applyResource requires a DynamicClient -- NewK8sTestSuite failed to build one")
+	}
+
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	mapping, err := suite.restMappingForGVK(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := resource.Metadata.Namespace
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace == "" {
+		namespace = suite.Namespace
+	}
+	suite.stampTracking(&resource.Metadata, gvk, namespace)
+
+	obj, err := resourceToUnstructured(resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s/%s to unstructured", resource.Kind, resource.Metadata.Name)
+	}
+	client := suite.resourceInterfaceForScope(mapping.Resource, mapping.Scope.Name(), namespace)
+
+	applied, err := client.Apply(suite.Context, resource.Metadata.Name, obj, metav1.ApplyOptions{
+		FieldManager: resourceEngineFieldManager,
+		Force:        true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to apply %s/%s", resource.Kind, resource.Metadata.Name)
+	}
+
+	suite.AppliedResources = append(suite.AppliedResources, AppliedResource{
+		GVK:       gvk,
+		Namespace: namespace,
+		Name:      resource.Metadata.Name,
+	})
+	suite.Logger.Info().Str("kind", resource.Kind).Str("name", resource.Metadata.Name).
+		Str("namespace", namespace).Msg("Applied resource via REST-mapped dynamic client")
+
+	return applied, nil
+}
+
+// runResourceTest evaluates test.AssertType against the live object named by
+// test.Type (its Kind) and test.Resource (its name) in test.Namespace,
+// polling every test.PollInterval (default 1s) until it passes or
+// test.PollTimeout elapses (default 2m). AssertType defaults to "condition"
+// so manifests that only set Conditions keep working unchanged.
+func (suite *K8sTestSuite) runResourceTest(test K8sResourceTest) error {
+	if test.Manifest != "" {
+		return suite.runWorkflowResourceTest(test)
+	}
+	if test.AssertType == "helmHookPhase" {
+		return suite.runHelmHookPhaseTest(test)
+	}
+
+	assertType := test.AssertType
+	if assertType == "" {
+		assertType = "condition"
+	}
+	if assertType == "ready" {
+		return suite.runResourceHandlerReadyTest(test)
+	}
+
+	interval := test.PollInterval
+	if interval <= 0 {
+		interval = defaultAssertPollInterval
+	}
+	timeout := test.PollTimeout
+	if timeout <= 0 {
+		timeout = defaultAssertPollTimeout
+	}
+
+	gvr, scope, err := suite.gvrForKind(test.Type)
+	if err != nil {
+		return err
+	}
+
+	namespace := test.Namespace
+	if namespace == "" {
+		namespace = suite.Namespace
+	}
+	client := suite.resourceInterfaceForScope(gvr, scope, namespace)
+
+	var lastErr error
+	pollErr := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		obj, getErr := client.Get(suite.Context, test.Resource, metav1.GetOptions{})
+		if assertType == "exists" {
+			lastErr = getErr
+			return getErr == nil, nil
+		}
+		if getErr != nil {
+			lastErr = getErr
+			return false, nil
+		}
+
+		ok, evalErr := evaluateResourceAssertion(assertType, obj, test)
+		if evalErr != nil {
+			lastErr = evalErr
+			return false, nil
+		}
+		return ok, nil
+	})
+	if pollErr != nil {
+		if lastErr != nil {
+			return errors.Wrapf(lastErr, "test %s (%s) did not pass within %s", test.Name, assertType, timeout)
+		}
+		return errors.Wrapf(pollErr, "test %s (%s) did not pass within %s", test.Name, assertType, timeout)
+	}
+	return nil
+}
+
+// evaluateResourceAssertion applies assertType's check to obj.
+func evaluateResourceAssertion(assertType string, obj *unstructured.Unstructured, test K8sResourceTest) (bool, error) {
+	switch assertType {
+	case "jsonpath", "condition":
+		for _, condition := range test.Conditions {
+			if err := evaluateCondition(obj, condition); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+
+	case "readyReplicas":
+		ready, _, err := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if err != nil {
+			return false, errors.Wrap(err, "failed to read status.readyReplicas")
+		}
+		want := int64(1)
+		if len(test.Conditions) > 0 {
+			parsed, err := toFloat64(test.Conditions[0].Value)
+			if err != nil {
+				return false, errors.Wrap(err, "readyReplicas condition value is not numeric")
+			}
+			want = int64(parsed)
+		}
+		return ready >= want, nil
+
+	case "rolloutComplete":
+		return rolloutComplete(obj)
+
+	default:
+		return false, errors.Errorf("unsupported assertType %q", assertType)
+	}
+}
+
+// rolloutComplete applies the same rollout-complete rule deploymentReadyReason
+// uses for typed Deployments, against an unstructured object instead -- so it
+// works for any Kind shaped like apps/v1's rollout status (replicas,
+// updatedReplicas, availableReplicas, observedGeneration vs generation).
+func rolloutComplete(obj *unstructured.Unstructured) (bool, error) {
+	generation := obj.GetGeneration()
+	observedGeneration, _, err := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read status.observedGeneration")
+	}
+	if observedGeneration < generation {
+		return false, nil
+	}
+
+	replicas, _, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read spec.replicas")
+	}
+	if replicas == 0 {
+		replicas = 1
+	}
+
+	updatedReplicas, _, err := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read status.updatedReplicas")
+	}
+	if updatedReplicas < replicas {
+		return false, nil
+	}
+
+	totalReplicas, _, err := unstructured.NestedInt64(obj.Object, "status", "replicas")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read status.replicas")
+	}
+	if totalReplicas-updatedReplicas != 0 {
+		return false, nil
+	}
+
+	availableReplicas, _, err := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read status.availableReplicas")
+	}
+	return availableReplicas >= updatedReplicas, nil
+}
+
+// ResourceFeature models sigs.k8s.io/e2e-framework's env/features pattern:
+// a named Setup/Assess/Teardown chain that RunFeature drives against the
+// suite, so a single manifest can be applied and asserted against with one
+// call. Any step may be nil to skip it.
+type ResourceFeature struct {
+	Name     string
+	Setup    func(*K8sTestSuite) error
+	Assess   func(*K8sTestSuite) error
+	Teardown func(*K8sTestSuite) error
+}
+
+// RunFeature runs feature's Setup, then Assess as a subtest (if t is
+// non-nil) or inline otherwise, then always runs Teardown regardless of
+// outcome -- mirroring e2e-framework's TestEnvironment.Test lifecycle.
+func (suite *K8sTestSuite) RunFeature(t *testing.T, feature ResourceFeature) error {
+	if feature.Teardown != nil {
+		defer func() {
+			if err := feature.Teardown(suite); err != nil {
+				suite.Logger.Warn().Err(err).Str("feature", feature.Name).Msg("feature teardown failed")
+			}
+		}()
+	}
+
+	if feature.Setup != nil {
+		if err := feature.Setup(suite); err != nil {
+			return errors.Wrapf(err, "feature %s setup failed", feature.Name)
+		}
+	}
+
+	if feature.Assess == nil {
+		return nil
+	}
+	if t == nil {
+		return feature.Assess(suite)
+	}
+
+	var assessErr error
+	t.Run(feature.Name, func(t *testing.T) {
+		if err := feature.Assess(suite); err != nil {
+			assessErr = err
+			t.Error(err)
+		}
+	})
+	return assessErr
+}
+
+// ManifestFeature builds a ResourceFeature that applies manifest in Setup and
+// runs its declarative Tests in Assess, tearing resources down in Teardown
+// when manifest.Cleanup is set -- the same manifest doubles as both the
+// apply and the assert step, as sigs.k8s.io/e2e-framework encourages.
+func ManifestFeature(manifest *K8sTestManifest) ResourceFeature {
+	return ResourceFeature{
+		Name: manifest.Name,
+		Setup: func(suite *K8sTestSuite) error {
+			return suite.ApplyTestManifest(manifest)
+		},
+		Assess: func(suite *K8sTestSuite) error {
+			for _, test := range manifest.Tests {
+				if err := suite.runResourceTest(test); err != nil {
+					return errors.Wrapf(err, "test %s", test.Name)
+				}
+			}
+			return nil
+		},
+		Teardown: func(suite *K8sTestSuite) error {
+			if !manifest.Cleanup {
+				return nil
+			}
+			suite.CleanupAppliedResources()
+			return nil
+		},
+	}
+}
@@ -0,0 +1,282 @@
+package test
+
+import (
+	"plugin"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/pkg/errors"
+)
+
+// ResourceHandler lets a Kind opt out of applyResource/runResourceTest's
+// generic REST-mapper path and supply its own apply/delete/status/wait
+// semantics -- e.g. waiting for a Deployment rollout instead of just
+// checking the object exists. Modeled on k8splugin's plugin-per-kind design.
+type ResourceHandler interface {
+	Apply(suite *K8sTestSuite, resource K8sResource) (*unstructured.Unstructured, error)
+	Delete(suite *K8sTestSuite, resource K8sResource) error
+	Status(suite *K8sTestSuite, obj *unstructured.Unstructured) (bool, error)
+	Wait(suite *K8sTestSuite, resource K8sResource, timeout time.Duration) error
+}
+
+// ResourceHandlerRegistry holds one ResourceHandler per GVK, the way
+// ServiceRegistry (gcp_registry_test.go) holds one factory per service type.
+// applyResource and runResourceTest consult it before falling back to the
+// generic unstructured/REST-mapper path.
+type ResourceHandlerRegistry struct {
+	mu    sync.RWMutex
+	byGVK map[schema.GroupVersionKind]ResourceHandler
+}
+
+// NewResourceHandlerRegistry returns a registry pre-populated with the
+// built-in Deployment/Job/Service/CRD handlers.
+func NewResourceHandlerRegistry() *ResourceHandlerRegistry {
+	r := &ResourceHandlerRegistry{byGVK: make(map[schema.GroupVersionKind]ResourceHandler)}
+	r.Register(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, deploymentResourceHandler{})
+	r.Register(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, jobResourceHandler{})
+	r.Register(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, serviceResourceHandler{})
+	r.Register(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}, crdResourceHandler{})
+	return r
+}
+
+// Register registers handler for gvk, replacing any handler already
+// registered for it -- the way test code or a plugin loaded via
+// LoadResourceHandlerPlugin overrides a built-in handler.
+func (r *ResourceHandlerRegistry) Register(gvk schema.GroupVersionKind, handler ResourceHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byGVK[gvk] = handler
+}
+
+// handlerFor returns the ResourceHandler registered for gvk, if any.
+func (r *ResourceHandlerRegistry) handlerFor(gvk schema.GroupVersionKind) (ResourceHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.byGVK[gvk]
+	return handler, ok
+}
+
+// resourceHandlerPluginSymbol is the exported symbol
+// LoadResourceHandlerPlugin looks up in the plugin.
+const resourceHandlerPluginSymbol = "RegisterResourceHandlers"
+
+// LoadResourceHandlerPlugin opens the Go plugin at path (built with `go
+// build -buildmode=plugin`) and calls its exported
+// func RegisterResourceHandlers(*ResourceHandlerRegistry), so a test binary
+// can ship custom-kind readiness semantics without this package knowing
+// about them at compile time.
+func (suite *K8sTestSuite) LoadResourceHandlerPlugin(path string) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open resource handler plugin %s", path)
+	}
+
+	sym, err := p.Lookup(resourceHandlerPluginSymbol)
+	if err != nil {
+		return errors.Wrapf(err, "plugin %s does not export %s", path, resourceHandlerPluginSymbol)
+	}
+	register, ok := sym.(func(*ResourceHandlerRegistry))
+	if !ok {
+		return errors.Errorf("plugin %s's %s has the wrong signature, want func(*ResourceHandlerRegistry)", path, resourceHandlerPluginSymbol)
+	}
+
+	register(suite.ResourceHandlers)
+	return nil
+}
+
+// resourceNamespace returns resource's namespace, defaulting to
+// suite.Namespace the same way applyResource and runResourceTest do.
+func resourceNamespace(suite *K8sTestSuite, resource K8sResource) string {
+	if resource.Metadata.Namespace != "" {
+		return resource.Metadata.Namespace
+	}
+	return suite.Namespace
+}
+
+// pollResourceHandlerReady polls check every defaultAssertPollInterval until
+// it reports ready or timeout elapses, the shared loop every built-in
+// handler's Wait uses.
+func (suite *K8sTestSuite) pollResourceHandlerReady(timeout time.Duration, check func() (bool, error)) error {
+	var lastErr error
+	pollErr := wait.PollImmediate(defaultAssertPollInterval, timeout, func() (bool, error) {
+		ready, err := check()
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		return ready, nil
+	})
+	if pollErr != nil {
+		if lastErr != nil {
+			return errors.Wrapf(lastErr, "not ready within %s", timeout)
+		}
+		return errors.Wrapf(pollErr, "not ready within %s", timeout)
+	}
+	return nil
+}
+
+// deploymentResourceHandler waits for the Helm 3 kstatus rollout-complete
+// rule deploymentReadyReason (k8s_readiness_test.go) already applies to
+// typed Deployments elsewhere in this package.
+type deploymentResourceHandler struct{}
+
+func (deploymentResourceHandler) Apply(suite *K8sTestSuite, resource K8sResource) (*unstructured.Unstructured, error) {
+	return suite.applyResourceGeneric(resource)
+}
+
+func (deploymentResourceHandler) Delete(suite *K8sTestSuite, resource K8sResource) error {
+	return suite.deleteWorkflowResource(resource)
+}
+
+func (deploymentResourceHandler) Status(suite *K8sTestSuite, obj *unstructured.Unstructured) (bool, error) {
+	dep, err := suite.Clientset.AppsV1().Deployments(obj.GetNamespace()).Get(suite.Context, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	_, ready := deploymentReadyReason(dep)
+	return ready, nil
+}
+
+func (deploymentResourceHandler) Wait(suite *K8sTestSuite, resource K8sResource, timeout time.Duration) error {
+	namespace := resourceNamespace(suite, resource)
+	return suite.pollResourceHandlerReady(timeout, func() (bool, error) {
+		dep, err := suite.Clientset.AppsV1().Deployments(namespace).Get(suite.Context, resource.Metadata.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		_, ready := deploymentReadyReason(dep)
+		return ready, nil
+	})
+}
+
+// jobResourceHandler waits for jobReadyReason's JobComplete condition.
+type jobResourceHandler struct{}
+
+func (jobResourceHandler) Apply(suite *K8sTestSuite, resource K8sResource) (*unstructured.Unstructured, error) {
+	return suite.applyResourceGeneric(resource)
+}
+
+func (jobResourceHandler) Delete(suite *K8sTestSuite, resource K8sResource) error {
+	return suite.deleteWorkflowResource(resource)
+}
+
+func (jobResourceHandler) Status(suite *K8sTestSuite, obj *unstructured.Unstructured) (bool, error) {
+	job, err := suite.Clientset.BatchV1().Jobs(obj.GetNamespace()).Get(suite.Context, obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	_, ready := jobReadyReason(job)
+	return ready, nil
+}
+
+func (jobResourceHandler) Wait(suite *K8sTestSuite, resource K8sResource, timeout time.Duration) error {
+	namespace := resourceNamespace(suite, resource)
+	return suite.pollResourceHandlerReady(timeout, func() (bool, error) {
+		job, err := suite.Clientset.BatchV1().Jobs(namespace).Get(suite.Context, resource.Metadata.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		reason, ready := jobReadyReason(job)
+		if !ready && reason != "no JobComplete condition yet" {
+			return false, errors.New(reason)
+		}
+		return ready, nil
+	})
+}
+
+// serviceResourceHandler waits for the Service's Endpoints to have at least
+// one ready address, rather than serviceReadyReason's LoadBalancer-only
+// check -- this is the readiness signal that matters for ClusterIP Services,
+// which is what most test manifests use.
+type serviceResourceHandler struct{}
+
+func (serviceResourceHandler) Apply(suite *K8sTestSuite, resource K8sResource) (*unstructured.Unstructured, error) {
+	return suite.applyResourceGeneric(resource)
+}
+
+func (serviceResourceHandler) Delete(suite *K8sTestSuite, resource K8sResource) error {
+	return suite.deleteWorkflowResource(resource)
+}
+
+func (serviceResourceHandler) Status(suite *K8sTestSuite, obj *unstructured.Unstructured) (bool, error) {
+	return suite.serviceHasEndpoints(obj.GetNamespace(), obj.GetName())
+}
+
+func (serviceResourceHandler) Wait(suite *K8sTestSuite, resource K8sResource, timeout time.Duration) error {
+	namespace := resourceNamespace(suite, resource)
+	return suite.pollResourceHandlerReady(timeout, func() (bool, error) {
+		return suite.serviceHasEndpoints(namespace, resource.Metadata.Name)
+	})
+}
+
+// serviceHasEndpoints reports whether the Endpoints object for name in
+// namespace has at least one subset with a ready address.
+func (suite *K8sTestSuite) serviceHasEndpoints(namespace, name string) (bool, error) {
+	endpoints, err := suite.Clientset.CoreV1().Endpoints(namespace).Get(suite.Context, name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// crdResourceHandler waits for crdReadyReason's Established condition.
+type crdResourceHandler struct{}
+
+func (crdResourceHandler) Apply(suite *K8sTestSuite, resource K8sResource) (*unstructured.Unstructured, error) {
+	return suite.applyResourceGeneric(resource)
+}
+
+func (crdResourceHandler) Delete(suite *K8sTestSuite, resource K8sResource) error {
+	return suite.deleteWorkflowResource(resource)
+}
+
+func (crdResourceHandler) Status(suite *K8sTestSuite, obj *unstructured.Unstructured) (bool, error) {
+	_, ready := suite.crdReadyReason(suite.Context, obj.GetName())
+	return ready, nil
+}
+
+func (crdResourceHandler) Wait(suite *K8sTestSuite, resource K8sResource, timeout time.Duration) error {
+	return suite.pollResourceHandlerReady(timeout, func() (bool, error) {
+		reason, ready := suite.crdReadyReason(suite.Context, resource.Metadata.Name)
+		if !ready && reason == "CRD not found" {
+			return false, nil
+		}
+		return ready, nil
+	})
+}
+
+// runResourceHandlerReadyTest backs AssertType "ready": it delegates to the
+// ResourceHandler registered for test.Type's GVK, erroring out if none is
+// registered rather than silently treating the test as passed.
+func (suite *K8sTestSuite) runResourceHandlerReadyTest(test K8sResourceTest) error {
+	mapping, err := suite.restMappingForGVK(schema.GroupVersionKind{Kind: test.Type})
+	if err != nil {
+		return err
+	}
+
+	timeout := test.PollTimeout
+	if timeout <= 0 {
+		timeout = defaultAssertPollTimeout
+	}
+	resource := K8sResource{
+		Kind:     test.Type,
+		Metadata: metav1.ObjectMeta{Name: test.Resource, Namespace: test.Namespace},
+	}
+
+	if suite.ResourceHandlers != nil {
+		if handler, ok := suite.ResourceHandlers.handlerFor(mapping.GroupVersionKind); ok {
+			return handler.Wait(suite, resource, timeout)
+		}
+	}
+	return errors.Errorf("test %s: assertType \"ready\" has no ResourceHandler registered for kind %s", test.Name, test.Type)
+}
@@ -0,0 +1,249 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// containerLogTailLines bounds how many trailing log lines
+// captureContainerLogs fetches per container, so a crash-looping pod
+// doesn't blow up a TestRun's Logs field.
+const containerLogTailLines = 200
+
+// SuiteResult is RunTestManifest's structured record of a manifest run,
+// modeled on Helm's releasetesting TestSuite: a start/completion timestamp
+// plus one TestRun per K8sResourceTest, so results can be consumed by CI
+// dashboards via a Reporter instead of scraping go test output.
+type SuiteResult struct {
+	Name        string    `json:"name"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	Tests       []TestRun `json:"tests"`
+}
+
+// TestRun is one K8sResourceTest's outcome within a SuiteResult: the phase
+// it reached, a human status message, its wall-clock window, the digest of
+// the manifest it was applied from, and -- on failure -- the
+// namespace-scoped events and container logs captured for diagnosability.
+type TestRun struct {
+	Name           string    `json:"name"`
+	Phase          string    `json:"phase"` // "Succeeded" or "Failed", mirroring Helm's release-testing hook phases
+	Status         string    `json:"status"`
+	Message        string    `json:"message,omitempty"`
+	StartedAt      time.Time `json:"startedAt"`
+	CompletedAt    time.Time `json:"completedAt"`
+	ManifestDigest string    `json:"manifestDigest,omitempty"`
+	Events         []string  `json:"events,omitempty"`
+	Logs           string    `json:"logs,omitempty"`
+}
+
+// Reporter renders a completed SuiteResult somewhere outside the test
+// binary -- a file a CI dashboard ingests, or a human reading the
+// terminal -- so RunTestManifest's callers aren't limited to t.Errorf.
+type Reporter interface {
+	Report(result SuiteResult) error
+}
+
+// runManifestOptions configures a single RunTestManifest call.
+type runManifestOptions struct {
+	reporters []Reporter
+}
+
+// RunOption customizes RunTestManifest.
+type RunOption func(*runManifestOptions)
+
+// WithReporters attaches one or more Reporters that receive the manifest's
+// SuiteResult once every test has run, alongside the t.Errorf calls
+// RunTestManifest already makes.
+func WithReporters(reporters ...Reporter) RunOption {
+	return func(o *runManifestOptions) { o.reporters = append(o.reporters, reporters...) }
+}
+
+// JUnitReporter writes a SuiteResult as a JUnit XML report at Path, reusing
+// the same JUnitTestSuite schema RunManifest and the compliance/DR reporters
+// write, so every reporter in this codebase feeds the same CI tooling.
+type JUnitReporter struct {
+	Path string
+}
+
+// Report implements Reporter.
+func (r JUnitReporter) Report(result SuiteResult) error {
+	suite := JUnitTestSuite{
+		Name: result.Name,
+		Time: result.CompletedAt.Sub(result.StartedAt).Seconds(),
+	}
+	for _, run := range result.Tests {
+		tc := JUnitTestCase{Name: run.Name, Time: run.CompletedAt.Sub(run.StartedAt).Seconds()}
+		if run.Status == "failed" {
+			tc.Failure = &JUnitFailure{Message: run.Phase, Text: run.Message}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+	}
+	return writeManifestJUnitReport(r.Path, suite)
+}
+
+// jsonLinesRecord is a single line JSONLinesReporter writes -- one line per
+// TestRun, with the owning suite's name and start time denormalized onto it
+// so each line stands alone for tools that tail the file rather than
+// parsing it as one JSON document.
+type jsonLinesRecord struct {
+	Suite          string    `json:"suite"`
+	SuiteStartedAt time.Time `json:"suiteStartedAt"`
+	TestRun
+}
+
+// JSONLinesReporter writes a SuiteResult as one JSON object per TestRun,
+// newline-delimited, at Path -- the format log aggregators (Loki, Splunk,
+// CloudWatch) expect without a custom parser.
+type JSONLinesReporter struct {
+	Path string
+}
+
+// Report implements Reporter.
+func (r JSONLinesReporter) Report(result SuiteResult) error {
+	file, err := os.Create(r.Path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create JSON lines report %s", r.Path)
+	}
+	defer file.Close()
+
+	enc := json.NewEncoder(file)
+	for _, run := range result.Tests {
+		record := jsonLinesRecord{Suite: result.Name, SuiteStartedAt: result.StartedAt, TestRun: run}
+		if err := enc.Encode(record); err != nil {
+			return errors.Wrapf(err, "failed to encode test run %s", run.Name)
+		}
+	}
+	return nil
+}
+
+// TerminalReporter writes a human-readable pass/fail summary to Writer
+// (os.Stdout when nil), for local runs and CI log output.
+type TerminalReporter struct {
+	Writer io.Writer
+}
+
+// Report implements Reporter.
+func (r TerminalReporter) Report(result SuiteResult) error {
+	w := r.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+
+	fmt.Fprintf(w, "Suite %s: %d test(s) in %s\n", result.Name, len(result.Tests), result.CompletedAt.Sub(result.StartedAt))
+	for _, run := range result.Tests {
+		status := "PASS"
+		if run.Status == "failed" {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "  [%s] %s (%s)\n", status, run.Name, run.CompletedAt.Sub(run.StartedAt))
+		if run.Status == "failed" {
+			fmt.Fprintf(w, "        %s\n", run.Message)
+		}
+	}
+	return nil
+}
+
+// manifestDigest returns a short sha256 digest of manifest's resources, so a
+// SuiteResult's TestRuns can be traced back to exactly what was applied
+// without embedding the full (and often large) manifest in every report.
+func manifestDigest(manifest *K8sTestManifest) string {
+	data, err := yaml.Marshal(manifest.Resources)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// formatEvents renders events as "Type/Reason: Message" strings for
+// TestRun.Events.
+func formatEvents(events []corev1.Event) []string {
+	formatted := make([]string, 0, len(events))
+	for _, event := range events {
+		formatted = append(formatted, fmt.Sprintf("%s/%s: %s", event.Type, event.Reason, event.Message))
+	}
+	return formatted
+}
+
+// captureContainerLogs best-effort fetches the tail of every container's
+// logs for the pods backing resourceName in namespace, for attaching to a
+// failed TestRun -- mirroring logHelmHookPodLogs' "helm test --logs" style
+// capture, generalized to any resource kind via podsForDiagnostics.
+func (suite *K8sTestSuite) captureContainerLogs(namespace, resourceName string) string {
+	pods, err := suite.podsForDiagnostics(namespace, resourceName)
+	if err != nil || len(pods) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	tail := int64(containerLogTailLines)
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			stream, err := suite.Clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				TailLines: &tail,
+			}).Stream(suite.Context)
+			if err != nil {
+				continue
+			}
+			logs, readErr := io.ReadAll(stream)
+			stream.Close()
+			if readErr != nil {
+				continue
+			}
+			fmt.Fprintf(&out, "--- %s/%s ---\n%s\n", pod.Name, container.Name, logs)
+		}
+	}
+	return out.String()
+}
+
+// podsForDiagnostics resolves the pods backing resourceName: directly by
+// name for a Pod, by job-name label for a Job, and otherwise by the
+// app.kubernetes.io/instance label stampTracking set, narrowed to pods whose
+// name has resourceName as a prefix -- the naming scheme
+// Deployments/StatefulSets/Jobs use for their generated Pods.
+func (suite *K8sTestSuite) podsForDiagnostics(namespace, resourceName string) ([]corev1.Pod, error) {
+	if pod, err := suite.Clientset.CoreV1().Pods(namespace).Get(suite.Context, resourceName, metav1.GetOptions{}); err == nil {
+		return []corev1.Pod{*pod}, nil
+	}
+
+	if pods, err := suite.Clientset.CoreV1().Pods(namespace).List(suite.Context, metav1.ListOptions{
+		LabelSelector: "job-name=" + resourceName,
+	}); err == nil && len(pods.Items) > 0 {
+		return pods.Items, nil
+	}
+
+	list, err := suite.Clientset.CoreV1().Pods(namespace).List(suite.Context, metav1.ListOptions{
+		LabelSelector: instanceLabel + "=" + suite.TestID,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list pods for %s diagnostics", resourceName)
+	}
+
+	var matched []corev1.Pod
+	for _, pod := range list.Items {
+		if strings.HasPrefix(pod.Name, resourceName) {
+			matched = append(matched, pod)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// instanceLabel/trackingIDAnnotation borrow argo-cd's resource-tracking
+// idea: every object applyResource applies carries both, so
+// CleanupByTrackingID can find and reclaim them later even if
+// suite.AppliedResources never saw them (e.g. the process crashed between
+// apply and the deferred Cleanup).
+const (
+	instanceLabel        = "app.kubernetes.io/instance"
+	trackingIDAnnotation = "learning-assistant.io/tracking-id"
+)
+
+// trackingIDFor encodes gvk/namespace/name/suiteID into the tracking-id
+// annotation value, matching argo-cd's "group/kind/namespace/name"
+// resource key with the owning suite ID appended.
+func trackingIDFor(gvk schema.GroupVersionKind, namespace, name, suiteID string) string {
+	return fmt.Sprintf("%s/%s/%s/%s:%s", gvk.Group, gvk.Kind, namespace, name, suiteID)
+}
+
+// stampTracking sets the instance label and tracking-id annotation on meta
+// so CleanupByTrackingID can later find and reclaim it, without disturbing
+// any labels/annotations the caller already set.
+func (suite *K8sTestSuite) stampTracking(meta *metav1.ObjectMeta, gvk schema.GroupVersionKind, namespace string) {
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	meta.Labels[instanceLabel] = suite.TestID
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[trackingIDAnnotation] = trackingIDFor(gvk, namespace, meta.Name, suite.TestID)
+}
+
+// ownsTrackedObject reports whether obj's tracking-id annotation encodes
+// this suite's TestID, so CleanupByTrackingID only ever deletes objects
+// this suite run actually applied -- not a human's object that happens to
+// share the instance label, nor another suite's run in a shared namespace.
+func (suite *K8sTestSuite) ownsTrackedObject(obj *unstructured.Unstructured) bool {
+	id, ok := obj.GetAnnotations()[trackingIDAnnotation]
+	return ok && strings.HasSuffix(id, ":"+suite.TestID)
+}
+
+// namespacesToScan returns suite.Namespace plus every namespace in
+// suite.TestNamespaces, deduplicated, for CleanupByTrackingID to list
+// against.
+func (suite *K8sTestSuite) namespacesToScan() []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	add := func(namespace string) {
+		if namespace == "" || seen[namespace] {
+			return
+		}
+		seen[namespace] = true
+		namespaces = append(namespaces, namespace)
+	}
+
+	add(suite.Namespace)
+	for _, namespace := range suite.TestNamespaces {
+		add(namespace)
+	}
+	return namespaces
+}
+
+// CleanupByTrackingID lists objects labeled app.kubernetes.io/instance=
+// suite.TestID, for every Kind in manifestGVRs, across namespacesToScan(),
+// and deletes only those whose tracking-id annotation confirms this suite
+// applied them. Unlike CleanupAppliedResources, this doesn't depend on
+// suite.AppliedResources still being populated in memory, so it also
+// reclaims objects left behind by a previous run that panicked or crashed
+// before its own Cleanup ran -- while never touching an object a parallel
+// suite run or a human created in a namespace this suite happens to share.
+func (suite *K8sTestSuite) CleanupByTrackingID() {
+	if suite.DynamicClient == nil {
+		return
+	}
+
+	selector := instanceLabel + "=" + suite.TestID
+	for kind, gvr := range manifestGVRs {
+		for _, namespace := range suite.namespacesToScan() {
+			list, err := suite.DynamicClient.Resource(gvr).Namespace(namespace).List(suite.Context, metav1.ListOptions{
+				LabelSelector: selector,
+			})
+			if err != nil {
+				suite.Logger.Warn().Err(err).Str("kind", kind).Str("namespace", namespace).Msg("failed to list tracked objects")
+				continue
+			}
+
+			for _, obj := range list.Items {
+				obj := obj
+				if !suite.ownsTrackedObject(&obj) {
+					continue
+				}
+				if err := suite.DynamicClient.Resource(gvr).Namespace(namespace).Delete(suite.Context, obj.GetName(), metav1.DeleteOptions{}); err != nil {
+					suite.Logger.Warn().Err(err).Str("kind", kind).Str("name", obj.GetName()).Msg("failed to delete tracked object")
+					continue
+				}
+				suite.Logger.Info().Str("kind", kind).Str("namespace", namespace).Str("name", obj.GetName()).Msg("deleted tracked object")
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// resourceConditionExpr is a parsed "status.phase == Succeeded"-style
+// expression, mirroring Argo Workflows' resource template successCondition/
+// failureCondition syntax.
+type resourceConditionExpr struct {
+	Field    string
+	Operator string
+	Value    string
+}
+
+// conditionExprPattern splits "<field> <op> <value>" where op is one of the
+// comparison operators below and value runs to the end of the string (so
+// values containing spaces, like "Pending Termination", still parse).
+var conditionExprPattern = regexp.MustCompile(`^\s*(\S+)\s*(==|!=|>=|<=|>|<)\s*(.+?)\s*$`)
+
+// parseConditionExpr parses expr into its field/operator/value parts.
+func parseConditionExpr(expr string) (resourceConditionExpr, error) {
+	matches := conditionExprPattern.FindStringSubmatch(expr)
+	if matches == nil {
+		return resourceConditionExpr{}, errors.Errorf("invalid condition expression %q, want \"<field> <op> <value>\"", expr)
+	}
+	return resourceConditionExpr{Field: matches[1], Operator: matches[2], Value: matches[3]}, nil
+}
+
+// evaluateConditionExpr reads expr.Field out of obj (a dotted path, e.g.
+// "status.phase") and compares it against expr.Value using expr.Operator.
+// A missing field is treated as not matching rather than an error, so
+// FailureCondition checks against fields that don't exist yet don't trip
+// early in a resource's lifecycle.
+func evaluateConditionExpr(obj *unstructured.Unstructured, expr resourceConditionExpr) (bool, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, splitFieldPath(expr.Field)...)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read field %s", expr.Field)
+	}
+	if !found {
+		return false, nil
+	}
+
+	switch expr.Operator {
+	case "==":
+		return fmt.Sprintf("%v", value) == expr.Value, nil
+	case "!=":
+		return fmt.Sprintf("%v", value) != expr.Value, nil
+	case ">", "<", ">=", "<=":
+		got, err := toFloat64(value)
+		if err != nil {
+			return false, errors.Wrapf(err, "condition %s", expr.Field)
+		}
+		want, err := strconv.ParseFloat(expr.Value, 64)
+		if err != nil {
+			return false, errors.Wrapf(err, "condition %s: value %q is not numeric", expr.Field, expr.Value)
+		}
+		switch expr.Operator {
+		case ">":
+			return got > want, nil
+		case "<":
+			return got < want, nil
+		case ">=":
+			return got >= want, nil
+		default:
+			return got <= want, nil
+		}
+	default:
+		return false, errors.Errorf("condition %s: unsupported operator %q", expr.Field, expr.Operator)
+	}
+}
+
+// runWorkflowResourceTest implements the Argo Workflows-style resource
+// template path: parse test.Manifest, perform test.Action against it, then
+// poll the resulting object until SuccessCondition matches (pass),
+// FailureCondition matches (fail fast), or PollTimeout elapses (fail).
+func (suite *K8sTestSuite) runWorkflowResourceTest(test K8sResourceTest) error {
+	var resource K8sResource
+	if err := yaml.Unmarshal([]byte(test.Manifest), &resource); err != nil {
+		return errors.Wrapf(err, "test %s: failed to parse inline manifest", test.Name)
+	}
+	if resource.Metadata.Namespace == "" {
+		resource.Metadata.Namespace = test.Namespace
+	}
+
+	action := test.Action
+	if action == "" {
+		action = "apply"
+	}
+
+	switch action {
+	case "create", "apply", "patch":
+		if _, err := suite.applyResource(resource); err != nil {
+			return errors.Wrapf(err, "test %s: failed to %s resource", test.Name, action)
+		}
+	case "delete":
+		if err := suite.deleteWorkflowResource(resource); err != nil {
+			return errors.Wrapf(err, "test %s: failed to delete resource", test.Name)
+		}
+	default:
+		return errors.Errorf("test %s: unsupported action %q", test.Name, action)
+	}
+
+	if test.SuccessCondition == "" && test.FailureCondition == "" {
+		return nil
+	}
+
+	var successExpr, failureExpr *resourceConditionExpr
+	if test.SuccessCondition != "" {
+		expr, err := parseConditionExpr(test.SuccessCondition)
+		if err != nil {
+			return errors.Wrapf(err, "test %s", test.Name)
+		}
+		successExpr = &expr
+	}
+	if test.FailureCondition != "" {
+		expr, err := parseConditionExpr(test.FailureCondition)
+		if err != nil {
+			return errors.Wrapf(err, "test %s", test.Name)
+		}
+		failureExpr = &expr
+	}
+
+	interval := test.PollInterval
+	if interval <= 0 {
+		interval = defaultAssertPollInterval
+	}
+	timeout := test.PollTimeout
+	if timeout <= 0 {
+		timeout = defaultAssertPollTimeout
+	}
+
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	mapping, err := suite.restMappingForGVK(gvk)
+	if err != nil {
+		return errors.Wrapf(err, "test %s", test.Name)
+	}
+	namespace := resource.Metadata.Namespace
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace == "" {
+		namespace = suite.Namespace
+	}
+	client := suite.resourceInterfaceForScope(mapping.Resource, mapping.Scope.Name(), namespace)
+
+	var lastErr error
+	pollErr := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		obj, getErr := client.Get(suite.Context, resource.Metadata.Name, metav1.GetOptions{})
+		if getErr != nil {
+			lastErr = getErr
+			return false, nil
+		}
+
+		if failureExpr != nil {
+			if matched, evalErr := evaluateConditionExpr(obj, *failureExpr); evalErr == nil && matched {
+				lastErr = errors.Errorf("failureCondition %q matched", test.FailureCondition)
+				return false, lastErr
+			}
+		}
+
+		if successExpr == nil {
+			return true, nil
+		}
+		matched, evalErr := evaluateConditionExpr(obj, *successExpr)
+		if evalErr != nil {
+			lastErr = evalErr
+			return false, nil
+		}
+		return matched, nil
+	})
+	if pollErr != nil {
+		if lastErr != nil {
+			return errors.Wrapf(lastErr, "test %s did not reach success condition within %s", test.Name, timeout)
+		}
+		return errors.Wrapf(pollErr, "test %s did not reach success condition within %s", test.Name, timeout)
+	}
+	return nil
+}
+
+// deleteWorkflowResource resolves resource's GVR via the REST mapper and
+// deletes it, for the Action: delete path.
+func (suite *K8sTestSuite) deleteWorkflowResource(resource K8sResource) error {
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	mapping, err := suite.restMappingForGVK(gvk)
+	if err != nil {
+		return err
+	}
+	namespace := resource.Metadata.Namespace
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace == "" {
+		namespace = suite.Namespace
+	}
+	client := suite.resourceInterfaceForScope(mapping.Resource, mapping.Scope.Name(), namespace)
+	return client.Delete(suite.Context, resource.Metadata.Name, metav1.DeleteOptions{})
+}
@@ -0,0 +1,266 @@
+package test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/subscription/armsubscription"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/latestresolve"
+)
+
+// resolvedMultiCloudConfigPath is where ResolveLatestVersions persists a
+// fully sentinel-resolved MultiCloudConfig after an initial "planning"
+// run, via MarshalResolved. NewMultiCloudTestSuite prefers this file over
+// multi-cloud-config.yaml when it exists, so subsequent runs consume the
+// pinned config verbatim instead of re-resolving against live provider
+// SDKs.
+const resolvedMultiCloudConfigPath = "multi-cloud-config.resolved.yaml"
+
+// latestResolutionCachePath caches sentinel -> concrete-value
+// resolutions between runs, independent of resolvedMultiCloudConfigPath --
+// once its TTL elapses, ResolveLatestVersions re-queries the provider
+// SDKs even if a resolved config file was never produced.
+const latestResolutionCachePath = ".multi-cloud-cache.json"
+
+// defaultLatestResolutionTTL bounds how long a cached sentinel resolution
+// is trusted before ResolveLatestVersions re-queries the provider SDKs.
+const defaultLatestResolutionTTL = 24 * time.Hour
+
+// MarshalResolved YAML-marshals config for persistence to
+// resolvedMultiCloudConfigPath once every "latest"/"latest-N" sentinel in
+// it has been expanded to a concrete value.
+func (config MultiCloudConfig) MarshalResolved() ([]byte, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal resolved multi-cloud config")
+	}
+	return data, nil
+}
+
+// LoadResolvedMultiCloudConfig loads a previously persisted
+// resolvedMultiCloudConfigPath, if one exists, reporting ok=false (not an
+// error) when it doesn't -- the caller falls back to resolving sentinels
+// fresh in that case.
+func LoadResolvedMultiCloudConfig(path string) (config MultiCloudConfig, ok bool, err error) {
+	if !files.FileExists(path) {
+		return MultiCloudConfig{}, false, nil
+	}
+
+	data, err := files.ReadFile(path)
+	if err != nil {
+		return MultiCloudConfig{}, false, errors.Wrapf(err, "failed to read resolved multi-cloud config %s", path)
+	}
+	if err := yaml.Unmarshal([]byte(data), &config); err != nil {
+		return MultiCloudConfig{}, false, errors.Wrapf(err, "failed to parse resolved multi-cloud config %s", path)
+	}
+	return config, true, nil
+}
+
+// ResolveLatestVersions expands every "latest"/"latest-N" sentinel in
+// suite.MultiCloudConfig's CloudProvider.Regions, BackupConfig.Targets[].
+// StorageClass, and IntegrationTest.Parameters into a concrete pinned
+// value, then persists the result to resolvedMultiCloudConfigPath.
+//
+// This has to run after initializeCloudProviderSuites rather than inside
+// LoadMultiCloudConfig itself, since resolving a sentinel means querying
+// the already-constructed provider clients (AWS EC2 DescribeRegions, GCP
+// compute.regions.list, Azure Locations - List, or the live Kubernetes
+// cluster's reported server version) -- none of which exist yet at the
+// point LoadMultiCloudConfig runs.
+//
+// CloudProvider.Services has no meaningful "latest" -- service
+// identifiers (e.g. "ec2", "lambda") aren't time-ordered releases -- so
+// sentinels there are left unresolved; callers should pin service lists
+// explicitly.
+func (suite *MultiCloudTestSuite) ResolveLatestVersions(ctx context.Context) error {
+	cache, err := latestresolve.LoadCache(latestResolutionCachePath, defaultLatestResolutionTTL)
+	if err != nil {
+		return errors.Wrap(err, "failed to load latest-resolution cache")
+	}
+
+	for i := range suite.MultiCloudConfig.Providers {
+		provider := &suite.MultiCloudConfig.Providers[i]
+		candidates := suite.regionCandidates(provider.Name)
+		for j, region := range provider.Regions {
+			resolved, err := latestresolve.Resolve(ctx, cache, "region/"+provider.Name+"/"+region, region, candidates)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve region sentinel for provider %q", provider.Name)
+			}
+			provider.Regions[j] = resolved
+		}
+	}
+
+	for i := range suite.MultiCloudConfig.BackupConfig.Targets {
+		target := &suite.MultiCloudConfig.BackupConfig.Targets[i]
+		resolved, err := latestresolve.Resolve(ctx, cache, "storage-class/"+target.Provider, target.StorageClass, storageClassCandidates(target.Provider))
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve storage class sentinel for provider %q", target.Provider)
+		}
+		target.StorageClass = resolved
+	}
+
+	for i := range suite.MultiCloudConfig.IntegrationTests {
+		test := &suite.MultiCloudConfig.IntegrationTests[i]
+		for key, value := range test.Parameters {
+			resolved, err := latestresolve.Resolve(ctx, cache, "parameter/"+test.Name+"/"+key, value, suite.versionCandidates(key))
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve %q sentinel for integration test %q", key, test.Name)
+			}
+			test.Parameters[key] = resolved
+		}
+	}
+
+	for variant, measurements := range suite.MultiCloudConfig.SecurityConfig.Attestation.Variants {
+		resolvedBootloader, err := latestresolve.Resolve(ctx, cache, "attestation/"+variant+"/bootloader_version", measurements.BootloaderVersion, attestationVersionCandidates(variant, "bootloader_version"))
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve bootloader version sentinel for attestation variant %q", variant)
+		}
+		measurements.BootloaderVersion = resolvedBootloader
+
+		resolvedTEE, err := latestresolve.Resolve(ctx, cache, "attestation/"+variant+"/tee_version", measurements.TEEVersion, attestationVersionCandidates(variant, "tee_version"))
+		if err != nil {
+			return errors.Wrapf(err, "failed to resolve TEE version sentinel for attestation variant %q", variant)
+		}
+		measurements.TEEVersion = resolvedTEE
+
+		suite.MultiCloudConfig.SecurityConfig.Attestation.Variants[variant] = measurements
+	}
+
+	if err := cache.Save(latestResolutionCachePath); err != nil {
+		return errors.Wrap(err, "failed to persist latest-resolution cache")
+	}
+
+	data, err := suite.MultiCloudConfig.MarshalResolved()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(resolvedMultiCloudConfigPath, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write resolved multi-cloud config to %s", resolvedMultiCloudConfigPath)
+	}
+
+	return nil
+}
+
+// regionCandidates lists providerName's available regions via its already
+// initialized suite client. Cloud provider SDKs don't expose a region's
+// launch date, so "latest" resolves to the first region the provider's
+// list call happens to return and "latest-N" to the Nth -- a positional
+// approximation, not a true recency ordering.
+func (suite *MultiCloudTestSuite) regionCandidates(providerName string) latestresolve.Candidates {
+	return func(ctx context.Context) ([]string, error) {
+		switch providerName {
+		case "aws":
+			if suite.AWSTestSuite == nil {
+				return nil, errors.New("AWS test suite is not initialized")
+			}
+			output, err := suite.AWSTestSuite.EC2().DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to describe AWS regions")
+			}
+			var regions []string
+			for _, region := range output.Regions {
+				if region.RegionName != nil {
+					regions = append(regions, *region.RegionName)
+				}
+			}
+			return regions, nil
+		case "gcp":
+			if suite.GCPTestSuite == nil || suite.GCPTestSuite.Compute == nil {
+				return nil, errors.New("GCP test suite is not initialized")
+			}
+			var regions []string
+			response, err := suite.GCPTestSuite.Compute.Regions.List(suite.GCPTestSuite.ProjectID).Context(ctx).Do()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list GCP regions")
+			}
+			for _, region := range response.Items {
+				regions = append(regions, region.Name)
+			}
+			return regions, nil
+		case "azure":
+			if suite.AzureTestSuite == nil {
+				return nil, errors.New("Azure test suite is not initialized")
+			}
+			client, err := armsubscription.NewSubscriptionsClient(suite.AzureTestSuite.Credential, suite.AzureTestSuite.ArmOptions)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create Azure subscriptions client")
+			}
+			var regions []string
+			pager := client.NewListLocationsPager(suite.AzureTestSuite.SubscriptionID, nil)
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to list Azure locations")
+				}
+				for _, location := range page.Value {
+					if location.Name != nil {
+						regions = append(regions, *location.Name)
+					}
+				}
+			}
+			return regions, nil
+		default:
+			return nil, errors.Errorf("no region resolver for provider %q", providerName)
+		}
+	}
+}
+
+// storageClassCandidates is a maintained, newest-announced-first list of
+// each provider's storage tiers/classes. Provider SDKs have no API that
+// reports when a storage class was introduced, so this list is
+// hand-maintained rather than queried live.
+func storageClassCandidates(providerName string) latestresolve.Candidates {
+	classes := map[string][]string{
+		"aws":   {"EXPRESS_ONEZONE", "INTELLIGENT_TIERING", "STANDARD", "STANDARD_IA", "ONEZONE_IA", "GLACIER_IR", "GLACIER", "DEEP_ARCHIVE"},
+		"gcp":   {"STANDARD", "NEARLINE", "COLDLINE", "ARCHIVE"},
+		"azure": {"Hot", "Cool", "Cold", "Archive"},
+	}
+	return func(ctx context.Context) ([]string, error) {
+		options, ok := classes[providerName]
+		if !ok {
+			return nil, errors.Errorf("no storage class resolver for provider %q", providerName)
+		}
+		return options, nil
+	}
+}
+
+// versionCandidates resolves an IntegrationTest.Parameters entry's
+// "latest"/"latest-N" sentinel. Only "k8s" is supported: it reports the
+// live cluster's own Discovery().ServerVersion() as its sole candidate,
+// since this harness has no upstream Kubernetes release feed to pick "the
+// newest patch of the current minor" from -- the cluster's running
+// version is the only version information actually available to it.
+func (suite *MultiCloudTestSuite) versionCandidates(key string) latestresolve.Candidates {
+	return func(ctx context.Context) ([]string, error) {
+		if key != "k8s" {
+			return nil, errors.Errorf("no version resolver for parameter %q", key)
+		}
+		if suite.K8sTestSuite == nil || suite.K8sTestSuite.Clientset == nil {
+			return nil, errors.New("Kubernetes test suite is not initialized")
+		}
+		version, err := suite.K8sTestSuite.Clientset.Discovery().ServerVersion()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to discover Kubernetes server version")
+		}
+		return []string{version.GitVersion}, nil
+	}
+}
+
+// attestationVersionCandidates would resolve an AttestationMeasurements
+// variant/field's "latest"/"latest-N" sentinel against a signed
+// attestation config API (the way Constellation resolves "latest" image
+// versions). This suite has no such API wired up, so a config that uses
+// the sentinel here gets a clear resolution error instead of a silently
+// stale or fabricated version -- the same shape as versionCandidates
+// above for unsupported parameter keys.
+func attestationVersionCandidates(variant, field string) latestresolve.Candidates {
+	return func(ctx context.Context) ([]string, error) {
+		return nil, errors.Errorf("no signed attestation config API is configured to resolve %q for variant %q", field, variant)
+	}
+}
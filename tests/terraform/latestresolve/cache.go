@@ -0,0 +1,86 @@
+package latestresolve
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CacheEntry is one sentinel's resolved value and when it was resolved.
+type CacheEntry struct {
+	Value      string    `json:"value"`
+	ResolvedAt time.Time `json:"resolved_at"`
+}
+
+// Cache persists resolved sentinel values to a local JSON file (e.g.
+// ".multi-cloud-cache.json") so a "planning" run's resolution survives
+// into subsequent test runs without re-querying provider SDKs every time.
+type Cache struct {
+	mu      sync.Mutex
+	Entries map[string]CacheEntry `json:"entries"`
+	TTL     time.Duration         `json:"-"`
+}
+
+// NewCache returns an empty Cache with the given TTL.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{Entries: make(map[string]CacheEntry), TTL: ttl}
+}
+
+// LoadCache reads path, returning a fresh empty Cache if it doesn't exist
+// yet.
+func LoadCache(path string, ttl time.Duration) (*Cache, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewCache(ttl), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read latest-resolution cache %s", path)
+	}
+
+	cache := NewCache(ttl)
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse latest-resolution cache %s", path)
+	}
+	return cache, nil
+}
+
+// Save writes the cache to path as indented JSON.
+func (c *Cache) Save(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal latest-resolution cache")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write latest-resolution cache %s", path)
+	}
+	return nil
+}
+
+// get returns key's cached value if present and still within the cache's
+// TTL (a zero TTL never expires).
+func (c *Cache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.Entries[key]
+	if !ok {
+		return "", false
+	}
+	if c.TTL > 0 && time.Since(entry.ResolvedAt) > c.TTL {
+		return "", false
+	}
+	return entry.Value, true
+}
+
+// set records key's resolved value as of now.
+func (c *Cache) set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[key] = CacheEntry{Value: value, ResolvedAt: time.Now()}
+}
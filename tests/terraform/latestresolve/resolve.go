@@ -0,0 +1,40 @@
+package latestresolve
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Candidates returns a sentinel's possible resolutions ordered
+// newest-first -- e.g. a provider's enabled regions, or a Kubernetes
+// minor's available patch releases.
+type Candidates func(ctx context.Context) ([]string, error)
+
+// Resolve expands a "latest"/"latest-N" sentinel for key into a concrete
+// value: a cache hit within TTL is returned verbatim; otherwise candidates
+// is queried and its offset'th entry (0 = newest) is cached under key and
+// returned. Values that aren't a recognized sentinel are returned
+// unchanged, so callers can run every field through Resolve uniformly.
+func Resolve(ctx context.Context, cache *Cache, key, value string, candidates Candidates) (string, error) {
+	offset, ok := Offset(value)
+	if !ok {
+		return value, nil
+	}
+
+	if cached, ok := cache.get(key); ok {
+		return cached, nil
+	}
+
+	options, err := candidates(ctx)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list resolution candidates for %q", key)
+	}
+	if offset >= len(options) {
+		return "", errors.Errorf("%q requested %d release(s) behind current, but only %d candidate(s) are available", key, offset, len(options))
+	}
+
+	resolved := options[offset]
+	cache.set(key, resolved)
+	return resolved, nil
+}
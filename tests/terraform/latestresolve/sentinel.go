@@ -0,0 +1,35 @@
+// Package latestresolve expands "latest" and "latest-N" sentinel values
+// used in place of a concrete region, service, or image/runtime version
+// into a pinned value, caching the result so repeated test runs stay
+// reproducible instead of silently drifting as upstream clouds change.
+package latestresolve
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IsSentinel reports whether value is a "latest" or "latest-N" pin
+// sentinel.
+func IsSentinel(value string) bool {
+	_, ok := Offset(value)
+	return ok
+}
+
+// Offset parses a sentinel's trailing "-N" into how many releases behind
+// the newest candidate it should resolve to -- "latest" is offset 0,
+// "latest-1" is offset 1 ("one behind current"), and so on. The second
+// return value is false if value isn't a recognized sentinel at all.
+func Offset(value string) (int, bool) {
+	if value == "latest" {
+		return 0, true
+	}
+	if !strings.HasPrefix(value, "latest-") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(value, "latest-"))
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
@@ -4,57 +4,223 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/terraform"
-	"github.com/gruntwork-io/terratest/modules/test-structure"
-	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/BurntSushi/toml"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-playground/validator/v10"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/terratest/modules/files"
 	"github.com/gruntwork-io/terratest/modules/logger"
 	"github.com/gruntwork-io/terratest/modules/random"
-	"github.com/gruntwork-io/terratest/modules/files"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-json"
 	"github.com/joho/godotenv"
+	"github.com/montanaflynn/stats"
+	"github.com/pkg/errors"
+	"github.com/pterm/pterm"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"github.com/pterm/pterm"
-	"github.com/pkg/errors"
-	"github.com/hashicorp/terraform-json"
-	"github.com/hashicorp/go-version"
-	"github.com/BurntSushi/toml"
-	"github.com/go-playground/validator/v10"
-	"github.com/goccy/go-json"
-	"github.com/cenkalti/backoff/v4"
-	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
-	"github.com/shirou/gopsutil/v3/cpu"
-	"github.com/shirou/gopsutil/v3/mem"
-	"github.com/shirou/gopsutil/v3/disk"
-	"github.com/shirou/gopsutil/v3/host"
-	"github.com/shirou/gopsutil/v3/load"
-	"github.com/shirou/gopsutil/v3/net"
-	"github.com/montanaflynn/stats"
 	"gopkg.in/yaml.v3"
+
+	"github.com/arvindcr4/learning-assistant/test/checker"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/metrics"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/plananalysis"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/pricing"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/stackmatrix"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/workload"
 )
 
 // TestConfig holds the configuration for all tests
 type TestConfig struct {
-	TerraformDir     string            `yaml:"terraform_dir" validate:"required"`
-	Environment      string            `yaml:"environment" validate:"required,oneof=dev staging prod"`
-	Region           string            `yaml:"region" validate:"required"`
-	ProjectName      string            `yaml:"project_name" validate:"required"`
-	Tags             map[string]string `yaml:"tags"`
-	TimeoutMinutes   int               `yaml:"timeout_minutes" validate:"min=5,max=120"`
-	RetryAttempts    int               `yaml:"retry_attempts" validate:"min=1,max=10"`
-	CleanupEnabled   bool              `yaml:"cleanup_enabled"`
-	ParallelTests    int               `yaml:"parallel_tests" validate:"min=1,max=20"`
-	ResourceQuotas   ResourceQuotas    `yaml:"resource_quotas"`
-	CostLimits       CostLimits        `yaml:"cost_limits"`
-	SecurityConfig   SecurityConfig    `yaml:"security_config"`
-	PerformanceConfig PerformanceConfig `yaml:"performance_config"`
+	TerraformDir                 string                    `yaml:"terraform_dir" validate:"required"`
+	Environment                  string                    `yaml:"environment" validate:"required,oneof=dev staging prod"`
+	Region                       string                    `yaml:"region" validate:"required"`
+	ProjectName                  string                    `yaml:"project_name" validate:"required"`
+	Tags                         map[string]string         `yaml:"tags"`
+	TimeoutMinutes               int                       `yaml:"timeout_minutes" validate:"min=5,max=120"`
+	RetryAttempts                int                       `yaml:"retry_attempts" validate:"min=1,max=10"`
+	CleanupEnabled               bool                      `yaml:"cleanup_enabled"`
+	ParallelTests                int                       `yaml:"parallel_tests" validate:"min=1,max=20"`
+	ResourceQuotas               ResourceQuotas            `yaml:"resource_quotas"`
+	CostLimits                   CostLimits                `yaml:"cost_limits"`
+	SecurityConfig               SecurityConfig            `yaml:"security_config"`
+	PerformanceConfig            PerformanceConfig         `yaml:"performance_config"`
+	AzureEnvironment             AzureEnvironment          `yaml:"azure_environment"`
+	AzureCredential              AzureCredentialConfig     `yaml:"azure_credential"`
+	RecoveryConfig               RecoveryConfig            `yaml:"recovery_config"`
+	FixtureConfig                FixtureConfig             `yaml:"fixture_config"`
+	CostBudgetPolicyPath         string                    `yaml:"cost_budget_policy_path"`
+	CostReportArtifactPath       string                    `yaml:"cost_report_artifact_path"`
+	CostAnomalyK                 float64                   `yaml:"cost_anomaly_k"`
+	AttestationConfig            AttestationPolicy         `yaml:"attestation_config"`
+	SeverityThreshold            string                    `yaml:"severity_threshold"`
+	SARIFOutputPath              string                    `yaml:"sarif_output_path"`
+	DRPlanPath                   string                    `yaml:"dr_plan_path"`
+	DriftReportPath              string                    `yaml:"drift_report_path"`
+	GCPCredentialsFile           string                    `yaml:"gcp_credentials_file"`
+	GCPQuotaProject              string                    `yaml:"gcp_quota_project"`
+	GCPMaxListPages              int                       `yaml:"gcp_max_list_pages"`
+	FirewallExpectations         FirewallExpectations      `yaml:"firewall_expectations"`
+	GCPDryRun                    bool                      `yaml:"gcp_dry_run"`
+	GCPMaxParallel               int                       `yaml:"gcp_max_parallel"`
+	GCPRateLimits                map[string]float64        `yaml:"gcp_rate_limits"`
+	GCPZones                     []string                  `yaml:"gcp_zones"`
+	GCPRegions                   []string                  `yaml:"gcp_regions"`
+	GCPDBEngine                  string                    `yaml:"gcp_db_engine"`
+	GCPDBUser                    string                    `yaml:"gcp_db_user"`
+	GCPDBPassword                string                    `yaml:"gcp_db_password"`
+	GCPDBName                    string                    `yaml:"gcp_db_name"`
+	GCPCacheAuthString           string                    `yaml:"gcp_cache_auth_string"`
+	GCPCacheTLS                  bool                      `yaml:"gcp_cache_tls"`
+	GCPBillingAccountID          string                    `yaml:"gcp_billing_account_id"`
+	GCPBillingExportTable        string                    `yaml:"gcp_billing_export_table"`
+	GCPCostLookbackDays          int                       `yaml:"gcp_cost_lookback_days"`
+	GCPReplicaLagSLOSeconds      int                       `yaml:"gcp_replica_lag_slo_seconds"`
+	GCPBackupRetentionDaysSLO    int                       `yaml:"gcp_backup_retention_days_slo"`
+	GCPDRFailover                bool                      `yaml:"gcp_dr_failover"`
+	GCPDRFailoverEndpoint        string                    `yaml:"gcp_dr_failover_endpoint"`
+	K8sImageSecurity             ImageSecurityTest         `yaml:"k8s_image_security"`
+	K8sMaxAllowedCVEs            int                       `yaml:"k8s_max_allowed_cves"`
+	K8sChaosScenarios            []ChaosScenario           `yaml:"-"`
+	K8sNativeChaosSpecs          []ChaosSpec               `yaml:"-"`
+	K8sLoadTest                  LoadTest                  `yaml:"k8s_load_test"`
+	K8sScaling                   ScalingTest               `yaml:"k8s_scaling"`
+	DR                           K8sDRConfig               `yaml:"dr"`
+	K8sDRContexts                []string                  `yaml:"k8s_dr_contexts"`
+	K8sDRWorkloadNamespace       string                    `yaml:"k8s_dr_workload_namespace"`
+	K8sDRWorkloadName            string                    `yaml:"k8s_dr_workload_name"`
+	K8sDRMinRegions              int                       `yaml:"k8s_dr_min_regions"`
+	K8sBaselineName              string                    `yaml:"k8s_baseline_name"`
+	K8sBaselineTolerances        Tolerances                `yaml:"k8s_baseline_tolerances"`
+	AWSRegions                   []string                  `yaml:"aws_regions"`
+	AWSResourceMaxAge            string                    `yaml:"aws_resource_max_age"`
+	AWSFixtureMode               FixtureMode               `yaml:"aws_fixture_mode"`
+	AWSFixturePath               string                    `yaml:"aws_fixture_path"`
+	AWSCostAnomalyStdDevK        float64                   `yaml:"aws_cost_anomaly_stddev_k"`
+	AWSCostAnomalyReportPath     string                    `yaml:"aws_cost_anomaly_report_path"`
+	ChaosFISRoleARN              string                    `yaml:"chaos_fis_role_arn"`
+	ChaosReportPath              string                    `yaml:"chaos_report_path"`
+	AWSSensitiveIngressWhitelist []string                  `yaml:"aws_sensitive_ingress_whitelist"`
+	AWSAccessControlMatrix       []AccessControlCheck      `yaml:"aws_access_control_matrix"`
+	SupportBundleDir             string                    `yaml:"support_bundle_dir"`
+	TerraformTestScenariosDir    string                    `yaml:"terraform_test_scenarios_dir"`
+	Checkers                     map[string]checker.Config `yaml:"checkers"`
+	PlanPolicy                   PlanPolicyConfig          `yaml:"plan_policy"`
+	StackMatrix                  StackMatrixConfig         `yaml:"stack_matrix"`
+
+	// BackupVerifyRestoreDSN, if set, is a Postgres connection string
+	// TestDatabaseBackup restores its canary rows against instead of the
+	// source endpoint -- point it at a snapshot/replica target to verify an
+	// actual restore rather than a same-database round trip.
+	BackupVerifyRestoreDSN string `yaml:"backup_verify_restore_dsn"`
+	// BackupVerifyReplicaBucket, if set, is the secondary/replica bucket
+	// TestStorageBackup reads its canary object back from instead of the
+	// source bucket, to verify cross-region replication rather than a
+	// same-bucket round trip.
+	BackupVerifyReplicaBucket string `yaml:"backup_verify_replica_bucket"`
+	// BackupVerifyMaxRPOSeconds bounds the age of the newest canary record
+	// TestDatabaseBackup/TestStorageBackup may report missing before
+	// failing; 0 disables the check.
+	BackupVerifyMaxRPOSeconds int `yaml:"backup_verify_max_rpo_seconds"`
+
+	// MonitoringRequiredMetrics lists metric names (optionally
+	// "name{label=value,...}" to also require a matching label on at
+	// least one sample) TestMonitoringEndpoint must find when it scrapes
+	// the monitoring endpoint.
+	MonitoringRequiredMetrics []string `yaml:"monitoring_required_metrics"`
+	// MonitoringExpectedTargets lists Prometheus job names
+	// TestMonitoringEndpoint requires to have at least one target and
+	// Health == "up", queried from the endpoint's /api/v1/targets.
+	MonitoringExpectedTargets []string `yaml:"monitoring_expected_targets"`
+	// MonitoringAllowedFiringAlerts lists alert names TestAlertingEndpoint
+	// permits to be firing (unsilenced) -- anything else Alertmanager
+	// reports active is treated as an unexpected firing alert.
+	MonitoringAllowedFiringAlerts []string `yaml:"monitoring_allowed_firing_alerts"`
+
+	// SkipValidation lets a caller opt out of NewMultiCloudTestSuite's
+	// MultiCloudConfig schema validation (see config_validation_test.go) --
+	// intended for fixture-driven test runs against a deliberately
+	// incomplete multi-cloud-config.yaml, not for routine use.
+	SkipValidation bool `yaml:"skip_validation"`
+}
+
+// AccessControlCheck is one caller-provided (principal, action, resource)
+// tuple TestAccessControls simulates via IAM's SimulatePrincipalPolicy and
+// checks against ExpectAllowed.
+type AccessControlCheck struct {
+	Principal     string `yaml:"principal"`
+	Action        string `yaml:"action"`
+	Resource      string `yaml:"resource"`
+	ExpectAllowed bool   `yaml:"expect_allowed"`
+}
+
+// K8sDRConfig configures BackupVerifier-driven disaster-recovery testing:
+// the RPO/RTO budget a Velero backup/restore cycle must stay within.
+type K8sDRConfig struct {
+	MaxRPOSeconds   int    `yaml:"max_rpo_seconds"`
+	MaxRTOSeconds   int    `yaml:"max_rto_seconds"`
+	SourceNamespace string `yaml:"source_namespace"`
+}
+
+// FixtureConfig controls whether ARM calls are recorded to (or replayed
+// from) a fixture file instead of hitting a live subscription. See
+// FixtureTransport.
+type FixtureConfig struct {
+	Mode FixtureMode `yaml:"mode"`
+	Path string      `yaml:"path"`
+}
+
+// RecoveryConfig identifies a paired region vault used for cross-region
+// restore validation. Left zero-valued to skip that test.
+type RecoveryConfig struct {
+	PairedRegion              string `yaml:"paired_region"`
+	PairedRegionVault         string `yaml:"paired_region_vault"`
+	PairedRegionResourceGroup string `yaml:"paired_region_resource_group"`
+}
+
+// PlanPolicyConfig bounds what RunPlanTests' plananalysis.Analyze result
+// is allowed to contain before the plan stage fails the test. SnapshotPath
+// is where the prior plan's refreshed state is recorded between runs so
+// RequireNoDrift has something to compare the current plan against; a
+// missing or empty SnapshotPath just means the first run establishes the
+// baseline rather than reporting drift.
+type PlanPolicyConfig struct {
+	MaxDestroys            int      `yaml:"max_destroys"`
+	ForbiddenResourceTypes []string `yaml:"forbidden_resource_types"`
+	RequireNoDrift         bool     `yaml:"require_no_drift"`
+	SnapshotPath           string   `yaml:"snapshot_path"`
+}
+
+// StackConfig is one stack in the StackMatrixConfig: an independently
+// deployable Terraform root module, optionally skipped when the
+// credentials it needs aren't present in the environment.
+type StackConfig struct {
+	Name           string `yaml:"name"`
+	Dir            string `yaml:"dir"`
+	SkipIfEnvUnset string `yaml:"skip_if_env_unset"`
+}
+
+// StackMatrixConfig drives RunValidationTests' StackMatrix: either an
+// explicit Stacks list, or (when Stacks is empty) a Glob of stack
+// directories to discover.
+type StackMatrixConfig struct {
+	Stacks []StackConfig `yaml:"stacks"`
+	Glob   string        `yaml:"glob"`
 }
 
 // ResourceQuotas defines resource limits for testing
@@ -68,10 +234,11 @@ type ResourceQuotas struct {
 
 // CostLimits defines cost limits for testing
 type CostLimits struct {
-	MaxHourlyCost  float64 `yaml:"max_hourly_cost" validate:"min=0.01,max=1000"`
-	MaxDailyCost   float64 `yaml:"max_daily_cost" validate:"min=0.01,max=10000"`
-	MaxMonthlyCost float64 `yaml:"max_monthly_cost" validate:"min=0.01,max=100000"`
-	AlertThreshold float64 `yaml:"alert_threshold" validate:"min=0.01,max=1"`
+	MaxHourlyCost       float64 `yaml:"max_hourly_cost" validate:"min=0.01,max=1000"`
+	MaxDailyCost        float64 `yaml:"max_daily_cost" validate:"min=0.01,max=10000"`
+	MaxMonthlyCost      float64 `yaml:"max_monthly_cost" validate:"min=0.01,max=100000"`
+	AlertThreshold      float64 `yaml:"alert_threshold" validate:"min=0.01,max=1"`
+	MaxEstimatedCostUSD float64 `yaml:"max_estimated_cost_usd"`
 }
 
 // SecurityConfig defines security testing configuration
@@ -97,78 +264,146 @@ type PerformanceConfig struct {
 	MaxMemoryUtilization   float64 `yaml:"max_memory_utilization" validate:"min=0.1,max=1"`
 	MaxDiskUtilization     float64 `yaml:"max_disk_utilization" validate:"min=0.1,max=1"`
 	MaxNetworkUtilization  float64 `yaml:"max_network_utilization" validate:"min=0.1,max=1"`
+	SamplingIntervalMs     int     `yaml:"sampling_interval_ms" validate:"min=100,max=60000"`
+	LoadSampleSeconds      int     `yaml:"load_sample_seconds" validate:"min=1,max=3600"`
+	StressSampleSeconds    int     `yaml:"stress_sample_seconds" validate:"min=1,max=3600"`
+	EnduranceSampleSeconds int     `yaml:"endurance_sample_seconds" validate:"min=1,max=86400"`
 }
 
 // TestResult holds the result of a test execution
 type TestResult struct {
-	TestName      string                 `json:"test_name"`
-	Status        string                 `json:"status"`
-	Duration      time.Duration          `json:"duration"`
-	StartTime     time.Time             `json:"start_time"`
-	EndTime       time.Time             `json:"end_time"`
-	Error         error                 `json:"error,omitempty"`
-	Metrics       map[string]interface{} `json:"metrics,omitempty"`
-	ResourceUsage ResourceUsage         `json:"resource_usage,omitempty"`
-	CostEstimate  CostEstimate          `json:"cost_estimate,omitempty"`
-	SecurityScan  SecurityScanResult    `json:"security_scan,omitempty"`
-	PerformanceResult PerformanceResult `json:"performance_result,omitempty"`
+	TestName          string                 `json:"test_name"`
+	Status            string                 `json:"status"`
+	Duration          time.Duration          `json:"duration"`
+	StartTime         time.Time              `json:"start_time"`
+	EndTime           time.Time              `json:"end_time"`
+	Error             error                  `json:"error,omitempty"`
+	Metrics           map[string]interface{} `json:"metrics,omitempty"`
+	ResourceUsage     ResourceUsage          `json:"resource_usage,omitempty"`
+	CostEstimate      CostEstimate           `json:"cost_estimate,omitempty"`
+	SecurityScan      SecurityScanResult     `json:"security_scan,omitempty"`
+	PerformanceResult PerformanceResult      `json:"performance_result,omitempty"`
 }
 
 // ResourceUsage tracks resource consumption during tests
 type ResourceUsage struct {
-	CPUUsage    float64 `json:"cpu_usage"`
-	MemoryUsage float64 `json:"memory_usage"`
-	DiskUsage   float64 `json:"disk_usage"`
-	NetworkIO   int64   `json:"network_io"`
-	Instances   int     `json:"instances"`
-	VCPUs       int     `json:"vcpus"`
-	MemoryGB    int     `json:"memory_gb"`
-	StorageGB   int     `json:"storage_gb"`
-}
-
-// CostEstimate holds cost estimation results
-type CostEstimate struct {
-	HourlyCost  float64 `json:"hourly_cost"`
-	DailyCost   float64 `json:"daily_cost"`
-	MonthlyCost float64 `json:"monthly_cost"`
-	YearlyCost  float64 `json:"yearly_cost"`
-	Currency    string  `json:"currency"`
-	Breakdown   map[string]float64 `json:"breakdown"`
+	CPUUsage    float64          `json:"cpu_usage"`
+	MemoryUsage float64          `json:"memory_usage"`
+	DiskUsage   float64          `json:"disk_usage"`
+	NetworkIO   int64            `json:"network_io"`
+	Instances   int              `json:"instances"`
+	VCPUs       int              `json:"vcpus"`
+	MemoryGB    int              `json:"memory_gb"`
+	StorageGB   int              `json:"storage_gb"`
+	Timeseries  []metrics.Sample `json:"timeseries,omitempty"`
 }
 
+// CostEstimate holds cost estimation results. It is an alias of
+// pricing.CostEstimate so TestResult's JSON shape is unchanged now that
+// TestCostEstimation matches planned resources against a real pricing.Catalog
+// instead of a flat len(outputs)*0.1 placeholder.
+type CostEstimate = pricing.CostEstimate
+
 // SecurityScanResult holds security scan results
 type SecurityScanResult struct {
-	VulnerabilityCount int      `json:"vulnerability_count"`
-	ComplianceScore    float64  `json:"compliance_score"`
-	SecurityFindings   []string `json:"security_findings"`
-	EncryptionStatus   bool     `json:"encryption_status"`
-	AccessControlScore float64  `json:"access_control_score"`
-	NetworkSecurityScore float64 `json:"network_security_score"`
+	VulnerabilityCount   int      `json:"vulnerability_count"`
+	ComplianceScore      float64  `json:"compliance_score"`
+	SecurityFindings     []string `json:"security_findings"`
+	EncryptionStatus     bool     `json:"encryption_status"`
+	AccessControlScore   float64  `json:"access_control_score"`
+	NetworkSecurityScore float64  `json:"network_security_score"`
 }
 
 // PerformanceResult holds performance test results
 type PerformanceResult struct {
-	AverageResponseTime time.Duration `json:"average_response_time"`
-	MaxResponseTime     time.Duration `json:"max_response_time"`
-	MinResponseTime     time.Duration `json:"min_response_time"`
-	ThroughputRPS       float64       `json:"throughput_rps"`
-	ErrorRate           float64       `json:"error_rate"`
-	SuccessRate         float64       `json:"success_rate"`
+	AverageResponseTime time.Duration            `json:"average_response_time"`
+	MaxResponseTime     time.Duration            `json:"max_response_time"`
+	MinResponseTime     time.Duration            `json:"min_response_time"`
+	ThroughputRPS       float64                  `json:"throughput_rps"`
+	ErrorRate           float64                  `json:"error_rate"`
+	SuccessRate         float64                  `json:"success_rate"`
 	Percentiles         map[string]time.Duration `json:"percentiles"`
+	ResourceStats       metrics.Summary          `json:"resource_stats,omitempty"`
 }
 
 // TestSuite manages the overall test execution
 type TestSuite struct {
-	Config     TestConfig
-	Logger     zerolog.Logger
-	Validator  *validator.Validate
-	Results    []TestResult
-	StartTime  time.Time
-	EndTime    time.Time
-	TotalTests int
-	PassedTests int
-	FailedTests int
+	Config       TestConfig
+	Logger       zerolog.Logger
+	Validator    *validator.Validate
+	Results      []TestResult
+	StartTime    time.Time
+	EndTime      time.Time
+	TotalTests   int
+	PassedTests  int
+	FailedTests  int
 	SkippedTests int
+
+	// PricingSource overrides the bundled pricing.Catalog TestCostEstimation
+	// matches planned resources against; set from the -pricing-source flag.
+	// Empty means use pricing.DefaultCatalog.
+	PricingSource string
+
+	// protected holds resource identifiers, keyed by kind, that Protect
+	// has exempted from deletion by Cleanup.
+	protected resourceSet
+
+	// created holds resource identifiers, keyed by kind, that
+	// TrackResource recorded as created during this run.
+	created resourceSet
+
+	// cleaners overrides, per resource kind, the function Cleanup calls
+	// to delete an unprotected created resource -- see RegisterCleaner.
+	cleaners sync.Map
+}
+
+// resourceSet is a mutex-protected set of resource identifiers grouped by
+// kind (e.g. "container", "bucket"), shared by TestSuite's protected and
+// created fields.
+type resourceSet struct {
+	mu     sync.Mutex
+	byKind map[string]map[string]struct{}
+}
+
+func (s *resourceSet) add(kind, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.byKind == nil {
+		s.byKind = make(map[string]map[string]struct{})
+	}
+	if s.byKind[kind] == nil {
+		s.byKind[kind] = make(map[string]struct{})
+	}
+	s.byKind[kind][id] = struct{}{}
+}
+
+func (s *resourceSet) has(kind, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.byKind[kind][id]
+	return ok
+}
+
+// kinds returns every kind with at least one tracked identifier.
+func (s *resourceSet) kinds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kinds := make([]string, 0, len(s.byKind))
+	for kind := range s.byKind {
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// items returns every identifier tracked under kind.
+func (s *resourceSet) items(kind string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.byKind[kind]))
+	for id := range s.byKind[kind] {
+		ids = append(ids, id)
+	}
+	return ids
 }
 
 // NewTestSuite creates a new test suite instance
@@ -194,10 +429,11 @@ func NewTestSuite(configPath string) (*TestSuite, error) {
 	}
 
 	return &TestSuite{
-		Config:    config,
-		Logger:    logger,
-		Validator: validator,
-		Results:   make([]TestResult, 0),
+		Config:        config,
+		Logger:        logger,
+		Validator:     validator,
+		Results:       make([]TestResult, 0),
+		PricingSource: *pricingSource,
 	}, nil
 }
 
@@ -210,6 +446,16 @@ func LoadTestConfig(configPath string) (TestConfig, error) {
 	config.RetryAttempts = 3
 	config.CleanupEnabled = true
 	config.ParallelTests = 4
+	config.TerraformTestScenariosDir = "../../terraform/tests"
+	config.StackMatrix = StackMatrixConfig{
+		Stacks: []StackConfig{
+			{Name: "terraform", Dir: "../../terraform"},
+			{Name: "infra", Dir: "../../infra"},
+			{Name: "aws", Dir: "../../stacks/aws", SkipIfEnvUnset: "AWS_ACCESS_KEY_ID"},
+			{Name: "gcp", Dir: "../../stacks/gcp", SkipIfEnvUnset: "GOOGLE_APPLICATION_CREDENTIALS"},
+			{Name: "azure", Dir: "../../stacks/azure", SkipIfEnvUnset: "ARM_CLIENT_ID"},
+		},
+	}
 	config.ResourceQuotas = ResourceQuotas{
 		MaxInstances:    10,
 		MaxVCPUs:        100,
@@ -243,6 +489,10 @@ func LoadTestConfig(configPath string) (TestConfig, error) {
 		MaxMemoryUtilization:   0.8,
 		MaxDiskUtilization:     0.8,
 		MaxNetworkUtilization:  0.8,
+		SamplingIntervalMs:     2000,
+		LoadSampleSeconds:      30,
+		StressSampleSeconds:    60,
+		EnduranceSampleSeconds: 300,
 	}
 
 	// Load from file if exists
@@ -273,15 +523,8 @@ func TestMain(m *testing.M) {
 		log.Fatal().Err(err).Msg("Failed to initialize test suite")
 	}
 
-	// Run tests
-	code := m.Run()
-
-	// Cleanup
-	if suite.Config.CleanupEnabled {
-		suite.Cleanup()
-	}
-
-	os.Exit(code)
+	// Run tests, handling SIGINT/SIGTERM and cleanup via RunSuite
+	os.Exit(RunSuite(m, suite))
 }
 
 // TestTerraformInfrastructure tests the complete infrastructure deployment
@@ -311,7 +554,7 @@ func TestTerraformInfrastructure(t *testing.T) {
 		progressBar.Stop()
 		endTime := time.Now()
 		duration := endTime.Sub(startTime)
-		
+
 		suite.Logger.Info().
 			Str("test_id", testID).
 			Str("test_name", testName).
@@ -332,7 +575,7 @@ func TestTerraformInfrastructure(t *testing.T) {
 
 	for i, stage := range stages {
 		progressBar.UpdateTitle(fmt.Sprintf("Running %s stage", stage))
-		
+
 		switch stage {
 		case "validate":
 			err = suite.RunValidationTests(t, testName)
@@ -352,7 +595,7 @@ func TestTerraformInfrastructure(t *testing.T) {
 				Str("stage", stage).
 				Str("test_id", testID).
 				Msg("Test stage failed")
-			
+
 			t.Errorf("Test stage %s failed: %v", stage, err)
 			return
 		}
@@ -365,68 +608,114 @@ func TestTerraformInfrastructure(t *testing.T) {
 		Msg("All infrastructure tests passed")
 }
 
-// RunValidationTests runs Terraform validation tests
+// RunValidationTests validates every stack in the configured StackMatrix
+// (falling back to glob-discovered stacks when none are listed)
+// concurrently, bounded by Config.ParallelTests, skipping any stack whose
+// directory is missing or whose SkipIfEnvUnset env var isn't set. Every
+// stack's outcome is collected before returning, so one cloud's validation
+// failure doesn't mask another's -- unlike the old serial, abort-on-first-
+// error directory list this replaces.
 func (ts *TestSuite) RunValidationTests(t *testing.T, testName string) error {
 	ts.Logger.Info().Str("test_name", testName).Msg("Running validation tests")
 
-	// Test multiple Terraform configurations
-	terraformDirs := []string{
-		"../../terraform",
-		"../../infra",
-		"../../stacks/aws",
-		"../../stacks/gcp",
-		"../../stacks/azure",
+	stacks, err := ts.resolveStacks()
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve stack matrix")
 	}
 
-	for _, dir := range terraformDirs {
-		if !files.FileExists(dir) {
-			ts.Logger.Warn().Str("dir", dir).Msg("Terraform directory not found, skipping")
-			continue
-		}
-
-		// Configure Terraform options
+	results := stackmatrix.Run(stacks, ts.Config.ParallelTests, ts.stackSkipFunc(), func(stack stackmatrix.Stack) error {
 		terraformOptions := &terraform.Options{
-			TerraformDir: dir,
+			TerraformDir: stack.Dir,
 			NoColor:      true,
 			Logger:       logger.Discard,
 			Vars: map[string]interface{}{
-				"environment":   ts.Config.Environment,
-				"region":        ts.Config.Region,
-				"project_name":  ts.Config.ProjectName,
-				"test_id":       testName,
+				"environment":  ts.Config.Environment,
+				"region":       ts.Config.Region,
+				"project_name": ts.Config.ProjectName,
+				"test_id":      testName,
 			},
 		}
-
-		// Add tags
 		if len(ts.Config.Tags) > 0 {
 			terraformOptions.Vars["tags"] = ts.Config.Tags
 		}
 
-		// Run validation
-		err := terraform.Validate(t, terraformOptions)
-		if err != nil {
-			return errors.Wrapf(err, "validation failed for %s", dir)
+		// Per-stack test_structure key: each stack gets its own isolated
+		// .test-data under its own directory, so concurrent stacks never
+		// clobber each other's saved Terraform options.
+		test_structure.SaveTerraformOptions(t, stack.Dir, terraformOptions)
+
+		return terraform.Validate(t, terraformOptions)
+	})
+
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			ts.Logger.Warn().Str("stack", result.Stack.Name).Str("reason", result.Reason).Msg("Skipping stack")
+		case result.Err != nil:
+			ts.Logger.Error().Err(result.Err).Str("stack", result.Stack.Name).Msg("Validation failed")
+		default:
+			ts.Logger.Info().Str("stack", result.Stack.Name).Msg("Validation passed")
+		}
+	}
+
+	return stackmatrix.AggregateErrors(results)
+}
+
+// resolveStacks returns StackMatrix.Stacks as stackmatrix.Stack values,
+// or (when none are configured) whatever StackMatrix.Glob discovers.
+func (ts *TestSuite) resolveStacks() ([]stackmatrix.Stack, error) {
+	if len(ts.Config.StackMatrix.Stacks) > 0 {
+		stacks := make([]stackmatrix.Stack, len(ts.Config.StackMatrix.Stacks))
+		for i, cfg := range ts.Config.StackMatrix.Stacks {
+			stacks[i] = stackmatrix.Stack{Name: cfg.Name, Dir: cfg.Dir}
 		}
+		return stacks, nil
+	}
+	if ts.Config.StackMatrix.Glob == "" {
+		return nil, nil
+	}
+	return stackmatrix.Discover(ts.Config.StackMatrix.Glob)
+}
 
-		ts.Logger.Info().Str("dir", dir).Msg("Validation passed")
+// stackSkipFunc skips a stack whose directory doesn't exist, or whose
+// configured SkipIfEnvUnset env var isn't set -- e.g. the aws stack when
+// AWS_ACCESS_KEY_ID is unset, so a laptop without cloud credentials
+// doesn't fail the whole stack matrix.
+func (ts *TestSuite) stackSkipFunc() stackmatrix.SkipFunc {
+	envVarByDir := map[string]string{}
+	for _, cfg := range ts.Config.StackMatrix.Stacks {
+		if cfg.SkipIfEnvUnset != "" {
+			envVarByDir[cfg.Dir] = cfg.SkipIfEnvUnset
+		}
 	}
 
-	return nil
+	return func(stack stackmatrix.Stack) string {
+		if !files.FileExists(stack.Dir) {
+			return "terraform directory not found"
+		}
+		if envVar, ok := envVarByDir[stack.Dir]; ok && os.Getenv(envVar) == "" {
+			return fmt.Sprintf("%s is not set", envVar)
+		}
+		return ""
+	}
 }
 
 // RunPlanTests runs Terraform plan tests
 func (ts *TestSuite) RunPlanTests(t *testing.T, testName string) error {
 	ts.Logger.Info().Str("test_name", testName).Msg("Running plan tests")
 
+	planFile := filepath.Join(os.TempDir(), testName+".tfplan")
+
 	terraformOptions := &terraform.Options{
 		TerraformDir: ts.Config.TerraformDir,
 		NoColor:      true,
 		Logger:       logger.Discard,
+		PlanFilePath: planFile,
 		Vars: map[string]interface{}{
-			"environment":   ts.Config.Environment,
-			"region":        ts.Config.Region,
-			"project_name":  ts.Config.ProjectName,
-			"test_id":       testName,
+			"environment":  ts.Config.Environment,
+			"region":       ts.Config.Region,
+			"project_name": ts.Config.ProjectName,
+			"test_id":      testName,
 		},
 	}
 
@@ -438,17 +727,80 @@ func (ts *TestSuite) RunPlanTests(t *testing.T, testName string) error {
 	// Initialize Terraform
 	terraform.Init(t, terraformOptions)
 
-	// Run plan
-	plan := terraform.Plan(t, terraformOptions)
-	
-	// Parse plan output
-	if plan != "" {
-		ts.Logger.Info().
-			Str("test_name", testName).
-			Str("plan_output", plan).
-			Msg("Terraform plan completed")
+	// Run plan, writing it to planFile so it can be re-read as structured
+	// JSON below.
+	terraform.Plan(t, terraformOptions)
+
+	planJSON := terraform.RunTerraformCommand(t, terraformOptions, "show", "-json", planFile)
+
+	var plan tfjson.Plan
+	if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
+		return errors.Wrap(err, "failed to parse plan JSON")
+	}
+
+	previousSnapshot := loadPlanSnapshot(ts.Config.PlanPolicy.SnapshotPath)
+	analysis := plananalysis.Analyze(&plan, previousSnapshot)
+
+	if plan.PriorState != nil && ts.Config.PlanPolicy.SnapshotPath != "" {
+		if err := savePlanSnapshot(ts.Config.PlanPolicy.SnapshotPath, plananalysis.Snapshot(plan.PriorState.Values)); err != nil {
+			ts.Logger.Warn().Err(err).Msg("failed to persist plan state snapshot")
+		}
+	}
+
+	// Expose the parsed plan to downstream stages (security/cost checkers)
+	// via terratest's test-state mechanism, keyed per test run.
+	test_structure.SaveString(t, ts.Config.TerraformDir, "plan_json_"+testName, planJSON)
+
+	ts.Logger.Info().
+		Str("test_name", testName).
+		Int("create", analysis.Totals.Create).
+		Int("update", analysis.Totals.Update).
+		Int("delete", analysis.Totals.Delete).
+		Int("replace", analysis.Totals.Replace).
+		Int("sensitive_resources", len(analysis.SensitiveResources)).
+		Int("drift", len(analysis.Drift)).
+		Msg("Terraform plan analyzed")
+
+	policy := plananalysis.Policy{
+		MaxDestroys:            ts.Config.PlanPolicy.MaxDestroys,
+		ForbiddenResourceTypes: ts.Config.PlanPolicy.ForbiddenResourceTypes,
+		RequireNoDrift:         ts.Config.PlanPolicy.RequireNoDrift,
+	}
+	if violations := analysis.Violations(policy); len(violations) > 0 {
+		return errors.Errorf("plan policy violated: %s", strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// loadPlanSnapshot reads path (if set and present) as a prior
+// plananalysis.Snapshot, returning an empty snapshot -- not an error --
+// when path is unset or this is the first run and no snapshot exists yet.
+func loadPlanSnapshot(path string) map[string]map[string]interface{} {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var snapshot map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil
 	}
+	return snapshot
+}
 
+// savePlanSnapshot writes snapshot to path as JSON, so the next run's
+// loadPlanSnapshot has something to diff RequireNoDrift against.
+func savePlanSnapshot(path string, snapshot map[string]map[string]interface{}) error {
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal plan state snapshot")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write plan state snapshot to %s", path)
+	}
 	return nil
 }
 
@@ -461,10 +813,10 @@ func (ts *TestSuite) RunApplyTests(t *testing.T, testName string) error {
 		NoColor:      true,
 		Logger:       logger.Discard,
 		Vars: map[string]interface{}{
-			"environment":   ts.Config.Environment,
-			"region":        ts.Config.Region,
-			"project_name":  ts.Config.ProjectName,
-			"test_id":       testName,
+			"environment":  ts.Config.Environment,
+			"region":       ts.Config.Region,
+			"project_name": ts.Config.ProjectName,
+			"test_id":      testName,
 		},
 	}
 
@@ -477,7 +829,7 @@ func (ts *TestSuite) RunApplyTests(t *testing.T, testName string) error {
 	test_structure.SaveTerraformOptions(t, ts.Config.TerraformDir, terraformOptions)
 
 	// Apply with retries
-	retry.DoWithRetry(t, "terraform apply", ts.Config.RetryAttempts, 
+	retry.DoWithRetry(t, "terraform apply", ts.Config.RetryAttempts,
 		time.Duration(ts.Config.TimeoutMinutes)*time.Minute, func() (string, error) {
 			terraform.Apply(t, terraformOptions)
 			return "", nil
@@ -497,7 +849,7 @@ func (ts *TestSuite) RunInfrastructureTests(t *testing.T, testName string) error
 
 	// Get outputs
 	outputs := terraform.OutputAll(t, terraformOptions)
-	
+
 	// Test infrastructure health
 	if err := ts.TestInfrastructureHealth(t, outputs); err != nil {
 		return errors.Wrap(err, "infrastructure health check failed")
@@ -517,13 +869,13 @@ func (ts *TestSuite) RunInfrastructureTests(t *testing.T, testName string) error
 
 	// Test performance
 	if ts.Config.PerformanceConfig.EnableLoadTesting {
-		if err := ts.TestPerformance(t, outputs); err != nil {
+		if err := ts.TestPerformance(t, testName, outputs); err != nil {
 			return errors.Wrap(err, "performance test failed")
 		}
 	}
 
 	// Test cost estimation
-	if err := ts.TestCostEstimation(t, outputs); err != nil {
+	if err := ts.TestCostEstimation(t, testName, outputs); err != nil {
 		return errors.Wrap(err, "cost estimation test failed")
 	}
 
@@ -537,6 +889,12 @@ func (ts *TestSuite) RunInfrastructureTests(t *testing.T, testName string) error
 		return errors.Wrap(err, "monitoring test failed")
 	}
 
+	// Run declarative terraform-test scenarios, if any are configured --
+	// see RunTerraformTestScenarios in terraform_test_runner_test.go.
+	if err := ts.RunTerraformTestScenarios(testName); err != nil {
+		return errors.Wrap(err, "terraform-test scenarios failed")
+	}
+
 	return nil
 }
 
@@ -548,7 +906,7 @@ func (ts *TestSuite) RunDestroyTests(t *testing.T, testName string) error {
 	terraformOptions := test_structure.LoadTerraformOptions(t, ts.Config.TerraformDir)
 
 	// Destroy with retries
-	retry.DoWithRetry(t, "terraform destroy", ts.Config.RetryAttempts, 
+	retry.DoWithRetry(t, "terraform destroy", ts.Config.RetryAttempts,
 		time.Duration(ts.Config.TimeoutMinutes)*time.Minute, func() (string, error) {
 			terraform.Destroy(t, terraformOptions)
 			return "", nil
@@ -559,111 +917,77 @@ func (ts *TestSuite) RunDestroyTests(t *testing.T, testName string) error {
 	return nil
 }
 
-// TestInfrastructureHealth tests the health of deployed infrastructure
-func (ts *TestSuite) TestInfrastructureHealth(t *testing.T, outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing infrastructure health")
-
-	// Test database connectivity
-	if dbEndpoint, ok := outputs["database_endpoint"].(string); ok && dbEndpoint != "" {
-		if err := ts.TestDatabaseHealth(dbEndpoint); err != nil {
-			return errors.Wrap(err, "database health check failed")
+// runCheckerCategory runs every registered checker.Checker under category
+// against outputs, honoring ts.Config.Checkers' per-name Disabled/Params
+// overrides, and folds any checker.StatusFailed results into a single
+// aggregate error so a caller sees every failure from this stage at once
+// rather than only the first.
+func (ts *TestSuite) runCheckerCategory(category checker.Category, outputs map[string]interface{}) error {
+	results := checker.RunAll(context.Background(), checker.Registered(category), outputs, ts.Config.Checkers)
+
+	var failures []string
+	for _, result := range results {
+		event := ts.Logger.Info()
+		if result.Status == checker.StatusFailed {
+			event = ts.Logger.Error()
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Checker, result.Message))
 		}
+		event.
+			Str("checker", result.Checker).
+			Str("status", string(result.Status)).
+			Str("message", result.Message).
+			Msg("checker completed")
 	}
 
-	// Test cache connectivity
-	if cacheEndpoint, ok := outputs["cache_endpoint"].(string); ok && cacheEndpoint != "" {
-		if err := ts.TestCacheHealth(cacheEndpoint); err != nil {
-			return errors.Wrap(err, "cache health check failed")
-		}
-	}
-
-	// Test load balancer health
-	if lbEndpoint, ok := outputs["load_balancer_endpoint"].(string); ok && lbEndpoint != "" {
-		if err := ts.TestLoadBalancerHealth(lbEndpoint); err != nil {
-			return errors.Wrap(err, "load balancer health check failed")
-		}
+	if len(failures) > 0 {
+		return errors.Errorf("%d checker(s) failed: %s", len(failures), strings.Join(failures, "; "))
 	}
-
-	// Test container service health
-	if containerEndpoint, ok := outputs["container_service_endpoint"].(string); ok && containerEndpoint != "" {
-		if err := ts.TestContainerServiceHealth(containerEndpoint); err != nil {
-			return errors.Wrap(err, "container service health check failed")
-		}
-	}
-
-	ts.Logger.Info().Msg("All infrastructure health checks passed")
 	return nil
 }
 
-// TestConnectivity tests network connectivity
+// TestInfrastructureHealth runs every checker.CategoryHealth Checker
+// (database/cache/load-balancer/container-service health, plus any the
+// caller has registered) against the deployed infrastructure's outputs.
+func (ts *TestSuite) TestInfrastructureHealth(t *testing.T, outputs map[string]interface{}) error {
+	ts.Logger.Info().Msg("Testing infrastructure health")
+	return ts.runCheckerCategory(checker.CategoryHealth, outputs)
+}
+
+// TestConnectivity runs every checker.CategoryConnectivity Checker
+// (external HTTP reachability, internal TCP reachability, plus any the
+// caller has registered) against the deployed infrastructure's outputs.
 func (ts *TestSuite) TestConnectivity(t *testing.T, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing connectivity")
-
-	// Test external connectivity
-	if publicEndpoint, ok := outputs["public_endpoint"].(string); ok && publicEndpoint != "" {
-		if err := ts.TestHTTPConnectivity(publicEndpoint); err != nil {
-			return errors.Wrap(err, "external connectivity test failed")
-		}
-	}
-
-	// Test internal connectivity
-	if privateEndpoint, ok := outputs["private_endpoint"].(string); ok && privateEndpoint != "" {
-		if err := ts.TestInternalConnectivity(privateEndpoint); err != nil {
-			return errors.Wrap(err, "internal connectivity test failed")
-		}
-	}
-
-	ts.Logger.Info().Msg("All connectivity tests passed")
-	return nil
+	return ts.runCheckerCategory(checker.CategoryConnectivity, outputs)
 }
 
-// TestSecurity runs security tests
+// TestSecurity runs every checker.CategorySecurity Checker (SSL/TLS,
+// network-ACL, access-control, encryption, plus any the caller has
+// registered) against the deployed infrastructure's outputs.
 func (ts *TestSuite) TestSecurity(t *testing.T, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing security")
-
-	// Test SSL/TLS configuration
-	if err := ts.TestSSLConfiguration(outputs); err != nil {
-		return errors.Wrap(err, "SSL configuration test failed")
-	}
-
-	// Test network security
-	if err := ts.TestNetworkSecurity(outputs); err != nil {
-		return errors.Wrap(err, "network security test failed")
-	}
-
-	// Test access controls
-	if err := ts.TestAccessControls(outputs); err != nil {
-		return errors.Wrap(err, "access control test failed")
-	}
-
-	// Test encryption
-	if err := ts.TestEncryption(outputs); err != nil {
-		return errors.Wrap(err, "encryption test failed")
-	}
-
-	ts.Logger.Info().Msg("All security tests passed")
-	return nil
+	return ts.runCheckerCategory(checker.CategorySecurity, outputs)
 }
 
 // TestPerformance runs performance tests
-func (ts *TestSuite) TestPerformance(t *testing.T, outputs map[string]interface{}) error {
+func (ts *TestSuite) TestPerformance(t *testing.T, testName string, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing performance")
 
 	// Test load performance
-	if err := ts.TestLoadPerformance(outputs); err != nil {
+	if err := ts.TestLoadPerformance(testName, outputs); err != nil {
 		return errors.Wrap(err, "load performance test failed")
 	}
 
 	// Test stress performance
 	if ts.Config.PerformanceConfig.EnableStressTesting {
-		if err := ts.TestStressPerformance(outputs); err != nil {
+		if err := ts.TestStressPerformance(testName, outputs); err != nil {
 			return errors.Wrap(err, "stress performance test failed")
 		}
 	}
 
 	// Test endurance performance
 	if ts.Config.PerformanceConfig.EnableEnduranceTesting {
-		if err := ts.TestEndurancePerformance(outputs); err != nil {
+		if err := ts.TestEndurancePerformance(testName, outputs); err != nil {
 			return errors.Wrap(err, "endurance performance test failed")
 		}
 	}
@@ -672,192 +996,370 @@ func (ts *TestSuite) TestPerformance(t *testing.T, outputs map[string]interface{
 	return nil
 }
 
-// TestCostEstimation tests cost estimation
-func (ts *TestSuite) TestCostEstimation(t *testing.T, outputs map[string]interface{}) error {
+// TestCostEstimation matches the plan RunPlanTests saved for testName
+// against a pricing.Catalog (the bundled AWS/GCP/Azure price sheets, or
+// ts.PricingSource's override), enforces CostLimits against the resulting
+// CostEstimate, and warns via pterm once projected spend crosses
+// CostLimits.AlertThreshold's fraction of any configured cap.
+func (ts *TestSuite) TestCostEstimation(t *testing.T, testName string, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing cost estimation")
 
-	// Get resource information
-	resourceCount := len(outputs)
-	
-	// Estimate basic costs (simplified)
-	estimatedHourlyCost := float64(resourceCount) * 0.1
-	estimatedDailyCost := estimatedHourlyCost * 24
-	estimatedMonthlyCost := estimatedDailyCost * 30
+	catalog, err := pricing.LoadCatalog(ts.PricingSource)
+	if err != nil {
+		return errors.Wrap(err, "failed to load pricing catalog")
+	}
+
+	planJSON := test_structure.LoadString(t, ts.Config.TerraformDir, "plan_json_"+testName)
+	var plan tfjson.Plan
+	if err := json.Unmarshal([]byte(planJSON), &plan); err != nil {
+		return errors.Wrap(err, "failed to parse saved plan JSON")
+	}
+
+	estimate := pricing.Estimate(&plan, catalog, ts.Config.Region)
+
+	if estimate.HourlyCost > ts.Config.CostLimits.MaxHourlyCost {
+		return fmt.Errorf("estimated hourly cost (%.2f) exceeds limit (%.2f)",
+			estimate.HourlyCost, ts.Config.CostLimits.MaxHourlyCost)
+	}
+
+	if estimate.DailyCost > ts.Config.CostLimits.MaxDailyCost {
+		return fmt.Errorf("estimated daily cost (%.2f) exceeds limit (%.2f)",
+			estimate.DailyCost, ts.Config.CostLimits.MaxDailyCost)
+	}
+
+	if estimate.MonthlyCost > ts.Config.CostLimits.MaxMonthlyCost {
+		return fmt.Errorf("estimated monthly cost (%.2f) exceeds limit (%.2f)",
+			estimate.MonthlyCost, ts.Config.CostLimits.MaxMonthlyCost)
+	}
 
-	// Check against limits
-	if estimatedHourlyCost > ts.Config.CostLimits.MaxHourlyCost {
-		return fmt.Errorf("estimated hourly cost (%.2f) exceeds limit (%.2f)", 
-			estimatedHourlyCost, ts.Config.CostLimits.MaxHourlyCost)
+	for _, bound := range []struct {
+		name string
+		cost float64
+		cap  float64
+	}{
+		{"hourly", estimate.HourlyCost, ts.Config.CostLimits.MaxHourlyCost},
+		{"daily", estimate.DailyCost, ts.Config.CostLimits.MaxDailyCost},
+		{"monthly", estimate.MonthlyCost, ts.Config.CostLimits.MaxMonthlyCost},
+	} {
+		if bound.cap > 0 && ts.Config.CostLimits.AlertThreshold > 0 && bound.cost >= bound.cap*ts.Config.CostLimits.AlertThreshold {
+			pterm.Warning.Printfln("projected %s cost $%.2f has crossed %.0f%% of the configured $%.2f cap",
+				bound.name, bound.cost, ts.Config.CostLimits.AlertThreshold*100, bound.cap)
+		}
 	}
 
-	if estimatedDailyCost > ts.Config.CostLimits.MaxDailyCost {
-		return fmt.Errorf("estimated daily cost (%.2f) exceeds limit (%.2f)", 
-			estimatedDailyCost, ts.Config.CostLimits.MaxDailyCost)
+	if previous, ok := loadPreviousCostEstimate(ts.Config.TerraformDir, testName); ok {
+		diff := pricing.Diff(previous, estimate)
+		ts.Logger.Info().
+			Float64("monthly_delta_usd", diff.MonthlyDeltaUSD).
+			Msg("Cost diff against prior applied state")
 	}
 
-	if estimatedMonthlyCost > ts.Config.CostLimits.MaxMonthlyCost {
-		return fmt.Errorf("estimated monthly cost (%.2f) exceeds limit (%.2f)", 
-			estimatedMonthlyCost, ts.Config.CostLimits.MaxMonthlyCost)
+	if err := saveCostEstimate(ts.Config.TerraformDir, testName, estimate); err != nil {
+		ts.Logger.Warn().Err(err).Msg("failed to persist cost estimate for future CostDiff")
 	}
 
 	ts.Logger.Info().
-		Float64("hourly_cost", estimatedHourlyCost).
-		Float64("daily_cost", estimatedDailyCost).
-		Float64("monthly_cost", estimatedMonthlyCost).
+		Float64("hourly_cost", estimate.HourlyCost).
+		Float64("daily_cost", estimate.DailyCost).
+		Float64("monthly_cost", estimate.MonthlyCost).
+		Interface("breakdown", estimate.Breakdown).
 		Msg("Cost estimation completed")
 
 	return nil
 }
 
-// TestBackupRecovery tests backup and recovery
+// TestBackupRecovery runs every checker.CategoryBackup Checker
+// (database backup, storage backup, plus any the caller has registered)
+// against the deployed infrastructure's outputs.
 func (ts *TestSuite) TestBackupRecovery(t *testing.T, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing backup and recovery")
-
-	// Test database backup
-	if dbEndpoint, ok := outputs["database_endpoint"].(string); ok && dbEndpoint != "" {
-		if err := ts.TestDatabaseBackup(dbEndpoint); err != nil {
-			return errors.Wrap(err, "database backup test failed")
-		}
-	}
-
-	// Test file system backup
-	if storageEndpoint, ok := outputs["storage_endpoint"].(string); ok && storageEndpoint != "" {
-		if err := ts.TestStorageBackup(storageEndpoint); err != nil {
-			return errors.Wrap(err, "storage backup test failed")
-		}
-	}
-
-	ts.Logger.Info().Msg("All backup and recovery tests passed")
-	return nil
+	return ts.runCheckerCategory(checker.CategoryBackup, outputs)
 }
 
-// TestMonitoring tests monitoring and alerting
+// TestMonitoring runs every checker.CategoryMonitoring Checker
+// (monitoring endpoint, alerting endpoint, plus any the caller has
+// registered) against the deployed infrastructure's outputs.
 func (ts *TestSuite) TestMonitoring(t *testing.T, outputs map[string]interface{}) error {
 	ts.Logger.Info().Msg("Testing monitoring")
+	return ts.runCheckerCategory(checker.CategoryMonitoring, outputs)
+}
 
-	// Test monitoring endpoints
-	if monitoringEndpoint, ok := outputs["monitoring_endpoint"].(string); ok && monitoringEndpoint != "" {
-		if err := ts.TestMonitoringEndpoint(monitoringEndpoint); err != nil {
-			return errors.Wrap(err, "monitoring endpoint test failed")
-		}
+// workloadTargetFromOutputs builds the workload.Target every
+// TestLoadPerformance/TestStressPerformance/TestEndurancePerformance drives
+// against, pointed at outputs["load_balancer_endpoint"] (falling back to
+// outputs["public_endpoint"]), mirroring AWSTestSuite's
+// awsLoadTargetFromOutputs (aws_load_test.go).
+func workloadTargetFromOutputs(outputs map[string]interface{}) (workload.Target, error) {
+	endpoint, _ := outputs["load_balancer_endpoint"].(string)
+	if endpoint == "" {
+		endpoint, _ = outputs["public_endpoint"].(string)
 	}
-
-	// Test alerting
-	if alertingEndpoint, ok := outputs["alerting_endpoint"].(string); ok && alertingEndpoint != "" {
-		if err := ts.TestAlertingEndpoint(alertingEndpoint); err != nil {
-			return errors.Wrap(err, "alerting endpoint test failed")
-		}
+	if endpoint == "" {
+		return workload.Target{}, errors.New("no load_balancer_endpoint or public_endpoint in outputs")
 	}
-
-	ts.Logger.Info().Msg("All monitoring tests passed")
-	return nil
+	if !strings.HasPrefix(endpoint, "http") {
+		endpoint = "https://" + endpoint
+	}
+	return workload.Target{Protocol: workload.ProtocolHTTP, URL: endpoint}, nil
 }
 
-// Helper methods for specific tests
-func (ts *TestSuite) TestDatabaseHealth(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing database health")
-	// Implementation would test actual database connectivity
-	return nil
-}
+// TestLoadPerformance drives an open-loop load scenario at
+// PerformanceConfig.MinThroughputRPS against outputs' endpoint for
+// LoadSampleSeconds, alongside system resource sampling, and fails if p99
+// latency, error rate, or resource utilization violate PerformanceConfig.
+func (ts *TestSuite) TestLoadPerformance(testName string, outputs map[string]interface{}) error {
+	ts.Logger.Info().Msg("Testing load performance")
 
-func (ts *TestSuite) TestCacheHealth(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing cache health")
-	// Implementation would test actual cache connectivity
-	return nil
+	rps := ts.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 50
+	}
+	assertions := workload.Assertions{
+		P99Latency:    time.Duration(ts.Config.PerformanceConfig.MaxResponseTimeMs) * time.Millisecond,
+		MinThroughput: float64(rps),
+	}
+	return ts.runPerformancePhase("load", testName, outputs, rps, 0, ts.Config.PerformanceConfig.LoadSampleSeconds, assertions)
 }
 
-func (ts *TestSuite) TestLoadBalancerHealth(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing load balancer health")
-	// Implementation would test actual load balancer connectivity
-	return nil
-}
+// TestStressPerformance drives a load scenario at 10x the configured
+// minimum throughput, ramped over a minute, for StressSampleSeconds, and
+// fails if the error rate or resource utilization breach PerformanceConfig.
+func (ts *TestSuite) TestStressPerformance(testName string, outputs map[string]interface{}) error {
+	ts.Logger.Info().Msg("Testing stress performance")
 
-func (ts *TestSuite) TestContainerServiceHealth(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing container service health")
-	// Implementation would test actual container service connectivity
-	return nil
+	rps := 10 * ts.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 500
+	}
+	assertions := workload.Assertions{MaxErrorRate: 0.05}
+	return ts.runPerformancePhase("stress", testName, outputs, rps, time.Minute, ts.Config.PerformanceConfig.StressSampleSeconds, assertions)
 }
 
-func (ts *TestSuite) TestHTTPConnectivity(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing HTTP connectivity")
-	// Implementation would test actual HTTP connectivity
-	return nil
-}
+// TestEndurancePerformance holds a steady-state load at
+// PerformanceConfig.MinThroughputRPS for EnduranceSampleSeconds to catch
+// leaks/degradation that only show up under sustained load; it only runs
+// when PerformanceConfig.EnableEnduranceTesting is set.
+func (ts *TestSuite) TestEndurancePerformance(testName string, outputs map[string]interface{}) error {
+	ts.Logger.Info().Msg("Testing endurance performance")
 
-func (ts *TestSuite) TestInternalConnectivity(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing internal connectivity")
-	// Implementation would test actual internal connectivity
-	return nil
-}
+	if !ts.Config.PerformanceConfig.EnableEnduranceTesting {
+		ts.Logger.Info().Msg("Endurance testing disabled, skipping")
+		return nil
+	}
 
-func (ts *TestSuite) TestSSLConfiguration(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing SSL configuration")
-	// Implementation would test SSL/TLS configuration
-	return nil
+	rps := ts.Config.PerformanceConfig.MinThroughputRPS
+	if rps <= 0 {
+		rps = 20
+	}
+	assertions := workload.Assertions{
+		P99Latency: time.Duration(ts.Config.PerformanceConfig.MaxResponseTimeMs) * time.Millisecond,
+	}
+	return ts.runPerformancePhase("endurance", testName, outputs, rps, 0, ts.Config.PerformanceConfig.EnduranceSampleSeconds, assertions)
 }
 
-func (ts *TestSuite) TestNetworkSecurity(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing network security")
-	// Implementation would test network security rules
-	return nil
-}
+// runPerformancePhase runs a workload.Scenario against outputs' endpoint
+// (open-loop, ramped to rps over rampUp) concurrently with system resource
+// sampling, both for durationSeconds, records a combined ResourceUsage/
+// PerformanceResult TestResult, writes the workload result's Prometheus
+// text report alongside the run's other reports, and fails when either the
+// workload's assertions or PerformanceConfig's p95 utilization limits are
+// breached. A missing load-balancer/public endpoint in outputs degrades to
+// resource sampling only, so suites without an HTTP frontend still get
+// utilization gating.
+func (ts *TestSuite) runPerformancePhase(phase, testName string, outputs map[string]interface{}, rps int, rampUp time.Duration, durationSeconds int, assertions workload.Assertions) error {
+	duration := time.Duration(durationSeconds) * time.Second
+	interval := time.Duration(ts.Config.PerformanceConfig.SamplingIntervalMs) * time.Millisecond
+
+	var samples []metrics.Sample
+	var workloadResult workload.Result
+	var workloadErr error
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		samples = sampleSystemMetrics(interval, "", duration)
+	}()
 
-func (ts *TestSuite) TestAccessControls(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing access controls")
-	// Implementation would test access control policies
-	return nil
-}
+	if target, err := workloadTargetFromOutputs(outputs); err != nil {
+		ts.Logger.Warn().Err(err).Str("phase", phase).Msg("No HTTP endpoint in outputs; running resource sampling only")
+	} else {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scenario := workload.Scenario{
+				Name:       phase,
+				Mode:       workload.ModeOpenLoop,
+				RPS:        rps,
+				RampUp:     rampUp,
+				Duration:   duration,
+				Targets:    []workload.Target{target},
+				Assertions: assertions,
+			}
+			workloadResult, workloadErr = workload.Run(ts.Context, scenario)
+		}()
+	}
+	wg.Wait()
 
-func (ts *TestSuite) TestEncryption(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing encryption")
-	// Implementation would test encryption configuration
-	return nil
-}
+	summary, err := metrics.Summarize(samples)
+	if err != nil {
+		return errors.Wrapf(err, "failed to summarize %s phase metrics", phase)
+	}
 
-func (ts *TestSuite) TestLoadPerformance(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing load performance")
-	// Implementation would run load performance tests
-	return nil
-}
+	reportDir := filepath.Join(ts.Config.TerraformDir, ".test-data")
+	if err := os.MkdirAll(reportDir, 0o755); err == nil {
+		metricsPath := filepath.Join(reportDir, fmt.Sprintf("%s-%s-metrics.prom", testName, phase))
+		if writeErr := os.WriteFile(metricsPath, []byte(metrics.PrometheusText(phase, summary)), 0o644); writeErr != nil {
+			ts.Logger.Warn().Err(writeErr).Str("phase", phase).Msg("failed to write Prometheus metrics report")
+		}
+		if workloadResult.Requests > 0 {
+			workloadPath := filepath.Join(reportDir, fmt.Sprintf("%s-%s-workload.prom", testName, phase))
+			if writeErr := os.WriteFile(workloadPath, []byte(workloadResult.PrometheusText()), 0o644); writeErr != nil {
+				ts.Logger.Warn().Err(writeErr).Str("phase", phase).Msg("failed to write Prometheus workload report")
+			}
+		}
+	}
 
-func (ts *TestSuite) TestStressPerformance(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing stress performance")
-	// Implementation would run stress performance tests
-	return nil
-}
+	ts.Results = append(ts.Results, TestResult{
+		TestName:  fmt.Sprintf("%s-%s", testName, phase),
+		StartTime: time.Now().Add(-duration),
+		EndTime:   time.Now(),
+		ResourceUsage: ResourceUsage{
+			CPUUsage:    summary.CPUPercent.Mean,
+			MemoryUsage: summary.MemoryPercent.Mean,
+			DiskUsage:   summary.DiskPercent.Mean,
+			Timeseries:  samples,
+		},
+		PerformanceResult: PerformanceResult{
+			ThroughputRPS: workloadResult.ThroughputRPS,
+			ErrorRate:     workloadResult.ErrorRate,
+			SuccessRate:   1 - workloadResult.ErrorRate,
+			Percentiles: map[string]time.Duration{
+				"p50":   workloadResult.P50,
+				"p90":   workloadResult.P90,
+				"p99":   workloadResult.P99,
+				"p99.9": workloadResult.P999,
+			},
+			ResourceStats: summary,
+		},
+	})
 
-func (ts *TestSuite) TestEndurancePerformance(outputs map[string]interface{}) error {
-	ts.Logger.Info().Msg("Testing endurance performance")
-	// Implementation would run endurance performance tests
-	return nil
-}
+	ts.Logger.Info().
+		Str("phase", phase).
+		Float64("cpu_p95", summary.CPUPercent.P95).
+		Float64("memory_p95", summary.MemoryPercent.P95).
+		Float64("disk_p95", summary.DiskPercent.P95).
+		Interface("workload_result", workloadResult).
+		Msg("Performance phase completed")
+
+	for _, bound := range []struct {
+		name  string
+		p95   float64
+		limit float64
+	}{
+		{"cpu", summary.CPUPercent.P95, ts.Config.PerformanceConfig.MaxCPUUtilization},
+		{"memory", summary.MemoryPercent.P95, ts.Config.PerformanceConfig.MaxMemoryUtilization},
+		{"disk", summary.DiskPercent.P95, ts.Config.PerformanceConfig.MaxDiskUtilization},
+	} {
+		if bound.limit > 0 && bound.p95/100.0 > bound.limit {
+			return fmt.Errorf("%s phase p95 %s utilization (%.2f%%) exceeds limit (%.2f%%)",
+				phase, bound.name, bound.p95, bound.limit*100)
+		}
+	}
+
+	if workloadErr != nil {
+		return errors.Wrapf(workloadErr, "%s phase workload scenario failed", phase)
+	}
 
-func (ts *TestSuite) TestDatabaseBackup(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing database backup")
-	// Implementation would test database backup functionality
 	return nil
 }
 
-func (ts *TestSuite) TestStorageBackup(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing storage backup")
-	// Implementation would test storage backup functionality
-	return nil
+// Protect exempts id -- one of kind's resources, e.g. a container name,
+// volume name, network name, DNS record, bucket, or database name -- from
+// deletion by Cleanup. This mirrors moby's protectedElements: a fixed
+// allow-list of resources a cleanup pass must never touch, such as a
+// shared VPC or a pre-existing DNS zone the suite only reads.
+func (ts *TestSuite) Protect(kind string, ids ...string) {
+	for _, id := range ids {
+		ts.protected.add(kind, id)
+	}
 }
 
-func (ts *TestSuite) TestMonitoringEndpoint(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing monitoring endpoint")
-	// Implementation would test monitoring endpoint
-	return nil
+// TrackResource records id as a kind resource this run created, so Cleanup
+// knows to consider deleting it. Call this at the point a test creates a
+// resource outside Terraform's own lifecycle (e.g. a scratch S3 bucket or
+// database created by a probe).
+func (ts *TestSuite) TrackResource(kind, id string) {
+	ts.created.add(kind, id)
 }
 
-func (ts *TestSuite) TestAlertingEndpoint(endpoint string) error {
-	ts.Logger.Info().Str("endpoint", endpoint).Msg("Testing alerting endpoint")
-	// Implementation would test alerting endpoint
-	return nil
+// RegisterCleaner registers the function Cleanup calls to delete an
+// unprotected kind resource by id. A kind with no registered cleaner is
+// only logged, never deleted, so tracking a resource before its cleaner is
+// registered fails safe instead of silently skipping it.
+func (ts *TestSuite) RegisterCleaner(kind string, deleteFn func(id string) error) {
+	ts.cleaners.Store(kind, deleteFn)
 }
 
-// Cleanup performs cleanup operations
+// Cleanup deletes every tracked resource not exempted by Protect, via the
+// cleaner RegisterCleaner registered for its kind, and logs every skip,
+// deletion, and failure so an interrupted or partial cleanup is always
+// auditable from the log. See RunSuite for the TestMain-level lifecycle
+// that guarantees this runs even when a run is interrupted.
 func (ts *TestSuite) Cleanup() {
 	ts.Logger.Info().Msg("Performing cleanup operations")
-	// Implementation would perform cleanup operations
-}
\ No newline at end of file
+
+	for _, kind := range ts.created.kinds() {
+		cleaner, hasCleaner := ts.cleaners.Load(kind)
+		for _, id := range ts.created.items(kind) {
+			logEvent := ts.Logger.Info().Str("kind", kind).Str("id", id)
+
+			if ts.protected.has(kind, id) {
+				logEvent.Msg("Skipping protected resource")
+				continue
+			}
+			if !hasCleaner {
+				ts.Logger.Warn().Str("kind", kind).Str("id", id).
+					Msg("No cleaner registered for resource kind; leaving in place")
+				continue
+			}
+			if err := cleaner.(func(string) error)(id); err != nil {
+				ts.Logger.Error().Err(err).Str("kind", kind).Str("id", id).Msg("Failed to clean up resource")
+				continue
+			}
+			logEvent.Msg("Cleaned up resource")
+		}
+	}
+}
+
+// RunSuite runs m, guaranteeing suite.Cleanup() still runs -- when
+// suite.Config.CleanupEnabled -- if the run is interrupted by SIGINT or
+// SIGTERM, so killing an integration run doesn't orphan cloud resources.
+// Returns the exit code the caller should pass to os.Exit.
+func RunSuite(m *testing.M, suite *TestSuite) int {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-sigCh:
+			suite.Logger.Warn().Str("signal", sig.String()).Msg("Received interrupt; running cleanup before exit")
+			if suite.Config.CleanupEnabled {
+				suite.Cleanup()
+			}
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	code := m.Run()
+	close(done)
+	signal.Stop(sigCh)
+
+	if suite.Config.CleanupEnabled {
+		suite.Cleanup()
+	}
+
+	return code
+}
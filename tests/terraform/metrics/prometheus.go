@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrometheusText renders summary as Prometheus text-exposition format, one
+// gauge per metric/statistic labeled by phase (e.g. "load", "stress",
+// "endurance"), so an operator can scrape or promtool-check a long
+// endurance run's report without a running Prometheus server.
+func PrometheusText(phase string, summary Summary) string {
+	var b strings.Builder
+	b.WriteString("# HELP terraform_test_resource_usage System resource utilization sampled during a performance test run.\n")
+	b.WriteString("# TYPE terraform_test_resource_usage gauge\n")
+
+	write := func(metric, stat string, value float64) {
+		fmt.Fprintf(&b, "terraform_test_resource_usage{phase=%q,metric=%q,stat=%q} %f\n", phase, metric, stat, value)
+	}
+
+	for _, m := range []struct {
+		name  string
+		stats MetricStats
+	}{
+		{"cpu_percent", summary.CPUPercent},
+		{"memory_percent", summary.MemoryPercent},
+		{"disk_percent", summary.DiskPercent},
+	} {
+		write(m.name, "mean", m.stats.Mean)
+		write(m.name, "stddev", m.stats.StdDev)
+		write(m.name, "p50", m.stats.P50)
+		write(m.name, "p90", m.stats.P90)
+		write(m.name, "p95", m.stats.P95)
+		write(m.name, "p99", m.stats.P99)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultInterval is how often a caller should poll when sampling at no
+// particular interval override.
+const DefaultInterval = 2 * time.Second
+
+// DefaultCapacity bounds how many Samples a RingBuffer holds before it
+// starts overwriting the oldest -- long enough for several hours of an
+// endurance run at DefaultInterval without unbounded memory growth.
+const DefaultCapacity = 10000
+
+// RingBuffer is a fixed-capacity buffer of Sample that overwrites its
+// oldest entry once full, so a long-running endurance sampler doesn't grow
+// without bound.
+type RingBuffer struct {
+	mu       sync.Mutex
+	samples  []Sample
+	capacity int
+	next     int
+	full     bool
+}
+
+// NewRingBuffer returns an empty RingBuffer holding at most capacity
+// Samples.
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &RingBuffer{samples: make([]Sample, capacity), capacity: capacity}
+}
+
+// Push appends sample, overwriting the oldest entry once the buffer is at
+// capacity.
+func (r *RingBuffer) Push(sample Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % r.capacity
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Samples returns every Sample currently held, oldest first.
+func (r *RingBuffer) Samples() []Sample {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		out := make([]Sample, r.next)
+		copy(out, r.samples[:r.next])
+		return out
+	}
+
+	out := make([]Sample, r.capacity)
+	copy(out, r.samples[r.next:])
+	copy(out[r.capacity-r.next:], r.samples[:r.next])
+	return out
+}
@@ -0,0 +1,23 @@
+// Package metrics continuously samples system resource utilization
+// (gopsutil cpu/mem/disk/net/load) during a performance test run and
+// reduces the samples to summary statistics (montanaflynn/stats), so
+// TestLoadPerformance/TestStressPerformance/TestEndurancePerformance in
+// tests/terraform/main_test.go have real numbers to gate
+// PerformanceConfig's utilization limits on instead of an unpopulated
+// PerformanceResult.
+package metrics
+
+import "time"
+
+// Sample is one point-in-time snapshot of system resource utilization.
+// NetworkBytesSent/Recv are the delta since the previous Sample, not a
+// cumulative counter.
+type Sample struct {
+	Timestamp        time.Time `json:"timestamp"`
+	CPUPercent       float64   `json:"cpu_percent"`
+	MemoryPercent    float64   `json:"memory_percent"`
+	DiskPercent      float64   `json:"disk_percent"`
+	NetworkBytesSent uint64    `json:"network_bytes_sent"`
+	NetworkBytesRecv uint64    `json:"network_bytes_recv"`
+	LoadAverage1     float64   `json:"load_average_1"`
+}
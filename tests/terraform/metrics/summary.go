@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"github.com/montanaflynn/stats"
+	"github.com/pkg/errors"
+)
+
+// MetricStats is one metric's summary statistics across a sampling run.
+type MetricStats struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	P95    float64 `json:"p95"`
+	P99    float64 `json:"p99"`
+}
+
+// Summary is Summarize's per-metric MetricStats across a set of Samples.
+type Summary struct {
+	CPUPercent    MetricStats `json:"cpu_percent"`
+	MemoryPercent MetricStats `json:"memory_percent"`
+	DiskPercent   MetricStats `json:"disk_percent"`
+}
+
+// Summarize computes mean/stddev/p50/p90/p95/p99 for CPU, memory, and disk
+// utilization across samples. An empty samples returns a zero Summary.
+func Summarize(samples []Sample) (Summary, error) {
+	if len(samples) == 0 {
+		return Summary{}, nil
+	}
+
+	cpuValues := make([]float64, len(samples))
+	memValues := make([]float64, len(samples))
+	diskValues := make([]float64, len(samples))
+	for i, sample := range samples {
+		cpuValues[i] = sample.CPUPercent
+		memValues[i] = sample.MemoryPercent
+		diskValues[i] = sample.DiskPercent
+	}
+
+	cpuStats, err := metricStats(cpuValues)
+	if err != nil {
+		return Summary{}, errors.Wrap(err, "failed to summarize cpu_percent")
+	}
+	memStats, err := metricStats(memValues)
+	if err != nil {
+		return Summary{}, errors.Wrap(err, "failed to summarize memory_percent")
+	}
+	diskStats, err := metricStats(diskValues)
+	if err != nil {
+		return Summary{}, errors.Wrap(err, "failed to summarize disk_percent")
+	}
+
+	return Summary{CPUPercent: cpuStats, MemoryPercent: memStats, DiskPercent: diskStats}, nil
+}
+
+func metricStats(values []float64) (MetricStats, error) {
+	mean, err := stats.Mean(values)
+	if err != nil {
+		return MetricStats{}, err
+	}
+	stddev, err := stats.StandardDeviation(values)
+	if err != nil {
+		return MetricStats{}, err
+	}
+	p50, err := stats.Percentile(values, 50)
+	if err != nil {
+		return MetricStats{}, err
+	}
+	p90, err := stats.Percentile(values, 90)
+	if err != nil {
+		return MetricStats{}, err
+	}
+	p95, err := stats.Percentile(values, 95)
+	if err != nil {
+		return MetricStats{}, err
+	}
+	p99, err := stats.Percentile(values, 99)
+	if err != nil {
+		return MetricStats{}, err
+	}
+
+	return MetricStats{Mean: mean, StdDev: stddev, P50: p50, P90: p90, P95: p95, P99: p99}, nil
+}
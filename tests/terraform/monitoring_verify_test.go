@@ -0,0 +1,128 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/observability"
+)
+
+// monitoringHTTPTimeout bounds every request runMonitoringCheck/
+// runAlertingCheck issues against a monitoring endpoint.
+const monitoringHTTPTimeout = 15 * time.Second
+
+// parseMetricRequirement parses one MonitoringRequiredMetrics entry, either
+// a bare metric name ("http_requests_total") or a name with an inline label
+// constraint ("http_requests_total{job=api}"), the same brace syntax
+// PromQL selectors use.
+func parseMetricRequirement(spec string) (observability.MetricRequirement, error) {
+	name, rest, hasLabels := strings.Cut(spec, "{")
+	if !hasLabels {
+		return observability.MetricRequirement{Name: name}, nil
+	}
+	rest = strings.TrimSuffix(rest, "}")
+
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(rest, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return observability.MetricRequirement{}, errors.Errorf("invalid metric requirement %q: malformed label %q", spec, pair)
+		}
+		labels[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return observability.MetricRequirement{Name: name, Labels: labels}, nil
+}
+
+// parseMetricRequirements parses every spec in specs via
+// parseMetricRequirement.
+func parseMetricRequirements(specs []string) ([]observability.MetricRequirement, error) {
+	requirements := make([]observability.MetricRequirement, 0, len(specs))
+	for _, spec := range specs {
+		req, err := parseMetricRequirement(spec)
+		if err != nil {
+			return nil, err
+		}
+		requirements = append(requirements, req)
+	}
+	return requirements, nil
+}
+
+// runMonitoringCheck scrapes endpoint as Prometheus would, asserts every
+// entry in requiredMetrics is present (with any inline label constraint
+// satisfied), and -- if expectedTargets is non-empty -- queries endpoint's
+// /api/v1/targets to assert each job has at least one target with
+// Health == "up". Shared by AWSTestSuite/AzureTestSuite/GCPTestSuite's
+// TestMonitoringEndpoint.
+func runMonitoringCheck(ctx context.Context, logger zerolog.Logger, endpoint string, requiredMetrics, expectedTargets []string) error {
+	client := &http.Client{Timeout: monitoringHTTPTimeout}
+
+	requirements, err := parseMetricRequirements(requiredMetrics)
+	if err != nil {
+		return err
+	}
+
+	var report observability.Report
+
+	if len(requirements) > 0 {
+		scrape, err := observability.Scrape(ctx, client, endpoint)
+		if err != nil {
+			return errors.Wrap(err, "failed to scrape monitoring endpoint")
+		}
+		report.MissingMetrics = scrape.Missing(requirements)
+	}
+
+	if len(expectedTargets) > 0 {
+		targets, err := observability.FetchTargets(ctx, client, endpoint)
+		if err != nil {
+			return errors.Wrap(err, "failed to query monitoring endpoint targets")
+		}
+		report.DownTargets = observability.FailedTargets(targets, expectedTargets)
+	}
+
+	logger.Info().
+		Int("required_metrics", len(requirements)).
+		Int("missing_metrics", len(report.MissingMetrics)).
+		Int("expected_targets", len(expectedTargets)).
+		Int("unhealthy_targets", len(report.DownTargets)).
+		Msg("monitoring endpoint checked")
+
+	if err := report.AsError(); err != nil {
+		return errors.Wrap(err, "monitoring endpoint validation failed")
+	}
+	return nil
+}
+
+// runAlertingCheck queries endpoint's Alertmanager /api/v2/alerts and
+// /api/v2/silences, and fails if any alert not in allowedFiringAlerts (and
+// not covered by an active silence) is firing. Shared by AWSTestSuite/
+// AzureTestSuite/GCPTestSuite's TestAlertingEndpoint.
+func runAlertingCheck(ctx context.Context, logger zerolog.Logger, endpoint string, allowedFiringAlerts []string) error {
+	client := &http.Client{Timeout: monitoringHTTPTimeout}
+
+	alerts, err := observability.FetchAlerts(ctx, client, endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to query alerting endpoint alerts")
+	}
+	silences, err := observability.FetchSilences(ctx, client, endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to query alerting endpoint silences")
+	}
+
+	report := observability.Report{FiringAlerts: observability.FiringAlerts(alerts, silences, allowedFiringAlerts)}
+
+	logger.Info().
+		Int("alerts", len(alerts)).
+		Int("silences", len(silences)).
+		Int("unexpected_firing", len(report.FiringAlerts)).
+		Msg("alerting endpoint checked")
+
+	if err := report.AsError(); err != nil {
+		return errors.Wrap(err, "alerting endpoint validation failed")
+	}
+	return nil
+}
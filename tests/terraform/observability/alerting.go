@@ -0,0 +1,154 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Alert is one entry from Alertmanager's /api/v2/alerts, trimmed to the
+// fields FiringAlerts needs.
+type Alert struct {
+	Name   string
+	Labels map[string]string
+	State  string
+}
+
+// alertmanagerAlert mirrors one entry of Alertmanager's /api/v2/alerts
+// response body.
+type alertmanagerAlert struct {
+	Labels map[string]string `json:"labels"`
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// FetchAlerts queries baseURL+"/api/v2/alerts" and returns every alert
+// Alertmanager currently knows about.
+func FetchAlerts(ctx context.Context, client *http.Client, baseURL string) ([]Alert, error) {
+	var raw []alertmanagerAlert
+	if err := getJSON(ctx, client, baseURL+"/api/v2/alerts", &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to query alerts API")
+	}
+
+	alerts := make([]Alert, 0, len(raw))
+	for _, a := range raw {
+		alerts = append(alerts, Alert{
+			Name:   a.Labels["alertname"],
+			Labels: a.Labels,
+			State:  a.Status.State,
+		})
+	}
+	return alerts, nil
+}
+
+// Silence is one entry from Alertmanager's /api/v2/silences, trimmed to
+// the fields FiringAlerts needs to tell an acknowledged alert from an
+// unexpected one.
+type Silence struct {
+	Matchers map[string]string
+	State    string
+}
+
+// alertmanagerSilence mirrors one entry of Alertmanager's /api/v2/silences
+// response body.
+type alertmanagerSilence struct {
+	Matchers []struct {
+		Name    string `json:"name"`
+		Value   string `json:"value"`
+		IsEqual *bool  `json:"isEqual"`
+		IsRegex bool   `json:"isRegex"`
+	} `json:"matchers"`
+	Status struct {
+		State string `json:"state"`
+	} `json:"status"`
+}
+
+// FetchSilences queries baseURL+"/api/v2/silences" and returns every
+// silence Alertmanager currently knows about.
+func FetchSilences(ctx context.Context, client *http.Client, baseURL string) ([]Silence, error) {
+	var raw []alertmanagerSilence
+	if err := getJSON(ctx, client, baseURL+"/api/v2/silences", &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to query silences API")
+	}
+
+	silences := make([]Silence, 0, len(raw))
+	for _, s := range raw {
+		matchers := make(map[string]string, len(s.Matchers))
+		for _, m := range s.Matchers {
+			if m.IsRegex {
+				continue
+			}
+			matchers[m.Name] = m.Value
+		}
+		silences = append(silences, Silence{Matchers: matchers, State: s.Status.State})
+	}
+	return silences, nil
+}
+
+// silenced reports whether alert matches an active silence's exact-value
+// matchers (a regex silence is skipped rather than approximated).
+func silenced(alert Alert, silences []Silence) bool {
+	for _, s := range silences {
+		if s.State != "active" || len(s.Matchers) == 0 {
+			continue
+		}
+		matched := true
+		for k, v := range s.Matchers {
+			if alert.Labels[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FiringAlerts returns the name of every alert in alerts whose State is
+// "active" (Alertmanager's v2 API name for firing), is not silenced by
+// silences, and is not in allowed -- the routing-rule check the request
+// calls for: a named alert is expected to fire, anything else firing is
+// unexpected.
+func FiringAlerts(alerts []Alert, silences []Silence, allowed []string) []string {
+	allow := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allow[name] = true
+	}
+
+	var firing []string
+	for _, a := range alerts {
+		if a.State != "active" || allow[a.Name] || silenced(a, silences) {
+			continue
+		}
+		firing = append(firing, fmt.Sprintf("%s %v", a.Name, a.Labels))
+	}
+	return firing
+}
+
+// getJSON decodes the JSON response of a GET request to url into out.
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build request for %s", url)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to query %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s returned status %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "failed to decode response from %s", url)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package observability
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Report aggregates every check this package's drivers can run against a
+// monitoring stack. Each field is independently optional -- a caller that
+// only wants the scrape check leaves the others nil -- so one Report
+// serves TestMonitoringEndpoint (MissingMetrics, DownTargets) and
+// TestAlertingEndpoint (FiringAlerts) alike.
+type Report struct {
+	MissingMetrics []string
+	DownTargets    []string
+	FiringAlerts   []string
+}
+
+// Empty reports whether every check in r passed.
+func (r Report) Empty() bool {
+	return len(r.MissingMetrics) == 0 && len(r.DownTargets) == 0 && len(r.FiringAlerts) == 0
+}
+
+// AsError renders r as a single error listing every failed check, or nil
+// if r is Empty.
+func (r Report) AsError() error {
+	if r.Empty() {
+		return nil
+	}
+
+	var sections []string
+	if len(r.MissingMetrics) > 0 {
+		sections = append(sections, fmt.Sprintf("missing metrics: %s", strings.Join(r.MissingMetrics, ", ")))
+	}
+	if len(r.DownTargets) > 0 {
+		sections = append(sections, fmt.Sprintf("unhealthy targets: %s", strings.Join(r.DownTargets, "; ")))
+	}
+	if len(r.FiringAlerts) > 0 {
+		sections = append(sections, fmt.Sprintf("unexpected firing alerts: %s", strings.Join(r.FiringAlerts, "; ")))
+	}
+	return errors.New(strings.Join(sections, " | "))
+}
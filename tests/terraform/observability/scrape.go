@@ -0,0 +1,99 @@
+// Package observability drives the same checks a monitoring stack's own
+// consumers would: scraping a Prometheus-compatible metrics endpoint and
+// asserting required series are present, querying Prometheus's targets API
+// for scrape health, and querying Alertmanager's API for firing alerts and
+// active silences. It only defines the HTTP drivers and the Report that
+// aggregates their results; TestMonitoringEndpoint/TestAlertingEndpoint on
+// each cloud-specific suite decide what to require and how to fail.
+package observability
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// MetricRequirement names a metric ScrapeReport.Missing must find among the
+// scraped families, optionally constraining it to have at least one sample
+// carrying every label in Labels (e.g. {"job": "api"}) -- this is the label
+// cardinality check the request calls for, expressed per-requirement rather
+// than as a separate pass.
+type MetricRequirement struct {
+	Name   string
+	Labels map[string]string
+}
+
+// ScrapeReport is the parsed result of one Prometheus text-exposition
+// scrape, keyed by metric name.
+type ScrapeReport struct {
+	Families map[string]*dto.MetricFamily
+}
+
+// Scrape fetches url and parses its body as the Prometheus text/plain;
+// version=0.0.4 exposition format (expfmt.TextParser, the same parser
+// Prometheus's own scrape loop uses), returning one MetricFamily per
+// metric name found.
+func Scrape(ctx context.Context, client *http.Client, url string) (*ScrapeReport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build request for %s", url)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to scrape %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("scrape of %s returned status %s", url, resp.Status)
+	}
+
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse metrics exposition from %s", url)
+	}
+	return &ScrapeReport{Families: families}, nil
+}
+
+// metricHasLabels reports whether family has at least one sample carrying
+// every key/value in labels.
+func metricHasLabels(family *dto.MetricFamily, labels map[string]string) bool {
+	if len(labels) == 0 {
+		return true
+	}
+	for _, m := range family.GetMetric() {
+		have := make(map[string]string, len(m.GetLabel()))
+		for _, lp := range m.GetLabel() {
+			have[lp.GetName()] = lp.GetValue()
+		}
+		matched := true
+		for k, v := range labels {
+			if have[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Missing returns the name of every required MetricRequirement r.Families
+// has no matching family for, or whose matching family has no sample
+// satisfying the requirement's Labels.
+func (r *ScrapeReport) Missing(required []MetricRequirement) []string {
+	var missing []string
+	for _, req := range required {
+		family, ok := r.Families[req.Name]
+		if !ok || !metricHasLabels(family, req.Labels) {
+			missing = append(missing, req.Name)
+		}
+	}
+	return missing
+}
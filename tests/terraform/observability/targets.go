@@ -0,0 +1,97 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Target is one entry from Prometheus's /api/v1/targets activeTargets,
+// trimmed to the fields FailedTargets needs.
+type Target struct {
+	Job       string
+	ScrapeURL string
+	Health    string
+	LastError string
+}
+
+// targetsAPIResponse mirrors the subset of Prometheus's /api/v1/targets
+// response body this package reads.
+type targetsAPIResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ActiveTargets []struct {
+			ScrapeURL string            `json:"scrapeUrl"`
+			Labels    map[string]string `json:"labels"`
+			Health    string            `json:"health"`
+			LastError string            `json:"lastError"`
+		} `json:"activeTargets"`
+	} `json:"data"`
+}
+
+// FetchTargets queries baseURL+"/api/v1/targets" and returns every active
+// target Prometheus reports.
+func FetchTargets(ctx context.Context, client *http.Client, baseURL string) ([]Target, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/targets", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build targets request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query targets API")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("targets API returned status %s", resp.Status)
+	}
+
+	var parsed targetsAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to decode targets API response")
+	}
+	if parsed.Status != "success" {
+		return nil, errors.Errorf("targets API reported status %q", parsed.Status)
+	}
+
+	targets := make([]Target, 0, len(parsed.Data.ActiveTargets))
+	for _, t := range parsed.Data.ActiveTargets {
+		targets = append(targets, Target{
+			Job:       t.Labels["job"],
+			ScrapeURL: t.ScrapeURL,
+			Health:    t.Health,
+			LastError: t.LastError,
+		})
+	}
+	return targets, nil
+}
+
+// FailedTargets returns, for every job in expectedJobs, a description of
+// why it's unhealthy: either no active target reports that job at all, or
+// at least one target for that job has Health != "up". A job with every
+// target healthy is omitted.
+func FailedTargets(targets []Target, expectedJobs []string) []string {
+	byJob := make(map[string][]Target, len(targets))
+	for _, t := range targets {
+		byJob[t.Job] = append(byJob[t.Job], t)
+	}
+
+	var failed []string
+	for _, job := range expectedJobs {
+		present, ok := byJob[job]
+		if !ok {
+			failed = append(failed, fmt.Sprintf("job %q has no active targets", job))
+			continue
+		}
+		for _, t := range present {
+			if t.Health != "up" {
+				failed = append(failed, fmt.Sprintf("job %q target %s is %s: %s", job, t.ScrapeURL, t.Health, t.LastError))
+			}
+		}
+	}
+	return failed
+}
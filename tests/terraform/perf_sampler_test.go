@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/metrics"
+)
+
+// pollSystemSample takes one metrics.Sample via gopsutil. prevSent/
+// prevRecv/havePrevNet track net.IOCounters as a running total across
+// calls so Sample.NetworkBytesSent/Recv report the delta since the
+// previous poll rather than gopsutil's cumulative counter. A metric
+// gopsutil fails to report is left at its zero value rather than
+// aborting the whole sample.
+func pollSystemSample(ctx context.Context, diskPath string, prevSent, prevRecv *uint64, havePrevNet *bool) metrics.Sample {
+	sample := metrics.Sample{Timestamp: time.Now()}
+
+	if percents, err := cpu.PercentWithContext(ctx, 0, false); err == nil && len(percents) > 0 {
+		sample.CPUPercent = percents[0]
+	}
+	if vm, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		sample.MemoryPercent = vm.UsedPercent
+	}
+	if usage, err := disk.UsageWithContext(ctx, diskPath); err == nil {
+		sample.DiskPercent = usage.UsedPercent
+	}
+	if counters, err := net.IOCountersWithContext(ctx, false); err == nil && len(counters) > 0 {
+		sent, recv := counters[0].BytesSent, counters[0].BytesRecv
+		if *havePrevNet {
+			sample.NetworkBytesSent = sent - *prevSent
+			sample.NetworkBytesRecv = recv - *prevRecv
+		}
+		*prevSent, *prevRecv, *havePrevNet = sent, recv, true
+	}
+	if avg, err := load.AvgWithContext(ctx); err == nil {
+		sample.LoadAverage1 = avg.Load1
+	}
+
+	return sample
+}
+
+// sampleSystemMetrics polls pollSystemSample every interval (metrics.
+// DefaultInterval if <= 0) into a metrics.RingBuffer of metrics.
+// DefaultCapacity until duration elapses, returning every Sample
+// collected in order.
+func sampleSystemMetrics(interval time.Duration, diskPath string, duration time.Duration) []metrics.Sample {
+	if interval <= 0 {
+		interval = metrics.DefaultInterval
+	}
+	if diskPath == "" {
+		diskPath = "/"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	buffer := metrics.NewRingBuffer(metrics.DefaultCapacity)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prevSent, prevRecv uint64
+	havePrevNet := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return buffer.Samples()
+		case <-ticker.C:
+			buffer.Push(pollSystemSample(ctx, diskPath, &prevSent, &prevRecv, &havePrevNet))
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package plananalysis turns a parsed Terraform plan
+// (github.com/hashicorp/terraform-json) into a structured PlanAnalysis --
+// per-resource-type action counts, which resources touch sensitive
+// attributes, and drift against a prior state snapshot -- so
+// TestSuite.RunPlanTests (tests/terraform/main_test.go) can gate a run on
+// a Policy instead of just logging the plan's raw text.
+package plananalysis
+
+import (
+	"fmt"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// ActionCounts tallies how many resources a plan creates, updates,
+// deletes, or replaces.
+type ActionCounts struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Delete  int `json:"delete"`
+	Replace int `json:"replace"`
+}
+
+// SensitiveResource is one resource address whose plan touches at least
+// one attribute Terraform marked sensitive.
+type SensitiveResource struct {
+	Address    string   `json:"address"`
+	Attributes []string `json:"attributes"`
+}
+
+// DriftEntry is one resource address whose recorded state differs from
+// (or is missing relative to) the prior snapshot DetectDrift compared
+// against.
+type DriftEntry struct {
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+}
+
+// PlanAnalysis is Analyze's structured summary of one Terraform plan.
+type PlanAnalysis struct {
+	Totals             ActionCounts            `json:"totals"`
+	ByResourceType     map[string]ActionCounts `json:"by_resource_type"`
+	SensitiveResources []SensitiveResource     `json:"sensitive_resources,omitempty"`
+	Drift              []DriftEntry            `json:"drift,omitempty"`
+}
+
+// Analyze classifies every ResourceChange in plan by action and resource
+// type, collects resources with sensitive attribute changes, and (when
+// plan carries a refreshed prior state) compares it against
+// previousSnapshot via DetectDrift.
+func Analyze(plan *tfjson.Plan, previousSnapshot map[string]map[string]interface{}) PlanAnalysis {
+	analysis := PlanAnalysis{ByResourceType: map[string]ActionCounts{}}
+
+	for _, change := range plan.ResourceChanges {
+		if change.Change == nil {
+			continue
+		}
+
+		counts := analysis.ByResourceType[change.Type]
+		switch {
+		case change.Change.Actions.Replace():
+			counts.Replace++
+			analysis.Totals.Replace++
+		case change.Change.Actions.Create():
+			counts.Create++
+			analysis.Totals.Create++
+		case change.Change.Actions.Update():
+			counts.Update++
+			analysis.Totals.Update++
+		case change.Change.Actions.Delete():
+			counts.Delete++
+			analysis.Totals.Delete++
+		default:
+			// no-op / read: nothing to tally
+		}
+		analysis.ByResourceType[change.Type] = counts
+
+		if paths := sensitivePaths("", change.Change.AfterSensitive); len(paths) > 0 {
+			analysis.SensitiveResources = append(analysis.SensitiveResources, SensitiveResource{
+				Address:    change.Address,
+				Attributes: paths,
+			})
+		}
+	}
+
+	if plan.PriorState != nil {
+		analysis.Drift = DetectDrift(previousSnapshot, Snapshot(plan.PriorState.Values))
+	}
+
+	return analysis
+}
+
+// sensitivePaths walks a Change's *Sensitive marker tree (booleans nested
+// inside maps/slices mirroring the value they mark) and returns the dotted
+// attribute paths marked true.
+func sensitivePaths(prefix string, marker interface{}) []string {
+	switch m := marker.(type) {
+	case bool:
+		if m {
+			return []string{prefix}
+		}
+		return nil
+	case map[string]interface{}:
+		var paths []string
+		for key, val := range m {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			paths = append(paths, sensitivePaths(path, val)...)
+		}
+		return paths
+	case []interface{}:
+		var paths []string
+		for i, val := range m {
+			paths = append(paths, sensitivePaths(fmt.Sprintf("%s[%d]", prefix, i), val)...)
+		}
+		return paths
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package plananalysis
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeTalliesActionsAndSensitiveResources(t *testing.T) {
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: "aws_instance.web",
+				Type:    "aws_instance",
+				Change: &tfjson.Change{
+					Actions:        tfjson.Actions{tfjson.ActionCreate},
+					AfterSensitive: map[string]interface{}{"password": true},
+				},
+			},
+			{
+				Address: "aws_instance.db",
+				Type:    "aws_instance",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+				},
+			},
+			{
+				Address: "aws_s3_bucket.logs",
+				Type:    "aws_s3_bucket",
+				Change: &tfjson.Change{
+					Actions: tfjson.Actions{tfjson.ActionNoOp},
+				},
+			},
+			{
+				Address: "aws_instance.skipped",
+				Type:    "aws_instance",
+				Change:  nil,
+			},
+		},
+	}
+
+	analysis := Analyze(plan, nil)
+
+	assert.Equal(t, 1, analysis.Totals.Create)
+	assert.Equal(t, 1, analysis.Totals.Replace)
+	assert.Equal(t, ActionCounts{Create: 1, Replace: 1}, analysis.ByResourceType["aws_instance"])
+	assert.Equal(t, ActionCounts{}, analysis.ByResourceType["aws_s3_bucket"])
+
+	require.Len(t, analysis.SensitiveResources, 1)
+	assert.Equal(t, "aws_instance.web", analysis.SensitiveResources[0].Address)
+	assert.Equal(t, []string{"password"}, analysis.SensitiveResources[0].Attributes)
+
+	assert.Empty(t, analysis.Drift, "no PriorState was given, so Analyze must not attempt to compute drift")
+}
+
+func TestAnalyzeDetectsDriftAgainstPriorState(t *testing.T) {
+	plan := &tfjson.Plan{
+		PriorState: &tfjson.State{
+			Values: &tfjson.StateValues{
+				RootModule: &tfjson.StateModule{
+					Resources: []*tfjson.StateResource{
+						{
+							Address:         "aws_instance.web",
+							AttributeValues: map[string]interface{}{"instance_type": "t3.large"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	previousSnapshot := map[string]map[string]interface{}{
+		"aws_instance.web": {"instance_type": "t3.micro"},
+		"aws_instance.old": {"instance_type": "t3.micro"},
+	}
+
+	analysis := Analyze(plan, previousSnapshot)
+
+	require.Len(t, analysis.Drift, 2)
+	reasons := map[string]string{}
+	for _, entry := range analysis.Drift {
+		reasons[entry.Address] = entry.Reason
+	}
+	assert.Contains(t, reasons["aws_instance.web"], "attribute values changed")
+	assert.Contains(t, reasons["aws_instance.old"], "missing from the current state")
+}
+
+func TestAnalyzeHandlesPriorStateWithNilValues(t *testing.T) {
+	plan := &tfjson.Plan{
+		PriorState: &tfjson.State{Values: nil},
+	}
+
+	analysis := Analyze(plan, map[string]map[string]interface{}{"aws_instance.web": {}})
+
+	require.Len(t, analysis.Drift, 1)
+	assert.Equal(t, "aws_instance.web", analysis.Drift[0].Address)
+}
+
+func TestViolationsMaxDestroys(t *testing.T) {
+	analysis := PlanAnalysis{Totals: ActionCounts{Delete: 2, Replace: 1}}
+
+	assert.Empty(t, analysis.Violations(Policy{MaxDestroys: 0}), "zero MaxDestroys means no limit")
+	assert.Empty(t, analysis.Violations(Policy{MaxDestroys: 3}))
+
+	violations := analysis.Violations(Policy{MaxDestroys: 2})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "destroys/replaces 3 resource(s)")
+}
+
+func TestViolationsForbiddenResourceTypes(t *testing.T) {
+	analysis := PlanAnalysis{
+		ByResourceType: map[string]ActionCounts{
+			"aws_iam_policy": {Create: 1},
+			"aws_s3_bucket":  {Update: 0},
+		},
+	}
+
+	violations := analysis.Violations(Policy{ForbiddenResourceTypes: []string{"aws_iam_policy", "aws_s3_bucket", "aws_instance"}})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], `"aws_iam_policy"`)
+}
+
+func TestViolationsRequireNoDrift(t *testing.T) {
+	analysis := PlanAnalysis{Drift: []DriftEntry{{Address: "aws_instance.web", Reason: "changed"}}}
+
+	assert.Empty(t, analysis.Violations(Policy{RequireNoDrift: false}))
+
+	violations := analysis.Violations(Policy{RequireNoDrift: true})
+	require.Len(t, violations, 1)
+	assert.Contains(t, violations[0], "1 resource(s) drifted")
+}
@@ -0,0 +1,62 @@
+package plananalysis
+
+import (
+	"reflect"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Snapshot flattens state's resources (including nested child modules)
+// into a map of resource address to its current attribute values, the
+// shape DetectDrift compares across runs.
+func Snapshot(state *tfjson.StateValues) map[string]map[string]interface{} {
+	snapshot := map[string]map[string]interface{}{}
+	if state == nil || state.RootModule == nil {
+		return snapshot
+	}
+
+	var walk func(module *tfjson.StateModule)
+	walk = func(module *tfjson.StateModule) {
+		for _, resource := range module.Resources {
+			snapshot[resource.Address] = resource.AttributeValues
+		}
+		for _, child := range module.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.RootModule)
+	return snapshot
+}
+
+// DetectDrift compares current against previous (both Snapshot's output
+// shape) and reports every resource whose attribute values changed, or
+// that disappeared entirely, since previous was recorded. A resource
+// present only in current (newly created since the last snapshot) is not
+// drift.
+func DetectDrift(previous, current map[string]map[string]interface{}) []DriftEntry {
+	var drift []DriftEntry
+
+	for address, currentValues := range current {
+		previousValues, ok := previous[address]
+		if !ok {
+			continue
+		}
+		if !reflect.DeepEqual(previousValues, currentValues) {
+			drift = append(drift, DriftEntry{
+				Address: address,
+				Reason:  "attribute values changed since the last recorded snapshot",
+			})
+		}
+	}
+
+	for address := range previous {
+		if _, ok := current[address]; !ok {
+			drift = append(drift, DriftEntry{
+				Address: address,
+				Reason:  "resource present in the last recorded snapshot is missing from the current state",
+			})
+		}
+	}
+
+	return drift
+}
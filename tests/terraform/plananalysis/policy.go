@@ -0,0 +1,41 @@
+package plananalysis
+
+import "fmt"
+
+// Policy bounds what a plan is allowed to do. A zero-valued field is
+// treated as "no limit" except RequireNoDrift, where the zero value
+// (false) means drift is tolerated.
+type Policy struct {
+	MaxDestroys            int
+	ForbiddenResourceTypes []string
+	RequireNoDrift         bool
+}
+
+// Violations evaluates analysis against policy and returns one message
+// per violated rule; a nil/empty result means the plan is within policy.
+func (analysis PlanAnalysis) Violations(policy Policy) []string {
+	var violations []string
+
+	destroys := analysis.Totals.Delete + analysis.Totals.Replace
+	if policy.MaxDestroys > 0 && destroys > policy.MaxDestroys {
+		violations = append(violations, fmt.Sprintf(
+			"plan destroys/replaces %d resource(s), exceeding the policy limit of %d", destroys, policy.MaxDestroys))
+	}
+
+	for _, forbidden := range policy.ForbiddenResourceTypes {
+		counts, ok := analysis.ByResourceType[forbidden]
+		if !ok {
+			continue
+		}
+		if counts.Create+counts.Update+counts.Delete+counts.Replace > 0 {
+			violations = append(violations, fmt.Sprintf("plan touches forbidden resource type %q", forbidden))
+		}
+	}
+
+	if policy.RequireNoDrift && len(analysis.Drift) > 0 {
+		violations = append(violations, fmt.Sprintf(
+			"%d resource(s) drifted since the last recorded state snapshot", len(analysis.Drift)))
+	}
+
+	return violations
+}
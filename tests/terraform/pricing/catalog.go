@@ -0,0 +1,132 @@
+// Package pricing estimates infrastructure spend from a parsed Terraform
+// plan (github.com/hashicorp/terraform-json) against a pluggable SKU price
+// catalog, replacing TestCostEstimation's previous len(outputs)*0.1
+// placeholder with a real per-resource breakdown.
+package pricing
+
+import (
+	"bytes"
+	"embed"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed pricesheets/aws.yaml pricesheets/gcp.yaml pricesheets/azure.yaml
+var bundledPriceSheets embed.FS
+
+// bundledPriceSheetFiles lists the catalog fragments DefaultCatalog merges,
+// one per cloud provider.
+var bundledPriceSheetFiles = []string{
+	"pricesheets/aws.yaml",
+	"pricesheets/gcp.yaml",
+	"pricesheets/azure.yaml",
+}
+
+// SKUPrice is one resource type's hourly rate for a given SKU in a given
+// region, as found in a price sheet.
+type SKUPrice struct {
+	ResourceType string  `yaml:"resource_type" json:"resource_type"`
+	SKU          string  `yaml:"sku" json:"sku"`
+	Region       string  `yaml:"region" json:"region"`
+	HourlyUSD    float64 `yaml:"hourly_usd" json:"hourly_usd"`
+}
+
+// Catalog is a flat set of SKUPrice entries Estimate matches planned
+// resources against. A single Catalog can hold entries across several
+// providers since Terraform resource type names are already
+// provider-specific (aws_instance, google_compute_instance, ...).
+type Catalog struct {
+	Prices []SKUPrice
+}
+
+// Lookup returns the hourly USD rate for resourceType/sku in region, or
+// false if no entry in the catalog matches all three.
+func (c Catalog) Lookup(resourceType, sku, region string) (float64, bool) {
+	for _, price := range c.Prices {
+		if price.ResourceType == resourceType && price.SKU == sku && price.Region == region {
+			return price.HourlyUSD, true
+		}
+	}
+	return 0, false
+}
+
+// DefaultCatalog returns the bundled AWS/GCP/Azure price sheets merged
+// into a single Catalog.
+func DefaultCatalog() (Catalog, error) {
+	var catalog Catalog
+	for _, name := range bundledPriceSheetFiles {
+		data, err := bundledPriceSheets.ReadFile(name)
+		if err != nil {
+			return Catalog{}, errors.Wrapf(err, "failed to read bundled price sheet %s", name)
+		}
+		var sheet []SKUPrice
+		if err := yaml.Unmarshal(data, &sheet); err != nil {
+			return Catalog{}, errors.Wrapf(err, "failed to parse bundled price sheet %s", name)
+		}
+		catalog.Prices = append(catalog.Prices, sheet...)
+	}
+	return catalog, nil
+}
+
+// LoadCatalog returns DefaultCatalog when source is empty, otherwise reads
+// source (a file:// or https:// URI, per TestSuite's --pricing-source flag)
+// as a YAML or JSON list of SKUPrice, replacing the bundled catalog
+// entirely with the caller's own price sheet.
+func LoadCatalog(source string) (Catalog, error) {
+	if source == "" {
+		return DefaultCatalog()
+	}
+
+	data, err := readCatalogSource(source)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	var sheet []SKUPrice
+	if looksLikeJSON(data) {
+		if err := json.Unmarshal(data, &sheet); err != nil {
+			return Catalog{}, errors.Wrapf(err, "failed to parse pricing source %s as JSON", source)
+		}
+	} else if err := yaml.Unmarshal(data, &sheet); err != nil {
+		return Catalog{}, errors.Wrapf(err, "failed to parse pricing source %s as YAML", source)
+	}
+
+	return Catalog{Prices: sheet}, nil
+}
+
+func readCatalogSource(source string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(source, "file://"):
+		data, err := os.ReadFile(strings.TrimPrefix(source, "file://"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read pricing source %s", source)
+		}
+		return data, nil
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		client := http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Get(source)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch pricing source %s", source)
+		}
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read pricing source %s", source)
+		}
+		return data, nil
+	default:
+		return nil, errors.Errorf("unsupported pricing source %q: expected a file:// or https:// URI", source)
+	}
+}
+
+func looksLikeJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
@@ -0,0 +1,19 @@
+package pricing
+
+// CostDiff is the incremental spend a plan's CostEstimate introduces
+// relative to the estimate recorded for the previously applied state.
+type CostDiff struct {
+	Previous        CostEstimate `json:"previous"`
+	Current         CostEstimate `json:"current"`
+	MonthlyDeltaUSD float64      `json:"monthly_delta_usd"`
+}
+
+// Diff compares current against previous and reports the incremental
+// monthly cost the plan would add (or remove, if negative).
+func Diff(previous, current CostEstimate) CostDiff {
+	return CostDiff{
+		Previous:        previous,
+		Current:         current,
+		MonthlyDeltaUSD: current.MonthlyCost - previous.MonthlyCost,
+	}
+}
@@ -0,0 +1,92 @@
+package pricing
+
+import (
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// skuAttributeByResourceType maps a Terraform resource type to the plan
+// attribute holding the value Catalog entries key their SKU on (instance
+// size, machine type, VM size, ...). A resource type absent from this map
+// is skipped by Estimate -- there's no bundled rate to match it against.
+var skuAttributeByResourceType = map[string]string{
+	"aws_instance":                    "instance_type",
+	"aws_db_instance":                 "instance_class",
+	"google_compute_instance":         "machine_type",
+	"google_sql_database_instance":    "tier",
+	"azurerm_linux_virtual_machine":   "size",
+	"azurerm_windows_virtual_machine": "size",
+	"azurerm_mssql_database":          "sku_name",
+}
+
+// ResourceCost is one planned resource's estimated hourly spend, matched
+// against the Catalog by resource type, SKU attribute, and region.
+type ResourceCost struct {
+	Address   string  `json:"address"`
+	Type      string  `json:"type"`
+	SKU       string  `json:"sku"`
+	HourlyUSD float64 `json:"hourly_usd"`
+}
+
+// CostEstimate is Estimate's projected spend for a plan, broken down by
+// resource address.
+type CostEstimate struct {
+	HourlyCost  float64            `json:"hourly_cost"`
+	DailyCost   float64            `json:"daily_cost"`
+	MonthlyCost float64            `json:"monthly_cost"`
+	YearlyCost  float64            `json:"yearly_cost"`
+	Currency    string             `json:"currency"`
+	Breakdown   map[string]float64 `json:"breakdown"`
+	Resources   []ResourceCost     `json:"resources,omitempty"`
+}
+
+// Estimate walks plan's resource changes that leave a resource standing
+// after apply (anything but a pure delete), matches each against catalog
+// for region using skuAttributeByResourceType, and sums the matched hourly
+// rates into a CostEstimate. Resources of a type the catalog has no rate
+// for are silently excluded from Breakdown -- they contribute nothing to
+// the projected cost rather than being reported as zero-cost.
+func Estimate(plan *tfjson.Plan, catalog Catalog, region string) CostEstimate {
+	breakdown := map[string]float64{}
+	var resources []ResourceCost
+	var hourly float64
+
+	for _, change := range plan.ResourceChanges {
+		if change.Change == nil || change.Change.Actions.Delete() {
+			continue
+		}
+
+		attr, ok := skuAttributeByResourceType[change.Type]
+		if !ok {
+			continue
+		}
+
+		after, ok := change.Change.After.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sku, ok := after[attr].(string)
+		if !ok || sku == "" {
+			continue
+		}
+
+		rate, ok := catalog.Lookup(change.Type, sku, region)
+		if !ok {
+			continue
+		}
+
+		resources = append(resources, ResourceCost{Address: change.Address, Type: change.Type, SKU: sku, HourlyUSD: rate})
+		breakdown[change.Address] = rate
+		hourly += rate
+	}
+
+	return CostEstimate{
+		HourlyCost:  hourly,
+		DailyCost:   hourly * 24,
+		MonthlyCost: hourly * 24 * 30,
+		YearlyCost:  hourly * 24 * 365,
+		Currency:    "USD",
+		Breakdown:   breakdown,
+		Resources:   resources,
+	}
+}
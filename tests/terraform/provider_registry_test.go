@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iamsynth"
+)
+
+// ProviderTestSuite is the capability-scoped interface
+// initializeCloudProviderSuites' registry hands back to callers instead of
+// a concrete *AWSTestSuite/*GCPTestSuite/*AzureTestSuite/*K8sTestSuite.
+// Only TestConnectivity is implemented by every built-in plugin today;
+// TestBackup and TestDatabaseReplication exist so a provider plugin can
+// advertise those capabilities once it implements them, but the bulk of
+// this suite's cross-provider tests still reach into
+// MultiCloudTestSuite.AWSTestSuite/etc. directly rather than going through
+// this interface -- porting every one of those call sites is out of scope
+// for the registry itself.
+type ProviderTestSuite interface {
+	Name() string
+	TestConnectivity(ctx context.Context) error
+	TestBackup(ctx context.Context) error
+	TestDatabaseReplication(ctx context.Context) error
+}
+
+// ProviderPlugin initializes a ProviderTestSuite for one CloudProvider
+// entry. A cloud joins MultiCloudTestSuite by registering a ProviderPlugin
+// via RegisterProvider (compiled in via init(), or loaded from
+// MultiCloudConfig.PluginDir) rather than by editing
+// initializeCloudProviderSuites.
+type ProviderPlugin interface {
+	Name() string
+	Init(provider CloudProvider, config TestConfig) (ProviderTestSuite, error)
+	Capabilities() []string
+}
+
+// ProviderFactory returns a new, unconfigured ProviderPlugin instance.
+// RegisterProvider stores one per provider name and
+// initializeCloudProviderSuites calls it fresh for every enabled
+// CloudProvider, so a plugin never has to worry about being reused across
+// providers or test runs.
+type ProviderFactory func() ProviderPlugin
+
+// recorderAwarePlugin is implemented by the built-in AWS/GCP/Azure plugins
+// so initializeCloudProviderSuites can hand them suite.Recorder for
+// least-privilege IAM policy synthesis without that parameter being part
+// of the ProviderPlugin interface itself -- a plugin that doesn't need a
+// recorder (Kubernetes, or any out-of-tree plugin) simply doesn't
+// implement this interface.
+type recorderAwarePlugin interface {
+	WithRecorder(recorder *iamsynth.Recorder) ProviderPlugin
+}
+
+var (
+	providerRegistryMu sync.Mutex
+	providerRegistry   = map[string]ProviderFactory{}
+)
+
+// RegisterProvider makes factory available to initializeCloudProviderSuites
+// under name, overwriting any previous registration for that name. Built-in
+// plugins call this from an init() in their own file; out-of-tree plugins
+// call it from the NewProviderPlugin-returned value's package init, loaded
+// by loadExternalProviderPlugins.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providerRegistryMu.Lock()
+	defer providerRegistryMu.Unlock()
+	providerRegistry[name] = factory
+}
+
+// lookupProviderFactory returns the ProviderFactory registered for name, if
+// any.
+func lookupProviderFactory(name string) (ProviderFactory, bool) {
+	providerRegistryMu.Lock()
+	defer providerRegistryMu.Unlock()
+	factory, ok := providerRegistry[name]
+	return factory, ok
+}
+
+// loadExternalProviderPlugins globs dir for Go plugin package *.so files
+// and registers each one's NewProviderPlugin symbol, mirroring how
+// Terraform resolves third-party providers it was never compiled against.
+// An empty dir is a no-op, not an error, since PluginDir is optional.
+func loadExternalProviderPlugins(dir string) error {
+	if dir == "" {
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return errors.Wrapf(err, "failed to glob plugin dir %s", dir)
+	}
+
+	for _, path := range matches {
+		p, err := plugin.Open(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to open provider plugin %s", path)
+		}
+
+		sym, err := p.Lookup("NewProviderPlugin")
+		if err != nil {
+			return errors.Wrapf(err, "provider plugin %s has no NewProviderPlugin symbol", path)
+		}
+
+		newPlugin, ok := sym.(func() ProviderPlugin)
+		if !ok {
+			return errors.Errorf("provider plugin %s's NewProviderPlugin has the wrong signature, want func() ProviderPlugin", path)
+		}
+
+		instance := newPlugin()
+		RegisterProvider(instance.Name(), func() ProviderPlugin { return newPlugin() })
+	}
+
+	return nil
+}
+
+// unimplementedCapability is the shared error TestBackup and
+// TestDatabaseReplication return on every built-in provider plugin: those
+// capabilities already exist as real test logic elsewhere in this suite
+// (testBackupVerification, the per-provider replication tests), and
+// duplicating that logic behind ProviderTestSuite here is out of scope for
+// the registry itself.
+func unimplementedCapability(providerName, capability string) error {
+	return fmt.Errorf("%s provider plugin does not implement %s at the ProviderTestSuite layer; see the suite's dedicated test*/Test* methods instead", providerName, capability)
+}
@@ -0,0 +1,181 @@
+package resourceid
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Node is one resource in a Graph, keyed by its URN.
+type Node struct {
+	URN      URN    `json:"urn"`
+	Provider string `json:"provider"`
+	Service  string `json:"service"`
+	Region   string `json:"region,omitempty"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+}
+
+// Edge is a directed dependency between two Nodes -- From depends on To
+// (e.g. a serverless function depends on the database it queries). Reason
+// records how the edge was inferred (e.g. "iam-policy-scan", "flow-log"),
+// since a blast-radius graph is only as trustworthy as its evidence.
+type Edge struct {
+	From   URN    `json:"from"`
+	To     URN    `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// Graph is a directed graph of URN-to-URN dependency edges across every
+// cloud provider a MultiCloudTestSuite touches; AddEdge does not reject
+// edges that form a cycle. It's safe for concurrent use, since edges
+// are inferred from several independent sources (IAM policy scans, flow
+// log captures) that may run concurrently.
+type Graph struct {
+	mu    sync.Mutex
+	nodes map[URN]Node
+	edges []Edge
+}
+
+// NewGraph returns an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{nodes: make(map[URN]Node)}
+}
+
+// AddNode records node, keyed by its URN. Adding the same URN twice
+// overwrites the earlier Node rather than erroring, so re-resolving a
+// resource's URN after provisioning doesn't require a remove-then-add.
+func (g *Graph) AddNode(node Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nodes[node.URN] = node
+}
+
+// AddEdge records a directed dependency from depends on to it's only
+// recorded if both URNs have already been added via AddNode, so the graph
+// never references a resource it can't also describe.
+func (g *Graph) AddEdge(from, to URN, reason string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, ok := g.nodes[from]; !ok {
+		return errors.Errorf("cannot add edge: %q is not a node in this graph", from)
+	}
+	if _, ok := g.nodes[to]; !ok {
+		return errors.Errorf("cannot add edge: %q is not a node in this graph", to)
+	}
+	g.edges = append(g.edges, Edge{From: from, To: to, Reason: reason})
+	return nil
+}
+
+// Nodes returns every Node in the graph, sorted by URN for deterministic
+// output (GraphViz/Meshery exports, test fixtures).
+func (g *Graph) Nodes() []Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].URN < nodes[j].URN })
+	return nodes
+}
+
+// Edges returns every Edge in the graph, sorted for deterministic output.
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	edges := make([]Edge, len(g.edges))
+	copy(edges, g.edges)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// ToGraphviz renders the graph as a GraphViz "dot" digraph, one node per
+// resource labeled with its provider/type/name and one edge per inferred
+// dependency labeled with its Reason.
+func (g *Graph) ToGraphviz() string {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, node := range g.Nodes() {
+		label := fmt.Sprintf("%s\\n%s/%s", node.Name, node.Provider, node.Type)
+		fmt.Fprintf(&b, "  %q [label=%q];\n", node.URN, label)
+	}
+	for _, edge := range g.Edges() {
+		fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Reason)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// MesheryModel is a minimal Meshery-style "design" document: components
+// keyed by URN, and relationships between them. It's a deliberately small
+// subset of Meshery's actual schema -- just enough structure for a policy
+// engine or visualizer to walk the blast radius a dependency graph
+// describes.
+type MesheryModel struct {
+	Name          string                `json:"name"`
+	Components    []MesheryComponent    `json:"components"`
+	Relationships []MesheryRelationship `json:"relationships"`
+}
+
+// MesheryComponent is one Graph Node rendered into Meshery's component shape.
+type MesheryComponent struct {
+	ID       URN    `json:"id"`
+	Provider string `json:"provider"`
+	Type     string `json:"type"`
+	Name     string `json:"displayName"`
+}
+
+// MesheryRelationship is one Graph Edge rendered into Meshery's
+// relationship shape.
+type MesheryRelationship struct {
+	Kind string `json:"kind"`
+	From URN    `json:"from"`
+	To   URN    `json:"to"`
+}
+
+// ToMeshery renders the graph as a MesheryModel named modelName, suitable
+// for import into Meshery or any tool that speaks its component/relationship
+// JSON shape.
+func (g *Graph) ToMeshery(modelName string) *MesheryModel {
+	model := &MesheryModel{Name: modelName}
+
+	for _, node := range g.Nodes() {
+		model.Components = append(model.Components, MesheryComponent{
+			ID:       node.URN,
+			Provider: node.Provider,
+			Type:     node.Type,
+			Name:     node.Name,
+		})
+	}
+	for _, edge := range g.Edges() {
+		model.Relationships = append(model.Relationships, MesheryRelationship{
+			Kind: edge.Reason,
+			From: edge.From,
+			To:   edge.To,
+		})
+	}
+
+	return model
+}
+
+// MarshalJSON renders g directly as ToMeshery's JSON, so a caller that just
+// wants bytes doesn't need the intermediate MesheryModel value.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.ToMeshery("dependency-graph"))
+}
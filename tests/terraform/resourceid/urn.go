@@ -0,0 +1,61 @@
+// Package resourceid assigns every resource MultiCloudTestSuite creates or
+// references a canonical URN, modeled on the AWS ARN grammar
+// (arn:partition:service:region:account-id:resource) but generalized so an
+// Azure resource ID or a GCP self-link can be expressed the same way, and
+// builds a cross-provider dependency Graph out of those URNs.
+package resourceid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// URN is a canonical cross-provider resource identifier of the form
+// "urn:<provider>:<service>:<region>:<account>:<type>/<name>". Region and
+// account are empty-string-able (rendered as "") for global resources
+// (e.g. an IAM role or a Route 53 zone) the same way an AWS ARN leaves
+// those fields blank.
+type URN string
+
+// Format builds a URN from its components. provider is "aws", "gcp", or
+// "azure"; service is the provider's own service name (e.g. "rds",
+// "cloudsql", "sql"); account is an AWS account ID, GCP project ID, or
+// Azure subscription ID.
+func Format(provider, service, region, account, resourceType, name string) URN {
+	return URN(fmt.Sprintf("urn:%s:%s:%s:%s:%s/%s", provider, service, region, account, resourceType, name))
+}
+
+// Components is a URN's parsed parts.
+type Components struct {
+	Provider     string
+	Service      string
+	Region       string
+	Account      string
+	ResourceType string
+	Name         string
+}
+
+// Parse splits a URN back into its Components, returning an error if urn
+// doesn't have the "urn:provider:service:region:account:type/name" shape.
+func Parse(urn URN) (Components, error) {
+	fields := strings.SplitN(string(urn), ":", 6)
+	if len(fields) != 6 || fields[0] != "urn" {
+		return Components{}, errors.Errorf("malformed URN %q", urn)
+	}
+
+	typeAndName := strings.SplitN(fields[5], "/", 2)
+	if len(typeAndName) != 2 {
+		return Components{}, errors.Errorf("malformed URN %q: resource segment has no type/name separator", urn)
+	}
+
+	return Components{
+		Provider:     fields[1],
+		Service:      fields[2],
+		Region:       fields[3],
+		Account:      fields[4],
+		ResourceType: typeAndName[0],
+		Name:         typeAndName[1],
+	}, nil
+}
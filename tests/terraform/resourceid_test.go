@@ -0,0 +1,240 @@
+package test
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/iamsynth"
+	"github.com/arvindcr4/learning-assistant/tests/terraform/resourceid"
+)
+
+// accountID resolves the account/project/subscription identifier a
+// provider's URNs should carry: the AWS account behind suite.AWSTestSuite
+// (via STS), suite.GCPTestSuite.ProjectID, or suite.AzureTestSuite.
+// SubscriptionID. Returns "" if that provider's suite isn't initialized.
+func (suite *MultiCloudTestSuite) accountID(ctx context.Context, provider string) string {
+	switch provider {
+	case "aws":
+		if suite.AWSTestSuite == nil {
+			return ""
+		}
+		identity, err := suite.AWSTestSuite.STS().GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+		if err != nil {
+			suite.Logger.Warn().Err(err).Msg("failed to resolve AWS account for URN resolution")
+			return ""
+		}
+		return aws.ToString(identity.Account)
+	case "gcp":
+		if suite.GCPTestSuite == nil {
+			return ""
+		}
+		return suite.GCPTestSuite.ProjectID
+	case "azure":
+		if suite.AzureTestSuite == nil {
+			return ""
+		}
+		return suite.AzureTestSuite.SubscriptionID
+	default:
+		return ""
+	}
+}
+
+// ResolveResourceURNs assigns a resourceid.URN to every DatabaseInstance,
+// StorageBucket, ComputeInstance, ContainerConfig, ServerlessConfig,
+// KubernetesConfig, and VPNConnection in suite.MultiCloudConfig, so
+// DependencyGraph has something stable to key nodes by.
+func (suite *MultiCloudTestSuite) ResolveResourceURNs(ctx context.Context) {
+	accounts := map[string]string{
+		"aws":   suite.accountID(ctx, "aws"),
+		"gcp":   suite.accountID(ctx, "gcp"),
+		"azure": suite.accountID(ctx, "azure"),
+	}
+
+	databases := suite.MultiCloudConfig.DatabaseConfig.Databases
+	for i := range databases {
+		databases[i].URN = resourceid.Format(databases[i].Provider, "database", databases[i].Region, accounts[databases[i].Provider], "database", databases[i].Name)
+	}
+
+	buckets := suite.MultiCloudConfig.StorageConfig.Buckets
+	for i := range buckets {
+		buckets[i].URN = resourceid.Format(buckets[i].Provider, "storage", buckets[i].Region, accounts[buckets[i].Provider], "bucket", buckets[i].Name)
+	}
+
+	instances := suite.MultiCloudConfig.ComputeConfig.Instances
+	for i := range instances {
+		instances[i].URN = resourceid.Format(instances[i].Provider, "compute", instances[i].Region, accounts[instances[i].Provider], "instance", instances[i].Name)
+	}
+
+	containers := suite.MultiCloudConfig.ComputeConfig.Containers
+	for i := range containers {
+		containers[i].URN = resourceid.Format(containers[i].Provider, "container", containers[i].Region, accounts[containers[i].Provider], "container", containers[i].Name)
+	}
+
+	serverless := suite.MultiCloudConfig.ComputeConfig.Serverless
+	for i := range serverless {
+		serverless[i].URN = resourceid.Format(serverless[i].Provider, "serverless", serverless[i].Region, accounts[serverless[i].Provider], "function", serverless[i].Name)
+	}
+
+	clusters := suite.MultiCloudConfig.ComputeConfig.Kubernetes
+	for i := range clusters {
+		clusters[i].URN = resourceid.Format(clusters[i].Provider, "kubernetes", clusters[i].Region, accounts[clusters[i].Provider], "cluster", clusters[i].Name)
+	}
+
+	vpns := suite.MultiCloudConfig.NetworkConfig.VPNConnections
+	for i := range vpns {
+		sourceProvider := strings.SplitN(vpns[i].Source, ":", 2)[0]
+		vpns[i].URN = resourceid.Format(sourceProvider, "vpn", "", accounts[sourceProvider], "connection", vpns[i].Name)
+	}
+}
+
+// DependencyGraph builds a resourceid.Graph covering every resource
+// ResolveResourceURNs has assigned a URN to, with edges inferred from two
+// sources: recorded IAM-call co-occurrence (suite.Recorder) and explicit
+// traffic observations a caller supplies via flowLogs (e.g. parsed VPC
+// Flow Log records) -- it does not itself capture live network traffic.
+func (suite *MultiCloudTestSuite) DependencyGraph(flowLogs []FlowLogRecord) *resourceid.Graph {
+	graph := resourceid.NewGraph()
+	suite.addResourceNodes(graph)
+	suite.inferIAMEdges(graph)
+	suite.inferFlowLogEdges(graph, flowLogs)
+	return graph
+}
+
+// addResourceNodes adds every URN-bearing resource in suite.MultiCloudConfig
+// as a Graph node, skipping any whose URN is still unresolved (zero value),
+// since DependencyGraph is only meaningful after ResolveResourceURNs.
+func (suite *MultiCloudTestSuite) addResourceNodes(graph *resourceid.Graph) {
+	for _, db := range suite.MultiCloudConfig.DatabaseConfig.Databases {
+		addNodeIfResolved(graph, db.URN, db.Provider, "database", db.Region, db.Name)
+	}
+	for _, bucket := range suite.MultiCloudConfig.StorageConfig.Buckets {
+		addNodeIfResolved(graph, bucket.URN, bucket.Provider, "bucket", bucket.Region, bucket.Name)
+	}
+	for _, instance := range suite.MultiCloudConfig.ComputeConfig.Instances {
+		addNodeIfResolved(graph, instance.URN, instance.Provider, "instance", instance.Region, instance.Name)
+	}
+	for _, container := range suite.MultiCloudConfig.ComputeConfig.Containers {
+		addNodeIfResolved(graph, container.URN, container.Provider, "container", container.Region, container.Name)
+	}
+	for _, fn := range suite.MultiCloudConfig.ComputeConfig.Serverless {
+		addNodeIfResolved(graph, fn.URN, fn.Provider, "function", fn.Region, fn.Name)
+	}
+	for _, cluster := range suite.MultiCloudConfig.ComputeConfig.Kubernetes {
+		addNodeIfResolved(graph, cluster.URN, cluster.Provider, "cluster", cluster.Region, cluster.Name)
+	}
+	for _, vpn := range suite.MultiCloudConfig.NetworkConfig.VPNConnections {
+		addNodeIfResolved(graph, vpn.URN, strings.SplitN(vpn.Source, ":", 2)[0], "vpn", "", vpn.Name)
+	}
+}
+
+func addNodeIfResolved(graph *resourceid.Graph, urn resourceid.URN, provider, resourceType, region, name string) {
+	if urn == "" {
+		return
+	}
+	graph.AddNode(resourceid.Node{
+		URN:      urn,
+		Provider: provider,
+		Service:  resourceType,
+		Region:   region,
+		Type:     resourceType,
+		Name:     name,
+	})
+}
+
+// inferIAMEdges scans suite.Recorder's captured SDK calls for a weak but
+// honest dependency signal: when a serverless function or container's name
+// appears in one recorded call's resource identifier and a database or
+// bucket's name appears in another recorded call from the same test run,
+// it adds an edge between them. This is co-occurrence in an IAM policy
+// scan, not proof of a live call path -- Reason records that limitation.
+func (suite *MultiCloudTestSuite) inferIAMEdges(graph *resourceid.Graph) {
+	if suite.Recorder == nil {
+		return
+	}
+
+	calls := suite.Recorder.Calls()
+	for _, fn := range suite.MultiCloudConfig.ComputeConfig.Serverless {
+		if fn.URN == "" || !callsReference(calls, fn.Name) {
+			continue
+		}
+		suite.linkToDataStores(graph, fn.URN, calls)
+	}
+	for _, container := range suite.MultiCloudConfig.ComputeConfig.Containers {
+		if container.URN == "" || !callsReference(calls, container.Name) {
+			continue
+		}
+		suite.linkToDataStores(graph, container.URN, calls)
+	}
+}
+
+// linkToDataStores adds an edge from source to every DatabaseInstance or
+// StorageBucket whose name also appears among calls.
+func (suite *MultiCloudTestSuite) linkToDataStores(graph *resourceid.Graph, source resourceid.URN, calls []iamsynth.Call) {
+	for _, db := range suite.MultiCloudConfig.DatabaseConfig.Databases {
+		if db.URN == "" || !callsReference(calls, db.Name) {
+			continue
+		}
+		if err := graph.AddEdge(source, db.URN, "iam-policy-scan"); err != nil {
+			suite.Logger.Warn().Err(err).Msg("failed to add inferred IAM dependency edge")
+		}
+	}
+	for _, bucket := range suite.MultiCloudConfig.StorageConfig.Buckets {
+		if bucket.URN == "" || !callsReference(calls, bucket.Name) {
+			continue
+		}
+		if err := graph.AddEdge(source, bucket.URN, "iam-policy-scan"); err != nil {
+			suite.Logger.Warn().Err(err).Msg("failed to add inferred IAM dependency edge")
+		}
+	}
+}
+
+// callsReference reports whether name appears in any call's Resource
+// identifier.
+func callsReference(calls []iamsynth.Call, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, call := range calls {
+		if strings.Contains(call.Resource, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FlowLogRecord is one observed network flow between two named resources
+// (e.g. a parsed VPC Flow Log line), used to infer a dependency edge with
+// stronger evidence than an IAM policy scan: the source actually talked to
+// the destination. SourceName/DestinationName are matched against
+// MultiCloudConfig resource names, not full URNs, since flow logs identify
+// endpoints by ENI/IP rather than by the names this suite's config uses.
+type FlowLogRecord struct {
+	SourceName      string
+	DestinationName string
+}
+
+// inferFlowLogEdges adds a "flow-log" edge for every FlowLogRecord whose
+// source and destination both match a resolved node's name.
+func (suite *MultiCloudTestSuite) inferFlowLogEdges(graph *resourceid.Graph, flowLogs []FlowLogRecord) {
+	nodesByName := make(map[string]resourceid.URN)
+	for _, node := range graph.Nodes() {
+		nodesByName[node.Name] = node.URN
+	}
+
+	for _, record := range flowLogs {
+		source, ok := nodesByName[record.SourceName]
+		if !ok {
+			continue
+		}
+		destination, ok := nodesByName[record.DestinationName]
+		if !ok {
+			continue
+		}
+		if err := graph.AddEdge(source, destination, "flow-log"); err != nil {
+			suite.Logger.Warn().Err(err).Msg("failed to add inferred flow-log dependency edge")
+		}
+	}
+}
@@ -0,0 +1,388 @@
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/dr"
+)
+
+// parseTagSelector splits a "tag:key=value" runbook step Selector into its
+// key/value pair -- the only selector syntax registerRunbookStepBuilders
+// understands, since a runbook author writing
+// "selector: tag:role=primary-db" is naming a resource set, not one
+// specific instance ID.
+func parseTagSelector(selector string) (key, value string, err error) {
+	const prefix = "tag:"
+	if !strings.HasPrefix(selector, prefix) {
+		return "", "", errors.Errorf("unsupported selector %q: expected \"tag:key=value\"", selector)
+	}
+	kv := strings.SplitN(strings.TrimPrefix(selector, prefix), "=", 2)
+	if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+		return "", "", errors.Errorf("unsupported selector %q: expected \"tag:key=value\"", selector)
+	}
+	return kv[0], kv[1], nil
+}
+
+// ec2InstanceIDsByTag lists the non-terminated EC2 instance IDs tagged
+// key=value.
+func ec2InstanceIDsByTag(ctx context.Context, suite *AWSTestSuite, key, value string) ([]string, error) {
+	output, err := suite.EC2().DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("tag:" + key), Values: []string{value}},
+			{Name: aws.String("instance-state-name"), Values: []string{"running", "stopped"}},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to describe instances tagged %s=%s", key, value)
+	}
+
+	var instanceIDs []string
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+		}
+	}
+	if len(instanceIDs) == 0 {
+		return nil, errors.Errorf("no instances matched selector tag:%s=%s", key, value)
+	}
+	return instanceIDs, nil
+}
+
+// registerRunbookStepBuilders wires a dr.Handler for every dr.StepAction
+// this harness can actually execute against a live provider. A step
+// targeting a provider/action pair not registered here surfaces
+// dr.Registry's own "no handler registered" error rather than silently
+// no-opping.
+func registerRunbookStepBuilders(registry *dr.Registry, suite *MultiCloudTestSuite) {
+	if suite.AWSTestSuite != nil {
+		registerAWSRunbookStepBuilders(registry, suite.AWSTestSuite)
+	}
+	if suite.GCPTestSuite != nil {
+		registerGCPRunbookStepBuilders(registry, suite.GCPTestSuite)
+	}
+}
+
+// registerAWSRunbookStepBuilders registers the AWS Builders for
+// terminate_instance, block_network, scale_up_instance and
+// promote_standby. scale_up_instance and promote_standby are recovery
+// actions, not fault injections -- registerAWSDisasterRecoveryBuilders's
+// ActionCorruptReplica builder sets the precedent for a no-op Undo on an
+// action that can't meaningfully be reversed, and these two follow it.
+func registerAWSRunbookStepBuilders(registry *dr.Registry, suite *AWSTestSuite) {
+	registry.Register(dr.ActionKillInstance, "aws", func(action dr.Action) (dr.Handler, error) {
+		key, value, err := parseTagSelector(action.Target)
+		if err != nil {
+			return dr.Handler{}, err
+		}
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				instanceIDs, err := ec2InstanceIDsByTag(ctx, suite, key, value)
+				if err != nil {
+					return err
+				}
+				_, err = suite.EC2().StopInstances(ctx, &ec2.StopInstancesInput{InstanceIds: instanceIDs})
+				return errors.Wrapf(err, "failed to stop instances matching tag:%s=%s", key, value)
+			},
+			Undo: func(ctx context.Context) error {
+				instanceIDs, err := ec2InstanceIDsByTag(ctx, suite, key, value)
+				if err != nil {
+					return err
+				}
+				_, err = suite.EC2().StartInstances(ctx, &ec2.StartInstancesInput{InstanceIds: instanceIDs})
+				return errors.Wrapf(err, "failed to restart instances matching tag:%s=%s", key, value)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionPartitionNetwork, "aws", func(action dr.Action) (dr.Handler, error) {
+		networkACLID := action.Target
+		cidr := action.Params["cidr"]
+		if cidr == "" {
+			cidr = "0.0.0.0/0"
+		}
+		var ruleNumber int32 = 1
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.EC2().CreateNetworkAclEntry(ctx, &ec2.CreateNetworkAclEntryInput{
+					NetworkAclId: aws.String(networkACLID),
+					RuleNumber:   aws.Int32(ruleNumber),
+					Protocol:     aws.String("-1"),
+					RuleAction:   ec2types.RuleActionDeny,
+					Egress:       aws.Bool(false),
+					CidrBlock:    aws.String(cidr),
+				})
+				return errors.Wrapf(err, "failed to insert deny rule for %s into network ACL %s", cidr, networkACLID)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.EC2().DeleteNetworkAclEntry(ctx, &ec2.DeleteNetworkAclEntryInput{
+					NetworkAclId: aws.String(networkACLID),
+					RuleNumber:   aws.Int32(ruleNumber),
+					Egress:       aws.Bool(false),
+				})
+				return errors.Wrapf(err, "failed to remove deny rule from network ACL %s", networkACLID)
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionScaleUp, "aws", func(action dr.Action) (dr.Handler, error) {
+		asgName := action.Target
+		desiredCapacity := int32(1)
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.AutoScaling().SetDesiredCapacity(ctx, &autoscaling.SetDesiredCapacityInput{
+					AutoScalingGroupName: aws.String(asgName),
+					DesiredCapacity:      aws.Int32(desiredCapacity),
+					HonorCooldown:        aws.Bool(false),
+				})
+				return errors.Wrapf(err, "failed to scale up pilot-light group %s", asgName)
+			},
+			Undo: func(ctx context.Context) error {
+				return nil
+			},
+		}, nil
+	})
+
+	registry.Register(dr.ActionPromoteStandby, "aws", func(action dr.Action) (dr.Handler, error) {
+		dbInstanceID := action.Target
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.RDS().PromoteReadReplica(ctx, &rds.PromoteReadReplicaInput{
+					DBInstanceIdentifier: aws.String(dbInstanceID),
+				})
+				return errors.Wrapf(err, "failed to promote standby replica %s", dbInstanceID)
+			},
+			Undo: func(ctx context.Context) error {
+				return nil
+			},
+		}, nil
+	})
+}
+
+// registerGCPRunbookStepBuilders registers the GCP block_network Builder:
+// a deny-all ingress firewall rule for the step's CIDR, removed on Undo.
+func registerGCPRunbookStepBuilders(registry *dr.Registry, suite *GCPTestSuite) {
+	registry.Register(dr.ActionPartitionNetwork, "gcp", func(action dr.Action) (dr.Handler, error) {
+		cidr := action.Params["cidr"]
+		if cidr == "" {
+			return dr.Handler{}, errors.New("block_network step against gcp requires a cidr")
+		}
+		firewallName := "chaos-block-" + strings.NewReplacer("/", "-", ".", "-").Replace(cidr)
+
+		return dr.Handler{
+			Do: func(ctx context.Context) error {
+				_, err := suite.Compute.Firewalls.Insert(suite.ProjectID, &compute.Firewall{
+					Name:         firewallName,
+					Network:      "global/networks/default",
+					Direction:    "INGRESS",
+					Priority:     1,
+					SourceRanges: []string{cidr},
+					Denied: []*compute.FirewallDenied{
+						{IPProtocol: "all"},
+					},
+				}).Context(ctx).Do()
+				return errors.Wrapf(err, "failed to insert deny-all firewall rule for %s", cidr)
+			},
+			Undo: func(ctx context.Context) error {
+				_, err := suite.Compute.Firewalls.Delete(suite.ProjectID, firewallName).Context(ctx).Do()
+				return errors.Wrapf(err, "failed to remove firewall rule %s", firewallName)
+			},
+		}, nil
+	})
+}
+
+// capacityPromotionStep returns the promotion step a DRSite's Capacity
+// tier requires before it can serve production traffic: a pilot-light
+// site needs its standby capacity scaled up from zero, a warm-standby
+// site needs its standby replica promoted. An active-active site already
+// serves traffic and needs no promotion, so ok is false for it (and for
+// any unrecognized Capacity value).
+func capacityPromotionStep(site DRSite) (step dr.Step, ok bool) {
+	selector := "tag:dr-site=" + site.Name
+	switch site.Capacity {
+	case "pilot-light":
+		return dr.Step{Action: string(dr.StepScaleUp), Provider: site.Provider, Selector: selector}, true
+	case "warm-standby":
+		return dr.Step{Action: string(dr.StepPromoteStandby), Provider: site.Provider, Selector: selector}, true
+	default:
+		return dr.Step{}, false
+	}
+}
+
+// chaosSignature computes the HMAC-SHA256 (keyed by signingKey) of
+// runbook.Steps, hex-encoded -- the same computation an operator runs to
+// populate DRRunbook.SignatureHash before committing a destructive
+// runbook.
+func chaosSignature(runbook DRRunbook, signingKey string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	for _, step := range runbook.Steps {
+		mac.Write([]byte(step))
+		mac.Write([]byte("\n"))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkChaosGuard refuses to run runbook unless CHAOS_ENABLED=true and
+// CHAOS_SIGNING_KEY together reproduce runbook.SignatureHash -- so
+// destructive steps can't run by accident (missing env var) or after an
+// unreviewed edit (mismatched signature).
+func checkChaosGuard(runbook DRRunbook) error {
+	if os.Getenv("CHAOS_ENABLED") != "true" {
+		return errors.New("CHAOS_ENABLED is not set to \"true\": refusing to run destructive DR runbook steps")
+	}
+
+	signingKey := os.Getenv("CHAOS_SIGNING_KEY")
+	if signingKey == "" {
+		return errors.New("CHAOS_SIGNING_KEY is not set: refusing to run destructive DR runbook steps without a signed runbook")
+	}
+
+	actual := chaosSignature(runbook, signingKey)
+	if !hmac.Equal([]byte(actual), []byte(runbook.SignatureHash)) {
+		return errors.Errorf("runbook %q's steps do not match its configured signature_hash -- refusing to run (re-sign it if this edit was intentional)", runbook.Name)
+	}
+	return nil
+}
+
+// siteForRunbook finds the DRSite a runbook's promotion sequence applies
+// to by matching DRRunbook.Name against each DRSite.Dependencies entry,
+// reporting ok=false when no site names the runbook (that's the common case for runbooks
+// that only exercise fault injection, not a promotion).
+func siteForRunbook(sites []DRSite, runbook DRRunbook) (site DRSite, ok bool) {
+	for _, candidate := range sites {
+		for _, dependency := range candidate.Dependencies {
+			if dependency == runbook.Name {
+				return candidate, true
+			}
+		}
+	}
+	return DRSite{}, false
+}
+
+// ExecuteDRRunbook parses runbook.Steps, appends the promotion step its
+// associated DRSite.Capacity requires (if any), and runs them through a
+// dr.RunbookExecutor gated by checkChaosGuard. When
+// DisasterRecoveryConfig.Testing.Validation is set, the returned
+// MultiCloudTestResult's Metrics carry the observed RTO/RPO and whether
+// they stayed within DisasterRecoveryConfig.RPO/RTO.
+func (suite *MultiCloudTestSuite) ExecuteDRRunbook(ctx context.Context, runbook DRRunbook) (*MultiCloudTestResult, error) {
+	if err := checkChaosGuard(runbook); err != nil {
+		return nil, err
+	}
+
+	steps := make([]dr.Step, 0, len(runbook.Steps)+1)
+	for _, raw := range runbook.Steps {
+		step, err := dr.ParseStep(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "runbook %q", runbook.Name)
+		}
+		steps = append(steps, step)
+	}
+
+	drConfig := suite.MultiCloudConfig.DisasterRecoveryConfig
+	if site, ok := siteForRunbook(drConfig.Sites, runbook); ok {
+		if promotion, ok := capacityPromotionStep(site); ok {
+			steps = append(steps, promotion)
+		} else {
+			suite.Logger.Info().Str("site", site.Name).Str("capacity", site.Capacity).
+				Msg("DR site requires no promotion step for this capacity tier")
+		}
+	}
+
+	registry := dr.NewRegistry()
+	registerRunbookStepBuilders(registry, suite)
+
+	checksummers := make(map[string]dr.Checksummer)
+	if suite.AWSTestSuite != nil {
+		for _, db := range suite.MultiCloudConfig.DatabaseConfig.Databases {
+			if db.Provider == "aws" {
+				checksummers[db.Name] = buildDatabaseChecksummer(suite.AWSTestSuite, db)
+			}
+		}
+	}
+
+	executor := &dr.RunbookExecutor{
+		Registry:     registry,
+		Checksummers: checksummers,
+	}
+	if suite.AWSTestSuite != nil {
+		executor.Probe = dr.Probe(func(ctx context.Context) error {
+			for _, lb := range suite.MultiCloudConfig.NetworkConfig.LoadBalancers {
+				if lb.Endpoint == "" {
+					continue
+				}
+				if err := suite.AWSTestSuite.TestLoadBalancerHealth(lb.Endpoint); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	provider := "aws"
+	if len(suite.MultiCloudConfig.Providers) > 0 {
+		provider = suite.MultiCloudConfig.Providers[0].Name
+	}
+
+	startTime := time.Now()
+	runbookResult := executor.Run(ctx, provider, steps)
+	endTime := time.Now()
+
+	result := &MultiCloudTestResult{
+		TestName:  runbook.Name,
+		TestType:  "disaster_recovery_runbook",
+		StartTime: startTime,
+		EndTime:   endTime,
+		Duration:  endTime.Sub(startTime),
+		Status:    "pass",
+		Providers: []string{provider},
+		Metrics:   map[string]interface{}{},
+	}
+	if runbookResult.Err != nil {
+		result.Status = "fail"
+		result.Error = runbookResult.Err
+	}
+
+	if drConfig.Testing.Validation {
+		slo, err := parseDisasterRecoverySLO(drConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Metrics["rto_seconds"] = runbookResult.RTO.Seconds()
+		rtoWithinSLO := slo.MaxRTO == 0 || runbookResult.RTO <= slo.MaxRTO
+		result.Metrics["rto_within_slo"] = rtoWithinSLO
+		if !rtoWithinSLO {
+			result.Status = "fail"
+		}
+
+		rpoBySeconds := make(map[string]float64, len(runbookResult.RPOByReplica))
+		rpoWithinSLO := true
+		for name, lost := range runbookResult.RPOByReplica {
+			rpoBySeconds[name] = lost.Seconds()
+			if slo.MaxRPO > 0 && lost > slo.MaxRPO {
+				rpoWithinSLO = false
+			}
+		}
+		result.Metrics["rpo_by_replica_seconds"] = rpoBySeconds
+		result.Metrics["rpo_within_slo"] = rpoWithinSLO
+		if !rpoWithinSLO {
+			result.Status = "fail"
+		}
+	}
+
+	return result, nil
+}
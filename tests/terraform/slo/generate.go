@@ -0,0 +1,98 @@
+package slo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WindowPlaceholder is the token an SLI's Query must contain wherever a
+// burn-rate alert's lookback window belongs, e.g.
+// "sum(rate(errors_total[{{window}}])) / sum(rate(requests_total[{{window}}]))".
+const WindowPlaceholder = "{{window}}"
+
+// SLI is the subset of a Service Level Indicator GenerateAlertRules
+// needs: a name and a PromQL-shaped ratio query containing
+// WindowPlaceholder.
+type SLI struct {
+	Name  string
+	Query string
+}
+
+// SLO is the subset of a Service Level Objective GenerateAlertRules
+// needs: a name and a target ratio (e.g. 0.999 for 99.9%).
+type SLO struct {
+	Name   string
+	Target float64
+}
+
+// Rule is one generated burn-rate alert. It mirrors AlertRule's fields
+// but lives in this package so slo has no dependency on package test;
+// callers convert Rule to their own AlertRule type.
+type Rule struct {
+	Name        string
+	Query       string
+	Severity    string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// GenerateAlertRules produces one burn-rate alert per DefaultWindows
+// pair for sli/slo. Each alert's Query ANDs the short- and long-window
+// burn rate against (1 - slo.Target) * window.BurnRate, so it only fires
+// once both windows agree the budget is burning too fast -- this is what
+// keeps the fast-burn alerts from paging on a brief blip.
+func GenerateAlertRules(sli SLI, slo SLO) []Rule {
+	budget := 1 - slo.Target
+
+	rules := make([]Rule, 0, len(DefaultWindows))
+	for _, window := range DefaultWindows {
+		threshold := budget * window.BurnRate
+		query := fmt.Sprintf("(%s > %s) and (%s > %s)",
+			windowQuery(sli.Query, window.Long), formatThreshold(threshold),
+			windowQuery(sli.Query, window.Short), formatThreshold(threshold))
+
+		rules = append(rules, Rule{
+			Name:     fmt.Sprintf("%s-burn-rate-%s-%s", slo.Name, promDuration(window.Long), promDuration(window.Short)),
+			Query:    query,
+			Severity: window.Severity,
+			Labels: map[string]string{
+				"slo":       slo.Name,
+				"sli":       sli.Name,
+				"burn_rate": formatThreshold(window.BurnRate),
+			},
+			Annotations: map[string]string{
+				"summary":     fmt.Sprintf("%s is burning its error budget %sx faster than sustainable over %s/%s windows", slo.Name, formatThreshold(window.BurnRate), promDuration(window.Long), promDuration(window.Short)),
+				"description": fmt.Sprintf("%s's SLI ratio over the %s window is {{ $value | humanizePercentage }}", slo.Name, promDuration(window.Long)),
+			},
+		})
+	}
+	return rules
+}
+
+// windowQuery substitutes window into every WindowPlaceholder occurrence
+// of query.
+func windowQuery(query string, window time.Duration) string {
+	return strings.ReplaceAll(query, WindowPlaceholder, promDuration(window))
+}
+
+// promDuration formats a time.Duration using PromQL's duration syntax
+// (e.g. "5m", "1h", "3d"), which rejects Go's zero-padded "1h0m0s" form.
+func promDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
+
+// formatThreshold trims a burn-rate threshold to its shortest decimal
+// representation (PromQL accepts plain floats).
+func formatThreshold(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.6f", value), "0"), ".")
+}
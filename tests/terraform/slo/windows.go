@@ -0,0 +1,28 @@
+// Package slo generates multi-window, multi-burn-rate alert rules for a
+// Service Level Objective, following the approach from Google's SRE
+// Workbook (chapter 5, "Alerting on SLOs"): an alert fires only once both
+// a short and a long lookback window agree the error budget is burning
+// faster than sustainable.
+package slo
+
+import "time"
+
+// BurnRateWindow is one short/long window pair: an alert fires only when
+// both windows' burn rate exceeds BurnRate simultaneously.
+type BurnRateWindow struct {
+	Short    time.Duration
+	Long     time.Duration
+	BurnRate float64
+	Severity string // "page" for fast-burn windows, "ticket" for slow-burn
+}
+
+// DefaultWindows are the four canonical pairs from the SRE Workbook: at
+// these burn rates, a 30-day error budget is exhausted in roughly 2
+// days, 5 days, 10 days, and 30 days respectively, so the first two pair
+// with "page" severity and the slower two with "ticket".
+var DefaultWindows = []BurnRateWindow{
+	{Short: 5 * time.Minute, Long: time.Hour, BurnRate: 14.4, Severity: "page"},
+	{Short: 30 * time.Minute, Long: 6 * time.Hour, BurnRate: 6, Severity: "page"},
+	{Short: 2 * time.Hour, Long: 24 * time.Hour, BurnRate: 3, Severity: "ticket"},
+	{Short: 6 * time.Hour, Long: 72 * time.Hour, BurnRate: 1, Severity: "ticket"},
+}
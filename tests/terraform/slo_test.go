@@ -0,0 +1,78 @@
+package test
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/slo"
+)
+
+// GenerateSLOAlertRules runs every MonitoringConfig.SLO through
+// slo.GenerateAlertRules against its matching SLI (SLOConfig.SLI names an
+// entry in MonitoringConfig.SLI), producing the multi-window,
+// multi-burn-rate AlertRule set testSLISLOMonitoring validates.
+func (suite *MultiCloudTestSuite) GenerateSLOAlertRules() ([]AlertRule, error) {
+	slis := make(map[string]SLIConfig, len(suite.MultiCloudConfig.MonitoringConfig.SLI))
+	for _, sli := range suite.MultiCloudConfig.MonitoringConfig.SLI {
+		slis[sli.Name] = sli
+	}
+
+	var rules []AlertRule
+	for _, sloConfig := range suite.MultiCloudConfig.MonitoringConfig.SLO {
+		sli, ok := slis[sloConfig.SLI]
+		if !ok {
+			return nil, errors.Errorf("SLO %q references unknown SLI %q", sloConfig.Name, sloConfig.SLI)
+		}
+
+		for _, generated := range slo.GenerateAlertRules(
+			slo.SLI{Name: sli.Name, Query: sli.Query},
+			slo.SLO{Name: sloConfig.Name, Target: sloConfig.Target},
+		) {
+			rules = append(rules, AlertRule{
+				Name:        generated.Name,
+				Query:       generated.Query,
+				Severity:    generated.Severity,
+				Labels:      generated.Labels,
+				Annotations: generated.Annotations,
+			})
+		}
+	}
+	return rules, nil
+}
+
+// validateAlertRules checks that every rule is acceptable to provider.
+// Prometheus and Alertmanager both consume the same PromQL grammar, so
+// their rules are validated by parsing Query with Prometheus's own
+// promql/parser -- the same parser the alerting provider itself would
+// reject an invalid rule with. Other providers (e.g. Datadog) have no
+// client wired into this harness, so their rules pass through
+// unvalidated.
+func validateAlertRules(provider string, rules []AlertRule) error {
+	switch provider {
+	case "prometheus", "alertmanager":
+		for _, rule := range rules {
+			if _, err := parser.ParseExpr(rule.Query); err != nil {
+				return errors.Wrapf(err, "alert rule %q has an invalid PromQL query", rule.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// testSLISLOMonitoring generates the burn-rate AlertRule set for every
+// configured SLO and validates it against MonitoringConfig.Alerting.Provider.
+func (suite *MultiCloudTestSuite) testSLISLOMonitoring() error {
+	suite.Logger.Info().Msg("Testing SLI/SLO monitoring")
+
+	rules, err := suite.GenerateSLOAlertRules()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate SLO burn-rate alert rules")
+	}
+
+	if err := validateAlertRules(suite.MultiCloudConfig.MonitoringConfig.Alerting.Provider, rules); err != nil {
+		return errors.Wrap(err, "generated alert rules were rejected")
+	}
+
+	suite.Logger.Info().Int("rules", len(rules)).Msg("Generated and validated SLO burn-rate alert rules")
+	return nil
+}
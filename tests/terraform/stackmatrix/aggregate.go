@@ -0,0 +1,36 @@
+package stackmatrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AggregateError multierr-style joins every failed stack's error into one,
+// naming which stack produced it, so a caller can report every cloud's
+// failure in a single error instead of only the first.
+type AggregateError struct {
+	Failures []Result
+}
+
+func (a *AggregateError) Error() string {
+	lines := make([]string, 0, len(a.Failures))
+	for _, result := range a.Failures {
+		lines = append(lines, fmt.Sprintf("%s: %v", result.Stack.Name, result.Err))
+	}
+	return fmt.Sprintf("%d stack(s) failed:\n%s", len(a.Failures), strings.Join(lines, "\n"))
+}
+
+// AggregateErrors returns an *AggregateError for every Result with a
+// non-nil Err, or nil if results contains no failures.
+func AggregateErrors(results []Result) error {
+	var failures []Result
+	for _, result := range results {
+		if result.Err != nil {
+			failures = append(failures, result)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &AggregateError{Failures: failures}
+}
@@ -0,0 +1,9 @@
+package stackmatrix
+
+import "os"
+
+// isDir reports whether path exists and is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,50 @@
+package stackmatrix
+
+import "golang.org/x/sync/errgroup"
+
+// Result is one Stack's outcome from Run.
+type Result struct {
+	Stack   Stack
+	Skipped bool
+	Reason  string
+	Err     error
+}
+
+// Run executes fn for every stack concurrently, bounded by concurrency
+// (treated as 1 if <= 0). A stack is skipped -- fn is never called for it
+// -- when skip is non-nil and returns a non-empty reason. Every stack gets
+// its own Result regardless of whether another stack's fn returned an
+// error: unlike errgroup.WithContext, nothing here cancels sibling work or
+// short-circuits on the first failure, so one failing cloud never masks
+// the others. Pass the resulting []Result to AggregateErrors to fail the
+// caller's test when any stack failed.
+func Run(stacks []Stack, concurrency int, skip SkipFunc, fn func(Stack) error) []Result {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]Result, len(stacks))
+
+	group := new(errgroup.Group)
+	group.SetLimit(concurrency)
+
+	for i, stack := range stacks {
+		i, stack := i, stack
+		group.Go(func() error {
+			if skip != nil {
+				if reason := skip(stack); reason != "" {
+					results[i] = Result{Stack: stack, Skipped: true, Reason: reason}
+					return nil
+				}
+			}
+			results[i] = Result{Stack: stack, Err: fn(stack)}
+			return nil
+		})
+	}
+
+	// group.Go's functions always return nil -- failures are recorded in
+	// results, not surfaced through the group -- so Wait never errors.
+	_ = group.Wait()
+
+	return results
+}
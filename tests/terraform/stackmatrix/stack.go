@@ -0,0 +1,43 @@
+// Package stackmatrix runs the same operation across a matrix of
+// independent Terraform stacks -- e.g. one per cloud provider -- bounded
+// by a caller-supplied concurrency limit, collecting every stack's
+// outcome instead of aborting the whole matrix on the first failure.
+// RunValidationTests (tests/terraform/main_test.go) is its first caller,
+// replacing a hard-coded, serial, abort-on-first-error directory list.
+package stackmatrix
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Stack is one independently-deployable Terraform root module in the
+// matrix.
+type Stack struct {
+	Name string
+	Dir  string
+}
+
+// SkipFunc reports why stack should be skipped, or "" if it shouldn't be.
+type SkipFunc func(Stack) string
+
+// Discover globs pattern (e.g. "../../stacks/*") for directories, each
+// becoming a Stack named after its base directory name. Non-directory
+// matches are ignored.
+func Discover(pattern string) ([]Stack, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to glob stack pattern %s", pattern)
+	}
+	sort.Strings(matches)
+
+	var stacks []Stack
+	for _, match := range matches {
+		if isDir(match) {
+			stacks = append(stacks, Stack{Name: filepath.Base(match), Dir: match})
+		}
+	}
+	return stacks, nil
+}
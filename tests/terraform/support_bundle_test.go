@@ -0,0 +1,394 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/supportbundle"
+)
+
+// defaultSupportBundleDir is where RecordFailure writes support bundle
+// archives when Config.SupportBundleDir isn't set.
+const defaultSupportBundleDir = "support-bundles"
+
+// RecordFailure builds a failed MultiCloudTestResult for testName, collects
+// a support bundle, persists it under Config.SupportBundleDir (falling back
+// to defaultSupportBundleDir), and logs every supportbundle.DefaultAnalyzers
+// verdict inline so CI output surfaces them without anyone opening the
+// archive. The result (with the archive path in Artifacts, if it was written
+// successfully) is appended to suite.TestResults before it's returned.
+func (suite *MultiCloudTestSuite) RecordFailure(testName, testType string, start time.Time, cause error) MultiCloudTestResult {
+	result := MultiCloudTestResult{
+		TestName:  testName,
+		TestType:  testType,
+		StartTime: start,
+		EndTime:   time.Now(),
+		Status:    "fail",
+		Error:     cause,
+	}
+	result.Duration = result.EndTime.Sub(start)
+
+	bundle, err := suite.CollectSupportBundle()
+	if err != nil {
+		suite.Logger.Warn().Err(err).Msg("Failed to collect support bundle")
+		suite.appendTestResult(result)
+		return result
+	}
+
+	dir := suite.Config.SupportBundleDir
+	if dir == "" {
+		dir = defaultSupportBundleDir
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		suite.Logger.Warn().Err(err).Msg("Failed to create support bundle directory")
+	} else {
+		archivePath := filepath.Join(dir, fmt.Sprintf("%s-%s.tar.gz", testName, suite.TestID))
+		if err := bundle.WriteTarGz(archivePath); err != nil {
+			suite.Logger.Warn().Err(err).Msg("Failed to write support bundle archive")
+		} else {
+			result.Artifacts = append(result.Artifacts, archivePath)
+		}
+	}
+
+	for _, verdict := range supportbundle.RunAnalyzers(bundle.FS(), supportbundle.DefaultAnalyzers()) {
+		suite.Logger.Info().
+			Str("check", verdict.Name).
+			Str("verdict", string(verdict.Verdict)).
+			Str("detail", verdict.Detail).
+			Msg("Support bundle analyzer verdict")
+	}
+
+	suite.appendTestResult(result)
+	return result
+}
+
+// appendTestResult appends result to suite.TestResults under suite.Mutex.
+func (suite *MultiCloudTestSuite) appendTestResult(result MultiCloudTestResult) {
+	suite.Mutex.Lock()
+	defer suite.Mutex.Unlock()
+	suite.TestResults = append(suite.TestResults, result)
+}
+
+// cniDaemonSetLabels lists the pod labels the common CNI implementations run
+// their node agent under, so collectK8sCluster can single out CNI state from
+// the rest of kube-system instead of dumping it wholesale.
+var cniDaemonSetLabels = []string{"k8s-app=calico-node", "app=flannel", "k8s-app=cilium", "app=aws-node"}
+
+// CollectSupportBundle gathers a support bundle covering every live
+// Kubernetes cluster, database instance, and storage bucket this suite knows
+// about. It's meant to be called from a failure path (a deferred helper in
+// TestCrossProvider* or similar), not on every successful run.
+func (suite *MultiCloudTestSuite) CollectSupportBundle() (*supportbundle.Bundle, error) {
+	bundle := supportbundle.NewBundle()
+
+	if suite.K8sTestSuite != nil {
+		for _, cluster := range suite.MultiCloudConfig.ComputeConfig.Kubernetes {
+			if err := suite.collectK8sCluster(bundle, cluster); err != nil {
+				suite.Logger.Warn().Err(err).Str("cluster", cluster.Name).Msg("Failed to collect Kubernetes support bundle state")
+			}
+		}
+	}
+
+	for _, db := range suite.MultiCloudConfig.DatabaseConfig.Databases {
+		if err := suite.collectDatabaseInstance(bundle, db); err != nil {
+			suite.Logger.Warn().Err(err).Str("database", db.Name).Msg("Failed to collect database support bundle state")
+		}
+	}
+
+	for _, bucket := range suite.MultiCloudConfig.StorageConfig.Buckets {
+		if err := suite.collectStorageBucket(bundle, bucket); err != nil {
+			suite.Logger.Warn().Err(err).Str("bucket", bucket.Name).Msg("Failed to collect storage support bundle state")
+		}
+	}
+
+	return bundle, nil
+}
+
+// collectK8sCluster dumps, per namespace, deployments/replicasets/statefulsets
+// as JSON, pod state plus logs for any pod not Running or Succeeded, and events -- plus
+// cluster-wide node descriptions and kube-system/CNI state.
+func (suite *MultiCloudTestSuite) collectK8sCluster(bundle *supportbundle.Bundle, cluster KubernetesConfig) error {
+	clientset := suite.K8sTestSuite.Clientset
+	ctx := suite.K8sTestSuite.Context
+	prefix := fmt.Sprintf("k8s/%s", cluster.Name)
+
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list namespaces")
+	}
+	for _, ns := range namespaces.Items {
+		if err := suite.collectK8sNamespace(bundle, prefix, ns.Name); err != nil {
+			suite.Logger.Warn().Err(err).Str("namespace", ns.Name).Msg("Failed to collect namespace support bundle state")
+		}
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list nodes")
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/nodes.json", prefix), nodes.Items); err != nil {
+		return err
+	}
+
+	for _, labelSelector := range cniDaemonSetLabels {
+		pods, err := clientset.CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil || len(pods.Items) == 0 {
+			continue
+		}
+		return writeJSON(bundle, fmt.Sprintf("%s/cni.json", prefix), pods.Items)
+	}
+
+	return nil
+}
+
+// collectK8sNamespace writes this namespace's deployments, replicasets,
+// statefulsets, pods, PVCs, and events, plus the logs of any pod not in the
+// Running or Succeeded phase. File shapes here are load-bearing: supportbundle's
+// DeploymentReplicaAnalyzer/PendingPodAnalyzer/UnboundPVCAnalyzer all parse
+// these exact JSON records.
+func (suite *MultiCloudTestSuite) collectK8sNamespace(bundle *supportbundle.Bundle, clusterPrefix, namespace string) error {
+	clientset := suite.K8sTestSuite.Clientset
+	ctx := suite.K8sTestSuite.Context
+	prefix := fmt.Sprintf("%s/%s", clusterPrefix, namespace)
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list deployments")
+	}
+	records := make([]deploymentRecord, 0, len(deployments.Items))
+	for _, dep := range deployments.Items {
+		desired := int32(1)
+		if dep.Spec.Replicas != nil {
+			desired = *dep.Spec.Replicas
+		}
+		records = append(records, deploymentRecord{
+			Namespace:       namespace,
+			Name:            dep.Name,
+			DesiredReplicas: desired,
+			ReadyReplicas:   dep.Status.ReadyReplicas,
+		})
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/deployments.json", prefix), records); err != nil {
+		return err
+	}
+
+	replicaSets, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list replicasets")
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/replicasets.json", prefix), replicaSets.Items); err != nil {
+		return err
+	}
+
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list statefulsets")
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/statefulsets.json", prefix), statefulSets.Items); err != nil {
+		return err
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list pods")
+	}
+	podRecords := make([]podRecordLocal, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		podRecords = append(podRecords, podRecordLocal{
+			Namespace: namespace,
+			Name:      pod.Name,
+			Phase:     string(pod.Status.Phase),
+			Reason:    pod.Status.Reason,
+		})
+		if pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		logs, err := clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).Do(ctx).Raw()
+		if err != nil {
+			suite.Logger.Warn().Err(err).Str("pod", pod.Name).Msg("Failed to collect pod logs")
+			continue
+		}
+		bundle.WriteFile(fmt.Sprintf("%s/logs/%s.log", prefix, pod.Name), logs)
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/pods.json", prefix), podRecords); err != nil {
+		return err
+	}
+
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list PVCs")
+	}
+	pvcRecords := make([]pvcRecordLocal, 0, len(pvcs.Items))
+	for _, pvc := range pvcs.Items {
+		pvcRecords = append(pvcRecords, pvcRecordLocal{Namespace: namespace, Name: pvc.Name, Phase: string(pvc.Status.Phase)})
+	}
+	if err := writeJSON(bundle, fmt.Sprintf("%s/pvcs.json", prefix), pvcRecords); err != nil {
+		return err
+	}
+
+	events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list events")
+	}
+	return writeJSON(bundle, fmt.Sprintf("%s/events.json", prefix), events.Items)
+}
+
+// collectDatabaseInstance captures slow-query logs and parameter/flag state
+// for db. AWS is fully wired via RDS's log-file API; GCP/Azure capture the
+// engine parameter/flag state their APIs expose directly, noting where
+// slow-query logs must instead be pulled from each provider's centralized
+// logging service rather than a per-instance file API.
+func (suite *MultiCloudTestSuite) collectDatabaseInstance(bundle *supportbundle.Bundle, db DatabaseInstance) error {
+	prefix := fmt.Sprintf("db/%s", db.Name)
+
+	switch db.Provider {
+	case "aws":
+		if suite.AWSTestSuite == nil {
+			return nil
+		}
+		rdsClient := suite.AWSTestSuite.RDS()
+		ctx := suite.AWSTestSuite.Context
+
+		logFiles, err := rdsClient.DescribeDBLogFiles(ctx, &rds.DescribeDBLogFilesInput{DBInstanceIdentifier: aws.String(db.Name)})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe DB log files")
+		}
+		for _, logFile := range logFiles.DescribeDBLogFiles {
+			if logFile.LogFileName == nil || !isSlowQueryLogFile(*logFile.LogFileName) {
+				continue
+			}
+			portion, err := rdsClient.DownloadDBLogFilePortion(ctx, &rds.DownloadDBLogFilePortionInput{
+				DBInstanceIdentifier: aws.String(db.Name),
+				LogFileName:          logFile.LogFileName,
+			})
+			if err != nil {
+				suite.Logger.Warn().Err(err).Str("log_file", *logFile.LogFileName).Msg("Failed to download DB log file")
+				continue
+			}
+			if portion.LogFileData != nil {
+				bundle.WriteFile(fmt.Sprintf("%s/slow-query.log", prefix), []byte(*portion.LogFileData))
+			}
+		}
+
+		parameters, err := rdsClient.DescribeDBParameters(ctx, &rds.DescribeDBParametersInput{DBParameterGroupName: aws.String(db.Name)})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe DB parameters")
+		}
+		return writeJSON(bundle, fmt.Sprintf("%s/parameters.json", prefix), parameters.Parameters)
+
+	case "gcp":
+		if suite.GCPTestSuite == nil {
+			return nil
+		}
+		instance, err := suite.GCPTestSuite.SQL.Instances.Get(suite.GCPTestSuite.ProjectID, db.Name).Context(suite.GCPTestSuite.Context).Do()
+		if err != nil {
+			return errors.Wrap(err, "failed to get Cloud SQL instance")
+		}
+		bundle.WriteFile(fmt.Sprintf("%s/slow-query.log", prefix),
+			[]byte("Cloud SQL does not expose slow-query logs via the Admin API; fetch them from Cloud Logging with resource.type=\"cloudsql_database\".\n"))
+		return writeJSON(bundle, fmt.Sprintf("%s/parameters.json", prefix), instance.Settings.DatabaseFlags)
+
+	case "azure":
+		if suite.AzureTestSuite == nil {
+			return nil
+		}
+		server, err := suite.AzureTestSuite.SQL.Get(suite.AzureTestSuite.Context, db.Name+"-rg", db.Name, nil)
+		if err != nil {
+			return errors.Wrap(err, "failed to get Azure SQL server")
+		}
+		bundle.WriteFile(fmt.Sprintf("%s/slow-query.log", prefix),
+			[]byte("Azure SQL query performance data is exposed via Query Store, not a per-server log file; query sys.query_store_runtime_stats instead.\n"))
+		return writeJSON(bundle, fmt.Sprintf("%s/parameters.json", prefix), server.Properties)
+	}
+
+	return nil
+}
+
+// collectStorageBucket captures IAM and lifecycle state for bucket. AWS is
+// fully wired via S3's bucket-policy/ACL and lifecycle-configuration APIs.
+func (suite *MultiCloudTestSuite) collectStorageBucket(bundle *supportbundle.Bundle, bucket StorageBucket) error {
+	prefix := fmt.Sprintf("storage/%s", bucket.Name)
+
+	switch bucket.Provider {
+	case "aws":
+		if suite.AWSTestSuite == nil {
+			return nil
+		}
+		s3Client := suite.AWSTestSuite.S3()
+		ctx := suite.AWSTestSuite.Context
+
+		type iamState struct {
+			Policy *string         `json:"policy,omitempty"`
+			ACL    []s3types.Grant `json:"acl,omitempty"`
+		}
+		var state iamState
+		if policy, err := s3Client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucket.Name)}); err == nil {
+			state.Policy = policy.Policy
+		}
+		if acl, err := s3Client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: aws.String(bucket.Name)}); err == nil {
+			state.ACL = acl.Grants
+		}
+		if err := writeJSON(bundle, fmt.Sprintf("%s/iam.json", prefix), state); err != nil {
+			return err
+		}
+
+		lifecycle, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket.Name)})
+		if err != nil {
+			bundle.WriteFile(fmt.Sprintf("%s/lifecycle.json", prefix), []byte("{}"))
+			return nil
+		}
+		return writeJSON(bundle, fmt.Sprintf("%s/lifecycle.json", prefix), lifecycle.Rules)
+	}
+
+	return nil
+}
+
+func isSlowQueryLogFile(name string) bool {
+	return strings.Contains(name, "slowquery") || strings.Contains(name, "slow-query")
+}
+
+func writeJSON(bundle *supportbundle.Bundle, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", name)
+	}
+	bundle.WriteFile(name, data)
+	return nil
+}
+
+// deploymentRecord, podRecordLocal, and pvcRecordLocal mirror the JSON shapes
+// supportbundle's analyzers expect (see supportbundle.DeploymentReplicaAnalyzer
+// et al.); they're redeclared here rather than imported since the analyzer
+// package only depends on the archive's bytes, never on package test's types.
+type deploymentRecord struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	DesiredReplicas int32  `json:"desired_replicas"`
+	ReadyReplicas   int32  `json:"ready_replicas"`
+}
+
+type podRecordLocal struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type pvcRecordLocal struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+}
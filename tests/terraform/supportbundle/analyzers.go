@@ -0,0 +1,163 @@
+package supportbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Verdict is an analyzer's judgment about one piece of collected state.
+type Verdict string
+
+const (
+	VerdictPass Verdict = "pass"
+	VerdictWarn Verdict = "warn"
+	VerdictFail Verdict = "fail"
+)
+
+// AnalyzeResult is one analyzer finding, ready to print inline in CI output.
+type AnalyzeResult struct {
+	Name    string  `json:"name"`
+	Verdict Verdict `json:"verdict"`
+	Detail  string  `json:"detail"`
+}
+
+// Analyzer inspects the collected bundle and reports zero or more findings.
+// Analyzers only ever see the archive's filesystem view, never the live
+// clients that produced it, so they can be unit tested against a
+// testing/fstest.MapFS fixture independent of any suite.
+type Analyzer func(bundle fs.FS) []AnalyzeResult
+
+// RunAnalyzers runs every analyzer against bundle and concatenates their
+// findings in order.
+func RunAnalyzers(bundle fs.FS, analyzers []Analyzer) []AnalyzeResult {
+	var results []AnalyzeResult
+	for _, analyze := range analyzers {
+		results = append(results, analyze(bundle)...)
+	}
+	return results
+}
+
+// deploymentRecord is the shape collectK8sNamespace writes to
+// "k8s/<cluster>/<namespace>/deployments.json".
+type deploymentRecord struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	DesiredReplicas int32  `json:"desired_replicas"`
+	ReadyReplicas   int32  `json:"ready_replicas"`
+}
+
+// DeploymentReplicaAnalyzer flags any deployment whose ready replica count is
+// below what it was scaled to.
+func DeploymentReplicaAnalyzer(bundle fs.FS) []AnalyzeResult {
+	var results []AnalyzeResult
+	_ = fs.WalkDir(bundle, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Base(p) != "deployments.json" {
+			return nil
+		}
+		var deployments []deploymentRecord
+		if err := readJSON(bundle, p, &deployments); err != nil {
+			return nil
+		}
+		for _, dep := range deployments {
+			name := fmt.Sprintf("deployment/%s/%s", dep.Namespace, dep.Name)
+			if dep.ReadyReplicas < dep.DesiredReplicas {
+				results = append(results, AnalyzeResult{
+					Name:    name,
+					Verdict: VerdictFail,
+					Detail:  fmt.Sprintf("%d/%d replicas ready", dep.ReadyReplicas, dep.DesiredReplicas),
+				})
+			} else {
+				results = append(results, AnalyzeResult{Name: name, Verdict: VerdictPass, Detail: "replicas ready"})
+			}
+		}
+		return nil
+	})
+	return results
+}
+
+// podRecord is the shape collectK8sNamespace writes to
+// "k8s/<cluster>/<namespace>/pods.json".
+type podRecord struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// PendingPodAnalyzer flags any pod stuck in the Pending phase.
+func PendingPodAnalyzer(bundle fs.FS) []AnalyzeResult {
+	var results []AnalyzeResult
+	_ = fs.WalkDir(bundle, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Base(p) != "pods.json" {
+			return nil
+		}
+		var pods []podRecord
+		if err := readJSON(bundle, p, &pods); err != nil {
+			return nil
+		}
+		for _, pod := range pods {
+			name := fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)
+			if strings.EqualFold(pod.Phase, "Pending") {
+				results = append(results, AnalyzeResult{
+					Name:    name,
+					Verdict: VerdictFail,
+					Detail:  fmt.Sprintf("stuck Pending: %s", pod.Reason),
+				})
+			}
+		}
+		return nil
+	})
+	return results
+}
+
+// pvcRecord is the shape collectK8sNamespace writes to
+// "k8s/<cluster>/<namespace>/pvcs.json".
+type pvcRecord struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+}
+
+// UnboundPVCAnalyzer flags any PersistentVolumeClaim that never reached Bound.
+func UnboundPVCAnalyzer(bundle fs.FS) []AnalyzeResult {
+	var results []AnalyzeResult
+	_ = fs.WalkDir(bundle, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Base(p) != "pvcs.json" {
+			return nil
+		}
+		var pvcs []pvcRecord
+		if err := readJSON(bundle, p, &pvcs); err != nil {
+			return nil
+		}
+		for _, pvc := range pvcs {
+			name := fmt.Sprintf("pvc/%s/%s", pvc.Namespace, pvc.Name)
+			if !strings.EqualFold(pvc.Phase, "Bound") {
+				results = append(results, AnalyzeResult{
+					Name:    name,
+					Verdict: VerdictWarn,
+					Detail:  fmt.Sprintf("phase is %s, not Bound", pvc.Phase),
+				})
+			}
+		}
+		return nil
+	})
+	return results
+}
+
+// DefaultAnalyzers is the analyzer set ExportSupportBundle-style callers run
+// by default -- every analyzer that only depends on collectK8sNamespace's
+// output shape, so it applies to any cluster a collector was run against.
+func DefaultAnalyzers() []Analyzer {
+	return []Analyzer{DeploymentReplicaAnalyzer, PendingPodAnalyzer, UnboundPVCAnalyzer}
+}
+
+func readJSON(bundle fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(bundle, name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,89 @@
+// Package supportbundle collects a structured, on-failure diagnostic archive
+// for MultiCloudTestSuite runs and evaluates it with pluggable analyzers --
+// separating "dump raw state" (collectors, which live alongside the suites
+// that have the live clients) from "decide if that state looks healthy"
+// (analyzers, which only ever see the archive's filesystem view).
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"testing/fstest"
+
+	"github.com/pkg/errors"
+)
+
+// Bundle accumulates collected files in memory as the collectors run, then
+// can be both analyzed in place (via FS) and persisted to disk (via
+// WriteTarGz) without a round trip through a temp directory.
+type Bundle struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewBundle returns an empty Bundle.
+func NewBundle() *Bundle {
+	return &Bundle{files: make(map[string][]byte)}
+}
+
+// WriteFile adds or replaces one file's contents. name is a slash-separated
+// path, e.g. "k8s/prod/kube-system/events.json" or "db/orders/parameters.json".
+func (b *Bundle) WriteFile(name string, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.files[name] = data
+}
+
+// FS returns a read-only fs.FS snapshot of the bundle's current contents, for
+// analyzers to walk.
+func (b *Bundle) FS() fs.FS {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	snapshot := make(fstest.MapFS, len(b.files))
+	for name, data := range b.files {
+		snapshot[name] = &fstest.MapFile{Data: data}
+	}
+	return snapshot
+}
+
+// WriteTarGz persists the bundle as a gzip-compressed tar archive at path,
+// with entries in sorted order so archives of identical content are
+// byte-for-byte reproducible.
+func (b *Bundle) WriteTarGz(path string) error {
+	b.mu.Lock()
+	files := make(map[string][]byte, len(b.files))
+	names := make([]string, 0, len(b.files))
+	for name, data := range b.files {
+		files[name] = data
+		names = append(names, name)
+	}
+	b.mu.Unlock()
+	sort.Strings(names)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create support bundle archive %s", path)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	for _, name := range names {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(data)), Mode: 0o644}); err != nil {
+			return errors.Wrapf(err, "failed to write support bundle header for %s", name)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return errors.Wrapf(err, "failed to write support bundle contents for %s", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"flag"
+
+	"github.com/pkg/errors"
+
+	"github.com/arvindcr4/learning-assistant/test/runner"
+)
+
+// junitXMLPath and jsonReportPath let an operator request machine-readable
+// terraform-test scenario reports without editing test-config.yaml:
+// go test ./tests/terraform/... -junit-xml=report.xml -json-report=report.json.
+// Both default to "" (no report written).
+var junitXMLPath = flag.String("junit-xml", "",
+	"path to write a JUnit XML report of terraform-test scenario results")
+var jsonReportPath = flag.String("json-report", "",
+	"path to write a JSON report of terraform-test scenario results")
+
+// RunTerraformTestScenarios discovers *.tftest.yaml scenario files under
+// ts.Config.TerraformTestScenariosDir and executes each against its own
+// Terraform workspace, bounded to ts.Config.ParallelTests running at once --
+// a `terraform test`-style assertion DSL alongside RunInfrastructureTests'
+// existing hard-coded health checks. A missing scenarios directory is not
+// an error: it's how a caller that hasn't adopted scenario files yet opts
+// out.
+func (ts *TestSuite) RunTerraformTestScenarios(testID string) error {
+	scenarios, err := runner.Discover(ts.Config.TerraformTestScenariosDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to discover terraform-test scenarios")
+	}
+	if len(scenarios) == 0 {
+		ts.Logger.Info().Str("dir", ts.Config.TerraformTestScenariosDir).Msg("no terraform-test scenarios found, skipping")
+		return nil
+	}
+
+	results, err := runner.Run(context.Background(), scenarios, testID, ts.Config.ParallelTests)
+	if err != nil {
+		return errors.Wrap(err, "failed to run terraform-test scenarios")
+	}
+
+	failed := 0
+	for _, result := range results {
+		event := ts.Logger.Info()
+		if result.Status == runner.StatusFailed {
+			failed++
+			event = ts.Logger.Error()
+		}
+		event.
+			Str("scenario", result.Scenario).
+			Str("status", string(result.Status)).
+			Dur("duration", result.Duration).
+			Strs("failures", result.Failures).
+			Msg("terraform-test scenario completed")
+	}
+
+	if *jsonReportPath != "" {
+		if err := runner.WriteJSONReport(*jsonReportPath, results); err != nil {
+			ts.Logger.Warn().Err(err).Msg("failed to write JSON scenario report")
+		}
+	}
+	if *junitXMLPath != "" {
+		if err := runner.WriteJUnitReport(*junitXMLPath, "terraform-test", results); err != nil {
+			ts.Logger.Warn().Err(err).Msg("failed to write JUnit scenario report")
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("%d of %d terraform-test scenarios failed", failed, len(results))
+	}
+	return nil
+}
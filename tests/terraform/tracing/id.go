@@ -0,0 +1,18 @@
+package tracing
+
+import "crypto/rand"
+
+// randomTraceID and randomSpanID generate the random bytes a W3C trace
+// context needs -- crypto/rand rather than math/rand since trace/span IDs
+// are meant to be globally unique, not just locally varied.
+func randomTraceID() [16]byte {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func randomSpanID() [8]byte {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
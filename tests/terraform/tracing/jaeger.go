@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// JaegerClient queries a Jaeger (or any OTLP-compatible store exposing
+// Jaeger's query HTTP API) for a trace by ID.
+type JaegerClient struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewJaegerClient returns a JaegerClient against baseURL (e.g.
+// "http://jaeger-query:16686"), using http.DefaultClient if httpClient is
+// nil.
+func NewJaegerClient(baseURL string, httpClient *http.Client) *JaegerClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &JaegerClient{BaseURL: baseURL, HTTPClient: httpClient}
+}
+
+// jaegerTraceResponse mirrors the shape of Jaeger's GET /api/traces/{id}
+// response -- only the fields AssertTrace's Span needs.
+type jaegerTraceResponse struct {
+	Data []struct {
+		TraceID string `json:"traceID"`
+		Spans   []struct {
+			SpanID        string `json:"spanID"`
+			OperationName string `json:"operationName"`
+			Duration      int64  `json:"duration"` // microseconds
+			ProcessID     string `json:"processID"`
+			Tags          []struct {
+				Key   string      `json:"key"`
+				Value interface{} `json:"value"`
+			} `json:"tags"`
+		} `json:"spans"`
+		Processes map[string]struct {
+			ServiceName string `json:"serviceName"`
+		} `json:"processes"`
+	} `json:"data"`
+}
+
+// FetchTrace retrieves traceID and normalizes it into a Trace, pulling each
+// span's region from its "region" tag and its error state from its "error"
+// tag -- the same two tags OpenTelemetry's semantic conventions recommend
+// (region as a resource attribute, error as a boolean span attribute).
+func (c *JaegerClient) FetchTrace(ctx context.Context, traceID string) (Trace, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/api/traces/"+traceID, nil)
+	if err != nil {
+		return Trace{}, errors.Wrap(err, "failed to build Jaeger trace query request")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return Trace{}, errors.Wrapf(err, "failed to query Jaeger for trace %s", traceID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Trace{}, errors.Errorf("Jaeger trace query for %s returned status %d", traceID, resp.StatusCode)
+	}
+
+	var parsed jaegerTraceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Trace{}, errors.Wrapf(err, "failed to decode Jaeger response for trace %s", traceID)
+	}
+	if len(parsed.Data) == 0 {
+		return Trace{}, errors.Errorf("Jaeger has no trace %s (it may not have finished exporting yet)", traceID)
+	}
+
+	result := Trace{TraceID: parsed.Data[0].TraceID}
+	for _, span := range parsed.Data[0].Spans {
+		normalized := Span{
+			SpanID:         span.SpanID,
+			Operation:      span.OperationName,
+			DurationMicros: span.Duration,
+		}
+		if process, ok := parsed.Data[0].Processes[span.ProcessID]; ok {
+			normalized.Service = process.ServiceName
+		}
+		for _, tag := range span.Tags {
+			switch tag.Key {
+			case "region":
+				if region, ok := tag.Value.(string); ok {
+					normalized.Region = region
+				}
+			case "error":
+				if isError, ok := tag.Value.(bool); ok {
+					normalized.Error = isError
+				}
+			}
+		}
+		result.Spans = append(result.Spans, normalized)
+	}
+
+	return result, nil
+}
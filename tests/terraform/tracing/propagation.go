@@ -0,0 +1,31 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewProbeTraceID starts a fresh, sampled W3C trace context for one HTTP
+// probe -- a root SpanContext with no local tracer behind it, since probes
+// only need to propagate a correlatable trace ID downstream, not record
+// spans of their own.
+func NewProbeTraceID(ctx context.Context) (context.Context, string) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID(randomTraceID()),
+		SpanID:     trace.SpanID(randomSpanID()),
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx = trace.ContextWithSpanContext(ctx, spanContext)
+	return ctx, spanContext.TraceID().String()
+}
+
+// InjectHeaders writes ctx's trace context into req using the standard W3C
+// tracecontext propagator, so a probe's outbound HTTP request carries the
+// traceparent header every downstream service needs to join the same
+// trace.
+func InjectHeaders(ctx context.Context, req *http.Request) {
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
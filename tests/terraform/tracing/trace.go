@@ -0,0 +1,115 @@
+// Package tracing asserts structural properties of a distributed trace
+// against a declared expected service graph: which services a probe should
+// (and shouldn't) have touched, which regions it should have stayed within,
+// per-service latency budgets, and whether any span reported an error.
+package tracing
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Span is one span of a fetched Trace, normalized from whatever backend
+// (Jaeger, an OTLP-compatible store) a JaegerClient queried.
+type Span struct {
+	SpanID         string
+	Service        string
+	Operation      string
+	Region         string
+	DurationMicros int64
+	Error          bool
+}
+
+// Trace is every Span belonging to one trace ID.
+type Trace struct {
+	TraceID string
+	Spans   []Span
+}
+
+// ExpectedService is one service a Scenario's probe is expected to have
+// produced spans for.
+type ExpectedService struct {
+	Service string
+	Region  string
+}
+
+// Expectations declares what a probe's resulting trace should look like:
+// the services it's expected to have touched (and in which region each
+// one), so any span from a service/region pair outside this list is an
+// unexpected -- possibly cross-region -- call.
+type Expectations struct {
+	ExpectedServices []ExpectedService
+}
+
+// Violation is one structural property AssertTrace found broken.
+type Violation struct {
+	Reason string
+}
+
+func (v Violation) Error() string { return v.Reason }
+
+// AssertTrace checks trace against expectations and a per-service p99
+// latency budget (serviceThresholdsMicros, e.g. sourced from
+// DatabasePerformanceConfig.PerformanceThresholds), returning every
+// Violation found -- an empty slice means the trace is clean.
+func AssertTrace(trace Trace, expectations Expectations, serviceThresholdsMicros map[string]float64) []Violation {
+	var violations []Violation
+
+	allowed := make(map[string]string, len(expectations.ExpectedServices))
+	for _, svc := range expectations.ExpectedServices {
+		allowed[svc.Service] = svc.Region
+	}
+
+	durationsByService := make(map[string][]int64)
+	for _, span := range trace.Spans {
+		if span.Error {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("span %s (%s/%s) reported an error", span.SpanID, span.Service, span.Operation),
+			})
+		}
+
+		expectedRegion, ok := allowed[span.Service]
+		if !ok {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("span %s touched service %q, which isn't in the expected service graph", span.SpanID, span.Service),
+			})
+		} else if expectedRegion != "" && span.Region != "" && span.Region != expectedRegion {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("span %s touched service %q in region %q, expected region %q (unexpected cross-region call)", span.SpanID, span.Service, span.Region, expectedRegion),
+			})
+		}
+
+		durationsByService[span.Service] = append(durationsByService[span.Service], span.DurationMicros)
+	}
+
+	for service, durations := range durationsByService {
+		thresholdMicros, ok := serviceThresholdsMicros[service]
+		if !ok {
+			continue
+		}
+		p99 := p99Of(durations)
+		if float64(p99) > thresholdMicros {
+			violations = append(violations, Violation{
+				Reason: fmt.Sprintf("service %q p99 span duration %dus exceeds threshold %.0fus", service, p99, thresholdMicros),
+			})
+		}
+	}
+
+	return violations
+}
+
+// p99Of returns the 99th-percentile value of durations (nearest-rank
+// method), or 0 for an empty slice.
+func p99Of(durations []int64) int64 {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := (99*len(sorted)+99)/100 - 1
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
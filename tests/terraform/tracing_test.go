@@ -0,0 +1,110 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/arvindcr4/learning-assistant/tests/terraform/tracing"
+)
+
+// TracedHTTPGet issues a GET to url with OpenTelemetry propagation headers
+// injected, so every downstream service the probe reaches joins the same
+// trace, and records the minted trace ID under probeID for a later
+// AssertTrace call. Only meaningful when
+// MultiCloudConfig.MonitoringConfig.Tracing.Instrumentation is enabled --
+// callers gate on that themselves, the same way other integration tests
+// gate on their own Config booleans before running.
+func (suite *MultiCloudTestSuite) TracedHTTPGet(ctx context.Context, probeID, url string) (*http.Response, error) {
+	tracedCtx, traceID := tracing.NewProbeTraceID(ctx)
+
+	req, err := http.NewRequestWithContext(tracedCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build traced probe request for %s", url)
+	}
+	tracing.InjectHeaders(tracedCtx, req)
+
+	suite.Mutex.Lock()
+	if suite.traceIDsByProbe == nil {
+		suite.traceIDsByProbe = make(map[string]string)
+	}
+	suite.traceIDsByProbe[probeID] = traceID
+	suite.Mutex.Unlock()
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "traced probe %s request to %s failed", probeID, url)
+	}
+	return resp, nil
+}
+
+// AssertTrace fetches the trace TracedHTTPGet(ctx, probeID, ...) produced
+// and asserts it on t: every span belongs to an expected service in its
+// expected region (per MultiCloudConfig.TracingExpectations' scenario for
+// probeID), no span reported an error, and each service's p99 span
+// duration is within DatabaseConfig.Performance.PerformanceThresholds.
+func (suite *MultiCloudTestSuite) AssertTrace(t *testing.T, probeID string) {
+	suite.Mutex.RLock()
+	traceID, ok := suite.traceIDsByProbe[probeID]
+	suite.Mutex.RUnlock()
+	if !ok {
+		t.Fatalf("AssertTrace: no trace ID recorded for probe %q -- did you call TracedHTTPGet first?", probeID)
+		return
+	}
+
+	queryEndpoint := suite.MultiCloudConfig.MonitoringConfig.Tracing.QueryEndpoint
+	if queryEndpoint == "" {
+		t.Fatalf("AssertTrace: MonitoringConfig.Tracing.QueryEndpoint is not configured")
+		return
+	}
+
+	scenario, ok := suite.tracingScenario(probeID)
+	if !ok {
+		t.Fatalf("AssertTrace: no TracingExpectations scenario declared for probe %q", probeID)
+		return
+	}
+
+	client := tracing.NewJaegerClient(queryEndpoint, nil)
+	trace, err := client.FetchTrace(suite.Context, traceID)
+	if !assert.NoError(t, err, "failed to fetch trace %s for probe %q", traceID, probeID) {
+		return
+	}
+
+	expectations := tracing.Expectations{}
+	for _, svc := range scenario.ExpectedServices {
+		expectations.ExpectedServices = append(expectations.ExpectedServices, tracing.ExpectedService{
+			Service: svc.Service,
+			Region:  svc.Region,
+		})
+	}
+
+	violations := tracing.AssertTrace(trace, expectations, suite.serviceLatencyThresholdsMicros())
+	for _, violation := range violations {
+		t.Errorf("trace %s (probe %q): %s", traceID, probeID, violation.Error())
+	}
+}
+
+// tracingScenario finds probeID's declared TracingScenario.
+func (suite *MultiCloudTestSuite) tracingScenario(probeID string) (TracingScenario, bool) {
+	for _, scenario := range suite.MultiCloudConfig.TracingExpectations.Scenarios {
+		if scenario.ProbeID == probeID {
+			return scenario, true
+		}
+	}
+	return TracingScenario{}, false
+}
+
+// serviceLatencyThresholdsMicros converts
+// DatabaseConfig.Performance.PerformanceThresholds (milliseconds, keyed by
+// service name) into the microsecond thresholds tracing.AssertTrace
+// compares span durations against.
+func (suite *MultiCloudTestSuite) serviceLatencyThresholdsMicros() map[string]float64 {
+	thresholds := make(map[string]float64)
+	for service, ms := range suite.MultiCloudConfig.DatabaseConfig.Performance.PerformanceThresholds {
+		thresholds[service] = ms * 1000
+	}
+	return thresholds
+}
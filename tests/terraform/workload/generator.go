@@ -0,0 +1,173 @@
+package workload
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hdrhistogram "github.com/HdrHistogram/hdrhistogram-go"
+	"github.com/pkg/errors"
+)
+
+// hdrHistogramMaxMicros bounds the histogram at 30s, far beyond any
+// latency an HTTP/gRPC/WebSocket issuer's own timeouts could ever record.
+const hdrHistogramMaxMicros = int64(30 * time.Second / time.Microsecond)
+
+// Run drives scenario -- open-loop (token-bucket arrival rate, ramped
+// linearly to RPS over RampUp) or closed-loop (Concurrency workers each
+// looping issue-then-wait) -- against its Targets (cycled round-robin,
+// each rendered with its own TemplateVars.Seq), records every request's
+// latency into an HDR histogram, and returns a Result checked against
+// scenario.Assertions. A non-nil error means at least one assertion was
+// violated; Result is still populated and usable either way.
+func Run(ctx context.Context, scenario Scenario) (Result, error) {
+	if len(scenario.Targets) == 0 {
+		return Result{}, errors.New("scenario has no targets")
+	}
+
+	issuers := make([]issuer, len(scenario.Targets))
+	for i, target := range scenario.Targets {
+		fn := issuerFor(target.Protocol)
+		if fn == nil {
+			return Result{}, errors.Errorf("unsupported protocol %q for target %d", target.Protocol, i)
+		}
+		issuers[i] = fn
+	}
+
+	hist := hdrhistogram.New(1, hdrHistogramMaxMicros, 3)
+	var histMu sync.Mutex
+	var requests, failed, seq int64
+
+	recordOne := func(ctx context.Context) {
+		idx := int(atomic.AddInt64(&seq, 1) - 1)
+		target := scenario.Targets[idx%len(scenario.Targets)]
+		vars := TemplateVars{Seq: int64(idx)}
+
+		start := time.Now()
+		ok := issuers[idx%len(issuers)](ctx, target, vars)
+		elapsed := time.Since(start)
+
+		atomic.AddInt64(&requests, 1)
+		if !ok {
+			atomic.AddInt64(&failed, 1)
+		}
+		histMu.Lock()
+		hist.RecordValue(min64(elapsed.Microseconds(), hdrHistogramMaxMicros))
+		histMu.Unlock()
+	}
+
+	start := time.Now()
+	deadline := start.Add(scenario.Duration)
+
+	switch scenario.Mode {
+	case ModeClosedLoop:
+		runClosedLoop(ctx, scenario, deadline, recordOne)
+	default:
+		runOpenLoop(ctx, scenario, start, deadline, recordOne)
+	}
+
+	elapsed := time.Since(start)
+	result := Result{
+		Scenario: scenario.Name,
+		Mode:     scenario.Mode,
+		Requests: atomic.LoadInt64(&requests),
+		Errors:   atomic.LoadInt64(&failed),
+	}
+	if result.Requests > 0 {
+		result.ErrorRate = float64(result.Errors) / float64(result.Requests)
+	}
+	if elapsed > 0 {
+		result.ThroughputRPS = float64(result.Requests) / elapsed.Seconds()
+	}
+	result.P50 = time.Duration(hist.ValueAtQuantile(50)) * time.Microsecond
+	result.P90 = time.Duration(hist.ValueAtQuantile(90)) * time.Microsecond
+	result.P99 = time.Duration(hist.ValueAtQuantile(99)) * time.Microsecond
+	result.P999 = time.Duration(hist.ValueAtQuantile(99.9)) * time.Microsecond
+
+	result.Violations = checkAssertions(result, scenario.Assertions)
+	if len(result.Violations) > 0 {
+		return result, errors.Errorf("%d assertion violation(s): %s", len(result.Violations), strings.Join(result.Violations, "; "))
+	}
+	return result, nil
+}
+
+// runOpenLoop drains a rate-limited token bucket through a fixed worker
+// pool, so offered load follows RPS regardless of how long requests take.
+func runOpenLoop(ctx context.Context, scenario Scenario, start, deadline time.Time, recordOne func(context.Context)) {
+	workers := scenario.Concurrency
+	if workers <= 0 {
+		workers = scenario.RPS
+	}
+	if workers <= 0 {
+		workers = 10
+	}
+
+	jobs := make(chan struct{}, workers*2)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				recordOne(ctx)
+			}
+		}()
+	}
+
+	bucket := newTokenBucket(scenario.RPS, scenario.RampUp, start)
+drive:
+	for {
+		now := time.Now()
+		if !now.Before(deadline) {
+			break drive
+		}
+		select {
+		case <-ctx.Done():
+			break drive
+		default:
+		}
+		if !bucket.take(now) {
+			time.Sleep(time.Millisecond)
+			continue
+		}
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// runClosedLoop runs a fixed pool of Concurrency workers, each issuing its
+// next request only after its previous one completes, so offered load
+// self-limits to what the target can actually keep up with.
+func runClosedLoop(ctx context.Context, scenario Scenario, deadline time.Time, recordOne func(context.Context)) {
+	concurrency := scenario.Concurrency
+	if concurrency <= 0 {
+		concurrency = 10
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+				recordOne(ctx)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
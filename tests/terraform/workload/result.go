@@ -0,0 +1,81 @@
+package workload
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Result summarizes a completed Scenario run.
+type Result struct {
+	Scenario      string        `json:"scenario"`
+	Mode          Mode          `json:"mode"`
+	Requests      int64         `json:"requests"`
+	Errors        int64         `json:"errors"`
+	ErrorRate     float64       `json:"error_rate"`
+	ThroughputRPS float64       `json:"throughput_rps"`
+	P50           time.Duration `json:"p50_ns"`
+	P90           time.Duration `json:"p90_ns"`
+	P99           time.Duration `json:"p99_ns"`
+	P999          time.Duration `json:"p999_ns"`
+	Violations    []string      `json:"violations,omitempty"`
+}
+
+// WriteJSONReport renders r as JSON to path.
+func (r Result) WriteJSONReport(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal workload report")
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write workload report to %s", path)
+	}
+	return nil
+}
+
+// PrometheusText renders r as Prometheus text-exposition-format gauges,
+// one per metric, labeled by scenario name -- the same shape
+// metrics.PrometheusText uses for system resource samples.
+func (r Result) PrometheusText() string {
+	var b strings.Builder
+	labels := fmt.Sprintf(`{scenario=%q}`, r.Scenario)
+	write := func(name string, value float64) {
+		fmt.Fprintf(&b, "# TYPE %s gauge\n%s%s %g\n", name, name, labels, value)
+	}
+	write("workload_requests_total", float64(r.Requests))
+	write("workload_errors_total", float64(r.Errors))
+	write("workload_error_rate", r.ErrorRate)
+	write("workload_throughput_rps", r.ThroughputRPS)
+	write("workload_latency_p50_ms", float64(r.P50.Milliseconds()))
+	write("workload_latency_p90_ms", float64(r.P90.Milliseconds()))
+	write("workload_latency_p99_ms", float64(r.P99.Milliseconds()))
+	write("workload_latency_p999_ms", float64(r.P999.Milliseconds()))
+	return b.String()
+}
+
+// checkAssertions returns one violation message per Assertions field r
+// breaches. A zero Assertions field is never checked.
+func checkAssertions(r Result, a Assertions) []string {
+	var violations []string
+	check := func(actual, limit time.Duration, name string) {
+		if limit > 0 && actual > limit {
+			violations = append(violations, fmt.Sprintf("%s %s exceeds limit %s", name, actual, limit))
+		}
+	}
+	check(r.P50, a.P50Latency, "p50 latency")
+	check(r.P90, a.P90Latency, "p90 latency")
+	check(r.P99, a.P99Latency, "p99 latency")
+	check(r.P999, a.P999Latency, "p99.9 latency")
+
+	if a.MaxErrorRate > 0 && r.ErrorRate > a.MaxErrorRate {
+		violations = append(violations, fmt.Sprintf("error rate %.4f exceeds limit %.4f", r.ErrorRate, a.MaxErrorRate))
+	}
+	if a.MinThroughput > 0 && r.ThroughputRPS < a.MinThroughput {
+		violations = append(violations, fmt.Sprintf("throughput %.2f rps is below minimum %.2f", r.ThroughputRPS, a.MinThroughput))
+	}
+	return violations
+}
@@ -0,0 +1,48 @@
+package workload
+
+import "time"
+
+// Mode selects how Run paces requests against Scenario.Concurrency/RPS.
+type Mode string
+
+const (
+	// ModeOpenLoop issues requests at an arrival rate (RPS, ramped
+	// linearly over RampUp via a token bucket) independent of how long
+	// each request takes -- the right mode for modeling real user traffic,
+	// where slow responses don't throttle the offered load.
+	ModeOpenLoop Mode = "open-loop"
+
+	// ModeClosedLoop runs a fixed pool of Concurrency workers, each
+	// issuing its next request only after the previous one completes --
+	// the right mode for finding a target's maximum sustainable
+	// throughput, since offered load self-limits to what the target can
+	// actually keep up with.
+	ModeClosedLoop Mode = "closed-loop"
+)
+
+// Assertions are the SLOs a Scenario's Result is checked against. A zero
+// field means "don't check this" -- there's no implicit default.
+type Assertions struct {
+	P50Latency    time.Duration
+	P90Latency    time.Duration
+	P99Latency    time.Duration
+	P999Latency   time.Duration
+	MaxErrorRate  float64
+	MinThroughput float64
+}
+
+// Scenario describes one workload-generator run. Targets are cycled
+// round-robin. In ModeOpenLoop, RPS/RampUp govern the token bucket and
+// Concurrency sizes the worker pool draining it (defaulting to RPS). In
+// ModeClosedLoop, RPS/RampUp are ignored and Concurrency alone determines
+// offered load.
+type Scenario struct {
+	Name        string
+	Mode        Mode
+	RPS         int
+	Concurrency int
+	RampUp      time.Duration
+	Duration    time.Duration
+	Targets     []Target
+	Assertions  Assertions
+}
@@ -0,0 +1,56 @@
+package workload
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket is a token-bucket rate limiter whose fill rate ramps
+// linearly from 0 to targetRPS over rampUp, then holds steady at
+// targetRPS. take is safe for concurrent use.
+type tokenBucket struct {
+	targetRPS int
+	rampUp    time.Duration
+	start     time.Time
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(targetRPS int, rampUp time.Duration, start time.Time) *tokenBucket {
+	return &tokenBucket{targetRPS: targetRPS, rampUp: rampUp, start: start, last: start}
+}
+
+// currentRate returns the permitted requests/sec at now.
+func (b *tokenBucket) currentRate(now time.Time) float64 {
+	if b.rampUp <= 0 {
+		return float64(b.targetRPS)
+	}
+	elapsed := now.Sub(b.start)
+	if elapsed >= b.rampUp {
+		return float64(b.targetRPS)
+	}
+	return float64(b.targetRPS) * (float64(elapsed) / float64(b.rampUp))
+}
+
+// take reports whether a token is available at now, refilling the bucket
+// at currentRate(now) tokens/sec since the last call. The bucket never
+// holds more than one second's worth of tokens, capping burst size.
+func (b *tokenBucket) take(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rate := b.currentRate(now)
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > rate {
+		b.tokens = rate
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}